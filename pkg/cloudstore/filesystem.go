@@ -73,6 +73,33 @@ type FileSystem interface {
 	Walk(root string, walkFn filepath.WalkFunc) error
 }
 
+// StartAfterWalker is an optional interface of a FileSystem which is able to
+// list only files sorting after a given path, using a "start-after" or
+// "marker" option of the provider's listing API, rather than listing every
+// file under the prefix.
+type StartAfterWalker interface {
+	// Like |Walk|, but calls |walkFn| only for files having paths which
+	// sort lexicographically after |startAfter|. An empty |startAfter|
+	// is equivalent to |Walk|.
+	WalkStartAfter(root, startAfter string, walkFn filepath.WalkFunc) error
+}
+
+// WalkStartAfter calls |walkFn| for each file under |root| of |fs| having a
+// path which sorts lexicographically after |startAfter|. If |fs| is a
+// StartAfterWalker, files are listed starting after |startAfter|. Otherwise,
+// all files under |root| are listed, and preceding files are filtered.
+func WalkStartAfter(fs FileSystem, root, startAfter string, walkFn filepath.WalkFunc) error {
+	if saw, ok := fs.(StartAfterWalker); ok {
+		return saw.WalkStartAfter(root, startAfter, walkFn)
+	}
+	return fs.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err == nil && path <= startAfter {
+			return nil
+		}
+		return walkFn(path, info, err)
+	})
+}
+
 // Selects a FileSystem implementation from |rawURL|. Implementations are
 // determined by URL scheme, and the path roots the resulting FileSystem.
 // Depending on provider, options are passed as URL query arguments.
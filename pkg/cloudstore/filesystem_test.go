@@ -478,6 +478,21 @@ func (s *FileSystemSuite) TestWalk(c *gc.C) {
 	test("path/to")
 }
 
+func (s *FileSystemSuite) TestWalkStartAfter(c *gc.C) {
+	var test = func(startAfter string) (accum []string) {
+		c.Check(WalkStartAfter(s.cfs, "path/to", startAfter, func(n string, _ os.FileInfo, err error) error {
+			accum = append(accum, n)
+			return err
+		}), gc.IsNil)
+		return
+	}
+
+	c.Check(test(""), gc.DeepEquals, []string{"path/to/fixture"})
+	c.Check(test("path/to/a"), gc.DeepEquals, []string{"path/to/fixture"})
+	c.Check(test("path/to/fixture"), gc.HasLen, 0)
+	c.Check(test("path/to/z"), gc.HasLen, 0)
+}
+
 func boxInt64(n int64) *int64 { return &n }
 
 var _ = gc.Suite(&FileSystemSuite{})
@@ -302,17 +302,29 @@ func (fs *s3Fs) Close() error {
 // |filepath.SkipDir| API allowing walk-functions to skip an entire directory
 // isn't implemented and should not be used.
 func (fs *s3Fs) Walk(root string, walkFn filepath.WalkFunc) error {
+	return fs.WalkStartAfter(root, "", walkFn)
+}
+
+// WalkStartAfter implements StartAfterWalker by passing the key of
+// |startAfter| as the StartAfter parameter of the S3 object listing.
+func (fs *s3Fs) WalkStartAfter(root, startAfter string, walkFn filepath.WalkFunc) error {
 	var bucket, subPath = pathToBucketAndSubpath(fs.prefix, root)
 	var svc = fs.svc()
-	var continuation *string
+	var continuation, startAfterKey *string
+
+	if startAfter != "" {
+		var _, key = pathToBucketAndSubpath(fs.prefix, startAfter)
+		startAfterKey = aws.String(key)
+	}
 
 	for {
 		var listParams = s3.ListObjectsV2Input{
 			Bucket:            aws.String(bucket),
 			ContinuationToken: continuation,
 			// No Delimiter set.
-			MaxKeys: s3MaxListObjectsKeys,
-			Prefix:  aws.String(subPath),
+			MaxKeys:    s3MaxListObjectsKeys,
+			Prefix:     aws.String(subPath),
+			StartAfter: startAfterKey,
 		}
 
 		var objects, err = svc.ListObjectsV2(&listParams)
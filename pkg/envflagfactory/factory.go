@@ -53,6 +53,106 @@ func NewMetricsPath() *string {
 	)
 }
 
+// NewDebugPort defines the port flag of profiling endpoints.
+func NewDebugPort() *string {
+	return envflag.CommandLine.String(
+		"debugPort",
+		"DEBUG_PORT",
+		"",
+		"Port of profiling endpoints. Disabled if empty.",
+	)
+}
+
+// NewProfileDir defines the directory flag of signal-triggered profiles.
+func NewProfileDir() *string {
+	return envflag.CommandLine.String(
+		"profileDir",
+		"PROFILE_DIR",
+		"/var/tmp",
+		"Directory to which signal-triggered profiles are written.",
+	)
+}
+
+// NewProfileFilename defines the filename template flag of signal-triggered profiles.
+func NewProfileFilename() *string {
+	return envflag.CommandLine.String(
+		"profileFilename",
+		"PROFILE_FILENAME",
+		"{type}_{pid}_{timestamp}.pprof",
+		"Filename template of signal-triggered profiles. Tokens {type}, {pid}, and {timestamp} are replaced.",
+	)
+}
+
+// NewProfileRetention defines the retention flag of signal-triggered profiles.
+func NewProfileRetention() *string {
+	return envflag.CommandLine.String(
+		"profileRetention",
+		"PROFILE_RETENTION",
+		"0",
+		"Number of signal-triggered profiles of each type to retain. Zero retains all profiles.",
+	)
+}
+
+// NewBlockProfileRate defines the block profiling rate flag.
+func NewBlockProfileRate() *string {
+	return envflag.CommandLine.String(
+		"blockProfileRate",
+		"BLOCK_PROFILE_RATE",
+		"10000",
+		"Rate of block profiling, while enabled, as per runtime.SetBlockProfileRate.",
+	)
+}
+
+// NewMutexProfileFraction defines the mutex profiling fraction flag.
+func NewMutexProfileFraction() *string {
+	return envflag.CommandLine.String(
+		"mutexProfileFraction",
+		"MUTEX_PROFILE_FRACTION",
+		"10",
+		"Fraction of mutex contention events profiled, while enabled, as per runtime.SetMutexProfileFraction.",
+	)
+}
+
+// NewMaxTraceDuration defines the maximum execution trace duration flag.
+func NewMaxTraceDuration() *string {
+	return envflag.CommandLine.String(
+		"maxTraceDuration",
+		"MAX_TRACE_DURATION",
+		"1m",
+		"Maximum duration of a signal-triggered execution trace.",
+	)
+}
+
+// NewProfileURL defines the continuous profiling upload URL flag.
+func NewProfileURL() *string {
+	return envflag.CommandLine.String(
+		"profileURL",
+		"PROFILE_URL",
+		"",
+		"Cloud filesystem URL (eg, gs://bucket/profiles/) to which continuous profiles are uploaded. Disabled if empty.",
+	)
+}
+
+// NewProfileInterval defines the continuous profiling interval flag.
+func NewProfileInterval() *string {
+	return envflag.CommandLine.String(
+		"profileInterval",
+		"PROFILE_INTERVAL",
+		"5m",
+		"Interval between continuous profile captures.",
+	)
+}
+
+// NewBuildVersion defines the build version flag.
+func NewBuildVersion() *string {
+	return envflag.CommandLine.String(
+		"buildVersion",
+		"BUILD_VERSION",
+		"",
+		"Build version of the program, with which continuous profiles are labeled.",
+	)
+}
+
 // NewMetricsPort defines the metrics port flag.
 func NewMetricsPort() *string {
 	return envflag.CommandLine.String(
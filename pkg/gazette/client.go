@@ -1,6 +1,7 @@
 package gazette
 
 import (
+	"context"
 	"encoding/json"
 	"expvar"
 	"fmt"
@@ -15,7 +16,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/hashicorp/golang-lru"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/LiveRamp/gazette/pkg/journal"
@@ -61,7 +61,7 @@ type Client struct {
 	// Maps request.URL.Path to previously-received "Location:" headers,,
 	// stripped of URL query arguments. Future requests of the same URL path are
 	// first attempted against the cached endpoint.
-	locationCache *lru.Cache
+	locationCache *locationCache
 
 	// Exported reader/writer statistics, and a mutex to guard creation of journal
 	// specific entries in the maps.
@@ -73,6 +73,16 @@ type Client struct {
 	// requests.
 	requests *currentRequestList
 
+	// Policy of retries and blocking deadlines applied by Get.
+	GetPolicy GetPolicy
+	// If true, Put doesn't issue a HEAD to discover the broker of a journal
+	// having no cached location. The PUT is instead sent directly to the
+	// default endpoint, and only if it's rejected with ErrNotBroker is the Put
+	// retried with discovery. This saves a round-trip for writes to journals
+	// having no cached location, and is most useful with a LocationCachePolicy
+	// TTL, or where the default endpoint is usually the journal broker.
+	SkipPutHead bool
+
 	// Underlying HTTP Client to use for all requests.
 	httpClient httpClient
 	// Test support: allow time.Now() to be swapped out.
@@ -96,11 +106,6 @@ func NewClientWithHttpClient(endpoint string, hc *http.Client) (*Client, error)
 		return nil, err
 	}
 
-	cache, err := lru.New(kClientRouteCacheSize)
-	if err != nil {
-		return nil, err
-	}
-
 	// If an API consumer sets his own transport, respect it, though things may
 	// fail if (for example) the file URL handler is not set.
 	if hc.Transport == nil {
@@ -109,11 +114,13 @@ func NewClientWithHttpClient(endpoint string, hc *http.Client) (*Client, error)
 
 	c := &Client{
 		defaultEndpoint: ep,
-		locationCache:   cache,
 		httpClient:      hc,
 		requests:        &currentRequestList{m: make(map[string]requestData)},
 		timeNow:         time.Now,
 	}
+	if err = c.SetLocationCachePolicy(LocationCachePolicy{}); err != nil {
+		return nil, err
+	}
 
 	// Create expvar skeleton under /gazette.
 	c.stats.readers = new(expvar.Map).Init()
@@ -126,6 +133,18 @@ func NewClientWithHttpClient(endpoint string, hc *http.Client) (*Client, error)
 	return c, nil
 }
 
+// SetLocationCachePolicy replaces the Client's cache of journal locations
+// with an empty cache bounded by |policy|. It must be called before the
+// Client is used.
+func (c *Client) SetLocationCachePolicy(policy LocationCachePolicy) error {
+	var cache, err = newLocationCache(policy, func() time.Time { return c.timeNow() })
+	if err != nil {
+		return err
+	}
+	c.locationCache = cache
+	return nil
+}
+
 // If you want to use your own |http.Transport| with Gazette, start with this one.
 func MakeHttpTransport() *http.Transport {
 	// See definition of |http.DefaultTransport| here:
@@ -190,7 +209,76 @@ func (c *Client) GetDirect(args journal.ReadArgs) (journal.ReadResult, io.ReadCl
 	return result, c.makeReadStatsWrapper(response.Body, args.Journal, result.Offset)
 }
 
+// GetPolicy parameterizes retries and blocking deadlines of Client Gets.
+// The zero-valued GetPolicy performs a single attempt, with no deadline
+// beyond that of the ReadArgs.
+type GetPolicy struct {
+	// Number of times a Get which fails with a transient error is retried.
+	// Transient errors are transport errors and routing errors (ErrNotBroker
+	// and ErrNotReplica). A value of -1 retries indefinitely.
+	Retries int
+	// If non-zero, the Deadline applied to blocking Gets having no Deadline
+	// of their own. A Get which blocks until the Deadline without content
+	// becoming available returns ErrNotYetAvailable.
+	BlockingTimeout time.Duration
+	// Backoff returns the delay prior to retry |attempt|, which begins at
+	// one. If nil, retries are not delayed.
+	Backoff func(attempt int) time.Duration
+}
+
+// Get performs a Gazette GET of |args|, applying the Client's GetPolicy.
 func (c *Client) Get(args journal.ReadArgs) (journal.ReadResult, io.ReadCloser) {
+	return c.GetWithPolicy(args, c.GetPolicy)
+}
+
+// GetWithPolicy performs a Gazette GET of |args|, applying |policy|.
+func (c *Client) GetWithPolicy(args journal.ReadArgs, policy GetPolicy) (journal.ReadResult, io.ReadCloser) {
+	if args.Blocking && args.Deadline.IsZero() && policy.BlockingTimeout != 0 {
+		args.Deadline = c.timeNow().Add(policy.BlockingTimeout)
+	}
+
+	for attempt := 1; true; attempt++ {
+		var result, rc = c.get(args)
+
+		if result.Error == nil || !isTransientGetError(args, result.Error) ||
+			(policy.Retries != -1 && attempt > policy.Retries) {
+			return result, rc
+		}
+
+		var delay time.Duration
+		if policy.Backoff != nil {
+			delay = policy.Backoff(attempt)
+		}
+		log.WithFields(log.Fields{"args": args, "err": result.Error, "attempt": attempt, "delay": delay}).
+			Warn("Get failed (will retry)")
+
+		if args.Context == nil {
+			time.Sleep(delay)
+			continue
+		}
+		select {
+		case <-time.After(delay):
+		case <-args.Context.Done():
+			return journal.ReadResult{Error: args.Context.Err()}, nil
+		}
+	}
+	panic("not reached")
+}
+
+// isTransientGetError returns whether a Get of |args| which failed with
+// |err| should be retried.
+func isTransientGetError(args journal.ReadArgs, err error) bool {
+	if args.Context != nil && args.Context.Err() != nil {
+		return false
+	} else if err == journal.ErrNotBroker || err == journal.ErrNotReplica {
+		return true
+	} else if _, ok := err.(*url.Error); ok {
+		return true // Transport error.
+	}
+	return false
+}
+
+func (c *Client) get(args journal.ReadArgs) (journal.ReadResult, io.ReadCloser) {
 	// Perform a non-blocking HEAD first, to check for an available persisted fragment.
 	headArgs := args
 	headArgs.Blocking = false
@@ -202,7 +290,7 @@ func (c *Client) Get(args journal.ReadArgs) (journal.ReadResult, io.ReadCloser)
 	} else if result.Error != nil {
 		return result, nil
 	} else if fragmentLocation != nil {
-		if body, err := c.openFragment(fragmentLocation, result); err != nil {
+		if body, err := c.openFragment(args.Context, fragmentLocation, result); err != nil {
 			result.Error = err
 			return result, nil
 		} else {
@@ -265,32 +353,52 @@ func (c *Client) makeReadStatsWrapper(stream io.ReadCloser, name journal.Name, o
 // potentially signed or authorized URL to fragment storage. The fragment is
 // opened, seek'd to the desired |result.Offset|, and returned. Note we don't
 // use a range request here, as the fragment is usually gzip'd (and implicitly
-// decompressed while being read).
-func (c *Client) openFragment(location *url.URL,
+// decompressed while being read). If |ctx| is non-nil, it's applied to the
+// request, and its cancellation also aborts reads of the returned body.
+func (c *Client) openFragment(ctx context.Context, location *url.URL,
 	result journal.ReadResult) (io.ReadCloser, error) {
 
-	response, err := c.httpClient.Get(location.String())
+	request, err := http.NewRequest("GET", location.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if ctx != nil {
+		request = request.WithContext(ctx)
+	}
+	response, err := c.httpClient.Do(request)
 	if err != nil {
 		return nil, err
 	} else if response.StatusCode != http.StatusOK {
 		response.Body.Close()
 		return nil, fmt.Errorf("fetching fragment: %s", response.Status)
 	}
+	// Transparently decompress fragments which are served compressed.
+	body, err := decodeFragment(location, response)
+	if err != nil {
+		response.Body.Close()
+		return nil, fmt.Errorf("decoding fragment: %s", err)
+	}
 	// Attempt to seek to |result.Offset| within the fragment.
 	delta := result.Offset - result.Fragment.Begin
-	if _, err := io.CopyN(ioutil.Discard, response.Body, delta); err != nil {
-		response.Body.Close()
+	if _, err := io.CopyN(ioutil.Discard, body, delta); err != nil {
+		body.Close()
 		return nil, fmt.Errorf("seeking fragment: %s", err)
 	}
 
 	var deltaF64 = float64(delta)
 	metrics.GazetteReadBytesTotal.Add(deltaF64)
 	metrics.GazetteDiscardBytesTotal.Add(deltaF64)
-	return response.Body, nil // Success.
+	return body, nil // Success.
 }
 
 // Creates the Journal of the given name.
 func (c *Client) Create(name journal.Name) error {
+	return c.CreateContext(context.Background(), name)
+}
+
+// CreateContext creates the Journal of the given name, using the provided
+// Context to cancel or supply a deadline for the request.
+func (c *Client) CreateContext(ctx context.Context, name journal.Name) error {
 	url := c.defaultEndpoint // Copy.
 	url.Path = "/" + name.String()
 
@@ -298,6 +406,8 @@ func (c *Client) Create(name journal.Name) error {
 	if err != nil {
 		return err
 	}
+	request = request.WithContext(ctx)
+
 	// Issue the request without using or updating the Journal location cache.
 	response, err := c.httpClient.Do(request)
 	if err != nil {
@@ -309,13 +419,47 @@ func (c *Client) Create(name journal.Name) error {
 // Performs a Gazette PUT operation, which appends content to the named journal.
 // Put panics if |args.Content| does not implement io.ReadSeeker.
 func (c *Client) Put(args journal.AppendArgs) journal.AppendResult {
+	if !c.SkipPutHead {
+		return c.put(args, true)
+	}
+
+	var rs = args.Content.(io.ReadSeeker)
+	var start, err = rs.Seek(0, os.SEEK_CUR)
+	if err != nil {
+		return journal.AppendResult{Error: err}
+	}
+
+	var result = c.put(args, false)
+	if result.Error != journal.ErrNotBroker {
+		return result
+	}
+	// The PUT was rejected by a server which isn't the journal broker, and no
+	// content was appended. Its redirect Location was cached by Do: rewind the
+	// content and retry, falling back to discovery of the broker with a HEAD.
+	if _, err = rs.Seek(start, os.SEEK_SET); err != nil {
+		return journal.AppendResult{Error: err}
+	}
+	return c.put(args, true)
+}
+
+// put performs a Gazette PUT. If |discover| and the journal has no cached
+// location, its broker is first discovered with a HEAD request.
+func (c *Client) put(args journal.AppendArgs, discover bool) journal.AppendResult {
 	request, err := http.NewRequest("PUT", "/"+args.Journal.String(), args.Content)
 	if err != nil {
 		return journal.AppendResult{Error: err}
 	}
-	if _, ok := c.locationCache.Get(request.URL.Path); !ok {
+	if args.Context != nil {
+		request = request.WithContext(args.Context)
+	}
+	if _, ok := c.locationCache.Get(request.URL.Path); !ok && discover {
 		// Speculatively issue a HEAD to fill the location cache for this path.
-		result, _ := c.Head(journal.ReadArgs{Journal: args.Journal, Blocking: false, Offset: -1})
+		result, _ := c.Head(journal.ReadArgs{
+			Journal:  args.Journal,
+			Blocking: false,
+			Offset:   -1,
+			Context:  args.Context,
+		})
 		if result.Error != nil && result.Error != journal.ErrNotYetAvailable {
 			return journal.AppendResult{Error: result.Error}
 		}
@@ -337,6 +481,13 @@ func (c *Client) Put(args journal.AppendArgs) journal.AppendResult {
 	defer response.Body.Close()
 	result := c.parseAppendResponse(response)
 
+	// A server error suggests the cached broker may no longer be healthy, or
+	// responsible for the journal. Invalidate its location, so that the next
+	// Put re-discovers the journal's current broker.
+	if response.StatusCode >= http.StatusInternalServerError {
+		c.locationCache.Remove("/" + args.Journal.String())
+	}
+
 	// Record the result.WriteHead as well as a cumulative count of all
 	// bytes written to this journal, if the write succeeded.
 	if result.Error == nil {
@@ -391,6 +542,10 @@ func (c *Client) parseReadResult(args journal.ReadArgs,
 		}
 	}
 
+	// Attribute the result to the responding broker, and its advertised route.
+	result.RouteToken = journal.RouteToken(response.Header.Get(RouteTokenHeader))
+	result.Broker = respondingBroker(response)
+
 	// Attempt to parse fragment information.
 	var fragmentNameStr = response.Header.Get(FragmentNameHeader)
 	if fragmentNameStr != "" {
@@ -472,7 +627,9 @@ func (l *currentRequestList) Delete(key string) {
 
 func (c *Client) parseAppendResponse(response *http.Response) journal.AppendResult {
 	var result = journal.AppendResult{
-		Error: journal.ErrorFromResponse(response),
+		Error:      journal.ErrorFromResponse(response),
+		RouteToken: journal.RouteToken(response.Header.Get(RouteTokenHeader)),
+		Broker:     respondingBroker(response),
 	}
 	if writeHead := response.Header.Get(WriteHeadHeader); writeHead != "" {
 		var err error
@@ -484,6 +641,20 @@ func (c *Client) parseAppendResponse(response *http.Response) journal.AppendResu
 	return result
 }
 
+// respondingBroker returns the endpoint of the broker which produced
+// |response|. If redirects were followed, it's the endpoint of the final
+// request of the redirect chain.
+func respondingBroker(response *http.Response) string {
+	if response.Request == nil || response.Request.URL == nil {
+		return ""
+	}
+	var u = url.URL{
+		Scheme: response.Request.URL.Scheme,
+		Host:   response.Request.URL.Host,
+	}
+	return u.String()
+}
+
 // Thin layer upon http.Do(), which manages re-writes from and update to the
 // Client.locationCache. Specifically, request.Path is mapped into a previously-
 // stored Location re-write. If none is available, the request is re-written to
@@ -494,8 +665,7 @@ func (c *Client) Do(request *http.Request) (*http.Response, error) {
 	var cacheKey = request.URL.Path // We may mutate |request| later.
 
 	// Apply a cached re-write for this request path if found.
-	if cached, ok := c.locationCache.Get(cacheKey); ok {
-		location := cached.(*url.URL)
+	if location, ok := c.locationCache.Get(cacheKey); ok {
 		request.URL.Scheme = location.Scheme
 		request.URL.User = location.User
 		request.URL.Host = location.Host
@@ -535,9 +705,11 @@ func (c *Client) Do(request *http.Request) (*http.Response, error) {
 
 // Returns the |Fragment| whose Modified time is closest to but prior to the
 // given |t|. Can return a zeroed Fragment structure, if no fragment matches.
-func (c *Client) FragmentBeforeTime(name journal.Name, t time.Time) (journal.Fragment, error) {
-	var args = journal.ReadArgs{Journal: name}
+// The provided Context may cancel or supply a deadline for the search.
+func (c *Client) FragmentBeforeTime(ctx context.Context, name journal.Name, t time.Time) (journal.Fragment, error) {
+	var args = journal.ReadArgs{Journal: name, Context: ctx}
 	var result journal.ReadResult
+	var err error
 
 	if result, _ = c.Head(args); result.Error != nil {
 		return journal.Fragment{}, result.Error
@@ -549,8 +721,11 @@ func (c *Client) FragmentBeforeTime(name journal.Name, t time.Time) (journal.Fra
 	var off = search(writeHead, func(off int64) bool {
 		args.Offset = off
 
-		if result, _ = c.Head(args); result.Error != nil {
-			panic(result.Error)
+		if err != nil {
+			return true // Unwind the search after a failed Head.
+		} else if result, _ = c.Head(args); result.Error != nil {
+			err = result.Error
+			return true
 		} else if result.Fragment.RemoteModTime.IsZero() {
 			// No remote fragment means we've reached the end of
 			// fragment-backed byte ranges. We assume that means it is after
@@ -561,8 +736,10 @@ func (c *Client) FragmentBeforeTime(name journal.Name, t time.Time) (journal.Fra
 		}
 	})
 
-	// Cannot satisfy the time |t| condition.
-	if off >= writeHead {
+	if err != nil {
+		return journal.Fragment{}, err
+	} else if off >= writeHead {
+		// Cannot satisfy the time |t| condition.
 		return journal.Fragment{}, nil
 	}
 
@@ -580,12 +757,13 @@ func (c *Client) FragmentBeforeTime(name journal.Name, t time.Time) (journal.Fra
 }
 
 // Returns a list of |Fragment|s that service the given offset range in |journal|.
-func (c *Client) FragmentsInRange(name journal.Name, minOff, maxOff int64) ([]journal.Fragment, error) {
+// The provided Context may cancel or supply a deadline for the listing.
+func (c *Client) FragmentsInRange(ctx context.Context, name journal.Name, minOff, maxOff int64) ([]journal.Fragment, error) {
 	var off = minOff
 	var fragments []journal.Fragment
 
 	for maxOff == -1 || off < maxOff {
-		var args = journal.ReadArgs{Journal: name, Offset: off}
+		var args = journal.ReadArgs{Journal: name, Offset: off, Context: ctx}
 		if result, locURI := c.Head(args); result.Error != nil {
 			return nil, result.Error
 		} else if locURI == nil {
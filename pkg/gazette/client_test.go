@@ -2,6 +2,7 @@ package gazette
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"expvar"
 	"io"
@@ -60,6 +61,7 @@ func newReadResponseFixture() *http.Response {
 			FragmentNameHeader:         []string{kFragmentFixtureStr},
 			FragmentLastModifiedHeader: []string{kFragmentLastModifiedStr},
 			FragmentLocationHeader:     []string{"http://cloud/fragment/location"},
+			RouteTokenHeader:           []string{"http://redirected-server|http://replica"},
 		},
 		Request: &http.Request{
 			URL: newURL("http://redirected-server/a/journal"),
@@ -87,9 +89,11 @@ func (s *ClientSuite) TestHeadRequestWithInvalidation(c *gc.C) {
 	expectFragment.Journal = "a/journal/path"
 
 	c.Check(result, gc.DeepEquals, journal.ReadResult{
-		Offset:    1005,
-		WriteHead: 3000,
-		Fragment:  expectFragment,
+		Offset:     1005,
+		WriteHead:  3000,
+		RouteToken: "http://redirected-server|http://replica",
+		Broker:     "http://redirected-server",
+		Fragment:   expectFragment,
 	})
 	c.Check(loc, gc.DeepEquals, newURL("http://cloud/fragment/location"))
 
@@ -130,9 +134,11 @@ func (s *ClientSuite) TestDirectGet(c *gc.C) {
 		Journal: "a/journal", Offset: 1005, Blocking: false})
 
 	c.Check(result, gc.DeepEquals, journal.ReadResult{
-		Offset:    1005,
-		WriteHead: 3000,
-		Fragment:  fragmentFixture,
+		Offset:     1005,
+		WriteHead:  3000,
+		RouteToken: "http://redirected-server|http://replica",
+		Broker:     "http://redirected-server",
+		Fragment:   fragmentFixture,
 	})
 	mockClient.AssertExpectations(c)
 
@@ -162,6 +168,71 @@ func (s *ClientSuite) TestDirectGetFails(c *gc.C) {
 	mockClient.AssertExpectations(c)
 }
 
+func (s *ClientSuite) TestGetPolicyRetriesTransientErrors(c *gc.C) {
+	mockClient := &mockHttpClient{}
+	var transportErr = &url.Error{Op: "Head", URL: "http://default", Err: errors.New("connection refused")}
+
+	// Expect an initial HEAD request, which is retried twice.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD" &&
+			request.URL.String() == "http://default/a/journal?block=false&offset=1005"
+	})).Return(nil, transportErr).Times(3)
+
+	var attempts []int
+	s.client.httpClient = mockClient
+	s.client.GetPolicy = GetPolicy{
+		Retries: 2,
+		Backoff: func(attempt int) time.Duration {
+			attempts = append(attempts, attempt)
+			return 0
+		},
+	}
+	result, body := s.client.Get(journal.ReadArgs{Journal: "a/journal", Offset: 1005})
+
+	c.Check(result.Error, gc.Equals, transportErr)
+	c.Check(body, gc.IsNil)
+	c.Check(attempts, gc.DeepEquals, []int{1, 2})
+
+	// Expect a per-call policy overrides the Client's, and that
+	// non-transient errors are not retried.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD"
+	})).Return(&http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       ioutil.NopCloser(strings.NewReader("not found")),
+	}, nil).Once()
+
+	result, _ = s.client.GetWithPolicy(journal.ReadArgs{Journal: "a/journal", Offset: 1005},
+		GetPolicy{Retries: -1})
+	c.Check(result.Error, gc.Equals, journal.ErrNotFound)
+
+	mockClient.AssertExpectations(c)
+}
+
+func (s *ClientSuite) TestGetPolicyAppliesBlockingTimeout(c *gc.C) {
+	mockClient := &mockHttpClient{}
+
+	responseFixture := newReadResponseFixture()
+	responseFixture.Header.Del(FragmentLocationHeader)
+
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD"
+	})).Return(responseFixture, nil).Once()
+
+	// Expect the blocking GET carries a deadline of the policy's BlockingTimeout.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "GET" &&
+			request.URL.String() == "http://redirected-server/a/journal?block=true&blockms=30000&offset=1005"
+	})).Return(responseFixture, nil).Once()
+
+	s.client.httpClient = mockClient
+	result, _ := s.client.GetWithPolicy(journal.ReadArgs{
+		Journal: "a/journal", Offset: 1005, Blocking: true}, GetPolicy{BlockingTimeout: 30 * time.Second})
+
+	c.Check(result.Error, gc.IsNil)
+	mockClient.AssertExpectations(c)
+}
+
 func (s *ClientSuite) TestGetWithoutFragmentLocation(c *gc.C) {
 	mockClient := &mockHttpClient{}
 
@@ -186,9 +257,11 @@ func (s *ClientSuite) TestGetWithoutFragmentLocation(c *gc.C) {
 		Journal: "a/journal", Offset: 1005, Blocking: true, Deadline: time.Unix(1240, 0)})
 
 	c.Check(result, gc.DeepEquals, journal.ReadResult{
-		Offset:    1005,
-		WriteHead: 3000,
-		Fragment:  fragmentFixture,
+		Offset:     1005,
+		WriteHead:  3000,
+		RouteToken: "http://redirected-server|http://replica",
+		Broker:     "http://redirected-server",
+		Fragment:   fragmentFixture,
 	})
 	mockClient.AssertExpectations(c)
 
@@ -218,7 +291,10 @@ func (s *ClientSuite) TestGetWithFragmentLocation(c *gc.C) {
 	})).Return(newReadResponseFixture(), nil).Once()
 
 	// Expect a following GET request to the returned cloud URL.
-	mockClient.On("Get", "http://cloud/fragment/location").Return(&http.Response{
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "GET" &&
+			request.URL.String() == "http://cloud/fragment/location"
+	})).Return(&http.Response{
 		StatusCode: http.StatusOK,
 		Body:       ioutil.NopCloser(strings.NewReader("xxxxxfragment-content...")),
 	}, nil).Once()
@@ -229,9 +305,11 @@ func (s *ClientSuite) TestGetWithFragmentLocation(c *gc.C) {
 
 	c.Check(result.Error, gc.IsNil)
 	c.Check(result, gc.DeepEquals, journal.ReadResult{
-		Offset:    1005,
-		WriteHead: 3000,
-		Fragment:  fragmentFixture,
+		Offset:     1005,
+		WriteHead:  3000,
+		RouteToken: "http://redirected-server|http://replica",
+		Broker:     "http://redirected-server",
+		Fragment:   fragmentFixture,
 	})
 	mockClient.AssertExpectations(c)
 
@@ -250,7 +328,10 @@ func (s *ClientSuite) TestGetWithFragmentLocationFails(c *gc.C) {
 	})).Return(newReadResponseFixture(), nil).Once()
 
 	// Expect a following GET request to the returned cloud URL, which fails.
-	mockClient.On("Get", "http://cloud/fragment/location").Return(&http.Response{
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "GET" &&
+			request.URL.String() == "http://cloud/fragment/location"
+	})).Return(&http.Response{
 		StatusCode: http.StatusInternalServerError,
 		Status:     "Internal Error",
 		Body:       ioutil.NopCloser(strings.NewReader("message")),
@@ -273,31 +354,41 @@ func (s *ClientSuite) TestGetPersistedErrorCases(c *gc.C) {
 	location := newURL("http://cloud/location")
 	readResult := journal.ReadResult{Offset: 1005, WriteHead: 3000, Fragment: fragmentFixture}
 
+	// Expect the provided Context is applied to fragment requests.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	// Expect response errors are passed through.
-	mockClient.On("Get", "http://cloud/location").Return(nil, errors.New("error!")).Once()
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.URL.String() == "http://cloud/location" && request.Context() == ctx
+	})).Return(nil, errors.New("error!")).Once()
 
-	body, err := s.client.openFragment(location, readResult)
+	body, err := s.client.openFragment(ctx, location, readResult)
 	c.Check(body, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, "error!")
 
 	// Expect non-200 is turned into an error.
-	mockClient.On("Get", "http://cloud/location").Return(&http.Response{
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.URL.String() == "http://cloud/location" && request.Context() == ctx
+	})).Return(&http.Response{
 		StatusCode: http.StatusTeapot,
 		Status:     "error!",
 		Body:       ioutil.NopCloser(nil),
 	}, nil).Once()
 
-	body, err = s.client.openFragment(location, readResult)
+	body, err = s.client.openFragment(ctx, location, readResult)
 	c.Check(body, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, "fetching fragment: error!")
 
 	// Seek failure (too little content). Expect error is returned.
-	mockClient.On("Get", "http://cloud/location").Return(&http.Response{
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.URL.String() == "http://cloud/location" && request.Context() == ctx
+	})).Return(&http.Response{
 		StatusCode: http.StatusOK,
 		Body:       ioutil.NopCloser(strings.NewReader("abc")),
 	}, nil).Once()
 
-	body, err = s.client.openFragment(location, readResult)
+	body, err = s.client.openFragment(ctx, location, readResult)
 	c.Check(body, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, "seeking fragment: EOF")
 }
@@ -330,6 +421,36 @@ func (s *ClientSuite) TestCreate(c *gc.C) {
 	mockClient.AssertExpectations(c)
 }
 
+func (s *ClientSuite) TestPutAppliesContext(c *gc.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockClient := &mockHttpClient{}
+
+	// Expect the speculative HEAD request uses the AppendArgs Context.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD" && request.Context() == ctx
+	})).Return(&http.Response{
+		StatusCode: http.StatusRequestedRangeNotSatisfiable,
+		Body:       ioutil.NopCloser(nil),
+	}, nil).Once()
+
+	// As does the PUT request.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "PUT" && request.Context() == ctx
+	})).Return(nil, context.Canceled).Once()
+
+	s.client.httpClient = mockClient
+	res := s.client.Put(journal.AppendArgs{
+		Journal: "a/journal",
+		Content: strings.NewReader("foobar"),
+		Context: ctx,
+	})
+	c.Check(res.Error, gc.Equals, context.Canceled)
+
+	mockClient.AssertExpectations(c)
+}
+
 func (s *ClientSuite) TestPut(c *gc.C) {
 	content := strings.NewReader("foobar")
 	mockClient := &mockHttpClient{}
@@ -389,18 +510,34 @@ func (s *ClientSuite) TestPut(c *gc.C) {
 	// exist yet.
 	c.Check(gazetteMap.Get("writers").(*expvar.Map).Get("a/journal"), gc.IsNil)
 
+	// The server error invalidated the cached location of the journal.
+	_, ok := s.client.locationCache.Get("/a/journal")
+	c.Check(ok, gc.Equals, false)
+
+	// Expect the location is re-discovered by a HEAD to the default endpoint.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD" &&
+			request.URL.Host == "default" &&
+			request.URL.Path == "/a/journal"
+	})).Return(&http.Response{
+		StatusCode: http.StatusRequestedRangeNotSatisfiable,
+		Request:    &http.Request{URL: newURL("http://redirected-server/a/journal")},
+		Body:       ioutil.NopCloser(nil),
+	}, nil).Once()
+
 	// Expect a PUT, and this time return success.
 	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
 		return request.Method == "PUT" &&
-			// Cache is cleared, so "default" pops up again.
 			request.URL.Host == "redirected-server" &&
 			request.URL.Path == "/a/journal" &&
 			request.ContentLength == 6
 	})).Return(&http.Response{
 		StatusCode: http.StatusNoContent, // Indicates success.
+		Request:    &http.Request{URL: newURL("http://redirected-server/a/journal")},
 		Body:       ioutil.NopCloser(nil),
 		Header: http.Header{
-			WriteHeadHeader: []string{"12341235"},
+			WriteHeadHeader:  []string{"12341235"},
+			RouteTokenHeader: []string{"http://redirected-server|http://replica"},
 		},
 	}, nil).Run(func(args mock.Arguments) {
 		request := args[0].(*http.Request)
@@ -410,6 +547,10 @@ func (s *ClientSuite) TestPut(c *gc.C) {
 	res = s.client.Put(journal.AppendArgs{Journal: "a/journal", Content: content})
 	c.Check(res.Error, gc.IsNil)
 	c.Check(res.WriteHead, gc.Equals, int64(12341235))
+	c.Check(res.Broker, gc.Equals, "http://redirected-server")
+	c.Check(res.RouteToken.Primary(), gc.Equals, "http://redirected-server")
+	c.Check(res.RouteToken.Members(), gc.DeepEquals,
+		[]string{"http://redirected-server", "http://replica"})
 	mockClient.AssertExpectations(c)
 
 	// Write success. Expect that the write stats were published to the
@@ -420,6 +561,99 @@ func (s *ClientSuite) TestPut(c *gc.C) {
 	c.Check(writerMap.Get("head").(*expvar.Int).String(), gc.Equals, "12341235")
 }
 
+func (s *ClientSuite) TestPutSkippingHead(c *gc.C) {
+	var content = strings.NewReader("foobar")
+	var mockClient = &mockHttpClient{}
+
+	var expectContent = func(args mock.Arguments) {
+		var b, _ = ioutil.ReadAll(args[0].(*http.Request).Body)
+		c.Check(string(b), gc.Equals, "foobar")
+	}
+
+	// Expect a PUT is issued directly to the default endpoint, without a HEAD.
+	// It's not the broker, and redirects to it.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "PUT" && request.URL.Host == "default"
+	})).Return(&http.Response{
+		StatusCode: http.StatusGone,
+		Header:     http.Header{"Location": []string{"http://broker/a/journal"}},
+		Body:       ioutil.NopCloser(nil),
+	}, nil).Run(expectContent).Once()
+
+	// Expect the PUT is retried against the redirected broker, with all content.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "PUT" && request.URL.Host == "broker"
+	})).Return(&http.Response{
+		StatusCode: http.StatusNoContent,
+		Body:       ioutil.NopCloser(nil),
+		Header:     http.Header{WriteHeadHeader: []string{"1234"}},
+	}, nil).Run(expectContent).Once()
+
+	s.client.httpClient = mockClient
+	s.client.SkipPutHead = true
+
+	var res = s.client.Put(journal.AppendArgs{Journal: "a/journal", Content: content})
+	c.Check(res.Error, gc.IsNil)
+	c.Check(res.WriteHead, gc.Equals, int64(1234))
+	mockClient.AssertExpectations(c)
+
+	// A failed PUT isn't retried.
+	content = strings.NewReader("foobar")
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "PUT" && request.URL.Host == "broker"
+	})).Return(nil, io.ErrUnexpectedEOF).Once()
+
+	res = s.client.Put(journal.AppendArgs{Journal: "a/journal", Content: content})
+	c.Check(res.Error, gc.Equals, io.ErrUnexpectedEOF)
+	mockClient.AssertExpectations(c)
+}
+
+func (s *ClientSuite) TestLocationCachePolicy(c *gc.C) {
+	var now = time.Unix(1234, 0)
+	s.client.timeNow = func() time.Time { return now }
+
+	c.Check(s.client.SetLocationCachePolicy(LocationCachePolicy{
+		MaxEntries: 2,
+		TTL:        time.Minute,
+	}), gc.IsNil)
+
+	s.client.locationCache.Add("/a/journal", newURL("http://server-a/a/journal"))
+	now = now.Add(30 * time.Second)
+	s.client.locationCache.Add("/b/journal", newURL("http://server-b/b/journal"))
+
+	// Touch /a/journal, making /b/journal the least-recently used entry.
+	loc, ok := s.client.locationCache.Get("/a/journal")
+	c.Check(ok, gc.Equals, true)
+	c.Check(loc, gc.DeepEquals, newURL("http://server-a/a/journal"))
+
+	// Expect adding a third entry evicts /b/journal.
+	s.client.locationCache.Add("/c/journal", newURL("http://server-c/c/journal"))
+	c.Check(s.client.locationCache.Len(), gc.Equals, 2)
+	_, ok = s.client.locationCache.Get("/b/journal")
+	c.Check(ok, gc.Equals, false)
+
+	// Expect /a/journal expires after its TTL, while /c/journal does not.
+	now = now.Add(30 * time.Second)
+	_, ok = s.client.locationCache.Get("/a/journal")
+	c.Check(ok, gc.Equals, false)
+	_, ok = s.client.locationCache.Get("/c/journal")
+	c.Check(ok, gc.Equals, true)
+
+	// Expect a request of an expired path is routed to the default endpoint.
+	var mockClient = &mockHttpClient{}
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD" && request.URL.Host == "default"
+	})).Return(newReadResponseFixture(), nil).Once()
+
+	s.client.httpClient = mockClient
+	var result, _ = s.client.Head(journal.ReadArgs{Journal: "a/journal", Offset: 1005})
+	c.Check(result.Error, gc.IsNil)
+	mockClient.AssertExpectations(c)
+
+	// A negative MaxEntries is an error.
+	c.Check(s.client.SetLocationCachePolicy(LocationCachePolicy{MaxEntries: -1}), gc.NotNil)
+}
+
 func (s *ClientSuite) TestReadResultParsingErrorCases(c *gc.C) {
 	args := journal.ReadArgs{Journal: "a/journal"}
 
@@ -547,7 +781,7 @@ func (s *ClientSuite) TestFragmentBeforeTime(c *gc.C) {
 	// Original request's fragment is picked, because the next one's timestamp
 	// exceeds the requested time.
 	s.client.httpClient = mockClient
-	frag, err := s.client.FragmentBeforeTime("a/journal",
+	frag, err := s.client.FragmentBeforeTime(context.Background(), "a/journal",
 		time.Date(2016, 7, 13, 0, 46, 0, 0, time.UTC))
 
 	var expect = fragmentFixture
@@ -571,13 +805,34 @@ func (s *ClientSuite) TestFragmentBeforeTimeNoMatch(c *gc.C) {
 	})).Return(response, nil)
 
 	s.client.httpClient = mockClient
-	frag, err := s.client.FragmentBeforeTime("a/journal",
+	frag, err := s.client.FragmentBeforeTime(context.Background(), "a/journal",
 		time.Date(2016, 7, 12, 23, 45, 59, 0, time.UTC))
 
 	c.Assert(err, gc.IsNil)
 	c.Check(frag, gc.DeepEquals, journal.Fragment{})
 }
 
+func (s *ClientSuite) TestFragmentBeforeTimeCancelled(c *gc.C) {
+	var mockClient = new(mockHttpClient)
+	var response = newReadResponseFixture()
+	var ctx, cancel = context.WithCancel(context.Background())
+
+	// The first Head succeeds. Subsequent requests fail with the cancelled
+	// Context, and the search is abandoned.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD" && request.Context() == ctx
+	})).Return(response, nil).Once().Run(func(mock.Arguments) { cancel() })
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD" && request.Context().Err() == context.Canceled
+	})).Return(nil, context.Canceled).Once()
+
+	s.client.httpClient = mockClient
+	var _, err = s.client.FragmentBeforeTime(ctx, "a/journal", time.Now())
+	c.Check(err, gc.Equals, context.Canceled)
+
+	mockClient.AssertExpectations(c)
+}
+
 func (s *ClientSuite) TestFragmentsInRange(c *gc.C) {
 	var mockClient = new(mockHttpClient)
 	var response = newReadResponseFixture()
@@ -602,7 +857,7 @@ func (s *ClientSuite) TestFragmentsInRange(c *gc.C) {
 	})).Return(response, nil).Times(5)
 
 	s.client.httpClient = mockClient
-	frags, err := s.client.FragmentsInRange("a/journal", 1001, 5999)
+	frags, err := s.client.FragmentsInRange(context.Background(), "a/journal", 1001, 5999)
 	c.Assert(err, gc.IsNil)
 	c.Check(frags, gc.DeepEquals, []journal.Fragment{
 		{Journal: "a/journal", Begin: 1000, End: 2000},
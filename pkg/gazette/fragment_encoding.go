@@ -0,0 +1,79 @@
+package gazette
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Content encodings of fragments, as stored. Stores which don't transcode
+// content on our behalf (eg, S3 and Minio) serve fragments with the encoding
+// they were written with.
+const (
+	encodingIdentity = "identity"
+	encodingGzip     = "gzip"
+	encodingZstd     = "zstd"
+)
+
+// fragmentEncoding returns the content encoding of a fragment |response| to
+// a GET of |location|. The response Content-Encoding is used if present.
+// Otherwise, the encoding is inferred from the fragment suffix.
+func fragmentEncoding(location *url.URL, response *http.Response) string {
+	if enc := response.Header.Get("Content-Encoding"); enc != "" {
+		return strings.ToLower(enc)
+	}
+	switch strings.ToLower(path.Ext(location.Path)) {
+	case ".gz", ".gzip":
+		return encodingGzip
+	case ".zst", ".zstandard":
+		return encodingZstd
+	default:
+		return encodingIdentity
+	}
+}
+
+// decodeFragment wraps the Body of a fragment |response| to a GET of
+// |location| with a decompressor of its content encoding. The returned
+// ReadCloser produces fragment content, such that offsets into it map directly
+// to journal offsets. Closing it closes the decompressor and response Body.
+func decodeFragment(location *url.URL, response *http.Response) (io.ReadCloser, error) {
+	var dec io.ReadCloser
+	var err error
+
+	switch enc := fragmentEncoding(location, response); enc {
+	case encodingIdentity:
+		return response.Body, nil
+	case encodingGzip:
+		dec, err = gzip.NewReader(response.Body)
+	case encodingZstd:
+		dec, err = zstdNewReader(response.Body)
+	default:
+		err = fmt.Errorf("unsupported fragment Content-Encoding: %s", enc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodedFragment{ReadCloser: dec, body: response.Body}, nil
+}
+
+// decodedFragment is a decompressor of a fragment response body.
+type decodedFragment struct {
+	io.ReadCloser
+	body io.Closer
+}
+
+func (d decodedFragment) Close() error {
+	var err = d.ReadCloser.Close()
+	if err2 := d.body.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+var zstdNewReader = func(io.Reader) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("zstd was not enabled at compile time")
+}
@@ -0,0 +1,63 @@
+package gazette
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	gc "github.com/go-check/check"
+)
+
+type FragmentEncodingSuite struct{}
+
+func (s *FragmentEncodingSuite) TestDecodingCases(c *gc.C) {
+	var gzipped bytes.Buffer
+	var gzw = gzip.NewWriter(&gzipped)
+	gzw.Write([]byte("fragment content"))
+	c.Assert(gzw.Close(), gc.IsNil)
+
+	var decode = func(rawurl, encoding string, body []byte) (string, error) {
+		var location, _ = url.Parse(rawurl)
+		var response = &http.Response{
+			Header: http.Header{},
+			Body:   ioutil.NopCloser(bytes.NewReader(body)),
+		}
+		if encoding != "" {
+			response.Header.Set("Content-Encoding", encoding)
+		}
+		var rc, err = decodeFragment(location, response)
+		if err != nil {
+			return "", err
+		}
+		b, err := ioutil.ReadAll(rc)
+		c.Check(rc.Close(), gc.IsNil)
+		return string(b), err
+	}
+
+	// Case: Content-Encoding is used, if present.
+	var out, err = decode("s3://bucket/a/journal/fragment", "gzip", gzipped.Bytes())
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.Equals, "fragment content")
+
+	// Case: otherwise, the encoding is inferred from the fragment suffix.
+	out, err = decode("s3://bucket/a/journal/fragment.gz", "", gzipped.Bytes())
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.Equals, "fragment content")
+
+	// Case: content is neither encoded nor suffixed.
+	out, err = decode("file:///a/journal/fragment", "", []byte("raw content"))
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.Equals, "raw content")
+
+	out, err = decode("file:///a/journal/fragment.gz", "identity", []byte("raw content"))
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.Equals, "raw content")
+
+	// Case: unsupported encodings fail.
+	_, err = decode("s3://bucket/a/journal/fragment", "br", []byte("content"))
+	c.Check(err, gc.ErrorMatches, `unsupported fragment Content-Encoding: br`)
+}
+
+var _ = gc.Suite(&FragmentEncodingSuite{})
@@ -0,0 +1,78 @@
+package gazette
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/golang-lru"
+)
+
+// LocationCachePolicy bounds the cache of journal locations held by a Client.
+// The zero-valued LocationCachePolicy caches up to kClientRouteCacheSize
+// locations, which never expire.
+type LocationCachePolicy struct {
+	// Maximum number of cached locations. When full, the least-recently used
+	// location is evicted. If zero, kClientRouteCacheSize is used.
+	MaxEntries int
+	// Duration after which a cached location expires, and requests of its path
+	// revert to the default endpoint (which will redirect them to the current
+	// broker). If zero, cached locations expire only upon eviction or failure.
+	TTL time.Duration
+}
+
+// locationCache is an LRU cache of request paths and their locations, with
+// optional expiry of cached entries.
+type locationCache struct {
+	lru *lru.Cache
+	ttl time.Duration
+	now func() time.Time
+}
+
+type locationEntry struct {
+	location *url.URL
+	expires  time.Time // Zero if the entry doesn't expire.
+}
+
+func newLocationCache(policy LocationCachePolicy, now func() time.Time) (*locationCache, error) {
+	var size = policy.MaxEntries
+	if size == 0 {
+		size = kClientRouteCacheSize
+	}
+	var cache, err = lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &locationCache{lru: cache, ttl: policy.TTL, now: now}, nil
+}
+
+// Get returns the cached location of |path|. An expired location is removed
+// and not returned.
+func (lc *locationCache) Get(path string) (*url.URL, bool) {
+	var v, ok = lc.lru.Get(path)
+	if !ok {
+		return nil, false
+	}
+	var entry = v.(locationEntry)
+
+	if !entry.expires.IsZero() && !lc.now().Before(entry.expires) {
+		lc.lru.Remove(path)
+		return nil, false
+	}
+	return entry.location, true
+}
+
+// Add caches |location| for |path|, replacing any current location.
+func (lc *locationCache) Add(path string, location *url.URL) {
+	var entry = locationEntry{location: location}
+	if lc.ttl != 0 {
+		entry.expires = lc.now().Add(lc.ttl)
+	}
+	lc.lru.Add(path, entry)
+}
+
+// Remove invalidates a cached location of |path|.
+func (lc *locationCache) Remove(path string) { lc.lru.Remove(path) }
+
+// Len returns the number of cached locations, including expired locations
+// which have not yet been removed.
+func (lc *locationCache) Len() int { return lc.lru.Len() }
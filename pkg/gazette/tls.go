@@ -0,0 +1,56 @@
+package gazette
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// TLSConfig configures TLS of the HTTP transport used by a Client. As the
+// transport is used both for broker requests and for GETs of fragments from
+// cloud storage, the configuration applies to each.
+type TLSConfig struct {
+	// CertFile and KeyFile are paths to a PEM-encoded client certificate
+	// and private key, presented to servers which require client
+	// authentication. If empty, no client certificate is presented.
+	CertFile string
+	KeyFile  string
+	// CAFile is a path to PEM-encoded certificates of root CAs which are
+	// trusted to verify servers. If empty, the system root CAs are used.
+	CAFile string
+	// InsecureSkipVerify disables verification of server certificates.
+	// It should be used only with test clusters.
+	InsecureSkipVerify bool
+}
+
+// BuildTLSConfig returns a *tls.Config reflecting the TLSConfig.
+func (cfg TLSConfig) BuildTLSConfig() (*tls.Config, error) {
+	var tc = &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		var cert, err = tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %s", err)
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+	if cfg.CAFile != "" {
+		var pem, err = ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %s", err)
+		}
+		tc.RootCAs = x509.NewCertPool()
+
+		if !tc.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", cfg.CAFile)
+		}
+	}
+	return tc, nil
+}
+
+// NewClientWithTLS returns a new Client which uses a transport built by
+// MakeHttpTransport, and configured with the TLSConfig.
+func NewClientWithTLS(endpoint string, cfg TLSConfig) (*Client, error) {
+	return NewClientWithTransportConfig(endpoint, TransportConfig{TLS: &cfg})
+}
@@ -0,0 +1,68 @@
+package gazette
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	gc "github.com/go-check/check"
+)
+
+type TLSSuite struct{}
+
+func (s *TLSSuite) TestClientVerifiesServerWithConfiguredCA(c *gc.C) {
+	var srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.URL.Path, gc.Equals, "/a/journal")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	var dir, err = ioutil.TempDir("", "TLSSuite")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(dir)
+
+	var caFile = filepath.Join(dir, "ca.pem")
+	c.Assert(ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	}), 0600), gc.IsNil)
+
+	// Case: server is verified using the configured CA.
+	client, err := NewClientWithTLS(srv.URL, TLSConfig{CAFile: caFile})
+	c.Assert(err, gc.IsNil)
+	c.Check(client.Create("a/journal"), gc.IsNil)
+
+	// Case: server cannot be verified using system root CAs.
+	client, err = NewClientWithTLS(srv.URL, TLSConfig{})
+	c.Assert(err, gc.IsNil)
+	c.Check(client.Create("a/journal"), gc.ErrorMatches, `.*certificate signed by unknown authority.*`)
+
+	// Case: verification is skipped.
+	client, err = NewClientWithTLS(srv.URL, TLSConfig{InsecureSkipVerify: true})
+	c.Assert(err, gc.IsNil)
+	c.Check(client.Create("a/journal"), gc.IsNil)
+}
+
+func (s *TLSSuite) TestConfigErrorCases(c *gc.C) {
+	var dir, err = ioutil.TempDir("", "TLSSuite")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(dir)
+
+	var caFile = filepath.Join(dir, "ca.pem")
+	c.Assert(ioutil.WriteFile(caFile, []byte("not a certificate"), 0600), gc.IsNil)
+
+	_, err = TLSConfig{CAFile: caFile}.BuildTLSConfig()
+	c.Check(err, gc.ErrorMatches, `no certificates found in CA file .*`)
+
+	_, err = TLSConfig{CAFile: filepath.Join(dir, "missing.pem")}.BuildTLSConfig()
+	c.Check(err, gc.ErrorMatches, `reading CA file: .*`)
+
+	_, err = TLSConfig{CertFile: caFile, KeyFile: caFile}.BuildTLSConfig()
+	c.Check(err, gc.ErrorMatches, `loading client certificate: .*`)
+}
+
+var _ = gc.Suite(&TLSSuite{})
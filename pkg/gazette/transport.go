@@ -0,0 +1,74 @@
+package gazette
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// TransportConfig configures an HTTP transport built by
+// MakeHttpTransportWithConfig. Zero-valued fields leave the corresponding
+// setting of MakeHttpTransport unchanged.
+type TransportConfig struct {
+	// EnableHTTP2 negotiates HTTP/2 with servers which support it. Concurrent
+	// requests to a server are then multiplexed over a single connection,
+	// rather than each requiring a connection of its own, which bounds the
+	// connections used by processes having many tailing readers. HTTP/2 is
+	// negotiated during the TLS handshake, and requires https:// endpoints
+	// (cloud storage endpoints are already served over TLS).
+	EnableHTTP2 bool
+	// MaxIdleConnsPerHost is the number of idle HTTP/1.1 connections retained
+	// for re-use with each host. The net/http default is 2, which results in
+	// connection churn when many requests to a host are made concurrently.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is the duration after which idle connections are closed.
+	IdleConnTimeout time.Duration
+	// ResponseHeaderTimeout bounds the time spent awaiting response headers
+	// after a request is written. It must exceed the blocking timeouts of
+	// ReadArgs, or blocking reads will fail prematurely.
+	ResponseHeaderTimeout time.Duration
+	// TLS optionally configures TLS of the transport.
+	TLS *TLSConfig
+}
+
+// MakeHttpTransportWithConfig returns a transport built by MakeHttpTransport,
+// and configured with the TransportConfig.
+func MakeHttpTransportWithConfig(cfg TransportConfig) (*http.Transport, error) {
+	var transport = MakeHttpTransport()
+
+	if cfg.TLS != nil {
+		var tc, err = cfg.TLS.BuildTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tc
+	}
+	if cfg.MaxIdleConnsPerHost != 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.IdleConnTimeout != 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	if cfg.ResponseHeaderTimeout != 0 {
+		transport.ResponseHeaderTimeout = cfg.ResponseHeaderTimeout
+	}
+	// The transport uses a custom Dial, which disables the automatic HTTP/2
+	// support of net/http. It must be configured explicitly.
+	if cfg.EnableHTTP2 {
+		if err := http2.ConfigureTransport(transport); err != nil {
+			return nil, err
+		}
+	}
+	return transport, nil
+}
+
+// NewClientWithTransportConfig returns a new Client which uses a transport
+// built by MakeHttpTransportWithConfig.
+func NewClientWithTransportConfig(endpoint string, cfg TransportConfig) (*Client, error) {
+	var transport, err = MakeHttpTransportWithConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientWithHttpClient(endpoint, &http.Client{Transport: transport})
+}
@@ -0,0 +1,61 @@
+package gazette
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	gc "github.com/go-check/check"
+	"golang.org/x/net/http2"
+)
+
+type TransportSuite struct{}
+
+func (s *TransportSuite) TestHTTP2IsNegotiatedWhenEnabled(c *gc.C) {
+	var protoCh = make(chan int, 1)
+
+	var srv = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		protoCh <- r.ProtoMajor
+		w.WriteHeader(http.StatusCreated)
+	}))
+	c.Assert(http2.ConfigureServer(srv.Config, nil), gc.IsNil)
+	srv.TLS = &tls.Config{NextProtos: []string{http2.NextProtoTLS, "http/1.1"}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	var cfg = TransportConfig{
+		TLS:                 &TLSConfig{InsecureSkipVerify: true},
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     time.Minute,
+	}
+
+	// Case: HTTP/1.1 is used by default.
+	client, err := NewClientWithTransportConfig(srv.URL, cfg)
+	c.Assert(err, gc.IsNil)
+	c.Check(client.Create("a/journal"), gc.IsNil)
+	c.Check(<-protoCh, gc.Equals, 1)
+
+	// Case: HTTP/2 is negotiated if enabled.
+	cfg.EnableHTTP2 = true
+	client, err = NewClientWithTransportConfig(srv.URL, cfg)
+	c.Assert(err, gc.IsNil)
+	c.Check(client.Create("a/journal"), gc.IsNil)
+	c.Check(<-protoCh, gc.Equals, 2)
+}
+
+func (s *TransportSuite) TestConfigIsApplied(c *gc.C) {
+	var transport, err = MakeHttpTransportWithConfig(TransportConfig{
+		MaxIdleConnsPerHost:   64,
+		IdleConnTimeout:       time.Minute,
+		ResponseHeaderTimeout: time.Hour,
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(transport.MaxIdleConnsPerHost, gc.Equals, 64)
+	c.Check(transport.IdleConnTimeout, gc.Equals, time.Minute)
+	c.Check(transport.ResponseHeaderTimeout, gc.Equals, time.Hour)
+	c.Check(transport.TLSClientConfig, gc.IsNil)
+	c.Check(transport.DisableCompression, gc.Equals, true)
+}
+
+var _ = gc.Suite(&TransportSuite{})
@@ -2,6 +2,7 @@ package gazette
 
 import (
 	"bytes"
+	"errors"
 	"flag"
 	"hash/crc32"
 	"io"
@@ -19,11 +20,17 @@ import (
 var (
 	// Time to wait in between broker write errors. Exposed for debugging.
 	writeServiceCoolOffTimeout = time.Second * 5
+	// Interval at which persistence of DurabilityPersisted writes is polled.
+	writeServicePersistPollInterval = time.Second * 5
 
 	writeConcurrency = flag.Int("gazetteWriteConcurrency", 4,
 		"Concurrency of asynchronous, locally-spooled Gazette write client")
 )
 
+// ErrWriteServiceStopped resolves DurabilityPersisted writes which were
+// committed, but not yet observed to be persisted, when the WriteService stopped.
+var ErrWriteServiceStopped = errors.New("write service stopped before write was persisted")
+
 const (
 	kMaxWriteSpoolSize = 1 << 27 // A single spool is up to 128MiB.
 	kWriteQueueSize    = 1024    // Allows a total of 128GiB of spooled writes.
@@ -81,6 +88,8 @@ func writeAllOrNone(write *pendingWrite, r io.Reader) error {
 type WriteService struct {
 	client  *Client
 	stopped chan struct{} // Coordinates exit of service loops.
+	// Closed upon Stop, after all writes have completed.
+	stopping chan struct{}
 
 	// Concurrent write queues (defaults to *writeConcurrency).
 	writeQueue []chan *pendingWrite
@@ -94,6 +103,7 @@ func NewWriteService(client *Client) *WriteService {
 	var writeService = &WriteService{
 		client:     client,
 		stopped:    make(chan struct{}),
+		stopping:   make(chan struct{}),
 		writeQueue: nil,
 		writeIndex: make(map[journal.Name]*pendingWrite),
 	}
@@ -125,6 +135,8 @@ func (c *WriteService) Start() {
 }
 
 // Stops the write service loop. Returns only after all writes have completed.
+// DurabilityPersisted writes not yet observed to be persisted are resolved
+// with ErrWriteServiceStopped.
 func (c *WriteService) Stop() {
 	for i := range c.writeQueue {
 		close(c.writeQueue[i])
@@ -132,6 +144,7 @@ func (c *WriteService) Stop() {
 	for _ = range c.writeQueue {
 		<-c.stopped
 	}
+	close(c.stopping)
 }
 
 func (c *WriteService) obtainWrite(name journal.Name) (*pendingWrite, bool, error) {
@@ -156,6 +169,28 @@ func (c *WriteService) obtainWrite(name journal.Name) (*pendingWrite, bool, erro
 	}
 }
 
+// Durability is the point in a write's lifecycle at which its AsyncAppend
+// is resolved. Writers select a Durability to trade latency for assurance
+// that the write will not be lost.
+type Durability int
+
+const (
+	// DurabilityCommitted resolves a write once a broker has acknowledged
+	// it, which happens only after the write is committed to all replicas
+	// of the journal's replication pipeline. This is the default.
+	DurabilityCommitted Durability = iota
+	// DurabilityAccepted resolves a write as soon as the WriteService has
+	// accepted it for delivery, by spooling it to local disk. Brokers of
+	// this protocol acknowledge writes only upon commit, so acceptance by
+	// a broker is not separately observable. The AppendResult of the
+	// resolved AsyncAppend is not populated.
+	DurabilityAccepted
+	// DurabilityPersisted resolves a write once it's committed, and the
+	// Fragment covering it has also been persisted to the journal's backing
+	// store. Persistence is polled for with HEAD requests.
+	DurabilityPersisted
+)
+
 // Appends |buffer| to |journal|. Either all of |buffer| is written, or none
 // of it is. Returns a AsyncAppendwhich is resolved when the write has
 // been fully committed.
@@ -163,10 +198,73 @@ func (c *WriteService) Write(name journal.Name, buf []byte) (*journal.AsyncAppen
 	return c.ReadFrom(name, bytes.NewReader(buf))
 }
 
+// WriteDurable is like Write, but returns an AsyncAppend which is resolved
+// when the write reaches the provided Durability.
+func (c *WriteService) WriteDurable(name journal.Name, buf []byte, d Durability) (*journal.AsyncAppend, error) {
+	return c.ReadFromDurable(name, bytes.NewReader(buf), d)
+}
+
 // Appends |r|'s content to |journal|, by reading until io.EOF. Either all of
 // |r| is written, or none of it is. Returns an AsyncAppend which is
 // resolved when the write has been fully committed.
 func (c *WriteService) ReadFrom(name journal.Name, r io.Reader) (*journal.AsyncAppend, error) {
+	return c.ReadFromDurable(name, r, DurabilityCommitted)
+}
+
+// ReadFromDurable is like ReadFrom, but returns an AsyncAppend which is
+// resolved when the write reaches the provided Durability.
+func (c *WriteService) ReadFromDurable(name journal.Name, r io.Reader, d Durability) (*journal.AsyncAppend, error) {
+	var result, err = c.readFrom(name, r)
+	if err != nil {
+		return result, err
+	}
+
+	switch d {
+	case DurabilityAccepted:
+		var accepted = &journal.AsyncAppend{Ready: make(chan struct{})}
+		close(accepted.Ready)
+		return accepted, nil
+	case DurabilityPersisted:
+		var persisted = &journal.AsyncAppend{Ready: make(chan struct{})}
+		go c.awaitPersisted(name, result, persisted)
+		return persisted, nil
+	default:
+		return result, nil
+	}
+}
+
+// awaitPersisted resolves |persisted| after |committed| is resolved, and the
+// Fragment covering its last written offset has been persisted. If the
+// WriteService is stopped first, |persisted| fails with ErrWriteServiceStopped.
+func (c *WriteService) awaitPersisted(name journal.Name, committed, persisted *journal.AsyncAppend) {
+	<-committed.Ready
+	persisted.AppendResult = committed.AppendResult
+
+	for {
+		// Brokers return a fragment location only for persisted Fragments.
+		var result, location = c.client.Head(journal.ReadArgs{
+			Journal: name,
+			Offset:  committed.WriteHead - 1,
+		})
+		if result.Error == nil && location != nil {
+			break
+		} else if result.Error != nil {
+			log.WithFields(log.Fields{"journal": name, "err": result.Error}).
+				Warn("failed to poll write persistence (will retry)")
+		}
+
+		select {
+		case <-time.After(writeServicePersistPollInterval):
+		case <-c.stopping:
+			persisted.Error = ErrWriteServiceStopped
+			close(persisted.Ready)
+			return
+		}
+	}
+	close(persisted.Ready)
+}
+
+func (c *WriteService) readFrom(name journal.Name, r io.Reader) (*journal.AsyncAppend, error) {
 	var result *journal.AsyncAppend
 	var writeErr error
 
@@ -184,6 +184,16 @@ func (s *WriteServiceSuite) TestWriteLifecycle(c *gc.C) {
 		Body:       ioutil.NopCloser(strings.NewReader("error")),
 	}, nil).Run(expectBazContent).Once()
 
+	// The server error invalidated the cached location of another/journal.
+	// Expect a HEAD re-discovers it.
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD" && request.URL.Path == "/another/journal"
+	})).Return(&http.Response{
+		StatusCode: http.StatusRequestedRangeNotSatisfiable,
+		Request:    &http.Request{URL: newURL("http://server/another/journal")},
+		Body:       ioutil.NopCloser(nil),
+	}, nil).Once()
+
 	// Final PUT to another/journal is retried with the same content. Succeeds.
 	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
 		return request.Method == "PUT" && request.URL.Path == "/another/journal"
@@ -204,4 +214,108 @@ func (s *WriteServiceSuite) TestWriteLifecycle(c *gc.C) {
 	mockClient.AssertExpectations(c)
 }
 
+func (s *WriteServiceSuite) TestWriteDurabilityModes(c *gc.C) {
+	actualInterval := writeServicePersistPollInterval
+	writeServicePersistPollInterval = time.Millisecond
+	defer func() { writeServicePersistPollInterval = actualInterval }()
+
+	var mockClient mockHttpClient
+
+	client, _ := NewClient("http://server")
+	client.httpClient = &mockClient
+	client.locationCache.Add("/a/journal", newURL("http://server/a/journal"))
+
+	writer := NewWriteService(client)
+	writer.SetConcurrency(1)
+
+	acceptedPromise, err := writer.WriteDurable("a/journal", []byte("foo"), DurabilityAccepted)
+	c.Check(err, gc.IsNil)
+	persistedPromise, err := writer.WriteDurable("a/journal", []byte("bar"), DurabilityPersisted)
+	c.Check(err, gc.IsNil)
+
+	// Expect an accepted write is resolved immediately.
+	<-acceptedPromise.Ready
+
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "PUT" && request.URL.Path == "/a/journal"
+	})).Return(&http.Response{
+		StatusCode: http.StatusNoContent,
+		Header:     http.Header{WriteHeadHeader: []string{"1006"}},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil).Once()
+
+	// Expect the last written offset is polled until its Fragment is persisted.
+	var isHeadOfLastOffset = mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD" &&
+			request.URL.Path == "/a/journal" &&
+			request.URL.Query().Get("offset") == "1005"
+	})
+	var headFixture = func(persisted bool) *http.Response {
+		var resp = newReadResponseFixture()
+		if !persisted {
+			resp.Header.Del(FragmentLocationHeader)
+		}
+		return resp
+	}
+	mockClient.On("Do", isHeadOfLastOffset).Return(headFixture(false), nil).Once()
+	mockClient.On("Do", isHeadOfLastOffset).Return(headFixture(true), nil).Once()
+
+	writer.Start()
+
+	<-persistedPromise.Ready
+	c.Check(persistedPromise.AppendResult.WriteHead, gc.Equals, int64(1006))
+
+	writer.Stop()
+	mockClient.AssertExpectations(c)
+}
+
+func (s *WriteServiceSuite) TestStopResolvesUnpersistedWrites(c *gc.C) {
+	actualInterval := writeServicePersistPollInterval
+	writeServicePersistPollInterval = time.Millisecond
+	defer func() { writeServicePersistPollInterval = actualInterval }()
+
+	var mockClient mockHttpClient
+
+	client, _ := NewClient("http://server")
+	client.httpClient = &mockClient
+	client.locationCache.Add("/a/journal", newURL("http://server/a/journal"))
+
+	writer := NewWriteService(client)
+	writer.SetConcurrency(1)
+
+	persistedPromise, err := writer.WriteDurable("a/journal", []byte("bar"), DurabilityPersisted)
+	c.Check(err, gc.IsNil)
+
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "PUT" && request.URL.Path == "/a/journal"
+	})).Return(&http.Response{
+		StatusCode: http.StatusNoContent,
+		Header:     http.Header{WriteHeadHeader: []string{"1003"}},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil).Once()
+
+	// The covering Fragment is never persisted.
+	var polledCh = make(chan struct{}, 1)
+	mockClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == "HEAD"
+	})).Return(func() *http.Response {
+		var resp = newReadResponseFixture()
+		resp.Header.Del(FragmentLocationHeader)
+		return resp
+	}(), nil).Run(func(mock.Arguments) {
+		select {
+		case polledCh <- struct{}{}:
+		default:
+		}
+	})
+
+	writer.Start()
+	<-polledCh // Persistence is being polled.
+
+	writer.Stop()
+	<-persistedPromise.Ready
+	c.Check(persistedPromise.Error, gc.Equals, ErrWriteServiceStopped)
+	c.Check(persistedPromise.WriteHead, gc.Equals, int64(1003))
+}
+
 var _ = gc.Suite(&WriteServiceSuite{})
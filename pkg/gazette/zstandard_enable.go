@@ -0,0 +1,13 @@
+// +build !nozstd
+
+package gazette
+
+import (
+	"io"
+
+	"github.com/DataDog/zstd"
+)
+
+func init() {
+	zstdNewReader = func(r io.Reader) (io.ReadCloser, error) { return zstd.NewReader(r), nil }
+}
@@ -1,10 +1,13 @@
 package journal
 
 import (
+	"bytes"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/url"
@@ -21,10 +24,35 @@ import (
 	"github.com/LiveRamp/gazette/pkg/cloudstore"
 )
 
+// SumAlgorithm is the hash algorithm of Fragment content sums, which are
+// encoded into Fragment content names.
+type SumAlgorithm int
+
+const (
+	// SumSHA1 names Fragments by the SHA-1 sum of their content.
+	SumSHA1 SumAlgorithm = iota
+	// SumSHA256 names Fragments by the SHA-256 sum of their content.
+	SumSHA256
+)
+
+// SelectSumAlgorithm returns the SumAlgorithm of new Fragments of the
+// journal. It may be replaced to migrate journals to SHA-256 naming. All
+// brokers must select consistently, as replicas of a journal must agree on
+// Fragment names. Readers parse and verify Fragments of either algorithm,
+// so journals may hold a mix of SHA-1 and SHA-256 Fragments while migrating.
+var SelectSumAlgorithm = func(Name) SumAlgorithm { return SumSHA1 }
+
+var ErrSumMismatch = errors.New("fragment content does not match its sum")
+
 type Fragment struct {
 	Journal    Name
 	Begin, End int64
-	Sum        [sha1.Size]byte
+	// Algorithm of the Fragment content sum.
+	SumAlgorithm SumAlgorithm
+	// SHA-1 sum of Fragment content, if SumAlgorithm is SumSHA1.
+	Sum [sha1.Size]byte
+	// SHA-256 sum of Fragment content, if SumAlgorithm is SumSHA256.
+	Sum256 [sha256.Size]byte
 
 	// Backing file of the fragment, if present locally.
 	File FragmentFile
@@ -35,7 +63,36 @@ type Fragment struct {
 }
 
 func (f Fragment) ContentName() string {
-	return fmt.Sprintf("%016x-%016x-%x", f.Begin, f.End, f.Sum)
+	return fmt.Sprintf("%016x-%016x-%x", f.Begin, f.End, f.SumBytes())
+}
+
+// SumBytes returns the content sum of the Fragment's SumAlgorithm.
+func (f Fragment) SumBytes() []byte {
+	if f.SumAlgorithm == SumSHA256 {
+		return f.Sum256[:]
+	}
+	return f.Sum[:]
+}
+
+// NewHash returns a hash.Hash of the Fragment's SumAlgorithm.
+func (f Fragment) NewHash() hash.Hash {
+	if f.SumAlgorithm == SumSHA256 {
+		return sha256.New()
+	}
+	return sha1.New()
+}
+
+// VerifySum reads |r| through EOF, and returns ErrSumMismatch if its
+// content doesn't match the Fragment sum.
+func (f Fragment) VerifySum(r io.Reader) error {
+	var h = f.NewHash()
+
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	} else if !bytes.Equal(h.Sum(nil), f.SumBytes()) {
+		return ErrSumMismatch
+	}
+	return nil
 }
 func (f *Fragment) ContentPath() string {
 	return f.Journal.String() + "/" + f.ContentName()
@@ -84,12 +141,19 @@ func ParseFragment(journal Name, contentName string) (Fragment, error) {
 	} else if r.Begin, err = strconv.ParseInt(fields[0], 16, 64); err != nil {
 	} else if r.End, err = strconv.ParseInt(fields[1], 16, 64); err != nil {
 	} else if sum, err = hex.DecodeString(fields[2]); err != nil {
-	} else if len(sum) != sha1.Size {
+	} else if len(sum) != sha1.Size && len(sum) != sha256.Size {
 		err = errors.New("invalid checksum")
 	} else if r.End < r.Begin {
 		err = errors.New("invalid content range")
 	}
-	copy(r.Sum[:], sum)
+
+	// The sum algorithm is implied by the sum length.
+	if len(sum) == sha256.Size {
+		r.SumAlgorithm = SumSHA256
+		copy(r.Sum256[:], sum)
+	} else {
+		copy(r.Sum[:], sum)
+	}
 	return r, err
 }
 
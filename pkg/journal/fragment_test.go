@@ -2,9 +2,12 @@ package journal
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"math"
 	"os"
+	"strings"
 	"time"
 
 	gc "github.com/go-check/check"
@@ -72,6 +75,40 @@ func (s *FragmentSuite) TestParsing(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "wrong format")
 }
 
+func (s *FragmentSuite) TestSHA256NamingAndParsing(c *gc.C) {
+	var sum = sha256.Sum256([]byte("content"))
+	var fragment = Fragment{
+		Journal:      "a/journal",
+		Begin:        1234567890,
+		End:          1234567897,
+		SumAlgorithm: SumSHA256,
+		Sum256:       sum,
+	}
+	c.Check(fragment.ContentName(), gc.Equals,
+		"00000000499602d2-00000000499602d9-"+hex.EncodeToString(sum[:]))
+
+	// Expect SHA-256 names round-trip, and the algorithm is inferred.
+	parsed, err := ParseFragment("a/journal", fragment.ContentName())
+	c.Check(err, gc.IsNil)
+	c.Check(parsed, gc.DeepEquals, fragment)
+
+	// Empty SHA-256 spool (begin == end, and zero checksum).
+	parsed, err = ParseFragment("a/journal",
+		"00000000499602d2-00000000499602d2-"+strings.Repeat("00", sha256.Size))
+	c.Check(err, gc.IsNil)
+	c.Check(parsed.SumAlgorithm, gc.Equals, SumSHA256)
+}
+
+func (s *FragmentSuite) TestVerifySum(c *gc.C) {
+	var sha1Fragment = Fragment{Sum: sha1.Sum([]byte("content"))}
+	var sha256Fragment = Fragment{SumAlgorithm: SumSHA256, Sum256: sha256.Sum256([]byte("content"))}
+
+	for _, fragment := range []Fragment{sha1Fragment, sha256Fragment} {
+		c.Check(fragment.VerifySum(strings.NewReader("content")), gc.IsNil)
+		c.Check(fragment.VerifySum(strings.NewReader("contenT")), gc.Equals, ErrSumMismatch)
+	}
+}
+
 func (s *FragmentSuite) TestPathWalkFuncAdapater(c *gc.C) {
 	var out []Fragment
 
@@ -1,6 +1,7 @@
 package journal
 
 import (
+	"os"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -8,17 +9,30 @@ import (
 	"github.com/LiveRamp/gazette/pkg/cloudstore"
 )
 
-const indexWatcherPeriod = 5 * time.Minute
+const (
+	indexWatcherPeriod = 5 * time.Minute
+	// Period between full listings of the journal's storage location.
+	// Refreshes between full listings are incremental.
+	indexWatcherFullRefreshPeriod = time.Hour
+)
 
 // IndexWatcher monitors a journal's storage location in the cloud filesystem
 // for new fragments, by performing periodic directory listings. When new
 // fragment metadata arrives, it's published to the journal Tail via a shared
 // channel, which indexes the fragment and makes it available for read requests.
+//
+// As fragment names sort on their begin and end offsets, most refreshes list
+// only files which sort after the last-observed file (see
+// cloudstore.WalkStartAfter). A fragment persisted out of order (eg, by a
+// broker which recovered a spool of older content) may be missed by an
+// incremental listing, so the full location is periodically re-listed.
 type IndexWatcher struct {
 	journal Name
 
-	cfs    cloudstore.FileSystem
-	cursor interface{}
+	cfs cloudstore.FileSystem
+	// Greatest path observed by a listing, and the time of the last full listing.
+	cursor          string
+	lastFullRefresh time.Time
 
 	// Channel into which discovered fragments are produced.
 	updates chan<- Fragment
@@ -80,8 +94,32 @@ func (w *IndexWatcher) loop() {
 }
 
 func (w *IndexWatcher) onRefresh() error {
-	return w.cfs.Walk(w.journal.String()+"/", NewWalkFuncAdapter(func(fragment Fragment) error {
+	var startAfter string
+	var now = time.Now()
+
+	if now.Sub(w.lastFullRefresh) < indexWatcherFullRefreshPeriod {
+		startAfter = w.cursor
+	}
+
+	var cursor = w.cursor
+	var adapter = NewWalkFuncAdapter(func(fragment Fragment) error {
 		w.updates <- fragment
 		return nil
-	}))
+	})
+
+	if err := cloudstore.WalkStartAfter(w.cfs, w.journal.String()+"/", startAfter,
+		func(path string, info os.FileInfo, err error) error {
+			if err == nil && path > cursor {
+				cursor = path
+			}
+			return adapter(path, info, err)
+		}); err != nil {
+		return err
+	}
+
+	w.cursor = cursor
+	if startAfter == "" {
+		w.lastFullRefresh = now
+	}
+	return nil
 }
@@ -0,0 +1,60 @@
+package journal
+
+import (
+	"os"
+	"time"
+
+	gc "github.com/go-check/check"
+
+	"github.com/LiveRamp/gazette/pkg/cloudstore"
+)
+
+type IndexWatcherSuite struct{}
+
+func (s *IndexWatcherSuite) TestIncrementalAndFullRefresh(c *gc.C) {
+	var cfs = cloudstore.NewTmpFileSystem()
+	defer cfs.Close()
+
+	var writeFixture = func(begin, end int64) {
+		var path = "a/journal/" + Fragment{Begin: begin, End: end}.ContentName()
+		c.Assert(cfs.MkdirAll("a/journal", 0750), gc.IsNil)
+
+		var f, err = cfs.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0640)
+		c.Assert(err, gc.IsNil)
+		_, err = f.Write(make([]byte, end-begin))
+		c.Assert(err, gc.IsNil)
+		c.Assert(f.Close(), gc.IsNil)
+	}
+	var updates = make(chan Fragment, 10)
+	var w = NewIndexWatcher("a/journal", cfs, updates)
+
+	var refresh = func() (out []int64) {
+		c.Assert(w.onRefresh(), gc.IsNil)
+		for len(updates) != 0 {
+			out = append(out, (<-updates).Begin)
+		}
+		return
+	}
+
+	writeFixture(0, 10)
+	writeFixture(10, 20)
+
+	// Initial refresh lists all fragments.
+	c.Check(refresh(), gc.DeepEquals, []int64{0, 10})
+	c.Check(w.cursor, gc.Equals, "a/journal/"+Fragment{Begin: 10, End: 20}.ContentName())
+
+	// Subsequent refreshes list only fragments after the last-observed one.
+	writeFixture(20, 30)
+	c.Check(refresh(), gc.DeepEquals, []int64{20})
+	c.Check(refresh(), gc.IsNil)
+
+	// A fragment persisted out of order is missed, until the next full refresh.
+	writeFixture(5, 15)
+	c.Check(refresh(), gc.IsNil)
+
+	w.lastFullRefresh = time.Now().Add(-indexWatcherFullRefreshPeriod)
+	c.Check(refresh(), gc.DeepEquals, []int64{0, 5, 10, 20})
+	c.Check(refresh(), gc.IsNil)
+}
+
+var _ = gc.Suite(&IndexWatcherSuite{})
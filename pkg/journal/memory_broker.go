@@ -2,6 +2,8 @@ package journal
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha1"
 	"io"
 	"io/ioutil"
 	"net/url"
@@ -10,7 +12,10 @@ import (
 
 // MemoryBroker provides an in-memory implementation of the Client interface.
 // The intended use is within unit tests which exercise components coordinating
-// through the Client interface.
+// through the Client interface, without running brokers or using mocks.
+// Journals are implicitly created upon their first write or read, or may be
+// explicitly created with Create. Blocking Gets await written content, or
+// cancellation of the ReadArgs Context, or its Deadline.
 type MemoryBroker struct {
 	// DelayWrites indicates that writes should queue (and their promises not resolve) until:
 	//   * The next explicit Flush, or
@@ -63,42 +68,107 @@ func (j *MemoryBroker) ReadFrom(name Name, r io.Reader) (*AsyncAppend, error) {
 }
 
 func (j *MemoryBroker) Get(args ReadArgs) (ReadResult, io.ReadCloser) {
+	var ctx = args.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !args.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, args.Deadline)
+		defer cancel()
+	}
+
+	// Wake blocked Gets upon cancellation of |ctx|.
+	var doneCh = make(chan struct{})
+	defer close(doneCh)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			j.mu.Lock()
+			j.cond.Broadcast()
+			j.mu.Unlock()
+		case <-doneCh:
+		}
+	}()
+
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
-	var buf = obtainBuffer(args.Journal, j.Content)
-
+	// Resolve a read from the write head only once, so that a blocking
+	// read awaits the next write.
 	if args.Offset == -1 {
-		args.Offset = int64(buf.Len())
+		args.Offset = int64(obtainBuffer(args.Journal, j.Content).Len())
 	}
 
 	for {
-		if int(args.Offset) < buf.Len() {
-			return ReadResult{
-				Offset:    args.Offset,
-				WriteHead: int64(buf.Len()),
-				Fragment:  Fragment{Begin: 0, End: int64(buf.Len())},
-			}, ioutil.NopCloser(bytes.NewReader(buf.Bytes()[args.Offset:]))
-		} else if !args.Blocking {
-			return ReadResult{
-				Error:     ErrNotYetAvailable,
-				WriteHead: int64(buf.Len()),
-			}, nil
-		} else if err := args.Context.Err(); err != nil {
-			return ReadResult{Error: err}, nil
+		var result, content = j.read(args)
+
+		if result.Error != ErrNotYetAvailable || !args.Blocking {
+			if result.Error != nil {
+				return result, nil
+			}
+			return result, ioutil.NopCloser(bytes.NewReader(content))
+		} else if args.Context != nil && args.Context.Err() != nil {
+			return ReadResult{Error: args.Context.Err()}, nil
+		} else if ctx.Err() != nil {
+			return result, nil // Deadline elapsed without content.
 		}
-
 		j.cond.Wait()
 	}
-	panic("not reached")
 }
 
+// Head returns the ReadResult of a Get of |args|, without blocking. As
+// content is not persisted to a fragment store, a fragment location is
+// never returned.
 func (j *MemoryBroker) Head(args ReadArgs) (ReadResult, *url.URL) {
-	panic("not yet implemented")
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if args.Offset == -1 {
+		args.Offset = int64(obtainBuffer(args.Journal, j.Content).Len())
+	}
+	var result, _ = j.read(args)
+	return result, nil
 }
 
+// Create the named journal, or return ErrExists if it exists.
 func (j *MemoryBroker) Create(journal Name) error {
-	panic("not yet implemented")
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, ok := j.Content[journal]; ok {
+		return ErrExists
+	} else if _, ok = j.Pending[journal]; ok {
+		return ErrExists
+	}
+	obtainBuffer(journal, j.Content)
+	return nil
+}
+
+// read returns the ReadResult and content of a non-blocking read of |args|.
+// The journal's content is modeled as a single Fragment. |j.mu| must be held.
+func (j *MemoryBroker) read(args ReadArgs) (ReadResult, []byte) {
+	var content = obtainBuffer(args.Journal, j.Content).Bytes()
+	var head = int64(len(content))
+
+	if args.Offset >= head {
+		return ReadResult{
+			Error:     ErrNotYetAvailable,
+			Offset:    args.Offset,
+			WriteHead: head,
+		}, nil
+	}
+	return ReadResult{
+		Offset:    args.Offset,
+		WriteHead: head,
+		Fragment: Fragment{
+			Journal: args.Journal,
+			Begin:   0,
+			End:     head,
+			Sum:     sha1.Sum(content),
+		},
+	}, append([]byte(nil), content[args.Offset:]...)
 }
 
 // Flush resolves all pending writes and wakes any blocked read operations.
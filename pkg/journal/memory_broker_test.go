@@ -0,0 +1,128 @@
+package journal
+
+import (
+	"context"
+	"crypto/sha1"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	gc "github.com/go-check/check"
+)
+
+type MemoryBrokerSuite struct{}
+
+func (s *MemoryBrokerSuite) TestWriteAndRead(c *gc.C) {
+	var b = NewMemoryBroker()
+
+	// Expect a non-blocking read of an empty journal is not yet available.
+	var result, rc = b.Get(ReadArgs{Journal: "a/journal"})
+	c.Check(result.Error, gc.Equals, ErrNotYetAvailable)
+	c.Check(rc, gc.IsNil)
+
+	var aa, err = b.Write("a/journal", []byte("hello, "))
+	c.Check(err, gc.IsNil)
+	<-aa.Ready
+	c.Check(aa.WriteHead, gc.Equals, int64(7))
+
+	aa, err = b.ReadFrom("a/journal", strings.NewReader("world!"))
+	c.Check(err, gc.IsNil)
+	c.Check(aa.WriteHead, gc.Equals, int64(13))
+
+	// Journals are implicitly created by writes and reads, or explicitly.
+	c.Check(b.Create("a/journal"), gc.Equals, ErrExists)
+	c.Check(b.Create("new/journal"), gc.IsNil)
+	c.Check(b.Create("new/journal"), gc.Equals, ErrExists)
+
+	result, rc = b.Get(ReadArgs{Journal: "a/journal", Offset: 7})
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.Offset, gc.Equals, int64(7))
+	c.Check(result.WriteHead, gc.Equals, int64(13))
+	c.Check(result.Fragment, gc.DeepEquals, Fragment{
+		Journal: "a/journal",
+		Begin:   0,
+		End:     13,
+		Sum:     sha1.Sum([]byte("hello, world!")),
+	})
+
+	content, _ := ioutil.ReadAll(rc)
+	c.Check(string(content), gc.Equals, "world!")
+	c.Check(b.Content["a/journal"].String(), gc.Equals, "hello, world!")
+
+	headResult, location := b.Head(ReadArgs{Journal: "a/journal", Offset: -1})
+	c.Check(headResult.Error, gc.Equals, ErrNotYetAvailable)
+	c.Check(headResult.Offset, gc.Equals, int64(13))
+	c.Check(headResult.WriteHead, gc.Equals, int64(13))
+	c.Check(location, gc.IsNil)
+
+	headResult, _ = b.Head(ReadArgs{Journal: "a/journal", Offset: 0})
+	c.Check(headResult.Error, gc.IsNil)
+	c.Check(headResult.Fragment.End, gc.Equals, int64(13))
+}
+
+func (s *MemoryBrokerSuite) TestDelayedWrites(c *gc.C) {
+	var b = NewMemoryBroker()
+	b.DelayWrites = true
+
+	var aa, _ = b.Write("a/journal", []byte("content"))
+	c.Check(aa.WriteHead, gc.Equals, int64(7))
+
+	// Pending writes aren't yet readable, and their promises are unresolved.
+	var result, _ = b.Get(ReadArgs{Journal: "a/journal"})
+	c.Check(result.Error, gc.Equals, ErrNotYetAvailable)
+
+	select {
+	case <-aa.Ready:
+		c.Fatal("expected write to be pending")
+	default:
+	}
+
+	b.Flush()
+	<-aa.Ready
+
+	result, _ = b.Get(ReadArgs{Journal: "a/journal"})
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.WriteHead, gc.Equals, int64(7))
+}
+
+func (s *MemoryBrokerSuite) TestBlockingGet(c *gc.C) {
+	var b = NewMemoryBroker()
+
+	var readCh = make(chan []byte)
+	go func() {
+		var result, rc = b.Get(ReadArgs{Journal: "a/journal", Offset: -1, Blocking: true})
+		c.Check(result.Error, gc.IsNil)
+		c.Check(result.Offset, gc.Equals, int64(0))
+
+		var content, _ = ioutil.ReadAll(rc)
+		readCh <- content
+	}()
+
+	// Expect a blocking Get of the write head awaits the next write, even
+	// if it's woken by other writes beforehand.
+	for obtained := false; !obtained; {
+		time.Sleep(time.Millisecond) // Wait for the Get to obtain the journal.
+
+		b.mu.Lock()
+		obtained = b.Content["a/journal"] != nil
+		b.mu.Unlock()
+	}
+	_, _ = b.Write("other/journal", []byte("other"))
+	_, _ = b.Write("a/journal", []byte("content"))
+	c.Check(string(<-readCh), gc.Equals, "content")
+
+	// Expect a blocking Get is aborted by Context cancellation.
+	var ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(time.Millisecond, cancel)
+
+	var result, rc = b.Get(ReadArgs{Journal: "a/journal", Offset: 7, Blocking: true, Context: ctx})
+	c.Check(result.Error, gc.Equals, context.Canceled)
+	c.Check(rc, gc.IsNil)
+
+	// Or by a Deadline, in which case it's not yet available.
+	result, _ = b.Get(ReadArgs{Journal: "a/journal", Offset: 7, Blocking: true,
+		Deadline: time.Now().Add(time.Millisecond)})
+	c.Check(result.Error, gc.Equals, ErrNotYetAvailable)
+}
+
+var _ = gc.Suite(&MemoryBrokerSuite{})
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -38,6 +39,25 @@ var (
 // Ex: "http://srv-2/a/root|https://srv-1|http://12.34.56.7:8080/other/root".
 type RouteToken string
 
+// Members returns the URLs of the RouteToken, with the broker first.
+// An empty RouteToken has no Members.
+func (rt RouteToken) Members() []string {
+	if rt == "" {
+		return nil
+	}
+	return strings.Split(string(rt), "|")
+}
+
+// Primary returns the URL of the RouteToken's broker, or empty if the
+// RouteToken is empty.
+func (rt RouteToken) Primary() string {
+	var s = string(rt)
+	if ind := strings.IndexByte(s, '|'); ind != -1 {
+		s = s[:ind]
+	}
+	return s
+}
+
 type ReplicateArgs struct {
 	Journal Name
 	// WriteHead (eg, first byte) of the replicated transaction.
@@ -119,8 +139,11 @@ type ReadResult struct {
 	Offset int64
 	// Write head at the completion of the operation.
 	WriteHead int64
-	// RouteToken of the Journal. Set on ErrNotReplica.
+	// RouteToken of the Journal. Set on ErrNotReplica, and by the Client
+	// from responses of brokers which advertise the current route.
 	RouteToken
+	// Broker is the endpoint which produced the result. Set by the Client.
+	Broker string
 	// Result fragment, set iff |Error| is nil.
 	Fragment Fragment
 }
@@ -131,9 +154,10 @@ func (a ReadResult) String() string {
 		Offset    int64
 		WriteHead int64
 		RouteToken
+		Broker   string
 		Fragment string
 		IsLocal  bool
-	}{a.Error, a.Offset, a.WriteHead, a.RouteToken,
+	}{a.Error, a.Offset, a.WriteHead, a.RouteToken, a.Broker,
 		a.Fragment.ContentPath(), a.Fragment.File != nil})
 }
 
@@ -165,8 +189,11 @@ type AppendResult struct {
 	Error error
 	// Write head at the completion of the operation.
 	WriteHead int64
-	// RouteToken of the Journal. Set on ErrNotBroker.
+	// RouteToken of the Journal. Set on ErrNotBroker, and by the Client
+	// from responses of brokers which advertise the current route.
 	RouteToken
+	// Broker is the endpoint which produced the result. Set by the Client.
+	Broker string
 }
 
 func (a AppendResult) String() string {
@@ -174,7 +201,8 @@ func (a AppendResult) String() string {
 		Error     error
 		WriteHead int64
 		RouteToken
-	}{a.Error, a.WriteHead, a.RouteToken})
+		Broker string
+	}{a.Error, a.WriteHead, a.RouteToken, a.Broker})
 }
 
 type AppendOp struct {
@@ -45,4 +45,20 @@ func (s *ProtocolSuite) TestErrorsAsStatusCodes(c *gc.C) {
 	c.Check(ErrorFromResponse(&response), gc.ErrorMatches, `error! \(body\)`)
 }
 
+func (s *ProtocolSuite) TestRouteTokenMembersAndPrimary(c *gc.C) {
+	var rt RouteToken = "http://srv-2/a/root|https://srv-1|http://12.34.56.7:8080/other/root"
+
+	c.Check(rt.Primary(), gc.Equals, "http://srv-2/a/root")
+	c.Check(rt.Members(), gc.DeepEquals, []string{
+		"http://srv-2/a/root", "https://srv-1", "http://12.34.56.7:8080/other/root"})
+
+	rt = "http://srv-1"
+	c.Check(rt.Primary(), gc.Equals, "http://srv-1")
+	c.Check(rt.Members(), gc.DeepEquals, []string{"http://srv-1"})
+
+	rt = ""
+	c.Check(rt.Primary(), gc.Equals, "")
+	c.Check(rt.Members(), gc.IsNil)
+}
+
 var _ = gc.Suite(&ProtocolSuite{})
@@ -1,7 +1,6 @@
 package journal
 
 import (
-	"crypto/sha1"
 	"errors"
 	"hash"
 	"io"
@@ -18,8 +17,8 @@ type Spool struct {
 	// Number of uncommitted bytes written to |Fragment.File| after
 	// the committed portion (ending at |Fragment.End|).
 	delta int64
-	// Incrementally builds |Fragment.Sum| as commits occur.
-	sha1Summer hash.Hash
+	// Incrementally builds the Fragment sum as commits occur.
+	summer hash.Hash
 	// Retained IO error.
 	err error
 }
@@ -27,9 +26,10 @@ type Spool struct {
 func NewSpool(directory string, at Mark) (*Spool, error) {
 	spool := &Spool{
 		Fragment: Fragment{
-			Journal: at.Journal,
-			Begin:   at.Offset,
-			End:     at.Offset,
+			Journal:      at.Journal,
+			Begin:        at.Offset,
+			End:          at.Offset,
+			SumAlgorithm: SelectSumAlgorithm(at.Journal),
 		},
 		directory: directory,
 	}
@@ -44,7 +44,7 @@ func NewSpool(directory string, at Mark) (*Spool, error) {
 	if err != nil {
 		return spool, err
 	}
-	spool.sha1Summer = sha1.New()
+	spool.summer = spool.NewHash()
 
 	return spool, err
 }
@@ -69,10 +69,10 @@ func (s *Spool) Commit(delta int64) error {
 		return nil // Trivial commit.
 	}
 
-	// Feed the new portion into sha1Summer, and flush the file.
+	// Feed the new portion into the summer, and flush the file.
 	if _, err := s.File.Seek(s.End-s.Begin, 0); err != nil {
 		return s.setErr(err)
-	} else if _, err = io.CopyN(s.sha1Summer, s.File, delta); err != nil {
+	} else if _, err = io.CopyN(s.summer, s.File, delta); err != nil {
 		return s.setErr(err)
 	} else if err := fdatasync(int(s.File.Fd())); err != nil {
 		return s.setErr(err)
@@ -82,7 +82,11 @@ func (s *Spool) Commit(delta int64) error {
 	// Perform an (atomic) file rename to record the commit.
 	previousPath := s.LocalPath()
 	s.End += delta
-	copy(s.Sum[:], s.sha1Summer.Sum(nil))
+	if s.SumAlgorithm == SumSHA256 {
+		copy(s.Sum256[:], s.summer.Sum(nil))
+	} else {
+		copy(s.Sum[:], s.summer.Sum(nil))
+	}
 	newPath := s.LocalPath()
 
 	return s.setErr(os.Rename(previousPath, newPath))
@@ -2,6 +2,9 @@ package journal
 
 import (
 	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"io/ioutil"
 	"math/rand"
@@ -104,6 +107,34 @@ func (s *SpoolSuite) TestMultipleWriteAndCommitFixture(c *gc.C) {
 	c.Check(string(content), gc.Equals, "an initial writeanota final write")
 }
 
+func (s *SpoolSuite) TestSHA256SumSelection(c *gc.C) {
+	defer func(fn func(Name) SumAlgorithm) { SelectSumAlgorithm = fn }(SelectSumAlgorithm)
+	SelectSumAlgorithm = func(name Name) SumAlgorithm {
+		if name == "journal/sha256" {
+			return SumSHA256
+		}
+		return SumSHA1
+	}
+
+	spool, err := NewSpool(s.localDir, Mark{"journal/sha256", 12345})
+	c.Assert(err, gc.IsNil)
+	c.Check(spool.SumAlgorithm, gc.Equals, SumSHA256)
+
+	_, err = spool.Write([]byte("an initial write"))
+	c.Check(err, gc.IsNil)
+	c.Check(spool.Commit(16), gc.IsNil)
+
+	c.Check(spool.Sum256, gc.Equals, sha256.Sum256([]byte("an initial write")))
+	c.Check(spool.Sum, gc.Equals, [sha1.Size]byte{})
+	c.Check(spool.LocalPath(), gc.Equals, filepath.Join(s.localDir, "journal/sha256/"+
+		"0000000000003039-0000000000003049-"+hex.EncodeToString(spool.Sum256[:])))
+
+	// Expect other journals continue to use SHA-1.
+	spool, err = NewSpool(s.localDir, Mark{"journal/sha1", 12345})
+	c.Assert(err, gc.IsNil)
+	c.Check(spool.SumAlgorithm, gc.Equals, SumSHA1)
+}
+
 func (s *SpoolSuite) TestFixtureChecksumEquivalence(c *gc.C) {
 	spool, err := NewSpool(s.localDir, Mark{"journal/name", 12345})
 	c.Check(err, gc.IsNil)
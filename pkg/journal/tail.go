@@ -5,6 +5,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/trace"
+
+	"github.com/LiveRamp/gazette/pkg/metrics"
 )
 
 const (
@@ -19,6 +21,8 @@ const (
 type Tail struct {
 	journal   Name
 	fragments FragmentSet
+	// Greatest End offset of a persisted (remote) fragment.
+	persistedEnd int64
 
 	readOps   chan ReadOp
 	updates   <-chan Fragment
@@ -101,6 +105,7 @@ func (t *Tail) loop() {
 		}
 	}
 	close(t.endOffset) // After close(), EndOffset() will thereafter return 0.
+	metrics.JournalTailLagBytes.DeleteLabelValues(t.journal.String())
 	log.WithField("journal", t.journal).Debug("tail loop exiting")
 	close(t.stop)
 }
@@ -113,6 +118,22 @@ func (t *Tail) onUpdate(fragment Fragment) {
 	}
 	t.fragments.Add(fragment)
 	t.wakeBlockedReads(time.Time{})
+
+	// Fragments without a local File have been persisted to the cloud
+	// filesystem. Track the extent to which persisted content trails the
+	// write head, which is content that would be unavailable to peers
+	// were this replica to fail.
+	if fragment.File == nil && fragment.End > t.persistedEnd {
+		t.persistedEnd = fragment.End
+	}
+	var persisted = t.persistedEnd
+	if len(t.fragments) == 0 {
+		return // Fragment was empty, and not added.
+	} else if begin := t.fragments[0].Begin; begin > persisted {
+		persisted = begin // Content before |begin| isn't part of the journal.
+	}
+	metrics.JournalTailLagBytes.WithLabelValues(t.journal.String()).
+		Set(float64(t.fragments.EndOffset() - persisted))
 }
 
 func (t *Tail) onRead(op ReadOp) {
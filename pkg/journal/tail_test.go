@@ -5,6 +5,9 @@ import (
 	"time"
 
 	gc "github.com/go-check/check"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/LiveRamp/gazette/pkg/metrics"
 )
 
 type TailSuite struct {
@@ -339,4 +342,30 @@ func (s *TailSuite) TestEndOffsetGenerator(c *gc.C) {
 	c.Check(s.tail.EndOffset(), gc.Equals, int64(475))
 }
 
+func (s *TailSuite) TestLagMetric(c *gc.C) {
+	var lag = func() float64 {
+		s.tail.EndOffset() // Sequence with the processing of prior updates.
+
+		var m dto.Metric
+		c.Assert(metrics.JournalTailLagBytes.WithLabelValues("a/journal").Write(&m), gc.IsNil)
+		return m.GetGauge().GetValue()
+	}
+	var local = &MockFragmentFile{}
+
+	// Local fragments are not yet persisted.
+	s.updates <- Fragment{Journal: "a/journal", Begin: 100, End: 200, File: local}
+	c.Check(lag(), gc.Equals, 100.0)
+	s.updates <- Fragment{Journal: "a/journal", Begin: 200, End: 300, File: local}
+	c.Check(lag(), gc.Equals, 200.0)
+
+	// Expect persisted fragments reduce lag.
+	s.updates <- Fragment{Journal: "a/journal", Begin: 100, End: 200}
+	c.Check(lag(), gc.Equals, 100.0)
+	s.updates <- Fragment{Journal: "a/journal", Begin: 200, End: 300}
+	c.Check(lag(), gc.Equals, 0.0)
+
+	s.updates <- Fragment{Journal: "a/journal", Begin: 300, End: 350, File: local}
+	c.Check(lag(), gc.Equals, 50.0)
+}
+
 var _ = gc.Suite(&TailSuite{})
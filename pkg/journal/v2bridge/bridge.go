@@ -0,0 +1,186 @@
+// Package v2bridge adapts v2 brokers to the legacy journal Writer, Getter and
+// Header interfaces. It allows applications built on pkg/journal to migrate
+// their clusters to v2 brokers before rewriting their I/O layer to use the v2
+// client package directly.
+package v2bridge
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/LiveRamp/gazette/pkg/journal"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// Client implements journal.Writer, journal.Getter, and journal.Header atop a
+// v2 RoutedJournalClient and AppendService. Writes are queued to the
+// AppendService, and the returned AsyncAppends are resolved when the write
+// commits. Gets are served by Read RPCs, and return content through the end
+// of the Fragment at the read offset.
+type Client struct {
+	ctx context.Context
+	rjc pb.RoutedJournalClient
+	as  *client.AppendService
+}
+
+// NewClient returns a Client which reads using |rjc| and writes to |as|. |ctx|
+// is used for Gets of ReadArgs which have no Context.
+func NewClient(ctx context.Context, rjc pb.RoutedJournalClient, as *client.AppendService) *Client {
+	return &Client{ctx: ctx, rjc: rjc, as: as}
+}
+
+// Write appends |buffer| to the named journal.
+func (c *Client) Write(name journal.Name, buffer []byte) (*journal.AsyncAppend, error) {
+	return c.ReadFrom(name, bytes.NewReader(buffer))
+}
+
+// ReadFrom appends the content of |r| to the named journal. If |r| returns
+// an error other than io.EOF, nothing is appended and the error is returned.
+func (c *Client) ReadFrom(name journal.Name, r io.Reader) (*journal.AsyncAppend, error) {
+	var j = pb.Journal(name)
+	if err := j.Validate(); err != nil {
+		return nil, err
+	}
+	var result = &journal.AsyncAppend{Ready: make(chan struct{})}
+
+	var aa = c.as.StartAppend(j)
+	var _, err = io.Copy(aa.Writer(), r)
+
+	aa.Require(err).OnCommit(func(_, end int64, err error) {
+		result.WriteHead, result.Error = end, err
+		close(result.Ready)
+	})
+	if err = aa.Release(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Get begins a read of |args|, and returns its ReadResult and a ReadCloser of
+// content from the ReadResult Offset through the end of its Fragment.
+func (c *Client) Get(args journal.ReadArgs) (journal.ReadResult, io.ReadCloser) {
+	var ctx, cancel = c.readContext(args)
+
+	var r = client.NewReader(ctx, c.rjc, pb.ReadRequest{
+		Journal: pb.Journal(args.Journal),
+		Offset:  args.Offset,
+		Block:   args.Blocking,
+	})
+	var result = c.readResult(args, r)
+
+	if result.Error != nil {
+		cancel()
+		return result, nil
+	}
+	return result, readCloser{
+		Reader: io.LimitReader(r, result.Fragment.End-result.Offset),
+		cancel: cancel,
+	}
+}
+
+// Head returns the ReadResult of a non-blocking read of |args|, and the
+// location of its Fragment if the Fragment is persisted to a store.
+func (c *Client) Head(args journal.ReadArgs) (journal.ReadResult, *url.URL) {
+	var ctx, cancel = c.readContext(args)
+	defer cancel()
+
+	var r = client.NewReader(ctx, c.rjc, pb.ReadRequest{
+		Journal:      pb.Journal(args.Journal),
+		Offset:       args.Offset,
+		MetadataOnly: true,
+	})
+	var result = c.readResult(args, r)
+
+	if result.Error != nil || r.Response.FragmentUrl == "" {
+		return result, nil
+	} else if location, err := url.Parse(r.Response.FragmentUrl); err != nil {
+		return journal.ReadResult{Error: err}, nil
+	} else {
+		return result, location
+	}
+}
+
+// readContext returns a Context for a read of |args|.
+func (c *Client) readContext(args journal.ReadArgs) (context.Context, context.CancelFunc) {
+	var ctx = args.Context
+	if ctx == nil {
+		ctx = c.ctx
+	}
+	if !args.Deadline.IsZero() {
+		return context.WithDeadline(ctx, args.Deadline)
+	}
+	return context.WithCancel(ctx)
+}
+
+// readResult reads response metadata of Reader |r|, and maps it to a ReadResult.
+func (c *Client) readResult(args journal.ReadArgs, r *client.Reader) journal.ReadResult {
+	// An empty Read returns upon reading response metadata.
+	var _, err = r.Read(nil)
+	if err == client.ErrOffsetJump {
+		err = nil
+	}
+
+	switch err {
+	case nil:
+		// Pass.
+	case client.ErrOffsetNotYetAvailable:
+		err = journal.ErrNotYetAvailable
+	case client.ErrJournalNotFound:
+		err = journal.ErrNotFound
+	case client.ErrNotJournalBroker:
+		err = journal.ErrNotBroker
+	case context.DeadlineExceeded:
+		// A blocking read which reaches its ReadArgs Deadline (rather than
+		// that of its Context) is not yet available.
+		if args.Context == nil || args.Context.Err() == nil {
+			err = journal.ErrNotYetAvailable
+		}
+	}
+	if err != nil {
+		return journal.ReadResult{
+			Error:     err,
+			Offset:    r.Response.Offset,
+			WriteHead: r.Response.WriteHead,
+		}
+	}
+	return journal.ReadResult{
+		Offset:    r.Response.Offset,
+		WriteHead: r.Response.WriteHead,
+		Fragment:  toFragment(*r.Response.Fragment),
+	}
+}
+
+// toFragment maps a v2 Fragment to its legacy equivalent.
+func toFragment(f pb.Fragment) journal.Fragment {
+	var out = journal.Fragment{
+		Journal: journal.Name(f.Journal),
+		Begin:   f.Begin,
+		End:     f.End,
+		Sum:     f.Sum.ToDigest(),
+	}
+	if f.ModTime != 0 {
+		out.RemoteModTime = time.Unix(f.ModTime, 0)
+	}
+	return out
+}
+
+// readCloser cancels the Context of a Get when closed.
+type readCloser struct {
+	io.Reader
+	cancel context.CancelFunc
+}
+
+func (rc readCloser) Close() error {
+	rc.cancel()
+	return nil
+}
+
+var (
+	_ journal.Writer = new(Client)
+	_ journal.Getter = new(Client)
+	_ journal.Header = new(Client)
+)
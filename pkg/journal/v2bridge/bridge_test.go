@@ -0,0 +1,100 @@
+package v2bridge
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	gc "github.com/go-check/check"
+
+	"github.com/LiveRamp/gazette/pkg/journal"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+type BridgeSuite struct{}
+
+func (s *BridgeSuite) TestWriteGetAndHead(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var mc = client.NewMemoryJournalClient()
+	var _, err = mc.Apply(ctx, &pb.ApplyRequest{Changes: []pb.ApplyRequest_Change{
+		{Upsert: &pb.JournalSpec{
+			Name:        "a/journal",
+			Replication: 1,
+			Fragment: pb.JournalSpec_Fragment{
+				Length:           1 << 24,
+				CompressionCodec: pb.CompressionCodec_NONE,
+				RefreshInterval:  time.Minute,
+				Retention:        time.Hour,
+			},
+		}},
+	}})
+	c.Assert(err, gc.IsNil)
+
+	var rjc = pb.NewRoutedJournalClient(mc, pb.NoopDispatchRouter{})
+	var bc = NewClient(ctx, rjc, client.NewAppendService(ctx, rjc))
+
+	// Writes resolve upon their commit.
+	aa, err := bc.Write("a/journal", []byte("hello, "))
+	c.Assert(err, gc.IsNil)
+	<-aa.Ready
+	c.Check(aa.Error, gc.IsNil)
+	c.Check(aa.WriteHead, gc.Equals, int64(7))
+
+	aa, err = bc.ReadFrom("a/journal", iotestErrReader{})
+	c.Check(err, gc.ErrorMatches, "read error")
+	c.Check(aa, gc.IsNil)
+
+	aa, err = bc.Write("a/journal", []byte("world!"))
+	c.Assert(err, gc.IsNil)
+	<-aa.Ready
+	c.Check(aa.WriteHead, gc.Equals, int64(13))
+
+	_, err = bc.Write("invalid journal name", nil)
+	c.Check(err, gc.NotNil)
+
+	// Gets return content through the end of the offset's Fragment.
+	result, rc := bc.Get(journal.ReadArgs{Journal: "a/journal", Offset: 2})
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.Offset, gc.Equals, int64(2))
+	c.Check(result.WriteHead, gc.Equals, int64(13))
+	c.Check(result.Fragment.Begin, gc.Equals, int64(0))
+	c.Check(result.Fragment.End, gc.Equals, int64(7))
+
+	b, err := ioutil.ReadAll(rc)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "llo, ")
+	c.Check(rc.Close(), gc.IsNil)
+
+	// Errors are mapped to their legacy equivalents.
+	result, rc = bc.Get(journal.ReadArgs{Journal: "a/journal", Offset: 13})
+	c.Check(result.Error, gc.Equals, journal.ErrNotYetAvailable)
+	c.Check(rc, gc.IsNil)
+
+	result, _ = bc.Get(journal.ReadArgs{Journal: "not/found"})
+	c.Check(result.Error, gc.Equals, journal.ErrNotFound)
+
+	result, _ = bc.Get(journal.ReadArgs{Journal: "a/journal", Offset: 13, Blocking: true,
+		Deadline: time.Now().Add(time.Millisecond)})
+	c.Check(result.Error, gc.Equals, journal.ErrNotYetAvailable)
+
+	// Head returns metadata only. Fragments of the MemoryJournalClient
+	// are never persisted, and have no location.
+	result, loc := bc.Head(journal.ReadArgs{Journal: "a/journal", Offset: 8})
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.Fragment.Begin, gc.Equals, int64(7))
+	c.Check(result.Fragment.End, gc.Equals, int64(13))
+	c.Check(loc, gc.IsNil)
+}
+
+type iotestErrReader struct{}
+
+func (iotestErrReader) Read([]byte) (int, error) { return 0, errors.New("read error") }
+
+var _ = gc.Suite(&BridgeSuite{})
+
+func Test(t *testing.T) { gc.TestingT(t) }
@@ -7,19 +7,24 @@
 package mainboilerplate
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/LiveRamp/gazette/pkg/cloudstore"
 	"github.com/LiveRamp/gazette/pkg/envflag"
 	"github.com/LiveRamp/gazette/pkg/envflagfactory"
+	"github.com/LiveRamp/gazette/pkg/pprof"
 )
 
 const (
@@ -40,10 +45,24 @@ func Initialize() {
 	var logLevel = envflagfactory.NewLogLevel()
 	var metricsPort = envflagfactory.NewMetricsPort()
 	var metricsPath = envflagfactory.NewMetricsPath()
+	var debugPort = envflagfactory.NewDebugPort()
+	var profileDir = envflagfactory.NewProfileDir()
+	var profileFilename = envflagfactory.NewProfileFilename()
+	var profileRetention = envflagfactory.NewProfileRetention()
+	var blockProfileRate = envflagfactory.NewBlockProfileRate()
+	var mutexProfileFraction = envflagfactory.NewMutexProfileFraction()
+	var maxTraceDuration = envflagfactory.NewMaxTraceDuration()
+	var profileURL = envflagfactory.NewProfileURL()
+	var profileInterval = envflagfactory.NewProfileInterval()
+	var buildVersion = envflagfactory.NewBuildVersion()
 
 	initFlags()
 	initLog(*logLevel)
 	initMetrics(*metricsPort, *metricsPath)
+	initDebug(*debugPort)
+	initProfiling(*profileDir, *profileFilename, *profileRetention,
+		*blockProfileRate, *mutexProfileFraction, *maxTraceDuration)
+	initContinuousProfiling(*profileURL, *profileInterval, *buildVersion)
 	RegisterSignalHandlers()
 }
 
@@ -72,6 +91,65 @@ func initMetrics(port, path string) {
 	go http.ListenAndServe(port, nil)
 }
 
+// initDebug enables serving of profiling endpoints over the given port, if set.
+func initDebug(port string) {
+	if port == "" {
+		return
+	}
+	go func() {
+		if err := pprof.ListenAndServe(port); err != nil {
+			log.WithField("err", err).Error("failed to serve profiling endpoints")
+		}
+	}()
+}
+
+// initProfiling configures signal-triggered profiling.
+func initProfiling(dir, template, retention, blockRate, mutexFraction, maxTrace string) {
+	var d, err = time.ParseDuration(maxTrace)
+	if err != nil || d <= 0 {
+		log.WithFields(log.Fields{"err": err, "value": maxTrace}).
+			Fatal("invalid max trace duration")
+	}
+	pprof.Configure(
+		pprof.WithDirectory(dir),
+		pprof.WithFilenameTemplate(template),
+		pprof.WithRetention(parseProfileInt("retention", retention)),
+		pprof.WithBlockProfileRate(parseProfileInt("block rate", blockRate)),
+		pprof.WithMutexProfileFraction(parseProfileInt("mutex fraction", mutexFraction)),
+		pprof.WithMaxTraceDuration(d),
+	)
+}
+
+// parseProfileInt parses a non-negative integer profiling
+// setting |name|, or exits if |value| is invalid.
+func parseProfileInt(name, value string) int {
+	var n, err = strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.WithFields(log.Fields{"err": err, "value": value}).
+			Fatalf("invalid profile %s", name)
+	}
+	return n
+}
+
+// initContinuousProfiling enables continuous profiling with uploads to the
+// given cloud filesystem URL, if set.
+func initContinuousProfiling(rawURL, interval, build string) {
+	if rawURL == "" {
+		return
+	}
+	var d, err = time.ParseDuration(interval)
+	if err != nil || d <= 0 {
+		log.WithFields(log.Fields{"err": err, "interval": interval}).
+			Fatal("invalid profile interval")
+	}
+	cfs, err := cloudstore.NewFileSystem(nil, rawURL)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "url": rawURL}).
+			Fatal("failed to initialize profile cloudstore")
+	}
+	go pprof.NewContinuousProfiler(cfs, d, build).Run(context.Background())
+}
+
 // LogPanic is intended to be a deferred call to log a panic at the end of the
 // program's lifecycle.
 func LogPanic() {
@@ -11,6 +11,7 @@ const (
 	CommittedBytesTotalKey            = "gazette_committed_bytes_total"
 	FailedCommitsTotalKey             = "gazette_failed_commits_total"
 	ItemRouteDurationSecondsKey       = "gazette_item_route_duration_seconds"
+	JournalTailLagBytesKey            = "gazette_journal_tail_lag_bytes"
 	RecoveryLogRecoveredBytesTotalKey = "gazette_recoverylog_recovered_bytes_total"
 )
 
@@ -32,6 +33,10 @@ var (
 		Name: ItemRouteDurationSecondsKey,
 		Help: "Benchmarking of Runner.ItemRoute calls.",
 	})
+	JournalTailLagBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: JournalTailLagBytesKey,
+		Help: "Number of bytes by which persisted fragments of a journal replica trail its write head.",
+	}, []string{"journal"})
 	RecoveryLogRecoveredBytesTotal = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: RecoveryLogRecoveredBytesTotalKey,
 		Help: "Cumulative number of bytes recovered.",
@@ -44,6 +49,7 @@ func GazetteCollectors() []prometheus.Collector {
 		CommittedBytesTotal,
 		FailedCommitsTotal,
 		ItemRouteDurationSeconds,
+		JournalTailLagBytes,
 		RecoveryLogRecoveredBytesTotal,
 	}
 }
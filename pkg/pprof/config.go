@@ -0,0 +1,175 @@
+package pprof
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Option configures signal-triggered profiling.
+type Option func(*config)
+
+// WithDirectory sets the directory to which profiles are written. The
+// directory is created if it doesn't exist. The default is "/var/tmp".
+func WithDirectory(dir string) Option {
+	return func(o *config) { o.dir = dir }
+}
+
+// WithFilenameTemplate sets the filename template of written profiles. Tokens
+// "{type}" (eg, "cpu"), "{pid}", and "{timestamp}" (the epoch time at which the
+// profile began) are replaced with their values. The default template is
+// DefaultFilenameTemplate.
+func WithFilenameTemplate(template string) Option {
+	return func(o *config) { o.template = template }
+}
+
+// WithRetention sets the number of profiles of each type which are retained
+// in the profile directory, removing older profiles as new ones are written.
+// Profiles are matched by the filename template. Zero (the default) retains
+// all profiles.
+func WithRetention(n int) Option {
+	return func(o *config) { o.retention = n }
+}
+
+// WithBlockProfileRate sets the rate of block profiling, while enabled, as
+// per runtime.SetBlockProfileRate. The default is DefaultBlockProfileRate.
+func WithBlockProfileRate(rate int) Option {
+	return func(o *config) { o.blockRate = rate }
+}
+
+// WithMutexProfileFraction sets the fraction of mutex contention events which
+// are profiled, while enabled, as per runtime.SetMutexProfileFraction. The
+// default is DefaultMutexProfileFraction.
+func WithMutexProfileFraction(fraction int) Option {
+	return func(o *config) { o.mutexFraction = fraction }
+}
+
+// WithMaxTraceDuration sets the maximum duration of an execution trace, after
+// which it's stopped. The default is DefaultMaxTraceDuration.
+func WithMaxTraceDuration(d time.Duration) Option {
+	return func(o *config) { o.maxTraceDuration = d }
+}
+
+// Configure signal-triggered profiling with |opts|. If contention profiling
+// is enabled, updated rates take effect immediately.
+func Configure(opts ...Option) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	for _, opt := range opts {
+		opt(&currentConfig)
+	}
+	if contentionEnabled {
+		setContentionRates(currentConfig.blockRate, currentConfig.mutexFraction)
+	}
+}
+
+const (
+	// DefaultFilenameTemplate is the default filename template of profiles.
+	DefaultFilenameTemplate = "{type}_{pid}_{timestamp}.pprof"
+	// DefaultBlockProfileRate is the default rate of block profiling: on
+	// average, one blocking event is sampled per 10µs spent blocked.
+	DefaultBlockProfileRate = 10000
+	// DefaultMutexProfileFraction is the default fraction of
+	// mutex contention events which are profiled (1 in 10).
+	DefaultMutexProfileFraction = 10
+	// DefaultMaxTraceDuration is the default maximum duration of an
+	// execution trace. Traces grow quickly, and are best kept short.
+	DefaultMaxTraceDuration = time.Minute
+)
+
+// config is the configuration of signal-triggered profiling.
+type config struct {
+	dir           string
+	template      string
+	retention     int
+	blockRate     int
+	mutexFraction int
+
+	maxTraceDuration time.Duration
+}
+
+var (
+	currentConfig = config{
+		dir:           "/var/tmp",
+		template:      DefaultFilenameTemplate,
+		blockRate:     DefaultBlockProfileRate,
+		mutexFraction: DefaultMutexProfileFraction,
+
+		maxTraceDuration: DefaultMaxTraceDuration,
+	}
+	configMu sync.Mutex
+)
+
+// createProfileFile creates a file for a profile of |kind| beginning at |now|,
+// removing older profiles of |kind| beyond the configured retention.
+func createProfileFile(kind string, now time.Time) (*os.File, error) {
+	configMu.Lock()
+	var o = currentConfig
+	configMu.Unlock()
+
+	if err := os.MkdirAll(o.dir, 0750); err != nil {
+		return nil, fmt.Errorf("making profile directory: %s", err)
+	}
+	var f, err = os.Create(filepath.Join(o.dir, o.filename(kind, strconv.Itoa(os.Getpid()), strconv.FormatInt(now.Unix(), 10))))
+	if err != nil {
+		return nil, err
+	}
+	if o.retention > 0 {
+		o.prune(kind)
+	}
+	return f, nil
+}
+
+// filename returns the templated filename of a profile.
+func (o config) filename(kind, pid, timestamp string) string {
+	return strings.NewReplacer(
+		"{type}", kind,
+		"{pid}", pid,
+		"{timestamp}", timestamp,
+	).Replace(o.template)
+}
+
+// prune removes the oldest profiles of |kind| in excess of the retention.
+func (o config) prune(kind string) {
+	var pattern = filepath.Join(o.dir, o.filename(kind, "*", "*"))
+
+	var names, err = filepath.Glob(pattern)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "pattern": pattern}).Warn("failed to list profiles")
+		return
+	}
+
+	type profile struct {
+		name    string
+		modTime time.Time
+	}
+	var profiles []profile
+
+	for _, name := range names {
+		if info, err := os.Stat(name); err == nil && !info.IsDir() {
+			profiles = append(profiles, profile{name, info.ModTime()})
+		}
+	}
+	if len(profiles) <= o.retention {
+		return
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].modTime.Equal(profiles[j].modTime) {
+			return profiles[i].name < profiles[j].name
+		}
+		return profiles[i].modTime.Before(profiles[j].modTime)
+	})
+	for _, p := range profiles[:len(profiles)-o.retention] {
+		if err := os.Remove(p.name); err != nil {
+			log.WithFields(log.Fields{"err": err, "path": p.name}).Warn("failed to remove profile")
+		}
+	}
+}
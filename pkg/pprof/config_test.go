@@ -0,0 +1,83 @@
+package pprof
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	gc "github.com/go-check/check"
+)
+
+type OutputSuite struct {
+	dir   string
+	saved config
+}
+
+func (s *OutputSuite) SetUpTest(c *gc.C) {
+	var err error
+	s.dir, err = ioutil.TempDir("", "pprof-output")
+	c.Assert(err, gc.IsNil)
+	s.saved = currentConfig
+}
+
+func (s *OutputSuite) TearDownTest(c *gc.C) {
+	currentConfig = s.saved
+	c.Check(os.RemoveAll(s.dir), gc.IsNil)
+}
+
+func (s *OutputSuite) TestFilenameTemplate(c *gc.C) {
+	var dir = filepath.Join(s.dir, "nested", "dir")
+	Configure(WithDirectory(dir), WithFilenameTemplate("app.{pid}.{type}.{timestamp}.prof"))
+
+	var f, err = createProfileFile("cpu", time.Unix(1500000000, 0))
+	c.Assert(err, gc.IsNil)
+	c.Check(f.Close(), gc.IsNil)
+
+	// Nested directories were created.
+	c.Check(f.Name(), gc.Equals,
+		filepath.Join(dir, fmt.Sprintf("app.%d.cpu.1500000000.prof", os.Getpid())))
+	_, err = os.Stat(f.Name())
+	c.Check(err, gc.IsNil)
+}
+
+func (s *OutputSuite) TestDefaults(c *gc.C) {
+	c.Check(s.saved.dir, gc.Equals, "/var/tmp")
+	c.Check(s.saved.filename("cpu", "123", "456"), gc.Equals, "cpu_123_456.pprof")
+}
+
+func (s *OutputSuite) TestRetention(c *gc.C) {
+	Configure(WithDirectory(s.dir), WithRetention(2))
+
+	var names []string
+	for i := int64(0); i != 4; i++ {
+		var now = time.Unix(1500000000+i, 0)
+
+		var f, err = createProfileFile("cpu", now)
+		c.Assert(err, gc.IsNil)
+		c.Check(f.Close(), gc.IsNil)
+		c.Check(os.Chtimes(f.Name(), now, now), gc.IsNil)
+		names = append(names, filepath.Base(f.Name()))
+	}
+	// A profile of another type is retained independently.
+	var f, err = createProfileFile("heap", time.Unix(1500000000, 0))
+	c.Assert(err, gc.IsNil)
+	c.Check(f.Close(), gc.IsNil)
+
+	// Only the two most recent "cpu" profiles remain.
+	infos, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, gc.IsNil)
+
+	var remaining []string
+	for _, info := range infos {
+		remaining = append(remaining, info.Name())
+	}
+	c.Check(remaining, gc.DeepEquals, []string{
+		names[2],
+		names[3],
+		fmt.Sprintf("heap_%d_1500000000.pprof", os.Getpid()),
+	})
+}
+
+var _ = gc.Suite(&OutputSuite{})
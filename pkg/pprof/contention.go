@@ -0,0 +1,60 @@
+package pprof
+
+import (
+	"runtime"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// EnableContentionProfiling enables runtime block and mutex profiling, at the
+// rates set by Configure. Lock contention and blocking of goroutines (eg, on
+// channels) are then reported by the "block" and "mutex" profiles, which are
+// served at /debug/pprof/ and dumped on SIGQUIT.
+func EnableContentionProfiling() {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	contentionEnabled = true
+	setContentionRates(currentConfig.blockRate, currentConfig.mutexFraction)
+}
+
+// DisableContentionProfiling disables runtime block and mutex profiling.
+func DisableContentionProfiling() {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	contentionEnabled = false
+	setContentionRates(0, 0)
+}
+
+// ContentionProfilingEnabled returns true if block and mutex
+// profiling is enabled.
+func ContentionProfilingEnabled() bool {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	return contentionEnabled
+}
+
+// toggleContentionProfiling enables block and mutex profiling
+// if it's disabled, and disables it otherwise.
+func toggleContentionProfiling() {
+	if ContentionProfilingEnabled() {
+		DisableContentionProfiling()
+		log.Info("disabled contention profiling")
+	} else {
+		EnableContentionProfiling()
+		log.Info("enabled contention profiling")
+	}
+}
+
+// setContentionRates sets runtime block and mutex profiling rates.
+// Rates of zero disable profiling.
+func setContentionRates(blockRate, mutexFraction int) {
+	runtime.SetBlockProfileRate(blockRate)
+	runtime.SetMutexProfileFraction(mutexFraction)
+}
+
+// contentionEnabled is true if contention profiling is enabled. It's
+// guarded by |configMu|.
+var contentionEnabled bool
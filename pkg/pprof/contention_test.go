@@ -0,0 +1,52 @@
+package pprof
+
+import (
+	"bytes"
+	"runtime"
+
+	gc "github.com/go-check/check"
+)
+
+type ContentionSuite struct {
+	saved config
+}
+
+func (s *ContentionSuite) SetUpTest(c *gc.C) { s.saved = currentConfig }
+
+func (s *ContentionSuite) TearDownTest(c *gc.C) {
+	DisableContentionProfiling()
+	currentConfig = s.saved
+}
+
+func (s *ContentionSuite) TestToggling(c *gc.C) {
+	Configure(WithBlockProfileRate(1), WithMutexProfileFraction(5))
+	c.Check(ContentionProfilingEnabled(), gc.Equals, false)
+	c.Check(runtime.SetMutexProfileFraction(-1), gc.Equals, 0) // -1 reads the current fraction.
+
+	toggleContentionProfiling()
+	c.Check(ContentionProfilingEnabled(), gc.Equals, true)
+	c.Check(runtime.SetMutexProfileFraction(-1), gc.Equals, 5)
+
+	// Updated rates take effect immediately.
+	Configure(WithMutexProfileFraction(7))
+	c.Check(runtime.SetMutexProfileFraction(-1), gc.Equals, 7)
+
+	toggleContentionProfiling()
+	c.Check(ContentionProfilingEnabled(), gc.Equals, false)
+	c.Check(runtime.SetMutexProfileFraction(-1), gc.Equals, 0)
+
+	// Updated rates don't enable profiling.
+	Configure(WithMutexProfileFraction(9))
+	c.Check(runtime.SetMutexProfileFraction(-1), gc.Equals, 0)
+}
+
+func (s *ContentionSuite) TestDumpIncludesContentionProfiles(c *gc.C) {
+	EnableContentionProfiling()
+
+	var buf bytes.Buffer
+	dump(&buf)
+
+	c.Check(buf.String(), gc.Matches, "(?s)heap profile: .*goroutine profile: .*--- contention:.*--- mutex:.*")
+}
+
+var _ = gc.Suite(&ContentionSuite{})
@@ -0,0 +1,149 @@
+package pprof
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	runtimepprof "runtime/pprof"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/LiveRamp/gazette/pkg/cloudstore"
+)
+
+// ContinuousProfiler periodically captures short CPU and heap profiles of the
+// program, and uploads them to a cloudstore.FileSystem (eg, one rooted at
+// "gs://bucket/profiles/"). Profiles are uploaded to paths of the form:
+//
+//	process=${PROCESS}/build=${BUILD}/host=${HOST}/${TYPE}_${PID}_${TIMESTAMP}.pprof
+//
+// where TYPE is "cpu" or "heap", and TIMESTAMP is the epoch time at which
+// capture of the profile began. Profiles of a fleet of brokers or consumers may
+// thus be listed and merged by process, build, or host.
+type ContinuousProfiler struct {
+	// Interval between the start of each round of profile captures.
+	Interval time.Duration
+	// CPUDuration is the duration of each CPU profile. It should be
+	// small relative to Interval.
+	CPUDuration time.Duration
+	// Labels of uploaded profiles.
+	Labels ProfileLabels
+
+	cfs cloudstore.FileSystem
+}
+
+// ProfileLabels identify the program of a profile.
+type ProfileLabels struct {
+	// Host of the program. Defaults to os.Hostname.
+	Host string
+	// Process name of the program. Defaults to the base name of os.Args[0].
+	Process string
+	// Build version of the program.
+	Build string
+}
+
+// NewContinuousProfiler returns a ContinuousProfiler of |cfs| which captures
+// profiles each |interval|, labeled with the given |build| version and the
+// default host and process labels.
+func NewContinuousProfiler(cfs cloudstore.FileSystem, interval time.Duration, build string) *ContinuousProfiler {
+	var labels = ProfileLabels{
+		Process: filepath.Base(os.Args[0]),
+		Build:   build,
+	}
+	if host, err := os.Hostname(); err == nil {
+		labels.Host = host
+	}
+	return &ContinuousProfiler{
+		Interval:    interval,
+		CPUDuration: defaultContinuousCPUDuration,
+		Labels:      labels,
+		cfs:         cfs,
+	}
+}
+
+// Run captures and uploads profiles each Interval, until |ctx| is cancelled.
+// Failures to capture or upload a profile are logged, and don't stop Run.
+func (p *ContinuousProfiler) Run(ctx context.Context) {
+	var ticker = time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := p.capture(ctx, now); err != nil {
+				log.WithField("err", err).Warn("failed to capture continuous profile")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// capture a round of CPU and heap profiles beginning at |now|, and upload them.
+func (p *ContinuousProfiler) capture(ctx context.Context, now time.Time) error {
+	var buf bytes.Buffer
+
+	if err := runtimepprof.StartCPUProfile(&buf); err != nil {
+		// A CPU profile is already running (eg, by SIGUSR1 or a /profile/cpu
+		// request). Skip the CPU profile of this round.
+		log.WithField("err", err).Info("skipping continuous CPU profile")
+	} else {
+		select {
+		case <-time.After(p.CPUDuration):
+		case <-ctx.Done():
+		}
+		runtimepprof.StopCPUProfile()
+
+		if err = p.upload(p.profilePath("cpu", now), &buf); err != nil {
+			return err
+		}
+	}
+
+	buf.Reset()
+	if err := runtimepprof.Lookup("heap").WriteTo(&buf, 0); err != nil {
+		return err
+	}
+	return p.upload(p.profilePath("heap", now), &buf)
+}
+
+// upload the profile content of |buf| to |name|.
+func (p *ContinuousProfiler) upload(name string, buf *bytes.Buffer) error {
+	if err := p.cfs.MkdirAll(path.Dir(name), 0750); err != nil {
+		return fmt.Errorf("making profile directory: %s", err)
+	}
+	var w, err = p.cfs.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return fmt.Errorf("opening profile for writing: %s", err)
+	}
+	if _, err = p.cfs.CopyAtomic(w, buf); err != nil {
+		return fmt.Errorf("copying profile: %s", err)
+	}
+	log.WithField("path", name).Debug("uploaded continuous profile")
+	return nil
+}
+
+// profilePath returns the path of a profile of |kind| captured at |now|.
+func (p *ContinuousProfiler) profilePath(kind string, now time.Time) string {
+	return path.Join(
+		"process="+labelValue(p.Labels.Process),
+		"build="+labelValue(p.Labels.Build),
+		"host="+labelValue(p.Labels.Host),
+		fmt.Sprintf("%s_%d_%d.pprof", kind, os.Getpid(), now.Unix()),
+	)
+}
+
+// labelValue returns |v| as a path component, or "unknown" if |v| is empty.
+func labelValue(v string) string {
+	if v == "" {
+		return "unknown"
+	}
+	return strings.Replace(v, "/", "_", -1)
+}
+
+// defaultContinuousCPUDuration is the default ContinuousProfiler CPUDuration.
+const defaultContinuousCPUDuration = 10 * time.Second
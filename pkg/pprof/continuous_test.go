@@ -0,0 +1,89 @@
+package pprof
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	runtimepprof "runtime/pprof"
+	"sort"
+	"time"
+
+	gc "github.com/go-check/check"
+
+	"github.com/LiveRamp/gazette/pkg/cloudstore"
+)
+
+type ContinuousSuite struct{}
+
+func (s *ContinuousSuite) TestCaptureAndUpload(c *gc.C) {
+	var cfs = cloudstore.NewTmpFileSystem()
+	defer cfs.Close()
+
+	var p = NewContinuousProfiler(cfs, time.Minute, "v1.2.3")
+	p.CPUDuration = 10 * time.Millisecond
+	p.Labels.Host = "a-host"
+	p.Labels.Process = "a/process"
+
+	var now = time.Unix(1500000000, 0)
+	c.Assert(p.capture(context.Background(), now), gc.IsNil)
+
+	var paths []string
+	c.Check(cfs.Walk("", func(name string, info os.FileInfo, err error) error {
+		c.Check(err, gc.IsNil)
+		paths = append(paths, filepath.ToSlash(name))
+		c.Check(info.Size(), gc.Not(gc.Equals), int64(0))
+		return nil
+	}), gc.IsNil)
+	sort.Strings(paths)
+
+	var dir = "process=a_process/build=v1.2.3/host=a-host/"
+	c.Check(paths, gc.DeepEquals, []string{
+		fmt.Sprintf("%scpu_%d_1500000000.pprof", dir, os.Getpid()),
+		fmt.Sprintf("%sheap_%d_1500000000.pprof", dir, os.Getpid()),
+	})
+
+	// A capture of the same round doesn't clobber previous uploads.
+	c.Check(p.capture(context.Background(), now), gc.ErrorMatches, "opening profile for writing: .*")
+}
+
+func (s *ContinuousSuite) TestCPUProfileAlreadyRunning(c *gc.C) {
+	var cfs = cloudstore.NewTmpFileSystem()
+	defer cfs.Close()
+
+	c.Assert(runtimepprof.StartCPUProfile(ioutil.Discard), gc.IsNil)
+	defer runtimepprof.StopCPUProfile()
+
+	var p = NewContinuousProfiler(cfs, time.Minute, "")
+	p.Labels = ProfileLabels{Host: "a-host", Process: "a-process"}
+
+	var now = time.Unix(1500000000, 0)
+	c.Assert(p.capture(context.Background(), now), gc.IsNil)
+
+	// Only the heap profile is uploaded.
+	var f, err = cfs.Open(p.profilePath("heap", now))
+	c.Assert(err, gc.IsNil)
+	c.Check(f.Close(), gc.IsNil)
+
+	_, err = cfs.Open(p.profilePath("cpu", now))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+
+	c.Check(p.profilePath("cpu", now), gc.Equals,
+		fmt.Sprintf("process=a-process/build=unknown/host=a-host/cpu_%d_1500000000.pprof", os.Getpid()))
+}
+
+func (s *ContinuousSuite) TestRunStopsOnCancel(c *gc.C) {
+	var cfs = cloudstore.NewTmpFileSystem()
+	defer cfs.Close()
+
+	var p = NewContinuousProfiler(cfs, time.Hour, "")
+	var ctx, cancel = context.WithCancel(context.Background())
+	var done = make(chan struct{})
+
+	go func() { p.Run(ctx); close(done) }()
+	cancel()
+	<-done
+}
+
+var _ = gc.Suite(&ContinuousSuite{})
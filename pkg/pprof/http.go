@@ -0,0 +1,86 @@
+package pprof
+
+import (
+	"fmt"
+	"net/http"
+	httppprof "net/http/pprof"
+	runtimepprof "runtime/pprof"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// NewHandler returns an http.Handler of profiling endpoints, for programs
+// where sending profiling signals is awkward (eg, within containers):
+//
+//   - "/debug/pprof/" serves the handlers of package net/http/pprof.
+//   - "/profile/cpu?seconds=N" responds with a CPU profile of the next N
+//     seconds (default 30). It fails with 409 (Conflict) if a CPU profile is
+//     already running, as by SIGUSR1 or a concurrent request.
+func NewHandler() http.Handler {
+	var mux = http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", httppprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", httppprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", httppprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", httppprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", httppprof.Trace)
+	mux.HandleFunc("/profile/cpu", serveCPUProfile)
+
+	return mux
+}
+
+// ListenAndServe serves the profiling endpoints of NewHandler on |addr|
+// (eg, ":6060"). It blocks until the server fails.
+func ListenAndServe(addr string) error {
+	log.WithField("addr", addr).Info("serving profiling endpoints")
+	return http.ListenAndServe(addr, NewHandler())
+}
+
+func serveCPUProfile(w http.ResponseWriter, r *http.Request) {
+	var seconds, err = parseSeconds(r.FormValue("seconds"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Set headers prior to StartCPUProfile, as the profile may be written
+	// to |w| at any point thereafter.
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="cpu.pprof"`)
+
+	if err = runtimepprof.StartCPUProfile(w); err != nil {
+		w.Header().Del("Content-Disposition")
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		http.Error(w, fmt.Sprintf("could not begin CPU profiling: %s", err), http.StatusConflict)
+		return
+	}
+
+	select {
+	case <-time.After(time.Duration(seconds) * time.Second):
+	case <-r.Context().Done():
+		// Client went away. Stop the profile early.
+	}
+	runtimepprof.StopCPUProfile()
+}
+
+// parseSeconds parses the "seconds" parameter of a /profile/cpu request.
+func parseSeconds(s string) (int, error) {
+	if s == "" {
+		return defaultProfileSeconds, nil
+	}
+	var n, err = strconv.Atoi(s)
+	if err != nil || n <= 0 || n > maxProfileSeconds {
+		return 0, fmt.Errorf("invalid seconds (%s; expected 1 to %d)", s, maxProfileSeconds)
+	}
+	return n, nil
+}
+
+const (
+	// defaultProfileSeconds is the duration of a /profile/cpu
+	// request which doesn't specify "seconds".
+	defaultProfileSeconds = 30
+	// maxProfileSeconds bounds the duration of a /profile/cpu request.
+	maxProfileSeconds = 600
+)
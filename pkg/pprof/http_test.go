@@ -0,0 +1,62 @@
+package pprof
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	runtimepprof "runtime/pprof"
+	"testing"
+
+	gc "github.com/go-check/check"
+)
+
+type HTTPSuite struct{}
+
+func (s *HTTPSuite) TestCPUProfile(c *gc.C) {
+	var handler = NewHandler()
+
+	var w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/profile/cpu?seconds=1", nil))
+
+	c.Check(w.Code, gc.Equals, http.StatusOK)
+	c.Check(w.Header().Get("Content-Type"), gc.Equals, "application/octet-stream")
+	c.Check(w.Body.Len(), gc.Not(gc.Equals), 0)
+}
+
+func (s *HTTPSuite) TestCPUProfileConflict(c *gc.C) {
+	var handler = NewHandler()
+
+	c.Assert(runtimepprof.StartCPUProfile(ioutil.Discard), gc.IsNil)
+	defer runtimepprof.StopCPUProfile()
+
+	var w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/profile/cpu?seconds=1", nil))
+	c.Check(w.Code, gc.Equals, http.StatusConflict)
+	c.Check(w.Header().Get("Content-Disposition"), gc.Equals, "")
+}
+
+func (s *HTTPSuite) TestSecondsParsing(c *gc.C) {
+	var handler = NewHandler()
+
+	for _, q := range []string{"0", "-1", "foo", "601"} {
+		var w = httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/profile/cpu?seconds="+q, nil))
+		c.Check(w.Code, gc.Equals, http.StatusBadRequest)
+	}
+
+	var n, err = parseSeconds("")
+	c.Check(err, gc.IsNil)
+	c.Check(n, gc.Equals, defaultProfileSeconds)
+}
+
+func (s *HTTPSuite) TestPProfIndex(c *gc.C) {
+	var w = httptest.NewRecorder()
+	NewHandler().ServeHTTP(w, httptest.NewRequest("GET", "/debug/pprof/", nil))
+
+	c.Check(w.Code, gc.Equals, http.StatusOK)
+	c.Check(w.Body.String(), gc.Matches, "(?s).*goroutine.*")
+}
+
+var _ = gc.Suite(&HTTPSuite{})
+
+func Test(t *testing.T) { gc.TestingT(t) }
@@ -0,0 +1,48 @@
+package pprof
+
+import (
+	"io"
+	"os"
+	runtimepprof "runtime/pprof"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// dump writes the heap, goroutine, block, and mutex profiles to |w|. Block
+// and mutex profiles are empty unless contention profiling is enabled.
+func dump(w io.Writer) {
+	runtimepprof.Lookup("heap").WriteTo(w, 1)
+	runtimepprof.Lookup("goroutine").WriteTo(w, 1)
+	runtimepprof.Lookup("block").WriteTo(w, 1)
+	runtimepprof.Lookup("mutex").WriteTo(w, 1)
+}
+
+// profileFile is the target file for CPU profiling.
+var profileFile *os.File
+
+// toggleProfiler starts and stops a long-running CPU profile using pprof. The
+// profile is written to a file of type "cpu" in the configured directory (see
+// Configure), by default /var/tmp/cpu_${PID}_${TIMESTAMP}.pprof where
+// TIMESTAMP represents the epoch time when the profiling session began.
+func toggleProfiler() {
+	if profileFile == nil {
+		var err error
+
+		profileFile, err = createProfileFile("cpu", time.Now())
+		if err != nil {
+			log.WithField("err", err).Error("could not begin CPU profiling")
+			profileFile = nil
+		} else if err = runtimepprof.StartCPUProfile(profileFile); err != nil {
+			// A CPU profile is already running (eg, of a /profile/cpu request).
+			log.WithField("err", err).Error("could not begin CPU profiling")
+			profileFile.Close()
+			os.Remove(profileFile.Name())
+			profileFile = nil
+		}
+	} else {
+		runtimepprof.StopCPUProfile()
+		profileFile.Close()
+		profileFile = nil
+	}
+}
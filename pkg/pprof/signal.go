@@ -0,0 +1,51 @@
+// Package pprof provides profiling of gazette programs, which may be triggered
+// by signals (see RegisterSignalHandlers) or served as HTTP endpoints (see
+// NewHandler and ListenAndServe), or captured continuously and uploaded to
+// cloud storage (see ContinuousProfiler).
+package pprof
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// RegisterSignalHandlers registers signal handlers for profiling.
+//
+// SIGQUIT
+//   Dump a one-time heap, goroutine, block, and mutex profile to stdout.
+//
+// SIGUSR1
+//   Start a long-running CPU profile using pprof. The profile is written to
+//   the directory and filename template set by Configure, by default
+//   /var/tmp/cpu_${PID}_${TIMESTAMP}.pprof where TIMESTAMP is the epoch
+//   time when the profiling session began. Sending SIGUSR1 again will stop the
+//   profiling and flush writes for the profile.
+//
+// SIGTTIN
+//   Toggle block and mutex (contention) profiling, at the rates set by
+//   Configure. See EnableContentionProfiling.
+//
+// SIGTTOU
+//   Start a runtime execution trace, written to the directory and filename
+//   template set by Configure with type "trace". Sending SIGTTOU again (or
+//   reaching the configured maximum trace duration) stops the trace. See
+//   StartTrace.
+func RegisterSignalHandlers() {
+	notifyChan := make(chan os.Signal, 1)
+	signal.Notify(notifyChan, syscall.SIGQUIT, syscall.SIGUSR1, syscall.SIGTTIN, syscall.SIGTTOU)
+	go func() {
+		for {
+			switch <-notifyChan {
+			case syscall.SIGQUIT:
+				dump(os.Stdout)
+			case syscall.SIGUSR1:
+				toggleProfiler()
+			case syscall.SIGTTIN:
+				toggleContentionProfiling()
+			case syscall.SIGTTOU:
+				toggleExecutionTrace()
+			}
+		}
+	}()
+}
@@ -0,0 +1,98 @@
+package pprof
+
+import (
+	"errors"
+	"os"
+	"runtime/trace"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// StartTrace begins capture of a runtime execution trace, as by package
+// runtime/trace, which is useful in diagnosing scheduler latency and GC pauses.
+// The trace is written to a file of type "trace" in the configured directory
+// (see Configure), the path of which is returned. Capture continues until
+// StopTrace is called or the configured maximum trace duration elapses.
+func StartTrace() (string, error) {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+
+	if traceFile != nil {
+		return "", errTraceRunning
+	}
+
+	configMu.Lock()
+	var maxDuration = currentConfig.maxTraceDuration
+	configMu.Unlock()
+
+	var f, err = createProfileFile("trace", time.Now())
+	if err != nil {
+		return "", err
+	} else if err = trace.Start(f); err != nil {
+		// A trace is already running (eg, of a /debug/pprof/trace request).
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	traceFile = f
+	traceTimer = time.AfterFunc(maxDuration, func() {
+		if err := stopTrace(f); err == nil {
+			log.WithField("path", f.Name()).Info("stopped execution trace at max duration")
+		}
+	})
+	return f.Name(), nil
+}
+
+// StopTrace stops a running execution trace started by StartTrace.
+func StopTrace() error { return stopTrace(nil) }
+
+// TraceRunning returns true if an execution trace started
+// by StartTrace is running.
+func TraceRunning() bool {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+
+	return traceFile != nil
+}
+
+// stopTrace stops the running execution trace. If |f| is non-nil, the trace
+// is stopped only if it's written to |f|.
+func stopTrace(f *os.File) error {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+
+	if traceFile == nil || (f != nil && f != traceFile) {
+		return errTraceNotRunning
+	}
+	trace.Stop()
+	traceTimer.Stop()
+
+	var err = traceFile.Close()
+	traceFile, traceTimer = nil, nil
+	return err
+}
+
+// toggleExecutionTrace starts an execution trace if one isn't
+// running, and stops the running trace otherwise.
+func toggleExecutionTrace() {
+	if TraceRunning() {
+		if err := StopTrace(); err != nil {
+			log.WithField("err", err).Error("could not stop execution trace")
+		}
+	} else if path, err := StartTrace(); err != nil {
+		log.WithField("err", err).Error("could not begin execution trace")
+	} else {
+		log.WithField("path", path).Info("began execution trace")
+	}
+}
+
+var (
+	traceFile  *os.File    // Target file of the running trace.
+	traceTimer *time.Timer // Stops the running trace at its max duration.
+	traceMu    sync.Mutex
+
+	errTraceRunning    = errors.New("execution trace is already running")
+	errTraceNotRunning = errors.New("execution trace is not running")
+)
@@ -0,0 +1,67 @@
+package pprof
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	gc "github.com/go-check/check"
+)
+
+type TraceSuite struct {
+	dir   string
+	saved config
+}
+
+func (s *TraceSuite) SetUpTest(c *gc.C) {
+	var err error
+	s.dir, err = ioutil.TempDir("", "pprof-trace")
+	c.Assert(err, gc.IsNil)
+	s.saved = currentConfig
+}
+
+func (s *TraceSuite) TearDownTest(c *gc.C) {
+	currentConfig = s.saved
+	c.Check(os.RemoveAll(s.dir), gc.IsNil)
+}
+
+func (s *TraceSuite) TestStartAndStop(c *gc.C) {
+	Configure(WithDirectory(s.dir), WithFilenameTemplate("{type}.out"))
+
+	var path, err = StartTrace()
+	c.Assert(err, gc.IsNil)
+	c.Check(path, gc.Equals, filepath.Join(s.dir, "trace.out"))
+	c.Check(TraceRunning(), gc.Equals, true)
+
+	_, err = StartTrace()
+	c.Check(err, gc.Equals, errTraceRunning)
+
+	c.Check(StopTrace(), gc.IsNil)
+	c.Check(TraceRunning(), gc.Equals, false)
+	c.Check(StopTrace(), gc.Equals, errTraceNotRunning)
+
+	info, err := os.Stat(path)
+	c.Assert(err, gc.IsNil)
+	c.Check(info.Size(), gc.Not(gc.Equals), int64(0))
+}
+
+func (s *TraceSuite) TestStoppedAtMaxDuration(c *gc.C) {
+	Configure(WithDirectory(s.dir), WithMaxTraceDuration(10*time.Millisecond))
+
+	toggleExecutionTrace()
+	c.Check(TraceRunning(), gc.Equals, true)
+
+	for deadline := time.Now().Add(5 * time.Second); TraceRunning(); {
+		c.Assert(time.Now().Before(deadline), gc.Equals, true)
+		time.Sleep(time.Millisecond)
+	}
+	// A subsequent toggle begins a new trace.
+	Configure(WithMaxTraceDuration(time.Hour))
+	toggleExecutionTrace()
+	c.Check(TraceRunning(), gc.Equals, true)
+	toggleExecutionTrace()
+	c.Check(TraceRunning(), gc.Equals, false)
+}
+
+var _ = gc.Suite(&TraceSuite{})
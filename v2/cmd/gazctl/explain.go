@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/olekukonko/tablewriter"
+)
+
+// ExplainConfig is common configuration of explain operations.
+type ExplainConfig struct {
+	Format string `long:"format" short:"o" choice:"table" choice:"json" default:"table" description:"Output format"`
+}
+
+// explainLong is the long description shared by explain operations.
+const explainLong = `
+Explain recent allocator changes of assignments.
+
+The allocator leader records an explanation of each assignment change it
+applies (an addition, removal, promotion, demotion, or packing of an
+assignment), along with the reason for the change. Explanations are retained
+in memory by the leader, for a bounded number of recent changes.
+
+Only the current allocator leader records explanations, and the service
+--address must resolve to the leader process to retrieve them. Other processes
+return explanations recorded while they were leader (if any), or none at all.
+`
+
+// fetchExplanations retrieves Explanations of |item| from the
+// "/debug/allocator/explain" handler of the process at |address|.
+func fetchExplanations(address pb.Endpoint, item string) []allocator.Explanation {
+	var u = address.URL()
+	u.Path = "/debug/allocator/explain"
+	if item != "" {
+		u.RawQuery = url.Values{"item": {item}}.Encode()
+	}
+
+	var resp, err = http.Get(u.String())
+	mbp.Must(err, "failed to fetch explanations", "url", u.String())
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		mbp.Must(fmt.Errorf("unexpected status %s", resp.Status), "failed to fetch explanations", "url", u.String())
+	}
+	var out []allocator.Explanation
+	mbp.Must(json.NewDecoder(resp.Body).Decode(&out), "failed to decode explanations")
+
+	return out
+}
+
+func (cfg ExplainConfig) output(explanations []allocator.Explanation) {
+	switch cfg.Format {
+	case "table":
+		var table = tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Time", "Revision", "Item", "Action", "Member", "Slot", "Reason"})
+
+		for _, e := range explanations {
+			table.Append([]string{
+				e.Time.Format(time.RFC3339),
+				fmt.Sprintf("%d", e.Revision),
+				e.ItemID,
+				e.Action,
+				e.MemberZone + "/" + e.MemberSuffix,
+				fmt.Sprintf("%d", e.Slot),
+				e.Reason,
+			})
+		}
+		table.Render()
+	case "json":
+		mbp.Must(json.NewEncoder(os.Stdout).Encode(explanations), "failed to encode to json")
+	}
+}
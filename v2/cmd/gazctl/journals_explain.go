@@ -0,0 +1,20 @@
+package main
+
+type cmdJournalsExplain struct {
+	ExplainConfig
+	Journal string `long:"journal" description:"Journal to explain. If empty, recent changes of all journals are shown"`
+}
+
+func init() {
+	_ = mustAddCmd(cmdJournals, "explain", "Explain recent allocator changes of journal assignments", explainLong+`
+Show recent changes of a journal:
+>    gazctl journals explain --journal my/journal
+`, &cmdJournalsExplain{})
+}
+
+func (cmd *cmdJournalsExplain) Execute([]string) error {
+	startup()
+
+	cmd.output(fetchExplanations(journalsCfg.Broker.Address, cmd.Journal))
+	return nil
+}
@@ -35,6 +35,9 @@ Match JournalSpecs having an exact name:
 Match JournalSpecs having a name prefix (must end in '/'):
 >    --selector "prefix = my/prefix/"
 
+Match JournalSpecs having a name which fully matches a regular expression:
+>    --selector "name =~ my/prefix/part-00[0-9]"
+
 Results can be output in a variety of --format options:
 yaml:  Prints a YAML journal hierarchy, compatible with "journals apply"
 json:  Prints JournalSpecs encoded as JSON
@@ -144,6 +147,7 @@ func listJournals(s string) *pb.ListResponse {
 
 	resp, err := client.ListAllJournals(ctx, pb.NewJournalClient(journalsCfg.Broker.Dial(ctx)), req)
 	mbp.Must(err, "failed to list journals")
+	warnOnVersionSkew(resp.Header)
 
 	return resp
 }
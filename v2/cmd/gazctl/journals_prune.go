@@ -36,7 +36,7 @@ func (cmd *cmdJournalsPrune) Execute([]string) error {
 	var m = journalsPruneMetrics{journalsTotal: len(resp.Journals)}
 	var now = time.Now()
 	for _, j := range resp.Journals {
-		for _, f := range fetchAgedFragments(j.Spec, now, &m) {
+		forEachAgedFragment(j.Spec, now, &m, func(f pb.Fragment) {
 			log.WithFields(log.Fields{
 				"journal": f.Journal,
 				"name":    f.ContentName(),
@@ -50,7 +50,7 @@ func (cmd *cmdJournalsPrune) Execute([]string) error {
 			}
 			m.fragmentsPruned++
 			m.bytesPruned += int(f.End - f.Begin)
-		}
+		})
 		m.journalsPruned++
 		logJournalsPruneMetrics(m, j.Spec.Name, "pruned journal")
 	}
@@ -93,35 +93,38 @@ func logJournalsPruneMetrics(metrics journalsPruneMetrics, journal pb.Journal, m
 	log.WithFields(f).Info(message)
 }
 
-// fetchAgedFragments returns fragments of the journal that are older than the
-// configured retention.
-func fetchAgedFragments(spec pb.JournalSpec, now time.Time, metrics *journalsPruneMetrics) []pb.Fragment {
+// forEachAgedFragment invokes |fn| with each fragment of the journal that is
+// older than the configured retention. Fragments are listed and pruned a page
+// at a time, as journals may have very many fragments.
+func forEachAgedFragment(spec pb.JournalSpec, now time.Time, metrics *journalsPruneMetrics, fn func(pb.Fragment)) {
 	var ctx = context.Background()
 	var jc = journalsCfg.Broker.RoutedJournalClient(ctx)
-	resp, err := client.ListAllFragments(ctx, jc, pb.FragmentsRequest{Journal: spec.Name})
-	mbp.Must(err, "failed to fetch fragments")
-
 	var retention = spec.Fragment.Retention
-
-	var aged = make([]pb.Fragment, 0)
-	for _, f := range resp.Fragments {
-		var spec = f.Spec
-		metrics.fragmentsTotal++
-		metrics.bytesTotal += int(spec.End - spec.Begin)
-		if spec.BackingStore == "" {
-			continue
-		}
-		var age = now.Sub(time.Unix(spec.ModTime, 0))
-		if age >= retention {
-			aged = append(aged, spec)
-		}
-	}
+	var total, aged int
+
+	var err = client.ListFragmentPages(ctx, jc, pb.FragmentsRequest{Journal: spec.Name},
+		func(resp *pb.FragmentsResponse) error {
+			for _, f := range resp.Fragments {
+				var spec = f.Spec
+				total++
+				metrics.fragmentsTotal++
+				metrics.bytesTotal += int(spec.End - spec.Begin)
+				if spec.BackingStore == "" {
+					continue
+				}
+				var age = now.Sub(time.Unix(spec.ModTime, 0))
+				if age >= retention {
+					aged++
+					fn(spec)
+				}
+			}
+			return nil
+		})
+	mbp.Must(err, "failed to fetch fragments")
 
 	log.WithFields(log.Fields{
 		"journal": spec.Name,
-		"total":   len(resp.Fragments),
-		"aged":    len(aged),
+		"total":   total,
+		"aged":    aged,
 	}).Info("fetched aged fragments")
-
-	return aged
 }
@@ -18,6 +18,7 @@ type cmdJournalRead struct {
 	Selector string `long:"selector" short:"l" required:"true" description:"Label Selector query to filter on"`
 	Blocking bool   `long:"blocking" short:"b" description:"Stream contents to Stdout as they are written to the selected journals"`
 	Offset   int64  `long:"offset" short:"o" default:"-1" description:"Offset to beging reading from journal"`
+	Codec    string `long:"content-codec" description:"Compression codec (GZIP, SNAPPY, or ZSTANDARD) of content sent by brokers"`
 }
 
 func init() {
@@ -39,6 +40,10 @@ it will be sent to Stdout.
 
 To read from an arbitrary offset into a journal(s) use the --offset flag.
 If not passed the default value is -1 which will read from the head of the journal.
+
+When reading highly-compressible journals across a WAN, use --content-codec to
+have brokers compress content before sending it:
+>    --content-codec SNAPPY
 `, &cmdJournalRead{})
 }
 
@@ -49,6 +54,14 @@ func (cmd *cmdJournalRead) Execute([]string) error {
 	var ctx = context.Background()
 	var brokerClient = journalsCfg.Broker.RoutedJournalClient(ctx)
 
+	var codec pb.CompressionCodec
+	if cmd.Codec != "" {
+		if codec = pb.CompressionCodec(pb.CompressionCodec_value[cmd.Codec]); codec == pb.CompressionCodec_INVALID ||
+			codec == pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION {
+			log.WithField("codec", cmd.Codec).Fatal("invalid --content-codec")
+		}
+	}
+
 	// Get the list of journals which match this selector.
 	var listRequest pb.ListRequest
 	listRequest.Selector, err = pb.ParseLabelSelector(cmd.Selector)
@@ -69,6 +82,7 @@ func (cmd *cmdJournalRead) Execute([]string) error {
 			client:   brokerClient,
 			blocking: cmd.Blocking,
 			offset:   cmd.Offset,
+			codec:    codec,
 			writer:   writer,
 		})
 		doneCounter++
@@ -104,15 +118,17 @@ type readjournalOpts struct {
 	client   pb.RoutedJournalClient
 	blocking bool
 	offset   int64
+	codec    pb.CompressionCodec
 	writer   *lockedWriter
 	doneChan chan<- struct{}
 }
 
 func readJournal(opts readjournalOpts) {
 	var req = pb.ReadRequest{
-		Journal: opts.spec.Name,
-		Offset:  opts.offset,
-		Block:   opts.blocking,
+		Journal:      opts.spec.Name,
+		Offset:       opts.offset,
+		Block:        opts.blocking,
+		ContentCodec: opts.codec,
 	}
 	var reader = client.NewReader(opts.ctx, opts.client, req)
 	var bufferedReader = bufio.NewReader(reader)
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/broker"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+type cmdJournalsSimulate struct {
+	SimulateConfig
+	Etcd struct {
+		mbp.EtcdConfig
+		Prefix string `long:"prefix" env:"PREFIX" default:"/gazette/brokers" description:"Etcd base prefix for broker state and coordination"`
+	} `group:"Etcd" namespace:"etcd" env-namespace:"ETCD"`
+}
+
+func init() {
+	_ = mustAddCmd(cmdJournals, "simulate", "Simulate journal assignments under hypothetical broker changes", simulateLong+`
+Simulate the removal of a broker:
+>    gazctl journals simulate --remove us-east-1/broker-abc
+
+Simulate the addition of three brokers having a limit of 512 journals:
+>    gazctl journals simulate --add 3 --add-zone us-east-1 --add-limit 512
+
+Simulate updated journal limits of brokers:
+>    gazctl journals simulate --item-limit us-east-1/broker-abc:128 --item-limit us-east-1/broker-def:0
+`, &cmdJournalsSimulate{})
+}
+
+func (cmd *cmdJournalsSimulate) Execute([]string) error {
+	startup()
+
+	cmd.simulate(broker.NewKeySpace(cmd.Etcd.Prefix), cmd.Etcd.MustDial(),
+		func(like allocator.MemberValue, id pb.ProcessSpec_ID, limit int) string {
+			var spec pb.BrokerSpec
+			if like != nil {
+				spec = *like.(*pb.BrokerSpec)
+			}
+			spec.Id, spec.JournalLimit = id, uint32(limit)
+			return spec.MarshalString()
+		})
+	return nil
+}
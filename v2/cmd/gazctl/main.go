@@ -8,6 +8,7 @@ import (
 	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
 	"github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/jessevdk/go-flags"
+	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
 
@@ -99,6 +100,20 @@ func startup() {
 	protocol.RegisterGRPCDispatcher(baseCfg.Zone)
 }
 
+// warnOnVersionSkew logs a warning if the Header was authored by a process
+// having a BuildVersion other than that of gazctl itself.
+func warnOnVersionSkew(hdr protocol.Header) {
+	if hdr.BuildVersion == protocol.BuildVersion {
+		return
+	}
+	log.WithFields(log.Fields{
+		"gazctl":       protocol.BuildVersion,
+		"process":      hdr.ProcessId,
+		"version":      hdr.BuildVersion,
+		"capabilities": hdr.Capabilities,
+	}).Warn("gazctl and server versions differ (an empty version pre-dates version reporting)")
+}
+
 func mustAddCmd(cmd *flags.Command, name, short, long string, cfg interface{}) *flags.Command {
 	cmd, err := cmd.AddCommand(name, short, long, cfg)
 	mbp.Must(err, "failed to add command")
@@ -0,0 +1,20 @@
+package main
+
+type cmdShardsExplain struct {
+	ExplainConfig
+	Shard string `long:"shard" description:"Shard to explain. If empty, recent changes of all shards are shown"`
+}
+
+func init() {
+	_ = mustAddCmd(cmdShards, "explain", "Explain recent allocator changes of shard assignments", explainLong+`
+Show recent changes of a shard:
+>    gazctl shards explain --shard my-shard
+`, &cmdShardsExplain{})
+}
+
+func (cmd *cmdShardsExplain) Execute([]string) error {
+	startup()
+
+	cmd.output(fetchExplanations(shardsCfg.Consumer.Address, cmd.Shard))
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	log "github.com/sirupsen/logrus"
+)
+
+type cmdShardsExport struct {
+	Shard    string `long:"shard" required:"true" description:"ID of the shard to export"`
+	Snapshot string `long:"snapshot" required:"true" description:"Recovery log journal into which the snapshot is recorded"`
+	Output   string `long:"output" short:"o" default:"-" description:"Path to which snapshot hints are written. Provide a dash (-) to use stdout."`
+	Etcd     struct {
+		mbp.EtcdConfig
+		Prefix string `long:"prefix" env:"PREFIX" required:"true" description:"Etcd prefix for consumer state and coordination (eg, /gazette/consumers/myApplication)"`
+	} `group:"Etcd" namespace:"etcd" env-namespace:"ETCD"`
+}
+
+func init() {
+	_ = mustAddCmd(cmdShards, "export", "Export a snapshot of a shard's store", `
+Export the current state of a shard's store into a snapshot recovery log, and
+write hints of the snapshot as JSON. The shard is not disturbed: its recovery
+log is played back through its current write head, and recovered files are
+recorded into the snapshot log.
+
+The snapshot log must already exist, and is typically bound to a fragment
+store in which snapshots are retained (eg, a bucket of backups). The snapshot
+may be imported into a shard of this or another consumer deployment with
+"gazctl shards import", so long as its brokers serve the snapshot log (eg,
+from the same fragment store).
+
+Export shard "my-shard" into snapshot log "snapshots/my-shard/2019-06-01":
+>    gazctl shards export --etcd.prefix /gazette/consumers/my-app --shard my-shard \
+>        --snapshot snapshots/my-shard/2019-06-01 --output my-shard.hints.json
+`, &cmdShardsExport{})
+}
+
+func (cmd *cmdShardsExport) Execute([]string) error {
+	startup()
+	var ctx = context.Background()
+
+	var ajc = client.NewAppendService(ctx, shardsCfg.Broker.RoutedJournalClient(ctx))
+	var hints, err = consumer.ExportSnapshot(ctx, cmd.Etcd.MustDial(), consumer.NewKeySpace(cmd.Etcd.Prefix),
+		ajc, consumer.ShardID(cmd.Shard), pb.Journal(cmd.Snapshot))
+	mbp.Must(err, "failed to export shard")
+
+	var out = os.Stdout
+	if cmd.Output != "-" {
+		out, err = os.Create(cmd.Output)
+		mbp.Must(err, "failed to create output file")
+	}
+	mbp.Must(json.NewEncoder(out).Encode(hints), "failed to write snapshot hints")
+	mbp.Must(out.Close(), "failed to close output")
+
+	log.WithFields(log.Fields{"shard": cmd.Shard, "snapshot": cmd.Snapshot}).Info("exported shard")
+	return nil
+}
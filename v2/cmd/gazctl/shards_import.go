@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	log "github.com/sirupsen/logrus"
+)
+
+type cmdShardsImport struct {
+	Shard string `long:"shard" required:"true" description:"ID of the shard into which the snapshot is imported"`
+	Hints string `long:"hints" required:"true" description:"Path to snapshot hints written by \"shards export\". Provide a dash (-) to use stdin."`
+	Etcd  struct {
+		mbp.EtcdConfig
+		Prefix string `long:"prefix" env:"PREFIX" required:"true" description:"Etcd prefix for consumer state and coordination (eg, /gazette/consumers/myApplication)"`
+	} `group:"Etcd" namespace:"etcd" env-namespace:"ETCD"`
+}
+
+func init() {
+	_ = mustAddCmd(cmdShards, "import", "Import a snapshot into a shard's store", `
+Import a snapshot written by "gazctl shards export" into a shard. The shard
+must exist and be disabled. Once the shard is enabled, it plays back the
+snapshot log and records its files into the shard's own recovery log,
+replacing the shard's current state. It then resumes its journals from the
+offsets of the snapshot.
+
+The snapshot log must be served by brokers of the shard's deployment. When
+importing into another deployment, first create a journal of the snapshot
+log's name which is bound to the fragment store holding the snapshot.
+
+Import a snapshot into disabled shard "my-shard":
+>    gazctl shards import --etcd.prefix /gazette/consumers/my-app --shard my-shard \
+>        --hints my-shard.hints.json
+`, &cmdShardsImport{})
+}
+
+func (cmd *cmdShardsImport) Execute([]string) error {
+	startup()
+
+	var b []byte
+	var err error
+
+	if cmd.Hints == "-" {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(cmd.Hints)
+	}
+	mbp.Must(err, "failed to read snapshot hints")
+
+	var hints recoverylog.FSMHints
+	mbp.Must(json.Unmarshal(b, &hints), "failed to decode snapshot hints")
+
+	mbp.Must(consumer.ImportSnapshot(context.Background(), cmd.Etcd.MustDial(),
+		consumer.NewKeySpace(cmd.Etcd.Prefix), consumer.ShardID(cmd.Shard), hints), "failed to import snapshot")
+
+	log.WithFields(log.Fields{"shard": cmd.Shard, "snapshot": hints.Log}).Info("imported snapshot")
+	return nil
+}
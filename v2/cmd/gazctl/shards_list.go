@@ -33,6 +33,9 @@ shards. Shard selectors support an additional meta-label "id".
 Match ShardSpecs having a specific ID:
 >    --selector "id in (shard-12, shard-34)"
 
+Match ShardSpecs having an ID which doesn't match a regular expression:
+>    --selector "id !~ shard-[0-9]+-test"
+
 Results can be output in a variety of --format options:
 yaml:  Prints shards in YAML form, compatible with "shards apply"
 json:  Prints ShardSpecs encoded as JSON
@@ -144,6 +147,7 @@ func listShards(s string) *consumer.ListResponse {
 
 	resp, err := consumer.ListShards(ctx, consumer.NewShardClient(shardsCfg.Consumer.Dial(ctx)), req)
 	mbp.Must(err, "failed to list shards")
+	warnOnVersionSkew(resp.Header)
 
 	return resp
 }
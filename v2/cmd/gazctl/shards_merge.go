@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	log "github.com/sirupsen/logrus"
+)
+
+type cmdShardsMerge struct {
+	Shard string `long:"shard" required:"true" description:"ID of the shard into which --from is merged"`
+	From  string `long:"from" required:"true" description:"ID of the shard to merge and remove"`
+	Etcd  struct {
+		mbp.EtcdConfig
+		Prefix string `long:"prefix" env:"PREFIX" required:"true" description:"Etcd prefix for consumer state and coordination (eg, /gazette/consumers/myApplication)"`
+	} `group:"Etcd" namespace:"etcd" env-namespace:"ETCD"`
+}
+
+func init() {
+	_ = mustAddCmd(cmdShards, "merge", "Merge a shard into the shard of its adjacent key range", `
+Merge a shard into another shard having the adjacent lower key range, and
+the same sources. The shard is extended to cover the key range of the merged
+shard, and the merged shard is removed. Upon its next recovery, the shard
+merges the state of the merged shard's recovery log into its own.
+
+The Application of the shards must be a KeyedApplication and a ShardMerger.
+Journal messages may be consumed more than once across the merge.
+
+Merge shard "my-shard-part-two" into shard "my-shard":
+>    gazctl shards merge --etcd.prefix /gazette/consumers/my-app --shard my-shard --from my-shard-part-two
+`, &cmdShardsMerge{})
+}
+
+func (cmd *cmdShardsMerge) Execute([]string) error {
+	startup()
+
+	mbp.Must(consumer.MergeShards(context.Background(), cmd.Etcd.MustDial(),
+		consumer.NewKeySpace(cmd.Etcd.Prefix),
+		consumer.ShardID(cmd.Shard), consumer.ShardID(cmd.From)), "failed to merge shards")
+
+	log.WithFields(log.Fields{"shard": cmd.Shard, "from": cmd.From}).Info("merged shards")
+	return nil
+}
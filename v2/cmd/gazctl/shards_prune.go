@@ -23,9 +23,11 @@ This includes all prior writes of client keys & values, and also RocksDB
 compactions, which can significantly inflate the total volume of writes
 relative to the data currently represented in a RocksDB.
 
-Prune log examines the provided hints to identify Fragments of the log
-which have no intersection with any live files of the DB, and can thus
-be safely deleted.
+Prune log examines the current primary and backup hints of each shard to
+identify Fragments of the log which have no intersection with any live files
+of the DB under any of those hints, and can thus be safely deleted. Shards
+having a replica which is currently backfilling are skipped, as it may be
+playing back from hints which are no longer current.
 `, &cmdShardsPrune{})
 }
 
@@ -36,47 +38,41 @@ func (cmd *cmdShardsPrune) Execute([]string) error {
 	var m = shardsPruneMetrics{}
 	for _, shard := range listShards(cmd.Selector).Shards {
 		m.shardsTotal++
-		var lastHints = fetchLastHints(ctx, shard.Spec.Id)
-		if lastHints == nil {
-			log.Infof("skipping shard %s, there are no backup hints for this shard", shard.Spec.Id)
+
+		if isBackfilling(shard) {
+			log.Infof("skipping shard %s, a replica of this shard is backfilling", shard.Spec.Id)
 			continue
 		}
-
-		var _, segments, err = lastHints.LiveLogSegments()
-		if err != nil {
-			mbp.Must(err, "unable to fetch hint segments")
+		var hints = fetchAllHints(ctx, shard.Spec.Id)
+		if len(hints) == 0 {
+			log.Infof("skipping shard %s, there are no hints for this shard", shard.Spec.Id)
+			continue
 		}
 
-		// Zero the LastOffset of the final hinted Segment. This has the effect of implicitly
-		// intersecting with all fragments having offsets greater than its FirstOffset.
-		// We want this behavior because playback will continue to read offsets & Fragments
-		// after reading past the final hinted Segment.
-		segments[len(segments)-1].LastOffset = 0
-
-		for _, f := range fetchFragments(ctx, lastHints.Log) {
-			var spec = f.Spec
-
+		var fragments []pb.Fragment
+		forEachFragment(ctx, hints[0].Log, func(f pb.FragmentsResponse__Fragment) {
 			m.fragmentsTotal++
-			m.bytesTotal += spec.ContentLength()
-
-			if len(segments.Intersect(spec.Begin, spec.End)) == 0 {
-				log.WithFields(log.Fields{
-					"log":  spec.Journal,
-					"name": spec.ContentName(),
-					"size": spec.ContentLength(),
-					"mod":  spec.ModTime,
-				}).Info("pruning fragment")
-
-				m.fragmentsPruned++
-				m.bytesPruned += spec.ContentLength()
-
-				if !cmd.DryRun {
-					err = fragment.Remove(ctx, spec)
-					if err != nil {
-						mbp.Must(err, "error removing fragment", "path", spec.ContentPath())
-					}
-
-				}
+			m.bytesTotal += f.Spec.ContentLength()
+			fragments = append(fragments, f.Spec)
+		})
+
+		var prunable, err = recoverylog.PrunableFragments(hints, fragments)
+		mbp.Must(err, "unable to determine prunable fragments", "shard", shard.Spec.Id)
+
+		for _, spec := range prunable {
+			log.WithFields(log.Fields{
+				"log":  spec.Journal,
+				"name": spec.ContentName(),
+				"size": spec.ContentLength(),
+				"mod":  spec.ModTime,
+			}).Info("pruning fragment")
+
+			m.fragmentsPruned++
+			m.bytesPruned += spec.ContentLength()
+
+			if !cmd.DryRun {
+				err = fragment.Remove(ctx, spec)
+				mbp.Must(err, "error removing fragment", "path", spec.ContentPath())
 			}
 		}
 		logShardsPruneMetrics(m, shard.Spec.Id.String(), "finished pruning log for shard")
@@ -85,7 +81,18 @@ func (cmd *cmdShardsPrune) Execute([]string) error {
 	return nil
 }
 
-func fetchLastHints(ctx context.Context, id consumer.ShardID) *recoverylog.FSMHints {
+// isBackfilling returns true if any replica of |shard| is in BACKFILL.
+func isBackfilling(shard consumer.ListResponse_Shard) bool {
+	for _, status := range shard.Status {
+		if status.Code == consumer.ReplicaStatus_BACKFILL {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchAllHints returns the primary and all backup hints of the shard.
+func fetchAllHints(ctx context.Context, id consumer.ShardID) []recoverylog.FSMHints {
 	var req = &consumer.GetHintsRequest{
 		Shard: id,
 	}
@@ -96,26 +103,33 @@ func fetchLastHints(ctx context.Context, id consumer.ShardID) *recoverylog.FSMHi
 		log.Panic("failed to fetch hints ", resp.Status.String())
 	}
 
-	for i := len(resp.BackupHints) - 1; i >= 0; i-- {
-		if resp.BackupHints[i].Hints != nil {
-			return resp.BackupHints[i].Hints
+	var out []recoverylog.FSMHints
+	if resp.PrimaryHints.Hints != nil {
+		out = append(out, *resp.PrimaryHints.Hints)
+	}
+	for _, bk := range resp.BackupHints {
+		if bk.Hints != nil {
+			out = append(out, *bk.Hints)
 		}
 	}
-
-	return nil
+	return out
 }
 
-func fetchFragments(ctx context.Context, journal pb.Journal) []pb.FragmentsResponse__Fragment {
-	var err error
+// forEachFragment invokes |fn| with each fragment of |journal|. Fragments
+// are listed a page at a time, and pages are not retained.
+func forEachFragment(ctx context.Context, journal pb.Journal, fn func(pb.FragmentsResponse__Fragment)) {
 	var req = pb.FragmentsRequest{
 		Journal: journal,
 	}
 	var brokerClient = journalsCfg.Broker.RoutedJournalClient(ctx)
 
-	resp, err := client.ListAllFragments(ctx, brokerClient, req)
+	var err = client.ListFragmentPages(ctx, brokerClient, req, func(resp *pb.FragmentsResponse) error {
+		for _, f := range resp.Fragments {
+			fn(f)
+		}
+		return nil
+	})
 	mbp.Must(err, "failed to fetch fragments")
-
-	return resp.Fragments
 }
 
 type shardsPruneMetrics struct {
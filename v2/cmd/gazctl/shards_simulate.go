@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+type cmdShardsSimulate struct {
+	SimulateConfig
+	Etcd struct {
+		mbp.EtcdConfig
+		Prefix string `long:"prefix" env:"PREFIX" required:"true" description:"Etcd prefix for consumer state and coordination (eg, /gazette/consumers/myApplication)"`
+	} `group:"Etcd" namespace:"etcd" env-namespace:"ETCD"`
+}
+
+func init() {
+	_ = mustAddCmd(cmdShards, "simulate", "Simulate shard assignments under hypothetical consumer changes", simulateLong+`
+Simulate the removal of a consumer:
+>    gazctl shards simulate --etcd.prefix /gazette/consumers/my-app --remove us-east-1/consumer-abc
+
+Simulate the addition of two consumers having a limit of 64 shards:
+>    gazctl shards simulate --etcd.prefix /gazette/consumers/my-app --add 2 --add-zone us-east-1 --add-limit 64
+`, &cmdShardsSimulate{})
+}
+
+func (cmd *cmdShardsSimulate) Execute([]string) error {
+	startup()
+
+	cmd.simulate(consumer.NewKeySpace(cmd.Etcd.Prefix), cmd.Etcd.MustDial(),
+		func(like allocator.MemberValue, id pb.ProcessSpec_ID, limit int) string {
+			var spec consumer.ConsumerSpec
+			if like != nil {
+				spec = *like.(*consumer.ConsumerSpec)
+			}
+			spec.Id, spec.ShardLimit = id, uint32(limit)
+			return spec.MarshalString()
+		})
+	return nil
+}
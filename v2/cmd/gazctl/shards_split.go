@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	log "github.com/sirupsen/logrus"
+)
+
+type cmdShardsSplit struct {
+	Shard string `long:"shard" required:"true" description:"ID of the shard to split"`
+	Child string `long:"child" required:"true" description:"ID of the child shard to create"`
+	Etcd  struct {
+		mbp.EtcdConfig
+		Prefix string `long:"prefix" env:"PREFIX" required:"true" description:"Etcd prefix for consumer state and coordination (eg, /gazette/consumers/myApplication)"`
+	} `group:"Etcd" namespace:"etcd" env-namespace:"ETCD"`
+}
+
+func init() {
+	_ = mustAddCmd(cmdShards, "split", "Split the key range of a shard into a new child shard", `
+Split a shard at the midpoint of its key range. The shard retains the lower
+half of its range, and a child shard having the upper half is created. The
+child is otherwise a copy of the shard's specification, and recovers the
+shard's state and journal offsets from the shard's recovery log.
+
+The shard is disabled while it's split, and is re-enabled once the child has
+recovered its state. The recovery log of the child must already exist. The
+Application of the shard must be a KeyedApplication.
+
+Split shard "my-shard" into a new shard "my-shard-part-two":
+>    gazctl shards split --etcd.prefix /gazette/consumers/my-app --shard my-shard --child my-shard-part-two
+`, &cmdShardsSplit{})
+}
+
+func (cmd *cmdShardsSplit) Execute([]string) error {
+	startup()
+
+	mbp.Must(consumer.SplitShard(context.Background(), cmd.Etcd.MustDial(),
+		consumer.NewKeySpace(cmd.Etcd.Prefix),
+		consumer.ShardID(cmd.Shard), consumer.ShardID(cmd.Child)), "failed to split shard")
+
+	log.WithFields(log.Fields{"shard": cmd.Shard, "child": cmd.Child}).Info("split shard")
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+)
+
+// SimulateConfig is common configuration of simulate operations.
+type SimulateConfig struct {
+	Remove     []string       `long:"remove" description:"Member to remove, as zone/suffix. May be repeated"`
+	Add        int            `long:"add" default:"0" description:"Number of members to add"`
+	AddZone    string         `long:"add-zone" default:"local" description:"Zone of added members"`
+	AddLimit   int            `long:"add-limit" default:"0" description:"Item limit of added members. If zero, the item limit of the first current member is used"`
+	ItemLimits map[string]int `long:"item-limit" description:"Updated item limit of a member, as zone/suffix:limit. May be repeated"`
+	Stickiness float64        `long:"primary-stickiness" default:"0" description:"Primary stickiness of the simulated allocator (see --primary-stickiness of the service)"`
+	Format     string         `long:"format" short:"o" choice:"table" choice:"json" default:"table" description:"Output format"`
+}
+
+// simulateLong is the long description shared by simulate operations.
+const simulateLong = `
+Simulate the allocation of assignments under hypothetical member changes.
+
+Simulate loads the current allocator state from Etcd, applies hypothetical
+changes to its members (removing members, adding new members, or updating
+member item limits), and solves for the resulting allocation exactly as the
+allocator leader would. It then prints the assignments which would be added
+or removed. Simulate makes no changes to Etcd.
+
+Added members are modeled on the first current member (including its labels),
+and are named "simulated-000", "simulated-001", etc.
+
+The allocator converges incrementally towards the simulated allocation, subject
+to replication guarantees and configured churn limits. Printed changes are the
+eventual (and not immediate) changes of the allocation.
+`
+
+// memberSpecFn returns the encoded MemberValue of a Member having |id| and
+// item |limit|, modeled on the MemberValue |like| (which may be nil).
+type memberSpecFn func(like allocator.MemberValue, id pb.ProcessSpec_ID, limit int) string
+
+// simulatedChange is an Assignment addition or removal of a simulation.
+type simulatedChange struct {
+	Action       string `json:"action"`
+	ItemID       string `json:"item"`
+	MemberZone   string `json:"zone"`
+	MemberSuffix string `json:"member"`
+}
+
+// simulate loads the KeySpace |ks| from Etcd, applies the hypothetical changes
+// of the SimulateConfig, and outputs resulting Assignment changes.
+func (cfg SimulateConfig) simulate(ks *keyspace.KeySpace, etcd *clientv3.Client, specFn memberSpecFn) {
+	var state = allocator.NewObservedState(ks, "")
+	mbp.Must(ks.Load(context.Background(), etcd, 0), "failed to load KeySpace")
+
+	ks.Mu.RLock()
+	var wr = cfg.buildEvents(ks, state, specFn)
+	ks.Mu.RUnlock()
+
+	if len(wr.Events) != 0 {
+		mbp.Must(ks.Apply(wr), "failed to apply simulated changes")
+	}
+
+	ks.Mu.RLock()
+	defer ks.Mu.RUnlock()
+
+	var desired = allocator.Simulate(state, allocator.ChurnLimits{PrimaryStickiness: cfg.Stickiness})
+	var added, removed = allocator.DiffAssignments(state.Assignments, desired)
+
+	log.WithFields(log.Fields{
+		"members":               len(state.Members),
+		"items":                 len(state.Items),
+		"added":                 len(added),
+		"removed":               len(removed),
+		"unattainable_replicas": state.ItemSlots - len(desired),
+	}).Info("simulated allocation")
+
+	var changes = make([]simulatedChange, 0, len(added)+len(removed))
+	for _, a := range removed {
+		changes = append(changes, simulatedChange{"remove", a.ItemID, a.MemberZone, a.MemberSuffix})
+	}
+	for _, a := range added {
+		changes = append(changes, simulatedChange{"add", a.ItemID, a.MemberZone, a.MemberSuffix})
+	}
+	cfg.output(changes)
+}
+
+// buildEvents returns a WatchResponse of Events which effect the hypothetical
+// changes of the SimulateConfig. The KeySpace must already be locked.
+func (cfg SimulateConfig) buildEvents(ks *keyspace.KeySpace, state *allocator.State, specFn memberSpecFn) clientv3.WatchResponse {
+	var wr = clientv3.WatchResponse{Header: ks.Header}
+	wr.Header.Revision++
+
+	var put = func(key, value string) {
+		var kv = &mvccpb.KeyValue{
+			Key:            []byte(key),
+			Value:          []byte(value),
+			CreateRevision: wr.Header.Revision,
+			ModRevision:    wr.Header.Revision,
+			Version:        1,
+		}
+		if ind, ok := ks.KeyValues.Search(key); ok {
+			kv.CreateRevision = ks.KeyValues[ind].Raw.CreateRevision
+			kv.Version = ks.KeyValues[ind].Raw.Version + 1
+		}
+		wr.Events = append(wr.Events, &clientv3.Event{Type: clientv3.EventTypePut, Kv: kv})
+	}
+
+	for _, id := range cfg.Remove {
+		var member = mustLookupMember(ks, id)
+		wr.Events = append(wr.Events, &clientv3.Event{
+			Type: clientv3.EventTypeDelete,
+			Kv: &mvccpb.KeyValue{
+				Key:         []byte(allocator.MemberKey(ks, member.Zone, member.Suffix)),
+				ModRevision: wr.Header.Revision,
+			},
+		})
+	}
+	for id, limit := range cfg.ItemLimits {
+		var member = mustLookupMember(ks, id)
+		put(allocator.MemberKey(ks, member.Zone, member.Suffix), specFn(member.MemberValue,
+			pb.ProcessSpec_ID{Zone: member.Zone, Suffix: member.Suffix}, limit))
+	}
+
+	var like allocator.MemberValue
+	var limit = cfg.AddLimit
+
+	if len(state.Members) != 0 {
+		like = state.Members[0].Decoded.(allocator.Member).MemberValue
+	}
+	if limit == 0 && like != nil {
+		limit = like.ItemLimit()
+	}
+	for i := 0; i != cfg.Add; i++ {
+		var id = pb.ProcessSpec_ID{Zone: cfg.AddZone, Suffix: fmt.Sprintf("simulated-%03d", i)}
+		put(allocator.MemberKey(ks, id.Zone, id.Suffix), specFn(like, id, limit))
+	}
+	return wr
+}
+
+// mustLookupMember returns the Member identified by |id| as "zone/suffix".
+func mustLookupMember(ks *keyspace.KeySpace, id string) allocator.Member {
+	var parts = strings.SplitN(id, "/", 2)
+	if len(parts) != 2 {
+		mbp.Must(fmt.Errorf("expected zone/suffix"), "invalid member", "member", id)
+	}
+	var member, ok = allocator.LookupMember(ks, parts[0], parts[1])
+	if !ok {
+		mbp.Must(fmt.Errorf("not found"), "invalid member", "member", id)
+	}
+	return member
+}
+
+func (cfg SimulateConfig) output(changes []simulatedChange) {
+	switch cfg.Format {
+	case "table":
+		var table = tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Action", "Item", "Member"})
+
+		for _, ch := range changes {
+			table.Append([]string{ch.Action, ch.ItemID, ch.MemberZone + "/" + ch.MemberSuffix})
+		}
+		table.Render()
+	case "json":
+		mbp.Must(json.NewEncoder(os.Stdout).Encode(changes), "failed to encode to json")
+	}
+}
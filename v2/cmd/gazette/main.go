@@ -2,14 +2,18 @@ package main
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
 	"github.com/LiveRamp/gazette/v2/pkg/broker"
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	"github.com/LiveRamp/gazette/v2/pkg/http_gateway"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
 	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	"github.com/LiveRamp/gazette/v2/pkg/protocol"
@@ -20,15 +24,36 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-const iniFilename = "gazette.ini"
+const (
+	iniFilename = "gazette.ini"
+	// Number of allocator Explanations retained for "/debug/allocator/explain".
+	explainTraceSize = 1024
+)
 
 // Config is the top-level configuration object of a Gazette broker.
 var Config = new(struct {
 	Broker struct {
 		mbp.ServiceConfig
-		Limit uint32 `long:"limit" env:"LIMIT" default:"1024" description:"Maximum number of Journals the broker will allocate"`
+		mbp.AllocatorConfig
+		Limit  uint32 `long:"limit" env:"LIMIT" default:"1024" description:"Maximum number of Journals the broker will allocate"`
+		Weight uint32 `long:"weight" env:"WEIGHT" default:"1" description:"Relative capacity weight of the broker, by which Journals are balanced across brokers"`
+
+		AppendSuspendTimeout time.Duration `long:"append-suspend-timeout" env:"APPEND_SUSPEND_TIMEOUT" default:"1m" description:"Duration after which a suspended Append which hasn't been resumed is rolled back, releasing its Journal for other Appends"`
+
+		ReplicationOverrides []string `long:"replication-override" env:"REPLICATION_OVERRIDES" env-delim:";" description:"Minimum replication of Journals matching a label selector, as MinReplication:Selector (eg, 3:tier=critical). May be repeated. All brokers must use the same overrides"`
+
+		SHA256Prefixes []string `long:"sha256-journal-prefix" env:"SHA256_JOURNAL_PREFIXES" env-delim:";" description:"Name prefix of Journals whose new Fragments are summed and named using SHA256, rather than SHA1. May be repeated. All brokers must use the same prefixes"`
 	} `group:"Broker" namespace:"broker" env-namespace:"BROKER"`
 
+	Gateway struct {
+		CORSOrigins  []string `long:"cors-origin" env:"CORS_ORIGINS" env-delim:"," description:"Origin from which browsers may make cross-origin requests of the HTTP gateway, or '*' for any origin. May be repeated. If unset, cross-origin requests are not allowed"`
+		CORSMethods  []string `long:"cors-method" env:"CORS_METHODS" env-delim:"," description:"Method of allowed cross-origin requests. May be repeated. If unset, GET, HEAD, PUT, and POST are allowed"`
+		CORSHeaders  []string `long:"cors-header" env:"CORS_HEADERS" env-delim:"," description:"Header which may be sent with cross-origin requests, or '*' for any header. May be repeated"`
+		RateRequests int      `long:"rate-requests" env:"RATE_REQUESTS" default:"0" description:"Requests per second permitted of each client of the HTTP gateway (by remote IP, and also by API key if authenticated). Zero is unlimited"`
+		RateBytes    int      `long:"rate-bytes" env:"RATE_BYTES" default:"0" description:"Bytes per second of reads and appends permitted of each client of the HTTP gateway (by remote IP, and also by API key if authenticated). Zero is unlimited"`
+		AuthFile     string   `long:"auth-file" env:"AUTH_FILE" description:"Path to a YAML file of API keys and basic-auth users of the HTTP gateway, and their read and append permissions. If unset, the HTTP gateway does not authenticate requests"`
+	} `group:"Gateway" namespace:"gateway" env-namespace:"GATEWAY"`
+
 	Etcd struct {
 		mbp.EtcdConfig
 		Prefix string `long:"prefix" env:"PREFIX" default:"/gazette/brokers" description:"Etcd base prefix for broker state and coordination"`
@@ -46,8 +71,17 @@ func (serveBroker) Execute(args []string) error {
 
 	log.WithField("config", Config).Info("starting broker")
 	prometheus.MustRegister(metrics.GazetteBrokerCollectors()...)
+	prometheus.MustRegister(metrics.KeySpaceCollectors()...)
+	prometheus.MustRegister(metrics.HTTPGatewayCollectors()...)
 
-	var ks = broker.NewKeySpace(Config.Etcd.Prefix)
+	var overrides []protocol.ReplicationOverride
+	for _, o := range Config.Broker.ReplicationOverrides {
+		var override, err = protocol.ParseReplicationOverride(o)
+		mbp.Must(err, "invalid replication override", "override", o)
+		overrides = append(overrides, override)
+	}
+
+	var ks = broker.NewKeySpace(Config.Etcd.Prefix, overrides...)
 	var allocState = allocator.NewObservedState(ks, Config.Broker.MemberKey(ks))
 
 	var etcd = Config.Etcd.MustDial()
@@ -59,14 +93,49 @@ func (serveBroker) Execute(args []string) error {
 	var service = broker.NewService(allocState, lo, etcd)
 	var rjc = protocol.NewRoutedJournalClient(lo, service)
 
+	var gateway = http_gateway.NewGateway(rjc)
+	gateway.SetCORSPolicy(http_gateway.CORSPolicy{
+		AllowedOrigins: Config.Gateway.CORSOrigins,
+		AllowedMethods: Config.Gateway.CORSMethods,
+		AllowedHeaders: Config.Gateway.CORSHeaders,
+	})
+	if path := Config.Gateway.AuthFile; path != "" {
+		var b, err = ioutil.ReadFile(path)
+		mbp.Must(err, "reading gateway auth file", "path", path)
+		verifier, err := http_gateway.ParseStaticVerifier(b)
+		mbp.Must(err, "parsing gateway auth file", "path", path)
+		gateway.SetVerifier(verifier)
+	}
+	gateway.SetRateLimits(http_gateway.RateLimits{
+		RequestsPerSecond: Config.Gateway.RateRequests,
+		BytesPerSecond:    Config.Gateway.RateBytes,
+	})
+
 	protocol.RegisterJournalServer(srv.GRPCServer, service)
-	srv.HTTPMux.Handle("/", http_gateway.NewGateway(rjc))
+	srv.HTTPMux.Handle("/", gateway)
+	if Config.Diagnostics.KeySpaceHandler {
+		srv.HTTPMux.Handle("/debug/keyspace", keyspace.NewHTTPHandler(ks, nil))
+	}
+	var trace = allocator.NewExplainTrace(explainTraceSize)
+	srv.HTTPMux.Handle("/debug/allocator/explain", trace)
 
 	var tasks = task.NewGroup(context.Background())
 	srv.QueueTasks(tasks)
 
 	var persister = fragment.NewPersister(ks)
 	broker.SetSharedPersister(persister)
+	broker.SetAppendSuspendTimeout(Config.Broker.AppendSuspendTimeout)
+
+	if prefixes := Config.Broker.SHA256Prefixes; len(prefixes) != 0 {
+		fragment.SetSHA256Journals(func(journal protocol.Journal) bool {
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(journal.String(), prefix) {
+					return true
+				}
+			}
+			return false
+		})
+	}
 
 	tasks.Queue("persister.Serve", func() error {
 		persister.Serve()
@@ -80,13 +149,22 @@ func (serveBroker) Execute(args []string) error {
 		LeaseTTL: Config.Etcd.LeaseTTL,
 		SignalCh: signalCh,
 		Spec: &protocol.BrokerSpec{
-			JournalLimit: Config.Broker.Limit,
-			ProcessSpec:  Config.Broker.ProcessSpec(),
+			JournalLimit:   Config.Broker.Limit,
+			CapacityWeight: Config.Broker.Weight,
+			ProcessSpec:    Config.Broker.ProcessSpec(),
 		},
-		State: allocState,
-		Tasks: tasks,
+		State:       allocState,
+		Tasks:       tasks,
+		ChurnLimits: Config.Broker.ChurnLimits(),
+		Trace:       trace,
 	}), "starting allocator session")
 
+	if interval := Config.Diagnostics.KeySpaceVerifyInterval; interval > 0 {
+		tasks.Queue("ks.VerifyPeriodically", func() error {
+			ks.VerifyPeriodically(tasks.Context(), etcd, interval)
+			return nil
+		})
+	}
 	tasks.Queue("service.Watch", func() error {
 		var err = service.Watch(tasks.Context())
 		// At Watch return, we're assured that all journal replicas have been
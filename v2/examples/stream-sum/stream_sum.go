@@ -154,6 +154,10 @@ func (Summer) InitApplication(args runconsumer.InitArgs) error { return nil }
 func (Summer) NewStore(shard consumer.Shard, dir string, rec *recoverylog.Recorder) (consumer.Store, error) {
 	var rdb = consumer.NewRocksDBStore(rec, dir)
 	rdb.Cache = make(map[StreamID]Sum)
+
+	if err := rdb.Configure(shard.Spec()); err != nil {
+		return nil, err
+	}
 	return rdb, rdb.Open()
 }
 
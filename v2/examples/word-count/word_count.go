@@ -89,6 +89,10 @@ func (counter *Counter) InitApplication(args runconsumer.InitArgs) error {
 func (Counter) NewStore(shard consumer.Shard, dir string, rec *recoverylog.Recorder) (consumer.Store, error) {
 	var rdb = consumer.NewRocksDBStore(rec, dir)
 	rdb.Cache = make(map[NGram]uint64)
+
+	if err := rdb.Configure(shard.Spec()); err != nil {
+		return nil, err
+	}
 	return rdb, rdb.Open()
 }
 
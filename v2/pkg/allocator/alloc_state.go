@@ -3,6 +3,7 @@ package allocator
 import (
 	"hash/crc64"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -25,10 +26,22 @@ type State struct {
 	LocalMemberInd int         // Index of |LocalKey| within |Members|, or -1 if not found.
 	LocalItems     []LocalItem // Assignments of this instance.
 
-	Zones       []string // Sorted and unique Zones of |Members|.
-	ZoneSlots   []int    // Total number of item slots summed across all |Members| of each Zone.
-	ItemSlots   int      // Total desired replication slots summed across all |Items|.
-	NetworkHash uint64   // Content-sum which captures Items & Members, and their constraints.
+	Zones             []string // Sorted and unique Zones of |Members|.
+	ZoneSlots         []int    // Total number of item slots summed across all |Members| of each Zone.
+	ZoneBalancedSlots []int    // Balanced item slots summed across all |Members| of each Zone.
+	MaxMemberWeight   int      // Maximum ItemWeight across all |Members|.
+	ItemSlots         int      // Total desired replication slots summed across all |Items|.
+	NetworkHash       uint64   // Content-sum which captures Items & Members, and their constraints.
+
+	// Zones may be hierarchical: a Zone is a "/"-separated path of topology
+	// keys, ordered from the highest-level failure domain to the lowest (eg,
+	// "us-east/az-1/rack-7"). Each level of failure domains above Zones is
+	// represented by a level of |Domains|, where a domain of level L is a
+	// Zone prefix of L+1 topology keys (or the entire Zone, if shorter).
+	// Where no Zone is hierarchical, |Domains| is empty.
+	Domains     [][]string // Sorted and unique failure domains of each level.
+	DomainSlots [][]int    // Total item slots summed across all |Members| of each domain.
+	ZoneDomains [][]int    // Index of each Zone's failure domain within each level of |Domains|.
 
 	// Number of total Assignments, and primary Assignments by Member.
 	// These share cardinality with |Members|.
@@ -65,32 +78,49 @@ func (s *State) observe() {
 	s.LocalItems = s.LocalItems[:0]
 	s.Zones = s.Zones[:0]
 	s.ZoneSlots = s.ZoneSlots[:0]
+	s.ZoneBalancedSlots = s.ZoneBalancedSlots[:0]
+	s.MaxMemberWeight = 1
 	s.ItemSlots = 0
 	s.NetworkHash = 0
 	s.MemberTotalCount = make([]int, len(s.Members))
 	s.MemberPrimaryCount = make([]int, len(s.Members))
 
+	for i := range s.Members {
+		if w := memberWeight(memberAt(s.Members, i).MemberValue); w > s.MaxMemberWeight {
+			s.MaxMemberWeight = w
+		}
+	}
 	// Walk Members to:
 	//  * Group the set of ordered |Zones| across all Members.
-	//  * Initialize |ZoneSlots|.
+	//  * Initialize |ZoneSlots| and |ZoneBalancedSlots|.
 	//  * Initialize |NetworkHash|.
 	for i := range s.Members {
 		var m = memberAt(s.Members, i)
-		var slots = m.ItemLimit()
+		var slots = s.memberItemLimit(i)
 		var zone = len(s.Zones) - 1
 
 		if len(s.Zones) == 0 || s.Zones[zone] < m.Zone {
 			s.Zones = append(s.Zones, m.Zone)
 			s.ZoneSlots = append(s.ZoneSlots, 0)
+			s.ZoneBalancedSlots = append(s.ZoneBalancedSlots, 0)
 			zone++
 		} else if s.Zones[zone] > m.Zone {
 			panic("invalid Member order")
 		}
 
 		s.ZoneSlots[zone] += slots
+		s.ZoneBalancedSlots[zone] += s.memberBalancedLimit(i)
 		s.NetworkHash = foldCRC(s.NetworkHash, s.Members[i].Raw.Key, slots)
+
+		// Fold only non-default weights, such that the NetworkHash of an
+		// unweighted network is unchanged by their introduction.
+		if w := memberWeight(m.MemberValue); w != 1 {
+			s.NetworkHash = foldCRC(s.NetworkHash, s.Members[i].Raw.Key, w)
+		}
 	}
 
+	s.extractDomains()
+
 	// Fetch |localMember| identified by |LocalKey|.
 	if ind, found := s.Members.Search(s.LocalKey); !found {
 		s.LocalMemberInd = -1
@@ -117,6 +147,25 @@ func (s *State) observe() {
 		s.ItemSlots += slots
 		s.NetworkHash = foldCRC(s.NetworkHash, s.Items[cur.Left].Raw.Key, slots)
 
+		// Fold only explicit zone constraints, such that the NetworkHash of an
+		// unconstrained network is unchanged by their introduction.
+		if zc, ok := item.ItemValue.(ZoneConstrainedItemValue); ok {
+			if minZones, maxPerZone := zc.ZoneConstraints(); minZones != 0 || maxPerZone != 0 {
+				s.NetworkHash = foldCRC(s.NetworkHash, s.Items[cur.Left].Raw.Key, minZones)
+				s.NetworkHash = foldCRC(s.NetworkHash, s.Items[cur.Left].Raw.Key, maxPerZone)
+			}
+		}
+		// Fold Members on which a placed Item may not be assigned. This captures
+		// changes of both the Item's placement and of Member labels, while the
+		// NetworkHash of an Item which may be placed anywhere is unchanged.
+		if placed, ok := item.ItemValue.(PlacedItemValue); ok {
+			for m := range s.Members {
+				if !placed.IsPlaceable(memberAt(s.Members, m).MemberValue) {
+					s.NetworkHash = foldCRC(s.NetworkHash, s.Members[m].Raw.Key, -1)
+				}
+			}
+		}
+
 		for r := cur.RightBegin; r != cur.RightEnd; r++ {
 			var a = assignmentAt(s.Assignments, r)
 			var key = MemberKey(s.KS, a.MemberZone, a.MemberSuffix)
@@ -138,9 +187,46 @@ func (s *State) observe() {
 	}
 }
 
+// extractDomains initializes |Domains|, |DomainSlots|, and |ZoneDomains|
+// from hierarchical |Zones|.
+func (s *State) extractDomains() {
+	var levels int
+	for _, zone := range s.Zones {
+		if n := strings.Count(zone, domainSeparator); n > levels {
+			levels = n
+		}
+	}
+	s.Domains = make([][]string, levels)
+	s.DomainSlots = make([][]int, levels)
+	s.ZoneDomains = make([][]int, len(s.Zones))
+
+	for level := 0; level != levels; level++ {
+		var index = make(map[string]int)
+		for _, zone := range s.Zones {
+			var d = zoneDomain(zone, level)
+			if _, ok := index[d]; !ok {
+				index[d] = 0
+				s.Domains[level] = append(s.Domains[level], d)
+			}
+		}
+		sort.Strings(s.Domains[level])
+
+		for ind, d := range s.Domains[level] {
+			index[d] = ind
+		}
+		s.DomainSlots[level] = make([]int, len(s.Domains[level]))
+
+		for zone := range s.Zones {
+			var ind = index[zoneDomain(s.Zones[zone], level)]
+			s.ZoneDomains[zone] = append(s.ZoneDomains[zone], ind)
+			s.DomainSlots[level][ind] += s.ZoneSlots[zone]
+		}
+	}
+}
+
 // shouldExit returns true iff the local Member is able to safely exit.
 func (s *State) shouldExit() bool {
-	return memberAt(s.Members, s.LocalMemberInd).ItemLimit() == 0 && len(s.LocalItems) == 0
+	return s.memberItemLimit(s.LocalMemberInd) == 0 && len(s.LocalItems) == 0
 }
 
 // isLeader returns true iff the local Member key is ordered first on
@@ -169,6 +255,7 @@ func (s *State) debugLog() {
 		"LocalKey":       s.LocalKey,
 		"LocalMemberInd": s.LocalMemberInd,
 		"ZoneSlots":      s.ZoneSlots,
+		"MaxWeight":      s.MaxMemberWeight,
 		"Members":        len(s.Members),
 		"NetworkHash":    s.NetworkHash,
 		"Revision":       s.KS.Header.Revision,
@@ -179,17 +266,99 @@ func (s *State) debugLog() {
 // memberLoadRatio maps an |assignment| to a Member "load ratio". Given all
 // |Members| and their corresponding |counts| (1:1 with |Members|),
 // memberLoadRatio maps |assignment| to a Member and, if found, returns the
-// ratio of the Member's index in |counts| to the Member's ItemLimit. If the
-// Member is not found, infinity is returned.
+// ratio of the Member's index in |counts| to the Member's balanced limit. If
+// the Member is not found, or may not be assigned Items, infinity is returned.
 func (s *State) memberLoadRatio(assignment keyspace.KeyValue, counts []int) float32 {
 	var a = assignment.Decoded.(Assignment)
 
 	if ind, found := s.Members.Search(MemberKey(s.KS, a.MemberZone, a.MemberSuffix)); found {
-		return float32(counts[ind]) / float32(memberAt(s.Members, ind).ItemLimit())
+		if limit := s.memberBalancedLimit(ind); limit != 0 {
+			return float32(counts[ind]) / float32(limit)
+		}
 	}
 	return math.MaxFloat32
 }
 
+// memberItemLimit returns the effective ItemLimit of the Member at index |ind|,
+// which is zero if the Member is draining.
+func (s *State) memberItemLimit(ind int) int {
+	var m = memberAt(s.Members, ind)
+	if memberDraining(m.MemberValue) {
+		return 0
+	}
+	return m.ItemLimit()
+}
+
+// isDrainingPrimaries returns true if the Member of |assignment| is draining,
+// and yet holds primary Assignments.
+func (s *State) isDrainingPrimaries(assignment keyspace.KeyValue) bool {
+	var a = assignment.Decoded.(Assignment)
+
+	if ind, found := s.Members.Search(MemberKey(s.KS, a.MemberZone, a.MemberSuffix)); found {
+		return memberDraining(memberAt(s.Members, ind).MemberValue) && s.MemberPrimaryCount[ind] != 0
+	}
+	return false
+}
+
+// isDraining returns true if the Member of |assignment| is draining.
+func (s *State) isDraining(assignment keyspace.KeyValue) bool {
+	var a = assignment.Decoded.(Assignment)
+
+	if ind, found := s.Members.Search(MemberKey(s.KS, a.MemberZone, a.MemberSuffix)); found {
+		return memberDraining(memberAt(s.Members, ind).MemberValue)
+	}
+	return false
+}
+
+// memberBalancedLimit returns the ItemLimit of the Member at index |ind|,
+// scaled by the ratio of its capacity weight to |MaxMemberWeight| and rounded
+// up. Items are balanced across Members in proportion to their balanced
+// limits. Where all Members have equal weight, it's simply the ItemLimit.
+func (s *State) memberBalancedLimit(ind int) int {
+	var m = memberAt(s.Members, ind)
+	var scaled = s.memberItemLimit(ind) * memberWeight(m.MemberValue)
+
+	if scaled%s.MaxMemberWeight == 0 {
+		return scaled / s.MaxMemberWeight
+	}
+	return (scaled / s.MaxMemberWeight) + 1
+}
+
+// memberWeight returns the capacity weight of MemberValue |m|, which is one
+// unless |m| is a WeightedMemberValue having a positive ItemWeight.
+func memberWeight(m MemberValue) int {
+	if wm, ok := m.(WeightedMemberValue); ok && wm.ItemWeight() > 0 {
+		return wm.ItemWeight()
+	}
+	return 1
+}
+
+// memberDraining returns true if MemberValue |m| is a DrainingMemberValue
+// which IsDraining.
+func memberDraining(m MemberValue) bool {
+	if dm, ok := m.(DrainingMemberValue); ok {
+		return dm.IsDraining()
+	}
+	return false
+}
+
+// zoneDomain returns the failure domain of |zone| at |level|, which is its
+// prefix of |level|+1 topology keys, or |zone| itself if it has fewer keys.
+func zoneDomain(zone string, level int) string {
+	for i := 0; i != len(zone); i++ {
+		if zone[i] != domainSeparator[0] {
+			continue
+		} else if level == 0 {
+			return zone[:i]
+		}
+		level--
+	}
+	return zone
+}
+
+// domainSeparator separates the topology keys of a hierarchical Zone.
+const domainSeparator = "/"
+
 func foldCRC(crc uint64, key []byte, n int) uint64 {
 	var tmp [12]byte
 	crc = crc64.Update(crc, crcTable, key)
@@ -38,6 +38,8 @@ func (s *AllocStateSuite) TestExtractOverFixture(c *gc.C) {
 		// Expect ordered Zones and slot counts were extracted.
 		c.Check(s.Zones, gc.DeepEquals, []string{"us-east", "us-west"})
 		c.Check(s.ZoneSlots, gc.DeepEquals, []int{3, 3})
+		c.Check(s.ZoneBalancedSlots, gc.DeepEquals, []int{3, 3})
+		c.Check(s.MaxMemberWeight, gc.Equals, 1)
 		c.Check(s.ItemSlots, gc.Equals, 3)
 		c.Check(s.NetworkHash, gc.Equals, uint64(0x175a17d95541fa12))
 
@@ -127,6 +129,76 @@ func (s *AllocStateSuite) TestExitCondition(c *gc.C) {
 	c.Check(states[0].NetworkHash, gc.Equals, uint64(0xfce0237931d8c200))
 }
 
+func (s *AllocStateSuite) TestNetworkHashCapturesZoneConstraints(c *gc.C) {
+	var client, ctx = etcdtest.TestClient(), context.Background()
+	buildAllocKeySpaceFixture(c, ctx, client)
+	defer etcdtest.Cleanup()
+
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "us-east", "foo"))
+	c.Check(ks.Load(ctx, client, 0), gc.IsNil)
+
+	var hashes = []uint64{state.NetworkHash}
+
+	// Expect each change of an Item's zone constraints alters the NetworkHash.
+	for _, v := range []string{
+		`{"R": 2, "Z": 2}`,
+		`{"R": 2, "Z": 2, "M": 1}`,
+		`{"R": 2, "M": 1}`,
+		`{"R": 2}`,
+	} {
+		var resp, err = client.Put(ctx, "/root/items/item-1", v)
+		c.Assert(err, gc.IsNil)
+		c.Assert(ks.Load(ctx, client, resp.Header.Revision), gc.IsNil)
+
+		hashes = append(hashes, state.NetworkHash)
+	}
+	c.Check(hashes[1], gc.Not(gc.Equals), hashes[0])
+	c.Check(hashes[2], gc.Not(gc.Equals), hashes[1])
+	c.Check(hashes[3], gc.Not(gc.Equals), hashes[2])
+	// Removing constraints restores the original NetworkHash.
+	c.Check(hashes[4], gc.Equals, hashes[0])
+}
+
+func (s *AllocStateSuite) TestNetworkHashCapturesPlacement(c *gc.C) {
+	var client, ctx = etcdtest.TestClient(), context.Background()
+	buildAllocKeySpaceFixture(c, ctx, client)
+	defer etcdtest.Cleanup()
+
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "us-east", "foo"))
+	c.Check(ks.Load(ctx, client, 0), gc.IsNil)
+
+	var hashes = []uint64{state.NetworkHash}
+
+	// Expect each change of Item placement, or of the Member labels
+	// (here, pools) it's evaluated against, alters the NetworkHash.
+	for _, kv := range [][2]string{
+		{"/root/items/item-1", `{"R": 2, "P": "ssd"}`},
+		{"/root/members/us-east#bar", `{"R": 1, "P": "ssd"}`},
+		{"/root/members/us-west#baz", `{"R": 3, "P": "ssd"}`},
+		{"/root/items/item-1", `{"R": 2, "P": "hdd"}`},
+		{"/root/items/item-1", `{"R": 2}`},
+	} {
+		var resp, err = client.Put(ctx, kv[0], kv[1])
+		c.Assert(err, gc.IsNil)
+		c.Assert(ks.Load(ctx, client, resp.Header.Revision), gc.IsNil)
+
+		hashes = append(hashes, state.NetworkHash)
+	}
+	for i := 1; i != len(hashes); i++ {
+		c.Check(hashes[i], gc.Not(gc.Equals), hashes[i-1])
+	}
+	// Member pools are not hashed where no Item is placed by them.
+	_, err := client.Put(ctx, "/root/members/us-east#bar", `{"R": 1}`)
+	c.Assert(err, gc.IsNil)
+	resp, err := client.Put(ctx, "/root/members/us-west#baz", `{"R": 3}`)
+	c.Assert(err, gc.IsNil)
+	c.Assert(ks.Load(ctx, client, resp.Header.Revision), gc.IsNil)
+
+	c.Check(state.NetworkHash, gc.Equals, hashes[0])
+}
+
 func (s *AllocStateSuite) TestLoadRatio(c *gc.C) {
 	var client, ctx = etcdtest.TestClient(), context.Background()
 	buildAllocKeySpaceFixture(c, ctx, client)
@@ -151,4 +223,26 @@ func (s *AllocStateSuite) TestLoadRatio(c *gc.C) {
 	}
 }
 
+func (s *AllocStateSuite) TestExtractDomains(c *gc.C) {
+	var state = &State{
+		Zones:     []string{"east", "east/a/1", "east/a/2", "east/b/1", "west/a/1"},
+		ZoneSlots: []int{1, 2, 3, 4, 5},
+	}
+	state.extractDomains()
+
+	c.Check(state.Domains, gc.DeepEquals, [][]string{
+		{"east", "west"},
+		{"east", "east/a", "east/b", "west/a"},
+	})
+	c.Check(state.DomainSlots, gc.DeepEquals, [][]int{{10, 5}, {1, 5, 4, 5}})
+	c.Check(state.ZoneDomains, gc.DeepEquals, [][]int{
+		{0, 0}, {0, 1}, {0, 1}, {0, 2}, {1, 3},
+	})
+
+	// Expect non-hierarchical Zones have no Domains.
+	state = &State{Zones: []string{"east", "west"}, ZoneSlots: []int{1, 2}}
+	state.extractDomains()
+	c.Check(state.Domains, gc.HasLen, 0)
+}
+
 var _ = gc.Suite(&AllocStateSuite{})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator/push_relabel"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
@@ -20,7 +21,14 @@ type AllocateArgs struct {
 	Etcd *clientv3.Client
 	// Allocator state, which is derived from a Watched KeySpace.
 	State *State
+	// ChurnLimits applied by Allocate while it's leader.
+	ChurnLimits ChurnLimits
+	// Trace is an optional ExplainTrace, to which Allocate records Explanations
+	// of the Assignment changes it applies while it's leader.
+	Trace *ExplainTrace
 	// TestHook is an optional testing hook, invoked after each convergence round.
+	// |isIdle| is true only if the round applied no changes and wasn't bounded
+	// by ChurnLimits, such that the allocation has converged.
 	TestHook func(round int, isIdle bool)
 }
 
@@ -39,7 +47,7 @@ type AllocateArgs struct {
 func Allocate(args AllocateArgs) error {
 	// flowNetwork is local to a single pass of the scheduler, but we retain a
 	// single instance and re-use it each iteration to reduce allocation.
-	var fn = new(flowNetwork)
+	var fn = &flowNetwork{stickiness: args.ChurnLimits.PrimaryStickiness}
 	// The leader runs push/relabel to re-compute a |desired| network only when
 	// the State |NetworkHash| changes. Otherwise, it incrementally converges
 	// towards the previous solution, which is still a valid maximum assignment.
@@ -47,6 +55,10 @@ func Allocate(args AllocateArgs) error {
 	// small instabilities in the prioritized push/relabel solution.
 	var desired []Assignment
 	var lastNetworkHash uint64
+	var churn = newChurnLimiter(args.ChurnLimits)
+	// Statistics of the last converge pass. Also retained across iterations
+	// to reduce allocation.
+	var stats convergeStats
 
 	var state = args.State
 	var ks = args.State.KS
@@ -66,9 +78,24 @@ func Allocate(args AllocateArgs) error {
 		// Response of the last transaction we applied. We'll ensure we've minimally
 		// watched through its revision before driving further action.
 		var txnResponse *clientv3.TxnResponse
-
-		if state.isLeader() {
-
+		// Time at which we should wake to iterate again, even if the KeySpace
+		// hasn't changed. If zero, we await only a KeySpace change.
+		var wake time.Time
+		var now = time.Now()
+
+		churn.observe(state.Members, now)
+
+		if !state.isLeader() {
+			// Pass.
+		} else if until := churn.pausedUntil(); !until.IsZero() {
+			log.WithField("until", until).Info("awaiting the return of departed members")
+			metrics.AllocatorChurnLimitedTotal.WithLabelValues("departure").Inc()
+			wake = until
+		} else if budget, end := churn.budget(now); budget <= 0 {
+			log.WithField("until", end).Info("allocation changes are rate-limited")
+			metrics.AllocatorChurnLimitedTotal.WithLabelValues("rate").Inc()
+			wake = end
+		} else {
 			// Do we need to re-solve for a maximum assignment?
 			if state.NetworkHash != lastNetworkHash {
 				log.WithFields(log.Fields{"last_hash": lastNetworkHash, "next_hash": state.NetworkHash}).
@@ -76,6 +103,8 @@ func Allocate(args AllocateArgs) error {
 
 				lastNetworkHash = state.NetworkHash
 
+				var solveStart = time.Now()
+
 				// Build a prioritized flowNetwork and solve for maximum flow.
 				fn.init(state)
 				push_relabel.FindMaxFlow(&fn.source, &fn.sink)
@@ -85,6 +114,7 @@ func Allocate(args AllocateArgs) error {
 				for item := range state.Items {
 					desired = extractItemFlow(state, fn, item, desired)
 				}
+				metrics.AllocatorSolveSeconds.Observe(time.Since(solveStart).Seconds())
 
 				if len(desired) < state.ItemSlots {
 					// We cannot assign each Item to the desired number of replicas. Most likely,
@@ -96,14 +126,23 @@ func Allocate(args AllocateArgs) error {
 
 			// Use batched transactions to amortize the network cost of Etcd updates,
 			// and re-verify our Member key with each flush to ensure we're still leader.
-			var txn = newBatchedTxn(ctx, args.Etcd,
+			var batched = newBatchedTxn(ctx, args.Etcd,
 				modRevisionUnchanged(state.Members[state.LocalMemberInd]))
+			// Bound the applied changes to the remaining |budget|.
+			var txn = &limitedTxn{checkpointTxn: batched, budget: budget}
 
 			// Converge the current state towards |desired|.
+			stats = convergeStats{explained: stats.explained[:0]}
+
 			var err error
-			if err = converge(txn, state, desired); err == nil {
+			if err = converge(txn, state, desired, &stats); err == nil {
 				txnResponse, err = txn.Commit()
 			}
+			churn.changes += txn.applied
+
+			if txn.limited {
+				wake = end
+			}
 
 			if err != nil {
 				log.WithFields(log.Fields{"err": err, "round": round, "rev": ks.Header.Revision}).
@@ -116,9 +155,13 @@ func Allocate(args AllocateArgs) error {
 				metrics.AllocatorMembers.Set(float64(len(state.Members)))
 				metrics.AllocatorItems.Set(float64(len(state.Items)))
 				metrics.AllocatorDesiredReplicationSlots.Set(float64(state.ItemSlots))
+				metrics.AllocatorItemsOutOfReplication.Set(float64(stats.outOfReplication))
+				metrics.AllocatorPendingChanges.Set(float64(stats.pendingChanges))
+
+				args.Trace.record(time.Now(), ks.Header.Revision, stats.explained)
 
 				if args.TestHook != nil {
-					args.TestHook(round, txn.noop)
+					args.TestHook(round, batched.noop && !txn.limited)
 				}
 				round++
 			}
@@ -131,18 +174,39 @@ func Allocate(args AllocateArgs) error {
 		if txnResponse != nil && txnResponse.Header.Revision > next {
 			next = txnResponse.Header.Revision
 		}
-		if err := ks.WaitForRevision(ctx, next); err != nil {
+		var waitCtx, cancel = ctx, context.CancelFunc(func() {})
+		if !wake.IsZero() {
+			waitCtx, cancel = context.WithDeadline(ctx, wake)
+		}
+		var err = ks.WaitForRevision(waitCtx, next)
+		cancel()
+
+		if err == context.DeadlineExceeded && ctx.Err() == nil {
+			// Pass. We woke to iterate again.
+		} else if err != nil {
 			return err
 		}
 	}
 }
 
+// convergeStats are statistics of a converge pass.
+type convergeStats struct {
+	// Number of Items having more or fewer current Assignments than their
+	// desired replication.
+	outOfReplication int
+	// Number of Assignment additions and removals required to reach the
+	// desired state, including those which are not yet allowed.
+	pendingChanges int
+	// Explanations of applied changes.
+	explained []Explanation
+}
+
 // converge identifies and applies allowed incremental changes which bring the
 // current state closer to the |desired| state. A change is allowed iff it does
 // not cause any Item or Member replication constraints to be violated (eg, by
 // leaving an Item with too few consistent replicas, or a Member with too many
-// assigned Items).
-func converge(txn checkpointTxn, as *State, desired []Assignment) error {
+// assigned Items). Statistics of the pass are accumulated into |stats|.
+func converge(txn checkpointTxn, as *State, desired []Assignment, stats *convergeStats) error {
 	var itemState = itemState{global: as}
 	var lastCRE int // cur.RightEnd of the previous iteration.
 
@@ -158,7 +222,7 @@ func converge(txn checkpointTxn, as *State, desired []Assignment) error {
 	for cur, ok := it.Next(); ok; cur, ok = it.Next() {
 		// Remove any Assignments skipped between the last cursor iteration, and this
 		// one. They must not have an associated Item (eg, it was deleted).
-		if err := removeDeadAssignments(txn, as.KS, as.Assignments[lastCRE:cur.RightBegin]); err != nil {
+		if err := removeDeadAssignments(txn, as.KS, as.Assignments[lastCRE:cur.RightBegin], stats); err != nil {
 			return err
 		}
 		lastCRE = cur.RightEnd
@@ -170,13 +234,22 @@ func converge(txn checkpointTxn, as *State, desired []Assignment) error {
 
 		// Initialize |itemState|, computing the delta of current and |desired| Item Assignments.
 		itemState.init(cur.Left, as.Assignments[cur.RightBegin:cur.RightEnd], desired[:limit])
+
+		if n := cur.RightEnd - cur.RightBegin; n != itemAt(as.Items, cur.Left).DesiredReplication() {
+			stats.outOfReplication++
+		}
+		stats.pendingChanges += len(itemState.add) + len(itemState.remove)
+
 		if err := itemState.constrainAndBuildOps(txn); err != nil {
 			return err
 		}
+		if checkpointApplied(txn) {
+			stats.explained = append(stats.explained, itemState.explain...)
+		}
 		desired = desired[limit:]
 	}
 	// Remove any trailing, dead Assignments.
-	if err := removeDeadAssignments(txn, as.KS, as.Assignments[lastCRE:]); err != nil {
+	if err := removeDeadAssignments(txn, as.KS, as.Assignments[lastCRE:], stats); err != nil {
 		return err
 	}
 
@@ -185,7 +258,7 @@ func converge(txn checkpointTxn, as *State, desired []Assignment) error {
 
 // removeDeadAssignments removes Assignments |asn|, after verifying each has no associated Item.
 // This is a sanity check that our removal hasn't raced a re-creation of the Item.
-func removeDeadAssignments(txn checkpointTxn, ks *keyspace.KeySpace, asn keyspace.KeyValues) error {
+func removeDeadAssignments(txn checkpointTxn, ks *keyspace.KeySpace, asn keyspace.KeyValues, stats *convergeStats) error {
 	for len(asn) != 0 {
 		var itemID, limit = assignmentAt(asn, 0).ItemID, 1
 		// Determine leading sub-slice of |asn| which are Assignments of |itemID|.
@@ -201,11 +274,28 @@ func removeDeadAssignments(txn checkpointTxn, ks *keyspace.KeySpace, asn keyspac
 		if err := txn.Checkpoint(); err != nil {
 			return err
 		}
+		stats.pendingChanges += limit
+
+		if checkpointApplied(txn) {
+			for i := 0; i != limit; i++ {
+				stats.explained = append(stats.explained,
+					explanation("remove", assignmentAt(asn, i), "item does not exist"))
+			}
+		}
 		asn = asn[limit:]
 	}
 	return nil
 }
 
+// checkpointApplied returns whether the last Checkpoint of |txn| was applied,
+// rather than discarded by a limitedTxn. As a limitedTxn discards all
+// checkpoints having operations once its budget is exhausted, it suffices to
+// check whether any checkpoint has been discarded.
+func checkpointApplied(txn checkpointTxn) bool {
+	var l, ok = txn.(*limitedTxn)
+	return !ok || !l.limited
+}
+
 // modRevisionUnchanged returns a Cmp which verifies the key has not changed
 // from the current KeyValue.
 func modRevisionUnchanged(kv keyspace.KeyValue) clientv3.Cmp {
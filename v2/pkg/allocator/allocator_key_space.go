@@ -35,6 +35,32 @@ type MemberValue interface {
 	ItemLimit() int
 }
 
+// WeightedMemberValue is an optional extension of MemberValue, which weights
+// the Member's capacity relative to other Members. Where there are more Member
+// slots than Item slots, the Allocator balances Items across Members in
+// proportion to the product of their ItemLimits and weights. A Member having
+// four times the weight of another (and equal ItemLimit) is thus assigned four
+// times the Items. ItemLimit remains a hard upper bound: a Member exceeds its
+// weighted share only if other Members lack the capacity to place all Items.
+type WeightedMemberValue interface {
+	MemberValue
+	// ItemWeight is the relative capacity weight of this Member. A weight of
+	// zero is treated as a weight of one.
+	ItemWeight() int
+}
+
+// DrainingMemberValue is an optional extension of MemberValue, which allows a
+// Member to signal that it's draining (eg, as part of a graceful shutdown). A
+// draining Member is assigned no further Items. Its primary Assignments are
+// first handed off to other consistent Assignments of their Items, and only
+// then are its remaining Assignments removed (as their Items are sufficiently
+// replicated elsewhere). The pace of draining is bounded by ChurnLimits.
+type DrainingMemberValue interface {
+	MemberValue
+	// IsDraining returns true if the Member is draining.
+	IsDraining() bool
+}
+
 // ItemValue is a user-defined Item representation which also supports required
 // APIs for use by Allocator.
 type ItemValue interface {
@@ -47,6 +73,32 @@ type ItemValue interface {
 	IsConsistent(assignment keyspace.KeyValue, allAssignments keyspace.KeyValues) bool
 }
 
+// ZoneConstrainedItemValue is an optional extension of ItemValue, which
+// constrains the placement of the Item's replicas across Member zones. The
+// Allocator will leave an Item under-replicated, rather than place its
+// replicas in violation of its constraints.
+type ZoneConstrainedItemValue interface {
+	ItemValue
+	// ZoneConstraints returns the minimum number of distinct zones across which
+	// replicas of the Item must be placed, and the maximum number of replicas
+	// which may be placed within any single zone. If |minZones| is zero, the
+	// Allocator's default applies: replicas are spread across at least two
+	// zones, if multiple zones have sufficient capacity. If |maxPerZone| is
+	// zero, the number of replicas per zone is not explicitly bounded.
+	ZoneConstraints() (minZones, maxPerZone int)
+}
+
+// PlacedItemValue is an optional extension of ItemValue, which restricts the
+// Members to which the Item may be assigned (eg, to pin an Item to a pool of
+// Members having particular hardware). The Allocator never assigns the Item to
+// a Member which isn't placeable, and removes current Assignments to such
+// Members. An Item having no placeable Members is left unassigned.
+type PlacedItemValue interface {
+	ItemValue
+	// IsPlaceable returns true if the Item may be assigned to |member|.
+	IsPlaceable(member MemberValue) bool
+}
+
 // AssignmentValue is a user-defined Assignment representation.
 type AssignmentValue interface{}
 
@@ -58,10 +110,32 @@ type Decoder interface {
 	DecodeAssignment(itemID, memberZone, memberSuffix string, slot int, raw *mvccpb.KeyValue) (AssignmentValue, error)
 }
 
+// ReplicationDecoder is an optional extension of Decoder, which determines the
+// effective DesiredReplication of each decoded Item. It allows for a policy
+// (eg, one applied across a cluster) to adjust the replication of Items
+// without altering their decoded ItemValues.
+type ReplicationDecoder interface {
+	Decoder
+	// ItemReplication returns the effective DesiredReplication of the Item.
+	ItemReplication(id string, value ItemValue) int
+}
+
 // Item composes an Item ID with its user-defined ItemValue.
 type Item struct {
 	ID string
 	ItemValue
+
+	// Effective replication of the Item, as determined by a ReplicationDecoder.
+	// If zero, the DesiredReplication of the ItemValue is used.
+	replication int
+}
+
+// DesiredReplication is the effective replication of the Item.
+func (i Item) DesiredReplication() int {
+	if i.replication != 0 {
+		return i.replication
+	}
+	return i.ItemValue.DesiredReplication()
 }
 
 // Member composes a Member Zone & Suffix with its user-defined MemberValue.
@@ -114,6 +188,8 @@ func NewAllocatorKeyValueDecoder(prefix string, decode Decoder) keyspace.KeyValu
 				return nil, fmt.Errorf("expected (id) in item key")
 			} else if value, err := decode.DecodeItem(p[0], raw); err != nil {
 				return nil, err
+			} else if rd, ok := decode.(ReplicationDecoder); ok {
+				return Item{ID: p[0], ItemValue: value, replication: rd.ItemReplication(p[0], value)}, nil
 			} else {
 				return Item{ID: p[0], ItemValue: value}, nil
 			}
@@ -187,6 +263,23 @@ func LookupItem(ks *keyspace.KeySpace, id string) (Item, bool) {
 	}
 }
 
+// HasPlaceableMember returns true if |item| may be placed on at least one
+// Member of the KeySpace. An ItemValue which isn't a PlacedItemValue may be
+// placed on any Member. The KeySpace must already be locked.
+func HasPlaceableMember(ks *keyspace.KeySpace, item ItemValue) bool {
+	var placed, ok = item.(PlacedItemValue)
+	if !ok {
+		return true
+	}
+	var members = ks.Prefixed(ks.Root + MembersPrefix)
+	for i := range members {
+		if placed.IsPlaceable(memberAt(members, i).MemberValue) {
+			return true
+		}
+	}
+	return false
+}
+
 func memberAt(kv keyspace.KeyValues, i int) Member         { return kv[i].Decoded.(Member) }
 func itemAt(kv keyspace.KeyValues, i int) Item             { return kv[i].Decoded.(Item) }
 func assignmentAt(kv keyspace.KeyValues, i int) Assignment { return kv[i].Decoded.(Assignment) }
@@ -346,18 +346,35 @@ func (d testAllocDecoder) DecodeAssignment(itemID, zone, suffix string, slot int
 	}
 }
 
-type testItem struct{ R int }
+type testItem struct {
+	R int    // Desired replication.
+	Z int    // Minimum zones.
+	M int    // Maximum replicas per zone.
+	P string `json:",omitempty"` // Pool of placeable Members.
+}
 
-func (i testItem) DesiredReplication() int { return i.R }
+func (i testItem) DesiredReplication() int                     { return i.R }
+func (i testItem) ZoneConstraints() (minZones, maxPerZone int) { return i.Z, i.M }
+func (i testItem) IsPlaceable(member MemberValue) bool {
+	return i.P == "" || i.P == member.(testMember).P
+}
 func (i testItem) IsConsistent(assignment keyspace.KeyValue, allAssignments keyspace.KeyValues) bool {
 	return assignment.Decoded.(Assignment).AssignmentValue.(testAssignment).consistent
 }
 
-type testMember struct{ R int }
+type testMember struct {
+	R int    // Item limit.
+	W int    `json:",omitempty"` // Capacity weight.
+	D bool   `json:",omitempty"` // Draining.
+	P string `json:",omitempty"` // Pool of the Member.
+}
 
-func (m testMember) ItemLimit() int  { return m.R }
-func (m testMember) Validate() error { return nil }
-func (m *testMember) ZeroLimit()     { m.R = 0 }
+func (m testMember) ItemLimit() int   { return m.R }
+func (m testMember) ItemWeight() int  { return m.W }
+func (m testMember) IsDraining() bool { return m.D }
+func (m testMember) Validate() error  { return nil }
+func (m *testMember) ZeroLimit()      { m.R = 0 }
+func (m *testMember) MarkDraining()   { m.D = true }
 
 func (m *testMember) MarshalString() string {
 	if b, err := json.Marshal(m); err != nil {
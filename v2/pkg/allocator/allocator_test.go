@@ -26,7 +26,7 @@ func (s *AllocatorSuite) TestRemoveDeadAssignments(c *gc.C) {
 	// Only "item-missing" is actually a dead Assignment in the fixture, but we can
 	// still pass all Assignments and verify Cmps and Ops of the resulting
 	// transaction (which wouldn't actually succeed, since Items exist).
-	c.Check(removeDeadAssignments(&txn, ks, assignments), gc.IsNil)
+	c.Check(removeDeadAssignments(&txn, ks, assignments, new(convergeStats)), gc.IsNil)
 
 	// Expect Assignments are grouped by Item. The non-existence of the Item is
 	// verified, as well as that each Assignment is unchanged.
@@ -66,13 +66,14 @@ func (s *AllocatorSuite) TestConvergeFixtureCases(c *gc.C) {
 
 	// Case 1: desired state matches current state, aside from fix-ups for missing Items / Members.
 	var txn mockTxnBuilder
+	var stats convergeStats
 	converge(&txn, as, []Assignment{
 		{ItemID: "item-1", MemberZone: "us-east", MemberSuffix: "foo"},
 		{ItemID: "item-1", MemberZone: "us-west", MemberSuffix: "baz"},
 
 		{ItemID: "item-two", MemberZone: "us-east", MemberSuffix: "bar"},
 		{ItemID: "item-two", MemberZone: "us-west", MemberSuffix: "baz"},
-	})
+	}, &stats)
 
 	var expectCmps = []clientv3.Cmp{
 		clientv3.Compare(clientv3.CreateRevision("/root/items/item-missing"), "=", 0),
@@ -85,6 +86,17 @@ func (s *AllocatorSuite) TestConvergeFixtureCases(c *gc.C) {
 		clientv3.OpDelete("/root/assign/item-missing#us-west#baz#0"),
 		clientv3.OpDelete("/root/assign/item-two#missing#member#2"),
 	})
+	// Expect applied removals were explained, and statistics were gathered.
+	c.Check(stats, gc.DeepEquals, convergeStats{
+		outOfReplication: 1, // item-two.
+		pendingChanges:   2,
+		explained: []Explanation{
+			{Action: "remove", ItemID: "item-missing", MemberZone: "us-west",
+				MemberSuffix: "baz", Slot: 0, Reason: "item does not exist"},
+			{Action: "remove", ItemID: "item-two", MemberZone: "missing",
+				MemberSuffix: "member", Slot: 2, Reason: "member does not exist"},
+		},
+	})
 
 	// Case 2: desire to flip "foo" and "bar". "bar" is at capacity, "foo" is not:
 	// expect an Assignment for "foo" (only) is created.
@@ -95,7 +107,7 @@ func (s *AllocatorSuite) TestConvergeFixtureCases(c *gc.C) {
 
 		{ItemID: "item-two", MemberZone: "us-east", MemberSuffix: "foo"},
 		{ItemID: "item-two", MemberZone: "us-west", MemberSuffix: "baz"},
-	})
+	}, new(convergeStats))
 
 	// In addition to the cleanup checks of the previous case,
 	// expect Member us-east/foo is also verified as unchanged.
@@ -82,6 +82,19 @@ type SessionArgs struct {
 	LeaseTTL time.Duration
 	SignalCh <-chan os.Signal
 	TestHook func(round int, isIdle bool)
+	// ChurnLimits of the Allocate loop.
+	ChurnLimits ChurnLimits
+	// Optional ExplainTrace of the Allocate loop.
+	Trace *ExplainTrace
+}
+
+// DrainableSpec is an optional extension of the SessionArgs Spec, which is
+// marked as draining (in addition to having its limit zeroed) upon a signal of
+// the SessionArgs SignalCh. The announced Member should then implement
+// DrainingMemberValue.
+type DrainableSpec interface {
+	// MarkDraining marks the Spec as draining.
+	MarkDraining()
 }
 
 // StartSession starts an allocator session. It:
@@ -91,7 +104,8 @@ type SessionArgs struct {
 // * Loads the KeySpace as-of the announcement revision.
 // * Queues tasks to the *task.Group which:
 //   - Closes the Etcd lease on task.Group cancellation.
-//   - Monitors SignalCh and zeros the MemberSpec ItemLimit on its signal.
+//   - Monitors SignalCh and zeros the MemberSpec ItemLimit on its signal
+//     (and marks the MemberSpec as draining, if supported).
 //   - Runs the Allocate loop, cancelling the *task.Group on completion.
 func StartSession(args SessionArgs) error {
 	if err := args.Spec.Validate(); err != nil {
@@ -126,8 +140,14 @@ func StartSession(args SessionArgs) error {
 
 		// Zero our advertised limit in Etcd. Upon seeing this, Allocator will
 		// work to discharge all of our assigned items, and Allocate will exit
-		// gracefully when none remain.
+		// gracefully when none remain. If the Spec also supports draining, the
+		// Allocator first hands off our primary assignments to peers, before
+		// removing any of our replicas, so that in-flight appends aren't
+		// interrupted. The zero limit is retained for peers which predate draining.
 		args.Spec.ZeroLimit()
+		if d, ok := args.Spec.(DrainableSpec); ok {
+			d.MarkDraining()
+		}
 		return ann.Update(args.Spec.MarshalString())
 	})
 
@@ -136,10 +156,12 @@ func StartSession(args SessionArgs) error {
 		defer args.Tasks.Cancel()
 
 		var err = Allocate(AllocateArgs{
-			Context:  args.Tasks.Context(),
-			Etcd:     args.Etcd,
-			State:    args.State,
-			TestHook: args.TestHook,
+			Context:     args.Tasks.Context(),
+			Etcd:        args.Etcd,
+			State:       args.State,
+			TestHook:    args.TestHook,
+			ChurnLimits: args.ChurnLimits,
+			Trace:       args.Trace,
 		})
 		if errors.Cause(err) == context.Canceled {
 			err = nil
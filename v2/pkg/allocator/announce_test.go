@@ -101,12 +101,13 @@ func (s *AnnounceSuite) TestBasicSessionStart(c *gc.C) {
 	})
 	args.Tasks.GoRun()
 
-	// By signaling, expect that our limit R is zero'd, Allocate exits and
-	// cancels the task.Group, and the lease is cancelled.
+	// By signaling, expect that our limit R is zero'd and we're marked as
+	// draining, Allocate exits and cancels the task.Group, and the lease is cancelled.
 	close(sigCh)
 
 	c.Check(args.Tasks.Wait(), gc.IsNil) // All tasks have exited.
 	c.Check(spec.R, gc.Equals, 0)        // Our limit was zero'd.
+	c.Check(spec.D, gc.Equals, true)     // We're draining.
 
 	leasesResp, err := etcd.Leases(context.Background())
 	c.Check(err, gc.IsNil)
@@ -0,0 +1,148 @@
+package allocator
+
+import (
+	"math"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+	"github.com/coreos/etcd/clientv3"
+)
+
+// ChurnLimits bound the rate at which Allocate changes Assignments, trading
+// speed of convergence for stability of the allocation. For example, a Member
+// which flaps (its lease lapses, and it then re-announces) would otherwise
+// cause an aggressive re-shuffle of Assignments, each of which must then
+// re-establish its replication pipeline. The zero-value imposes no limits.
+type ChurnLimits struct {
+	// MaxChanges is the maximum number of Assignment changes (creations and
+	// deletions) which are applied within each Interval. Zero is unlimited.
+	// Changes of an Item are applied together, and the changes of a single
+	// Item may exceed the limit.
+	MaxChanges int
+	// Interval over which MaxChanges is applied.
+	Interval time.Duration
+	// DepartureWindow is the duration for which Allocate awaits the return of
+	// a departed Member (eg, one whose lease lapsed) before reacting to its
+	// departure. All allocation is paused while any departure is within its
+	// window: no Assignments are created or removed, including those of other
+	// Items and Members. Allocation resumes when the window elapses or the
+	// Member returns, and nothing is restored to a returning Member which
+	// wasn't otherwise retained. Zero reacts to departures immediately.
+	DepartureWindow time.Duration
+	// PrimaryStickiness biases the allocation towards retaining current primary
+	// Assignments, which are costly to move (a new primary must warm up its
+	// state). It's the fraction by which a Member holding primaries may exceed
+	// its balanced share of Items, in order to retain them. Where a Member
+	// must shed Assignments, its replicas are shed before its primaries.
+	// Zero disables the bias.
+	PrimaryStickiness float64
+}
+
+// churnLimiter tracks Assignment changes and Member departures of Allocate,
+// in order to enforce ChurnLimits.
+type churnLimiter struct {
+	ChurnLimits
+
+	// Beginning of the current Interval, and changes applied within it.
+	intervalBegin time.Time
+	changes       int
+	// Member keys as-of the last observation.
+	members []string
+	// Departed Member keys, and the time at which each departure was observed.
+	departures map[string]time.Time
+}
+
+func newChurnLimiter(limits ChurnLimits) *churnLimiter {
+	return &churnLimiter{
+		ChurnLimits: limits,
+		departures:  make(map[string]time.Time),
+	}
+}
+
+// observe updates tracked departures from current |members|, as of |now|.
+// Departures of Members which have returned, or whose windows have elapsed,
+// are cleared.
+func (l *churnLimiter) observe(members keyspace.KeyValues, now time.Time) {
+	if l.DepartureWindow <= 0 {
+		return
+	}
+	for _, key := range l.members {
+		if _, found := members.Search(key); !found {
+			if _, ok := l.departures[key]; !ok {
+				l.departures[key] = now
+			}
+		}
+	}
+	for key, at := range l.departures {
+		if _, found := members.Search(key); found || now.Sub(at) >= l.DepartureWindow {
+			delete(l.departures, key)
+		}
+	}
+
+	l.members = l.members[:0]
+	for _, kv := range members {
+		l.members = append(l.members, string(kv.Raw.Key))
+	}
+}
+
+// pausedUntil returns the time at which the earliest pending departure window
+// elapses, or the zero Time if no departures are pending.
+func (l *churnLimiter) pausedUntil() time.Time {
+	var out time.Time
+	for _, at := range l.departures {
+		if t := at.Add(l.DepartureWindow); out.IsZero() || t.Before(out) {
+			out = t
+		}
+	}
+	return out
+}
+
+// budget returns the number of changes remaining within the current Interval
+// as of |now|, and the time at which the Interval ends. If changes are not
+// limited, budget returns math.MaxInt32 and the zero Time.
+func (l *churnLimiter) budget(now time.Time) (int, time.Time) {
+	if l.MaxChanges <= 0 || l.Interval <= 0 {
+		return math.MaxInt32, time.Time{}
+	}
+	if now.Sub(l.intervalBegin) >= l.Interval {
+		l.intervalBegin, l.changes = now, 0
+	}
+	return l.MaxChanges - l.changes, l.intervalBegin.Add(l.Interval)
+}
+
+// limitedTxn is a checkpointTxn which applies operations only until |budget|
+// operations have been applied, and thereafter discards checkpoints. As each
+// checkpoint is a valid, incremental change of the allocation, discarding
+// whole checkpoints leaves the allocation valid (though not yet converged).
+type limitedTxn struct {
+	checkpointTxn
+	budget  int
+	applied int  // Number of operations applied.
+	limited bool // Whether a checkpoint was discarded.
+
+	nextCmps []clientv3.Cmp
+	nextOps  []clientv3.Op
+}
+
+func (t *limitedTxn) If(c ...clientv3.Cmp) checkpointTxn {
+	t.nextCmps = append(t.nextCmps, c...)
+	return t
+}
+
+func (t *limitedTxn) Then(o ...clientv3.Op) checkpointTxn {
+	t.nextOps = append(t.nextOps, o...)
+	return t
+}
+
+func (t *limitedTxn) Checkpoint() error {
+	var nc, no = t.nextCmps, t.nextOps
+	t.nextCmps, t.nextOps = t.nextCmps[:0], t.nextOps[:0]
+
+	if len(no) != 0 && t.applied >= t.budget {
+		t.limited = true
+		return nil
+	}
+	t.applied += len(no)
+	t.checkpointTxn.If(nc...).Then(no...)
+	return t.checkpointTxn.Checkpoint()
+}
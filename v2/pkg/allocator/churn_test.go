@@ -0,0 +1,96 @@
+package allocator
+
+import (
+	"math"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	gc "github.com/go-check/check"
+)
+
+type ChurnSuite struct{}
+
+func (s *ChurnSuite) TestDepartureTracking(c *gc.C) {
+	var l = newChurnLimiter(ChurnLimits{DepartureWindow: time.Minute})
+	var t0 = time.Unix(1000, 0)
+
+	l.observe(memberKeysFixture("/a", "/b", "/c"), t0)
+	c.Check(l.pausedUntil().IsZero(), gc.Equals, true)
+
+	// "/b" and "/c" depart at different times.
+	l.observe(memberKeysFixture("/a", "/c"), t0.Add(time.Second))
+	l.observe(memberKeysFixture("/a"), t0.Add(2*time.Second))
+	c.Check(l.pausedUntil(), gc.Equals, t0.Add(time.Second+time.Minute))
+
+	// "/b" returns. Expect its departure is cleared.
+	l.observe(memberKeysFixture("/a", "/b"), t0.Add(3*time.Second))
+	c.Check(l.pausedUntil(), gc.Equals, t0.Add(2*time.Second+time.Minute))
+
+	// The departure window of "/c" elapses.
+	l.observe(memberKeysFixture("/a", "/b"), t0.Add(2*time.Second+time.Minute))
+	c.Check(l.pausedUntil().IsZero(), gc.Equals, true)
+
+	// Without a DepartureWindow, departures are not tracked.
+	l = newChurnLimiter(ChurnLimits{})
+	l.observe(memberKeysFixture("/a", "/b"), t0)
+	l.observe(memberKeysFixture("/a"), t0)
+	c.Check(l.pausedUntil().IsZero(), gc.Equals, true)
+}
+
+func (s *ChurnSuite) TestBudget(c *gc.C) {
+	var l = newChurnLimiter(ChurnLimits{MaxChanges: 10, Interval: time.Minute})
+	var t0 = time.Unix(1000, 0)
+
+	var budget, end = l.budget(t0)
+	c.Check(budget, gc.Equals, 10)
+	c.Check(end, gc.Equals, t0.Add(time.Minute))
+
+	l.changes += 7
+	budget, end = l.budget(t0.Add(time.Second))
+	c.Check(budget, gc.Equals, 3)
+	c.Check(end, gc.Equals, t0.Add(time.Minute))
+
+	// Expect the budget is reset with a new interval.
+	budget, end = l.budget(t0.Add(time.Minute))
+	c.Check(budget, gc.Equals, 10)
+	c.Check(end, gc.Equals, t0.Add(2*time.Minute))
+
+	// Changes are unlimited if either MaxChanges or Interval is zero.
+	budget, end = newChurnLimiter(ChurnLimits{Interval: time.Minute}).budget(t0)
+	c.Check(budget, gc.Equals, math.MaxInt32)
+	c.Check(end.IsZero(), gc.Equals, true)
+}
+
+func (s *ChurnSuite) TestLimitedTxn(c *gc.C) {
+	var cmp = clientv3.Compare(clientv3.Value("/key"), "=", "val")
+	var op1, op2, op3 = clientv3.OpDelete("/one"), clientv3.OpDelete("/two"), clientv3.OpDelete("/three")
+
+	var mock mockTxnBuilder
+	var txn = &limitedTxn{checkpointTxn: &mock, budget: 2}
+
+	// A checkpoint without operations is always applied.
+	c.Check(txn.If(cmp).Checkpoint(), gc.IsNil)
+	// The budget may be exceeded by a single checkpoint.
+	c.Check(txn.If(cmp).Then(op1, op2, op3).Checkpoint(), gc.IsNil)
+	c.Check(txn.limited, gc.Equals, false)
+
+	// Further checkpoints having operations are discarded.
+	c.Check(txn.If(cmp).Then(op1).Checkpoint(), gc.IsNil)
+	c.Check(txn.limited, gc.Equals, true)
+	c.Check(txn.applied, gc.Equals, 3)
+
+	c.Check(mock.cmps, gc.DeepEquals, []clientv3.Cmp{cmp, cmp})
+	c.Check(mock.ops, gc.DeepEquals, []clientv3.Op{op1, op2, op3})
+}
+
+func memberKeysFixture(keys ...string) keyspace.KeyValues {
+	var out keyspace.KeyValues
+	for _, k := range keys {
+		out = append(out, keyspace.KeyValue{Raw: mvccpb.KeyValue{Key: []byte(k)}})
+	}
+	return out
+}
+
+var _ = gc.Suite(&ChurnSuite{})
@@ -0,0 +1,117 @@
+package allocator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Explanation records an Assignment change applied by Allocate, and the
+// reason it was made.
+type Explanation struct {
+	// Time at which the change was committed.
+	Time time.Time `json:"time"`
+	// KeySpace Revision from which the change was decided.
+	Revision int64 `json:"revision"`
+	// Action taken, one of "add", "remove", "promote", "demote", or "pack".
+	Action string `json:"action"`
+	// Assignment which was changed. For "promote", "demote", and "pack"
+	// actions, Slot is the new Slot of the Assignment.
+	ItemID       string `json:"item"`
+	MemberZone   string `json:"zone"`
+	MemberSuffix string `json:"member"`
+	Slot         int    `json:"slot"`
+	// Reason for the change.
+	Reason string `json:"reason"`
+}
+
+// explanation returns an Explanation of |action| on Assignment |a|.
+func explanation(action string, a Assignment, reason string) Explanation {
+	return Explanation{
+		Action:       action,
+		ItemID:       a.ItemID,
+		MemberZone:   a.MemberZone,
+		MemberSuffix: a.MemberSuffix,
+		Slot:         a.Slot,
+		Reason:       reason,
+	}
+}
+
+// ExplainTrace is a bounded, in-memory trace of the most recent Explanations
+// recorded by Allocate. Only the current leader applies (and explains)
+// Assignment changes, and the ExplainTrace of other Members will be empty or
+// stale. An ExplainTrace is also an http.Handler, which serves its
+// Explanations as JSON, and is typically registered under
+// "/debug/allocator/explain". An "item" query parameter restricts
+// served Explanations to the Item.
+type ExplainTrace struct {
+	mu      sync.Mutex
+	entries []Explanation // Ring buffer of Explanations.
+	next    int           // Index of |entries| at which the next Explanation is written.
+	full    bool          // Whether |entries| has wrapped.
+}
+
+// NewExplainTrace returns an ExplainTrace which retains the |size|
+// most recent Explanations.
+func NewExplainTrace(size int) *ExplainTrace {
+	if size <= 0 {
+		panic("size must be > 0")
+	}
+	return &ExplainTrace{entries: make([]Explanation, size)}
+}
+
+// Explanations returns retained Explanations of |itemID|, ordered from oldest
+// to most recent. If |itemID| is empty, all retained Explanations are returned.
+func (t *ExplainTrace) Explanations(itemID string) []Explanation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var out = []Explanation{}
+	var ordered = t.entries[:t.next]
+	if t.full {
+		ordered = append(t.entries[t.next:len(t.entries):len(t.entries)], ordered...)
+	}
+	for _, e := range ordered {
+		if itemID == "" || e.ItemID == itemID {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// ServeHTTP implements http.Handler.
+func (t *ExplainTrace) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	var enc = json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(t.Explanations(r.URL.Query().Get("item"))); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// record adds |explained| to the ExplainTrace, as having been committed at
+// |now| and decided from KeySpace |revision|. A nil ExplainTrace is a no-op.
+func (t *ExplainTrace) record(now time.Time, revision int64, explained []Explanation) {
+	if t == nil || len(explained) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, e := range explained {
+		e.Time, e.Revision = now, revision
+		t.entries[t.next] = e
+
+		if t.next++; t.next == len(t.entries) {
+			t.next, t.full = 0, true
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package allocator
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"time"
+
+	gc "github.com/go-check/check"
+)
+
+type ExplainSuite struct{}
+
+func (s *ExplainSuite) TestTraceRetainsMostRecent(c *gc.C) {
+	var trace = NewExplainTrace(3)
+	var t0 = time.Unix(1000, 0)
+
+	c.Check(trace.Explanations(""), gc.DeepEquals, []Explanation{})
+
+	trace.record(t0, 10, []Explanation{
+		explanation("add", Assignment{ItemID: "one", MemberZone: "a", MemberSuffix: "m1", Slot: 1}, "reason 1"),
+		explanation("add", Assignment{ItemID: "two", MemberZone: "a", MemberSuffix: "m2"}, "reason 2"),
+	})
+	c.Check(trace.Explanations("one"), gc.DeepEquals, []Explanation{
+		{Time: t0, Revision: 10, Action: "add", ItemID: "one", MemberZone: "a",
+			MemberSuffix: "m1", Slot: 1, Reason: "reason 1"},
+	})
+
+	// Record Explanations which wrap the trace. Expect the oldest is dropped.
+	trace.record(t0.Add(time.Second), 11, []Explanation{
+		explanation("promote", Assignment{ItemID: "one", MemberZone: "a", MemberSuffix: "m1"}, "reason 3"),
+		explanation("remove", Assignment{ItemID: "two", MemberZone: "a", MemberSuffix: "m2"}, "reason 4"),
+	})
+	var reasons []string
+	for _, e := range trace.Explanations("") {
+		reasons = append(reasons, e.Reason)
+	}
+	c.Check(reasons, gc.DeepEquals, []string{"reason 2", "reason 3", "reason 4"})
+
+	// A nil ExplainTrace ignores records.
+	(*ExplainTrace)(nil).record(t0, 12, []Explanation{{}})
+}
+
+func (s *ExplainSuite) TestServeHTTP(c *gc.C) {
+	var trace = NewExplainTrace(10)
+	trace.record(time.Unix(1000, 0), 10, []Explanation{
+		explanation("add", Assignment{ItemID: "one", MemberZone: "a", MemberSuffix: "m1"}, "reason 1"),
+		explanation("add", Assignment{ItemID: "two", MemberZone: "a", MemberSuffix: "m2"}, "reason 2"),
+	})
+
+	var w = httptest.NewRecorder()
+	trace.ServeHTTP(w, httptest.NewRequest("GET", "/debug/allocator/explain?item=two", nil))
+	c.Check(w.Code, gc.Equals, 200)
+
+	var out []Explanation
+	c.Check(json.NewDecoder(w.Body).Decode(&out), gc.IsNil)
+	c.Assert(out, gc.HasLen, 1)
+	c.Check(out[0].ItemID, gc.Equals, "two")
+	c.Check(out[0].Revision, gc.Equals, int64(10))
+
+	w = httptest.NewRecorder()
+	trace.ServeHTTP(w, httptest.NewRequest("POST", "/debug/allocator/explain", nil))
+	c.Check(w.Code, gc.Equals, 405)
+}
+
+var _ = gc.Suite(&ExplainSuite{})
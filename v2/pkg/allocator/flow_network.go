@@ -49,26 +49,62 @@ import (
 //
 // TODO(johnny): Update this diagram to reflect the Overflow node (Issue #157).
 //
+// Where Zones are hierarchical (see State.Domains), additional levels of
+// "Domain Items" (an Item within the context of a higher-level failure
+// domain) are interposed between Items and Zone Items. Arcs from each Item or
+// Domain Item to its child domains (or Zones) are constrained such that
+// replicas are spread across the highest-level domains first.
+//
 type flowNetwork struct {
-	source    pr.Node
-	members   []pr.Node
-	items     []pr.Node
-	zoneItems []pr.Node
-	overflow  pr.Node
-	sink      pr.Node
+	source      pr.Node
+	members     []pr.Node
+	items       []pr.Node
+	domainItems [][]pr.Node // Domain Items of each level of State.Domains.
+	zoneItems   []pr.Node
+	overflow    pr.Node
+	sink        pr.Node
+
+	// Parent index (within the level above) of each domain of each level.
+	domainParents [][]int
+	// Number of effective child domains (or Zones) of each domain of each level.
+	domainChildren [][]int
+	// Number of effective domains of the first level.
+	rootChildren int
+	// Scratch space of buildItemArcs: item slots which may be placed, and
+	// number of current Assignments, of each domain of each level.
+	domainCaps [][]int
+	domainFlow [][]int
+
+	// Fraction by which a Member may exceed its scaled capacity in order to
+	// retain its current primary Assignments. See ChurnLimits.PrimaryStickiness.
+	// Unlike other fields, it's not reset by init.
+	stickiness float64
 }
 
 func (fn *flowNetwork) init(s *State) {
 	// Size Nodes and set labeled height. Push/Relabel initializes all Node labels
 	// to their distance from the Sink node, with the exception of the Source, which
 	// is initialized to the total number of nodes.
-	fn.items = pr.InitNodes(fn.items, len(s.Items), 3)
+	var levels = len(s.Domains)
+	var domainNodes int
+
+	for len(fn.domainItems) < levels {
+		fn.domainItems = append(fn.domainItems, nil)
+	}
+	fn.domainItems = fn.domainItems[:levels]
+
+	for level := range fn.domainItems {
+		fn.domainItems[level] = pr.InitNodes(fn.domainItems[level],
+			len(s.Items)*len(s.Domains[level]), levels-level+2)
+		domainNodes += len(fn.domainItems[level])
+	}
+	fn.items = pr.InitNodes(fn.items, len(s.Items), levels+3)
 	fn.zoneItems = pr.InitNodes(fn.zoneItems, len(s.Items)*len(s.Zones), 2)
 	fn.members = pr.InitNodes(fn.members, len(s.Members), 1)
 	fn.sink = pr.Node{Arcs: fn.sink.Arcs[:0], Height: 0}
 	fn.source = pr.Node{
 		Arcs:   fn.source.Arcs[:0],
-		Height: uint32(len(fn.items) + len(fn.zoneItems) + len(fn.members) + 3),
+		Height: uint32(len(fn.items) + domainNodes + len(fn.zoneItems) + len(fn.members) + 3),
 	}
 	// Initialize the Overflow node with a height which is _just_ small enough
 	// that flow is pushed through overflow arcs rather than all the way back to
@@ -77,7 +113,8 @@ func (fn *flowNetwork) init(s *State) {
 	//  * Flow may be pushed "down" an arc having a height gradient of exactly one.
 	//  * Thus in order to push to the Source, an Item node must have a label of V+1.
 	//  * The Overflow node is distance 3 from an Item node; V + 1 - 3 = V - 2.
-	fn.overflow = pr.Node{Arcs: fn.overflow.Arcs[:0], Height: fn.source.Height - 2}
+	//    Each level of Domain Items adds one to this distance.
+	fn.overflow = pr.Node{Arcs: fn.overflow.Arcs[:0], Height: fn.source.Height - 2 - uint32(levels)}
 	// Add effectively-infinite capacity from the Overflow node to Sink.
 	// Constrained Arcs to the overflow are then added from each member.
 	addArc(&fn.overflow, &fn.sink, math.MaxInt32, 0)
@@ -112,6 +149,10 @@ func (fn *flowNetwork) init(s *State) {
 		}
 	}
 
+	// Map failure domains to their parents, and count the effective children of
+	// each. As with Zones, a domain is effective if it can hold all Items, plus one.
+	fn.initDomains(s, len(s.Items))
+
 	if effectiveSlots <= s.ItemSlots {
 		log.WithFields(log.Fields{
 			"memberSlots": effectiveSlots,
@@ -146,7 +187,7 @@ func (fn *flowNetwork) init(s *State) {
 	}
 
 	// Determine scaling factors for each zone.
-	var zsfNum, zsfDenom = zoneScalingFactors(len(s.Items), s.ItemSlots, s.ZoneSlots)
+	var zsfNum, zsfDenom = zoneScalingFactors(len(s.Items), s.ItemSlots, s.ZoneBalancedSlots)
 
 	// Perform a left-join of |Members| with |Zones|. Add Arcs from each Member to sink.
 	it = LeftJoin{
@@ -160,9 +201,10 @@ func (fn *flowNetwork) init(s *State) {
 		var member = cur.Left
 		var zone = cur.RightBegin
 
-		// Calculate scaled member capacity using integer division, rounded up.
-		var limit = memberAt(s.Members, member).ItemLimit()
-		var scaled = limit * zsfNum[zone]
+		// Calculate scaled member capacity of the Member's balanced limit,
+		// using integer division, rounded up.
+		var limit = s.memberItemLimit(member)
+		var scaled = s.memberBalancedLimit(member) * zsfNum[zone]
 
 		if scaled == 0 {
 			// Pass.
@@ -174,23 +216,96 @@ func (fn *flowNetwork) init(s *State) {
 		// Arc from Member to Sink, with capacity of the adjusted Member ItemLimit.
 		// Previous flow is the number of current Assignments.
 		addArc(&fn.members[member], &fn.sink, scaled, s.MemberTotalCount[member])
+
+		// If primaries are sticky, allow the Member to exceed its scaled capacity
+		// by up to the number of its current primaries, bounded by the stickiness
+		// fraction of its scaled capacity.
+		var sticky = int(float64(scaled) * fn.stickiness)
+		sticky = min(sticky, min(s.MemberPrimaryCount[member], limit-scaled))
+
+		if sticky > 0 {
+			addArc(&fn.members[member], &fn.sink, sticky, s.MemberTotalCount[member]-scaled)
+		}
 		// Add any remaining capacity via an arc to the Overflow node.
-		if limit > scaled {
-			addArc(&fn.members[member], &fn.overflow, limit-scaled, 0)
+		if limit > scaled+sticky {
+			addArc(&fn.members[member], &fn.overflow, limit-scaled-sticky, 0)
 		}
 	}
 
 	// Sort all Node Arcs by priority.
 	pr.SortNodeArcs(fn.source)
 	pr.SortNodeArcs(fn.items...)
+	for level := range fn.domainItems {
+		pr.SortNodeArcs(fn.domainItems[level]...)
+	}
 	pr.SortNodeArcs(fn.zoneItems...)
 	pr.SortNodeArcs(fn.members...)
 	pr.SortNodeArcs(fn.overflow)
 	pr.SortNodeArcs(fn.sink)
 }
 
+// initDomains initializes |domainParents| and |domainChildren| from the
+// failure domains of State |s|. A domain or Zone is effective if it has
+// more than |itemCount| slots.
+func (fn *flowNetwork) initDomains(s *State, itemCount int) {
+	var levels = len(s.Domains)
+
+	fn.domainParents = resizeLevels(fn.domainParents, s.Domains)
+	fn.domainChildren = resizeLevels(fn.domainChildren, s.Domains)
+	fn.domainCaps = resizeLevels(fn.domainCaps, s.Domains)
+	fn.domainFlow = resizeLevels(fn.domainFlow, s.Domains)
+
+	for zone := range s.Zones {
+		for level := 0; level != levels; level++ {
+			var d = s.ZoneDomains[zone][level]
+
+			if level != 0 {
+				fn.domainParents[level][d] = s.ZoneDomains[zone][level-1]
+			}
+			if level+1 != levels {
+				continue
+			} else if s.ZoneSlots[zone] > itemCount {
+				fn.domainChildren[level][d]++ // Zone is an effective child of |d|.
+			}
+		}
+	}
+	fn.rootChildren = 0
+
+	for level := 0; level < levels; level++ {
+		for d, slots := range s.DomainSlots[level] {
+			if slots <= itemCount {
+				continue
+			} else if level == 0 {
+				fn.rootChildren++
+			} else {
+				fn.domainChildren[level-1][fn.domainParents[level][d]]++
+			}
+		}
+	}
+}
+
+// resizeLevels returns |in| re-sized and zeroed to match the shape of |domains|.
+func resizeLevels(in [][]int, domains [][]string) [][]int {
+	for len(in) < len(domains) {
+		in = append(in, nil)
+	}
+	in = in[:len(domains)]
+
+	for level := range in {
+		if cap(in[level]) < len(domains[level]) {
+			in[level] = make([]int, len(domains[level]))
+		} else {
+			in[level] = in[level][:len(domains[level])]
+			for i := range in[level] {
+				in[level][i] = 0
+			}
+		}
+	}
+	return in
+}
+
 // zoneScalingFactor computes a scaling factor (0, 1] which is applied to Member
-// ItemLimits of a given Zone. Where there are more Member slots than Item slots,
+// balanced limits of a given Zone. Where there are more Member slots than Item slots,
 // this balances the smaller set of Items evenly across Zones and their Members,
 // rather than having some Members near or fully allocated while others are idle
 // (which is an otherwise valid max-flow).
@@ -249,19 +364,21 @@ func zoneScalingFactors(itemCount, itemSlots int, zoneSlots []int) (num, denom [
 }
 
 func buildItemArcs(s *State, fn *flowNetwork, item int, itemAssignments keyspace.KeyValues, itemSlots, effectiveZones int) {
-	// Item capacity is defined by its replication factor. Within a zone (and
-	// assuming there are multiple Zones), capacity is the replication factor
-	// minus one (eg, requiring that replicas be split across at least two
-	// Zones), lower-bounded to one.
-	var zoneSlots = itemSlots
-	if zoneSlots > 1 && effectiveZones > 1 {
-		zoneSlots--
-	}
+	// Item capacity is defined by its replication factor. Within a zone,
+	// capacity is further bounded by the Item's zone constraints.
+	var zoneSlots = itemZoneSlots(itemAt(s.Items, item).ItemValue, itemSlots, effectiveZones)
+	// If the Item restricts its placement, Members which aren't placeable
+	// receive no Arc (and current Assignments to them are dropped).
+	var placed, _ = itemAt(s.Items, item).ItemValue.(PlacedItemValue)
 
 	// Arc from Source to Item, with capacity of the total desired item replication.
 	// Previous flow is the number of current Assignments.
 	addArc(&fn.source, &fn.items[item], itemSlots, len(itemAssignments))
 
+	if len(s.Domains) != 0 {
+		buildDomainItemArcs(s, fn, item, itemAssignments, itemSlots)
+	}
+
 	// Perform a Left-join of all Zones with |itemAssignments| (also ordered on zone).
 	var zit = LeftJoin{
 		LenL: len(s.Zones),
@@ -281,9 +398,21 @@ func buildItemArcs(s *State, fn *flowNetwork, item int, itemAssignments keyspace
 		var zoneItem = item*len(s.Zones) + zone
 		var zoneAssignments = itemAssignments[zcur.RightBegin:zcur.RightEnd]
 
-		// Arc from Item to ZoneItem, with capacity of |zoneSlots|, and previous flow being
-		// the total number of current Assignments to Members in this zone.
-		addArc(&fn.items[item], &fn.zoneItems[zoneItem], zoneSlots, len(zoneAssignments))
+		if levels := len(s.Domains); levels == 0 {
+			// Arc from Item to ZoneItem, with capacity of |zoneSlots|, and previous flow being
+			// the total number of current Assignments to Members in this zone.
+			addArc(&fn.items[item], &fn.zoneItems[zoneItem], zoneSlots, len(zoneAssignments))
+		} else {
+			// Arc from the DomainItem of the Zone's lowest-level domain to ZoneItem.
+			// Capacity is spread across effective Zones of the domain, and is
+			// further bounded by explicit zone constraints of the Item.
+			var d = s.ZoneDomains[zone][levels-1]
+			var slots = min(domainSlots(fn.domainCaps[levels-1][d], fn.domainChildren[levels-1][d]),
+				itemZoneSlots(itemAt(s.Items, item).ItemValue, itemSlots, 0))
+
+			addArc(&fn.domainItems[levels-1][item*len(s.Domains[levels-1])+d],
+				&fn.zoneItems[zoneItem], slots, len(zoneAssignments))
+		}
 
 		// Perform a Left-join of |Members| with |zoneAssignments| (also ordered on member suffix).
 		var mit = LeftJoin{
@@ -309,13 +438,114 @@ func buildItemArcs(s *State, fn *flowNetwork, item int, itemAssignments keyspace
 				panic("invalid member / zone order")
 			}
 
+			if placed != nil && !placed.IsPlaceable(memberAt(s.Members, member).MemberValue) {
+				continue
+			}
 			// Arc from ZoneItem to Member, with capacity of 1 and a previous flow being
 			// the number of current Assignments to this member (which can be zero or one).
-			addArc(&fn.zoneItems[zoneItem], &fn.members[member], 1, mcur.RightEnd-mcur.RightBegin)
+			var prevFlow = mcur.RightEnd - mcur.RightBegin
+
+			if prevFlow != 0 && fn.stickiness > 0 &&
+				assignmentAt(zoneAssignments, mcur.RightBegin).Slot == 0 {
+				// Prefer to retain a current primary Assignment over other current
+				// Assignments. As the reciprocal residual Arc has the lowest
+				// priority, the primary is also the last to be displaced.
+				pr.AddArc(&fn.zoneItems[zoneItem], &fn.members[member], 1, primaryPriority)
+			} else {
+				addArc(&fn.zoneItems[zoneItem], &fn.members[member], 1, prevFlow)
+			}
 		}
 	}
 }
 
+// buildDomainItemArcs adds Arcs from Item |item| to DomainItems of each first-level
+// failure domain, and from each DomainItem to DomainItems of its child domains.
+// Capacity of each Arc is the parent's capacity spread across its effective
+// children, such that replicas are spread across the highest-level domains first.
+// Previous flow of each Arc is the number of current Assignments in the domain.
+func buildDomainItemArcs(s *State, fn *flowNetwork, item int, itemAssignments keyspace.KeyValues, itemSlots int) {
+	for level := range fn.domainFlow {
+		for d := range fn.domainFlow[level] {
+			fn.domainFlow[level][d] = 0
+		}
+	}
+	for i := range itemAssignments {
+		var zone = sort.SearchStrings(s.Zones, assignmentAt(itemAssignments, i).MemberZone)
+		if zone == len(s.Zones) || s.Zones[zone] != assignmentAt(itemAssignments, i).MemberZone {
+			continue // Assignment of a Member which no longer exists.
+		}
+		for level, d := range s.ZoneDomains[zone] {
+			fn.domainFlow[level][d]++
+		}
+	}
+
+	for level := range s.Domains {
+		var n = len(s.Domains[level])
+
+		for d := range s.Domains[level] {
+			var from *pr.Node
+			var parentSlots, parentChildren int
+
+			if level == 0 {
+				from, parentSlots, parentChildren = &fn.items[item], itemSlots, fn.rootChildren
+			} else {
+				var p = fn.domainParents[level][d]
+				from = &fn.domainItems[level-1][item*len(s.Domains[level-1])+p]
+				parentSlots, parentChildren = fn.domainCaps[level-1][p], fn.domainChildren[level-1][p]
+			}
+			fn.domainCaps[level][d] = domainSlots(parentSlots, parentChildren)
+
+			addArc(from, &fn.domainItems[level][item*n+d], fn.domainCaps[level][d], fn.domainFlow[level][d])
+		}
+	}
+}
+
+// domainSlots returns the number of |parentSlots| which may be placed within
+// a single child failure domain. As with Zones, where there are multiple
+// effective children, it's the parent's slots minus one (lower-bounded to one).
+func domainSlots(parentSlots, effectiveChildren int) int {
+	if parentSlots > 1 && effectiveChildren > 1 {
+		return parentSlots - 1
+	}
+	return parentSlots
+}
+
+// itemZoneSlots returns the number of an Item's |itemSlots| which may be
+// placed within a single zone. By default (and assuming there are multiple
+// effective Zones), it's the replication factor minus one (eg, requiring that
+// replicas be split across at least two Zones), lower-bounded to one.
+//
+// Where the Item is a ZoneConstrainedItemValue, its constraints are instead
+// applied. A flow network cannot directly express "span at least N zones",
+// so |minZones| is reduced to a per-zone bound which implies it: if no zone
+// holds more than Z replicas, then R replicas span at least N zones so long
+// as (N-1) * Z < R. Explicit constraints are applied regardless of the number
+// of effective Zones.
+func itemZoneSlots(item ItemValue, itemSlots, effectiveZones int) int {
+	var minZones, maxPerZone int
+	if zc, ok := item.(ZoneConstrainedItemValue); ok {
+		minZones, maxPerZone = zc.ZoneConstraints()
+	}
+	var zoneSlots = itemSlots
+
+	switch {
+	case minZones == 0:
+		if zoneSlots > 1 && effectiveZones > 1 {
+			zoneSlots--
+		}
+	case minZones > 1:
+		zoneSlots = max(1, (itemSlots-1)/(minZones-1))
+	}
+	if maxPerZone > 0 && zoneSlots > maxPerZone {
+		zoneSlots = maxPerZone
+	}
+	return zoneSlots
+}
+
+// primaryPriority is the Arc priority of a current primary Assignment, where
+// primaries are sticky. It exceeds the priority of any other saturated Arc.
+const primaryPriority = 3
+
 func addArc(from, to *pr.Node, capacity, prevFlow int) {
 	var priority int
 	if prevFlow >= capacity {
@@ -61,6 +61,36 @@ func (s *FlowNetworkSuite) TestZoneScalingFactors(c *gc.C) {
 	}
 }
 
+func (s *FlowNetworkSuite) TestItemZoneSlots(c *gc.C) {
+	var cases = []struct {
+		item           testItem
+		effectiveZones int
+		expect         int
+	}{
+		// Default constraints: replicas span two zones, if there are two.
+		{item: testItem{R: 3}, effectiveZones: 2, expect: 2},
+		{item: testItem{R: 3}, effectiveZones: 1, expect: 3},
+		{item: testItem{R: 1}, effectiveZones: 2, expect: 1},
+		// A single minimum zone permits co-location of all replicas.
+		{item: testItem{R: 3, Z: 1}, effectiveZones: 2, expect: 3},
+		// Minimum zones are applied even if there aren't enough effective zones.
+		{item: testItem{R: 3, Z: 2}, effectiveZones: 1, expect: 2},
+		{item: testItem{R: 3, Z: 3}, effectiveZones: 3, expect: 1},
+		{item: testItem{R: 5, Z: 3}, effectiveZones: 3, expect: 2},
+		{item: testItem{R: 4, Z: 3}, effectiveZones: 3, expect: 1},
+		{item: testItem{R: 2, Z: 5}, effectiveZones: 3, expect: 1},
+		// Maximum replicas per zone further bound zone slots.
+		{item: testItem{R: 3, M: 1}, effectiveZones: 2, expect: 1},
+		{item: testItem{R: 5, M: 3}, effectiveZones: 2, expect: 3},
+		{item: testItem{R: 5, Z: 1, M: 3}, effectiveZones: 1, expect: 3},
+		{item: testItem{R: 7, Z: 2, M: 2}, effectiveZones: 2, expect: 2},
+	}
+	for _, tc := range cases {
+		c.Check(itemZoneSlots(tc.item, tc.item.R, tc.effectiveZones), gc.Equals, tc.expect,
+			gc.Commentf("%#v", tc))
+	}
+}
+
 func (s *FlowNetworkSuite) TestFlowOverSimpleFixture(c *gc.C) {
 	var client, ctx = etcdtest.TestClient(), context.Background()
 	defer etcdtest.Cleanup()
@@ -150,6 +180,70 @@ func (s *FlowNetworkSuite) TestFlowOverSimpleFixture(c *gc.C) {
 	})
 }
 
+func (s *FlowNetworkSuite) TestFlowWithStickyPrimaries(c *gc.C) {
+	var client, ctx = etcdtest.TestClient(), context.Background()
+	defer etcdtest.Cleanup()
+	buildAllocKeySpaceFixture(c, ctx, client)
+
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var state = NewObservedState(ks, MemberKey(ks, "us-west", "baz"))
+	c.Check(ks.Load(ctx, client, 0), gc.IsNil)
+
+	var fn = flowNetwork{stickiness: 1.0}
+	fn.init(state)
+
+	var (
+		I1E  = &fn.zoneItems[0]
+		I1W  = &fn.zoneItems[1]
+		I2E  = &fn.zoneItems[2]
+		I2W  = &fn.zoneItems[3]
+		MBar = &fn.members[0]
+		MFoo = &fn.members[1]
+		MBaz = &fn.members[2]
+		T    = &fn.sink
+	)
+
+	// Expect current primaries have the highest priority.
+	verifyNode(c, I1E, &pr.Node{ID: 0, Height: 2, Arcs: []pr.Arc{
+		{Capacity: 1, Priority: 2, Target: MFoo},
+		{Capacity: 1, Priority: 0, Target: MBar},
+	}})
+	verifyNode(c, I1W, &pr.Node{ID: 1, Height: 2, Arcs: []pr.Arc{
+		{Capacity: 1, Priority: primaryPriority, Target: MBaz},
+	}})
+	verifyNode(c, I2E, &pr.Node{ID: 2, Height: 2, Arcs: []pr.Arc{
+		{Capacity: 1, Priority: primaryPriority, Target: MBar},
+		{Capacity: 1, Priority: 0, Target: MFoo},
+	}})
+	verifyNode(c, I2W, &pr.Node{ID: 3, Height: 2, Arcs: []pr.Arc{
+		{Capacity: 1, Priority: 2, Target: MBaz},
+	}})
+	// Members us-east/bar and us-east/foo have no spare capacity. Member
+	// us-west/baz may exceed its scaled capacity by its single primary,
+	// which replaces its arc to the Overflow node.
+	verifyNode(c, MBar, &pr.Node{ID: 0, Height: 1, Arcs: []pr.Arc{
+		{Capacity: 1, Priority: 2, Target: T},
+	}})
+	verifyNode(c, MFoo, &pr.Node{ID: 1, Height: 1, Arcs: []pr.Arc{
+		{Capacity: 2, Priority: 1, Target: T},
+	}})
+	verifyNode(c, MBaz, &pr.Node{ID: 2, Height: 1, Arcs: []pr.Arc{
+		{Capacity: 2, Priority: 2, Target: T},
+		{Capacity: 1, Priority: 0, Target: T},
+	}})
+
+	pr.FindMaxFlow(&fn.source, &fn.sink)
+
+	// Expect the over-replicated "item-two" retains its primary us-east/bar.
+	c.Check(extractItemFlow(state, &fn, 0, nil), gc.DeepEquals, []Assignment{
+		{ItemID: "item-1", MemberZone: "us-east", MemberSuffix: "foo"},
+		{ItemID: "item-1", MemberZone: "us-west", MemberSuffix: "baz"},
+	})
+	c.Check(extractItemFlow(state, &fn, 1, nil), gc.DeepEquals, []Assignment{
+		{ItemID: "item-two", MemberZone: "us-east", MemberSuffix: "bar"},
+	})
+}
+
 func verifyNode(c *gc.C, node, expect *pr.Node) {
 	c.Check(node.ID, gc.Equals, expect.ID)
 	c.Check(node.Height, gc.Equals, expect.Height)
@@ -1,6 +1,7 @@
 package allocator
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
@@ -18,6 +19,11 @@ type itemState struct {
 	add     []Assignment       // Assignments we seek to add.
 	remove  keyspace.KeyValues // Assignments we seek to remove.
 	reorder keyspace.KeyValues // Assignments we seek to keep, and potentially re-order.
+	demote  keyspace.KeyValues // Primary Assignment of a draining Member we seek to demote.
+
+	nextSlot int // Next Slot which is unused by any Assignment of the Item.
+
+	explain []Explanation // Explanations of operations built by the itemState.
 }
 
 // init initializes the itemState by deriving the set of added, removed, and reordered Assignments
@@ -32,14 +38,16 @@ func (s *itemState) init(item int, current keyspace.KeyValues, desired []Assignm
 		add:     s.add[:0],
 		remove:  s.remove[:0],
 		reorder: s.reorder[:0],
+		demote:  s.demote[:0],
+		explain: s.explain[:0],
 	}
 
-	var i, j, nextSlot int
+	var i, j int
 
 	// Initialize |nextSlot| to be greater than any Slot in use by any |current| Assignment.
 	for _, a := range s.current {
-		if s := a.Decoded.(Assignment).Slot + 1; s > nextSlot {
-			nextSlot = s
+		if slot := a.Decoded.(Assignment).Slot + 1; slot > s.nextSlot {
+			s.nextSlot = slot
 		}
 	}
 
@@ -50,7 +58,7 @@ func (s *itemState) init(item int, current keyspace.KeyValues, desired []Assignm
 
 		if c := compareAssignment(a, desired[j]); c > 0 {
 			var a = desired[j]
-			a.Slot, nextSlot = nextSlot, nextSlot+1
+			a.Slot, s.nextSlot = s.nextSlot, s.nextSlot+1
 			s.add = append(s.add, a)
 			j++
 		} else if c < 0 {
@@ -67,7 +75,7 @@ func (s *itemState) init(item int, current keyspace.KeyValues, desired []Assignm
 	}
 	for ; j != len(desired); j++ {
 		var a = desired[j]
-		a.Slot, nextSlot = nextSlot, nextSlot+1
+		a.Slot, s.nextSlot = s.nextSlot, s.nextSlot+1
 		s.add = append(s.add, a)
 	}
 }
@@ -75,6 +83,17 @@ func (s *itemState) init(item int, current keyspace.KeyValues, desired []Assignm
 // constrainRemovals prunes Assignments from |s.remove| which would otherwise violate
 // constraints, moving them to |s.reorder|.
 func (s *itemState) constrainRemovals() {
+	// Assignments of a draining Member are retained so long as the Member holds
+	// any primary Assignment, such that all primaries are handed off from the
+	// Member before any of its replicas are removed.
+	for i := 0; i != len(s.remove); {
+		if s.global.isDrainingPrimaries(s.remove[i]) {
+			s.reorder = append(s.reorder, s.remove[i])
+			s.remove = append(s.remove[:i], s.remove[i+1:]...)
+		} else {
+			i++
+		}
+	}
 	// Order |s.remove| on decreasing member load ratio
 	// (the ratio of the member's total Assignments, vs its item limit).
 	sort.Slice(s.remove, func(i, j int) bool {
@@ -109,6 +128,29 @@ func (s *itemState) constrainRemovals() {
 	s.remove = s.remove[:limit]
 }
 
+// constrainDemotion identifies a current primary Assignment of a draining
+// Member, and moves it from |s.reorder| to |s.demote| if another consistent
+// Assignment of a non-draining Member may be promoted in its place.
+func (s *itemState) constrainDemotion() {
+	var item = itemAt(s.global.Items, s.item)
+	var primary, candidate = -1, false
+
+	for i := range s.reorder {
+		if s.global.isDraining(s.reorder[i]) {
+			if assignmentAt(s.reorder, i).Slot == 0 {
+				primary = i
+			}
+		} else if item.IsConsistent(s.reorder[i], s.current) {
+			candidate = true
+		}
+	}
+	if primary == -1 || !candidate {
+		return
+	}
+	s.demote = append(s.demote, s.reorder[primary])
+	s.reorder = append(s.reorder[:primary], s.reorder[primary+1:]...)
+}
+
 // constrainReorders updates the ordering of |s.reorders| to ensure the best
 // Assignment is selected as primary (which is always s.reorders[0]).
 func (s *itemState) constrainReorders() {
@@ -162,7 +204,7 @@ func (s *itemState) constrainAdds() {
 			panic("member not found")
 		}
 
-		if memberAt(s.global.Members, ind).ItemLimit() <= s.global.MemberTotalCount[ind] {
+		if s.global.memberItemLimit(ind) <= s.global.MemberTotalCount[ind] {
 			// Addition would violate member's ItemLimit. Remove this Assignment.
 			copy(s.add[i:], s.add[i+1:])
 			s.add = s.add[:len(s.add)-1]
@@ -191,6 +233,14 @@ func (s *itemState) buildRemoveOps(txn checkpointTxn) {
 				s.global.MemberPrimaryCount[ind] -= 1
 			}
 			s.global.MemberTotalCount[ind] -= 1
+
+			if memberDraining(memberAt(s.global.Members, ind).MemberValue) {
+				s.explainf("remove", a, "member is draining")
+			} else {
+				s.explainf("remove", a, "member is not desired by the allocation")
+			}
+		} else {
+			s.explainf("remove", a, "member does not exist")
 		}
 		// We allow for !found (and do not panic) to gracefully handle assignments
 		// which somehow linger after their corresponding member is deleted (note
@@ -198,6 +248,22 @@ func (s *itemState) buildRemoveOps(txn checkpointTxn) {
 	}
 }
 
+// buildDemoteOps adds operations to |txn| which demote each of |s.demote|
+// to a new, unused Slot.
+func (s *itemState) buildDemoteOps(txn checkpointTxn) {
+	for i, d := range s.demote {
+		var a = assignmentAt(s.demote, i)
+		a.Slot, s.nextSlot = s.nextSlot, s.nextSlot+1
+
+		// Update to reflect the member's primary count has decreased.
+		if ind, found := s.global.Members.Search(MemberKey(s.global.KS, a.MemberZone, a.MemberSuffix)); found {
+			s.global.MemberPrimaryCount[ind] -= 1
+		}
+		s.buildMoveOps(txn, d, a)
+		s.explainf("demote", a, "member is draining, and a consistent replica may be promoted")
+	}
+}
+
 // buildPromoteOps adds operations to |txn| which, if required,
 // promote a current Assignment to primary, if required.
 func (s *itemState) buildPromoteOps(txn checkpointTxn) {
@@ -206,7 +272,12 @@ func (s *itemState) buildPromoteOps(txn checkpointTxn) {
 	} else if a := assignmentAt(s.reorder, 0); a.Slot == 0 {
 		return // Assignment is already primary.
 	} else {
+		var consistent = itemAt(s.global.Items, s.item).IsConsistent(s.reorder[0], s.current)
+		var loadRatio = s.global.memberLoadRatio(s.reorder[0], s.global.MemberPrimaryCount)
+
 		a.Slot = 0 // Promote to Primary.
+		s.explainf("promote", a, "item has no primary (replica is consistent: %t, member primary load ratio: %.2f)",
+			consistent, loadRatio)
 
 		// Update to reflect the member's primary count has increased.
 		if ind, found := s.global.Members.Search(MemberKey(s.global.KS, a.MemberZone, a.MemberSuffix)); found {
@@ -239,6 +310,9 @@ func (s *itemState) buildAddOps(txn checkpointTxn) {
 			s.global.MemberPrimaryCount[ind] += 1
 		}
 		s.global.MemberTotalCount[ind] += 1
+
+		s.explainf("add", a, "member is desired by the allocation (item has %d of %d desired replicas)",
+			len(s.current), itemAt(s.global.Items, s.item).DesiredReplication())
 	}
 }
 
@@ -249,13 +323,22 @@ func (s *itemState) buildPackOps(txn checkpointTxn) {
 		if i == 0 {
 			continue // Case handled by buildPromoteOps.
 		} else if a := assignmentAt(s.reorder, i); a.Slot != i {
+			var from = a.Slot
+
 			a.Slot = i
 			s.buildMoveOps(txn, s.reorder[i], a)
+			s.explainf("pack", a, "lower slot is unused (packed from slot %d)", from)
 			break
 		}
 	}
 }
 
+// explainf adds an Explanation of |action| on Assignment |a|, with a
+// formatted reason, to |s.explain|.
+func (s *itemState) explainf(action string, a Assignment, format string, args ...interface{}) {
+	s.explain = append(s.explain, explanation(action, a, fmt.Sprintf(format, args...)))
+}
+
 // buildMoveOps atomically moves the |cur| Assignment to a new key.
 func (s *itemState) buildMoveOps(txn checkpointTxn, cur keyspace.KeyValue, a Assignment) {
 	// Atomic move of same value from current Assignment key, to a new one under the current Lease.
@@ -267,17 +350,19 @@ func (s *itemState) buildMoveOps(txn checkpointTxn, cur keyspace.KeyValue, a Ass
 }
 
 // constrainAndBuildOps applies all constraints, and then applies resulting
-// add, remove, promotion, and packing operations to |txn|.
+// add, remove, demotion, promotion, and packing operations to |txn|.
 func (s *itemState) constrainAndBuildOps(txn checkpointTxn) error {
 	s.constrainRemovals()
+	s.constrainDemotion()
 	s.constrainReorders()
 	s.constrainAdds()
 
 	s.buildAddOps(txn)
 	s.buildRemoveOps(txn)
+	s.buildDemoteOps(txn)
 	s.buildPromoteOps(txn)
 
-	if len(s.add) == 0 && len(s.remove) == 0 {
+	if len(s.add) == 0 && len(s.remove) == 0 && len(s.demote) == 0 {
 		s.buildPackOps(txn)
 	}
 	return txn.Checkpoint()
@@ -2,6 +2,7 @@ package allocator
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
@@ -849,6 +850,184 @@ func (s *ScenariosSuite) TestCleanupOfAssignmentsWithoutMember(c *gc.C) {
 	})
 }
 
+func (s *ScenariosSuite) TestZoneConstraints(c *gc.C) {
+	c.Check(insert(s.ctx, s.client,
+		"/root/items/item-1", `{"R": 3}`, // Default constraints.
+		"/root/items/item-2", `{"R": 3, "M": 1}`, // Never co-locate replicas.
+		"/root/items/item-3", `{"R": 2, "Z": 2}`, // Span at least two zones.
+
+		"/root/members/zone-a#member-A1", `{"R": 4}`,
+		"/root/members/zone-a#member-A2", `{"R": 4}`,
+		"/root/members/zone-b#member-B", `{"R": 4}`,
+	), gc.IsNil)
+	c.Check(serveUntilIdle(c, s.ctx, s.client, s.ks), gc.Equals, 1)
+
+	// item-2 cannot be fully replicated without co-locating replicas within
+	// a zone. Expect it's instead left under-replicated.
+	c.Check(zoneCounts(s.ks.Prefixed(s.ks.Root+AssignmentsPrefix)), gc.DeepEquals,
+		map[string]map[string]int{
+			"item-1": {"zone-a": 2, "zone-b": 1},
+			"item-2": {"zone-a": 1, "zone-b": 1},
+			"item-3": {"zone-a": 1, "zone-b": 1},
+		})
+
+	// Add a third zone. Expect item-2 is now fully replicated.
+	c.Check(markAllConsistent(s.ctx, s.client, s.ks), gc.IsNil)
+	c.Check(insert(s.ctx, s.client,
+		"/root/members/zone-c#member-C", `{"R": 4}`,
+	), gc.IsNil)
+	serveUntilIdle(c, s.ctx, s.client, s.ks)
+
+	// Mark new Assignments as consistent, allowing replaced ones to be removed.
+	c.Check(markAllConsistent(s.ctx, s.client, s.ks), gc.IsNil)
+	serveUntilIdle(c, s.ctx, s.client, s.ks)
+
+	var counts = zoneCounts(s.ks.Prefixed(s.ks.Root + AssignmentsPrefix))
+	c.Check(counts["item-2"], gc.DeepEquals, map[string]int{"zone-a": 1, "zone-b": 1, "zone-c": 1})
+}
+
+func (s *ScenariosSuite) TestWeightedMemberCapacity(c *gc.C) {
+	var items []string
+	for i := 0; i != 30; i++ {
+		items = append(items, fmt.Sprintf("/root/items/item-%02d", i), `{"R": 1}`)
+	}
+	// Members have equal ItemLimits, but A1 has four times the weight of A2.
+	var members = []string{
+		"/root/members/zone-a#member-A1", `{"R": 20, "W": 4}`,
+		"/root/members/zone-a#member-A2", `{"R": 20, "W": 1}`,
+	}
+	c.Check(insert(s.ctx, s.client, append(members, items[:20]...)...), gc.IsNil)
+	c.Check(serveUntilIdle(c, s.ctx, s.client, s.ks), gc.Equals, 1)
+
+	// Expect Items are balanced in proportion to Member weight.
+	c.Check(memberCounts(s.ks.Prefixed(s.ks.Root+AssignmentsPrefix)), gc.DeepEquals,
+		map[string]int{"member-A1": 8, "member-A2": 2})
+
+	// Add Items in excess of A1's weighted share. Expect A1 is filled to its
+	// ItemLimit, and A2 takes the remainder beyond its share.
+	c.Check(insert(s.ctx, s.client, items[20:]...), gc.IsNil)
+	serveUntilIdle(c, s.ctx, s.client, s.ks)
+
+	c.Check(memberCounts(s.ks.Prefixed(s.ks.Root+AssignmentsPrefix)), gc.DeepEquals,
+		map[string]int{"member-A1": 20, "member-A2": 10})
+}
+
+func (s *ScenariosSuite) TestMemberDraining(c *gc.C) {
+	// Insert member-B first, such that it's the leader and not member-A
+	// (which would otherwise exit upon being drained).
+	c.Check(insert(s.ctx, s.client,
+		"/root/members/zone-a#member-B", `{"R": 1}`), gc.IsNil)
+	c.Check(insert(s.ctx, s.client,
+		"/root/items/item-1", `{"R": 1}`,
+		"/root/items/item-2", `{"R": 2}`,
+		"/root/members/zone-a#member-A", `{"R": 2}`,
+	), gc.IsNil)
+	c.Check(serveUntilIdle(c, s.ctx, s.client, s.ks), gc.Equals, 1)
+	c.Check(markAllConsistent(s.ctx, s.client, s.ks), gc.IsNil)
+
+	c.Check(memberCounts(s.ks.Prefixed(s.ks.Root+AssignmentsPrefix)), gc.DeepEquals,
+		map[string]int{"member-A": 2, "member-B": 1})
+
+	// Add member-C, and begin to drain member-A.
+	c.Check(insert(s.ctx, s.client,
+		"/root/members/zone-a#member-C", `{"R": 3}`), gc.IsNil)
+	c.Check(update(s.ctx, s.client,
+		"/root/members/zone-a#member-A", `{"R": 2, "D": true}`), gc.IsNil)
+	serveUntilIdle(c, s.ctx, s.client, s.ks)
+
+	// Expect Assignments were added to member-C, and member-A retains all of its
+	// Assignments while it's primary for any Item. item-2 was handed off to
+	// consistent member-B, but item-1 has no other consistent Assignment.
+	c.Check(memberCounts(s.ks.Prefixed(s.ks.Root+AssignmentsPrefix)), gc.DeepEquals,
+		map[string]int{"member-A": 2, "member-B": 1, "member-C": 2})
+	c.Check(primaryMembers(s.ks.Prefixed(s.ks.Root+AssignmentsPrefix)), gc.DeepEquals,
+		map[string]string{"item-1": "member-A", "item-2": "member-B"})
+
+	// Once member-C is consistent, expect item-1 is handed off to it, and
+	// member-A is then fully drained.
+	c.Check(markAllConsistent(s.ctx, s.client, s.ks), gc.IsNil)
+	serveUntilIdle(c, s.ctx, s.client, s.ks)
+
+	c.Check(keys(s.ks.Prefixed(s.ks.Root+AssignmentsPrefix)), gc.DeepEquals, []string{
+		"/root/assign/item-1#zone-a#member-C#0",
+		"/root/assign/item-2#zone-a#member-B#0",
+		"/root/assign/item-2#zone-a#member-C#1",
+	})
+}
+
+func (s *ScenariosSuite) TestPlacementPools(c *gc.C) {
+	c.Check(insert(s.ctx, s.client,
+		"/root/items/item-1", `{"R": 2, "P": "ssd"}`,
+		"/root/items/item-2", `{"R": 2}`,
+		"/root/items/item-3", `{"R": 1, "P": "tape"}`,
+
+		"/root/members/zone-a#member-A", `{"R": 4, "P": "ssd"}`,
+		"/root/members/zone-a#member-B", `{"R": 4}`,
+		"/root/members/zone-b#member-C", `{"R": 4, "P": "ssd"}`,
+	), gc.IsNil)
+	c.Check(serveUntilIdle(c, s.ctx, s.client, s.ks), gc.Equals, 1)
+
+	// Expect item-1 is placed only on "ssd" Members, and item-3 (having no
+	// placeable Members) is left unassigned.
+	var members = func(id string) (out []string) {
+		for _, kv := range s.ks.Prefixed(s.ks.Root + AssignmentsPrefix) {
+			if a := kv.Decoded.(Assignment); a.ItemID == id {
+				out = append(out, a.MemberSuffix)
+			}
+		}
+		return
+	}
+	c.Check(members("item-1"), gc.DeepEquals, []string{"member-A", "member-C"})
+	c.Check(members("item-2"), gc.HasLen, 2)
+	c.Check(members("item-3"), gc.IsNil)
+
+	// Move member-A out of the "ssd" pool, and add member-D to it. Expect the
+	// Assignment of item-1 to member-A is retained until item-1 is consistent
+	// on member-D, and is then removed.
+	c.Check(update(s.ctx, s.client,
+		"/root/members/zone-a#member-A", `{"R": 4}`), gc.IsNil)
+	c.Check(insert(s.ctx, s.client,
+		"/root/members/zone-a#member-D", `{"R": 4, "P": "ssd"}`), gc.IsNil)
+	serveUntilIdle(c, s.ctx, s.client, s.ks)
+
+	c.Check(members("item-1"), gc.DeepEquals, []string{"member-A", "member-D", "member-C"})
+
+	c.Check(markAllConsistent(s.ctx, s.client, s.ks), gc.IsNil)
+	serveUntilIdle(c, s.ctx, s.client, s.ks)
+
+	c.Check(members("item-1"), gc.DeepEquals, []string{"member-D", "member-C"})
+}
+
+func (s *ScenariosSuite) TestHierarchicalFailureDomains(c *gc.C) {
+	c.Check(insert(s.ctx, s.client,
+		"/root/items/item-1", `{"R": 2}`,
+		"/root/items/item-2", `{"R": 2}`,
+		"/root/items/item-3", `{"R": 2}`,
+		"/root/items/item-4", `{"R": 2}`,
+		"/root/items/item-5", `{"R": 3}`,
+
+		"/root/members/east/a#member-A", `{"R": 10}`,
+		"/root/members/east/b#member-B", `{"R": 10}`,
+		"/root/members/west/a#member-C", `{"R": 10}`,
+	), gc.IsNil)
+	c.Check(serveUntilIdle(c, s.ctx, s.client, s.ks), gc.Equals, 1)
+
+	var counts = zoneCounts(s.ks.Prefixed(s.ks.Root + AssignmentsPrefix))
+
+	// Expect replicas are spread across "east" and "west" regions first, though
+	// two of three Zones are within "east". Within a region, replicas are
+	// further spread across Zones.
+	for _, id := range []string{"item-1", "item-2", "item-3", "item-4"} {
+		var regions = make(map[string]int)
+		for zone, n := range counts[id] {
+			regions[zoneDomain(zone, 0)] += n
+		}
+		c.Check(regions, gc.DeepEquals, map[string]int{"east": 1, "west": 1})
+	}
+	c.Check(counts["item-5"], gc.DeepEquals,
+		map[string]int{"east/a": 1, "east/b": 1, "west/a": 1})
+}
+
 // insert creates new keys with values, requiring that the key not already exist.
 func insert(ctx context.Context, client *clientv3.Client, keyValues ...string) error {
 	var txn = newBatchedTxn(ctx, client)
@@ -909,6 +1088,39 @@ func keys(kv keyspace.KeyValues) []string {
 	return r
 }
 
+// zoneCounts returns the number of Assignments of each Item, by Member zone.
+func zoneCounts(kv keyspace.KeyValues) map[string]map[string]int {
+	var out = make(map[string]map[string]int)
+	for i := range kv {
+		var a = assignmentAt(kv, i)
+		if out[a.ItemID] == nil {
+			out[a.ItemID] = make(map[string]int)
+		}
+		out[a.ItemID][a.MemberZone]++
+	}
+	return out
+}
+
+// memberCounts returns the number of Assignments of each Member suffix.
+func memberCounts(kv keyspace.KeyValues) map[string]int {
+	var out = make(map[string]int)
+	for i := range kv {
+		out[assignmentAt(kv, i).MemberSuffix]++
+	}
+	return out
+}
+
+// primaryMembers returns the Member suffix of the primary Assignment of each Item.
+func primaryMembers(kv keyspace.KeyValues) map[string]string {
+	var out = make(map[string]string)
+	for i := range kv {
+		if a := assignmentAt(kv, i); a.Slot == 0 {
+			out[a.ItemID] = a.MemberSuffix
+		}
+	}
+	return out
+}
+
 func serveUntilIdle(c *gc.C, ctx context.Context, client *clientv3.Client, ks *keyspace.KeySpace) int {
 	// Pluck out the key of the current Member leader. We'll assume its identity.
 	var resp, err = client.Get(ctx, ks.Root+MembersPrefix,
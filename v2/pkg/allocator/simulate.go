@@ -0,0 +1,56 @@
+package allocator
+
+import (
+	"github.com/LiveRamp/gazette/v2/pkg/allocator/push_relabel"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+)
+
+// Simulate solves for the maximum Assignment of Items to Members of the State,
+// exactly as the Allocator leader would, and returns the desired Assignments
+// in natural Assignment order. Simulate effects no changes, and is intended
+// for tools which preview the allocation implied by a hypothetical KeySpace
+// (eg, one having a removed Member, or updated ItemLimits). Of |limits|,
+// only PrimaryStickiness is applicable. The KeySpace must already be locked.
+//
+// Note that the leader incrementally converges towards its desired
+// Assignments, subject to replication guarantees and ChurnLimits, and the
+// returned Assignments are the eventual (and not immediate) allocation.
+func Simulate(s *State, limits ChurnLimits) []Assignment {
+	var fn = &flowNetwork{stickiness: limits.PrimaryStickiness}
+	fn.init(s)
+	push_relabel.FindMaxFlow(&fn.source, &fn.sink)
+
+	var desired []Assignment
+	for item := range s.Items {
+		desired = extractItemFlow(s, fn, item, desired)
+	}
+	return desired
+}
+
+// DiffAssignments returns Assignments of |desired| which are not |current|
+// Assignments as |added|, and |current| Assignments not in |desired| as
+// |removed|. Both |current| and |desired| must be in natural Assignment
+// order. Slots are ignored: a |desired| Assignment matches a |current|
+// Assignment of the same Item and Member.
+func DiffAssignments(current keyspace.KeyValues, desired []Assignment) (added, removed []Assignment) {
+	for len(current) != 0 || len(desired) != 0 {
+		var c int
+		if len(current) == 0 {
+			c = 1
+		} else if len(desired) == 0 {
+			c = -1
+		} else {
+			c = compareAssignment(assignmentAt(current, 0), desired[0])
+		}
+
+		switch c {
+		case -1:
+			removed, current = append(removed, assignmentAt(current, 0)), current[1:]
+		case 1:
+			added, desired = append(added, desired[0]), desired[1:]
+		default:
+			current, desired = current[1:], desired[1:]
+		}
+	}
+	return
+}
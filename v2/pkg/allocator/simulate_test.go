@@ -0,0 +1,86 @@
+package allocator
+
+import (
+	"context"
+
+	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+	gc "github.com/go-check/check"
+)
+
+type SimulateSuite struct{}
+
+func (s *SimulateSuite) TestSimulateOverFixture(c *gc.C) {
+	var client, ctx = etcdtest.TestClient(), context.Background()
+	defer etcdtest.Cleanup()
+	buildAllocKeySpaceFixture(c, ctx, client)
+
+	var ks = NewAllocatorKeySpace("/root", testAllocDecoder{})
+	var as = NewObservedState(ks, "")
+	c.Check(ks.Load(ctx, client, 0), gc.IsNil)
+
+	ks.Mu.RLock()
+	defer ks.Mu.RUnlock()
+
+	// Expect all Item slots are assignable.
+	var desired = Simulate(as, ChurnLimits{})
+	c.Check(desired, gc.HasLen, as.ItemSlots)
+
+	// Expect applying the diff to current Assignments yields |desired|.
+	var added, removed = DiffAssignments(as.Assignments, desired)
+
+	var result = make(map[Assignment]bool)
+	for _, kv := range as.Assignments {
+		var a = kv.Decoded.(Assignment)
+		result[Assignment{ItemID: a.ItemID, MemberZone: a.MemberZone, MemberSuffix: a.MemberSuffix}] = true
+	}
+	for _, a := range removed {
+		delete(result, Assignment{ItemID: a.ItemID, MemberZone: a.MemberZone, MemberSuffix: a.MemberSuffix})
+	}
+	for _, a := range added {
+		result[a] = true
+	}
+	var expect = make(map[Assignment]bool)
+	for _, a := range desired {
+		expect[a] = true
+	}
+	c.Check(result, gc.DeepEquals, expect)
+
+	// Expect Assignments of the missing Item and Member are removed.
+	var removedKeys = make(map[string]bool)
+	for _, a := range removed {
+		removedKeys[a.ItemID+"#"+a.MemberZone+"#"+a.MemberSuffix] = true
+	}
+	c.Check(removedKeys["item-missing#us-west#baz"], gc.Equals, true)
+	c.Check(removedKeys["item-two#missing#member"], gc.Equals, true)
+}
+
+func (s *SimulateSuite) TestDiffAssignments(c *gc.C) {
+	var current = keyspace.KeyValues{
+		{Decoded: Assignment{ItemID: "a", MemberZone: "z", MemberSuffix: "m1", Slot: 0}},
+		{Decoded: Assignment{ItemID: "a", MemberZone: "z", MemberSuffix: "m2", Slot: 1}},
+		{Decoded: Assignment{ItemID: "b", MemberZone: "z", MemberSuffix: "m1", Slot: 0}},
+	}
+	var desired = []Assignment{
+		{ItemID: "a", MemberZone: "z", MemberSuffix: "m2"},
+		{ItemID: "a", MemberZone: "z", MemberSuffix: "m3"},
+		{ItemID: "b", MemberZone: "z", MemberSuffix: "m1"},
+		{ItemID: "c", MemberZone: "z", MemberSuffix: "m1"},
+	}
+	var added, removed = DiffAssignments(current, desired)
+
+	c.Check(added, gc.DeepEquals, []Assignment{
+		{ItemID: "a", MemberZone: "z", MemberSuffix: "m3"},
+		{ItemID: "c", MemberZone: "z", MemberSuffix: "m1"},
+	})
+	c.Check(removed, gc.DeepEquals, []Assignment{
+		{ItemID: "a", MemberZone: "z", MemberSuffix: "m1", Slot: 0},
+	})
+
+	// Expect an empty |desired| removes all |current| Assignments.
+	added, removed = DiffAssignments(current, nil)
+	c.Check(added, gc.IsNil)
+	c.Check(removed, gc.HasLen, 3)
+}
+
+var _ = gc.Suite(&SimulateSuite{})
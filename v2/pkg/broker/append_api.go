@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"hash"
 	"io"
+	"mime"
 	"time"
 
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
 	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	uuid "github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
@@ -47,10 +50,16 @@ func (srv *Service) Append(stream pb.Journal_AppendServer) error {
 		} else if !res.journalSpec.Flags.MayWrite() {
 			err = stream.SendAndClose(&pb.AppendResponse{Status: pb.Status_NOT_ALLOWED, Header: res.Header})
 			break
+		} else if !contentTypeMatches(req.ContentType, res.journalSpec) {
+			err = stream.SendAndClose(&pb.AppendResponse{Status: pb.Status_WRONG_CONTENT_TYPE, Header: res.Header})
+			break
 		} else if res.replica == nil {
 			req.Header = &res.Header // Attach resolved Header to |req|, which we'll forward.
 			err = proxyAppend(stream, req, srv.jc)
 			break
+		} else if req.Continuation != "" {
+			err = resumeAppend(stream, req, res)
+			break
 		} else if err = res.replica.index.WaitForFirstRemoteRefresh(stream.Context()); err != nil {
 			break
 		}
@@ -133,7 +142,8 @@ func serveAppend(stream pb.Journal_AppendServer, req *pb.AppendRequest, res reso
 	} else if po != offset {
 		// Send a proposal which rolls the pipeline forward to |offset|.
 		var proposal = pln.spool.Fragment.Fragment
-		proposal.Begin, proposal.End, proposal.Sum = offset, offset, pb.SHA1Sum{}
+		proposal.Begin, proposal.End = offset, offset
+		proposal.Sum, proposal.Sum256 = pb.SHA1Sum{}, pb.SHA256Sum{}
 
 		pln.scatter(&pb.ReplicateRequest{
 			Proposal:    &proposal,
@@ -143,10 +153,28 @@ func serveAppend(stream pb.Journal_AppendServer, req *pb.AppendRequest, res reso
 
 	// Forward the client's content through the pipeline.
 	var appender = beginAppending(pln, res.journalSpec.Fragment)
+	return continueAppend(stream, res, &appender)
+}
+
+// continueAppend forwards the client's content through the pipeline of the
+// appender, and then commits, rolls back, or suspends the Append.
+func continueAppend(stream pb.Journal_AppendServer, res resolution, appender *appender) error {
+	var pln = appender.pln
+
 	for appender.onRecv(stream.Recv()) {
 	}
 	addTrace(stream.Context(), "read client EOF => %s", appender)
 
+	if appender.reqSuspend {
+		// Retain ownership of the pipeline for a following resumption.
+		var token = suspendAppend(res.replica, *appender)
+		return stream.SendAndClose(&pb.AppendResponse{
+			Status:       pb.Status_OK,
+			Header:       pln.Header,
+			Continuation: token,
+		})
+	}
+
 	var err = releasePipelineAndGatherResponse(stream.Context(), pln, res.replica.pipelineCh)
 	if err != nil {
 		metrics.CommitsTotal.WithLabelValues(metrics.Fail).Inc()
@@ -168,6 +196,86 @@ func serveAppend(stream pb.Journal_AppendServer, req *pb.AppendRequest, res reso
 	}
 }
 
+// resumeAppend resumes the suspended Append of the request Continuation,
+// and continues to serve it.
+func resumeAppend(stream pb.Journal_AppendServer, req *pb.AppendRequest, res resolution) error {
+	var s = takeSuspendedAppend(res.replica, req.Continuation)
+
+	if s != nil && !s.appender.pln.Route.Equivalent(&res.Header.Route) {
+		// The journal Route changed while the Append was suspended,
+		// and its pipeline is no longer valid.
+		s.rollBack(res.replica)
+		s = nil
+	}
+	if s == nil {
+		return stream.SendAndClose(&pb.AppendResponse{
+			Status: pb.Status_APPEND_CONTINUATION_EXPIRED,
+			Header: res.Header,
+		})
+	}
+	addTrace(stream.Context(), "resuming suspended append => %s", s.appender)
+
+	s.appender.reqSuspend = false
+	return continueAppend(stream, res, &s.appender)
+}
+
+// suspendedAppend is an Append which was suspended by its client. It retains
+// sole ownership of the replica pipeline until it's resumed, or expires.
+type suspendedAppend struct {
+	token    string
+	appender appender
+	timer    *time.Timer
+}
+
+// suspendAppend stores the appender as the suspended Append of the replica,
+// which expires and is rolled back if not resumed within appendSuspendTimeout.
+// It returns the continuation token by which the Append may be resumed.
+func suspendAppend(r *replica, a appender) string {
+	var token = uuid.NewV4().String()
+	var s = &suspendedAppend{token: token, appender: a}
+
+	r.suspendedMu.Lock()
+	if r.suspended != nil {
+		panic("invariant violated: replica already has a suspended append")
+	}
+	r.suspended = s
+	s.timer = time.AfterFunc(appendSuspendTimeout, func() {
+		if s := takeSuspendedAppend(r, token); s != nil {
+			log.WithField("journal", r.journal).Warn("rolling back expired suspended append")
+			s.rollBack(r)
+		}
+	})
+	r.suspendedMu.Unlock()
+
+	return token
+}
+
+// takeSuspendedAppend returns and clears the suspended Append of the replica,
+// if it has continuation |token|, or if |token| is empty. Otherwise it
+// returns nil.
+func takeSuspendedAppend(r *replica, token string) *suspendedAppend {
+	r.suspendedMu.Lock()
+	defer r.suspendedMu.Unlock()
+
+	var s = r.suspended
+	if s == nil || (token != "" && s.token != token) {
+		return nil
+	}
+	s.timer.Stop()
+	r.suspended = nil
+	return s
+}
+
+// rollBack the content of the suspendedAppend, and release its pipeline.
+func (s *suspendedAppend) rollBack(r *replica) {
+	s.appender.onRecv(nil, errAppendSuspendExpired)
+
+	if err := releasePipelineAndGatherResponse(r.ctx, s.appender.pln, r.pipelineCh); err != nil {
+		log.WithFields(log.Fields{"err": err, "journal": r.journal}).
+			Warn("rollBack: pipeline failed")
+	}
+}
+
 // appender streams Append content through the pipeline, tracking the exact
 // Journal Fragment appended by the RPC and any client error.
 type appender struct {
@@ -175,6 +283,7 @@ type appender struct {
 	spec pb.JournalSpec_Fragment
 
 	reqCommit   bool
+	reqSuspend  bool
 	reqErr      error
 	reqFragment *pb.Fragment
 	reqSummer   hash.Hash
@@ -217,13 +326,22 @@ func (a *appender) onRecv(req *pb.AppendRequest, err error) bool {
 		}
 	}
 
-	if err == io.EOF && !a.reqCommit {
+	if err == io.EOF && a.reqSuspend {
+		// The Append is suspended. Spooled content is neither committed
+		// nor rolled back, until the Append is resumed or expires.
+		return false
+	} else if err == io.EOF && !a.reqCommit {
 		// EOF without first receiving an empty chunk is unexpected,
 		// and we treat it as a roll-back.
 		err = io.ErrUnexpectedEOF
-	} else if err == nil && a.reqCommit {
+	} else if err == nil && (a.reqCommit || a.reqSuspend) {
 		// *Not* reading an EOF after reading an empty chunk is also unexpected.
 		err = errExpectedEOF
+	} else if err == nil && req.Suspend {
+		// Empty, suspending chunk indicates an EOF will follow,
+		// at which point we suspend.
+		a.reqSuspend = true
+		return true
 	} else if err == nil && len(req.Content) == 0 {
 		// Empty chunk indicates an EOF will follow, at which point we commit.
 		a.reqCommit = true
@@ -268,13 +386,35 @@ func (a *appender) onRecv(req *pb.AppendRequest, err error) bool {
 	return false
 }
 
+// contentTypeMatches returns true if |declared| is empty, or the JournalSpec
+// has no ContentType label, or if their media types (ignoring parameters) match.
+func contentTypeMatches(declared string, spec *pb.JournalSpec) bool {
+	var label = spec.LabelSet.ValueOf(labels.ContentType)
+	if declared == "" || label == "" {
+		return true
+	}
+	// Both |declared| and |label| are validated to parse.
+	var d, _, _ = mime.ParseMediaType(declared)
+	var l, _, _ = mime.ParseMediaType(label)
+	return d == l
+}
+
 // String returns a debugging representation of the appender.
 func (a appender) String() string {
-	return fmt.Sprintf("appender<reqCommit: %t, reqErr: %v, reqFragment: %s>",
-		a.reqCommit, a.reqErr, a.reqFragment.String())
+	return fmt.Sprintf("appender<reqCommit: %t, reqSuspend: %t, reqErr: %v, reqFragment: %s>",
+		a.reqCommit, a.reqSuspend, a.reqErr, a.reqFragment.String())
 }
 
+// appendSuspendTimeout is the duration after which a suspended Append which
+// has not been resumed is rolled back, releasing the journal for other Appends.
+var appendSuspendTimeout = time.Minute
+
+// SetAppendSuspendTimeout sets the duration after which a suspended Append is
+// rolled back. It's advertised to clients via Header.AppendSuspendTimeout.
+func SetAppendSuspendTimeout(d time.Duration) { appendSuspendTimeout = d }
+
 var (
 	errExpectedEOF          = fmt.Errorf("expected EOF after empty Content chunk")
 	errExpectedContentChunk = fmt.Errorf("expected Content chunk")
+	errAppendSuspendExpired = fmt.Errorf("suspended append expired")
 )
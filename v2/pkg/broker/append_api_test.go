@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	gc "github.com/go-check/check"
 )
@@ -226,6 +228,135 @@ func (s *AppendSuite) TestRollbackCases(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `rpc error: code = Canceled desc = context canceled`)
 }
 
+func (s *AppendSuite) TestSuspendAndResumeCases(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	defer func(d time.Duration) { appendSuspendTimeout = d }(appendSuspendTimeout)
+
+	var broker = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReadyReplica)
+	var peer = newMockBroker(c, tf, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+
+	newTestJournal(c, tf, pb.JournalSpec{Name: "a/journal", Replication: 2}, broker.id, peer.id)
+	var res, _ = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "a/journal"})
+
+	var ctx = pb.WithDispatchDefault(tf.ctx)
+
+	// Case: client appends content, and then suspends the Append.
+	var stream, _ = broker.MustClient().Append(ctx)
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal"}), gc.IsNil)
+	expectPipelineSync(c, peer, res.Header)
+	expectUnackedSnappyProposal(c, peer)
+
+	c.Check(stream.Send(&pb.AppendRequest{Content: []byte("foo")}), gc.IsNil)
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Content: []byte("foo"), ContentDelta: 0})
+	c.Check(stream.Send(&pb.AppendRequest{Suspend: true}), gc.IsNil)
+
+	// Expect a continuation is returned, and that nothing was committed.
+	var resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+	c.Check(resp.Commit, gc.IsNil)
+	c.Check(resp.Continuation, gc.Not(gc.Equals), "")
+	var token = resp.Continuation
+
+	// Case: client attempts to resume with an unknown continuation.
+	stream, _ = broker.MustClient().Append(ctx)
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal", Continuation: "unknown"}), gc.IsNil)
+
+	resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp, gc.DeepEquals, &pb.AppendResponse{Status: pb.Status_APPEND_CONTINUATION_EXPIRED, Header: res.Header})
+
+	// Case: client resumes the Append, appends more content, and commits.
+	stream, _ = broker.MustClient().Append(ctx)
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal", Continuation: token}), gc.IsNil)
+	c.Check(stream.Send(&pb.AppendRequest{Content: []byte("bar")}), gc.IsNil)
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Content: []byte("bar"), ContentDelta: 3})
+	c.Check(stream.Send(&pb.AppendRequest{}), gc.IsNil)
+	c.Check(stream.CloseSend(), gc.IsNil)
+
+	// Expect a single commit of all content of the Append.
+	var commit = &pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            0,
+		End:              6,
+		Sum:              pb.SHA1SumOf("foobar"),
+		CompressionCodec: pb.CompressionCodec_SNAPPY,
+	}
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Proposal: commit, Acknowledge: true})
+	peer.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK} // Acknowledge.
+
+	resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp, gc.DeepEquals, &pb.AppendResponse{Status: pb.Status_OK, Header: res.Header, Commit: commit})
+
+	// Case: a suspended Append isn't resumed, and expires.
+	appendSuspendTimeout = time.Millisecond
+
+	stream, _ = broker.MustClient().Append(ctx)
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal"}), gc.IsNil)
+
+	// Expect the Spool is first rolled forward (as the journal's first write).
+	var rolled = &pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            6,
+		End:              6,
+		CompressionCodec: pb.CompressionCodec_SNAPPY,
+	}
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Proposal: rolled})
+
+	c.Check(stream.Send(&pb.AppendRequest{Content: []byte("baz")}), gc.IsNil)
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Content: []byte("baz"), ContentDelta: 0})
+	c.Check(stream.Send(&pb.AppendRequest{Suspend: true}), gc.IsNil)
+
+	resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	token = resp.Continuation
+
+	// Expect the configured timeout is advertised in resolved Headers.
+	var expiring, _ = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "a/journal"})
+	c.Check(expiring.Header.AppendSuspendTimeout, gc.Equals, time.Millisecond)
+
+	// A competing Append of the journal is started, which blocks until the
+	// suspended Append expires and releases the pipeline.
+	var competing, _ = broker.MustClient().Append(ctx)
+	c.Check(competing.Send(&pb.AppendRequest{Journal: "a/journal"}), gc.IsNil)
+	c.Check(competing.Send(&pb.AppendRequest{Content: []byte("qux")}), gc.IsNil)
+	c.Check(competing.Send(&pb.AppendRequest{}), gc.IsNil)
+	c.Check(competing.CloseSend(), gc.IsNil)
+
+	// Expect the replication peer receives a rollback upon expiry.
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Proposal: rolled, Acknowledge: true})
+	peer.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK} // Acknowledge.
+
+	// Expect the competing Append then proceeds, and commits only its content.
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Content: []byte("qux"), ContentDelta: 0})
+
+	commit = &pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            6,
+		End:              9,
+		Sum:              pb.SHA1SumOf("qux"),
+		CompressionCodec: pb.CompressionCodec_SNAPPY,
+	}
+	c.Check(<-peer.ReplReqCh, gc.DeepEquals, &pb.ReplicateRequest{Proposal: commit, Acknowledge: true})
+	peer.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK} // Acknowledge.
+
+	resp, err = competing.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+	c.Check(resp.Commit, gc.DeepEquals, commit)
+
+	// Case: client attempts to resume the expired Append.
+	stream, _ = broker.MustClient().Append(ctx)
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "a/journal", Continuation: token}), gc.IsNil)
+
+	resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_APPEND_CONTINUATION_EXPIRED)
+}
+
 func (s *AppendSuite) TestAppendOffsetReset(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -368,6 +499,21 @@ func (s *AppendSuite) TestRequestErrorCases(c *gc.C) {
 	c.Check(err, gc.IsNil)
 	c.Check(resp, gc.DeepEquals, &pb.AppendResponse{Status: pb.Status_NOT_ALLOWED, Header: res.Header})
 
+	// Case: declared content type which doesn't match the journal.
+	newTestJournal(c, tf, pb.JournalSpec{
+		Name:        "json/journal",
+		Replication: 1,
+		LabelSet:    pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+	}, broker.id)
+	res, _ = broker.resolve(resolveArgs{ctx: ctx, journal: "json/journal"})
+
+	stream, _ = broker.MustClient().Append(ctx)
+	c.Check(stream.Send(&pb.AppendRequest{Journal: "json/journal", ContentType: labels.ContentType_CSV}), gc.IsNil)
+
+	resp, err = stream.CloseAndRecv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp, gc.DeepEquals, &pb.AppendResponse{Status: pb.Status_WRONG_CONTENT_TYPE, Header: res.Header})
+
 	// Case: incorrect request Offset.
 	newTestJournal(c, tf, pb.JournalSpec{Name: "valid/journal", Replication: 1}, broker.id)
 	res, _ = broker.resolve(resolveArgs{ctx: ctx, journal: "valid/journal"})
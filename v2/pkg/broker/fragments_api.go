@@ -2,6 +2,7 @@ package broker
 
 import (
 	"context"
+	"io"
 	"sort"
 	"time"
 
@@ -72,6 +73,84 @@ func (svc *Service) ListFragments(ctx context.Context, req *pb.FragmentsRequest)
 	return resp, nil
 }
 
+// StreamFragments dispatches the JournalServer.StreamFragments API.
+func (svc *Service) StreamFragments(req *pb.FragmentsRequest, stream pb.Journal_StreamFragmentsServer) (err error) {
+	defer instrumentJournalServerOp("stream_fragments", &err, time.Now())
+
+	if err = req.Validate(); err != nil {
+		return err
+	}
+
+	var res resolution
+	res, err = svc.resolver.resolve(resolveArgs{
+		ctx:                   stream.Context(),
+		journal:               req.Journal,
+		mayProxy:              !req.DoNotProxy,
+		requirePrimary:        false,
+		requireFullAssignment: false,
+		proxyHeader:           req.Header,
+	})
+
+	if err != nil {
+		return err
+	} else if res.status != pb.Status_OK {
+		return stream.Send(&pb.FragmentsResponse{Status: res.status, Header: res.Header})
+	} else if !res.journalSpec.Flags.MayRead() {
+		return stream.Send(&pb.FragmentsResponse{Status: pb.Status_NOT_ALLOWED, Header: res.Header})
+	} else if res.replica == nil {
+		req.Header = &res.Header // Attach resolved Header to |req|, which we'll forward.
+		var ctx = pb.WithDispatchRoute(stream.Context(), req.Header.Route, req.Header.ProcessId)
+
+		var client pb.Journal_StreamFragmentsClient
+		if client, err = svc.jc.StreamFragments(ctx, req); err != nil {
+			return err
+		}
+		return proxyStreamFragments(stream, client)
+	}
+
+	if req.PageLimit == 0 {
+		req.PageLimit = int32(defaultPageLimit)
+	}
+
+	if err = res.replica.index.WaitForFirstRemoteRefresh(stream.Context()); err != nil {
+		err = pb.ExtendContext(err, "error waiting for index")
+		return err
+	}
+
+	// Send successive pages, each listed from the current index. Fragments
+	// which are added or removed between pages may or may not be included.
+	for {
+		var resp = &pb.FragmentsResponse{
+			Status: pb.Status_OK,
+			Header: res.Header,
+		}
+		if err = res.replica.index.Inspect(func(fragmentSet fragment.CoverSet) error {
+			resp.Fragments, resp.NextPageToken, err = listFragments(req, fragmentSet)
+			return err
+		}); err != nil {
+			return err
+		} else if err = stream.Send(resp); err != nil {
+			return err
+		} else if resp.NextPageToken == 0 {
+			return nil // All done.
+		}
+		req.NextPageToken = resp.NextPageToken
+	}
+}
+
+// proxyStreamFragments forwards each FragmentsResponse of |client| to |stream|.
+func proxyStreamFragments(stream pb.Journal_StreamFragmentsServer, client pb.Journal_StreamFragmentsClient) error {
+	for {
+		if resp, err := client.Recv(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		} else if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
 // List FragmentsResponse__Fragment matching the query, and return the
 // NextPageToken to be used for subsequent requests. If NextPageToken is nil
 // there are no further Fragments to enumerate.
@@ -2,6 +2,7 @@ package broker
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/client"
@@ -220,6 +221,75 @@ func (s *FragmentsSuite) TestListFragments(c *gc.C) {
 	})
 }
 
+func (s *FragmentsSuite) TestStreamFragments(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var broker = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReplica)
+	var peer = newMockBroker(c, tf, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+	var rc = client.NewRouteCache(10, time.Hour)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), rc)
+	var ctx = pb.WithDispatchDefault(tf.ctx)
+
+	var recvAll = func(req *pb.FragmentsRequest) (out []*pb.FragmentsResponse) {
+		var stream, err = rjc.StreamFragments(ctx, req)
+		c.Assert(err, gc.IsNil)
+
+		for {
+			var resp, err = stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			c.Assert(err, gc.IsNil)
+			out = append(out, resp)
+		}
+	}
+
+	// Case: Read from a write only journal.
+	newTestJournal(c, tf, pb.JournalSpec{Name: "write/only/journal", Replication: 1, Flags: pb.JournalSpec_O_WRONLY}, peer.id)
+	var res, _ = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "write/only/journal", mayProxy: true})
+	c.Check(recvAll(&pb.FragmentsRequest{Journal: "write/only/journal"}), gc.DeepEquals, []*pb.FragmentsResponse{
+		{Status: pb.Status_NOT_ALLOWED, Header: res.Header},
+	})
+
+	// Case: Fragments are streamed in pages of PageLimit.
+	var fixture = buildFragmentsFixture()
+	newTestJournal(c, tf, pb.JournalSpec{Name: "a/journal", Replication: 1}, broker.id)
+	res, _ = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "a/journal", mayProxy: true})
+	res.replica.index.ReplaceRemote(buildFragmentSet(fixture))
+
+	var oneSec = time.Second
+	c.Check(recvAll(&pb.FragmentsRequest{Journal: "a/journal", PageLimit: 2, SignatureTTL: &oneSec}),
+		gc.DeepEquals, []*pb.FragmentsResponse{
+			{Status: pb.Status_OK, Header: res.Header, Fragments: fixture[:2], NextPageToken: fixture[2].Spec.Begin},
+			{Status: pb.Status_OK, Header: res.Header, Fragments: fixture[2:4], NextPageToken: fixture[4].Spec.Begin},
+			{Status: pb.Status_OK, Header: res.Header, Fragments: fixture[4:]},
+		})
+
+	// Case: A listing is resumed from a NextPageToken, and may be a single page.
+	c.Check(recvAll(&pb.FragmentsRequest{Journal: "a/journal", NextPageToken: fixture[4].Spec.Begin}),
+		gc.DeepEquals, []*pb.FragmentsResponse{
+			{Status: pb.Status_OK, Header: res.Header, Fragments: []pb.FragmentsResponse__Fragment{
+				{Spec: fixture[4].Spec}, fixture[5]}},
+		})
+
+	// Case: Proxy request to peer.
+	newTestJournal(c, tf, pb.JournalSpec{Name: "peer/journal", Replication: 1}, peer.id)
+	res, _ = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "peer/journal", mayProxy: true})
+
+	peer.StreamFragmentsFunc = func(req *pb.FragmentsRequest, srv pb.Journal_StreamFragmentsServer) error {
+		c.Check(req.Header, gc.DeepEquals, &res.Header)
+
+		c.Check(srv.Send(&pb.FragmentsResponse{Header: res.Header, NextPageToken: 10}), gc.IsNil)
+		c.Check(srv.Send(&pb.FragmentsResponse{Header: res.Header}), gc.IsNil)
+		return nil
+	}
+	c.Check(recvAll(&pb.FragmentsRequest{Journal: "peer/journal"}), gc.DeepEquals, []*pb.FragmentsResponse{
+		{Header: res.Header, NextPageToken: 10},
+		{Header: res.Header},
+	})
+}
+
 // return a fixture which can be modified as needed over the course of a test.
 var buildFragmentsFixture = func() []pb.FragmentsResponse__Fragment {
 	return []pb.FragmentsResponse__Fragment{
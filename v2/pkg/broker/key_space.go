@@ -9,9 +9,11 @@ import (
 
 // NewKeySpace returns a KeySpace suitable for use with an Allocator.
 // It decodes allocator Items as JournalSpec messages, Members as BrokerSpecs,
-// and Assignments as Routes.
-func NewKeySpace(prefix string) *keyspace.KeySpace {
-	return allocator.NewAllocatorKeySpace(prefix, decoder{})
+// and Assignments as Routes. The allocated replication of each JournalSpec is
+// raised by matching ReplicationOverrides, and all brokers of a cluster must
+// use the same |overrides|.
+func NewKeySpace(prefix string, overrides ...pb.ReplicationOverride) *keyspace.KeySpace {
+	return allocator.NewAllocatorKeySpace(prefix, decoder{overrides: overrides})
 }
 
 // decoder is an instance of allocator.Decoder. It strictly enforces that
@@ -21,7 +23,14 @@ func NewKeySpace(prefix string) *keyspace.KeySpace {
 // themselves, making them a stand-alone representation and allowing for
 // content-addressing of a spec into its appropriate Etcd key. This decoder
 // behavior provides an assertion that these identifiers never diverge.
-type decoder struct{}
+//
+// The decoder is also an allocator.ReplicationDecoder, which raises the
+// allocated replication of JournalSpecs to that of any matching
+// ReplicationOverrides. Decoded JournalSpecs are not modified, and retain
+// their stored Replication.
+type decoder struct {
+	overrides []pb.ReplicationOverride
+}
 
 func (d decoder) DecodeItem(id string, raw *mvccpb.KeyValue) (allocator.ItemValue, error) {
 	var s = new(pb.JournalSpec)
@@ -36,6 +45,10 @@ func (d decoder) DecodeItem(id string, raw *mvccpb.KeyValue) (allocator.ItemValu
 	return s, nil
 }
 
+func (d decoder) ItemReplication(_ string, value allocator.ItemValue) int {
+	return int(pb.OverriddenReplication(value.(*pb.JournalSpec), d.overrides))
+}
+
 func (d decoder) DecodeMember(zone, suffix string, raw *mvccpb.KeyValue) (allocator.MemberValue, error) {
 	var s = new(pb.BrokerSpec)
 
@@ -2,10 +2,12 @@ package broker
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
 )
@@ -27,11 +29,80 @@ func (srv *Service) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResp
 
 	// TODO(johnny): Implement support for PageLimit & PageToken.
 
-	var metaLabels, allLabels pb.LabelSet
-
 	defer s.KS.Mu.RUnlock()
 	s.KS.Mu.RLock()
 
+	resp.Journals = listJournals(s, req.Selector)
+	return resp, nil
+}
+
+// WatchList dispatches the JournalServer.WatchList API.
+func (srv *Service) WatchList(req *pb.ListRequest, stream pb.Journal_WatchListServer) (err error) {
+	defer instrumentJournalServerOp("watchList", &err, time.Now())
+
+	if err = req.Validate(); err != nil {
+		return err
+	}
+	var s = srv.resolver.state
+	var prior map[pb.Journal]pb.ListResponse_Journal
+	var rev int64
+
+	for {
+		var journals []pb.ListResponse_Journal
+
+		s.KS.Mu.RLock()
+		if err = s.KS.WaitForRevision(stream.Context(), rev+1); err == nil {
+			rev = s.KS.Header.Revision
+			journals = listJournals(s, req.Selector)
+		}
+		s.KS.Mu.RUnlock()
+
+		if err == context.Canceled {
+			return nil // Gracefully terminate RPC.
+		} else if err != nil {
+			return err
+		}
+
+		// Build the Header only after awaiting the revision, so that it's at
+		// least as recent as the listing it accompanies.
+		var resp = &pb.ListResponse{
+			Status: pb.Status_OK,
+			Header: pb.NewUnroutedHeader(s),
+		}
+
+		var next = make(map[pb.Journal]pb.ListResponse_Journal, len(journals))
+		for _, j := range journals {
+			next[j.Spec.Name] = j
+
+			if p, ok := prior[j.Spec.Name]; !ok || p.ModRevision != j.ModRevision || !p.Route.Equivalent(&j.Route) {
+				resp.Journals = append(resp.Journals, j)
+			}
+		}
+		for name := range prior {
+			if _, ok := next[name]; !ok {
+				resp.RemovedJournals = append(resp.RemovedJournals, name)
+			}
+		}
+		sort.Slice(resp.RemovedJournals, func(i, j int) bool {
+			return resp.RemovedJournals[i] < resp.RemovedJournals[j]
+		})
+
+		// Always send the initial listing, but skip empty deltas.
+		if prior != nil && len(resp.Journals) == 0 && len(resp.RemovedJournals) == 0 {
+			continue
+		} else if err = stream.Send(resp); err != nil {
+			return err
+		}
+		prior = next
+	}
+}
+
+// listJournals returns the ListResponse_Journals of State which match the
+// LabelSelector. The State KeySpace lock must be held.
+func listJournals(s *allocator.State, sel pb.LabelSelector) []pb.ListResponse_Journal {
+	var out []pb.ListResponse_Journal
+	var metaLabels, allLabels pb.LabelSet
+
 	var it = allocator.LeftJoin{
 		LenL: len(s.Items),
 		LenR: len(s.Assignments),
@@ -48,16 +119,16 @@ func (srv *Service) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResp
 		metaLabels = pb.ExtractJournalSpecMetaLabels(&journal.Spec, metaLabels)
 		allLabels = pb.UnionLabelSets(metaLabels, journal.Spec.LabelSet, allLabels)
 
-		if !req.Selector.Matches(allLabels) {
+		if !sel.Matches(allLabels) {
 			continue
 		}
 		journal.ModRevision = s.Items[cur.Left].Raw.ModRevision
 		journal.Route.Init(s.Assignments[cur.RightBegin:cur.RightEnd])
 		journal.Route.AttachEndpoints(s.KS)
 
-		resp.Journals = append(resp.Journals, journal)
+		out = append(out, journal)
 	}
-	return resp, nil
+	return out
 }
 
 // Apply dispatches the JournalServer.Apply API.
@@ -73,6 +144,8 @@ func (srv *Service) Apply(ctx context.Context, req *pb.ApplyRequest) (*pb.ApplyR
 	}
 	if err = req.Validate(); err != nil {
 		return resp, err
+	} else if err = validatePlacements(s.KS, req.Changes); err != nil {
+		return resp, err
 	}
 
 	var cmp []clientv3.Cmp
@@ -104,3 +177,20 @@ func (srv *Service) Apply(ctx context.Context, req *pb.ApplyRequest) (*pb.ApplyR
 	}
 	return resp, nil
 }
+
+// validatePlacements returns an error if a JournalSpec upserted by |changes|
+// has a Placement which matches no current broker.
+func validatePlacements(ks *keyspace.KeySpace, changes []pb.ApplyRequest_Change) error {
+	defer ks.Mu.RUnlock()
+	ks.Mu.RLock()
+
+	for i, change := range changes {
+		if change.Upsert == nil || change.Upsert.Placement.IsEmpty() {
+			continue
+		} else if !allocator.HasPlaceableMember(ks, change.Upsert) {
+			return pb.ExtendContext(pb.NewValidationError("no brokers match selector (%s)",
+				change.Upsert.Placement.String()), "Changes[%d].Upsert.Placement", i)
+		}
+	}
+	return nil
+}
@@ -120,6 +120,80 @@ func (s *ListApplySuite) TestListCases(c *gc.C) {
 	etcdtest.Cleanup() // We wrote keys outside of |bk|'s lease, and must manually cleanup.
 }
 
+func (s *ListApplySuite) TestWatchListCases(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var fragSpec = pb.JournalSpec_Fragment{
+		Length:           1024,
+		RefreshInterval:  time.Second,
+		CompressionCodec: pb.CompressionCodec_SNAPPY,
+	}
+	var specA = pb.JournalSpec{Name: "journal/A", Replication: 1, Fragment: fragSpec}
+	var specB = pb.JournalSpec{Name: "journal/B", Replication: 1, Fragment: fragSpec}
+	var specC = pb.JournalSpec{Name: "journal/C", Replication: 1, Fragment: fragSpec}
+
+	var bk = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReplica)
+	var rjc = pb.NewRoutedJournalClient(bk.MustClient(), pb.NoopDispatchRouter{})
+	var ctx = pb.WithDispatchDefault(tf.ctx)
+
+	var apply = func(changes ...pb.ApplyRequest_Change) {
+		var resp, err = rjc.Apply(ctx, &pb.ApplyRequest{Changes: changes})
+		c.Assert(err, gc.IsNil)
+		c.Assert(resp.Status, gc.Equals, pb.Status_OK)
+	}
+	var names = func(resp *pb.ListResponse) (out []pb.Journal) {
+		for _, j := range resp.Journals {
+			out = append(out, j.Spec.Name)
+		}
+		return
+	}
+	apply(pb.ApplyRequest_Change{Upsert: &specA}, pb.ApplyRequest_Change{Upsert: &specB})
+
+	var stream, err = rjc.WatchList(ctx, &pb.ListRequest{})
+	c.Assert(err, gc.IsNil)
+
+	// Expect an initial, complete listing.
+	resp, err := stream.Recv()
+	c.Assert(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+	c.Check(names(resp), gc.DeepEquals, []pb.Journal{"journal/A", "journal/B"})
+	c.Check(resp.RemovedJournals, gc.IsNil)
+
+	var revB = resp.Journals[1].ModRevision
+
+	// Case: a created journal is streamed as a delta.
+	apply(pb.ApplyRequest_Change{Upsert: &specC})
+
+	resp, err = stream.Recv()
+	c.Assert(err, gc.IsNil)
+	c.Check(names(resp), gc.DeepEquals, []pb.Journal{"journal/C"})
+	c.Check(resp.RemovedJournals, gc.IsNil)
+
+	// Case: updated and removed journals are streamed as a delta.
+	specB.Labels = append(specB.Labels, pb.Label{Name: "foo", Value: "bar"})
+	apply(
+		pb.ApplyRequest_Change{Upsert: &specB, ExpectModRevision: revB},
+		pb.ApplyRequest_Change{Delete: "journal/A", ExpectModRevision: revB},
+	)
+
+	resp, err = stream.Recv()
+	c.Assert(err, gc.IsNil)
+	c.Check(names(resp), gc.DeepEquals, []pb.Journal{"journal/B"})
+	c.Check(resp.Journals[0].Spec, gc.DeepEquals, specB)
+	c.Check(resp.RemovedJournals, gc.DeepEquals, []pb.Journal{"journal/A"})
+
+	// Case: Errors on request validation error.
+	stream, err = rjc.WatchList(ctx, &pb.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("prefix", "invalid/because/missing/trailing/slash")},
+	})
+	c.Assert(err, gc.IsNil)
+	_, err = stream.Recv()
+	c.Check(err, gc.ErrorMatches, `.* Selector.Include.Labels\["prefix"\]: expected trailing '/' (.*)`)
+
+	etcdtest.Cleanup() // We wrote keys outside of |bk|'s lease, and must manually cleanup.
+}
+
 func (s *ListApplySuite) TestApplyCases(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -205,6 +279,13 @@ func (s *ListApplySuite) TestApplyCases(c *gc.C) {
 	})
 	c.Check(err, gc.ErrorMatches, `.* Changes\[0\].Delete: not a valid token \(invalid journal name\)`)
 
+	// Case: Upsert having a Placement matching no broker fails with an error.
+	specA.Placement = pb.LabelSelector{Include: pb.MustLabelSet("tier", "ssd")}
+	_, err = rjc.Apply(ctx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &specA}},
+	})
+	c.Check(err, gc.ErrorMatches, `.* Changes\[0\].Upsert.Placement: no brokers match selector \(tier=ssd,\)`)
+
 	etcdtest.Cleanup() // We wrote keys outside of |bk|'s lease, and must manually cleanup.
 }
 
@@ -86,7 +86,7 @@ func (pln *pipeline) synchronize() error {
 			// again. This time all peers should agree on the new Fragment.
 			proposal.Begin = rollToOffset
 			proposal.End = rollToOffset
-			proposal.Sum = pb.SHA1Sum{}
+			proposal.Sum, proposal.Sum256 = pb.SHA1Sum{}, pb.SHA256Sum{}
 			continue
 		}
 
@@ -1,12 +1,14 @@
 package broker
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"io/ioutil"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/codecs"
 	"github.com/LiveRamp/gazette/v2/pkg/fragment"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	log "github.com/sirupsen/logrus"
@@ -80,6 +82,7 @@ func proxyRead(stream grpc.ServerStream, req *pb.ReadRequest, jc pb.JournalClien
 // serveRead evaluates a client's Read RPC against the local replica index.
 func serveRead(stream grpc.ServerStream, req *pb.ReadRequest, hdr *pb.Header, index *fragment.Index) error {
 	var buffer = make([]byte, chunkSize)
+	var compressed bytes.Buffer
 	var reader io.ReadCloser
 
 	for i := 0; true; i++ {
@@ -126,9 +129,15 @@ func serveRead(stream grpc.ServerStream, req *pb.ReadRequest, hdr *pb.Header, in
 				continue
 			}
 
+			var content, codec = buffer[:n], pb.CompressionCodec_INVALID
+			if req.ContentCodec != pb.CompressionCodec_INVALID && req.ContentCodec != pb.CompressionCodec_NONE {
+				content, codec = compressChunk(content, req.ContentCodec, &compressed)
+			}
+
 			if err = stream.SendMsg(&pb.ReadResponse{
-				Offset:  req.Offset,
-				Content: buffer[:n],
+				Offset:       req.Offset,
+				Content:      content,
+				ContentCodec: codec,
 			}); err != nil {
 				return err
 			}
@@ -146,4 +155,24 @@ func serveRead(stream grpc.ServerStream, req *pb.ReadRequest, hdr *pb.Header, in
 	return nil
 }
 
+// compressChunk compresses |chunk| with |codec| into |buf|, returning the
+// compressed chunk and |codec|. If |chunk| cannot be compressed with |codec|
+// (eg, because it's not enabled in this build) or isn't made smaller by doing
+// so, |chunk| is instead returned with a zero-valued CompressionCodec.
+func compressChunk(chunk []byte, codec pb.CompressionCodec, buf *bytes.Buffer) ([]byte, pb.CompressionCodec) {
+	buf.Reset()
+
+	var cw, err = codecs.NewCodecWriter(buf, codec)
+	if err == nil {
+		_, err = cw.Write(chunk)
+	}
+	if err == nil {
+		err = cw.Close()
+	}
+	if err != nil || buf.Len() >= len(chunk) {
+		return chunk, pb.CompressionCodec_INVALID
+	}
+	return buf.Bytes(), codec
+}
+
 var chunkSize = 1 << 17 // 128K.
@@ -1,12 +1,14 @@
 package broker
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/codecs"
@@ -100,6 +102,56 @@ func (s *ReadSuite) TestStreaming(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `rpc error: code = Canceled .*`)
 }
 
+func (s *ReadSuite) TestCompressedContent(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var broker = newTestBroker(c, tf, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"}, newReplica)
+	newTestJournal(c, tf, pb.JournalSpec{Name: "a/journal", Replication: 2}, broker.id)
+
+	var res, _ = broker.resolve(resolveArgs{ctx: tf.ctx, journal: "a/journal"})
+	var spool, err = acquireSpool(tf.ctx, res.replica)
+	c.Check(err, gc.IsNil)
+
+	var compressible = strings.Repeat("compressible ", 100)
+	spool.MustApply(&pb.ReplicateRequest{Content: []byte(compressible)})
+	spool.MustApply(&pb.ReplicateRequest{Proposal: boxFragment(spool.Next())})
+
+	stream, err := broker.MustClient().Read(pb.WithDispatchDefault(tf.ctx),
+		&pb.ReadRequest{
+			Journal:      "a/journal",
+			Offset:       0,
+			DoNotProxy:   true,
+			ContentCodec: pb.CompressionCodec_SNAPPY,
+		})
+	c.Assert(err, gc.IsNil)
+	c.Check(stream.CloseSend(), gc.IsNil)
+
+	// Metadata response is not affected by the requested ContentCodec.
+	resp, err := stream.Recv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Fragment.End, gc.Equals, int64(len(compressible)))
+
+	// Expect content is compressed with the requested codec.
+	resp, err = stream.Recv()
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Offset, gc.Equals, int64(0))
+	c.Check(resp.ContentCodec, gc.Equals, pb.CompressionCodec_SNAPPY)
+	c.Check(len(resp.Content) < len(compressible), gc.Equals, true)
+
+	dec, err := codecs.NewCodecReader(bytes.NewReader(resp.Content), resp.ContentCodec)
+	c.Assert(err, gc.IsNil)
+	b, err := ioutil.ReadAll(dec)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, compressible)
+
+	// Content which isn't made smaller by compression is sent uncompressed.
+	var buf bytes.Buffer
+	var chunk, codec = compressChunk([]byte("abc"), pb.CompressionCodec_SNAPPY, &buf)
+	c.Check(string(chunk), gc.Equals, "abc")
+	c.Check(codec, gc.Equals, pb.CompressionCodec_INVALID)
+}
+
 func (s *ReadSuite) TestMetadataAndNonBlocking(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -2,6 +2,7 @@ package broker
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
@@ -10,6 +11,7 @@ import (
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // replica is a runtime instance of a journal which is assigned to this broker.
@@ -25,6 +27,10 @@ type replica struct {
 	spoolCh chan fragment.Spool
 	// pipelineCh synchronizes access to the single pipeline of the replica.
 	pipelineCh chan *pipeline
+	// suspended is an Append which was suspended by its client, and which
+	// holds ownership of the pipeline until it's resumed or expires.
+	suspended   *suspendedAppend
+	suspendedMu sync.Mutex
 	// pulsePipelineCh is signaled by the resolver when the replica is primary
 	// for the journal, and current assignments in Etcd are inconsistent.
 	// maintenanceLoop() reads the signal and drives a pipeline synchronization,
@@ -78,7 +84,11 @@ func acquireSpool(ctx context.Context, r *replica) (spool fragment.Spool, err er
 
 // acquirePipeline performs a blocking acquisition of the replica's single
 // pipeline, building a new pipeline if a ready instance doesn't already exist.
-func acquirePipeline(ctx context.Context, r *replica, hdr pb.Header, jc pb.JournalClient) (*pipeline, int64, error) {
+func acquirePipeline(ctx context.Context, r *replica, hdr pb.Header, jc pb.JournalClient) (_ *pipeline, _ int64, err error) {
+	var span trace.Span
+	ctx, span = pb.StartSpan(ctx, "broker.acquirePipeline")
+	defer func() { pb.EndSpan(span, err) }()
+
 	var pln *pipeline
 
 	select {
@@ -105,8 +115,6 @@ func acquirePipeline(ctx context.Context, r *replica, hdr pb.Header, jc pb.Journ
 		pln = nil
 	}
 
-	var err error
-
 	if pln == nil {
 		addTrace(ctx, " ... must build new pipeline")
 
@@ -138,7 +146,11 @@ func acquirePipeline(ctx context.Context, r *replica, hdr pb.Header, jc pb.Journ
 // routine release the pipeline for other goroutines to acquire, waits for all
 // prior readers of the ordered pipeline to complete, and gathers the single
 // expected response. Any encountered error is returned.
-func releasePipelineAndGatherResponse(ctx context.Context, pln *pipeline, releaseCh chan<- *pipeline) error {
+func releasePipelineAndGatherResponse(ctx context.Context, pln *pipeline, releaseCh chan<- *pipeline) (err error) {
+	var span trace.Span
+	ctx, span = pb.StartSpan(ctx, "broker.gatherReplicationResponse")
+	defer func() { pb.EndSpan(span, err) }()
+
 	// Retain sendErr(), as we cannot safely access it upon sending to |releaseCh|.
 	var sendErr = pln.sendErr()
 	var waitFor, closeAfter = pln.barrier()
@@ -173,7 +185,7 @@ func releasePipelineAndGatherResponse(ctx context.Context, pln *pipeline, releas
 	// recvErr()s are generally more informational that sendErr()s:
 	// gRPC SendMsg returns io.EOF on remote stream breaks, while RecvMsg
 	// returns the actual causal error.
-	if err := pln.recvErr(); err != nil {
+	if err = pln.recvErr(); err != nil {
 		return err
 	}
 	return sendErr
@@ -181,14 +193,21 @@ func releasePipelineAndGatherResponse(ctx context.Context, pln *pipeline, releas
 
 // shutDownReplica drains replica pipeline & spool channels and cancels its context.
 func shutDownReplica(r *replica) {
-	if pln := <-r.pipelineCh; pln != nil && pln.readThroughRev == 0 {
+	// A suspended Append holds the pipeline. Discard it, and take its pipeline.
+	var pln *pipeline
+	if s := takeSuspendedAppend(r, ""); s != nil {
+		pln = s.appender.pln
+	} else {
+		pln = <-r.pipelineCh
+	}
+	if pln != nil && pln.readThroughRev == 0 {
 		pln.shutdown(false)
 	}
 	var sp = <-r.spoolCh
 
 	// Roll the Spool forward to finalize & persist its current Fragment.
 	var proposal = sp.Fragment.Fragment
-	proposal.Begin, proposal.Sum = proposal.End, pb.SHA1Sum{}
+	proposal.Begin, proposal.Sum, proposal.Sum256 = proposal.End, pb.SHA1Sum{}, pb.SHA256Sum{}
 	sp.MustApply(&pb.ReplicateRequest{Proposal: &proposal})
 
 	// We intentionally don't return the pipeline or spool to their channels.
@@ -309,7 +328,7 @@ func nextProposal(cur fragment.Spool, spec pb.JournalSpec_Fragment) pb.Fragment
 	if flushFragment {
 		var next = cur.Fragment.Fragment
 		next.Begin = next.End
-		next.Sum = pb.SHA1Sum{}
+		next.Sum, next.Sum256 = pb.SHA1Sum{}, pb.SHA256Sum{}
 		next.CompressionCodec = spec.CompressionCodec
 
 		return next
@@ -11,6 +11,8 @@ import (
 	"github.com/coreos/etcd/clientv3"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // resolver maps journals to responsible broker instances and, potentially, a local replica.
@@ -59,6 +61,9 @@ type resolution struct {
 	pb.Header
 	// JournalSpec of the Journal at the current Etcd Revision.
 	journalSpec *pb.JournalSpec
+	// Effective replication of the Journal, which may exceed that of its
+	// JournalSpec due to ReplicationOverrides.
+	replication int
 	// Assignments of the Journal at the current Etcd Revision.
 	assignments keyspace.KeyValues
 	// replica of the local assigned journal, iff the journal was resolved to this broker
@@ -67,6 +72,11 @@ type resolution struct {
 }
 
 func (r *resolver) resolve(args resolveArgs) (res resolution, err error) {
+	var span trace.Span
+	args.ctx, span = pb.StartSpan(args.ctx, "broker.resolve",
+		trace.WithAttributes(attribute.String("journal", args.journal.String())))
+	defer func() { pb.EndSpan(span, err) }()
+
 	var ks = r.state.KS
 	defer ks.Mu.RUnlock()
 	ks.Mu.RLock()
@@ -114,10 +124,13 @@ func (r *resolver) resolve(args resolveArgs) (res resolution, err error) {
 			args.minEtcdRevision, ks.Header.Revision)
 	}
 	res.Etcd = pb.FromEtcdResponseHeader(ks.Header)
+	res.BuildVersion, res.Capabilities = pb.BuildVersion, pb.LocalCapabilities
+	res.AppendSuspendTimeout = appendSuspendTimeout
 
 	// Extract JournalSpec.
 	if item, ok := allocator.LookupItem(ks, args.journal.String()); ok {
 		res.journalSpec = item.ItemValue.(*pb.JournalSpec)
+		res.replication = item.DesiredReplication()
 	}
 	// Extract Assignments and build Route.
 	res.assignments = ks.KeyValues.Prefixed(
@@ -150,7 +163,7 @@ func (r *resolver) resolve(args resolveArgs) (res resolution, err error) {
 		res.status = pb.Status_NO_JOURNAL_PRIMARY_BROKER
 	} else if len(res.Route.Members) == 0 {
 		res.status = pb.Status_INSUFFICIENT_JOURNAL_BROKERS
-	} else if args.requireFullAssignment && len(res.Route.Members) < int(res.journalSpec.Replication) {
+	} else if args.requireFullAssignment && len(res.Route.Members) < res.replication {
 		res.status = pb.Status_INSUFFICIENT_JOURNAL_BROKERS
 	} else if !args.mayProxy && res.ProcessId != localID {
 		if args.requirePrimary {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 	gc "github.com/go-check/check"
 )
 
@@ -153,7 +154,10 @@ func (s *ResolverSuite) TestFutureRevisionCasesWithProxyHeader(c *gc.C) {
 			Primary:   0,
 			Endpoints: []pb.Endpoint{broker.Endpoint()},
 		},
-		Etcd: pb.FromEtcdResponseHeader(tf.ks.Header),
+		Etcd:                 pb.FromEtcdResponseHeader(tf.ks.Header),
+		BuildVersion:         pb.BuildVersion,
+		Capabilities:         pb.LocalCapabilities,
+		AppendSuspendTimeout: appendSuspendTimeout,
 	}
 	hdr.Etcd.Revision += 1
 
@@ -208,4 +212,39 @@ func (s *ResolverSuite) TestProxyHeaderErrorCases(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `proxied request Etcd ClusterId doesn't match our own \(\d+.*`)
 }
 
+func (s *ResolverSuite) TestDecoderReplicationOverrides(c *gc.C) {
+	var override, err = pb.ParseReplicationOverride("3:tier=critical")
+	c.Assert(err, gc.IsNil)
+	var d = decoder{overrides: []pb.ReplicationOverride{override}}
+
+	var spec = pb.JournalSpec{
+		Name:        "a/journal",
+		Replication: 1,
+		LabelSet:    pb.MustLabelSet("tier", "critical"),
+		Fragment: pb.JournalSpec_Fragment{
+			Length:           1024,
+			CompressionCodec: pb.CompressionCodec_NONE,
+			RefreshInterval:  time.Second,
+		},
+	}
+	raw, err := spec.Marshal()
+	c.Assert(err, gc.IsNil)
+
+	// Expect the decoded JournalSpec retains its stored Replication,
+	// while its allocated replication is raised by the override.
+	value, err := d.DecodeItem("a/journal", &mvccpb.KeyValue{Value: raw})
+	c.Assert(err, gc.IsNil)
+	c.Check(value.(*pb.JournalSpec).Replication, gc.Equals, int32(1))
+	c.Check(d.ItemReplication("a/journal", value), gc.Equals, 3)
+
+	// A JournalSpec not matched by the override is unaffected.
+	spec.LabelSet = pb.LabelSet{}
+	raw, err = spec.Marshal()
+	c.Assert(err, gc.IsNil)
+
+	value, err = d.DecodeItem("a/journal", &mvccpb.KeyValue{Value: raw})
+	c.Assert(err, gc.IsNil)
+	c.Check(d.ItemReplication("a/journal", value), gc.Equals, 1)
+}
+
 var _ = gc.Suite(&ResolverSuite{})
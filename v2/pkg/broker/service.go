@@ -11,7 +11,6 @@ import (
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
 	log "github.com/sirupsen/logrus"
-	"golang.org/x/net/trace"
 )
 
 // Service is the top-level runtime concern of a Gazette Broker process. It
@@ -165,9 +164,7 @@ func (svc *Service) maintenanceLoop(r *replica) {
 }
 
 func addTrace(ctx context.Context, format string, args ...interface{}) {
-	if tr, ok := trace.FromContext(ctx); ok {
-		tr.LazyPrintf(format, args...)
-	}
+	pb.AddTrace(ctx, format, args...)
 }
 
 // instrumentJournalServerOp measures and reports the response time of
@@ -10,6 +10,8 @@ import (
 	gc "github.com/go-check/check"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // LoopbackServer serves a JournalServer over a loopback, for use within tests.
@@ -56,9 +58,11 @@ type Broker struct {
 	AppendReqCh  chan *pb.AppendRequest
 	AppendRespCh chan *pb.AppendResponse
 
-	ListFunc          func(context.Context, *pb.ListRequest) (*pb.ListResponse, error)
-	ApplyFunc         func(context.Context, *pb.ApplyRequest) (*pb.ApplyResponse, error)
-	ListFragmentsFunc func(context.Context, *pb.FragmentsRequest) (*pb.FragmentsResponse, error)
+	ListFunc            func(context.Context, *pb.ListRequest) (*pb.ListResponse, error)
+	WatchListFunc       func(*pb.ListRequest, pb.Journal_WatchListServer) error
+	ApplyFunc           func(context.Context, *pb.ApplyRequest) (*pb.ApplyResponse, error)
+	ListFragmentsFunc   func(context.Context, *pb.FragmentsRequest) (*pb.FragmentsResponse, error)
+	StreamFragmentsFunc func(*pb.FragmentsRequest, pb.Journal_StreamFragmentsServer) error
 
 	ErrCh chan error
 }
@@ -198,6 +202,11 @@ func (p *Broker) List(ctx context.Context, req *pb.ListRequest) (*pb.ListRespons
 	return p.ListFunc(ctx, req)
 }
 
+// WatchList implements the JournalServer interface by proxying through WatchListFunc.
+func (p *Broker) WatchList(req *pb.ListRequest, srv pb.Journal_WatchListServer) error {
+	return p.WatchListFunc(req, srv)
+}
+
 // Apply implements the JournalServer interface by proxying through ApplyFunc.
 func (p *Broker) Apply(ctx context.Context, req *pb.ApplyRequest) (*pb.ApplyResponse, error) {
 	return p.ApplyFunc(ctx, req)
@@ -208,4 +217,14 @@ func (p *Broker) ListFragments(ctx context.Context, req *pb.FragmentsRequest) (*
 	return p.ListFragmentsFunc(ctx, req)
 }
 
+// StreamFragments implements the JournalServer interface by proxying through
+// StreamFragmentsFunc. If StreamFragmentsFunc is nil, the RPC is Unimplemented
+// (as it is by brokers which predate it).
+func (p *Broker) StreamFragments(req *pb.FragmentsRequest, srv pb.Journal_StreamFragmentsServer) error {
+	if p.StreamFragmentsFunc == nil {
+		return status.Error(codes.Unimplemented, "StreamFragments is not implemented")
+	}
+	return p.StreamFragmentsFunc(req, srv)
+}
+
 func init() { pb.RegisterGRPCDispatcher("local") }
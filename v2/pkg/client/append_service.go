@@ -182,6 +182,9 @@ type AsyncAppend struct {
 	checkpoint   int64          // Buffer |fb| offset to append through.
 	err          error          // Retained Require(error) or aborting Context error.
 
+	callbacks []CommitCallback // Callbacks of the current writer, not yet Released.
+	segments  []appendSegment  // Released writes having callbacks or a Context.
+
 	mu   *sync.Mutex  // Shared mutex over all AsyncAppends of the journal.
 	next *AsyncAppend // Next ordered AsyncAppend of the journal.
 }
@@ -204,6 +207,26 @@ func (p *AsyncAppend) Require(err error) *AsyncAppend {
 	return p
 }
 
+// CommitCallback is invoked upon completion of content written to an
+// AsyncAppend by a single caller (between StartAppend and Release). If the
+// content committed, |begin| and |end| are the journal offsets at which it was
+// written and |err| is nil. Otherwise, |err| is the causal Context error and
+// |begin| and |end| are zero.
+type CommitCallback func(begin, end int64, err error)
+
+// OnCommit registers a CommitCallback to be invoked with the final offset
+// range of content written by the caller, once the AsyncAppend completes.
+// Unlike Response, which reflects the entire batch written to the broker, the
+// callback range covers only the caller's own writes. OnCommit is valid for use
+// only until Release is called. If Release rolls back the caller's writes,
+// registered callbacks are discarded and never invoked. Callbacks are invoked
+// in write order from the AppendService's service loop, and must not block.
+// OnCommit returns itself, allowing uses like `OnCommit(fn).Release()`.
+func (p *AsyncAppend) OnCommit(cb CommitCallback) *AsyncAppend {
+	p.callbacks = append(p.callbacks, cb)
+	return p
+}
+
 // Release the AsyncAppend, allowing further writes to queue or for it to be
 // dispatched to the brokers. Release first determines whether a previous Require
 // failed, or if a Writer error occurred, in which case it will roll back all
@@ -211,19 +234,41 @@ func (p *AsyncAppend) Require(err error) *AsyncAppend {
 // non-nil error. A non-nil error is returned if and only if the Append was
 // rolled back. Otherwise, the caller may then select on Done to determine when
 // the AsyncAppend has committed and its Response may be examined.
-func (p *AsyncAppend) Release() error {
+func (p *AsyncAppend) Release() error { return p.release(nil) }
+
+// ReleaseContext is like Release, but additionally allows the caller's queued
+// writes to be abandoned by cancelling |ctx|. If |ctx| is Done before the Append
+// RPC of the AsyncAppend begins, the caller's writes are omitted from the RPC
+// (writes of other callers batched into the AsyncAppend are unaffected), and
+// registered CommitCallbacks are invoked with the |ctx| error. Once the RPC has
+// begun, cancellation of |ctx| has no effect.
+func (p *AsyncAppend) ReleaseContext(ctx context.Context) error { return p.release(ctx) }
+
+func (p *AsyncAppend) release(ctx context.Context) error {
 	// Require that a bufio.Writer error is not set.
 	var _, err = p.fb.buf.Write(nil)
 	p.Require(err)
 
 	// Swap and test whether |p.err| was set.
 	if err, p.err = p.err, nil; err != nil {
+		p.callbacks = nil
+
 		// rollback in background, as it may block until an underlying disk
 		// error is resolved. Note |mu| is still held until rollback completes.
 		go p.rollback()
 		return err
 	}
-	p.checkpoint = p.fb.offset + int64(p.fb.buf.Buffered())
+	var checkpoint = p.fb.offset + int64(p.fb.buf.Buffered())
+
+	if ctx != nil || len(p.callbacks) != 0 {
+		p.segments = append(p.segments, appendSegment{
+			begin:     p.checkpoint,
+			end:       checkpoint,
+			ctx:       ctx,
+			callbacks: p.callbacks,
+		})
+	}
+	p.checkpoint, p.callbacks = checkpoint, nil
 	p.mu.Unlock()
 
 	return nil
@@ -239,6 +284,56 @@ func (p *AsyncAppend) rollback() {
 	p.mu.Unlock()
 }
 
+// abandonSegments marks each segment having a Done Context as abandoned.
+// It's called just prior to beginning the Append RPC.
+func (p *AsyncAppend) abandonSegments() {
+	for i := range p.segments {
+		if ctx := p.segments[i].ctx; ctx != nil && ctx.Err() != nil {
+			p.segments[i].err = ctx.Err()
+		}
+	}
+}
+
+// content returns a Reader of buffered content through |p.checkpoint|,
+// which omits the content of abandoned segments.
+func (p *AsyncAppend) content() io.Reader {
+	var readers []io.Reader
+	var offset int64
+
+	for _, seg := range p.segments {
+		if seg.err != nil {
+			readers = append(readers, io.NewSectionReader(p.fb.file, offset, seg.begin-offset))
+			offset = seg.end
+		}
+	}
+	if readers == nil {
+		return io.NewSectionReader(p.fb.file, 0, p.checkpoint) // Common case.
+	}
+	readers = append(readers, io.NewSectionReader(p.fb.file, offset, p.checkpoint-offset))
+	return io.MultiReader(readers...)
+}
+
+// notifySegments invokes the CommitCallbacks of each segment, mapping segment
+// buffer offsets to offsets of the committed journal Fragment.
+func (p *AsyncAppend) notifySegments() {
+	var skipped int64 // Buffer bytes of abandoned segments not written.
+
+	for _, seg := range p.segments {
+		var begin, end, err = int64(0), int64(0), seg.err
+
+		if err != nil {
+			skipped += seg.end - seg.begin
+		} else if err = p.err; err == nil {
+			begin = p.app.Response.Commit.Begin + seg.begin - skipped
+			end = p.app.Response.Commit.Begin + seg.end - skipped
+		}
+		for _, cb := range seg.callbacks {
+			cb(begin, end, err)
+		}
+	}
+	p.segments = nil
+}
+
 // Request returns the AppendRequest that was or will be made by this AsyncAppend.
 // Request is safe to call at all times.
 func (p *AsyncAppend) Request() pb.AppendRequest { return p.app.Request }
@@ -308,9 +403,10 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 
 		if aa.fb != nil {
 			retryUntil(aa.fb.flush, aa.app.Request.Journal, "failed to flush appendBuffer")
+			aa.abandonSegments()
 
 			retryUntil(func() error {
-				var _, err = io.Copy(&aa.app, io.NewSectionReader(aa.fb.file, 0, aa.checkpoint))
+				var _, err = io.Copy(&aa.app, aa.content())
 				if err == nil {
 					err = aa.app.Close()
 				}
@@ -330,6 +426,7 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 		close(aa.commitCh) // Notify clients & dependent appends of completion.
 
 		if aa.fb != nil {
+			aa.notifySegments()
 			releaseFileBuffer(aa.fb)
 		}
 
@@ -338,6 +435,15 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 	}
 }
 
+// appendSegment is a range of content written by a single caller to an
+// AsyncAppend, which has registered CommitCallbacks or a cancellation Context.
+type appendSegment struct {
+	begin, end int64           // Buffer |fb| offsets of the segment content.
+	ctx        context.Context // Optional Context which abandons the segment.
+	err        error           // Error of an abandoned segment.
+	callbacks  []CommitCallback
+}
+
 // appendBuffer composes a backing File with a bufio.Writer, and additionally
 // tracks the offset through which the file is written.
 type appendBuffer struct {
@@ -229,6 +229,78 @@ func (s *AppendServiceSuite) TestAppendContextCancellation(c *gc.C) {
 	c.Check(aa3.Err(), gc.Equals, context.Canceled)
 }
 
+func (s *AppendServiceSuite) TestCommitCallbacksAndAbandonment(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var as = NewAppendService(ctx, rjc)
+
+	var serveCh, cleanup = gateServeAppends()
+	defer cleanup()
+
+	type result struct {
+		id         string
+		begin, end int64
+		err        error
+	}
+	var results []result
+	var record = func(id string) CommitCallback {
+		return func(begin, end int64, err error) {
+			results = append(results, result{id, begin, end, err})
+		}
+	}
+	var abandonCtx, abandon = context.WithCancel(context.Background())
+
+	var aa = as.StartAppend("a/journal")
+	aa.Writer().WriteString("hello")
+	c.Check(aa.OnCommit(record("one")).Release(), gc.IsNil)
+
+	aa = as.StartAppend("a/journal")
+	aa.Writer().WriteString("[abandoned]")
+	c.Check(aa.OnCommit(record("two")).ReleaseContext(abandonCtx), gc.IsNil)
+
+	aa = as.StartAppend("a/journal")
+	aa.Writer().WriteString(", ")
+	c.Check(aa.Release(), gc.IsNil) // No callback.
+
+	aa = as.StartAppend("a/journal")
+	aa.Writer().WriteString("rolled back")
+	aa.OnCommit(record("rollback")).Require(errors.New("whoops"))
+	c.Check(aa.Release(), gc.ErrorMatches, "whoops")
+
+	aa = as.StartAppend("a/journal")
+	aa.Writer().WriteString("world")
+	c.Check(aa.OnCommit(record("three")).OnCommit(record("four")).
+		ReleaseContext(context.Background()), gc.IsNil)
+
+	// Abandon the second write before serveAppends begins the RPC.
+	abandon()
+	close(serveCh)
+
+	// Expect abandoned content is omitted from the RPC.
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("hello")})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte(", world")})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+	c.Check(<-broker.AppendReqCh, gc.IsNil)
+
+	broker.AppendRespCh <- buildAppendResponseFixture(broker)
+
+	<-aa.Done()
+	WaitForPendingAppends(as.PendingExcept("")) // Callbacks have run.
+
+	// Expect callbacks were invoked in write order, with offsets relative to
+	// the committed Fragment Begin (100), and excluding abandoned content.
+	c.Check(results, gc.DeepEquals, []result{
+		{"one", 100, 105, nil},
+		{"two", 0, 0, context.Canceled},
+		{"three", 107, 112, nil},
+		{"four", 107, 112, nil},
+	})
+}
+
 func (s *AppendServiceSuite) TestFlushErrorHandlingCases(c *gc.C) {
 	var mf = mockFile{n: 6}
 
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"errors"
 	"io"
 	"math"
 	"time"
@@ -63,10 +62,30 @@ func (a *Appender) Write(p []byte) (n int, err error) {
 // written content. If Close returns without an error, Append.Response
 // will hold the broker response.
 func (a *Appender) Close() (err error) {
-	// Send an empty chunk to signal commit of previously written content
+	// Send an empty chunk to signal commit of previously written content.
+	return a.finish(new(pb.AppendRequest))
+}
+
+// Suspend the Append, rather than committing it. The broker retains previously
+// written content without committing it, and if Suspend returns without an
+// error, Append.Response.Continuation holds a token by which the Append may
+// be resumed. A following Appender which sets the token as its
+// Request.Continuation extends the suspended Append, and commits all of its
+// content upon Close. Suspended Appends which aren't promptly resumed are
+// rolled back by the broker. As with any in-progress Append, a suspended
+// Append prevents the broker from serving other Appends of the journal.
+//
+// Brokers which pre-date suspension interpret Suspend as a commit. Clients
+// should first verify the broker Header Supports pb.Capability_APPEND_CONTINUATION.
+func (a *Appender) Suspend() error {
+	return a.finish(&pb.AppendRequest{Suspend: true})
+}
+
+// finish the Append by sending the final |chunk|, and reading the response.
+func (a *Appender) finish(chunk *pb.AppendRequest) (err error) {
 	if err = a.lazyInit(); err != nil {
 		// Pass.
-	} else if err = a.sendMsg(new(pb.AppendRequest)); err != nil {
+	} else if err = a.sendMsg(chunk); err != nil {
 		// Pass.
 	} else if _ = a.stream.CloseSend(); false {
 		// Ignore CloseSend's error. Currently, gRPC will never return one. If the
@@ -82,12 +101,19 @@ func (a *Appender) Close() (err error) {
 		switch a.Response.Status {
 		case pb.Status_OK:
 			// Pass.
+		case pb.Status_NOT_JOURNAL_BROKER:
+			// Invalidate our (likely stale) Route, such that a retry
+			// will be dispatched to the default service address.
+			a.client.UpdateRoute(a.Request.Journal.String(), nil)
+			err = ErrNotJournalBroker
 		case pb.Status_NOT_JOURNAL_PRIMARY_BROKER:
 			err = ErrNotJournalPrimaryBroker
 		case pb.Status_WRONG_APPEND_OFFSET:
 			err = ErrWrongAppendOffset
+		case pb.Status_APPEND_CONTINUATION_EXPIRED:
+			err = ErrAppendContinuationExpired
 		default:
-			err = errors.New(a.Response.Status.String())
+			err = StatusError(a.Response.Status)
 		}
 	}
 
@@ -157,7 +183,7 @@ func Append(ctx context.Context, rjc pb.RoutedJournalClient, req pb.AppendReques
 			return a.Response, nil
 		} else if s, ok := status.FromError(err); ok && s.Code() == codes.Unavailable {
 			// Fallthrough to retry
-		} else if err == ErrNotJournalPrimaryBroker {
+		} else if err == ErrNotJournalPrimaryBroker || err == ErrNotJournalBroker {
 			// Fallthrough.
 		} else {
 			return a.Response, err
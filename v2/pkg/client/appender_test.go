@@ -63,6 +63,63 @@ func (s *AppenderSuite) TestCommitSuccess(c *gc.C) {
 	})
 }
 
+func (s *AppenderSuite) TestSuspendAndResume(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+
+	go func() {
+		// Expect the first Appender suspends, rather than commits.
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("foo")})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Suspend: true})
+		c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+
+		broker.AppendRespCh <- &pb.AppendResponse{
+			Status:       pb.Status_OK,
+			Header:       *buildHeaderFixture(broker),
+			Continuation: "a-token",
+		}
+
+		// Expect the second Appender resumes, and commits.
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals,
+			&pb.AppendRequest{Journal: "a/journal", Continuation: "a-token"})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("bar")})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+		c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+
+		broker.AppendRespCh <- &pb.AppendResponse{
+			Status: pb.Status_OK,
+			Header: *buildHeaderFixture(broker),
+			Commit: &pb.Fragment{
+				Journal:          "a/journal",
+				Begin:            100,
+				End:              106,
+				Sum:              pb.SHA1SumOf("foobar"),
+				CompressionCodec: pb.CompressionCodec_NONE,
+			},
+		}
+	}()
+
+	var a = NewAppender(ctx, rjc, pb.AppendRequest{Journal: "a/journal"})
+	var _, err = a.Write([]byte("foo"))
+	c.Check(err, gc.IsNil)
+	c.Check(a.Suspend(), gc.IsNil)
+	c.Check(a.Response.Commit, gc.IsNil)
+	c.Check(a.Response.Continuation, gc.Equals, "a-token")
+
+	a = NewAppender(ctx, rjc, pb.AppendRequest{
+		Journal:      "a/journal",
+		Continuation: a.Response.Continuation,
+	})
+	_, err = a.Write([]byte("bar"))
+	c.Check(err, gc.IsNil)
+	c.Check(a.Close(), gc.IsNil)
+	c.Check(a.Response.Commit.End, gc.Equals, int64(106))
+}
+
 func (s *AppenderSuite) TestBrokerWriteError(c *gc.C) {
 	var ctx, cancel = context.WithCancel(context.Background())
 	defer cancel()
@@ -130,6 +187,17 @@ func (s *AppenderSuite) TestBrokerCommitError(c *gc.C) {
 			errVal:      ErrNotJournalPrimaryBroker,
 			cachedRoute: 1,
 		},
+		// Case: known error status (not a journal broker). The stale Route is invalidated.
+		{
+			finish: func() {
+				broker.AppendRespCh <- &pb.AppendResponse{
+					Status: pb.Status_NOT_JOURNAL_BROKER,
+					Header: *buildHeaderFixture(broker),
+				}
+			},
+			errVal:      ErrNotJournalBroker,
+			cachedRoute: 0,
+		},
 		// Case: known error status (wrong append offset).
 		{
 			finish: func() {
@@ -141,6 +209,17 @@ func (s *AppenderSuite) TestBrokerCommitError(c *gc.C) {
 			errVal:      ErrWrongAppendOffset,
 			cachedRoute: 1,
 		},
+		// Case: known error status (append continuation expired).
+		{
+			finish: func() {
+				broker.AppendRespCh <- &pb.AppendResponse{
+					Status: pb.Status_APPEND_CONTINUATION_EXPIRED,
+					Header: *buildHeaderFixture(broker),
+				}
+			},
+			errVal:      ErrAppendContinuationExpired,
+			cachedRoute: 1,
+		},
 		// Case: other error status.
 		{
 			finish: func() {
@@ -257,6 +336,7 @@ func (s *AppenderSuite) TestAppendCases(c *gc.C) {
 	// Case 2: Unexpected status is surfaced.
 	_, err = Append(ctx, rjc, pb.AppendRequest{Journal: "a/journal"}, con, tent)
 	c.Check(err, gc.ErrorMatches, "INSUFFICIENT_JOURNAL_BROKERS")
+	c.Check(err, gc.Equals, ErrInsufficientJournalBrokers)
 
 	// Case 3: As are errors.
 	_, err = Append(ctx, rjc, pb.AppendRequest{Journal: "a/journal"}, con, tent)
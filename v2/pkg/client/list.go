@@ -2,17 +2,21 @@ package client
 
 import (
 	"context"
-	"errors"
+	"io"
+	"sort"
 	"sync/atomic"
 	"time"
 
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// PolledList performs periodic polls of a ListRequest. Its most recent
-// polled result may be accessed via List.
+// PolledList performs periodic polls of a ListRequest, or alternatively
+// watches the ListRequest for changes (see NewWatchedList). Its most recent
+// result may be accessed via List.
 type PolledList struct {
 	ctx    context.Context
 	client pb.JournalClient
@@ -58,6 +62,117 @@ func (pl *PolledList) periodicRefresh(dur time.Duration) {
 	}
 }
 
+// NewWatchedList returns a PolledList of the ListRequest which is initialized
+// and ready for immediate use. Rather than polling, the PolledList is kept
+// current by a WatchList RPC, which streams deltas of the listing as they occur.
+// An error encountered in opening the first WatchList RPC is returned.
+// Subsequent RPC errors will be logged as warnings, and the WatchList RPC
+// will be restarted after a brief delay.
+func NewWatchedList(ctx context.Context, client pb.JournalClient, req pb.ListRequest) (*PolledList, error) {
+	var pl = &PolledList{ctx: ctx, client: client, req: req}
+
+	var stream, err = pl.openWatch()
+	if err != nil {
+		return nil, err
+	}
+	go pl.watch(stream)
+	return pl, nil
+}
+
+// openWatch begins a WatchList RPC, and reads and stores its initial listing.
+func (pl *PolledList) openWatch() (pb.Journal_WatchListClient, error) {
+	// WatchList RPCs may be dispatched to any broker.
+	var stream, err = pl.client.WatchList(pb.WithDispatchDefault(pl.ctx), &pl.req, grpc.FailFast(false))
+	if err != nil {
+		return nil, mapGRPCCtxErr(pl.ctx, err)
+	}
+	resp, err := recvWatchListResponse(pl.ctx, stream)
+	if err != nil {
+		return nil, err
+	}
+	updateJournalRoutes(pl.client, resp.Journals)
+	pl.resp.Store(resp)
+
+	return stream, nil
+}
+
+func (pl *PolledList) watch(stream pb.Journal_WatchListClient) {
+	for {
+		var delta, err = recvWatchListResponse(pl.ctx, stream)
+		if err == nil {
+			updateJournalRoutes(pl.client, delta.Journals)
+			pl.resp.Store(applyListDelta(pl.List(), delta))
+			continue
+		}
+
+		for attempt := 1; err != nil; attempt++ {
+			if pl.ctx.Err() != nil {
+				return
+			}
+			log.WithFields(log.Fields{"err": err, "req": pl.req.String(), "attempt": attempt}).
+				Warn("WatchList failed (will retry)")
+
+			select {
+			case <-time.After(backoff(attempt + 1)):
+			case <-pl.ctx.Done():
+				return
+			}
+			stream, err = pl.openWatch()
+		}
+	}
+}
+
+// recvWatchListResponse reads and validates the next ListResponse of a WatchList RPC.
+func recvWatchListResponse(ctx context.Context, stream pb.Journal_WatchListClient) (*pb.ListResponse, error) {
+	if r, err := stream.Recv(); err != nil {
+		return nil, mapGRPCCtxErr(ctx, err)
+	} else if err = r.Validate(); err != nil {
+		return nil, err
+	} else if r.Status != pb.Status_OK {
+		return nil, StatusError(r.Status)
+	} else {
+		return r, nil
+	}
+}
+
+// applyListDelta returns a new ListResponse which applies the |delta|
+// ListResponse of a WatchList RPC to the prior ListResponse |cur|.
+// |cur| is not modified.
+func applyListDelta(cur, delta *pb.ListResponse) *pb.ListResponse {
+	var out = &pb.ListResponse{
+		Status:   delta.Status,
+		Header:   delta.Header,
+		Journals: make([]pb.ListResponse_Journal, 0, len(cur.Journals)+len(delta.Journals)),
+	}
+	var removed = make(map[pb.Journal]struct{}, len(delta.RemovedJournals)+len(delta.Journals))
+
+	for _, name := range delta.RemovedJournals {
+		removed[name] = struct{}{}
+	}
+	for _, j := range delta.Journals {
+		removed[j.Spec.Name] = struct{}{} // Replaced by |j|.
+		out.Journals = append(out.Journals, j)
+	}
+	for _, j := range cur.Journals {
+		if _, ok := removed[j.Spec.Name]; !ok {
+			out.Journals = append(out.Journals, j)
+		}
+	}
+	sort.Slice(out.Journals, func(i, j int) bool {
+		return out.Journals[i].Spec.Name < out.Journals[j].Spec.Name
+	})
+	return out
+}
+
+// updateJournalRoutes advises |client| of journal Routes, if it's a DispatchRouter.
+func updateJournalRoutes(client pb.JournalClient, journals []pb.ListResponse_Journal) {
+	if dr, ok := client.(pb.DispatchRouter); ok {
+		for _, j := range journals {
+			dr.UpdateRoute(j.Spec.Name.String(), &j.Route)
+		}
+	}
+}
+
 // ListAllJournals performs multiple List RPCs, as required to join across multiple
 // ListResponse pages, and returns the complete ListResponse of the ListRequest.
 // Any encountered error is returned.
@@ -71,7 +186,7 @@ func ListAllJournals(ctx context.Context, client pb.JournalClient, req pb.ListRe
 		} else if err = r.Validate(); err != nil {
 			return resp, err
 		} else if r.Status != pb.Status_OK {
-			return resp, errors.New(r.Status.String())
+			return resp, StatusError(r.Status)
 		} else {
 			req.PageToken, r.NextPageToken = r.NextPageToken, ""
 
@@ -86,11 +201,7 @@ func ListAllJournals(ctx context.Context, client pb.JournalClient, req pb.ListRe
 		}
 	}
 
-	if dr, ok := client.(pb.DispatchRouter); ok {
-		for _, j := range resp.Journals {
-			dr.UpdateRoute(j.Spec.Name.String(), &j.Route)
-		}
-	}
+	updateJournalRoutes(client, resp.Journals)
 	return resp, nil
 }
 
@@ -130,7 +241,7 @@ func ApplyJournalsInBatches(ctx context.Context, jc pb.JournalClient, req *pb.Ap
 		} else if err = resp.Validate(); err != nil {
 			return resp, err
 		} else if resp.Status != pb.Status_OK {
-			return resp, errors.New(resp.Status.String())
+			return resp, StatusError(resp.Status)
 		}
 
 		offset = offset + len(curReq.Changes)
@@ -142,31 +253,108 @@ func ApplyJournalsInBatches(ctx context.Context, jc pb.JournalClient, req *pb.Ap
 
 // ListAllFragments performs multiple Fragments RPCs, as required to join across multiple
 // FragmentsResponse pages, and returns the completed FragmentResponse.
-// Any encountered error is returned.
+// Any encountered error is returned. Journals having very many Fragments
+// should instead use ListFragmentPages, which doesn't hold all pages in memory.
 func ListAllFragments(ctx context.Context, client pb.RoutedJournalClient, req pb.FragmentsRequest) (*pb.FragmentsResponse, error) {
 	var resp *pb.FragmentsResponse
+
+	var err = ListFragmentPages(ctx, client, req, func(r *pb.FragmentsResponse) error {
+		if r.NextPageToken = 0; resp == nil {
+			resp = r
+		} else {
+			resp.Fragments = append(resp.Fragments, r.Fragments...)
+		}
+		return nil
+	})
+	return resp, err
+}
+
+// ListFragmentPages performs a StreamFragments RPC of |req|, and invokes |fn|
+// with each FragmentsResponse page in turn, continuing until the final page has
+// been processed. Pages are streamed as |fn| consumes them, and the caller may
+// bound their size through |req.PageLimit|. The NextPageToken of each page is
+// retained, and may be used as the |req.NextPageToken| of a later call to
+// resume the listing. If |fn| returns an error, the listing stops and the error
+// is returned. If the broker doesn't implement StreamFragments, pages are
+// instead listed by successive Fragments RPCs.
+func ListFragmentPages(ctx context.Context, client pb.RoutedJournalClient, req pb.FragmentsRequest,
+	fn func(*pb.FragmentsResponse) error) error {
+
+	// Cancel an incomplete stream upon returning.
+	var streamCtx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	var routedCtx = pb.WithDispatchItemRoute(streamCtx, client, req.Journal.String(), false)
+	var reresolved bool
+	var stream pb.Journal_StreamFragmentsClient
+	var err error
+
+	for {
+		var r *pb.FragmentsResponse
+
+		if stream == nil {
+			stream, err = client.StreamFragments(routedCtx, &req)
+		}
+		if err == nil {
+			r, err = stream.Recv()
+		}
+
+		if status.Code(err) == codes.Unimplemented {
+			return listFragmentPagesUnary(ctx, client, req, fn)
+		} else if err == io.EOF {
+			return io.ErrUnexpectedEOF // Stream closed prior to the final page.
+		} else if err != nil {
+			return mapGRPCCtxErr(ctx, err)
+		} else if err = r.Validate(); err != nil {
+			return err
+		} else if r.Status == pb.Status_NOT_JOURNAL_BROKER && !reresolved && !client.IsNoopRouter() {
+			// Our Route is likely stale. Invalidate it, and retry
+			// once against the default service address.
+			client.UpdateRoute(req.Journal.String(), nil)
+			routedCtx, reresolved = pb.WithDispatchItemRoute(streamCtx, client, req.Journal.String(), false), true
+			stream = nil
+		} else if r.Status != pb.Status_OK {
+			return StatusError(r.Status)
+		} else {
+			req.NextPageToken = r.NextPageToken
+
+			if err = fn(r); err != nil {
+				return err
+			} else if req.NextPageToken == 0 {
+				return nil // All done.
+			}
+		}
+	}
+}
+
+// listFragmentPagesUnary is ListFragmentPages, implemented by successive
+// Fragments RPCs for brokers which don't implement StreamFragments.
+func listFragmentPagesUnary(ctx context.Context, client pb.RoutedJournalClient, req pb.FragmentsRequest,
+	fn func(*pb.FragmentsResponse) error) error {
+
 	var routedCtx = pb.WithDispatchItemRoute(ctx, client, req.Journal.String(), false)
+	var reresolved bool
 
 	for {
 		if r, err := client.ListFragments(routedCtx, &req); err != nil {
-			return resp, mapGRPCCtxErr(ctx, err)
+			return mapGRPCCtxErr(ctx, err)
 		} else if err = r.Validate(); err != nil {
-			return resp, err
+			return err
+		} else if r.Status == pb.Status_NOT_JOURNAL_BROKER && !reresolved && !client.IsNoopRouter() {
+			// Our Route is likely stale. Invalidate it, and retry
+			// once against the default service address.
+			client.UpdateRoute(req.Journal.String(), nil)
+			routedCtx, reresolved = pb.WithDispatchItemRoute(ctx, client, req.Journal.String(), false), true
 		} else if r.Status != pb.Status_OK {
-			return resp, errors.New(r.Status.String())
+			return StatusError(r.Status)
 		} else {
-			req.NextPageToken, r.NextPageToken = r.NextPageToken, 0
+			req.NextPageToken = r.NextPageToken
 
-			if resp == nil {
-				resp = r
-			} else {
-				resp.Fragments = append(resp.Fragments, r.Fragments...)
-			}
-			if req.NextPageToken == 0 {
-				break // All done.
+			if err = fn(r); err != nil {
+				return err
+			} else if req.NextPageToken == 0 {
+				return nil // All done.
 			}
 		}
 	}
-
-	return resp, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/broker/teststub"
@@ -118,6 +119,70 @@ func (s *ListSuite) TestPolledList(c *gc.C) {
 	c.Check(pl.List(), gc.DeepEquals, &fixture)
 }
 
+func (s *ListSuite) TestWatchedList(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var mk = buildListResponseFixture // Alias.
+	var hdr = *buildHeaderFixture(broker)
+
+	var respCh = make(chan *pb.ListResponse)
+
+	broker.WatchListFunc = func(req *pb.ListRequest, srv pb.Journal_WatchListServer) error {
+		for {
+			select {
+			case resp := <-respCh:
+				if err := srv.Send(resp); err != nil {
+					return err
+				}
+			case <-srv.Context().Done():
+				return nil
+			}
+		}
+	}
+
+	// Expect NewWatchedList reads the initial listing before returning.
+	go func() { respCh <- &pb.ListResponse{Header: hdr, Journals: mk("part-one", "part-two")} }()
+
+	var pl, err = NewWatchedList(ctx, broker.MustClient(), pb.ListRequest{})
+	c.Check(err, gc.IsNil)
+	c.Check(pl.List(), gc.DeepEquals, &pb.ListResponse{Header: hdr, Journals: mk("part-one", "part-two")})
+
+	var initial = pl.List()
+
+	// Send a delta which adds, updates, and removes journals.
+	var updated = mk("part-one")
+	updated[0].ModRevision = 5678
+
+	respCh <- &pb.ListResponse{
+		Header:          hdr,
+		Journals:        append(mk("part-three"), updated...),
+		RemovedJournals: []pb.Journal{"part-two"},
+	}
+
+	var expect = &pb.ListResponse{
+		Header:   hdr,
+		Journals: append(updated, mk("part-three")...),
+	}
+	for i := 0; i != 1000 && pl.List() == initial; i++ {
+		time.Sleep(time.Millisecond) // Wait for the delta to be applied.
+	}
+	c.Check(pl.List(), gc.DeepEquals, expect)
+}
+
+func (s *ListSuite) TestApplyListDelta(c *gc.C) {
+	var mk = buildListResponseFixture // Alias.
+
+	var cur = &pb.ListResponse{Journals: mk("a", "b", "c")}
+	var out = applyListDelta(cur, &pb.ListResponse{
+		Journals:        mk("d", "b"),
+		RemovedJournals: []pb.Journal{"a", "z"},
+	})
+	c.Check(out.Journals, gc.DeepEquals, mk("b", "c", "d"))
+	c.Check(cur.Journals, gc.DeepEquals, mk("a", "b", "c")) // Not modified.
+}
+
 func (s *ListSuite) TestListAllFragments(c *gc.C) {
 	var ctx = context.Background()
 	var broker = teststub.NewBroker(c, ctx)
@@ -164,6 +229,7 @@ func (s *ListSuite) TestListAllFragments(c *gc.C) {
 	resp, err = ListAllFragments(ctx, client, req)
 	c.Check(resp, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, pb.Status_JOURNAL_NOT_FOUND.String())
+	c.Check(err, gc.Equals, ErrJournalNotFound)
 
 	// Case: broker error
 	broker.ListFragmentsFunc = func(_ context.Context, req *pb.FragmentsRequest) (*pb.FragmentsResponse, error) {
@@ -185,6 +251,86 @@ func (s *ListSuite) TestListAllFragments(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `Status: invalid status \(1000\)`)
 }
 
+func (s *ListSuite) TestListFragmentPages(c *gc.C) {
+	var ctx = context.Background()
+	var broker = teststub.NewBroker(c, ctx)
+	var hdr = buildHeaderFixture(broker)
+
+	var buildPage = func(req *pb.FragmentsRequest) *pb.FragmentsResponse {
+		c.Check(req.PageLimit, gc.Equals, int32(30))
+
+		var resp = &pb.FragmentsResponse{
+			Header:    *hdr,
+			Fragments: buildSignedFragmentsFixture("a/journal", req.NextPageToken),
+		}
+		if req.NextPageToken < 60 {
+			resp.NextPageToken = req.NextPageToken + 30
+		}
+		return resp
+	}
+	broker.StreamFragmentsFunc = func(req *pb.FragmentsRequest, srv pb.Journal_StreamFragmentsServer) error {
+		for {
+			var resp = buildPage(req)
+			if err := srv.Send(resp); err != nil || resp.NextPageToken == 0 {
+				return err
+			}
+			req.NextPageToken = resp.NextPageToken
+		}
+	}
+
+	var client = pb.NewRoutedJournalClient(broker.MustClient(), NewRouteCache(2, time.Hour))
+	var req = pb.FragmentsRequest{Journal: pb.Journal("a/journal"), PageLimit: 30}
+
+	// Case: each page is passed in turn, retaining its NextPageToken.
+	var tokens []int64
+	c.Check(ListFragmentPages(ctx, client, req, func(resp *pb.FragmentsResponse) error {
+		c.Check(resp.Fragments, gc.HasLen, len(buildSignedFragmentsFixture("a/journal", 0)))
+		tokens = append(tokens, resp.NextPageToken)
+		return nil
+	}), gc.IsNil)
+	c.Check(tokens, gc.DeepEquals, []int64{30, 60, 0})
+
+	// Case: an error of the callback halts the listing, and is returned.
+	tokens = tokens[:0]
+	c.Check(ListFragmentPages(ctx, client, req, func(resp *pb.FragmentsResponse) error {
+		tokens = append(tokens, resp.NextPageToken)
+		return errors.New("halt")
+	}), gc.ErrorMatches, "halt")
+	c.Check(tokens, gc.DeepEquals, []int64{30})
+
+	// Case: a listing may be resumed from a NextPageToken.
+	tokens, req.NextPageToken = tokens[:0], 60
+	c.Check(ListFragmentPages(ctx, client, req, func(resp *pb.FragmentsResponse) error {
+		tokens = append(tokens, resp.NextPageToken)
+		return nil
+	}), gc.IsNil)
+	c.Check(tokens, gc.DeepEquals, []int64{0})
+
+	// Case: a stream which closes prior to its final page is an error.
+	broker.StreamFragmentsFunc = func(req *pb.FragmentsRequest, srv pb.Journal_StreamFragmentsServer) error {
+		return srv.Send(buildPage(req))
+	}
+	tokens, req.NextPageToken = tokens[:0], 0
+	c.Check(ListFragmentPages(ctx, client, req, func(resp *pb.FragmentsResponse) error {
+		tokens = append(tokens, resp.NextPageToken)
+		return nil
+	}), gc.Equals, io.ErrUnexpectedEOF)
+	c.Check(tokens, gc.DeepEquals, []int64{30})
+
+	// Case: brokers which don't implement StreamFragments are instead
+	// listed by successive Fragments RPCs.
+	broker.StreamFragmentsFunc = nil
+	broker.ListFragmentsFunc = func(_ context.Context, req *pb.FragmentsRequest) (*pb.FragmentsResponse, error) {
+		return buildPage(req), nil
+	}
+	tokens = tokens[:0]
+	c.Check(ListFragmentPages(ctx, client, req, func(resp *pb.FragmentsResponse) error {
+		tokens = append(tokens, resp.NextPageToken)
+		return nil
+	}), gc.IsNil)
+	c.Check(tokens, gc.DeepEquals, []int64{30, 60, 0})
+}
+
 func (s *ListSuite) TestApplyJournalsInBatches(c *gc.C) {
 	var ctx, cancel = context.WithCancel(context.Background())
 	defer cancel()
@@ -0,0 +1,462 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MemoryJournalClient is an in-memory implementation of pb.JournalClient,
+// which allows applications to unit test code built on Reader, Appender,
+// AppendService, and the List / Apply APIs without running brokers or Etcd.
+// Wrap it with pb.NewRoutedJournalClient and a pb.NoopDispatchRouter to obtain
+// a RoutedJournalClient.
+//
+// Journals are created and removed via Apply. Each committed Append produces
+// a Fragment of the journal, which remains local: Fragments have no ModTime,
+// and are never persisted to a store, so ReadResponses never include a
+// FragmentUrl. The WatchList and Replicate APIs are not implemented.
+type MemoryJournalClient struct {
+	journals map[pb.Journal]*memoryJournal
+	revision int64
+	// |changeCh| is closed and replaced upon each committed Append,
+	// to wake blocking Reads.
+	changeCh chan struct{}
+	mu       sync.Mutex
+}
+
+type memoryJournal struct {
+	spec        pb.JournalSpec
+	modRevision int64
+	content     []byte
+	fragments   []pb.Fragment
+}
+
+// NewMemoryJournalClient returns a MemoryJournalClient having no journals.
+func NewMemoryJournalClient() *MemoryJournalClient {
+	return &MemoryJournalClient{
+		journals: make(map[pb.Journal]*memoryJournal),
+		revision: 1,
+		changeCh: make(chan struct{}),
+	}
+}
+
+// Content returns a copy of the content appended to the journal.
+func (c *MemoryJournalClient) Content(journal pb.Journal) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if j, ok := c.journals[journal]; ok {
+		return append([]byte(nil), j.content...)
+	}
+	return nil
+}
+
+// List implements the JournalClient interface. All matched journals are
+// returned in a single page.
+func (c *MemoryJournalClient) List(ctx context.Context, req *pb.ListRequest, _ ...grpc.CallOption) (*pb.ListResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resp = &pb.ListResponse{Status: pb.Status_OK, Header: c.header()}
+	var metaLabels, allLabels pb.LabelSet
+
+	for _, j := range c.journals {
+		metaLabels = pb.ExtractJournalSpecMetaLabels(&j.spec, metaLabels)
+		allLabels = pb.UnionLabelSets(metaLabels, j.spec.LabelSet, allLabels)
+
+		if !req.Selector.Matches(allLabels) {
+			continue
+		}
+		resp.Journals = append(resp.Journals, pb.ListResponse_Journal{
+			Spec:        j.spec,
+			ModRevision: j.modRevision,
+			Route:       pb.Route{Primary: -1},
+		})
+	}
+	sort.Slice(resp.Journals, func(i, j int) bool {
+		return resp.Journals[i].Spec.Name < resp.Journals[j].Spec.Name
+	})
+	return resp, nil
+}
+
+// WatchList is not implemented by MemoryJournalClient.
+func (c *MemoryJournalClient) WatchList(context.Context, *pb.ListRequest, ...grpc.CallOption) (pb.Journal_WatchListClient, error) {
+	return nil, status.Error(codes.Unimplemented, "WatchList is not implemented by MemoryJournalClient")
+}
+
+// Apply implements the JournalClient interface. As with brokers, Changes are
+// applied transactionally, and an ExpectModRevision of zero requires that the
+// journal not exist.
+func (c *MemoryJournalClient) Apply(ctx context.Context, req *pb.ApplyRequest, _ ...grpc.CallOption) (*pb.ApplyResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, change := range req.Changes {
+		var name = change.Delete
+		if change.Upsert != nil {
+			name = change.Upsert.Name
+		}
+		var rev int64
+		if j, ok := c.journals[name]; ok {
+			rev = j.modRevision
+		}
+		if rev != change.ExpectModRevision {
+			return &pb.ApplyResponse{Status: pb.Status_ETCD_TRANSACTION_FAILED, Header: c.header()}, nil
+		}
+	}
+	c.revision++
+
+	for _, change := range req.Changes {
+		if change.Upsert == nil {
+			delete(c.journals, change.Delete)
+			continue
+		}
+		var j, ok = c.journals[change.Upsert.Name]
+		if !ok {
+			j = new(memoryJournal)
+			c.journals[change.Upsert.Name] = j
+		}
+		j.spec, j.modRevision = *change.Upsert, c.revision
+	}
+	return &pb.ApplyResponse{Status: pb.Status_OK, Header: c.header()}, nil
+}
+
+// Read implements the JournalClient interface.
+func (c *MemoryJournalClient) Read(ctx context.Context, req *pb.ReadRequest, _ ...grpc.CallOption) (pb.Journal_ReadClient, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &memoryReadClient{
+		memoryStream: memoryStream{ctx: ctx},
+		client:       c,
+		req:          *req,
+	}, nil
+}
+
+// Append implements the JournalClient interface.
+func (c *MemoryJournalClient) Append(ctx context.Context, _ ...grpc.CallOption) (pb.Journal_AppendClient, error) {
+	return &memoryAppendClient{
+		memoryStream: memoryStream{ctx: ctx},
+		client:       c,
+	}, nil
+}
+
+// Replicate is not implemented by MemoryJournalClient.
+func (c *MemoryJournalClient) Replicate(context.Context, ...grpc.CallOption) (pb.Journal_ReplicateClient, error) {
+	return nil, status.Error(codes.Unimplemented, "Replicate is not implemented by MemoryJournalClient")
+}
+
+// ListFragments implements the JournalClient interface. All matched Fragments
+// are returned in a single page.
+func (c *MemoryJournalClient) ListFragments(ctx context.Context, req *pb.FragmentsRequest, _ ...grpc.CallOption) (*pb.FragmentsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var j, ok = c.journals[req.Journal]
+	if !ok {
+		return &pb.FragmentsResponse{Status: pb.Status_JOURNAL_NOT_FOUND, Header: c.header()}, nil
+	}
+	var resp = &pb.FragmentsResponse{Status: pb.Status_OK, Header: c.header()}
+
+	for _, f := range j.fragments {
+		// Fragments are never persisted, and have no ModTime. As with
+		// brokers, they're excluded only if an EndModTime is specified.
+		if req.EndModTime != 0 {
+			continue
+		}
+		resp.Fragments = append(resp.Fragments, pb.FragmentsResponse__Fragment{Spec: f})
+	}
+	return resp, nil
+}
+
+// StreamFragments implements the JournalClient interface. As with
+// ListFragments, all matched Fragments are streamed in a single page.
+func (c *MemoryJournalClient) StreamFragments(ctx context.Context, req *pb.FragmentsRequest, _ ...grpc.CallOption) (pb.Journal_StreamFragmentsClient, error) {
+	var resp, err = c.ListFragments(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryFragmentsClient{memoryStream: memoryStream{ctx: ctx}, resp: resp}, nil
+}
+
+// header returns a Header reflecting the current revision. |c.mu| must be held.
+func (c *MemoryJournalClient) header() pb.Header {
+	return pb.Header{
+		Route: pb.Route{Primary: -1},
+		Etcd: pb.Header_Etcd{
+			ClusterId: 1,
+			MemberId:  1,
+			Revision:  c.revision,
+			RaftTerm:  1,
+		},
+	}
+}
+
+// append commits |content| to the journal of the |preamble| AppendRequest.
+func (c *MemoryJournalClient) append(preamble *pb.AppendRequest, content []byte) *pb.AppendResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resp = &pb.AppendResponse{Header: c.header()}
+
+	var j, ok = c.journals[preamble.Journal]
+	if !ok {
+		resp.Status = pb.Status_JOURNAL_NOT_FOUND
+		return resp
+	}
+	var head = int64(len(j.content))
+
+	if preamble.Offset != 0 && preamble.Offset != head {
+		resp.Status = pb.Status_WRONG_APPEND_OFFSET
+		return resp
+	}
+	var frag = pb.Fragment{
+		Journal:          preamble.Journal,
+		Begin:            head,
+		End:              head + int64(len(content)),
+		Sum:              pb.SHA1SumOf(string(content)),
+		CompressionCodec: pb.CompressionCodec_NONE,
+	}
+	if len(content) != 0 {
+		j.content = append(j.content, content...)
+		j.fragments = append(j.fragments, frag)
+
+		close(c.changeCh)
+		c.changeCh = make(chan struct{})
+	}
+	resp.Status, resp.Commit = pb.Status_OK, &frag
+	return resp
+}
+
+// read returns ReadResponses of |req|, or a channel which is signalled when
+// responses may be available. A |req| Offset of -1 is resolved in place.
+func (c *MemoryJournalClient) read(req *pb.ReadRequest, withHeader bool) ([]pb.ReadResponse, <-chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var hdr *pb.Header
+	if withHeader {
+		var h = c.header()
+		hdr = &h
+	}
+
+	var j, ok = c.journals[req.Journal]
+	if !ok {
+		return []pb.ReadResponse{{Status: pb.Status_JOURNAL_NOT_FOUND, Header: hdr}}, nil
+	}
+	var head = int64(len(j.content))
+
+	if req.Offset == -1 {
+		req.Offset = head // Resolve to the current write head.
+	}
+	var offset = req.Offset
+
+	if offset >= head {
+		if req.Block {
+			return nil, c.changeCh
+		}
+		return []pb.ReadResponse{{
+			Status:    pb.Status_OFFSET_NOT_YET_AVAILABLE,
+			Header:    hdr,
+			Offset:    offset,
+			WriteHead: head,
+		}}, nil
+	}
+	// Find the first Fragment which ends after |offset|.
+	var ind = sort.Search(len(j.fragments), func(i int) bool {
+		return j.fragments[i].End > offset
+	})
+	var frag = j.fragments[ind]
+
+	var out = []pb.ReadResponse{{
+		Status:    pb.Status_OK,
+		Header:    hdr,
+		Offset:    offset,
+		WriteHead: head,
+		Fragment:  &frag,
+	}}
+	if !req.MetadataOnly {
+		out = append(out, pb.ReadResponse{
+			Offset:  offset,
+			Content: append([]byte(nil), j.content[offset:frag.End]...),
+		})
+	}
+	return out, nil
+}
+
+// memoryStream implements the grpc.ClientStream interface.
+type memoryStream struct{ ctx context.Context }
+
+func (s memoryStream) Header() (metadata.MD, error) { return nil, nil }
+func (s memoryStream) Trailer() metadata.MD         { return nil }
+func (s memoryStream) CloseSend() error             { return nil }
+func (s memoryStream) Context() context.Context     { return s.ctx }
+
+func (s memoryStream) SendMsg(interface{}) error {
+	return errors.New("unexpected SendMsg")
+}
+
+// memoryReadClient implements the Journal_ReadClient interface.
+type memoryReadClient struct {
+	memoryStream
+	client *MemoryJournalClient
+	req    pb.ReadRequest
+
+	queued []pb.ReadResponse
+	sent   bool // Whether a first response (with Header) has been sent.
+	eof    bool // Whether the stream has been closed.
+}
+
+func (s *memoryReadClient) Recv() (*pb.ReadResponse, error) {
+	var resp = new(pb.ReadResponse)
+	if err := s.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *memoryReadClient) RecvMsg(m interface{}) error {
+	for len(s.queued) == 0 {
+		if s.eof {
+			return io.EOF
+		} else if err := s.ctx.Err(); err != nil {
+			return memoryCtxErr(err)
+		}
+		var out, waitCh = s.client.read(&s.req, !s.sent)
+
+		if waitCh != nil {
+			select {
+			case <-waitCh:
+			case <-s.ctx.Done():
+			}
+			continue
+		}
+		s.queued = out
+
+		if out[0].Status != pb.Status_OK || s.req.MetadataOnly {
+			// As with brokers, the stream is closed after a !OK status
+			// or a metadata-only response.
+			s.eof = true
+		} else {
+			s.req.Offset = out[0].Fragment.End
+		}
+	}
+	*m.(*pb.ReadResponse), s.queued = s.queued[0], s.queued[1:]
+	s.sent = true
+
+	return nil
+}
+
+// memoryFragmentsClient implements the Journal_StreamFragmentsClient interface.
+type memoryFragmentsClient struct {
+	memoryStream
+	resp *pb.FragmentsResponse // Remaining response, or nil if sent.
+}
+
+func (s *memoryFragmentsClient) Recv() (*pb.FragmentsResponse, error) {
+	var resp = new(pb.FragmentsResponse)
+	if err := s.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *memoryFragmentsClient) RecvMsg(m interface{}) error {
+	if err := s.ctx.Err(); err != nil {
+		return memoryCtxErr(err)
+	} else if s.resp == nil {
+		return io.EOF
+	}
+	*m.(*pb.FragmentsResponse), s.resp = *s.resp, nil
+	return nil
+}
+
+// memoryAppendClient implements the Journal_AppendClient interface.
+type memoryAppendClient struct {
+	memoryStream
+	client *MemoryJournalClient
+
+	preamble *pb.AppendRequest
+	content  []byte
+	commit   bool // Whether an empty AppendRequest signalled commit intent.
+	done     bool // Whether the AppendResponse was received.
+}
+
+func (s *memoryAppendClient) Send(req *pb.AppendRequest) error { return s.SendMsg(req) }
+
+func (s *memoryAppendClient) SendMsg(m interface{}) error {
+	var req = m.(*pb.AppendRequest)
+
+	if err := s.ctx.Err(); err != nil {
+		return memoryCtxErr(err)
+	} else if err = req.Validate(); err != nil {
+		return err
+	}
+
+	if s.preamble == nil {
+		if req.Journal == "" {
+			return errors.New("expected Journal in first AppendRequest")
+		}
+		s.preamble = req
+	} else if s.commit || req.Journal != "" {
+		return errors.New("unexpected AppendRequest")
+	} else if len(req.Content) == 0 {
+		s.commit = true
+	} else {
+		s.content = append(s.content, req.Content...)
+	}
+	return nil
+}
+
+func (s *memoryAppendClient) CloseAndRecv() (*pb.AppendResponse, error) {
+	var resp = new(pb.AppendResponse)
+	if err := s.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *memoryAppendClient) RecvMsg(m interface{}) error {
+	if s.done {
+		return io.EOF
+	}
+	s.done = true
+
+	if err := s.ctx.Err(); err != nil {
+		return memoryCtxErr(err)
+	} else if !s.commit {
+		// As with brokers, content is rolled back if the client
+		// closes without first signalling commit intent.
+		return io.ErrUnexpectedEOF
+	}
+	*m.(*pb.AppendResponse) = *s.client.append(s.preamble, s.content)
+	return nil
+}
+
+// memoryCtxErr maps a Context error to its equivalent gRPC status error.
+func memoryCtxErr(err error) error {
+	if err == context.DeadlineExceeded {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Canceled, err.Error())
+}
+
+var _ pb.JournalClient = new(MemoryJournalClient)
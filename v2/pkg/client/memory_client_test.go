@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type MemoryJournalClientSuite struct{}
+
+func (s *MemoryJournalClientSuite) TestApplyAndList(c *gc.C) {
+	var ctx, mc = context.Background(), NewMemoryJournalClient()
+	var specA, specB = buildMemorySpecFixture("journal/A"), buildMemorySpecFixture("journal/B")
+	specB.LabelSet = pb.MustLabelSet("foo", "bar")
+
+	resp, err := mc.Apply(ctx, &pb.ApplyRequest{Changes: []pb.ApplyRequest_Change{
+		{Upsert: specA}, {Upsert: specB},
+	}})
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+
+	// Expect a create of an existing journal fails.
+	resp, err = mc.Apply(ctx, &pb.ApplyRequest{Changes: []pb.ApplyRequest_Change{{Upsert: specA}}})
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_ETCD_TRANSACTION_FAILED)
+
+	list, err := ListAllJournals(ctx, mc, pb.ListRequest{})
+	c.Check(err, gc.IsNil)
+	c.Assert(list.Journals, gc.HasLen, 2)
+	c.Check(list.Journals[0].Spec, gc.DeepEquals, *specA)
+	c.Check(list.Journals[1].Spec, gc.DeepEquals, *specB)
+
+	// Delete journal/A at its current revision.
+	resp, err = mc.Apply(ctx, &pb.ApplyRequest{Changes: []pb.ApplyRequest_Change{
+		{Delete: "journal/A", ExpectModRevision: list.Journals[0].ModRevision},
+	}})
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+
+	// Expect selectors are applied.
+	list, err = ListAllJournals(ctx, mc, pb.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("foo", "bar")},
+	})
+	c.Check(err, gc.IsNil)
+	c.Assert(list.Journals, gc.HasLen, 1)
+	c.Check(list.Journals[0].Spec.Name, gc.Equals, pb.Journal("journal/B"))
+}
+
+func (s *MemoryJournalClientSuite) TestAppendAndRead(c *gc.C) {
+	var ctx, mc = context.Background(), NewMemoryJournalClient()
+	var rjc = pb.NewRoutedJournalClient(mc, pb.NoopDispatchRouter{})
+
+	_, err := mc.Apply(ctx, &pb.ApplyRequest{Changes: []pb.ApplyRequest_Change{
+		{Upsert: buildMemorySpecFixture("a/journal")},
+	}})
+	c.Assert(err, gc.IsNil)
+
+	var a = NewAppender(ctx, rjc, pb.AppendRequest{Journal: "a/journal"})
+	_, _ = a.Write([]byte("hello, "))
+	c.Check(a.Close(), gc.IsNil)
+	c.Check(a.Response.Commit.Begin, gc.Equals, int64(0))
+	c.Check(a.Response.Commit.End, gc.Equals, int64(7))
+
+	a = NewAppender(ctx, rjc, pb.AppendRequest{Journal: "a/journal"})
+	_, _ = a.Write([]byte("world!"))
+	c.Check(a.Close(), gc.IsNil)
+
+	// Expect an Append at the wrong offset fails.
+	a = NewAppender(ctx, rjc, pb.AppendRequest{Journal: "a/journal", Offset: 5})
+	_, _ = a.Write([]byte("oops"))
+	c.Check(a.Close(), gc.Equals, ErrWrongAppendOffset)
+
+	// As does an Append to a journal which doesn't exist.
+	a = NewAppender(ctx, rjc, pb.AppendRequest{Journal: "not/found"})
+	_, _ = a.Write([]byte("oops"))
+	c.Check(a.Close(), gc.Equals, ErrJournalNotFound)
+
+	// An aborted Append is not committed.
+	a = NewAppender(ctx, rjc, pb.AppendRequest{Journal: "a/journal"})
+	_, _ = a.Write([]byte("aborted"))
+	a.Abort()
+
+	c.Check(string(mc.Content("a/journal")), gc.Equals, "hello, world!")
+
+	// Expect content is read across Fragments, through to the write head.
+	var r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 2})
+	b, err := ioutil.ReadAll(r)
+	c.Check(err, gc.Equals, ErrOffsetNotYetAvailable)
+	c.Check(string(b), gc.Equals, "llo, world!")
+	// The final response is of the write head, and has no Fragment.
+	c.Check(r.Response.Status, gc.Equals, pb.Status_OFFSET_NOT_YET_AVAILABLE)
+	c.Check(r.Response.Offset, gc.Equals, int64(13))
+	c.Check(r.Response.Fragment, gc.IsNil)
+
+	frags, err := ListAllFragments(ctx, rjc, pb.FragmentsRequest{Journal: "a/journal"})
+	c.Check(err, gc.IsNil)
+	c.Check(frags.Fragments, gc.HasLen, 2)
+
+	// Expect a blocking read awaits further appends.
+	go func() {
+		time.Sleep(time.Millisecond)
+		var _, err = Append(ctx, rjc, pb.AppendRequest{Journal: "a/journal"},
+			strings.NewReader(" and more"))
+		c.Check(err, gc.IsNil)
+	}()
+
+	var readCtx, cancel = context.WithCancel(ctx)
+	r = NewReader(readCtx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: -1, Block: true})
+
+	var buf = make([]byte, 32)
+	var n int
+	for n == 0 && err == nil || err == ErrOffsetJump {
+		n, err = r.Read(buf)
+	}
+	c.Check(err, gc.IsNil)
+	c.Check(string(buf[:n]), gc.Equals, " and more")
+
+	// Cancellation aborts a blocked read.
+	cancel()
+	_, err = r.Read(buf)
+	c.Check(err, gc.Equals, context.Canceled)
+}
+
+func buildMemorySpecFixture(name pb.Journal) *pb.JournalSpec {
+	return &pb.JournalSpec{
+		Name:        name,
+		Replication: 1,
+		Fragment: pb.JournalSpec_Fragment{
+			Length:           1 << 24, // 16MB.
+			CompressionCodec: pb.CompressionCodec_NONE,
+			RefreshInterval:  time.Minute,
+			Retention:        time.Hour,
+		},
+	}
+}
+
+var _ = gc.Suite(&MemoryJournalClientSuite{})
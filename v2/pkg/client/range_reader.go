@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// RangeReader adapts a fixed byte range of a journal to the io.ReaderAt and
+// io.WriterTo interfaces, allowing tooling which expects random access or
+// bulk copies (eg, archive/zip, or columnar file readers) to consume journal
+// content directly. Offsets of the RangeReader are relative to the beginning
+// of the range: offset zero is the Offset of the ReadRequest. Wrap a RangeReader with
+// io.NewSectionReader(rr, 0, rr.Size()) to obtain an io.ReadSeeker.
+//
+// Under the hood, reads are broken into chunks of ChunkSize, which are fetched
+// with up to Parallelism concurrent Read RPCs (or direct Fragment URL reads,
+// if the ReadRequest sets DoNotProxy). All reads are non-blocking: the range
+// must already be written to the journal, and a read of content which is not
+// yet available returns ErrOffsetNotYetAvailable. A read of content which is
+// no longer available (eg, because its Fragment was removed) returns
+// ErrOffsetJump.
+type RangeReader struct {
+	// Parallelism is the maximum number of concurrent chunk reads.
+	Parallelism int
+	// ChunkSize is the size of individually fetched chunks.
+	ChunkSize int
+
+	ctx    context.Context
+	client pb.RoutedJournalClient
+	req    pb.ReadRequest
+	end    int64
+}
+
+// NewRangeReader returns a RangeReader of the journal range beginning at
+// |req.Offset| and ending at |end| (exclusive).
+func NewRangeReader(ctx context.Context, client pb.RoutedJournalClient, req pb.ReadRequest, end int64) *RangeReader {
+	if end < req.Offset {
+		panic(fmt.Sprintf("invalid range [%d, %d)", req.Offset, end))
+	}
+	req.Block, req.MetadataOnly = false, false
+
+	return &RangeReader{
+		Parallelism: rangeReaderParallelism,
+		ChunkSize:   rangeReaderChunkSize,
+		ctx:         ctx,
+		client:      client,
+		req:         req,
+		end:         end,
+	}
+}
+
+// Size returns the number of bytes of the range.
+func (rr *RangeReader) Size() int64 { return rr.end - rr.req.Offset }
+
+// ReadAt implements io.ReaderAt, reading len(|p|) bytes at range offset |off|.
+func (rr *RangeReader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	} else if off >= rr.Size() {
+		return 0, io.EOF
+	}
+	var eof bool
+	if rem := rr.Size() - off; int64(len(p)) > rem {
+		p, eof = p[:rem], true
+	}
+
+	var ctx, cancel = context.WithCancel(rr.ctx)
+	defer cancel()
+
+	// Read chunks of |p| in parallel, and gather the first error of the
+	// lowest-offset chunk to fail. Chunks are read directly into |p|.
+	var chunks = rr.dispatch(ctx, off, int64(len(p)), func(begin, end int64) []byte {
+		return p[begin-off : end-off]
+	})
+	for r := range chunks {
+		var result = <-r
+		n += result.n
+
+		if result.err != nil {
+			// Cancel and await remaining chunk reads, as |p| may not be
+			// written to after we return.
+			cancel()
+			for r := range chunks {
+				<-r
+			}
+			return n, result.err
+		}
+	}
+	if eof {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// WriteTo implements io.WriterTo, writing the full range to |w|.
+func (rr *RangeReader) WriteTo(w io.Writer) (n int64, err error) {
+	var ctx, cancel = context.WithCancel(rr.ctx)
+	defer cancel()
+
+	var chunks = rr.dispatch(ctx, 0, rr.Size(), func(begin, end int64) []byte {
+		return make([]byte, end-begin)
+	})
+	for r := range chunks {
+		var result = <-r
+		var nn int
+
+		nn, err = w.Write(result.buf[:result.n])
+		n += int64(nn)
+
+		if err == nil {
+			err = result.err
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// rangeChunk is the result of reading a chunk of the range.
+type rangeChunk struct {
+	buf []byte
+	n   int
+	err error
+}
+
+// dispatch begins concurrent reads of chunks spanning range offsets
+// [|off|, |off|+|size|), into buffers returned by |bufFn|. It returns a
+// channel of chunk results, ordered on offset. At most Parallelism chunks are
+// read ahead of the consumer. Cancel |ctx| to abort outstanding reads.
+func (rr *RangeReader) dispatch(ctx context.Context, off, size int64,
+	bufFn func(begin, end int64) []byte) <-chan chan rangeChunk {
+
+	var parallelism, chunkSize = rr.Parallelism, int64(rr.ChunkSize)
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	if chunkSize <= 0 {
+		chunkSize = int64(rangeReaderChunkSize)
+	}
+	var out = make(chan chan rangeChunk, parallelism-1)
+
+	go func() {
+		defer close(out)
+
+		for begin, end := off, off+size; begin != end; {
+			var chunkEnd = begin + chunkSize
+			if chunkEnd > end {
+				chunkEnd = end
+			}
+			var ch = make(chan rangeChunk, 1)
+
+			select {
+			case out <- ch:
+			case <-ctx.Done():
+				return
+			}
+			go func(buf []byte, begin int64) {
+				var n, err = rr.readChunk(ctx, buf, begin)
+				ch <- rangeChunk{buf: buf, n: n, err: err}
+			}(bufFn(begin, chunkEnd), begin)
+
+			begin = chunkEnd
+		}
+	}()
+	return out
+}
+
+// readChunk fills |buf| with content beginning at range offset |off|.
+func (rr *RangeReader) readChunk(ctx context.Context, buf []byte, off int64) (int, error) {
+	var req = rr.req
+	req.Offset += off
+
+	var r = NewRetryReader(ctx, rr.client, req)
+	defer r.Cancel()
+
+	return io.ReadFull(r, buf)
+}
+
+var (
+	rangeReaderParallelism = 4
+	rangeReaderChunkSize   = 1 << 22 // 4MB.
+)
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/broker/teststub"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type RangeReaderSuite struct{}
+
+func (s *RangeReaderSuite) TestReadAtAndWriteTo(c *gc.C) {
+	var frag, url, dir, cleanup = buildFragmentFixture(c)
+	defer cleanup()
+	defer InstallFileTransport(dir)()
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), NewRouteCache(2, time.Hour))
+
+	var urlFixture = readFixture{fragment: &frag, fragmentUrl: url}
+	go serveReadFixtures(c, broker,
+		// WriteTo: three chunks, each read directly from the fragment URL.
+		urlFixture, urlFixture, urlFixture,
+		// ReadAt: a single, final chunk.
+		urlFixture,
+		// ReadAt: content is not yet available.
+		readFixture{status: pb.Status_OFFSET_NOT_YET_AVAILABLE},
+	)
+
+	// Fragment fixture content is "XXXXXhello, world!!!" at [100, 120).
+	var rr = NewRangeReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 105}, 117)
+	rr.Parallelism, rr.ChunkSize = 2, 5
+	c.Check(rr.Size(), gc.Equals, int64(12))
+
+	// Case: WriteTo the full range.
+	var buf bytes.Buffer
+	var n, err = rr.WriteTo(&buf)
+	c.Check(err, gc.IsNil)
+	c.Check(n, gc.Equals, int64(12))
+	c.Check(buf.String(), gc.Equals, "hello, world")
+
+	// Case: ReadAt which extends beyond the range end.
+	var b = make([]byte, 6)
+	nn, err := rr.ReadAt(b, 8)
+	c.Check(err, gc.Equals, io.EOF)
+	c.Check(string(b[:nn]), gc.Equals, "orld")
+
+	// Case: ReadAt of content which isn't available (a single chunk).
+	nn, err = rr.ReadAt(b[:5], 0)
+	c.Check(err, gc.Equals, ErrOffsetNotYetAvailable)
+	c.Check(nn, gc.Equals, 0)
+
+	// Case: ReadAt at or beyond range end.
+	nn, err = rr.ReadAt(b, 12)
+	c.Check(err, gc.Equals, io.EOF)
+	c.Check(nn, gc.Equals, 0)
+}
+
+var _ = gc.Suite(&RangeReaderSuite{})
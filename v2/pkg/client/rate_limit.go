@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+)
+
+// RateLimitedJournalClient wraps a RoutedJournalClient with token-bucket
+// budgets of appended bytes per second and Append RPCs per second, which are
+// shared across all Appends (to any journal) dispatched through it. This
+// allows, for example, a batch backfill to run alongside live traffic without
+// saturating brokers. As Appender, Append, and AppendService each dispatch
+// through a RoutedJournalClient, budgets are enforced for all of them.
+//
+// If Shed is false, Appends block until budget is available (or until the
+// RPC's Context is cancelled). Otherwise, an Append which would exceed the
+// budget fails with a *RateLimitError, and its RPC is aborted. Note that
+// AppendService retries failed Appends, and with a shedding client will
+// repeatedly back off and retry until budget is available.
+type RateLimitedJournalClient struct {
+	pb.RoutedJournalClient
+	// Shed Appends which exceed the budget, rather than blocking them.
+	Shed bool
+
+	bytes   *rate.Limiter // Budget of appended bytes, or nil if unlimited.
+	appends *rate.Limiter // Budget of Append RPCs, or nil if unlimited.
+}
+
+// NewRateLimitedJournalClient returns a RateLimitedJournalClient which wraps
+// |rjc| with budgets of |bytesPerSecond| and |appendsPerSecond|. Either may
+// be zero, in which case it's not limited. A budget is permitted to burst
+// up to one second of its rate.
+func NewRateLimitedJournalClient(rjc pb.RoutedJournalClient, bytesPerSecond, appendsPerSecond int) *RateLimitedJournalClient {
+	var c = &RateLimitedJournalClient{RoutedJournalClient: rjc}
+
+	if bytesPerSecond > 0 {
+		c.bytes = rate.NewLimiter(rate.Limit(bytesPerSecond), bytesPerSecond)
+	}
+	if appendsPerSecond > 0 {
+		c.appends = rate.NewLimiter(rate.Limit(appendsPerSecond), appendsPerSecond)
+	}
+	return c
+}
+
+// RateLimitError is returned by a shedding RateLimitedJournalClient for an
+// Append which exceeded its budget.
+type RateLimitError struct {
+	// Journal of the shed Append.
+	Journal pb.Journal
+	// Budget which was exceeded: "bytes" or "appends".
+	Budget string
+	// Delay after which the budget would have been available.
+	Delay time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("append rate limit exceeded (journal %s, %s budget, delay %s)",
+		e.Journal, e.Budget, e.Delay)
+}
+
+// Append implements the JournalClient interface, wrapping the returned
+// Journal_AppendClient to apply budgets to each sent AppendRequest.
+func (c *RateLimitedJournalClient) Append(ctx context.Context, opts ...grpc.CallOption) (pb.Journal_AppendClient, error) {
+	// Derive a cancelable Context, which is used to abort the RPC if
+	// an AppendRequest is shed.
+	ctx, cancel := context.WithCancel(ctx)
+
+	var stream, err = c.RoutedJournalClient.Append(ctx, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &rateLimitedAppendClient{
+		Journal_AppendClient: stream,
+		client:               c,
+		ctx:                  ctx,
+		cancel:               cancel,
+	}, nil
+}
+
+// rateLimitedAppendClient applies budgets to each sent AppendRequest.
+type rateLimitedAppendClient struct {
+	pb.Journal_AppendClient
+
+	client  *RateLimitedJournalClient
+	ctx     context.Context
+	cancel  context.CancelFunc
+	journal pb.Journal
+}
+
+func (s *rateLimitedAppendClient) Send(req *pb.AppendRequest) error { return s.SendMsg(req) }
+
+func (s *rateLimitedAppendClient) SendMsg(m interface{}) error {
+	var req, ok = m.(*pb.AppendRequest)
+	if !ok {
+		return s.Journal_AppendClient.SendMsg(m)
+	}
+	var err error
+
+	if req.Journal != "" {
+		// This is the request preamble, which begins a new Append.
+		s.journal = req.Journal
+		err = s.take(s.client.appends, 1, "appends")
+	} else if len(req.Content) != 0 {
+		err = s.take(s.client.bytes, len(req.Content), "bytes")
+	}
+
+	if err != nil {
+		s.cancel() // Abort the RPC.
+		return err
+	}
+	return s.Journal_AppendClient.SendMsg(m)
+}
+
+func (s *rateLimitedAppendClient) RecvMsg(m interface{}) error {
+	// Append RPCs have a single response, after which the RPC is complete.
+	defer s.cancel()
+	return s.Journal_AppendClient.RecvMsg(m)
+}
+
+func (s *rateLimitedAppendClient) CloseAndRecv() (*pb.AppendResponse, error) {
+	if err := s.CloseSend(); err != nil {
+		s.cancel()
+		return nil, err
+	}
+	var resp = new(pb.AppendResponse)
+	if err := s.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// take |n| tokens from the Limiter, which may be nil.
+func (s *rateLimitedAppendClient) take(lim *rate.Limiter, n int, budget string) error {
+	if lim == nil {
+		return nil
+	}
+	// Take tokens in increments of at most the Limiter's burst,
+	// as larger requests could never be satisfied.
+	var burst = lim.Burst()
+
+	if !s.client.Shed {
+		for n != 0 {
+			var m = n
+			if m > burst {
+				m = burst
+			}
+			if err := lim.WaitN(s.ctx, m); err != nil {
+				return err
+			}
+			n -= m
+		}
+		return nil
+	}
+
+	var now = time.Now()
+	var reservations []*rate.Reservation
+
+	for n != 0 {
+		var m = n
+		if m > burst {
+			m = burst
+		}
+		var r = lim.ReserveN(now, m)
+		reservations = append(reservations, r)
+
+		if delay := r.DelayFrom(now); !r.OK() || delay != 0 {
+			for _, r := range reservations {
+				r.CancelAt(now)
+			}
+			return &RateLimitError{Journal: s.journal, Budget: budget, Delay: delay}
+		}
+		n -= m
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/broker/teststub"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type RateLimitSuite struct{}
+
+func (s *RateLimitSuite) TestShedAppendsExceedingBudget(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rlc = NewRateLimitedJournalClient(
+		pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{}), 4, 1)
+	rlc.Shed = true
+
+	// Expect a first Append is permitted, but its second write exceeds
+	// the byte budget and is shed.
+	var a = NewAppender(ctx, rlc, pb.AppendRequest{Journal: "a/journal"})
+
+	var n, err = a.Write([]byte("foo"))
+	c.Check(err, gc.IsNil)
+	c.Check(n, gc.Equals, 3)
+
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("foo")})
+
+	_, err = a.Write([]byte("bar"))
+	c.Assert(err, gc.FitsTypeOf, &RateLimitError{})
+	c.Check(err.(*RateLimitError).Journal, gc.Equals, pb.Journal("a/journal"))
+	c.Check(err.(*RateLimitError).Budget, gc.Equals, "bytes")
+	c.Check(err.(*RateLimitError).Delay > 0, gc.Equals, true)
+
+	c.Check(<-broker.AppendReqCh, gc.IsNil) // Aborted RPC.
+
+	// Expect a second Append exceeds the appends budget, and is shed
+	// prior to sending any request.
+	a = NewAppender(ctx, rlc, pb.AppendRequest{Journal: "a/journal"})
+
+	_, err = a.Write([]byte("baz"))
+	c.Assert(err, gc.FitsTypeOf, &RateLimitError{})
+	c.Check(err.(*RateLimitError).Budget, gc.Equals, "appends")
+	c.Check(err, gc.ErrorMatches, `append rate limit exceeded \(journal a/journal, appends budget, delay .*\)`)
+}
+
+func (s *RateLimitSuite) TestBlockingAppendsAwaitBudget(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rlc = NewRateLimitedJournalClient(
+		pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{}), 20, 0)
+
+	go func() {
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("01234567890123456789")})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("0123456789")})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+		c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+
+		broker.AppendRespCh <- buildAppendResponseFixture(broker)
+	}()
+
+	// Expect writes of 20 and then 10 bytes are sent, and that the second
+	// awaits budget (of 20 bytes/sec, having a burst of 20 bytes).
+	var a = NewAppender(ctx, rlc, pb.AppendRequest{Journal: "a/journal"})
+	var start = time.Now()
+
+	var _, err = a.Write([]byte("01234567890123456789"))
+	c.Check(err, gc.IsNil)
+	_, err = a.Write([]byte("0123456789"))
+	c.Check(err, gc.IsNil)
+	c.Check(a.Close(), gc.IsNil)
+
+	c.Check(time.Since(start) >= 400*time.Millisecond, gc.Equals, true)
+}
+
+var _ = gc.Suite(&RateLimitSuite{})
@@ -2,15 +2,22 @@ package client
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha1"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/LiveRamp/gazette/v2/pkg/codecs"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -25,11 +32,16 @@ import (
 type Reader struct {
 	Request  pb.ReadRequest  // ReadRequest of the Reader.
 	Response pb.ReadResponse // Most recent ReadResponse from broker.
-
-	ctx    context.Context
-	client pb.RoutedJournalClient // Client against which Read is dispatched.
-	stream pb.Journal_ReadClient  // Server stream.
-	direct io.ReadCloser          // Directly opened Fragment URL.
+	// Header of the most recent ReadResponse which included one. It identifies
+	// the broker (ProcessId) which is serving the read, and the journal Route
+	// (Members and Primary) which that broker advertised.
+	Header pb.Header
+
+	ctx        context.Context
+	client     pb.RoutedJournalClient // Client against which Read is dispatched.
+	stream     pb.Journal_ReadClient  // Server stream.
+	direct     io.ReadCloser          // Directly opened Fragment URL.
+	reresolved bool                   // Whether a stale Route was invalidated & retried.
 }
 
 // NewReader returns a Reader initialized with the given BrokerClient and ReadRequest.
@@ -79,6 +91,8 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 		} else if r.Response.Status == pb.Status_OK && r.Response.Offset < r.Request.Offset {
 			err = pb.NewValidationError("invalid ReadResponse offset (%d; expected >= %d)",
 				r.Response.Offset, r.Request.Offset) // Violation of Read API contract.
+		} else if r.Response.ContentCodec != pb.CompressionCodec_INVALID {
+			err = decompressContent(&r.Response)
 		}
 	} else {
 		err = mapGRPCCtxErr(r.ctx, err)
@@ -87,12 +101,14 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 	// A note on resource leaks: an invariant of Read is that in invocations where
 	// the returned error != nil, an error has also been read from |r.stream|,
 	// implying that the gRPC stream has been torn down. The exception is if
-	// response validation fails, which indicates a client / server API version
-	// incompatibility and cannot happen in normal operation.
+	// response validation (or Content decompression) fails, which indicates a
+	// client / server API version incompatibility and cannot happen in normal
+	// operation.
 
 	if err == nil {
 		// If a Header was sent, advise of its advertised journal Route.
 		if r.Response.Header != nil {
+			r.Header = *r.Response.Header
 			r.client.UpdateRoute(r.Request.Journal.String(), &r.Response.Header.Route)
 		}
 
@@ -135,11 +151,19 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 			panic(err.Error()) // Status_OK implies graceful stream closure.
 		}
 	case pb.Status_NOT_JOURNAL_BROKER:
+		// The Route we dispatched with is likely stale. Invalidate it, and retry
+		// once against the default service address (which will re-resolve).
+		if !r.reresolved && !r.client.IsNoopRouter() {
+			r.client.UpdateRoute(r.Request.Journal.String(), nil)
+			r.reresolved, r.stream, r.Response = true, nil, pb.ReadResponse{}
+
+			return r.Read(p)
+		}
 		err = ErrNotJournalBroker
 	case pb.Status_OFFSET_NOT_YET_AVAILABLE:
 		err = ErrOffsetNotYetAvailable
 	default:
-		err = errors.New(r.Response.Status.String())
+		err = StatusError(r.Response.Status)
 	}
 	return
 }
@@ -175,10 +199,45 @@ func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 	return r.Request.Offset, err
 }
 
+// WithFragmentSumVerification returns a Context which instructs fragments
+// directly opened with it (by OpenFragmentURL, or by a Reader or RetryReader
+// reading a Fragment URL) to verify the SHA1 sum of their content as it's
+// read. See FragmentReader.
+func WithFragmentSumVerification(ctx context.Context) context.Context {
+	return context.WithValue(ctx, verifySumCtxKey{}, true)
+}
+
+type verifySumCtxKey struct{}
+
+// decompressContent replaces the compressed Content of |resp| with its
+// decompression, and clears its ContentCodec.
+func decompressContent(resp *pb.ReadResponse) error {
+	var dec, err = codecs.NewCodecReader(bytes.NewReader(resp.Content), resp.ContentCodec)
+	if err != nil {
+		return err
+	}
+	var content []byte
+	if content, err = ioutil.ReadAll(dec); err == nil {
+		err = dec.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("decompressing %s ReadResponse Content: %s", resp.ContentCodec, err)
+	}
+	resp.Content, resp.ContentCodec = content, pb.CompressionCodec_INVALID
+	return nil
+}
+
 // OpenFragmentURL directly opens |fragment|, which must be available at URL
 // |url|, and returns a *FragmentReader which has been pre-seeked to |offset|.
-func OpenFragmentURL(ctx context.Context, fragment pb.Fragment, offset int64, url string) (*FragmentReader, error) {
-	var req, err = http.NewRequest("GET", url, nil)
+// If |ctx| was returned by WithFragmentSumVerification, the FragmentReader
+// verifies fragment content against its SHA1 sum.
+func OpenFragmentURL(ctx context.Context, fragment pb.Fragment, offset int64, url string) (_ *FragmentReader, err error) {
+	var span trace.Span
+	ctx, span = pb.StartSpan(ctx, "client.OpenFragmentURL",
+		trace.WithAttributes(attribute.String("fragment", fragment.ContentName())))
+	defer func() { pb.EndSpan(span, err) }()
+
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -202,22 +261,55 @@ func OpenFragmentURL(ctx context.Context, fragment pb.Fragment, offset int64, ur
 		return nil, err
 	} else if resp.StatusCode != http.StatusOK {
 		_ = resp.Body.Close()
-		return nil, fmt.Errorf("!OK fetching (%s, %q)", resp.Status, url)
+		return nil, &FragmentFetchError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
-	// Technically the store _must_ decompress in response to honor our
-	// Accept-Encoding header, but some implementations (eg, Minio) don't.
-	if fragment.CompressionCodec == pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION &&
-		resp.Header.Get("Content-Encoding") == "gzip" {
+	// Decompress client-side if content is served with an encoding
+	// other than that of the Fragment's CompressionCodec.
+	fragment.CompressionCodec = responseCodec(fragment.CompressionCodec, resp)
 
-		fragment.CompressionCodec = pb.CompressionCodec_GZIP // Decompress client-side.
+	var verify, _ = ctx.Value(verifySumCtxKey{}).(bool)
+	return newFragmentReader(resp.Body, fragment, offset, verify)
+}
+
+// responseCodec returns the CompressionCodec of content of a fragment |resp|,
+// where |codec| is the CompressionCodec of the Fragment (as determined by its
+// content name suffix). Stores may additionally serve content with the
+// Content-Encoding of the stored object, in which case it must be
+// decompressed client-side.
+func responseCodec(codec pb.CompressionCodec, resp *http.Response) pb.CompressionCodec {
+	var encoding = strings.ToLower(resp.Header.Get("Content-Encoding"))
+
+	switch codec {
+	case pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION:
+		// Technically the store _must_ decompress in response to honor our
+		// Accept-Encoding header, but some implementations (eg, Minio) don't.
+		if encoding == "gzip" {
+			return pb.CompressionCodec_GZIP
+		}
+	case pb.CompressionCodec_NONE:
+		if encoding == "gzip" {
+			return pb.CompressionCodec_GZIP
+		} else if encoding == "zstd" {
+			return pb.CompressionCodec_ZSTANDARD
+		}
 	}
-	return NewFragmentReader(resp.Body, fragment, offset)
+	return codec
 }
 
 // NewFragmentReader wraps |rc|, which is a io.ReadCloser of raw Fragment bytes,
 // with a returned *FragmentReader which has been pre-seeked to |offset|.
 func NewFragmentReader(rc io.ReadCloser, fragment pb.Fragment, offset int64) (*FragmentReader, error) {
+	return newFragmentReader(rc, fragment, offset, false)
+}
+
+// NewVerifiedFragmentReader is like NewFragmentReader, but the returned
+// *FragmentReader additionally verifies content against the Fragment SHA1 sum.
+func NewVerifiedFragmentReader(rc io.ReadCloser, fragment pb.Fragment, offset int64) (*FragmentReader, error) {
+	return newFragmentReader(rc, fragment, offset, true)
+}
+
+func newFragmentReader(rc io.ReadCloser, fragment pb.Fragment, offset int64, verify bool) (*FragmentReader, error) {
 	var decomp, err = codecs.NewCodecReader(rc, fragment.CompressionCodec)
 	if err != nil {
 		_ = rc.Close()
@@ -230,6 +322,11 @@ func NewFragmentReader(rc io.ReadCloser, fragment pb.Fragment, offset int64) (*F
 		Fragment: fragment,
 		Offset:   fragment.Begin,
 	}
+	if verify && !fragment.Sum256.IsZero() {
+		fr.sha = sha256.New()
+	} else if verify && !fragment.Sum.IsZero() {
+		fr.sha = sha1.New()
+	}
 
 	// Attempt to seek to |offset| within the fragment.
 	var delta = offset - fragment.Begin
@@ -247,6 +344,7 @@ type FragmentReader struct {
 
 	decomp io.ReadCloser
 	raw    io.ReadCloser
+	sha    hash.Hash // Running SHA1 (or SHA256) of content from Fragment.Begin, if verifying.
 }
 
 // Read returns the next bytes of decompressed Fragment content. When Read
@@ -254,7 +352,11 @@ type FragmentReader struct {
 // Read returns EOF only if the underlying Reader returns EOF at precisely
 // Offset == Fragment.End. If the underlying Reader is too short,
 // io.ErrUnexpectedEOF is returned. If it's too long, ErrDidNotReadExpectedEOF
-// is returned.
+// is returned. If the FragmentReader verifies content and the sum of content
+// read through Fragment.End doesn't match Fragment.Sum256 (or Sum), Read returns
+// ErrFragmentSumMismatch in place of EOF. Note that as the sum can be checked
+// only upon reaching Fragment.End, corrupt content is detected only after
+// it's been read.
 func (fr *FragmentReader) Read(p []byte) (n int, err error) {
 	n, err = fr.decomp.Read(p)
 	fr.Offset += int64(n)
@@ -267,9 +369,26 @@ func (fr *FragmentReader) Read(p []byte) (n int, err error) {
 		// Did we read EOF before the reaching Fragment.End?
 		err = io.ErrUnexpectedEOF
 	}
+
+	if fr.sha != nil {
+		_, _ = fr.sha.Write(p[:n])
+
+		if err == io.EOF && !fr.sumMatches() {
+			err = ErrFragmentSumMismatch
+		}
+	}
 	return
 }
 
+// sumMatches returns whether the running sum of read content matches the
+// Fragment's SHA256 sum, or its SHA1 sum if it has no SHA256 sum.
+func (fr *FragmentReader) sumMatches() bool {
+	if !fr.Fragment.Sum256.IsZero() {
+		return pb.SHA256SumFromDigest(fr.sha.Sum(nil)) == fr.Fragment.Sum256
+	}
+	return pb.SHA1SumFromDigest(fr.sha.Sum(nil)) == fr.Fragment.Sum
+}
+
 // Close closes the underlying ReadCloser and associated
 // decompressor (if any).
 func (fr *FragmentReader) Close() error {
@@ -284,8 +403,20 @@ func (fr *FragmentReader) Close() error {
 // with the http.Client used by OpenFragmentURL. The returned cleanup function
 // removes the handler and restores the prior http.Client.
 func InstallFileTransport(root string) (remove func()) {
-	var transport = new(http.Transport)
-	*transport = *http.DefaultTransport.(*http.Transport) // Clone.
+	// Build a new Transport with the settings of the DefaultTransport, rather
+	// than copying the DefaultTransport (and its internal state) by value.
+	var def = http.DefaultTransport.(*http.Transport)
+	var transport = &http.Transport{
+		Proxy:                 def.Proxy,
+		DialContext:           def.DialContext,
+		TLSClientConfig:       def.TLSClientConfig,
+		TLSHandshakeTimeout:   def.TLSHandshakeTimeout,
+		MaxIdleConns:          def.MaxIdleConns,
+		MaxIdleConnsPerHost:   def.MaxIdleConnsPerHost,
+		IdleConnTimeout:       def.IdleConnTimeout,
+		ResponseHeaderTimeout: def.ResponseHeaderTimeout,
+		ExpectContinueTimeout: def.ExpectContinueTimeout,
+	}
 	transport.RegisterProtocol("file", http.NewFileTransport(http.Dir(root)))
 
 	var prevClient = httpClient
@@ -308,16 +439,49 @@ func mapGRPCCtxErr(ctx context.Context, err error) error {
 	return err
 }
 
+// StatusError is a non-OK broker response Status, returned as an error.
+// Callers may compare a returned error directly with the named errors below
+// (eg, err == ErrJournalNotFound), or type-assert it to a StatusError to
+// recover the pb.Status.
+type StatusError pb.Status
+
+func (s StatusError) Error() string { return pb.Status(s).String() }
+
+// FragmentFetchError is returned by OpenFragmentURL if the fragment store
+// responds with a non-OK HTTP status.
+type FragmentFetchError struct {
+	// URL of the fetched Fragment.
+	URL string
+	// HTTP status code and status text of the store response.
+	StatusCode int
+	Status     string
+}
+
+func (e *FragmentFetchError) Error() string {
+	return fmt.Sprintf("!OK fetching (%s, %q)", e.Status, e.URL)
+}
+
 var (
-	// Map common broker error statuses into named errors.
-	ErrNotJournalBroker        = errors.New(pb.Status_NOT_JOURNAL_BROKER.String())
-	ErrNotJournalPrimaryBroker = errors.New(pb.Status_NOT_JOURNAL_PRIMARY_BROKER.String())
-	ErrOffsetNotYetAvailable   = errors.New(pb.Status_OFFSET_NOT_YET_AVAILABLE.String())
-	ErrWrongAppendOffset       = errors.New(pb.Status_WRONG_APPEND_OFFSET.String())
+	// Map broker error statuses into named errors.
+	ErrJournalNotFound            error = StatusError(pb.Status_JOURNAL_NOT_FOUND)
+	ErrNoJournalPrimaryBroker     error = StatusError(pb.Status_NO_JOURNAL_PRIMARY_BROKER)
+	ErrNotJournalPrimaryBroker    error = StatusError(pb.Status_NOT_JOURNAL_PRIMARY_BROKER)
+	ErrNotJournalBroker           error = StatusError(pb.Status_NOT_JOURNAL_BROKER)
+	ErrInsufficientJournalBrokers error = StatusError(pb.Status_INSUFFICIENT_JOURNAL_BROKERS)
+	ErrOffsetNotYetAvailable      error = StatusError(pb.Status_OFFSET_NOT_YET_AVAILABLE)
+	ErrWrongRoute                 error = StatusError(pb.Status_WRONG_ROUTE)
+	ErrFragmentMismatch           error = StatusError(pb.Status_FRAGMENT_MISMATCH)
+	ErrEtcdTransactionFailed      error = StatusError(pb.Status_ETCD_TRANSACTION_FAILED)
+	ErrNotAllowed                 error = StatusError(pb.Status_NOT_ALLOWED)
+	ErrWrongAppendOffset          error = StatusError(pb.Status_WRONG_APPEND_OFFSET)
+	ErrIndexHasGreaterOffset      error = StatusError(pb.Status_INDEX_HAS_GREATER_OFFSET)
+	ErrWrongContentType           error = StatusError(pb.Status_WRONG_CONTENT_TYPE)
+	ErrAppendContinuationExpired  error = StatusError(pb.Status_APPEND_CONTINUATION_EXPIRED)
 
 	ErrOffsetJump            = errors.New("offset jump")
 	ErrSeekRequiresNewReader = errors.New("seek offset requires new Reader")
 	ErrDidNotReadExpectedEOF = errors.New("did not read EOF at expected Fragment.End")
+	ErrFragmentSumMismatch   = errors.New("fragment content does not match Fragment.Sum")
 
 	// httpClient is the http.Client used by OpenFragmentURL
 	httpClient = http.DefaultClient
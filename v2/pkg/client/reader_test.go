@@ -2,10 +2,13 @@ package client
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -47,6 +50,55 @@ func (s *ReaderSuite) TestOpenFragmentURLCases(c *gc.C) {
 	c.Check(rc.Offset, gc.Equals, rc.Fragment.End)
 	c.Check(rc.Close(), gc.IsNil)
 
+	// Case: read a portion of the fragment, verifying its sum.
+	rc, err = OpenFragmentURL(WithFragmentSumVerification(ctx), frag, frag.Begin+5, url)
+	c.Check(err, gc.IsNil)
+
+	b, err = ioutil.ReadAll(rc)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+	c.Check(rc.Close(), gc.IsNil)
+
+	// Case: fragment content doesn't match its sum.
+	var badSum = frag
+	badSum.Sum = pb.SHA1SumOf("something else")
+
+	rc, err = OpenFragmentURL(WithFragmentSumVerification(ctx), badSum, frag.Begin+5, url)
+	c.Check(err, gc.IsNil)
+
+	b, err = ioutil.ReadAll(rc)
+	c.Check(err, gc.Equals, ErrFragmentSumMismatch)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+	c.Check(rc.Close(), gc.IsNil)
+
+	// Case: without verification, a mismatched sum isn't detected.
+	rc, err = OpenFragmentURL(ctx, badSum, frag.Begin+5, url)
+	c.Check(err, gc.IsNil)
+
+	_, err = ioutil.ReadAll(rc)
+	c.Check(err, gc.IsNil)
+	c.Check(rc.Close(), gc.IsNil)
+
+	// Case: a fragment having a SHA256 sum is verified by it.
+	var sha256Sum = frag
+	sha256Sum.Sum, sha256Sum.Sum256 = pb.SHA1Sum{}, pb.SHA256SumOf("XXXXXhello, world!!!")
+
+	rc, err = OpenFragmentURL(WithFragmentSumVerification(ctx), sha256Sum, frag.Begin+5, url)
+	c.Check(err, gc.IsNil)
+
+	_, err = ioutil.ReadAll(rc)
+	c.Check(err, gc.IsNil)
+	c.Check(rc.Close(), gc.IsNil)
+
+	sha256Sum.Sum256 = pb.SHA256SumOf("something else")
+
+	rc, err = OpenFragmentURL(WithFragmentSumVerification(ctx), sha256Sum, frag.Begin+5, url)
+	c.Check(err, gc.IsNil)
+
+	_, err = ioutil.ReadAll(rc)
+	c.Check(err, gc.Equals, ErrFragmentSumMismatch)
+	c.Check(rc.Close(), gc.IsNil)
+
 	// Case: stream ends before Fragment.End.
 	frag.End += 1
 	rc, err = OpenFragmentURL(ctx, frag, frag.Begin+5, url)
@@ -70,6 +122,7 @@ func (s *ReaderSuite) TestOpenFragmentURLCases(c *gc.C) {
 	// Case: doesn't exist.
 	rc, err = OpenFragmentURL(ctx, frag, frag.Begin, url+"does-not-exist")
 	c.Check(err, gc.ErrorMatches, `!OK fetching \(404 Not Found, "file:///.*\)`)
+	c.Check(err.(*FragmentFetchError).StatusCode, gc.Equals, http.StatusNotFound)
 
 	// Case: decompression fails.
 	frag.CompressionCodec = pb.CompressionCodec_SNAPPY
@@ -77,6 +130,44 @@ func (s *ReaderSuite) TestOpenFragmentURLCases(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `snappy: corrupt input`)
 }
 
+func (s *ReaderSuite) TestOpenFragmentURLWithContentEncoding(c *gc.C) {
+	const data = "XXXXXhello, world!!!"
+
+	var encoded bytes.Buffer
+	var comp, err = codecs.NewCodecWriter(&encoded, pb.CompressionCodec_ZSTANDARD)
+	c.Assert(err, gc.IsNil)
+	_, err = comp.Write([]byte(data))
+	c.Assert(err, gc.IsNil)
+	c.Assert(comp.Close(), gc.IsNil)
+
+	// Serve the fragment as an object stored with "Content-Encoding: zstd",
+	// which the store doesn't decode on our behalf.
+	var srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "zstd")
+		_, _ = w.Write(encoded.Bytes())
+	}))
+	defer srv.Close()
+
+	var frag = pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            100,
+		End:              120,
+		Sum:              pb.SHA1SumOf(data),
+		CompressionCodec: pb.CompressionCodec_NONE,
+	}
+	// Expect content is decoded, and offsets reflect decoded content.
+	rc, err := OpenFragmentURL(WithFragmentSumVerification(context.Background()),
+		frag, frag.Begin+5, srv.URL+"/"+frag.ContentName())
+	c.Assert(err, gc.IsNil)
+	c.Check(rc.Fragment.CompressionCodec, gc.Equals, pb.CompressionCodec_ZSTANDARD)
+
+	b, err := ioutil.ReadAll(rc)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+	c.Check(rc.Offset, gc.Equals, frag.End)
+	c.Check(rc.Close(), gc.IsNil)
+}
+
 func (s *ReaderSuite) TestReaderCases(c *gc.C) {
 	var frag, url, dir, cleanup = buildFragmentFixture(c)
 	defer cleanup()
@@ -94,6 +185,8 @@ func (s *ReaderSuite) TestReaderCases(c *gc.C) {
 		// Case 2: fragment metadata but no URL, at read offset -1.
 		readFixture{fragment: &frag, offset: 110},
 		// Case 3: wrong broker (and it's not instructed to proxy).
+		// Expect the Reader invalidates its Route and retries once.
+		readFixture{status: pb.Status_NOT_JOURNAL_BROKER},
 		readFixture{status: pb.Status_NOT_JOURNAL_BROKER},
 		// Case 4: read some content, and then OFFSET_NOT_YET_AVAILABLE.
 		readFixture{content: "prior content", status: pb.Status_OFFSET_NOT_YET_AVAILABLE},
@@ -145,12 +238,13 @@ func (s *ReaderSuite) TestReaderCases(c *gc.C) {
 	c.Check(n, gc.Equals, 0)
 	c.Check(err, gc.Equals, io.EOF)
 
-	// Case 3: NOT_JOURNAL_BROKER => ErrNotJournalBroker.
+	// Case 3: NOT_JOURNAL_BROKER => retry => ErrNotJournalBroker.
 	r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 105})
 	n, err = r.Read(b)
 
 	c.Check(n, gc.Equals, 0)
 	c.Check(err, gc.Equals, ErrNotJournalBroker)
+	c.Check(r.reresolved, gc.Equals, true)
 
 	// Case 4: read content, then OFFSET_NOT_YET_AVAILABLE => ErrOffsetNotYetAvailable.
 	r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 105})
@@ -182,6 +276,8 @@ func (s *ReaderSuite) TestReaderCases(c *gc.C) {
 
 	// Expect offset was skipped forward to Response.Offset.
 	c.Check(r.Request.Offset, gc.Equals, int64(110))
+	// Expect the responding broker and its Route are retained.
+	c.Check(r.Header, gc.DeepEquals, *buildHeaderFixture(broker))
 
 	// Note the fixture content is split across two ReadResponses.
 	// Consume both messages across four small Reads.
@@ -211,6 +307,10 @@ func (s *ReaderSuite) TestReaderCases(c *gc.C) {
 	c.Check(string(b[:n]), gc.Equals, "baz bi")
 	c.Check(r.Request.Offset, gc.Equals, int64(124))
 
+	// Later ReadResponses have no Header, but the Reader's Header is retained.
+	c.Check(r.Response.Header, gc.IsNil)
+	c.Check(r.Header.ProcessId, gc.Equals, pb.ProcessSpec_ID{Zone: "a", Suffix: "broker"})
+
 	n, err = r.Read(b[:])
 	c.Check(err, gc.IsNil)
 	c.Check(string(b[:n]), gc.Equals, "ng")
@@ -259,6 +359,51 @@ func (s *ReaderSuite) TestBufferedOffsetAdjustment(c *gc.C) {
 	c.Check(r.AdjustedOffset(br), gc.Equals, int64(100+7))
 }
 
+func (s *ReaderSuite) TestCompressedContentIsDecompressed(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+
+	go func() {
+		var req = <-broker.ReadReqCh
+		c.Check(req.ContentCodec, gc.Equals, pb.CompressionCodec_GZIP)
+
+		var comp bytes.Buffer
+		var w, err = codecs.NewCodecWriter(&comp, pb.CompressionCodec_GZIP)
+		c.Assert(err, gc.IsNil)
+		_, _ = w.Write([]byte("hello, compressed world"))
+		c.Assert(w.Close(), gc.IsNil)
+
+		broker.ReadRespCh <- &pb.ReadResponse{
+			Status:    pb.Status_OK,
+			Header:    buildHeaderFixture(broker),
+			Offset:    100,
+			WriteHead: 1024,
+			Fragment:  &pb.Fragment{Journal: "a/journal", Begin: 0, End: 1024, CompressionCodec: pb.CompressionCodec_NONE},
+		}
+		broker.ReadRespCh <- &pb.ReadResponse{
+			Offset:       100,
+			Content:      comp.Bytes(),
+			ContentCodec: pb.CompressionCodec_GZIP,
+		}
+		broker.ErrCh <- nil
+	}()
+
+	var r = NewReader(ctx, rjc, pb.ReadRequest{
+		Journal:      "a/journal",
+		Offset:       100,
+		ContentCodec: pb.CompressionCodec_GZIP,
+	})
+	var b, err = ioutil.ReadAll(r)
+	c.Check(string(b), gc.Equals, "hello, compressed world")
+	c.Check(err, gc.IsNil)
+
+	// Expect offsets reflect uncompressed content.
+	c.Check(r.Request.Offset, gc.Equals, int64(100+23))
+}
+
 func (s *ReaderSuite) TestReaderSeekCases(c *gc.C) {
 	var frag, url, dir, cleanup = buildFragmentFixture(c)
 	defer cleanup()
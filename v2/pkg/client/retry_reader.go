@@ -56,6 +56,7 @@ func (rr *RetryReader) Offset() int64 {
 //    for a non-blocking ReadRequest.
 //  * An offset jump occurred (ErrOffsetJump), in which case the client
 //    should inspect the new Offset may continue reading if desired.
+//  * A directly read Fragment failed verification (ErrFragmentSumMismatch).
 // All other errors are retried.
 func (rr *RetryReader) Read(p []byte) (n int, err error) {
 	for i := 0; true; i++ {
@@ -75,7 +76,7 @@ func (rr *RetryReader) Read(p []byte) (n int, err error) {
 		rr.Reader = NewReader(rr.Reader.ctx, rr.Reader.client, rr.Reader.Request)
 
 		switch err {
-		case context.DeadlineExceeded, context.Canceled:
+		case context.DeadlineExceeded, context.Canceled, ErrFragmentSumMismatch:
 			return // Surface to caller.
 		case ErrOffsetNotYetAvailable:
 			if rr.Reader.Request.Block {
@@ -0,0 +1,406 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SpoolAppender provides store-and-forward semantics for producers which must
+// continue to accept writes while brokers are unreachable (eg, at the network
+// edge). Each Append is durably spooled to a file of a local directory before
+// returning, and spooled Appends are then forwarded to brokers in the order
+// they were spooled. If brokers are unavailable, spooled Appends accumulate
+// on disk and are drained once connectivity returns. As the spool directory
+// is recovered by NewSpoolAppender, Appends also survive process restarts.
+//
+// Appends may carry a dedup key. An Append having the key of an Append which
+// is still spooled, or which was recently forwarded, is ignored. This lets
+// producers safely re-spool content (eg, on restart) without duplicating it.
+// Delivery is at-least-once: an Append which commits while its response is
+// lost (or during process shutdown) is forwarded again.
+//
+// Appends which fail with an error that's not transient (eg, because the
+// journal doesn't exist) cannot be forwarded. Their spool files are renamed
+// with a unique ".<timestamp>.failed" suffix for inspection, and draining continues.
+type SpoolAppender struct {
+	ctx    context.Context
+	client pb.RoutedJournalClient
+	dir    string
+
+	mu        sync.Mutex
+	seq       int64          // Next spool file sequence number.
+	queue     []spoolEntry   // Spooled entries, in forwarding order.
+	pending   map[string]int // Count of spooled entries, by dedup key.
+	forwarded []string       // Ring of recently forwarded dedup keys.
+	recent    map[string]int // Count of |forwarded| entries, by dedup key.
+	idleCh    chan struct{}  // Closed when |queue| is empty.
+	signalCh  chan struct{}  // Signals that |queue| was extended.
+	doneCh    chan struct{}  // Closed when the serve loop exits.
+}
+
+// spoolEntry is a spooled Append.
+type spoolEntry struct {
+	seq int64
+	key string
+}
+
+// NewSpoolAppender returns a SpoolAppender which spools to |dir|, and which
+// forwards spooled Appends using |client| until |ctx| is cancelled. Appends
+// which were spooled to |dir| by a previous SpoolAppender, and not yet
+// forwarded, are recovered and will be forwarded first.
+func NewSpoolAppender(ctx context.Context, client pb.RoutedJournalClient, dir string) (*SpoolAppender, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	var s = &SpoolAppender{
+		ctx:      ctx,
+		client:   client,
+		dir:      dir,
+		pending:  make(map[string]int),
+		recent:   make(map[string]int),
+		idleCh:   make(chan struct{}),
+		signalCh: make(chan struct{}, 1),
+		doneCh:   make(chan struct{}),
+	}
+
+	// Recover spool files of a previous instance. ReadDir sorts on file name,
+	// which orders spool files on their zero-padded sequence number.
+	var infos, err = ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		var name = info.Name()
+
+		if strings.HasSuffix(name, spoolTempSuffix) {
+			// Spooling was interrupted prior to the Append returning.
+			if err = os.Remove(filepath.Join(dir, name)); err != nil {
+				return nil, err
+			}
+			continue
+		} else if !strings.HasSuffix(name, spoolFileSuffix) {
+			continue // Not a spool file (eg, a failed Append).
+		}
+
+		seq, err := strconv.ParseInt(strings.TrimSuffix(name, spoolFileSuffix), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing spool file name %q: %s", name, err)
+		}
+		_, key, _, err := readSpoolFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		s.queue = append(s.queue, spoolEntry{seq: seq, key: key})
+		s.pending[key]++
+		s.seq = seq + 1
+	}
+
+	if len(s.queue) == 0 {
+		close(s.idleCh)
+	} else {
+		log.WithFields(log.Fields{"dir": dir, "pending": len(s.queue)}).
+			Info("recovered spooled appends")
+	}
+	go s.serve()
+
+	return s, nil
+}
+
+// Append durably spools |content| to be appended to |journal|. If |key| is
+// non-empty and matches that of an Append which is spooled or was recently
+// forwarded, the Append is ignored.
+func (s *SpoolAppender) Append(journal pb.Journal, key string, content []byte) error {
+	if err := journal.Validate(); err != nil {
+		return pb.ExtendContext(err, "journal")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key != "" && (s.pending[key] != 0 || s.recent[key] != 0) {
+		return nil // Duplicate of an Append already spooled or forwarded.
+	}
+	var seq = s.seq
+
+	if err := writeSpoolFile(s.dir, seq, journal, key, content); err != nil {
+		return err
+	}
+	s.seq++
+
+	if len(s.queue) == 0 {
+		s.idleCh = make(chan struct{})
+	}
+	s.queue = append(s.queue, spoolEntry{seq: seq, key: key})
+	s.pending[key]++
+
+	select {
+	case s.signalCh <- struct{}{}:
+	default: // Already signaled.
+	}
+	return nil
+}
+
+// Pending returns the number of spooled Appends which have not yet been
+// forwarded.
+func (s *SpoolAppender) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// Flush blocks until no spooled Appends remain to be forwarded, or until
+// |ctx| or the SpoolAppender's Context is cancelled.
+func (s *SpoolAppender) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	var idleCh = s.idleCh
+	s.mu.Unlock()
+
+	select {
+	case <-idleCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.doneCh:
+		return s.ctx.Err()
+	}
+}
+
+// Done returns a channel which is closed after the SpoolAppender's Context
+// is cancelled, and it has stopped forwarding Appends.
+func (s *SpoolAppender) Done() <-chan struct{} { return s.doneCh }
+
+// serve forwards spooled Appends until the Context is cancelled.
+func (s *SpoolAppender) serve() {
+	defer close(s.doneCh)
+
+	for {
+		s.mu.Lock()
+		var entry, ok = spoolEntry{}, len(s.queue) != 0
+		if ok {
+			entry = s.queue[0]
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			select {
+			case <-s.signalCh:
+				continue
+			case <-s.ctx.Done():
+				return
+			}
+		}
+
+		if err := s.forward(entry); s.ctx.Err() != nil {
+			return
+		} else if err != nil {
+			log.WithFields(log.Fields{"seq": entry.seq, "err": err}).
+				Error("failed to forward spooled append (will retry)")
+
+			select {
+			case <-time.After(spoolRetryInterval):
+				continue
+			case <-s.ctx.Done():
+				return
+			}
+		}
+
+		s.mu.Lock()
+		s.queue = s.queue[1:]
+
+		if s.pending[entry.key]--; s.pending[entry.key] == 0 {
+			delete(s.pending, entry.key)
+		}
+		if entry.key != "" {
+			s.forwarded = append(s.forwarded, entry.key)
+			s.recent[entry.key]++
+
+			if len(s.forwarded) > spoolDedupKeys {
+				var evict = s.forwarded[0]
+				s.forwarded = s.forwarded[1:]
+
+				if s.recent[evict]--; s.recent[evict] == 0 {
+					delete(s.recent, evict)
+				}
+			}
+		}
+		if len(s.queue) == 0 {
+			close(s.idleCh)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// forward the spooled |entry|, retrying transient errors until it
+// succeeds or the Context is cancelled. Upon success, or a non-transient
+// failure, the entry's spool file is removed or renamed (respectively).
+func (s *SpoolAppender) forward(entry spoolEntry) error {
+	var path = spoolFilePath(s.dir, entry.seq)
+
+	var journal, _, content, err = readSpoolFile(path)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; true; attempt++ {
+		var a = NewAppender(s.ctx, s.client, pb.AppendRequest{Journal: journal})
+
+		if _, err = a.Write(content); err == nil {
+			err = a.Close()
+		} else {
+			a.Abort()
+		}
+
+		if err == nil {
+			log.WithFields(log.Fields{
+				"journal": journal,
+				"seq":     entry.seq,
+				"commit":  a.Response.Commit,
+			}).Debug("forwarded spooled append")
+
+			return os.Remove(path)
+		} else if s.ctx.Err() != nil {
+			return s.ctx.Err()
+		} else if !isTransientAppendErr(err) {
+			log.WithFields(log.Fields{"journal": journal, "seq": entry.seq, "err": err}).
+				Error("spooled append failed (will not retry)")
+
+			return os.Rename(path, failedSpoolFilePath(path))
+		}
+
+		if attempt != 0 {
+			log.WithFields(log.Fields{"journal": journal, "seq": entry.seq, "err": err}).
+				Warn("failed to forward spooled append (will retry)")
+		}
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		case <-time.After(backoff(attempt)):
+		}
+	}
+	panic("not reached")
+}
+
+// isTransientAppendErr returns whether |err| is expected to resolve on
+// retry, as when brokers are unreachable or journal routes are changing.
+func isTransientAppendErr(err error) bool {
+	if err == ErrNotJournalBroker || err == ErrNotJournalPrimaryBroker {
+		return true
+	} else if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+			return true
+		}
+	}
+	return false
+}
+
+// writeSpoolFile durably writes a spool file of sequence |seq|. The file is
+// written under a temporary name, synced, and then renamed into place, such
+// that spool files are always complete.
+func writeSpoolFile(dir string, seq int64, journal pb.Journal, key string, content []byte) error {
+	var buf = make([]byte, 0, 2*binary.MaxVarintLen64+len(journal)+len(key))
+	buf = appendSpoolField(buf, journal.String())
+	buf = appendSpoolField(buf, key)
+
+	var path = spoolFilePath(dir, seq)
+	var f, err = os.Create(path + spoolTempSuffix)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(buf); err == nil {
+		_, err = f.Write(content)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(path+spoolTempSuffix, path)
+	}
+	if err != nil {
+		_ = os.Remove(path + spoolTempSuffix)
+	}
+	return err
+}
+
+// readSpoolFile reads the journal, dedup key, and content of a spool file.
+func readSpoolFile(path string) (journal pb.Journal, key string, content []byte, err error) {
+	if content, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+	var r = bytes.NewReader(content)
+	var field string
+
+	if field, err = readSpoolField(r); err != nil {
+		err = fmt.Errorf("reading spool file %s: %s", path, err)
+		return
+	}
+	journal = pb.Journal(field)
+
+	if key, err = readSpoolField(r); err != nil {
+		err = fmt.Errorf("reading spool file %s: %s", path, err)
+		return
+	}
+	content = content[len(content)-r.Len():]
+	return
+}
+
+func appendSpoolField(b []byte, field string) []byte {
+	var n [binary.MaxVarintLen64]byte
+	b = append(b, n[:binary.PutUvarint(n[:], uint64(len(field)))]...)
+	return append(b, field...)
+}
+
+func readSpoolField(r *bytes.Reader) (string, error) {
+	var n, err = binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	} else if n > uint64(r.Len()) {
+		return "", fmt.Errorf("invalid field length (%d; remaining %d)", n, r.Len())
+	}
+	var b = make([]byte, n)
+	_, _ = r.Read(b)
+	return string(b), nil
+}
+
+func spoolFilePath(dir string, seq int64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%s", seq, spoolFileSuffix))
+}
+
+// failedSpoolFilePath returns a unique path for the failed spool file |path|.
+// Sequence numbers of failed spool files may be re-used after a restart, and
+// the suffix is qualified with a timestamp so as not to replace a prior one.
+func failedSpoolFilePath(path string) string {
+	return fmt.Sprintf("%s.%d%s", path, time.Now().UnixNano(), spoolFailedSuffix)
+}
+
+const (
+	spoolFileSuffix   = ".spool"
+	spoolTempSuffix   = ".tmp"
+	spoolFailedSuffix = ".failed"
+)
+
+// spoolRetryInterval is the interval at which a spooled Append is retried,
+// after failing to access its spool file.
+var spoolRetryInterval = 5 * time.Second
+
+// spoolDedupKeys is the number of recently forwarded dedup keys which are
+// retained by a SpoolAppender.
+var spoolDedupKeys = 1024
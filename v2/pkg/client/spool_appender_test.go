@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/broker/teststub"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SpoolAppenderSuite struct{}
+
+func (s *SpoolAppenderSuite) TestSpoolRecoverAndForward(c *gc.C) {
+	var dir, err = ioutil.TempDir("", "SpoolAppenderSuite")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(dir)
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+
+	// Spool Appends with a SpoolAppender which is already cancelled,
+	// and is unable to forward them.
+	var cancelledCtx, cancelFn = context.WithCancel(ctx)
+	cancelFn()
+
+	sa, err := NewSpoolAppender(cancelledCtx, rjc, dir)
+	c.Assert(err, gc.IsNil)
+	<-sa.Done()
+
+	c.Check(sa.Append("a/journal", "key-1", []byte("hello, ")), gc.IsNil)
+	c.Check(sa.Append("a/journal", "key-1", []byte("hello, ")), gc.IsNil) // Duplicate.
+	c.Check(sa.Append("a/journal", "", []byte("world")), gc.IsNil)
+	c.Check(sa.Append("a/journal", "", []byte("world")), gc.IsNil) // Not a duplicate.
+	c.Check(sa.Append("invalid journal", "", nil), gc.ErrorMatches, `journal: .*`)
+	c.Check(sa.Pending(), gc.Equals, 3)
+	c.Check(sa.Flush(ctx), gc.Equals, context.Canceled)
+
+	// Simulate a spool file which was not completely written.
+	c.Check(ioutil.WriteFile(filepath.Join(dir, "00000000000000000009.spool.tmp"), []byte("partial"), 0600), gc.IsNil)
+
+	// Expect a new SpoolAppender recovers spooled Appends, and forwards each
+	// in order. A first attempt fails with a transient error, and is retried.
+	go func() {
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("hello, ")})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+		c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+		broker.ErrCh <- status.Error(codes.Unavailable, "unavailable")
+
+		for _, content := range []string{"hello, ", "world", "world"} {
+			c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+			c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte(content)})
+			c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+			c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+
+			broker.AppendRespCh <- buildAppendResponseFixture(broker)
+		}
+	}()
+
+	sa, err = NewSpoolAppender(ctx, rjc, dir)
+	c.Assert(err, gc.IsNil)
+
+	// Expect a recovered key continues to be de-duplicated.
+	c.Check(sa.Append("a/journal", "key-1", []byte("hello, ")), gc.IsNil)
+
+	var flushCtx, flushCancel = context.WithTimeout(ctx, 10*time.Second)
+	defer flushCancel()
+
+	c.Check(sa.Flush(flushCtx), gc.IsNil)
+	c.Check(sa.Pending(), gc.Equals, 0)
+
+	// Expect a forwarded key is still de-duplicated.
+	c.Check(sa.Append("a/journal", "key-1", []byte("hello, ")), gc.IsNil)
+	c.Check(sa.Pending(), gc.Equals, 0)
+
+	// Expect an Append which fails with a non-transient error is set aside.
+	go func() {
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("!")})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+		c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+
+		var resp = buildAppendResponseFixture(broker)
+		resp.Status, resp.Commit = pb.Status_JOURNAL_NOT_FOUND, nil
+		broker.AppendRespCh <- resp
+	}()
+
+	c.Check(sa.Append("a/journal", "", []byte("!")), gc.IsNil)
+	c.Check(sa.Flush(flushCtx), gc.IsNil)
+
+	infos, err := ioutil.ReadDir(dir)
+	c.Assert(err, gc.IsNil)
+	c.Assert(infos, gc.HasLen, 1)
+	c.Check(infos[0].Name(), gc.Matches, `00000000000000000003\.spool\.\d+\.failed`)
+
+	cancel()
+	<-sa.Done()
+}
+
+var _ = gc.Suite(&SpoolAppenderSuite{})
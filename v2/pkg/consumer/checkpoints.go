@@ -0,0 +1,71 @@
+package consumer
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Checkpoints are named progress markers defined by an Application, such as
+// the cursor of an external API through which the Application has read or
+// written. A CheckpointStore persists its Checkpoints atomically with the
+// journal offsets of each consumer transaction: upon the Shard's recovery,
+// its Checkpoints reflect exactly the messages which have been consumed,
+// and an Application may use them (eg, within BecomePrimary) to resume
+// external side effects from where its last committed transaction left off.
+//
+// Checkpoints are written in their entirety with each transaction, and should
+// be kept small. Checkpoints are not safe for concurrent use. They're intended
+// to be held by a CheckpointStore, and to be used by Applications only from
+// within BecomePrimary, ConsumeMessage, OnTimer, and FinalizeTxn.
+type Checkpoints struct {
+	values map[string][]byte
+}
+
+// NewCheckpoints returns an empty Checkpoints.
+func NewCheckpoints() *Checkpoints {
+	return &Checkpoints{values: make(map[string][]byte)}
+}
+
+// Get the value of Checkpoint |name|, and whether it's set.
+func (c *Checkpoints) Get(name string) ([]byte, bool) {
+	var v, ok = c.values[name]
+	return v, ok
+}
+
+// Set Checkpoint |name| to |value|.
+func (c *Checkpoints) Set(name string, value []byte) {
+	c.values[name] = append([]byte(nil), value...)
+}
+
+// Delete Checkpoint |name|, if it's set.
+func (c *Checkpoints) Delete(name string) {
+	delete(c.values, name)
+}
+
+// Names returns the sorted names of set Checkpoints.
+func (c *Checkpoints) Names() []string {
+	var out = make([]string, 0, len(c.values))
+	for name := range c.values {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// MarshalJSON encodes the Checkpoints, in a form suited for persistence by a CheckpointStore.
+func (c *Checkpoints) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.values)
+}
+
+// UnmarshalJSON decodes Checkpoints previously encoded by MarshalJSON.
+func (c *Checkpoints) UnmarshalJSON(b []byte) error {
+	var values map[string][]byte
+	if err := json.Unmarshal(b, &values); err != nil {
+		return err
+	}
+	if values == nil {
+		values = make(map[string][]byte)
+	}
+	c.values = values
+	return nil
+}
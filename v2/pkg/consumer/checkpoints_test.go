@@ -0,0 +1,54 @@
+package consumer
+
+import (
+	"encoding/json"
+
+	gc "github.com/go-check/check"
+)
+
+type CheckpointsSuite struct{}
+
+func (s *CheckpointsSuite) TestSetGetAndDelete(c *gc.C) {
+	var cp = NewCheckpoints()
+
+	var v, ok = cp.Get("cursor")
+	c.Check(ok, gc.Equals, false)
+	c.Check(v, gc.IsNil)
+
+	var b = []byte("page-1")
+	cp.Set("cursor", b)
+	cp.Set("other", []byte("value"))
+	b[0] = 'P' // Expect Set copies its value.
+
+	v, ok = cp.Get("cursor")
+	c.Check(ok, gc.Equals, true)
+	c.Check(v, gc.DeepEquals, []byte("page-1"))
+	c.Check(cp.Names(), gc.DeepEquals, []string{"cursor", "other"})
+
+	cp.Delete("other")
+	cp.Delete("not-set")
+	c.Check(cp.Names(), gc.DeepEquals, []string{"cursor"})
+}
+
+func (s *CheckpointsSuite) TestJSONRoundTrip(c *gc.C) {
+	var cp = NewCheckpoints()
+	cp.Set("cursor", []byte("page-2"))
+	cp.Set("empty", nil)
+
+	var b, err = json.Marshal(cp)
+	c.Assert(err, gc.IsNil)
+
+	var out = NewCheckpoints()
+	c.Assert(json.Unmarshal(b, out), gc.IsNil)
+	c.Check(out.Names(), gc.DeepEquals, []string{"cursor", "empty"})
+
+	var v, _ = out.Get("cursor")
+	c.Check(v, gc.DeepEquals, []byte("page-2"))
+
+	// A null encoding decodes as empty Checkpoints.
+	c.Assert(json.Unmarshal([]byte("null"), out), gc.IsNil)
+	c.Check(out.Names(), gc.HasLen, 0)
+	out.Set("foo", []byte("bar")) // Doesn't panic.
+}
+
+var _ = gc.Suite(&CheckpointsSuite{})
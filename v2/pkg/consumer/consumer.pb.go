@@ -184,7 +184,9 @@ type ShardSpec struct {
 	// to continuously mirror the primary's on-disk DB file structure. Should the
 	// primary experience failure, one of the hot standbys will be assigned to take
 	// over as the new shard primary, which is accomplished by simply opening its
-	// local copy of the RocksDB.
+	// local copy of the Store (eg, a RocksDB or Pebble database). Standbys report
+	// status TAILING once they've caught up to the live log, and only the
+	// remaining suffix of the log must be played upon a promotion to primary.
 	//
 	// Note that under regular operation, Shard hand-off is zero downtime even if
 	// standbys are zero, as the current primary will not cede ownership until the
@@ -194,6 +196,56 @@ type ShardSpec struct {
 	// User-defined Labels of this ShardSpec. The label "id" is reserved and may
 	// not be used with a ShardSpec's labels.
 	protocol.LabelSet `protobuf:"bytes,10,opt,name=labels,embedded=labels" json:"labels" yaml:",omitempty,inline"`
+	// Minimum number of distinct zones across which the primary and hot standbys
+	// of the Shard must be placed. If zero, they're placed across at least two
+	// zones where the consumers of multiple zones have sufficient capacity.
+	// Unlike that default, an explicit minimum is enforced: the Shard is left
+	// with fewer standbys if it cannot otherwise be satisfied.
+	MinZones uint32 `protobuf:"varint,11,opt,name=min_zones,json=minZones,proto3" json:"min_zones,omitempty" yaml:"min_zones,omitempty"`
+	// Maximum number of replicas of the Shard (its primary and hot standbys)
+	// which may be placed within a single zone. If zero, the replicas of a zone
+	// are not explicitly bounded. A value of one ensures no two replicas of the
+	// Shard share a zone.
+	MaxReplicasPerZone uint32 `protobuf:"varint,12,opt,name=max_replicas_per_zone,json=maxReplicasPerZone,proto3" json:"max_replicas_per_zone,omitempty" yaml:"max_replicas_per_zone,omitempty"`
+	// Placement selects the consumers to which the Shard may be assigned, by
+	// matching against consumer labels. If empty, the Shard may be assigned to
+	// any consumer.
+	Placement protocol.LabelSelector `protobuf:"bytes,13,opt,name=placement,proto3" json:"placement" yaml:",omitempty"`
+	// Max number of messages of shard transactions. If non-zero, a transaction
+	// stops reading messages upon reaching this number and begins to commit,
+	// even if |min_txn_duration| hasn't yet elapsed. Low-latency shards may use
+	// a small value to bound the work of each transaction.
+	MaxTxnMessages uint32 `protobuf:"varint,14,opt,name=max_txn_messages,json=maxTxnMessages,proto3" json:"max_txn_messages,omitempty" yaml:"max_txn_messages,omitempty"`
+	// Max number of message bytes of shard transactions. If non-zero, a
+	// transaction stops reading messages upon reaching (approximately) this
+	// number of bytes of source journal content, and begins to commit. Bytes
+	// are measured as the journal offset delta between consecutive messages of
+	// a journal within the transaction.
+	MaxTxnBytes int64 `protobuf:"varint,15,opt,name=max_txn_bytes,json=maxTxnBytes,proto3" json:"max_txn_bytes,omitempty" yaml:"max_txn_bytes,omitempty"`
+	// Key range of messages consumed by the Shard. Where the Application is a
+	// KeyedApplication, a message is consumed only if the 32-bit FNV-1a hash of
+	// its key falls within [key_begin, key_end). A |key_end| of zero denotes the
+	// end of the key space: if both are zero, all messages are consumed. Key
+	// ranges allow for a Shard to be split into Shards of narrower ranges which
+	// each consume the same source journals.
+	KeyBegin uint32 `protobuf:"varint,16,opt,name=key_begin,json=keyBegin,proto3" json:"key_begin,omitempty" yaml:"key_begin,omitempty"`
+	KeyEnd   uint32 `protobuf:"varint,17,opt,name=key_end,json=keyEnd,proto3" json:"key_end,omitempty" yaml:"key_end,omitempty"`
+	// Max number of decoded messages which are read ahead of their consumption
+	// by the Shard. Reads of source journals block while this many messages
+	// await a transaction. If zero, a default of 8192 messages is used.
+	ReadAheadMessages uint32 `protobuf:"varint,18,opt,name=read_ahead_messages,json=readAheadMessages,proto3" json:"read_ahead_messages,omitempty" yaml:"read_ahead_messages,omitempty"`
+	// Max number of message bytes which are read ahead of their consumption by
+	// the Shard, measured (as with |max_txn_bytes|) in source journal content.
+	// Reads of source journals block while this many bytes await a transaction,
+	// though a single message larger than the bound is always read. If zero,
+	// read-ahead bytes are not bounded.
+	ReadAheadBytes int64 `protobuf:"varint,19,opt,name=read_ahead_bytes,json=readAheadBytes,proto3" json:"read_ahead_bytes,omitempty" yaml:"read_ahead_bytes,omitempty"`
+	// Pause processing of the Shard. Unlike |disable|, a paused Shard remains
+	// assigned and its primary retains its recovered store, but it doesn't
+	// begin further transactions and its source journal offsets are unchanged
+	// until the Shard is resumed. Operators may pause a misbehaving Shard for
+	// investigation, without losing its processing state.
+	Pause bool `protobuf:"varint,20,opt,name=pause,proto3" json:"pause,omitempty" yaml:",omitempty"`
 }
 
 func (m *ShardSpec) Reset()         { *m = ShardSpec{} }
@@ -247,6 +299,20 @@ type ShardSpec_Source struct {
 	// for shard initialization, directing it to skip over undesired historical
 	// sections of the journal.
 	MinOffset int64 `protobuf:"varint,3,opt,name=min_offset,json=minOffset,proto3" json:"min_offset,omitempty" yaml:"min_offset,omitempty"`
+	// Filter of messages of the journal which are consumed by the Shard.
+	// Label names of the selector are field paths of decoded messages (eg,
+	// "type" or "user.region"), and are matched against string representations
+	// of field values. Messages which don't match are skipped by the consumer
+	// framework, and are never passed to the Application. If empty, all
+	// messages are consumed.
+	Filter protocol.LabelSelector `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter" yaml:",omitempty"`
+	// Broadcast sources are consumed in their entirety by every Shard which
+	// lists them, and are not subject to the key range of the Shard (see
+	// |key_begin| and |key_end|). They're intended for slowly-changing
+	// reference data (eg, dimension tables) which is joined against other,
+	// partitioned sources of the Shard. Read offsets of broadcast sources are
+	// persisted and recovered like those of any other source.
+	Broadcast bool `protobuf:"varint,5,opt,name=broadcast,proto3" json:"broadcast,omitempty" yaml:",omitempty"`
 }
 
 func (m *ShardSpec_Source) Reset()         { *m = ShardSpec_Source{} }
@@ -289,6 +355,15 @@ type ConsumerSpec struct {
 	protocol.ProcessSpec `protobuf:"bytes,1,opt,name=process_spec,json=processSpec,embedded=process_spec" json:"process_spec" yaml:",inline"`
 	// Maximum number of assigned Shards.
 	ShardLimit uint32 `protobuf:"varint,2,opt,name=shard_limit,json=shardLimit,proto3" json:"shard_limit,omitempty"`
+	// Relative capacity weight of the consumer. Shards are balanced
+	// across consumers in proportion to the products of their limits and
+	// weights. A consumer exceeds its weighted share only if other consumers
+	// lack capacity. If zero, a weight of one is used.
+	CapacityWeight uint32 `protobuf:"varint,3,opt,name=capacity_weight,json=capacityWeight,proto3" json:"capacity_weight,omitempty"`
+	// Draining consumers are assigned no new Shards, and hand off current
+	// primary assignments before their standbys are removed. Consumers
+	// mark themselves as draining upon a graceful shutdown.
+	Draining bool `protobuf:"varint,4,opt,name=draining,proto3" json:"draining,omitempty"`
 }
 
 func (m *ConsumerSpec) Reset()         { *m = ConsumerSpec{} }
@@ -376,6 +451,12 @@ type ListRequest struct {
 	// additionally supported by the selector, where "id=example-shard-ID"
 	// will match a ShardSpec with ID "example-shard-ID".
 	Selector protocol.LabelSelector `protobuf:"bytes,1,opt,name=selector" json:"selector"`
+	// Include SourceLags of each listed shard having a primary. Lags are
+	// obtained by a Stat of each shard, and shards which cannot be Stat'd
+	// (for example, because they have no primary) have no SourceLags.
+	// Shards are Stat'd concurrently, and the RPC is read-only: it's suited for
+	// use by external monitors of shard read offsets and lag.
+	IncludeSourceLags bool `protobuf:"varint,2,opt,name=include_source_lags,json=includeSourceLags,proto3" json:"include_source_lags,omitempty"`
 }
 
 func (m *ListRequest) Reset()         { *m = ListRequest{} }
@@ -461,6 +542,8 @@ type ListResponse_Shard struct {
 	Route protocol.Route `protobuf:"bytes,3,opt,name=route" json:"route"`
 	// Status of each replica. Cardinality and ordering matches |route|.
 	Status []ReplicaStatus `protobuf:"bytes,4,rep,name=status" json:"status"`
+	// Lags of each source journal of the shard, if ListRequest.IncludeSourceLags.
+	SourceLags []SourceLag `protobuf:"bytes,5,rep,name=source_lags,json=sourceLags" json:"source_lags"`
 }
 
 func (m *ListResponse_Shard) Reset()         { *m = ListResponse_Shard{} }
@@ -666,6 +749,8 @@ type StatResponse struct {
 	Header protocol.Header `protobuf:"bytes,2,opt,name=header" json:"header"`
 	// Offsets of journals being read by the shard.
 	Offsets map[github_com_LiveRamp_gazette_v2_pkg_protocol.Journal]int64 `protobuf:"bytes,3,rep,name=offsets,castkey=github.com/LiveRamp/gazette/v2/pkg/protocol.Journal" json:"offsets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Lags of each source journal of the shard.
+	SourceLags []SourceLag `protobuf:"bytes,4,rep,name=source_lags,json=sourceLags" json:"source_lags"`
 }
 
 func (m *StatResponse) Reset()         { *m = StatResponse{} }
@@ -823,6 +908,52 @@ func (m *GetHintsResponse_ResponseHints) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetHintsResponse_ResponseHints proto.InternalMessageInfo
 
+// SourceLag is the consumption lag of a shard source journal.
+type SourceLag struct {
+	// Journal of the source.
+	Journal github_com_LiveRamp_gazette_v2_pkg_protocol.Journal `protobuf:"bytes,1,opt,name=journal,proto3,casttype=github.com/LiveRamp/gazette/v2/pkg/protocol.Journal" json:"journal,omitempty"`
+	// Offset of the journal through which the shard has read.
+	ReadOffset int64 `protobuf:"varint,2,opt,name=read_offset,json=readOffset,proto3" json:"read_offset,omitempty"`
+	// Current write head of the journal.
+	WriteHead int64 `protobuf:"varint,3,opt,name=write_head,json=writeHead,proto3" json:"write_head,omitempty"`
+	// Lag of the shard, in bytes: the difference of |write_head| and
+	// |read_offset|, or zero if |read_offset| is at or beyond |write_head|.
+	Lag int64 `protobuf:"varint,4,opt,name=lag,proto3" json:"lag,omitempty"`
+}
+
+func (m *SourceLag) Reset()         { *m = SourceLag{} }
+func (m *SourceLag) String() string { return proto.CompactTextString(m) }
+func (*SourceLag) ProtoMessage()    {}
+func (*SourceLag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_consumer_9e9608ed376e3e47, []int{11}
+}
+func (m *SourceLag) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SourceLag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SourceLag.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SourceLag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SourceLag.Merge(dst, src)
+}
+func (m *SourceLag) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *SourceLag) XXX_DiscardUnknown() {
+	xxx_messageInfo_SourceLag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SourceLag proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*ShardSpec)(nil), "consumer.ShardSpec")
 	proto.RegisterType((*ShardSpec_Source)(nil), "consumer.ShardSpec.Source")
@@ -840,6 +971,7 @@ func init() {
 	proto.RegisterType((*GetHintsRequest)(nil), "consumer.GetHintsRequest")
 	proto.RegisterType((*GetHintsResponse)(nil), "consumer.GetHintsResponse")
 	proto.RegisterType((*GetHintsResponse_ResponseHints)(nil), "consumer.GetHintsResponse.ResponseHints")
+	proto.RegisterType((*SourceLag)(nil), "consumer.SourceLag")
 	proto.RegisterEnum("consumer.Status", Status_name, Status_value)
 	proto.RegisterEnum("consumer.ReplicaStatus_Code", ReplicaStatus_Code_name, ReplicaStatus_Code_value)
 }
@@ -1112,6 +1244,74 @@ func (m *ShardSpec) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n3
+	if m.MinZones != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.MinZones))
+	}
+	if m.MaxReplicasPerZone != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.MaxReplicasPerZone))
+	}
+	dAtA[i] = 0x6a
+	i++
+	i = encodeVarintConsumer(dAtA, i, uint64(m.Placement.ProtoSize()))
+	n16, err := m.Placement.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n16
+	if m.MaxTxnMessages != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.MaxTxnMessages))
+	}
+	if m.MaxTxnBytes != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.MaxTxnBytes))
+	}
+	if m.KeyBegin != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.KeyBegin))
+	}
+	if m.KeyEnd != 0 {
+		dAtA[i] = 0x88
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.KeyEnd))
+	}
+	if m.ReadAheadMessages != 0 {
+		dAtA[i] = 0x90
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.ReadAheadMessages))
+	}
+	if m.ReadAheadBytes != 0 {
+		dAtA[i] = 0x98
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.ReadAheadBytes))
+	}
+	if m.Pause {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.Pause {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -1141,6 +1341,24 @@ func (m *ShardSpec_Source) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintConsumer(dAtA, i, uint64(m.MinOffset))
 	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintConsumer(dAtA, i, uint64(m.Filter.ProtoSize()))
+	n17, err := m.Filter.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n17
+	if m.Broadcast {
+		dAtA[i] = 0x28
+		i++
+		if m.Broadcast {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -1172,6 +1390,21 @@ func (m *ConsumerSpec) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintConsumer(dAtA, i, uint64(m.ShardLimit))
 	}
+	if m.CapacityWeight != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.CapacityWeight))
+	}
+	if m.Draining {
+		dAtA[i] = 0x20
+		i++
+		if m.Draining {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -1236,6 +1469,16 @@ func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n5
+	if m.IncludeSourceLags {
+		dAtA[i] = 0x10
+		i++
+		if m.IncludeSourceLags {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -1330,6 +1573,18 @@ func (m *ListResponse_Shard) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.SourceLags) > 0 {
+		for _, msg := range m.SourceLags {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintConsumer(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
@@ -1511,6 +1766,18 @@ func (m *StatResponse) MarshalTo(dAtA []byte) (int, error) {
 			i = encodeVarintConsumer(dAtA, i, uint64(v))
 		}
 	}
+	if len(m.SourceLags) > 0 {
+		for _, msg := range m.SourceLags {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintConsumer(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
@@ -1617,6 +1884,45 @@ func (m *GetHintsResponse_ResponseHints) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *SourceLag) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SourceLag) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Journal) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(len(m.Journal)))
+		i += copy(dAtA[i:], m.Journal)
+	}
+	if m.ReadOffset != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.ReadOffset))
+	}
+	if m.WriteHead != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.WriteHead))
+	}
+	if m.Lag != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintConsumer(dAtA, i, uint64(m.Lag))
+	}
+	return i, nil
+}
+
 func encodeVarintConsumer(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -1665,6 +1971,35 @@ func (m *ShardSpec) ProtoSize() (n int) {
 	}
 	l = m.LabelSet.ProtoSize()
 	n += 1 + l + sovConsumer(uint64(l))
+	if m.MinZones != 0 {
+		n += 1 + sovConsumer(uint64(m.MinZones))
+	}
+	if m.MaxReplicasPerZone != 0 {
+		n += 1 + sovConsumer(uint64(m.MaxReplicasPerZone))
+	}
+	l = m.Placement.ProtoSize()
+	n += 1 + l + sovConsumer(uint64(l))
+	if m.MaxTxnMessages != 0 {
+		n += 1 + sovConsumer(uint64(m.MaxTxnMessages))
+	}
+	if m.MaxTxnBytes != 0 {
+		n += 1 + sovConsumer(uint64(m.MaxTxnBytes))
+	}
+	if m.KeyBegin != 0 {
+		n += 2 + sovConsumer(uint64(m.KeyBegin))
+	}
+	if m.KeyEnd != 0 {
+		n += 2 + sovConsumer(uint64(m.KeyEnd))
+	}
+	if m.ReadAheadMessages != 0 {
+		n += 2 + sovConsumer(uint64(m.ReadAheadMessages))
+	}
+	if m.ReadAheadBytes != 0 {
+		n += 2 + sovConsumer(uint64(m.ReadAheadBytes))
+	}
+	if m.Pause {
+		n += 3
+	}
 	return n
 }
 
@@ -1681,6 +2016,11 @@ func (m *ShardSpec_Source) ProtoSize() (n int) {
 	if m.MinOffset != 0 {
 		n += 1 + sovConsumer(uint64(m.MinOffset))
 	}
+	l = m.Filter.ProtoSize()
+	n += 1 + l + sovConsumer(uint64(l))
+	if m.Broadcast {
+		n += 2
+	}
 	return n
 }
 
@@ -1695,6 +2035,12 @@ func (m *ConsumerSpec) ProtoSize() (n int) {
 	if m.ShardLimit != 0 {
 		n += 1 + sovConsumer(uint64(m.ShardLimit))
 	}
+	if m.CapacityWeight != 0 {
+		n += 1 + sovConsumer(uint64(m.CapacityWeight))
+	}
+	if m.Draining {
+		n += 2
+	}
 	return n
 }
 
@@ -1724,6 +2070,9 @@ func (m *ListRequest) ProtoSize() (n int) {
 	_ = l
 	l = m.Selector.ProtoSize()
 	n += 1 + l + sovConsumer(uint64(l))
+	if m.IncludeSourceLags {
+		n += 2
+	}
 	return n
 }
 
@@ -1766,6 +2115,12 @@ func (m *ListResponse_Shard) ProtoSize() (n int) {
 			n += 1 + l + sovConsumer(uint64(l))
 		}
 	}
+	if len(m.SourceLags) > 0 {
+		for _, e := range m.SourceLags {
+			l = e.ProtoSize()
+			n += 1 + l + sovConsumer(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1854,6 +2209,12 @@ func (m *StatResponse) ProtoSize() (n int) {
 			n += mapEntrySize + 1 + sovConsumer(uint64(mapEntrySize))
 		}
 	}
+	if len(m.SourceLags) > 0 {
+		for _, e := range m.SourceLags {
+			l = e.ProtoSize()
+			n += 1 + l + sovConsumer(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1905,6 +2266,28 @@ func (m *GetHintsResponse_ResponseHints) ProtoSize() (n int) {
 	return n
 }
 
+func (m *SourceLag) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Journal)
+	if l > 0 {
+		n += 1 + l + sovConsumer(uint64(l))
+	}
+	if m.ReadOffset != 0 {
+		n += 1 + sovConsumer(uint64(m.ReadOffset))
+	}
+	if m.WriteHead != 0 {
+		n += 1 + sovConsumer(uint64(m.WriteHead))
+	}
+	if m.Lag != 0 {
+		n += 1 + sovConsumer(uint64(m.Lag))
+	}
+	return n
+}
+
 func sovConsumer(x uint64) (n int) {
 	for {
 		n++
@@ -2213,6 +2596,208 @@ func (m *ShardSpec) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinZones", wireType)
+			}
+			m.MinZones = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinZones |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxReplicasPerZone", wireType)
+			}
+			m.MaxReplicasPerZone = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxReplicasPerZone |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsumer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTxnMessages", wireType)
+			}
+			m.MaxTxnMessages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxTxnMessages |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTxnBytes", wireType)
+			}
+			m.MaxTxnBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxTxnBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyBegin", wireType)
+			}
+			m.KeyBegin = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeyBegin |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyEnd", wireType)
+			}
+			m.KeyEnd = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeyEnd |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadAheadMessages", wireType)
+			}
+			m.ReadAheadMessages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadAheadMessages |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadAheadBytes", wireType)
+			}
+			m.ReadAheadBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadAheadBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pause", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Pause = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsumer(dAtA[iNdEx:])
@@ -2265,9 +2850,57 @@ func (m *ShardSpec_Source) Unmarshal(dAtA []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Journal", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Journal", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsumer
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Journal = github_com_LiveRamp_gazette_v2_pkg_protocol.Journal(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinOffset", wireType)
+			}
+			m.MinOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filter", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsumer
@@ -2277,26 +2910,27 @@ func (m *ShardSpec_Source) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConsumer
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Journal = github_com_LiveRamp_gazette_v2_pkg_protocol.Journal(dAtA[iNdEx:postIndex])
+			if err := m.Filter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MinOffset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Broadcast", wireType)
 			}
-			m.MinOffset = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsumer
@@ -2306,11 +2940,12 @@ func (m *ShardSpec_Source) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MinOffset |= (int64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Broadcast = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsumer(dAtA[iNdEx:])
@@ -2410,6 +3045,45 @@ func (m *ConsumerSpec) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CapacityWeight", wireType)
+			}
+			m.CapacityWeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CapacityWeight |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Draining", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Draining = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsumer(dAtA[iNdEx:])
@@ -2588,6 +3262,26 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeSourceLags", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeSourceLags = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsumer(dAtA[iNdEx:])
@@ -2878,6 +3572,37 @@ func (m *ListResponse_Shard) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceLags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsumer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceLags = append(m.SourceLags, SourceLag{})
+			if err := m.SourceLags[len(m.SourceLags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsumer(dAtA[iNdEx:])
@@ -3507,6 +4232,37 @@ func (m *StatResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.Offsets[github_com_LiveRamp_gazette_v2_pkg_protocol.Journal(mapkey)] = mapvalue
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceLags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsumer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceLags = append(m.SourceLags, SourceLag{})
+			if err := m.SourceLags[len(m.SourceLags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsumer(dAtA[iNdEx:])
@@ -3850,6 +4606,142 @@ func (m *GetHintsResponse_ResponseHints) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SourceLag) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsumer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SourceLag: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SourceLag: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Journal", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsumer
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Journal = github_com_LiveRamp_gazette_v2_pkg_protocol.Journal(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOffset", wireType)
+			}
+			m.ReadOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteHead", wireType)
+			}
+			m.WriteHead = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteHead |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lag", wireType)
+			}
+			m.Lag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsumer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Lag |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsumer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConsumer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipConsumer(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3958,93 +4850,101 @@ var (
 func init() { proto.RegisterFile("consumer.proto", fileDescriptor_consumer_9e9608ed376e3e47) }
 
 var fileDescriptor_consumer_9e9608ed376e3e47 = []byte{
-	// 1403 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xb1, 0x6f, 0xdb, 0xc6,
-	0x17, 0x36, 0x25, 0x99, 0xb2, 0x1f, 0x65, 0x47, 0x3e, 0xc7, 0xb1, 0xa2, 0x24, 0x92, 0xcd, 0x64,
-	0x10, 0x7e, 0xbf, 0x84, 0x0e, 0x94, 0x06, 0x49, 0x8d, 0xb6, 0xa8, 0x68, 0xd9, 0xb1, 0x1a, 0xc5,
-	0x76, 0x28, 0x17, 0x68, 0xbb, 0x10, 0x14, 0x79, 0x96, 0xd9, 0x50, 0x3c, 0x96, 0xa4, 0x0c, 0xab,
-	0x63, 0x81, 0x2e, 0x9d, 0x32, 0x16, 0x68, 0x87, 0xa2, 0x73, 0xff, 0x85, 0x02, 0x1d, 0x3d, 0x06,
-	0x9d, 0x8a, 0x0e, 0x0a, 0x1a, 0xf7, 0x2f, 0xf0, 0x98, 0xa9, 0xe0, 0xdd, 0x51, 0xa2, 0x1c, 0x19,
-	0x68, 0x0a, 0x64, 0xe3, 0xbd, 0xf7, 0xbd, 0xef, 0xdd, 0xfb, 0xee, 0xdd, 0x3b, 0xc2, 0xbc, 0x49,
-	0xdc, 0xa0, 0xd7, 0xc5, 0xbe, 0xe2, 0xf9, 0x24, 0x24, 0x68, 0x26, 0x5e, 0x17, 0xd7, 0x3b, 0x76,
-	0x78, 0xd8, 0x6b, 0x2b, 0x26, 0xe9, 0xae, 0x35, 0xed, 0x23, 0xac, 0x19, 0x5d, 0x6f, 0xad, 0x63,
-	0x7c, 0x8d, 0xc3, 0x10, 0xaf, 0x1d, 0x55, 0xd7, 0xbc, 0x67, 0x9d, 0x35, 0x1a, 0x63, 0x12, 0x67,
-	0xf8, 0xc1, 0x58, 0x8a, 0x1f, 0xff, 0x8b, 0x58, 0x1f, 0x9b, 0xe4, 0x08, 0xfb, 0x7d, 0x87, 0xb0,
-	0x6f, 0xdf, 0xc2, 0x96, 0x4e, 0x3c, 0xce, 0x70, 0x27, 0xc1, 0xd0, 0x21, 0x1d, 0xc2, 0x32, 0xb4,
-	0x7b, 0x07, 0x74, 0x45, 0x17, 0xf4, 0x8b, 0xc3, 0x4b, 0x1d, 0x42, 0x3a, 0x0e, 0x1e, 0xa1, 0xac,
-	0x9e, 0x6f, 0x84, 0x36, 0x71, 0x99, 0x5f, 0xfe, 0x2d, 0x0b, 0xb3, 0xad, 0x43, 0xc3, 0xb7, 0x5a,
-	0x1e, 0x36, 0xd1, 0x5d, 0x48, 0xd9, 0x56, 0x41, 0x58, 0x11, 0x2a, 0xb3, 0xea, 0xca, 0xd9, 0xa0,
-	0xbc, 0xd0, 0x37, 0xba, 0xce, 0xba, 0x7c, 0x9b, 0x74, 0xed, 0x10, 0x77, 0xbd, 0xb0, 0x2f, 0xbf,
-	0x1e, 0x94, 0xb3, 0x14, 0xdf, 0xa8, 0x6b, 0x29, 0xdb, 0x42, 0xbb, 0x90, 0x0d, 0x48, 0xcf, 0x37,
-	0x71, 0x50, 0x48, 0xad, 0xa4, 0x2b, 0x52, 0xb5, 0xa8, 0x0c, 0x85, 0x1b, 0xf2, 0x2a, 0x2d, 0x0a,
-	0x51, 0xaf, 0x9e, 0x0c, 0xca, 0x53, 0x13, 0x69, 0xb5, 0x98, 0x05, 0x7d, 0x06, 0x8b, 0xb1, 0x00,
-	0xba, 0x43, 0x3a, 0xba, 0xe7, 0xe3, 0x03, 0xfb, 0xb8, 0x90, 0xa6, 0x7b, 0xaa, 0x9c, 0x0d, 0xca,
-	0xb7, 0x58, 0xf0, 0x04, 0x50, 0x92, 0x6f, 0x21, 0xf6, 0x37, 0x49, 0x67, 0x8f, 0x7a, 0x51, 0x0d,
-	0xa4, 0x43, 0xdb, 0x0d, 0x63, 0xc6, 0xcc, 0xb0, 0xca, 0xeb, 0x8c, 0x31, 0xe1, 0x4c, 0x32, 0x41,
-	0x64, 0xe7, 0x14, 0x75, 0xc8, 0x51, 0x54, 0xdb, 0x30, 0x9f, 0xf5, 0xbc, 0xa0, 0x30, 0xbd, 0x22,
-	0x54, 0xa6, 0xd5, 0xd5, 0xb3, 0x41, 0xf9, 0x46, 0x82, 0x83, 0x7b, 0x93, 0x24, 0x34, 0xb3, 0xca,
-	0xec, 0xc8, 0x87, 0x7c, 0xd7, 0x38, 0xd6, 0xc3, 0x63, 0x57, 0x8f, 0x4f, 0xa3, 0x20, 0xae, 0x08,
-	0x15, 0xa9, 0x7a, 0x55, 0x61, 0xc7, 0xa5, 0xc4, 0xc7, 0xa5, 0xd4, 0x39, 0x40, 0xbd, 0xc3, 0xb5,
-	0x5b, 0x65, 0x89, 0xce, 0x13, 0x24, 0x92, 0x7d, 0xff, 0xb2, 0x2c, 0x68, 0xf3, 0x5d, 0xe3, 0x78,
-	0xff, 0xd8, 0x8d, 0xc3, 0x69, 0x4e, 0xdb, 0x1d, 0xcf, 0x99, 0x7d, 0xdb, 0x9c, 0xe7, 0x08, 0xde,
-	0xcc, 0x69, 0xbb, 0xc9, 0x9c, 0x6b, 0x90, 0xb5, 0xec, 0xc0, 0x68, 0x3b, 0xb8, 0x30, 0xb3, 0x22,
-	0x54, 0x66, 0xd4, 0xa5, 0x0b, 0xce, 0x9e, 0xa3, 0xa8, 0xbc, 0x24, 0xd4, 0x83, 0xd0, 0x70, 0xad,
-	0x76, 0x3f, 0x28, 0xcc, 0xae, 0x08, 0x95, 0xb9, 0x31, 0x79, 0x13, 0xde, 0x71, 0x79, 0x49, 0xd8,
-	0xe2, 0x76, 0xb4, 0x07, 0xa2, 0x63, 0xb4, 0xb1, 0x13, 0x14, 0x80, 0x16, 0x88, 0x94, 0xe1, 0x25,
-	0x6c, 0x46, 0xf6, 0x16, 0x0e, 0xd5, 0x5b, 0x51, 0x65, 0x2f, 0x06, 0x65, 0xe1, 0x6c, 0x50, 0x2e,
-	0x9c, 0xdf, 0xd1, 0x6d, 0xdb, 0x75, 0x6c, 0x17, 0xcb, 0x1a, 0xe7, 0x29, 0xfe, 0x20, 0x80, 0xc8,
-	0x5a, 0x18, 0x3d, 0x85, 0xec, 0x97, 0xa4, 0xe7, 0xbb, 0x86, 0xc3, 0xaf, 0xc9, 0x83, 0xd7, 0x83,
-	0xf2, 0xbd, 0xb7, 0x98, 0x08, 0xca, 0x27, 0x2c, 0x5c, 0x8b, 0x79, 0xd0, 0x47, 0x00, 0x91, 0xb2,
-	0xe4, 0xe0, 0x20, 0xc0, 0x21, 0x6d, 0xf4, 0xb4, 0x5a, 0x3e, 0x1b, 0x94, 0xaf, 0x8d, 0x54, 0x67,
-	0xbe, 0x64, 0xc5, 0xb3, 0x5d, 0xdb, 0xdd, 0xa5, 0x56, 0xf9, 0x5b, 0x01, 0x72, 0x1b, 0xfc, 0xce,
-	0xd1, 0x5b, 0xbc, 0x0f, 0x39, 0xcf, 0x27, 0x26, 0x0e, 0x02, 0x3d, 0xf0, 0xb0, 0x49, 0x37, 0x2a,
-	0x55, 0x97, 0x46, 0x32, 0xec, 0x31, 0x6f, 0x04, 0x56, 0x8b, 0x09, 0x25, 0xe6, 0xb9, 0x12, 0x71,
-	0xfd, 0x92, 0x37, 0x02, 0xa2, 0x32, 0x48, 0x41, 0x74, 0xa1, 0x75, 0xc7, 0xee, 0xda, 0x61, 0x21,
-	0x15, 0x9d, 0x8d, 0x06, 0xd4, 0xd4, 0x8c, 0x2c, 0xf2, 0xcf, 0x02, 0xcc, 0x69, 0xd8, 0x73, 0x6c,
-	0xd3, 0x68, 0x85, 0x46, 0xd8, 0x0b, 0xd0, 0x5d, 0xc8, 0x98, 0xc4, 0xc2, 0x74, 0x03, 0xf3, 0xd5,
-	0xeb, 0xa3, 0xc9, 0x30, 0x06, 0x53, 0x36, 0x88, 0x85, 0x35, 0x8a, 0x44, 0x57, 0x40, 0xc4, 0xbe,
-	0x4f, 0x7c, 0x36, 0x4d, 0x66, 0x35, 0xbe, 0x92, 0x1f, 0x41, 0x26, 0x42, 0xa1, 0x19, 0xc8, 0x34,
-	0xea, 0xcd, 0xcd, 0xfc, 0x14, 0xca, 0xc1, 0x8c, 0x5a, 0xdb, 0x78, 0xbc, 0xd5, 0x68, 0x36, 0xf3,
-	0x16, 0xca, 0x41, 0x76, 0xbf, 0xd6, 0x68, 0x36, 0x76, 0x1e, 0xe5, 0x4f, 0x84, 0x68, 0xb5, 0xa7,
-	0x35, 0x9e, 0xd4, 0xb4, 0xcf, 0xf3, 0xbf, 0xa4, 0x90, 0x04, 0xe2, 0x56, 0xad, 0xd1, 0xdc, 0xac,
-	0xe7, 0x9f, 0xa7, 0xe5, 0x6d, 0x90, 0x9a, 0x76, 0x10, 0x6a, 0xf8, 0xab, 0x1e, 0x0e, 0x42, 0xf4,
-	0x3e, 0xcc, 0x04, 0xd8, 0xc1, 0x66, 0x48, 0x7c, 0x2e, 0xd3, 0xf2, 0x1b, 0xdd, 0xc2, 0xdc, 0x6a,
-	0x26, 0x12, 0x4a, 0x1b, 0xc2, 0xe5, 0xbf, 0x53, 0x90, 0x63, 0x54, 0x81, 0x47, 0xdc, 0x00, 0xa3,
-	0x0a, 0x88, 0x01, 0x2d, 0x88, 0xd7, 0x9b, 0x4f, 0x4c, 0x42, 0x6a, 0xd7, 0xb8, 0x1f, 0x29, 0x20,
-	0x1e, 0x62, 0xc3, 0xc2, 0x3e, 0x55, 0x51, 0xaa, 0xe6, 0x47, 0x39, 0xb7, 0xa9, 0x9d, 0x27, 0xe3,
-	0x28, 0xb4, 0x0e, 0x22, 0xd5, 0x39, 0x28, 0xa4, 0xe9, 0x8c, 0x4d, 0x28, 0x99, 0xdc, 0x01, 0x1b,
-	0xb8, 0x71, 0x2c, 0x8b, 0x28, 0xfe, 0x2a, 0xc0, 0x34, 0xb5, 0xa3, 0x3b, 0x90, 0x49, 0xb4, 0xc3,
-	0xe2, 0x84, 0x39, 0xcd, 0x43, 0x29, 0x0c, 0xad, 0x42, 0xae, 0x4b, 0x2c, 0xdd, 0xc7, 0x47, 0x76,
-	0x10, 0x4d, 0x8b, 0x68, 0xab, 0x69, 0x4d, 0xea, 0x12, 0x4b, 0xe3, 0x26, 0xf4, 0x7f, 0x98, 0xf6,
-	0x49, 0x2f, 0xc4, 0xb4, 0x69, 0xa5, 0xea, 0xa5, 0x51, 0x19, 0x5a, 0x64, 0xe6, 0x74, 0x0c, 0x83,
-	0xee, 0x0f, 0xe5, 0xc9, 0xd0, 0x22, 0x96, 0x2f, 0x68, 0x87, 0xe1, 0xfe, 0xe9, 0x4a, 0xfe, 0x53,
-	0x80, 0x5c, 0xcd, 0xf3, 0x9c, 0x7e, 0x7c, 0x64, 0x1f, 0x42, 0xd6, 0x3c, 0x34, 0xdc, 0x0e, 0x8e,
-	0x74, 0x8e, 0x88, 0x6e, 0x8c, 0x88, 0x92, 0x40, 0x65, 0x83, 0xa2, 0x38, 0x5d, 0x1c, 0x53, 0xfc,
-	0x4e, 0x00, 0x91, 0x79, 0x90, 0x02, 0x8b, 0xf8, 0xd8, 0xc3, 0x66, 0xa8, 0x8f, 0x15, 0x2a, 0xd0,
-	0x42, 0x17, 0x98, 0xeb, 0xc9, 0x58, 0xb9, 0x62, 0xcf, 0x0b, 0xb0, 0x1f, 0xf2, 0x63, 0x9b, 0x24,
-	0xa1, 0xc6, 0x21, 0xe8, 0x26, 0x88, 0x16, 0x76, 0x30, 0x17, 0x67, 0x56, 0x95, 0x92, 0x2f, 0x27,
-	0x77, 0xc9, 0x36, 0xcc, 0xf1, 0x2d, 0xbf, 0xeb, 0x1e, 0x92, 0xbf, 0x00, 0x29, 0x62, 0x88, 0x55,
-	0xac, 0x0c, 0xc3, 0x85, 0xc9, 0xe1, 0xc3, 0xe6, 0x5b, 0x85, 0x69, 0xda, 0x4a, 0x34, 0xcf, 0xb9,
-	0x3a, 0x98, 0x47, 0xfe, 0x31, 0x05, 0x39, 0x46, 0xfe, 0xce, 0xaf, 0xc2, 0x11, 0x64, 0xd9, 0x30,
-	0x8c, 0xef, 0xc2, 0xcd, 0x71, 0xea, 0xe1, 0x5d, 0x60, 0xc3, 0x31, 0xd8, 0x74, 0x43, 0xbf, 0xaf,
-	0x3e, 0xf8, 0xe6, 0xe5, 0x7f, 0x1c, 0xd2, 0x3c, 0x59, 0x71, 0x1d, 0x72, 0x49, 0x46, 0x94, 0x87,
-	0xf4, 0x33, 0xdc, 0x67, 0x6f, 0x80, 0x16, 0x7d, 0xa2, 0xcb, 0x30, 0x7d, 0x64, 0x38, 0x3d, 0xcc,
-	0x2f, 0x0a, 0x5b, 0xac, 0xa7, 0x1e, 0x0a, 0xf2, 0x7b, 0x70, 0xe9, 0x11, 0x0e, 0xb7, 0x6d, 0x37,
-	0x0c, 0x62, 0xf9, 0x87, 0xa2, 0x0a, 0x17, 0x8a, 0xfa, 0x7b, 0x0a, 0xf2, 0xa3, 0xb0, 0x77, 0x2e,
-	0x6c, 0x0b, 0xe6, 0x3c, 0xdf, 0xee, 0x1a, 0x7e, 0x5f, 0x8f, 0xfe, 0x55, 0x02, 0x7e, 0xa7, 0x2b,
-	0xa3, 0x04, 0xe7, 0x37, 0xa3, 0xc4, 0x1f, 0xd4, 0xca, 0xe9, 0x72, 0x9c, 0x84, 0xda, 0xd0, 0x53,
-	0xc8, 0xb1, 0x9f, 0x21, 0xce, 0xc9, 0x6e, 0xfe, 0xdb, 0x72, 0x4a, 0x8c, 0x83, 0x9a, 0x8a, 0x1f,
-	0x44, 0x8f, 0x4c, 0x02, 0x13, 0x0d, 0x21, 0x46, 0x1e, 0x3f, 0x73, 0x89, 0xff, 0x67, 0x65, 0xab,
-	0xf5, 0x84, 0xf1, 0x33, 0xcc, 0xff, 0x08, 0x88, 0xfc, 0x6d, 0x12, 0x21, 0xb5, 0xfb, 0x38, 0x3f,
-	0x85, 0x16, 0xe1, 0x52, 0x6b, 0xbb, 0xa6, 0xd5, 0xf5, 0x9d, 0xdd, 0x7d, 0x7d, 0x6b, 0xf7, 0xd3,
-	0x9d, 0x7a, 0x5e, 0x40, 0x97, 0x21, 0xbf, 0xb3, 0xab, 0x33, 0x7b, 0xfc, 0x92, 0xa4, 0xd0, 0x12,
-	0x2c, 0x44, 0xa0, 0x71, 0x73, 0x1a, 0x5d, 0x83, 0xe5, 0xcd, 0xfd, 0x8d, 0xba, 0xbe, 0xaf, 0xd5,
-	0x76, 0x5a, 0xb5, 0x8d, 0xfd, 0xc6, 0xee, 0x8e, 0xce, 0x1f, 0x9c, 0x4c, 0xf5, 0x6c, 0x38, 0x7e,
-	0xef, 0x43, 0x26, 0x4a, 0x8d, 0x96, 0xce, 0x37, 0x2c, 0xed, 0x88, 0xe2, 0x95, 0xc9, 0x7d, 0x1c,
-	0x85, 0x45, 0x33, 0x3e, 0x19, 0x96, 0x78, 0xc0, 0x92, 0x61, 0x63, 0x8f, 0xd1, 0x43, 0x98, 0xa6,
-	0x93, 0x05, 0x5d, 0x99, 0x3c, 0x1d, 0x8b, 0xcb, 0x6f, 0xd8, 0x79, 0x64, 0x0d, 0x66, 0xe2, 0x53,
-	0x41, 0x57, 0x27, 0x9d, 0x14, 0x8b, 0x2f, 0x5e, 0x7c, 0x88, 0xea, 0xf5, 0x93, 0xbf, 0x4a, 0x53,
-	0x27, 0xaf, 0x4a, 0xc2, 0x8b, 0x57, 0x25, 0xe1, 0xf9, 0x69, 0x69, 0xea, 0xa7, 0xd3, 0x92, 0xf0,
-	0xe2, 0xb4, 0x34, 0xf5, 0xc7, 0x69, 0x69, 0xaa, 0x2d, 0xd2, 0x46, 0xbc, 0xf7, 0x4f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x54, 0x4a, 0x1a, 0x5c, 0x62, 0x0d, 0x00, 0x00,
+	// 1527 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcd, 0x6f, 0xdb, 0x46,
+	0x16, 0x37, 0x25, 0x59, 0x1f, 0x4f, 0xb2, 0x2d, 0x8f, 0x63, 0x9b, 0x51, 0x12, 0xc9, 0x56, 0x82,
+	0x5d, 0x21, 0x9b, 0xd0, 0x81, 0xb2, 0x41, 0xb2, 0xde, 0x0f, 0xac, 0x64, 0xd9, 0xb1, 0x36, 0x8a,
+	0xed, 0x50, 0x5e, 0xec, 0x36, 0x40, 0x41, 0xd0, 0xe4, 0x58, 0x66, 0x43, 0x72, 0x58, 0x92, 0x72,
+	0xad, 0x1c, 0x7b, 0xec, 0x29, 0xc7, 0x02, 0xed, 0xa1, 0xe8, 0xb9, 0xe8, 0x7f, 0xd0, 0xbb, 0xd1,
+	0x53, 0xd0, 0x53, 0xd1, 0x83, 0x82, 0xc6, 0xfd, 0x0b, 0x74, 0xcc, 0xa9, 0xe0, 0xcc, 0x50, 0xa2,
+	0xfc, 0x91, 0x36, 0x05, 0x72, 0xe3, 0xbc, 0xf7, 0x7b, 0xbf, 0xf7, 0x39, 0x6f, 0x24, 0x98, 0xd6,
+	0x88, 0xed, 0x75, 0x2d, 0xec, 0x4a, 0x8e, 0x4b, 0x7c, 0x82, 0xd2, 0xe1, 0xb9, 0xb0, 0xda, 0x31,
+	0xfc, 0x83, 0xee, 0x9e, 0xa4, 0x11, 0x6b, 0xa5, 0x65, 0x1c, 0x62, 0x59, 0xb5, 0x9c, 0x95, 0x8e,
+	0xfa, 0x1c, 0xfb, 0x3e, 0x5e, 0x39, 0xac, 0xae, 0x38, 0xcf, 0x3a, 0x2b, 0xd4, 0x46, 0x23, 0xe6,
+	0xf0, 0x83, 0xb1, 0x14, 0xfe, 0xfd, 0x3b, 0x6c, 0x5d, 0xac, 0x91, 0x43, 0xec, 0xf6, 0x4c, 0xc2,
+	0xbe, 0x5d, 0x1d, 0xeb, 0x0a, 0x71, 0x38, 0xc3, 0xed, 0x08, 0x43, 0x87, 0x74, 0x08, 0xf3, 0xb0,
+	0xd7, 0xdd, 0xa7, 0x27, 0x7a, 0xa0, 0x5f, 0x1c, 0x5e, 0xec, 0x10, 0xd2, 0x31, 0xf1, 0x08, 0xa5,
+	0x77, 0x5d, 0xd5, 0x37, 0x88, 0xcd, 0xf4, 0xe5, 0x6f, 0x33, 0x90, 0x69, 0x1f, 0xa8, 0xae, 0xde,
+	0x76, 0xb0, 0x86, 0xee, 0x40, 0xcc, 0xd0, 0x45, 0x61, 0x49, 0xa8, 0x64, 0xea, 0x4b, 0x83, 0x7e,
+	0x69, 0xb6, 0xa7, 0x5a, 0xe6, 0x6a, 0xf9, 0x16, 0xb1, 0x0c, 0x1f, 0x5b, 0x8e, 0xdf, 0x2b, 0xbf,
+	0xe9, 0x97, 0x52, 0x14, 0xdf, 0x6c, 0xc8, 0x31, 0x43, 0x47, 0xdb, 0x90, 0xf2, 0x48, 0xd7, 0xd5,
+	0xb0, 0x27, 0xc6, 0x96, 0xe2, 0x95, 0x6c, 0xb5, 0x20, 0x0d, 0x0b, 0x37, 0xe4, 0x95, 0xda, 0x14,
+	0x52, 0xbf, 0x7c, 0xdc, 0x2f, 0x4d, 0x9c, 0x4b, 0x2b, 0x87, 0x2c, 0xe8, 0xff, 0x30, 0x17, 0x16,
+	0x40, 0x31, 0x49, 0x47, 0x71, 0x5c, 0xbc, 0x6f, 0x1c, 0x89, 0x71, 0x1a, 0x53, 0x65, 0xd0, 0x2f,
+	0xdd, 0x60, 0xc6, 0xe7, 0x80, 0xa2, 0x7c, 0xb3, 0xa1, 0xbe, 0x45, 0x3a, 0x3b, 0x54, 0x8b, 0x6a,
+	0x90, 0x3d, 0x30, 0x6c, 0x3f, 0x64, 0x4c, 0x0c, 0xb3, 0xbc, 0xca, 0x18, 0x23, 0xca, 0x28, 0x13,
+	0x04, 0x72, 0x4e, 0xd1, 0x80, 0x1c, 0x45, 0xed, 0xa9, 0xda, 0xb3, 0xae, 0xe3, 0x89, 0x93, 0x4b,
+	0x42, 0x65, 0xb2, 0xbe, 0x3c, 0xe8, 0x97, 0xae, 0x45, 0x38, 0xb8, 0x36, 0x4a, 0x42, 0x3d, 0xd7,
+	0x99, 0x1c, 0xb9, 0x90, 0xb7, 0xd4, 0x23, 0xc5, 0x3f, 0xb2, 0x95, 0xb0, 0x1b, 0x62, 0x72, 0x49,
+	0xa8, 0x64, 0xab, 0x97, 0x25, 0xd6, 0x2e, 0x29, 0x6c, 0x97, 0xd4, 0xe0, 0x80, 0xfa, 0x6d, 0x5e,
+	0xbb, 0x65, 0xe6, 0xe8, 0x34, 0x41, 0xc4, 0xd9, 0xe7, 0xaf, 0x4a, 0x82, 0x3c, 0x6d, 0xa9, 0x47,
+	0xbb, 0x47, 0x76, 0x68, 0x4e, 0x7d, 0x1a, 0xf6, 0xb8, 0xcf, 0xd4, 0xbb, 0xfa, 0x34, 0xec, 0xdf,
+	0xf0, 0x69, 0xd8, 0x51, 0x9f, 0x2b, 0x90, 0xd2, 0x0d, 0x4f, 0xdd, 0x33, 0xb1, 0x98, 0x5e, 0x12,
+	0x2a, 0xe9, 0xfa, 0xfc, 0x05, 0xbd, 0xe7, 0x28, 0x5a, 0x5e, 0xe2, 0x2b, 0x9e, 0xaf, 0xda, 0xfa,
+	0x5e, 0xcf, 0x13, 0x33, 0x4b, 0x42, 0x65, 0x6a, 0xac, 0xbc, 0x11, 0xed, 0x78, 0x79, 0x89, 0xdf,
+	0xe6, 0x72, 0xb4, 0x03, 0x49, 0x53, 0xdd, 0xc3, 0xa6, 0x27, 0x02, 0x4d, 0x10, 0x49, 0xc3, 0x4b,
+	0xd8, 0x0a, 0xe4, 0x6d, 0xec, 0xd7, 0x6f, 0x04, 0x99, 0xbd, 0xec, 0x97, 0x84, 0x41, 0xbf, 0x24,
+	0x9e, 0x8e, 0xe8, 0x96, 0x61, 0x9b, 0x86, 0x8d, 0xcb, 0x32, 0xe7, 0x41, 0x7f, 0x87, 0x4c, 0x90,
+	0xfb, 0x73, 0x62, 0x63, 0x4f, 0xcc, 0xd2, 0xa0, 0x8a, 0x83, 0x7e, 0xa9, 0x30, 0x2a, 0x0b, 0x55,
+	0x45, 0x23, 0x4a, 0x5b, 0x86, 0xfd, 0x34, 0x10, 0xa2, 0x0f, 0x61, 0x3e, 0x68, 0x96, 0x8b, 0x1d,
+	0xd3, 0xd0, 0x54, 0x4f, 0x71, 0xb0, 0x4b, 0xe1, 0x62, 0x8e, 0x12, 0xdd, 0x1c, 0xf4, 0x4b, 0x7f,
+	0x1a, 0xf5, 0xf4, 0x0c, 0x2c, 0x4a, 0x8a, 0x2c, 0xf5, 0x48, 0xe6, 0x80, 0x1d, 0xec, 0x06, 0xfc,
+	0x68, 0x07, 0x32, 0x8e, 0xa9, 0x6a, 0xd8, 0xc2, 0xb6, 0x2f, 0x4e, 0xd1, 0x84, 0x17, 0xcf, 0x24,
+	0x6c, 0x62, 0xcd, 0x27, 0xee, 0xdb, 0xee, 0xdf, 0x88, 0xa4, 0xf0, 0x85, 0x00, 0x49, 0x76, 0x61,
+	0xd1, 0x13, 0x48, 0x7d, 0x44, 0xba, 0xae, 0xad, 0x9a, 0x7c, 0x29, 0xdc, 0x7f, 0xd3, 0x2f, 0xdd,
+	0x7d, 0x87, 0xfd, 0x27, 0xfd, 0x87, 0x99, 0xcb, 0x21, 0x0f, 0xfa, 0x17, 0x40, 0x50, 0x30, 0xb2,
+	0xbf, 0xef, 0x61, 0x9f, 0x5e, 0xeb, 0x78, 0xbd, 0x34, 0xe8, 0x97, 0xae, 0x8c, 0x8a, 0xc9, 0x74,
+	0x63, 0xd1, 0x59, 0x86, 0xbd, 0x4d, 0xa5, 0xe5, 0xef, 0x05, 0xc8, 0xad, 0xf1, 0x0d, 0x43, 0x77,
+	0xd6, 0x2e, 0xe4, 0x1c, 0x97, 0x68, 0xd8, 0xf3, 0x14, 0xcf, 0xc1, 0x1a, 0x0d, 0x34, 0x5b, 0x9d,
+	0x1f, 0xd5, 0x60, 0x87, 0x69, 0x03, 0x70, 0xbd, 0x10, 0xe9, 0xfb, 0x34, 0xaf, 0x42, 0xd8, 0xed,
+	0xac, 0x33, 0x02, 0xa2, 0x12, 0x64, 0xbd, 0x60, 0x7d, 0x29, 0xa6, 0x61, 0x19, 0xbe, 0x18, 0x0b,
+	0x7a, 0x25, 0x03, 0x15, 0xb5, 0x02, 0x09, 0xfa, 0x33, 0xcc, 0x68, 0xaa, 0xa3, 0x6a, 0x86, 0xdf,
+	0x53, 0x3e, 0xc1, 0x46, 0xe7, 0x80, 0x25, 0x33, 0x25, 0x4f, 0x87, 0xe2, 0xff, 0x51, 0x29, 0x2a,
+	0x40, 0x5a, 0x77, 0x55, 0xc3, 0x36, 0xec, 0x0e, 0xdd, 0x39, 0x69, 0x79, 0x78, 0x2e, 0x7f, 0x2d,
+	0xc0, 0x14, 0x6f, 0x68, 0xdb, 0x57, 0xfd, 0xae, 0x87, 0xee, 0x40, 0x42, 0x23, 0x3a, 0xa6, 0x59,
+	0x4c, 0x57, 0xaf, 0x8e, 0x96, 0xe9, 0x18, 0x4c, 0x5a, 0x23, 0x3a, 0x96, 0x29, 0x12, 0x2d, 0x40,
+	0x12, 0xbb, 0x2e, 0x71, 0xd9, 0x02, 0xce, 0xc8, 0xfc, 0x54, 0x7e, 0x08, 0x89, 0x00, 0x85, 0xd2,
+	0x90, 0x68, 0x36, 0x5a, 0xeb, 0xf9, 0x09, 0x94, 0x83, 0x74, 0xbd, 0xb6, 0xf6, 0x68, 0xa3, 0xd9,
+	0x6a, 0xe5, 0x75, 0x94, 0x83, 0xd4, 0x6e, 0xad, 0xd9, 0x6a, 0x6e, 0x3d, 0xcc, 0x1f, 0x0b, 0xc1,
+	0x69, 0x47, 0x6e, 0x3e, 0xae, 0xc9, 0x1f, 0xe4, 0xbf, 0x89, 0xa1, 0x2c, 0x24, 0x37, 0x6a, 0xcd,
+	0xd6, 0x7a, 0x23, 0xff, 0x22, 0x5e, 0xde, 0x84, 0x6c, 0xcb, 0xf0, 0x7c, 0x19, 0x7f, 0xdc, 0xc5,
+	0x9e, 0x8f, 0xfe, 0x06, 0x69, 0x8f, 0x0f, 0x94, 0x28, 0xbc, 0x7d, 0xde, 0x12, 0x41, 0xb5, 0xe5,
+	0x21, 0xbc, 0xfc, 0x4b, 0x0c, 0x72, 0x8c, 0xca, 0x73, 0x88, 0xed, 0x61, 0x54, 0x81, 0xa4, 0x47,
+	0x13, 0xe2, 0xf9, 0xe6, 0x23, 0x8f, 0x07, 0x95, 0xcb, 0x5c, 0x8f, 0x24, 0x48, 0x1e, 0x60, 0x55,
+	0xc7, 0x2e, 0x6d, 0x45, 0xb6, 0x9a, 0x1f, 0xf9, 0xdc, 0xa4, 0x72, 0xee, 0x8c, 0xa3, 0xd0, 0x2a,
+	0x24, 0x69, 0xb3, 0x3c, 0x31, 0x4e, 0x9f, 0xa5, 0x48, 0x25, 0xa3, 0x11, 0xb0, 0x37, 0x2a, 0xb4,
+	0x65, 0x16, 0x85, 0xef, 0x04, 0x98, 0xa4, 0x72, 0x74, 0x1b, 0x12, 0x91, 0x99, 0x9a, 0x3b, 0xe7,
+	0x69, 0xe3, 0xa6, 0x14, 0x86, 0x96, 0x21, 0x67, 0x11, 0x5d, 0x71, 0xf1, 0xa1, 0xe1, 0x05, 0x0b,
+	0x36, 0x08, 0x35, 0x2e, 0x67, 0x2d, 0xa2, 0xcb, 0x5c, 0x84, 0xfe, 0x02, 0x93, 0x2e, 0xe9, 0xfa,
+	0x98, 0x0e, 0x4b, 0xb6, 0x3a, 0x33, 0x4a, 0x43, 0x0e, 0xc4, 0x9c, 0x8e, 0x61, 0xd0, 0xbd, 0x61,
+	0x79, 0x12, 0x34, 0x89, 0xc5, 0x0b, 0xc6, 0x61, 0x18, 0x3f, 0x3d, 0x95, 0x7f, 0x12, 0x20, 0x57,
+	0x73, 0x1c, 0xb3, 0x17, 0xb6, 0xec, 0x9f, 0x90, 0xd2, 0x0e, 0x54, 0xbb, 0x83, 0x83, 0x3a, 0x07,
+	0x44, 0xd7, 0x46, 0x44, 0x51, 0xa0, 0xb4, 0x46, 0x51, 0x9c, 0x2e, 0xb4, 0x29, 0x7c, 0x26, 0x40,
+	0x92, 0x69, 0x90, 0x04, 0x73, 0xf8, 0xc8, 0xc1, 0x9a, 0xaf, 0x8c, 0x25, 0x2a, 0xd0, 0x44, 0x67,
+	0x99, 0xea, 0xf1, 0x58, 0xba, 0xc9, 0xae, 0xe3, 0x61, 0xd7, 0x17, 0x63, 0x17, 0x96, 0x50, 0xe6,
+	0x10, 0x74, 0x1d, 0x92, 0x3a, 0x36, 0x31, 0x2f, 0x4e, 0xa6, 0x9e, 0x8d, 0xfe, 0xd8, 0xe0, 0xaa,
+	0xb2, 0x01, 0x53, 0x3c, 0xe4, 0xf7, 0x3d, 0x43, 0xe5, 0xa7, 0x90, 0x0d, 0x18, 0xc2, 0x2a, 0x56,
+	0x86, 0xe6, 0xc2, 0xf9, 0xe6, 0xc3, 0xe1, 0x5b, 0x86, 0x49, 0x3a, 0x4a, 0x62, 0xec, 0x6c, 0x1e,
+	0x4c, 0x53, 0xfe, 0x32, 0x06, 0x39, 0x46, 0xfe, 0xde, 0xaf, 0xc2, 0x21, 0xa4, 0xd8, 0x46, 0x0d,
+	0xef, 0xc2, 0xf5, 0x71, 0xea, 0xe1, 0x5d, 0x60, 0x1b, 0xd6, 0x5b, 0xb7, 0x7d, 0xb7, 0x57, 0xbf,
+	0xff, 0xe9, 0xab, 0x3f, 0xb8, 0xe9, 0xb9, 0xb3, 0xc2, 0x2a, 0xe4, 0xa2, 0x8c, 0x28, 0x0f, 0xf1,
+	0x67, 0xb8, 0xc7, 0x1e, 0x12, 0x39, 0xf8, 0x44, 0x97, 0x60, 0xf2, 0x50, 0x35, 0xbb, 0x98, 0x5f,
+	0x14, 0x76, 0x58, 0x8d, 0x3d, 0x10, 0xca, 0x7f, 0x85, 0x99, 0x87, 0xd8, 0xdf, 0x34, 0x6c, 0xdf,
+	0x0b, 0xcb, 0x3f, 0x2c, 0xaa, 0x70, 0x61, 0x51, 0x7f, 0x88, 0x41, 0x7e, 0x64, 0xf6, 0xde, 0x0b,
+	0xdb, 0x86, 0x29, 0xc7, 0x35, 0x2c, 0xd5, 0xed, 0x29, 0xc1, 0xcf, 0x3b, 0x8f, 0xdf, 0xe9, 0xca,
+	0xc8, 0xc1, 0xe9, 0x60, 0xa4, 0xf0, 0x83, 0x4a, 0x39, 0x5d, 0x8e, 0x93, 0x50, 0x19, 0x7a, 0x02,
+	0x39, 0xf6, 0xfb, 0x91, 0x73, 0xb2, 0x9b, 0xff, 0xae, 0x9c, 0x59, 0xc6, 0x41, 0x45, 0x85, 0x7f,
+	0xc0, 0xd4, 0x18, 0x26, 0x58, 0x42, 0x8c, 0x3c, 0x7c, 0x2b, 0x23, 0x7f, 0x39, 0xa4, 0x8d, 0xf6,
+	0x63, 0xc6, 0xcf, 0x30, 0x37, 0x09, 0x24, 0xf9, 0xdb, 0x94, 0x84, 0xd8, 0xf6, 0xa3, 0xfc, 0x04,
+	0x9a, 0x83, 0x99, 0xf6, 0x66, 0x4d, 0x6e, 0x28, 0x5b, 0xdb, 0xbb, 0xca, 0xc6, 0xf6, 0x7f, 0xb7,
+	0x1a, 0x79, 0x01, 0x5d, 0x82, 0xfc, 0xd6, 0xb6, 0xc2, 0xe4, 0xe1, 0x4b, 0x12, 0x43, 0xf3, 0x30,
+	0x1b, 0x80, 0xc6, 0xc5, 0x71, 0x74, 0x05, 0x16, 0xd7, 0x77, 0xd7, 0x1a, 0xca, 0xae, 0x5c, 0xdb,
+	0x6a, 0xd7, 0xd6, 0x76, 0x9b, 0xdb, 0x5b, 0x0a, 0x7f, 0x70, 0x12, 0xd5, 0xc1, 0x70, 0xfd, 0xde,
+	0x83, 0x44, 0xe0, 0x1a, 0xcd, 0x9f, 0x1e, 0x58, 0x3a, 0x11, 0x85, 0x85, 0xf3, 0xe7, 0x38, 0x30,
+	0x0b, 0x76, 0x7c, 0xd4, 0x2c, 0xf2, 0x80, 0x15, 0x16, 0x4e, 0x8b, 0xb9, 0xd9, 0x03, 0x98, 0xa4,
+	0x9b, 0x05, 0x2d, 0x9c, 0xbf, 0x1d, 0x0b, 0x8b, 0x67, 0xe4, 0xdc, 0xb2, 0x06, 0xe9, 0xb0, 0x2b,
+	0xe8, 0xf2, 0x79, 0x9d, 0x62, 0xf6, 0x85, 0x8b, 0x9b, 0x58, 0xbf, 0x7a, 0xfc, 0x73, 0x71, 0xe2,
+	0xf8, 0x75, 0x51, 0x78, 0xf9, 0xba, 0x28, 0xbc, 0x38, 0x29, 0x4e, 0x7c, 0x75, 0x52, 0x14, 0x5e,
+	0x9e, 0x14, 0x27, 0x7e, 0x3c, 0x29, 0x4e, 0xec, 0x25, 0xe9, 0x20, 0xde, 0xfd, 0x75, 0x00, 0x53,
+	0x22, 0x95, 0x91, 0x95, 0x0e, 0x00, 0x00,
 }
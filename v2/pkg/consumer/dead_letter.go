@@ -0,0 +1,138 @@
+package consumer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Retryable marks |err| as retryable. Where an Application is wrapped by
+// WithRetries or WithDeadLetters, a retryable error returned by ConsumeMessage
+// causes the message to be re-consumed and, should it continue to fail, to
+// fail the Shard or be routed to the dead-letter Journal, respectively.
+// Errors which are not retryable fail the Shard.
+func Retryable(err error) error { return retryableError{err} }
+
+// IsRetryable returns true iff the cause of |err| was marked as Retryable.
+func IsRetryable(err error) bool {
+	var r, ok = errors.Cause(err).(interface{ Retryable() bool })
+	return ok && r.Retryable()
+}
+
+// DeadLetterConfig configures the routing of failing messages to a
+// dead-letter Journal.
+type DeadLetterConfig struct {
+	// Journal to which DeadLetters are appended.
+	Journal pb.Journal
+	// Maximum number of attempts to consume a message which fails with a
+	// Retryable error, before it's routed to the dead-letter Journal.
+	MaxAttempts int
+	// Backoff and MaxBackoff between attempts, as per RetryPolicy.
+	Backoff, MaxBackoff time.Duration
+}
+
+// DeadLetter is a record of a message which could not be consumed. It's
+// appended to the dead-letter Journal as newline-delimited JSON.
+type DeadLetter struct {
+	// Journal from which the message was read.
+	Journal pb.Journal `json:"journal"`
+	// Offset of the next message within the Journal.
+	NextOffset int64 `json:"next_offset"`
+	// ContentType of the Journal, and of the framed Message.
+	ContentType string `json:"content_type"`
+	// Message is the framed message, re-encoded under its ContentType.
+	Message []byte `json:"message"`
+	// Attempts made to consume the message.
+	Attempts int `json:"attempts"`
+	// Error returned by the final attempt.
+	Error string `json:"error"`
+}
+
+// WithDeadLetters returns an Application which wraps |app|. A message for
+// which ConsumeMessage returns a Retryable error is consumed again (after a
+// backoff), up to DeadLetterConfig.MaxAttempts times. If it continues to fail,
+// a DeadLetter of the message is appended to the DeadLetterConfig.Journal and
+// the Shard advances past the message, rather than failing. Appends to the dead-letter
+// Journal commit before the offsets of the consumer transaction.
+func WithDeadLetters(app Application, cfg DeadLetterConfig) Application {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	return deadLetterApp{delegatingApp: delegatingApp{app}, cfg: cfg}
+}
+
+type deadLetterApp struct {
+	delegatingApp
+	cfg DeadLetterConfig
+}
+
+// ConsumeMessage delegates to the wrapped Application, routing
+// messages which repeatedly fail to the dead-letter Journal.
+func (a deadLetterApp) ConsumeMessage(shard Shard, store Store, env message.Envelope) error {
+	var policy = RetryPolicy{
+		MaxAttempts: a.cfg.MaxAttempts,
+		Backoff:     a.cfg.Backoff,
+		MaxBackoff:  a.cfg.MaxBackoff,
+	}
+	var attempt, err = policy.consume(a.Application, shard, store, env)
+	if err == nil || !IsRetryable(err) {
+		return err
+	}
+
+	var ct = env.JournalSpec.LabelSet.ValueOf(labels.ContentType)
+	var dl = DeadLetter{
+		Journal:     env.JournalSpec.Name,
+		NextOffset:  env.NextOffset,
+		ContentType: ct,
+		Attempts:    attempt,
+		Error:       err.Error(),
+	}
+	if dl.Message, err = marshalFramed(ct, env.Message); err != nil {
+		return extendErr(err, "marshaling dead letter (%s:%d)", dl.Journal, dl.NextOffset)
+	}
+
+	var aa = shard.JournalClient().StartAppend(a.cfg.Journal)
+	aa.Require(json.NewEncoder(aa.Writer()).Encode(dl))
+
+	if err = aa.Release(); err != nil {
+		return extendErr(err, "appending dead letter (%s:%d)", dl.Journal, dl.NextOffset)
+	}
+	metrics.GazetteConsumerDeadLettersTotal.Inc()
+
+	log.WithFields(log.Fields{
+		"shard":      shard.Spec().Id,
+		"journal":    dl.Journal,
+		"offset":     dl.NextOffset,
+		"deadLetter": a.cfg.Journal,
+		"err":        dl.Error,
+	}).Warn("routed failed message to dead-letter journal")
+
+	return nil
+}
+
+// marshalFramed returns |msg| framed under |contentType|.
+func marshalFramed(contentType string, msg message.Message) ([]byte, error) {
+	var framing, err = message.FramingByContentType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	if err = framing.Marshal(msg, bw); err == nil {
+		err = bw.Flush()
+	}
+	return buf.Bytes(), err
+}
+
+type retryableError struct{ error }
+
+func (retryableError) Retryable() bool { return true }
@@ -0,0 +1,72 @@
+package consumer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type DeadLetterSuite struct{}
+
+func (s *DeadLetterSuite) TestRetryableErrors(c *gc.C) {
+	var err = errors.New("whoops")
+
+	c.Check(IsRetryable(err), gc.Equals, false)
+	c.Check(IsRetryable(Retryable(err)), gc.Equals, true)
+	c.Check(IsRetryable(extendErr(Retryable(err), "wrapped")), gc.Equals, true)
+	c.Check(Retryable(err), gc.ErrorMatches, "whoops")
+}
+
+func (s *DeadLetterSuite) TestRoutingOfFailingMessages(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	playAndComplete(c, r)
+
+	var testApp = r.app.(*testApplication)
+	var app = WithDeadLetters(testApp, DeadLetterConfig{Journal: sourceB, MaxAttempts: 3})
+	var env = message.Envelope{
+		JournalSpec: &pb.JournalSpec{
+			Name:     sourceA,
+			LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+		},
+		NextOffset: 1234,
+		Message:    &testMessage{Key: "foo", Value: "bar"},
+	}
+
+	// Case: ConsumeMessage succeeds.
+	c.Check(app.ConsumeMessage(r, r.store, env), gc.IsNil)
+
+	// Case: ConsumeMessage fails with a non-retryable error, which is returned.
+	testApp.consumeErr = errors.New("fatal error")
+	c.Check(app.ConsumeMessage(r, r.store, env), gc.ErrorMatches, "fatal error")
+
+	// Case: ConsumeMessage fails with a retryable error. Expect the message is
+	// routed to the dead-letter journal, and no error is returned.
+	testApp.consumeErr = Retryable(errors.New("retryable error"))
+	c.Check(app.ConsumeMessage(r, r.store, env), gc.IsNil)
+
+	var br = bufio.NewReader(client.NewReader(r.ctx, r.JournalClient(),
+		pb.ReadRequest{Journal: sourceB, Block: true}))
+	var line, err = br.ReadBytes('\n')
+	c.Assert(err, gc.IsNil)
+
+	var dl DeadLetter
+	c.Check(json.Unmarshal(line, &dl), gc.IsNil)
+	c.Check(dl, gc.DeepEquals, DeadLetter{
+		Journal:     sourceA,
+		NextOffset:  1234,
+		ContentType: labels.ContentType_JSONLines,
+		Message:     []byte(`{"Key":"foo","Value":"bar"}` + "\n"),
+		Attempts:    3,
+		Error:       "retryable error",
+	})
+}
+
+var _ = gc.Suite(&DeadLetterSuite{})
@@ -126,3 +126,24 @@ type BeginFinisher interface {
 	// return an error just because argument error is non-nil.
 	FinishTxn(Shard, Store, error) error
 }
+
+// ShardTransitioner is an optional interface of Application which is informed
+// as a Shard of the local consumer process transitions between standby,
+// primary, and shut-down states. It allows the Application to warm caches,
+// register metrics, or release external resources of the Shard.
+type ShardTransitioner interface {
+	// BecomeStandby is called as the Shard begins to serve as a hot standby,
+	// prior to its playback of the recovery log. It's not called for a Shard
+	// which is assigned directly as primary. A returned error fails the Shard.
+	BecomeStandby(Shard) error
+	// BecomePrimary is called as the Shard becomes primary, after recovery
+	// and the initialization of its Store but before any consumer transaction
+	// begins, or before the Shard serves requests resolved to its Store.
+	// A returned error fails the Shard.
+	BecomePrimary(Shard, Store) error
+	// Shutdown is called once the Shard is no longer assigned to the local
+	// consumer process, and its processing has fully stopped. The Store is
+	// that of the Shard, or nil if the Shard never became primary, and is
+	// destroyed upon Shutdown's return. An error returned by Shutdown is logged.
+	Shutdown(Shard, Store) error
+}
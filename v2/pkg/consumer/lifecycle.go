@@ -8,7 +8,9 @@ import (
 	"io/ioutil"
 	"time"
 
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
 	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	"github.com/LiveRamp/gazette/v2/pkg/labels"
 	"github.com/LiveRamp/gazette/v2/pkg/message"
 	"github.com/LiveRamp/gazette/v2/pkg/metrics"
@@ -16,6 +18,7 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
 	"github.com/coreos/etcd/clientv3"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -71,11 +74,22 @@ func completePlayback(shard Shard, app Application, pl *recoverylog.Player,
 	var store Store
 	var offsets map[pb.Journal]int64
 
-	if store, err = app.NewStore(shard, pl.Dir, recorder); err != nil {
+	// A seed which replaces the Shard's state is recovered prior to
+	// initializing the store, and a merged seed after.
+	if _, err = recoverSeed(shard, app, nil, recorder, pl.Dir, etcd); err != nil {
+		return nil, nil, extendErr(err, "recovering seed")
+	} else if store, err = app.NewStore(shard, pl.Dir, recorder); err != nil {
 		return nil, nil, extendErr(err, "initializing store")
+	} else if err = checkStoreExtensions(app, store); err != nil {
+		store.Destroy()
+		return nil, nil, err
+	} else if _, err = recoverSeed(shard, app, store, recorder, pl.Dir, etcd); err != nil {
+		store.Destroy()
+		return nil, nil, extendErr(err, "merging seed")
 	} else if offsets, err = store.FetchJournalOffsets(); err != nil {
 		return nil, nil, extendErr(err, "fetching journal offsets from store")
 	}
+	beginSequencer(shard, store)
 
 	// Lower-bound each source to its ShardSpec.Source.MinOffset.
 	for _, src := range shard.Spec().Sources {
@@ -86,8 +100,12 @@ func completePlayback(shard Shard, app Application, pl *recoverylog.Player,
 	return store, offsets, nil
 }
 
-// pumpMessages reads and decodes messages from a Journal & offset into the provided channel.
-func pumpMessages(shard Shard, app Application, journal pb.Journal, offset int64, msgCh chan<- message.Envelope) error {
+// pumpMessages reads and decodes messages from a Journal & offset into the
+// provided channel. Messages consumed prior to the merge of |frontiers| are
+// skipped. Bytes of each message are acquired from |ra| (if non-nil)
+// before the message is sent, bounding messages read ahead of consumption.
+func pumpMessages(shard Shard, app Application, journal pb.Journal, offset int64,
+	frontiers []mergeFrontier, msgCh chan<- message.Envelope, ra *readAhead) error {
 	var spec, err = fetchJournalSpec(shard.Context(), journal, shard.JournalClient())
 	if err != nil {
 		return extendErr(err, "fetching JournalSpec")
@@ -97,6 +115,21 @@ func pumpMessages(shard Shard, app Application, journal pb.Journal, offset int64
 		return extendErr(err, "determining framing (%s)", journal)
 	}
 
+	// Messages which aren't matched by a Filter of the Source are passed with
+	// a nil Message, such that the transaction may still checkpoint past them.
+	var filter = newSourceFilter(shard.Spec(), journal)
+	// Similarly, messages having keys outside of the Shard's key range are
+	// passed with a nil Message, unless the Source is a broadcast one.
+	inRange, err := shardKeyFilter(shard.Spec(), journal, app)
+	if err != nil {
+		return err
+	}
+	// As are messages already consumed by a Shard which was merged into this one.
+	consumed, err := mergeFilter(shard.Spec(), journal, app, frontiers)
+	if err != nil {
+		return err
+	}
+
 	var rr = client.NewRetryReader(shard.Context(), shard.JournalClient(), pb.ReadRequest{
 		Journal:    journal,
 		Offset:     offset,
@@ -129,10 +162,37 @@ func pumpMessages(shard Shard, app Application, journal pb.Journal, offset int64
 		if msg, err = app.NewMessage(spec); err != nil {
 			return extendErr(err, "NewMessage (%s)", journal)
 		} else if err = framing.Unmarshal(frame, msg); err != nil {
+			// A failure to consult a schema registry is not a problem of the
+			// message itself, which must not be skipped. Nor is a schema which
+			// is unknown or incompatible, which may be resolved by registering
+			// it (or by deploying an Application which can decode it). Fail
+			// the shard, such that it's retried.
+			if _, ok := err.(*message.SchemaRegistryError); ok {
+				return extendErr(err, "unmarshal (%s:%d)", spec.Name, offset)
+			} else if c := errors.Cause(err); c == message.ErrUnknownSchema || c == message.ErrIncompatibleSchema {
+				return extendErr(err, "unmarshal (%s:%d)", spec.Name, offset)
+			}
 			log.WithFields(log.Fields{"journal": journal, "offset": offset, "err": err}).
 				Error("failed to unmarshal message")
 			continue
 		}
+		if filter != nil {
+			if ok, err := filter.matches(msg); err != nil {
+				return extendErr(err, "filtering message (%s:%d)", spec.Name, offset)
+			} else if !ok {
+				msg = nil
+			}
+		}
+		if msg != nil && inRange != nil && !inRange(msg) {
+			msg = nil
+		}
+		if msg != nil && consumed != nil && consumed(offset, msg) {
+			msg = nil
+		}
+
+		if err = ra.acquire(shard.Context(), journal, offset, next); err != nil {
+			return extendErr(err, "acquiring read-ahead (%s:%d)", spec.Name, offset)
+		}
 
 		select {
 		case msgCh <- message.Envelope{
@@ -150,8 +210,10 @@ func pumpMessages(shard Shard, app Application, journal pb.Journal, offset int64
 
 // consumeMessages runs consumer transactions, consuming from the provided
 // |msgCh| and, when notified by |hintsCh|, occasionally stores recorded FSMHints.
-func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.Client,
-	msgCh <-chan message.Envelope, hintsCh <-chan time.Time) (err error) {
+// Bytes of received messages are released to |ra|, if non-nil. Transactions
+// don't begin while the ShardSpec is paused, as observed through |ks|.
+func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.Client, ks *keyspace.KeySpace,
+	msgCh <-chan message.Envelope, ra *readAhead, hintsCh <-chan time.Time) (err error) {
 
 	// Supply an idle timer for txnStep's use in timing transaction durations.
 	var realTimer = time.NewTimer(0)
@@ -164,6 +226,8 @@ func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.C
 		Stop:  realTimer.Stop,
 	}
 	var txn, prior transaction
+	var shardID = shard.Spec().Id.String()
+	var consumeSeconds = metrics.GazetteConsumerMessageConsumeSeconds.WithLabelValues(shardID)
 
 	for {
 		select {
@@ -180,9 +244,18 @@ func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.C
 			// Pass.
 		}
 
+		if shard.Spec().Pause {
+			if err = waitWhilePaused(shard, ks); err != nil {
+				err = extendErr(err, "waitWhilePaused")
+				return
+			}
+		}
+
 		var spec = shard.Spec()
 		txn.minDur, txn.maxDur = spec.MinTxnDuration, spec.MaxTxnDuration
-		txn.msgCh = msgCh
+		txn.maxMsgs, txn.maxBytes = int(spec.MaxTxnMessages), spec.MaxTxnBytes
+		txn.msgCh, txn.readAhead = msgCh, ra
+		txn.consumeSeconds = consumeSeconds
 		txn.offsets = make(map[pb.Journal]int64)
 
 		// Run the transaction until completion or error.
@@ -200,7 +273,7 @@ func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.C
 			return
 		}
 
-		recordMetrics(&prior)
+		recordMetrics(shardID, &prior)
 		prior, txn = txn, transaction{doneCh: txn.barrier.Done()}
 	}
 }
@@ -351,12 +424,38 @@ func storeRecoveredHints(shard Shard, hints recoverylog.FSMHints, etcd *clientv3
 	return
 }
 
+// waitWhilePaused blocks until the ShardSpec of |shard| within |ks| is no
+// longer paused, or until the |shard| context is done.
+func waitWhilePaused(shard Shard, ks *keyspace.KeySpace) error {
+	var id = shard.Spec().Id
+	var key = allocator.ItemKey(ks, id.String())
+
+	log.WithField("shard", id).Info("shard is paused")
+
+	ks.Mu.RLock()
+	var err = ks.WaitForCondition(shard.Context(), func() bool {
+		var ind, found = ks.KeyValues.Search(key)
+		return !found || !ks.KeyValues[ind].Decoded.(allocator.Item).ItemValue.(*ShardSpec).Pause
+	})
+	ks.Mu.RUnlock()
+
+	if err == nil {
+		log.WithField("shard", id).Info("shard is resumed")
+	}
+	return err
+}
+
 // transaction models state and metrics used in the execution of a consumer transaction.
 type transaction struct {
 	barrier        *client.AsyncAppend     // Write barrier of the txn at commit.
 	minDur, maxDur time.Duration           // Minimum and maximum durations. Marked as -1 when elapsed.
 	msgCh          <-chan message.Envelope // Message source. Nil'd upon reaching |maxDur|.
+	readAhead      *readAhead              // Released by received messages. May be nil.
+	consumeSeconds prometheus.Observer     // Observes latencies of consumed messages. May be nil.
 	msgCount       int                     // Number of messages batched into this transaction.
+	msgBytes       int64                   // Approximate bytes of messages batched into this transaction.
+	maxMsgs        int                     // Maximum messages of the transaction, or zero if unbounded.
+	maxBytes       int64                   // Maximum message bytes of the transaction, or zero if unbounded.
 	offsets        map[pb.Journal]int64    // End (exclusive) journal offsets of the transaction.
 	doneCh         <-chan struct{}         // DoneCh of prior transaction barrier.
 
@@ -396,12 +495,8 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 				txn.beganAt = timeNow()
 				timer.Reset(txn.minDur)
 			}
-			txn.msgCount++
-			txn.offsets[msg.JournalSpec.Name] = msg.NextOffset
-
-			if err = app.ConsumeMessage(shard, store, msg); err != nil {
-				err = extendErr(err, "app.ConsumeMessage")
-			}
+			txn.addMessage(msg, timer)
+			err = consumeMessage(shard, store, app, msg, txn.consumeSeconds)
 			return
 
 		case tick := <-timer.C:
@@ -435,12 +530,8 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 	// Continue reading messages so long as we do not block or reach |maxDur|.
 	select {
 	case msg := <-txn.msgCh:
-		txn.msgCount++
-		txn.offsets[msg.JournalSpec.Name] = msg.NextOffset
-
-		if err = app.ConsumeMessage(shard, store, msg); err != nil {
-			err = extendErr(err, "app.ConsumeMessage")
-		}
+		txn.addMessage(msg, timer)
+		err = consumeMessage(shard, store, app, msg, txn.consumeSeconds)
 		return
 
 	case tick := <-timer.C:
@@ -463,6 +554,10 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 	if txn.flushedAt = timeNow(); txn.stalledAt.IsZero() {
 		txn.stalledAt = txn.flushedAt // We spent no time stalled.
 	}
+	if err = fireTimers(shard, store, app); err != nil {
+		err = extendErr(err, "app.OnTimer")
+		return
+	}
 	if err = app.FinalizeTxn(shard, store); err != nil {
 		err = extendErr(err, "app.FinalizeTxn")
 		return
@@ -490,8 +585,55 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 	return
 }
 
-// recordMetrics of a fully completed transaction.
-func recordMetrics(txn *transaction) {
+// consumeMessage passes |msg| to the Application, unless it was filtered
+// or is a duplicate of a message already consumed.
+func consumeMessage(shard Shard, store Store, app Application, msg message.Envelope, consumeSeconds prometheus.Observer) error {
+	if msg.Message == nil {
+		return nil // Filtered by its Source.
+	} else if isDuplicate(store, msg.Message) {
+		return nil
+	}
+	var began = timeNow()
+
+	if err := app.ConsumeMessage(shard, store, msg); err != nil {
+		return extendErr(err, "app.ConsumeMessage")
+	}
+	if consumeSeconds != nil {
+		consumeSeconds.Observe(timeNow().Sub(began).Seconds())
+	}
+	advanceWatermark(store, msg.Message)
+	return nil
+}
+
+// addMessage adds |msg| to the transaction. If the transaction thereby reaches
+// its maximum messages or bytes, it stops reading messages and its |timer| is
+// stopped, as though |maxDur| had elapsed.
+func (txn *transaction) addMessage(msg message.Envelope, timer txnTimer) {
+	txn.readAhead.release(msg.JournalSpec.Name, msg.NextOffset)
+
+	if prev, ok := txn.offsets[msg.JournalSpec.Name]; ok {
+		txn.msgBytes += msg.NextOffset - prev
+	}
+	txn.msgCount++
+	txn.offsets[msg.JournalSpec.Name] = msg.NextOffset
+
+	if (txn.maxMsgs == 0 || txn.msgCount < txn.maxMsgs) &&
+		(txn.maxBytes == 0 || txn.msgBytes < txn.maxBytes) {
+		return
+	}
+	if txn.maxDur != -1 && !timer.Stop() {
+		<-timer.C
+	}
+	txn.minDur, txn.maxDur = -1, -1 // Mark as completed.
+	txn.msgCh = nil                 // Stop reading messages.
+
+	if txn.doneCh != nil {
+		txn.stalledAt = timeNow() // We're stalled waiting for prior txn IO.
+	}
+}
+
+// recordMetrics of a fully completed transaction of Shard |shardID|.
+func recordMetrics(shardID string, txn *transaction) {
 	metrics.GazetteConsumerTxCountTotal.Inc()
 	metrics.GazetteConsumerTxMessagesTotal.Add(float64(txn.msgCount))
 
@@ -500,6 +642,26 @@ func recordMetrics(txn *transaction) {
 	metrics.GazetteConsumerTxStalledSecondsTotal.Add(txn.flushedAt.Sub(txn.stalledAt).Seconds())
 	metrics.GazetteConsumerTxFlushSecondsTotal.Add(txn.committedAt.Sub(txn.flushedAt).Seconds())
 	metrics.GazetteConsumerTxSyncSecondsTotal.Add(txn.syncedAt.Sub(txn.committedAt).Seconds())
+
+	if txn.msgCount == 0 {
+		return // Zero-valued |prior| of the Shard's first transaction.
+	}
+	metrics.GazetteConsumerTxDurationSeconds.WithLabelValues(shardID).Observe(txn.syncedAt.Sub(txn.beganAt).Seconds())
+	metrics.GazetteConsumerTxMessages.WithLabelValues(shardID).Observe(float64(txn.msgCount))
+	metrics.GazetteConsumerTxBytes.WithLabelValues(shardID).Observe(float64(txn.msgBytes))
+}
+
+// deleteShardMetrics removes per-shard metrics of Shard |shardID|.
+func deleteShardMetrics(shardID string) {
+	for _, vec := range []*prometheus.HistogramVec{
+		metrics.GazetteConsumerMessageConsumeSeconds,
+		metrics.GazetteConsumerTxDurationSeconds,
+		metrics.GazetteConsumerTxMessages,
+		metrics.GazetteConsumerTxBytes,
+		metrics.GazetteConsumerRecoverySeconds,
+	} {
+		vec.DeleteLabelValues(shardID)
+	}
 }
 
 func extendErr(err error, mFmt string, args ...interface{}) error {
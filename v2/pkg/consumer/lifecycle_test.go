@@ -11,10 +11,14 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	"github.com/LiveRamp/gazette/v2/pkg/labels"
 	"github.com/LiveRamp/gazette/v2/pkg/message"
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
 	"github.com/coreos/etcd/clientv3"
 	gc "github.com/go-check/check"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 type LifecycleSuite struct{}
@@ -164,7 +168,7 @@ func (s *LifecycleSuite) TestMessagePump(c *gc.C) {
 
 	go func() {
 		var src = r.spec.Sources[0]
-		c.Check(pumpMessages(r, r.app, src.Journal, src.MinOffset, msgCh), gc.Equals, context.Canceled)
+		c.Check(pumpMessages(r, r.app, src.Journal, src.MinOffset, nil, msgCh, nil), gc.Equals, context.Canceled)
 	}()
 
 	var aa = r.JournalClient().StartAppend(sourceA)
@@ -214,7 +218,7 @@ func (s *LifecycleSuite) TestMessagePumpConsumesOffsetJumpError(c *gc.C) {
 	// offset. Expect that error is consumed and the pump continues.
 	var msgCh = make(chan message.Envelope)
 	go func() {
-		c.Check(pumpMessages(r, r.app, sourceA, -1, msgCh), gc.Equals, context.Canceled)
+		c.Check(pumpMessages(r, r.app, sourceA, -1, nil, msgCh, nil), gc.Equals, context.Canceled)
 	}()
 
 	c.Check((<-msgCh).Message, gc.DeepEquals, &testMessage{Key: "aKey"})
@@ -224,7 +228,7 @@ func (s *LifecycleSuite) TestMessagePumpFailsOnUnknownJournal(c *gc.C) {
 	var r, cleanup = newLifecycleTestFixture(c)
 	defer cleanup()
 
-	c.Check(pumpMessages(r, r.app, "unknown/journal", 0, nil),
+	c.Check(pumpMessages(r, r.app, "unknown/journal", 0, nil, nil, nil),
 		gc.ErrorMatches, `fetching JournalSpec: named journal does not exist \(unknown/journal\)`)
 }
 
@@ -232,7 +236,7 @@ func (s *LifecycleSuite) TestMessagePumpFailsOnBadFraming(c *gc.C) {
 	var r, cleanup = newLifecycleTestFixture(c)
 	defer cleanup()
 
-	c.Check(pumpMessages(r, r.app, r.spec.RecoveryLog(), 0, nil),
+	c.Check(pumpMessages(r, r.app, r.spec.RecoveryLog(), 0, nil, nil, nil),
 		gc.ErrorMatches, `determining framing (.*): unrecognized `+labels.ContentType+` \(`+labels.ContentType_RecoveryLog+`\)`)
 }
 
@@ -247,10 +251,28 @@ func (s *LifecycleSuite) TestMessagePumpFailsOnNewMessageError(c *gc.C) {
 	_, _ = aa.Writer().WriteString("\n")
 	c.Check(aa.Release(), gc.IsNil)
 
-	c.Check(pumpMessages(r, r.app, sourceA, 0, nil),
+	c.Check(pumpMessages(r, r.app, sourceA, 0, nil, nil, nil),
 		gc.ErrorMatches, `NewMessage \(source/A\): new message error`)
 }
 
+func (s *LifecycleSuite) TestMessagePumpFailsOnSchemaError(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	var aa = r.JournalClient().StartAppend(sourceA)
+	_, _ = aa.Writer().WriteString("{\"key\": \"foo\"}\n")
+	c.Check(aa.Release(), gc.IsNil)
+
+	// Unlike other unmarshal errors, a schema which is unknown or
+	// incompatible fails the pump rather than skipping the message.
+	for _, cause := range []error{message.ErrUnknownSchema, message.ErrIncompatibleSchema} {
+		var app = schemaErrTestApplication{testApplication: r.app.(*testApplication), cause: cause}
+
+		c.Check(pumpMessages(r, app, sourceA, r.spec.Sources[0].MinOffset, nil, nil, nil), gc.ErrorMatches,
+			`unmarshal \(source/A:\d+\): id 1234: `+cause.Error())
+	}
+}
+
 func (s *LifecycleSuite) TestTxnPriorSyncsThenMinDurElapses(c *gc.C) {
 	var r, cleanup = newLifecycleTestFixture(c)
 	defer cleanup()
@@ -516,6 +538,122 @@ func (s *LifecycleSuite) TestTxnMaxDurElapsesBusyPipeline(c *gc.C) {
 	c.Check(txn.committedAt, gc.Equals, faketime(5))
 }
 
+func (s *LifecycleSuite) TestTxnMaxBytesReachedThenPriorSyncs(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	playAndComplete(c, r)
+	var msgCh = make(chan message.Envelope, 128)
+
+	var timer, restore = newTestTimer()
+	defer restore()
+
+	var priorDoneCh = make(chan struct{})
+	var prior, txn = transaction{}, transaction{
+		minDur:   3 * time.Second,
+		maxDur:   5 * time.Second,
+		maxBytes: 150,
+		msgCh:    msgCh,
+		offsets:  make(map[pb.Journal]int64),
+		doneCh:   priorDoneCh,
+	}
+
+	// Initial message opens the txn.
+	sendMsgFixture(msgCh, true, 100)
+	c.Check(mustTxnStep(c, r, &txn, &prior, timer.txnTimer), gc.Equals, false)
+	c.Check(timer.reset, gc.Equals, 3*time.Second) // Reset to |minDur|.
+
+	// Consume a message which brings the txn to |maxBytes|. The timer is
+	// stopped and no further messages are read.
+	timer.timepoint = faketime(1)
+	sendMsgFixture(msgCh, false, 250)
+	c.Check(mustTxnStep(c, r, &txn, &prior, timer.txnTimer), gc.Equals, false)
+
+	c.Check(timer.stopped, gc.Equals, true)
+	c.Check(txn.minDur, gc.Equals, time.Duration(-1))
+	c.Check(txn.maxDur, gc.Equals, time.Duration(-1))
+	c.Check(txn.msgCh, gc.IsNil)
+	c.Check(txn.msgBytes, gc.Equals, int64(150))
+
+	// Resolve prior commit.
+	timer.timepoint = faketime(2)
+	close(priorDoneCh)
+	c.Check(mustTxnStep(c, r, &txn, &prior, timer.txnTimer), gc.Equals, false)
+
+	// Additional message is not consumed.
+	sendMsgFixture(msgCh, false, 300)
+	// |msgCh| stalls, and the transaction completes.
+	c.Check(mustTxnStep(c, r, &txn, &prior, timer.txnTimer), gc.Equals, true)
+
+	c.Check(txn.barrier, gc.NotNil)
+	c.Check(txn.msgCount, gc.Equals, 2)
+	c.Check(txn.doneCh, gc.IsNil)
+
+	c.Check(r.store.(*JSONFileStore).State, gc.DeepEquals, &map[string]string{"key": "250"})
+	c.Check(r.store.(*JSONFileStore).offsets, gc.DeepEquals, map[pb.Journal]int64{"source/A": 250})
+
+	c.Check(txn.beganAt, gc.Equals, faketime(0))
+	c.Check(prior.syncedAt, gc.Equals, faketime(2))
+	c.Check(txn.stalledAt, gc.Equals, faketime(1))
+	c.Check(txn.flushedAt, gc.Equals, faketime(2))
+	c.Check(txn.committedAt, gc.Equals, faketime(2))
+}
+
+func (s *LifecycleSuite) TestTxnMaxMessagesReached(c *gc.C) {
+	var timer, restore = newTestTimer()
+	defer restore()
+
+	var txn = transaction{
+		minDur:  -1,
+		maxDur:  5 * time.Second,
+		maxMsgs: 3,
+		msgCh:   make(chan message.Envelope),
+		offsets: make(map[pb.Journal]int64),
+	}
+	var env = message.Envelope{JournalSpec: &pb.JournalSpec{Name: "source/A"}}
+
+	for _, offset := range []int64{10, 20} {
+		env.NextOffset = offset
+		txn.addMessage(env, timer.txnTimer)
+	}
+	c.Check(txn.msgCh, gc.NotNil)
+	c.Check(timer.stopped, gc.Equals, false)
+
+	env.NextOffset = 30
+	txn.addMessage(env, timer.txnTimer)
+
+	c.Check(txn.msgCh, gc.IsNil)
+	c.Check(txn.maxDur, gc.Equals, time.Duration(-1))
+	c.Check(timer.stopped, gc.Equals, true)
+	c.Check(txn.msgCount, gc.Equals, 3)
+	c.Check(txn.msgBytes, gc.Equals, int64(20))
+}
+
+func (s *LifecycleSuite) TestRecordShardMetrics(c *gc.C) {
+	var txn = transaction{
+		msgCount: 12,
+		msgBytes: 3456,
+		beganAt:  faketime(0),
+		syncedAt: faketime(2),
+	}
+	recordMetrics("a-shard", &transaction{}) // Not observed.
+	recordMetrics("a-shard", &txn)
+
+	var hist = func(vec *prometheus.HistogramVec) *dto.Histogram {
+		var out dto.Metric
+		c.Assert(vec.WithLabelValues("a-shard").(prometheus.Metric).Write(&out), gc.IsNil)
+		return out.Histogram
+	}
+	c.Check(hist(metrics.GazetteConsumerTxDurationSeconds).GetSampleCount(), gc.Equals, uint64(1))
+	c.Check(hist(metrics.GazetteConsumerTxDurationSeconds).GetSampleSum(), gc.Equals, 2.0)
+	c.Check(hist(metrics.GazetteConsumerTxMessages).GetSampleSum(), gc.Equals, 12.0)
+	c.Check(hist(metrics.GazetteConsumerTxBytes).GetSampleSum(), gc.Equals, 3456.0)
+
+	// Expect per-shard metrics are reset upon their deletion.
+	deleteShardMetrics("a-shard")
+	c.Check(hist(metrics.GazetteConsumerTxMessages).GetSampleCount(), gc.Equals, uint64(0))
+}
+
 func (s *LifecycleSuite) TestTxnCancelledBeforeStart(c *gc.C) {
 	var r, cleanup = newLifecycleTestFixture(c)
 	defer cleanup()
@@ -591,7 +729,7 @@ func (s *LifecycleSuite) TestConsumeUpdatesPrimaryHints(c *gc.C) {
 	var hintsCh = make(chan time.Time, 1)
 
 	go func() {
-		c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, hintsCh), gc.Equals, context.Canceled)
+		c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.ks, msgCh, nil, hintsCh), gc.Equals, context.Canceled)
 	}()
 	// Precondition: recorded hints are not set.
 	c.Check(mustGet(c, r.etcd, r.spec.HintPrimaryKey()).Kvs, gc.HasLen, 0)
@@ -619,7 +757,7 @@ func (s *LifecycleSuite) TestConsumeErrorCases(c *gc.C) {
 	app.finalizeErr = errors.New("finalize error")
 
 	sendMsgFixture(msgCh, false, 100)
-	c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil),
+	c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.ks, msgCh, nil, nil),
 		gc.ErrorMatches, `txnStep: app.FinalizeTxn: finalize error`)
 
 	<-finishCh // Expect FinishTxn was still called and |finishCh| closed.
@@ -628,14 +766,14 @@ func (s *LifecycleSuite) TestConsumeErrorCases(c *gc.C) {
 	app.consumeErr = errors.New("consume error")
 
 	sendMsgFixture(msgCh, false, 100)
-	c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil),
+	c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.ks, msgCh, nil, nil),
 		gc.ErrorMatches, `txnStep: app.ConsumeMessage: consume error`)
 
 	// Case: BeginTxn fails.
 	app.beginErr = errors.New("begin error")
 
 	sendMsgFixture(msgCh, false, 100)
-	c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil),
+	c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.ks, msgCh, nil, nil),
 		gc.ErrorMatches, `txnStep: app.BeginTxn: begin error`)
 }
 
@@ -648,11 +786,32 @@ func (s *LifecycleSuite) TestPumpAndConsume(c *gc.C) {
 
 	go func() {
 		var src = r.spec.Sources[0]
-		c.Check(pumpMessages(r, r.app, src.Journal, src.MinOffset, msgCh), gc.Equals, context.Canceled)
+		c.Check(pumpMessages(r, r.app, src.Journal, src.MinOffset, nil, msgCh, nil), gc.Equals, context.Canceled)
+	}()
+
+	go func() {
+		c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.ks, msgCh, nil, nil), gc.Equals, context.Canceled)
+	}()
+
+	runSomeTransactions(c, r)
+}
+
+func (s *LifecycleSuite) TestPumpAndConsumeWithReadAhead(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	playAndComplete(c, r)
+	var msgCh = make(chan message.Envelope, 128)
+	// A single byte of read-ahead admits only one message at a time.
+	var ra = newReadAhead(1)
+
+	go func() {
+		var src = r.spec.Sources[0]
+		c.Check(pumpMessages(r, r.app, src.Journal, src.MinOffset, nil, msgCh, ra), gc.Equals, context.Canceled)
 	}()
 
 	go func() {
-		c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil), gc.Equals, context.Canceled)
+		c.Check(consumeMessages(r, r.store, r.app, r.etcd, r.ks, msgCh, ra, nil), gc.Equals, context.Canceled)
 	}()
 
 	runSomeTransactions(c, r)
@@ -832,6 +991,21 @@ func sendMsgAndWait(ta *testApplication, msgCh chan<- message.Envelope) {
 	<-finishCh
 }
 
+type schemaErrTestApplication struct {
+	*testApplication
+	cause error
+}
+
+func (a schemaErrTestApplication) NewMessage(*pb.JournalSpec) (message.Message, error) {
+	return &schemaErrMessage{cause: a.cause}, nil
+}
+
+type schemaErrMessage struct{ cause error }
+
+func (m *schemaErrMessage) UnmarshalJSON([]byte) error {
+	return pkgerrors.WithMessage(m.cause, "id 1234")
+}
+
 func faketime(delta int64) time.Time { return time.Unix(1500000000+delta, 0) }
 
 var _ = gc.Suite(&LifecycleSuite{})
@@ -0,0 +1,97 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// readAhead bounds the bytes of messages which have been read and decoded
+// from source journals, but not yet received by a consumer transaction.
+// Bytes are measured as journal offset deltas: pumpMessages acquires bytes
+// through the NextOffset of each message it reads, and the transaction
+// releases bytes through the NextOffset of each message it receives.
+// A nil *readAhead is unbounded.
+type readAhead struct {
+	max int64
+
+	mu       sync.Mutex
+	inFlight int64
+	offsets  map[pb.Journal]readAheadOffsets
+	notifyCh chan struct{} // Closed and replaced upon a release.
+}
+
+// readAheadOffsets are offsets through which bytes of a journal have been
+// acquired and released.
+type readAheadOffsets struct {
+	acquired, released int64
+}
+
+// newReadAhead returns a readAhead bounded to |max| bytes, or nil if |max| is zero.
+func newReadAhead(max int64) *readAhead {
+	if max == 0 {
+		return nil
+	}
+	return &readAhead{
+		max:      max,
+		offsets:  make(map[pb.Journal]readAheadOffsets),
+		notifyCh: make(chan struct{}),
+	}
+}
+
+// acquire bytes of |journal| through |next|, where |offset| is that of the
+// message which ends at |next|. It blocks until the bytes fit within the bound,
+// or until no other bytes are in flight (such that a message larger than the
+// bound may still be read), or until |ctx| is cancelled.
+func (r *readAhead) acquire(ctx context.Context, journal pb.Journal, offset, next int64) error {
+	if r == nil {
+		return nil
+	}
+	for {
+		r.mu.Lock()
+
+		var o, ok = r.offsets[journal]
+		if !ok {
+			o = readAheadOffsets{acquired: offset, released: offset}
+		}
+		// Bytes skipped by an offset jump are acquired with the message.
+		var n = next - o.acquired
+
+		if r.inFlight == 0 || r.inFlight+n <= r.max {
+			o.acquired = next
+			r.offsets[journal] = o
+			r.inFlight += n
+			r.mu.Unlock()
+			return nil
+		}
+		var ch = r.notifyCh
+		r.mu.Unlock()
+
+		select {
+		case <-ch: // Retry.
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// release bytes of |journal| through |next|.
+func (r *readAhead) release(journal pb.Journal, next int64) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var o, ok = r.offsets[journal]
+	if !ok || next <= o.released {
+		return
+	}
+	r.inFlight -= next - o.released
+	o.released = next
+	r.offsets[journal] = o
+
+	close(r.notifyCh)
+	r.notifyCh = make(chan struct{})
+}
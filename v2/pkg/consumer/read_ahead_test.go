@@ -0,0 +1,61 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	gc "github.com/go-check/check"
+)
+
+type ReadAheadSuite struct{}
+
+func (s *ReadAheadSuite) TestAcquireAndRelease(c *gc.C) {
+	var ra = newReadAhead(100)
+
+	// Acquire bytes of two journals, up to the bound.
+	c.Check(ra.acquire(context.Background(), "a/journal", 1000, 1060), gc.IsNil)
+	c.Check(ra.acquire(context.Background(), "b/journal", 0, 40), gc.IsNil)
+	c.Check(ra.inFlight, gc.Equals, int64(100))
+
+	// Further acquisitions block until bytes are released.
+	var doneCh = make(chan error)
+	go func() { doneCh <- ra.acquire(context.Background(), "a/journal", 1070, 1090) }()
+
+	select {
+	case <-doneCh:
+		c.Error("expected acquire to block")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	ra.release("b/journal", 40)
+	c.Check(<-doneCh, gc.IsNil)
+	// Bytes 1060-1070 (eg, an offset jump) are acquired with the message.
+	c.Check(ra.inFlight, gc.Equals, int64(90))
+
+	// Released bytes of a journal include all messages through the offset.
+	ra.release("a/journal", 1090)
+	c.Check(ra.inFlight, gc.Equals, int64(0))
+	// Repeated or unknown releases are ignored.
+	ra.release("a/journal", 1060)
+	ra.release("c/journal", 1234)
+	c.Check(ra.inFlight, gc.Equals, int64(0))
+
+	// A message larger than the bound is acquired if no other bytes are in flight.
+	c.Check(ra.acquire(context.Background(), "c/journal", 0, 500), gc.IsNil)
+	c.Check(ra.inFlight, gc.Equals, int64(500))
+
+	// A blocked acquisition returns upon context cancellation.
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	c.Check(ra.acquire(ctx, "a/journal", 1090, 1091), gc.Equals, context.Canceled)
+}
+
+func (s *ReadAheadSuite) TestNilIsUnbounded(c *gc.C) {
+	var ra = newReadAhead(0)
+	c.Check(ra, gc.IsNil)
+
+	c.Check(ra.acquire(context.Background(), "a/journal", 0, 1<<40), gc.IsNil)
+	ra.release("a/journal", 1<<40)
+}
+
+var _ = gc.Suite(&ReadAheadSuite{})
@@ -10,6 +10,7 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/client"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	"github.com/LiveRamp/gazette/v2/pkg/message"
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
 	"github.com/coreos/etcd/clientv3"
@@ -20,9 +21,10 @@ import (
 const (
 	// Frequency with which current FSM hints are written to Etcd.
 	storeHintsInterval = 5 * time.Minute
-	// Size of the channel used between message decode & consumption. Needs to
-	// be rather large, to minimize processing stalls. The current value will
-	// tolerate a data delay of up to 82ms @ 100K messages / sec without stalling.
+	// Default size of the channel used between message decode & consumption,
+	// where not set by ShardSpec.ReadAheadMessages. Needs to be rather large,
+	// to minimize processing stalls. The current value will tolerate a data
+	// delay of up to 82ms @ 100K messages / sec without stalling.
 	messageBufferSize = 1 << 13 // 8192.
 )
 
@@ -98,10 +100,10 @@ var transition = func(r *Replica, spec *ShardSpec, assignment keyspace.KeyValue)
 
 	if r.spec == nil && !isSlot0 {
 		r.wg.Add(1) // Transition initial => standby.
-		go r.serveStandby()
+		go r.serveStandby(true)
 	} else if r.spec == nil && isSlot0 {
 		r.wg.Add(2) // Transition initial => primary.
-		go r.serveStandby()
+		go r.serveStandby(false)
 		go r.servePrimary()
 	} else if r.spec != nil && isSlot0 && !wasSlot0 {
 		r.wg.Add(1) // Transition standby => primary.
@@ -111,10 +113,20 @@ var transition = func(r *Replica, spec *ShardSpec, assignment keyspace.KeyValue)
 }
 
 // serveStandby recovers and tails the shard recovery log, until the Replica is
-// cancelled or promoted to primary.
-func (r *Replica) serveStandby() {
+// cancelled or promoted to primary. If |isStandby|, the Replica was assigned
+// as a standby (rather than directly as primary), and the Application is
+// notified of its transition.
+func (r *Replica) serveStandby(isStandby bool) {
 	defer r.wg.Done()
 
+	if st, ok := r.app.(ShardTransitioner); ok && isStandby {
+		if err := st.BecomeStandby(r); err != nil {
+			err = r.logFailure(extendErr(err, "app.BecomeStandby"))
+			tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
+			return
+		}
+	}
+
 	go func() {
 		tryUpdateStatus(r, r.ks, r.etcd, ReplicaStatus{Code: ReplicaStatus_BACKFILL})
 
@@ -138,24 +150,47 @@ func (r *Replica) serveStandby() {
 func (r *Replica) servePrimary() {
 	defer r.wg.Done()
 
+	var began = time.Now()
 	var store, offsets, err = completePlayback(r, r.app, r.player, r.etcd)
 	if err != nil {
 		err = r.logFailure(extendErr(err, "completePlayback"))
 		tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
 		return
 	}
+	metrics.GazetteConsumerRecoverySeconds.WithLabelValues(r.Spec().Id.String()).
+		Observe(time.Since(began).Seconds())
 
 	r.store = store
+
+	if st, ok := r.app.(ShardTransitioner); ok {
+		if err = st.BecomePrimary(r, store); err != nil {
+			err = r.logFailure(extendErr(err, "app.BecomePrimary"))
+			tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
+			return
+		}
+	}
+	frontiers, err := fetchMergeFrontiers(store)
+	if err != nil {
+		err = r.logFailure(extendErr(err, "fetchMergeFrontiers"))
+		tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
+		return
+	}
 	close(r.storeReadyCh)
 	tryUpdateStatus(r, r.ks, r.etcd, ReplicaStatus{Code: ReplicaStatus_PRIMARY})
 
-	// Spawn service loops to read & decode messages.
-	var msgCh = make(chan message.Envelope, messageBufferSize)
+	// Spawn service loops to read & decode messages, bounded in the number and
+	// bytes of messages read ahead of the consuming transaction.
+	var bufferSize = messageBufferSize
+	if n := r.Spec().ReadAheadMessages; n != 0 {
+		bufferSize = int(n)
+	}
+	var msgCh = make(chan message.Envelope, bufferSize)
+	var ra = newReadAhead(r.Spec().ReadAheadBytes)
 
 	for _, src := range r.Spec().Sources {
 		r.wg.Add(1)
 		go func(journal pb.Journal, offset int64) {
-			if err := pumpMessages(r, r.app, journal, offset, msgCh); err != nil {
+			if err := pumpMessages(r, r.app, journal, offset, frontiers, msgCh, ra); err != nil {
 				err = r.logFailure(extendErr(err, "pumpMessages"))
 				tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
 			}
@@ -167,7 +202,7 @@ func (r *Replica) servePrimary() {
 	defer hintsTicker.Stop()
 
 	// Consume messages from |msgCh| until an error occurs (such as context.Cancelled).
-	if err = consumeMessages(r, r.store, r.app, r.etcd, msgCh, hintsTicker.C); err != nil {
+	if err = consumeMessages(r, r.store, r.app, r.etcd, r.ks, msgCh, ra, hintsTicker.C); err != nil {
 		err = r.logFailure(extendErr(err, "consumeMessages"))
 		tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
 	}
@@ -180,9 +215,18 @@ func (r *Replica) waitAndTearDown(done func()) {
 	r.wg.Wait()
 	client.WaitForPendingAppends(r.journalClient.PendingExcept(""))
 
+	if st, ok := r.app.(ShardTransitioner); ok {
+		if err := st.Shutdown(r, r.store); err != nil {
+			log.WithFields(log.Fields{
+				"err":   err,
+				"shard": r.Spec().Id,
+			}).Warn("app.Shutdown failed")
+		}
+	}
 	if r.store != nil {
 		r.store.Destroy()
 	}
+	deleteShardMetrics(r.Spec().Id.String())
 	done()
 }
 
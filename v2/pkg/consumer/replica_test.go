@@ -3,6 +3,7 @@ package consumer
 import (
 	"context"
 	"errors"
+	"time"
 
 	gc "github.com/go-check/check"
 )
@@ -55,6 +56,39 @@ func (s *ReplicaSuite) TestDirectToPrimaryTransition(c *gc.C) {
 	tf.allocateShard(c, makeShard(shardA)) // Cleanup.
 }
 
+func (s *ReplicaSuite) TestPauseAndResume(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var spec = makeShard(shardA)
+	spec.Pause = true
+	tf.allocateShard(c, spec, localID)
+
+	// A paused shard is still assigned and becomes PRIMARY.
+	expectStatusCode(c, tf.state, ReplicaStatus_PRIMARY)
+
+	var res, err = tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardA})
+	c.Check(err, gc.IsNil)
+	defer res.Done()
+
+	var doneCh = make(chan error)
+	go func() { doneCh <- waitWhilePaused(res.Shard, tf.ks) }()
+
+	select {
+	case <-doneCh:
+		c.Error("expected waitWhilePaused to block")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Resume the shard. Expect transactions now proceed.
+	tf.allocateShard(c, makeShard(shardA), localID)
+	c.Check(<-doneCh, gc.IsNil)
+
+	runSomeTransactions(c, res.Shard)
+
+	tf.allocateShard(c, makeShard(shardA)) // Cleanup.
+}
+
 func (s *ReplicaSuite) TestPlayRecoveryLogError(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -83,6 +117,52 @@ func (s *ReplicaSuite) TestCompletePlaybackError(c *gc.C) {
 	tf.allocateShard(c, makeShard(shardA)) // Cleanup.
 }
 
+func (s *ReplicaSuite) TestBecomePrimaryError(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	tf.app.becomePrimaryErr = errors.New("an error")
+	tf.allocateShard(c, makeShard(shardA), localID)
+
+	c.Check(expectStatusCode(c, tf.state, ReplicaStatus_FAILED).Errors[0],
+		gc.Matches, `app.BecomePrimary: an error`)
+
+	tf.allocateShard(c, makeShard(shardA)) // Cleanup.
+}
+
+func (s *ReplicaSuite) TestTransitionHooks(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var expect = func(transitions ...string) {
+		for {
+			tf.app.transitionsMu.Lock()
+			var actual = append([]string(nil), tf.app.transitions...)
+			tf.app.transitionsMu.Unlock()
+
+			if len(actual) >= len(transitions) {
+				c.Check(actual, gc.DeepEquals, transitions)
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// Standby which is removed.
+	tf.allocateShard(c, makeShard(shardA), remoteID, localID)
+	expectStatusCode(c, tf.state, ReplicaStatus_TAILING)
+	tf.allocateShard(c, makeShard(shardA))
+	expect("standby", "shutdown (store: false)")
+
+	// Standby which is promoted to primary, and then removed.
+	tf.allocateShard(c, makeShard(shardA), remoteID, localID)
+	expectStatusCode(c, tf.state, ReplicaStatus_TAILING)
+	tf.allocateShard(c, makeShard(shardA), localID)
+	expectStatusCode(c, tf.state, ReplicaStatus_PRIMARY)
+	tf.allocateShard(c, makeShard(shardA))
+	expect("standby", "shutdown (store: false)", "standby", "primary", "shutdown (store: true)")
+}
+
 func (s *ReplicaSuite) TestPumpMessagesError(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
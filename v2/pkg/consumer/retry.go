@@ -0,0 +1,174 @@
+package consumer
+
+import (
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// RetryPolicy configures the re-consumption of a message for which
+// ConsumeMessage returns a Retryable error. Retries happen within the
+// current consumer transaction, after a backoff which doubles with each
+// attempt.
+type RetryPolicy struct {
+	// Maximum number of attempts to consume a message. Values less than one
+	// are treated as one (eg, no retries).
+	MaxAttempts int
+	// Backoff prior to the second attempt. Each further attempt doubles the
+	// backoff of its prior attempt.
+	Backoff time.Duration
+	// MaxBackoff bounds the backoff prior to an attempt. If zero, backoff is
+	// not bounded.
+	MaxBackoff time.Duration
+}
+
+// WithRetries returns an Application which wraps |app|. A message for which
+// ConsumeMessage returns a Retryable error is consumed again as per the
+// RetryPolicy. If it continues to fail, the error of its final attempt is
+// returned, and fails the Shard. Applications which would instead route such
+// messages to a dead-letter Journal should use WithDeadLetters.
+func WithRetries(app Application, policy RetryPolicy) Application {
+	return retryApp{delegatingApp: delegatingApp{app}, policy: policy}
+}
+
+type retryApp struct {
+	delegatingApp
+	policy RetryPolicy
+}
+
+// ConsumeMessage delegates to the wrapped Application, retrying Retryable errors.
+func (a retryApp) ConsumeMessage(shard Shard, store Store, env message.Envelope) error {
+	var attempts, err = a.policy.consume(a.Application, shard, store, env)
+	if err != nil && attempts > 1 {
+		err = extendErr(err, "after %d attempts", attempts)
+	}
+	return err
+}
+
+// consume |env| using |app|, retrying Retryable errors as per the RetryPolicy.
+// It returns the number of attempts made, and the error of the final attempt.
+func (p RetryPolicy) consume(app Application, shard Shard, store Store, env message.Envelope) (attempt int, err error) {
+	for attempt = 1; ; attempt++ {
+		if err = app.ConsumeMessage(shard, store, env); err == nil || !IsRetryable(err) {
+			return
+		} else if attempt >= p.MaxAttempts {
+			return
+		}
+		var delay = p.backoff(attempt)
+
+		log.WithFields(log.Fields{
+			"shard":   shard.Spec().Id,
+			"journal": env.JournalSpec.Name,
+			"offset":  env.NextOffset,
+			"attempt": attempt,
+			"backoff": delay,
+			"err":     err,
+		}).Warn("retrying failed message")
+
+		select {
+		case <-time.After(delay):
+		case <-shard.Context().Done():
+			return attempt, shard.Context().Err()
+		}
+	}
+}
+
+// backoff returns the delay to apply after failed attempt |attempt|.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	var d = p.Backoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff != 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// delegatingApp wraps an Application, and delegates optional Application
+// interfaces to the wrapped Application if it implements them.
+type delegatingApp struct {
+	Application
+}
+
+// unwrap returns the wrapped Application.
+func (a delegatingApp) unwrap() Application { return a.Application }
+
+// asKeyedApplication returns |app| as a KeyedApplication, if it is one. An
+// Application which wraps another is keyed only if the wrapped one is.
+func asKeyedApplication(app Application) (KeyedApplication, bool) {
+	for {
+		if w, ok := app.(interface{ unwrap() Application }); ok {
+			app = w.unwrap()
+		} else {
+			break
+		}
+	}
+	var keyed, ok = app.(KeyedApplication)
+	return keyed, ok
+}
+
+// BeginTxn delegates to the wrapped Application, if it's a BeginFinisher.
+func (a delegatingApp) BeginTxn(shard Shard, store Store) error {
+	if bf, ok := a.Application.(BeginFinisher); ok {
+		return bf.BeginTxn(shard, store)
+	}
+	return nil
+}
+
+// FinishTxn delegates to the wrapped Application, if it's a BeginFinisher.
+func (a delegatingApp) FinishTxn(shard Shard, store Store, err error) error {
+	if bf, ok := a.Application.(BeginFinisher); ok {
+		return bf.FinishTxn(shard, store, err)
+	}
+	return nil
+}
+
+// OnTimer delegates to the wrapped Application, if it's a TimerApplication.
+func (a delegatingApp) OnTimer(shard Shard, store Store, timer Timer) error {
+	if ta, ok := a.Application.(TimerApplication); ok {
+		return ta.OnTimer(shard, store, timer)
+	}
+	return nil
+}
+
+// BecomeStandby delegates to the wrapped Application, if it's a ShardTransitioner.
+func (a delegatingApp) BecomeStandby(shard Shard) error {
+	if st, ok := a.Application.(ShardTransitioner); ok {
+		return st.BecomeStandby(shard)
+	}
+	return nil
+}
+
+// BecomePrimary delegates to the wrapped Application, if it's a ShardTransitioner.
+func (a delegatingApp) BecomePrimary(shard Shard, store Store) error {
+	if st, ok := a.Application.(ShardTransitioner); ok {
+		return st.BecomePrimary(shard, store)
+	}
+	return nil
+}
+
+// Shutdown delegates to the wrapped Application, if it's a ShardTransitioner.
+func (a delegatingApp) Shutdown(shard Shard, store Store) error {
+	if st, ok := a.Application.(ShardTransitioner); ok {
+		return st.Shutdown(shard, store)
+	}
+	return nil
+}
+
+// MessageKey delegates to the wrapped Application, which must be a
+// KeyedApplication (see asKeyedApplication).
+func (a delegatingApp) MessageKey(msg message.Message, b []byte) []byte {
+	return a.Application.(KeyedApplication).MessageKey(msg, b)
+}
+
+// MergeStore delegates to the wrapped Application, if it's a ShardMerger.
+func (a delegatingApp) MergeStore(shard Shard, store Store, dir string) (map[pb.Journal]int64, error) {
+	if sm, ok := a.Application.(ShardMerger); ok {
+		return sm.MergeStore(shard, store, dir)
+	}
+	return nil, errors.New("Application is not a ShardMerger, and cannot merge a seed")
+}
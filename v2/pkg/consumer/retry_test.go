@@ -0,0 +1,79 @@
+package consumer
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type RetrySuite struct{}
+
+func (s *RetrySuite) TestBackoff(c *gc.C) {
+	var p = RetryPolicy{Backoff: time.Second}
+	c.Check(p.backoff(1), gc.Equals, time.Second)
+	c.Check(p.backoff(2), gc.Equals, 2*time.Second)
+	c.Check(p.backoff(4), gc.Equals, 8*time.Second)
+
+	p.MaxBackoff = 5 * time.Second
+	c.Check(p.backoff(3), gc.Equals, 4*time.Second)
+	c.Check(p.backoff(4), gc.Equals, 5*time.Second)
+	c.Check(p.backoff(100), gc.Equals, 5*time.Second)
+}
+
+func (s *RetrySuite) TestRetriesOfFailingMessages(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	playAndComplete(c, r)
+
+	var testApp = r.app.(*testApplication)
+	var app = WithRetries(testApp, RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond})
+	var env = message.Envelope{
+		JournalSpec: &pb.JournalSpec{Name: sourceA},
+		NextOffset:  1234,
+		Message:     &testMessage{Key: "foo", Value: "bar"},
+	}
+
+	// Case: ConsumeMessage succeeds.
+	c.Check(app.ConsumeMessage(r, r.store, env), gc.IsNil)
+
+	// Case: ConsumeMessage fails with a non-retryable error, which is returned.
+	testApp.consumeErr = errors.New("fatal error")
+	c.Check(app.ConsumeMessage(r, r.store, env), gc.ErrorMatches, "fatal error")
+
+	// Case: ConsumeMessage fails with a retryable error, and then succeeds.
+	testApp.consumeErr = nil
+	var retryApp = app.(retryApp)
+	retryApp.Application = &flakyApp{testApplication: testApp, failures: 2}
+	c.Check(retryApp.ConsumeMessage(r, r.store, env), gc.IsNil)
+
+	// Case: ConsumeMessage continues to fail with a retryable error.
+	testApp.consumeErr = Retryable(errors.New("retryable error"))
+	var err = app.ConsumeMessage(r, r.store, env)
+	c.Check(err, gc.ErrorMatches, "after 3 attempts: retryable error")
+	c.Check(IsRetryable(err), gc.Equals, true)
+
+	// Case: the Shard is cancelled while backing off.
+	app = WithRetries(testApp, RetryPolicy{MaxAttempts: 3, Backoff: time.Hour})
+	r.cancel()
+	c.Check(app.ConsumeMessage(r, r.store, env), gc.Equals, r.ctx.Err())
+}
+
+// flakyApp fails ConsumeMessage with a Retryable error |failures| times.
+type flakyApp struct {
+	*testApplication
+	failures int
+}
+
+func (a *flakyApp) ConsumeMessage(shard Shard, store Store, env message.Envelope) error {
+	if a.failures != 0 {
+		a.failures--
+		return Retryable(errors.New("flaky error"))
+	}
+	return a.testApplication.ConsumeMessage(shard, store, env)
+}
+
+var _ = gc.Suite(&RetrySuite{})
@@ -0,0 +1,160 @@
+package consumer
+
+import (
+	"encoding/json"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	"github.com/pkg/errors"
+)
+
+// Sequence identifies a message published by a Shard. Messages published by
+// a Shard are stamped with increasing sequence numbers, which resume from the
+// last committed consumer transaction upon the Shard's recovery. Should a
+// recovered Shard re-publish messages of a transaction which failed to commit,
+// they're stamped with the same sequence numbers as before, and a reader may
+// thereby discard them as duplicates.
+//
+// Sequence may be embedded within a Message type, which then implements
+// SequencedMessage.
+type Sequence struct {
+	// Producer is the ID of the publishing Shard.
+	Producer ShardID `json:"producer,omitempty"`
+	// Epoch of the publishing Shard, which increases with each assignment of
+	// the Shard's primary. Messages published by a Shard primary which has
+	// since been superseded have a lesser Epoch, and are discarded by readers
+	// which have observed the greater Epoch.
+	Epoch int64 `json:"epoch,omitempty"`
+	// Seq is the sequence number of the message.
+	Seq int64 `json:"seq,omitempty"`
+}
+
+// GetSequence returns the Sequence.
+func (s *Sequence) GetSequence() Sequence { return *s }
+
+// SetSequence sets the Sequence to |seq|.
+func (s *Sequence) SetSequence(seq Sequence) { *s = seq }
+
+// SequencedMessage is an optional interface of a Message which is stamped
+// with a Sequence upon being published by PublishSequenced.
+type SequencedMessage interface {
+	message.Message
+	// GetSequence returns the Sequence of the message.
+	GetSequence() Sequence
+	// SetSequence stamps the message with a Sequence.
+	SetSequence(Sequence)
+}
+
+// Sequencer stamps messages published by a Shard with Sequences, and
+// discards duplicate SequencedMessages read by a Shard. It's not safe for
+// concurrent use. It's intended to be held by a SequencerStore, and to be
+// used by Applications only from within ConsumeMessage, OnTimer, and
+// FinalizeTxn.
+//
+// Where a Shard's Store is a SequencerStore, its consumed SequencedMessages
+// are deduplicated automatically: a message having a Sequence which was
+// already read from its Producer, or having an Epoch prior to one already
+// read, is not passed to ConsumeMessage. Offsets of the message are still
+// checkpointed.
+type Sequencer struct {
+	producer  ShardID
+	epoch     int64
+	seq       int64
+	producers map[ShardID]Sequence
+}
+
+// NewSequencer returns an empty Sequencer.
+func NewSequencer() *Sequencer {
+	return &Sequencer{producers: make(map[ShardID]Sequence)}
+}
+
+// Stamp |msg| with the next Sequence of the Shard.
+func (s *Sequencer) Stamp(msg SequencedMessage) {
+	s.seq++
+	msg.SetSequence(Sequence{Producer: s.producer, Epoch: s.epoch, Seq: s.seq})
+}
+
+// Dedup returns true if |msg| is a duplicate of, or was published by a
+// superseded primary of, a SequencedMessage already passed to Dedup. If not,
+// the Sequence of |msg| is retained. Messages which aren't SequencedMessages,
+// or which are un-stamped, are never duplicates.
+func (s *Sequencer) Dedup(msg message.Message) bool {
+	var sm, ok = msg.(SequencedMessage)
+	if !ok {
+		return false
+	}
+	var seq = sm.GetSequence()
+	if seq.Producer == "" {
+		return false
+	}
+	var last = s.producers[seq.Producer]
+
+	if seq.Epoch < last.Epoch {
+		return true // Published by a superseded primary.
+	} else if seq.Epoch > last.Epoch {
+		// A new primary of the producer. It may re-publish messages of a
+		// failed transaction, which we've already read.
+		last.Epoch = seq.Epoch
+		s.producers[seq.Producer] = last
+	}
+	if seq.Seq <= last.Seq {
+		return true
+	}
+	s.producers[seq.Producer] = seq
+	return false
+}
+
+// begin publishing as Shard |producer| of |epoch|.
+func (s *Sequencer) begin(producer ShardID, epoch int64) {
+	s.producer, s.epoch = producer, epoch
+}
+
+// MarshalJSON encodes the Sequencer, in a form suited for persistence by a SequencerStore.
+func (s *Sequencer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sequencerJSON{Seq: s.seq, Producers: s.producers})
+}
+
+// UnmarshalJSON decodes a Sequencer previously encoded by MarshalJSON.
+func (s *Sequencer) UnmarshalJSON(b []byte) error {
+	var doc sequencerJSON
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	if doc.Producers == nil {
+		doc.Producers = make(map[ShardID]Sequence)
+	}
+	s.seq, s.producers = doc.Seq, doc.Producers
+	return nil
+}
+
+// PublishSequenced stamps |msg| with the next Sequence of the |shard|, which
+// must have a SequencerStore, and publishes it via message.Publish.
+func PublishSequenced(shard Shard, store Store, mapping message.MappingFunc, msg SequencedMessage) (*client.AsyncAppend, error) {
+	var ss, ok = store.(SequencerStore)
+	if !ok {
+		return nil, errors.New("Store is not a SequencerStore")
+	}
+	ss.Sequencer().Stamp(msg)
+	return message.Publish(shard.JournalClient(), mapping, msg)
+}
+
+// beginSequencer begins the Sequencer of |store| for the current assignment
+// of |shard|, if |store| is a SequencerStore. The Epoch is the Etcd
+// CreateRevision of the assignment, which increases with each new primary.
+func beginSequencer(shard Shard, store Store) {
+	if ss, ok := store.(SequencerStore); ok {
+		ss.Sequencer().begin(shard.Spec().Id, shard.Assignment().Raw.CreateRevision)
+	}
+}
+
+// isDuplicate returns true if |store| is a SequencerStore, and its Sequencer
+// deems |msg| to be a duplicate.
+func isDuplicate(store Store, msg message.Message) bool {
+	var ss, ok = store.(SequencerStore)
+	return ok && ss.Sequencer().Dedup(msg)
+}
+
+type sequencerJSON struct {
+	Seq       int64                `json:"seq"`
+	Producers map[ShardID]Sequence `json:"producers,omitempty"`
+}
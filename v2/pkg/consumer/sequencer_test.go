@@ -0,0 +1,81 @@
+package consumer
+
+import (
+	"encoding/json"
+
+	gc "github.com/go-check/check"
+)
+
+type SequencerSuite struct{}
+
+func (s *SequencerSuite) TestStampAndDedup(c *gc.C) {
+	var pub, sub = NewSequencer(), NewSequencer()
+	pub.begin("producer", 10)
+
+	var stamp = func() *sequencedTestMessage {
+		var msg = new(sequencedTestMessage)
+		pub.Stamp(msg)
+		return msg
+	}
+	var m1, m2 = stamp(), stamp()
+	c.Check(m2.GetSequence(), gc.Equals, Sequence{Producer: "producer", Epoch: 10, Seq: 2})
+
+	c.Check(sub.Dedup(m1), gc.Equals, false)
+	c.Check(sub.Dedup(m2), gc.Equals, false)
+	c.Check(sub.Dedup(m1), gc.Equals, true) // Repeated.
+	c.Check(sub.Dedup(m2), gc.Equals, true)
+
+	// Un-stamped, and non-sequenced messages are never duplicates.
+	c.Check(sub.Dedup(new(sequencedTestMessage)), gc.Equals, false)
+	c.Check(sub.Dedup(&testMessage{}), gc.Equals, false)
+
+	// Model a failed transaction: |pub| stamps a message which |sub| reads,
+	// but |pub| fails before its Sequencer is persisted.
+	b, err := json.Marshal(pub)
+	c.Assert(err, gc.IsNil)
+	var m3 = stamp()
+	c.Check(sub.Dedup(m3), gc.Equals, false)
+
+	// A new primary of the producer recovers the Sequencer, and re-publishes.
+	var next = NewSequencer()
+	c.Assert(json.Unmarshal(b, next), gc.IsNil)
+	next.begin("producer", 12)
+
+	var m3r = new(sequencedTestMessage)
+	next.Stamp(m3r)
+	c.Check(m3r.GetSequence(), gc.Equals, Sequence{Producer: "producer", Epoch: 12, Seq: 3})
+	c.Check(sub.Dedup(m3r), gc.Equals, true) // Re-published m3.
+
+	var m4 = new(sequencedTestMessage)
+	next.Stamp(m4)
+	c.Check(sub.Dedup(m4), gc.Equals, false)
+
+	// The prior primary continues to publish, but has been superseded.
+	c.Check(sub.Dedup(stamp()), gc.Equals, true)
+	c.Check(sub.Dedup(stamp()), gc.Equals, true)
+}
+
+func (s *SequencerSuite) TestJSONRoundTrip(c *gc.C) {
+	var seq = NewSequencer()
+	seq.begin("producer", 10)
+	seq.Stamp(new(sequencedTestMessage))
+	c.Check(seq.Dedup(&sequencedTestMessage{Sequence: Sequence{Producer: "other", Epoch: 3, Seq: 5}}), gc.Equals, false)
+
+	var b, err = json.Marshal(seq)
+	c.Assert(err, gc.IsNil)
+
+	var out = NewSequencer()
+	c.Assert(json.Unmarshal(b, out), gc.IsNil)
+
+	c.Check(out.seq, gc.Equals, int64(1))
+	c.Check(out.producers, gc.DeepEquals, map[ShardID]Sequence{
+		"other": {Producer: "other", Epoch: 3, Seq: 5},
+	})
+}
+
+type sequencedTestMessage struct {
+	Sequence
+	Value string
+}
+
+var _ = gc.Suite(&SequencerSuite{})
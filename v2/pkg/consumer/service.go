@@ -6,7 +6,6 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
-	"golang.org/x/net/trace"
 	"google.golang.org/grpc"
 )
 
@@ -49,7 +48,5 @@ func (svc *Service) Watch(ctx context.Context) error {
 }
 
 func addTrace(ctx context.Context, format string, args ...interface{}) {
-	if tr, ok := trace.FromContext(ctx); ok {
-		tr.LazyPrintf(format, args...)
-	}
+	pb.AddTrace(ctx, format, args...)
 }
@@ -2,12 +2,15 @@ package consumer
 
 import (
 	"context"
-	"errors"
 	"strings"
+	"sync"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/coreos/etcd/clientv3"
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
 
@@ -43,9 +46,43 @@ func (srv *Service) Stat(ctx context.Context, req *StatRequest) (*StatResponse,
 		var txn = res.Store.Recorder().WeakBarrier()
 		_, err = <-txn.Done(), txn.Err()
 	}
+	if err == nil {
+		resp.SourceLags, err = fetchSourceLags(ctx, res.Shard.JournalClient(), res.Spec, resp.Offsets)
+	}
 	return resp, err
 }
 
+// fetchSourceLags returns SourceLags of each source of |spec|, given the
+// journal |offsets| read by the shard.
+func fetchSourceLags(ctx context.Context, rjc pb.RoutedJournalClient, spec *ShardSpec,
+	offsets map[pb.Journal]int64) ([]SourceLag, error) {
+
+	var out []SourceLag
+	for _, src := range spec.Sources {
+		var lag = SourceLag{Journal: src.Journal, ReadOffset: offsets[src.Journal]}
+		if lag.ReadOffset < src.MinOffset {
+			lag.ReadOffset = src.MinOffset
+		}
+
+		// Determine the journal write head via a non-blocking read at offset -1.
+		var r = client.NewReader(ctx, rjc, pb.ReadRequest{
+			Journal: src.Journal,
+			Offset:  -1,
+			Block:   false,
+		})
+		if _, err := r.Read(nil); err != client.ErrOffsetNotYetAvailable {
+			return nil, extendErr(err, "fetching write head of %s", src.Journal)
+		}
+		lag.WriteHead = r.Response.WriteHead
+
+		if lag.WriteHead > lag.ReadOffset {
+			lag.Lag = lag.WriteHead - lag.ReadOffset
+		}
+		out = append(out, lag)
+	}
+	return out, nil
+}
+
 // List dispatches the ShardServer.List API.
 func (srv *Service) List(ctx context.Context, req *ListRequest) (*ListResponse, error) {
 	var s = srv.Resolver.state
@@ -58,7 +95,6 @@ func (srv *Service) List(ctx context.Context, req *ListRequest) (*ListResponse,
 		return resp, err
 	}
 
-	defer s.KS.Mu.RUnlock()
 	s.KS.Mu.RLock()
 
 	var metaLabels, allLabels pb.LabelSet
@@ -93,9 +129,47 @@ func (srv *Service) List(ctx context.Context, req *ListRequest) (*ListResponse,
 
 		resp.Shards = append(resp.Shards, shard)
 	}
+	s.KS.Mu.RUnlock()
+
+	if !req.IncludeSourceLags {
+		return resp, nil
+	}
+	// Concurrently Stat each shard having a primary. Stat of a remote shard is
+	// proxied to its primary. A shard which fails to Stat is logged and listed
+	// without SourceLags, such that monitors (eg, lag exporters) observe all
+	// other shards.
+	var sem = make(chan struct{}, listStatConcurrency)
+	var wg sync.WaitGroup
+
+	for i := range resp.Shards {
+		var shard = &resp.Shards[i]
+		if shard.Route.Primary == -1 {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+
+			var stat, err = srv.Stat(ctx, &StatRequest{Shard: shard.Spec.Id})
+			if err != nil {
+				log.WithFields(log.Fields{"shard": shard.Spec.Id, "err": err}).
+					Warn("failed to Stat shard for SourceLags")
+			} else if stat.Status == Status_OK {
+				shard.SourceLags = stat.SourceLags
+			}
+		}()
+	}
+	wg.Wait()
+
 	return resp, nil
 }
 
+// listStatConcurrency is the maximum number of concurrent shard Stats
+// of a List RPC which includes SourceLags.
+const listStatConcurrency = 16
+
 // Apply dispatches the ShardServer.Apply API.
 func (srv *Service) Apply(ctx context.Context, req *ApplyRequest) (*ApplyResponse, error) {
 	var s = srv.Resolver.state
@@ -106,6 +180,8 @@ func (srv *Service) Apply(ctx context.Context, req *ApplyRequest) (*ApplyRespons
 	}
 	if err := req.Validate(); err != nil {
 		return resp, err
+	} else if err = validatePlacements(s.KS, req.Changes); err != nil {
+		return resp, err
 	}
 
 	var cmp []clientv3.Cmp
@@ -138,6 +214,23 @@ func (srv *Service) Apply(ctx context.Context, req *ApplyRequest) (*ApplyRespons
 	return resp, err
 }
 
+// validatePlacements returns an error if a ShardSpec upserted by |changes|
+// has a Placement which matches no current consumer.
+func validatePlacements(ks *keyspace.KeySpace, changes []ApplyRequest_Change) error {
+	defer ks.Mu.RUnlock()
+	ks.Mu.RLock()
+
+	for i, change := range changes {
+		if change.Upsert == nil || change.Upsert.Placement.IsEmpty() {
+			continue
+		} else if !allocator.HasPlaceableMember(ks, change.Upsert) {
+			return pb.ExtendContext(pb.NewValidationError("no consumers match selector (%s)",
+				change.Upsert.Placement.String()), "Changes[%d].Upsert.Placement", i)
+		}
+	}
+	return nil
+}
+
 // GetHints dispatches the ShardServer.Hints API.
 func (srv *Service) GetHints(ctx context.Context, req *GetHintsRequest) (*GetHintsResponse, error) {
 	var (
@@ -186,7 +279,7 @@ func ListShards(ctx context.Context, sc ShardClient, req *ListRequest) (*ListRes
 	} else if err = r.Validate(); err != nil {
 		return r, err
 	} else if r.Status != Status_OK {
-		return r, errors.New(r.Status.String())
+		return r, StatusError(r.Status)
 	} else {
 		return r, nil
 	}
@@ -200,7 +293,7 @@ func StatShard(ctx context.Context, rc RoutedShardClient, req *StatRequest) (*St
 	} else if err = r.Validate(); err != nil {
 		return r, err
 	} else if r.Status != Status_OK {
-		return r, errors.New(r.Status.String())
+		return r, StatusError(r.Status)
 	} else {
 		return r, nil
 	}
@@ -243,7 +336,7 @@ func ApplyShardsInBatches(ctx context.Context, sc ShardClient, req *ApplyRequest
 		} else if err = resp.Validate(); err != nil {
 			return resp, err
 		} else if resp.Status != Status_OK {
-			return resp, errors.New(resp.Status.String())
+			return resp, StatusError(resp.Status)
 		}
 
 		offset = offset + len(curReq.Changes)
@@ -260,8 +353,23 @@ func FetchHints(ctx context.Context, sc ShardClient, req *GetHintsRequest) (*Get
 	} else if err = r.Validate(); err != nil {
 		return r, err
 	} else if r.Status != Status_OK {
-		return r, errors.New(r.Status.String())
+		return r, StatusError(r.Status)
 	} else {
 		return r, nil
 	}
 }
+
+// StatusError is a non-OK shard API response Status, returned as an error.
+// It's equal to the corresponding named error (eg, ErrShardNotFound), and
+// err.(StatusError) yields the Status itself.
+type StatusError Status
+
+func (s StatusError) Error() string { return Status(s).String() }
+
+var (
+	// Map shard API error statuses into named errors.
+	ErrShardNotFound         error = StatusError(Status_SHARD_NOT_FOUND)
+	ErrNoShardPrimary        error = StatusError(Status_NO_SHARD_PRIMARY)
+	ErrNotShardPrimary       error = StatusError(Status_NOT_SHARD_PRIMARY)
+	ErrEtcdTransactionFailed error = StatusError(Status_ETCD_TRANSACTION_FAILED)
+)
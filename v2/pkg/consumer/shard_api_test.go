@@ -39,8 +39,19 @@ func (s *APISuite) TestStatCases(c *gc.C) {
 	c.Check(err, gc.IsNil)
 	c.Check(resp.Status, gc.Equals, Status_OK)
 	c.Check(resp.Offsets, gc.DeepEquals, map[pb.Journal]int64{sourceA: expectOffset})
+	c.Check(resp.SourceLags, gc.DeepEquals, []SourceLag{
+		{Journal: sourceA, ReadOffset: expectOffset, WriteHead: expectOffset},
+		{Journal: sourceB},
+	})
 	c.Check(resp.Header.ProcessId, gc.DeepEquals, localID)
 
+	// Expect Lag reflects content appended but not yet consumed.
+	c.Check(res.Store.Flush(map[pb.Journal]int64{sourceA: expectOffset - 10}), gc.IsNil)
+	resp, err = tf.service.Stat(tf.ctx, &StatRequest{Shard: shardA})
+	c.Check(err, gc.IsNil)
+	c.Check(resp.SourceLags[0], gc.DeepEquals,
+		SourceLag{Journal: sourceA, ReadOffset: expectOffset - 10, WriteHead: expectOffset, Lag: 10})
+
 	// Case: Stat of non-existent Shard.
 	resp, err = tf.service.Stat(tf.ctx, &StatRequest{Shard: "missing-shard"})
 	c.Check(err, gc.IsNil)
@@ -109,6 +120,21 @@ func (s *APISuite) TestListCases(c *gc.C) {
 	})
 	c.Check(err, gc.IsNil)
 	verify(resp, specC)
+	c.Check(resp.Shards[0].SourceLags, gc.HasLen, 0)
+
+	// Case: SourceLags of shards are included, if requested.
+	resp, err = tf.service.List(tf.ctx, &ListRequest{
+		Selector:          pb.LabelSelector{Include: pb.MustLabelSet("id", shardC)},
+		IncludeSourceLags: true,
+	})
+	c.Check(err, gc.IsNil)
+	verify(resp, specC)
+
+	var minOffset = int64(len(sourceAWriteFixture))
+	c.Check(resp.Shards[0].SourceLags, gc.DeepEquals, []SourceLag{
+		{Journal: sourceA, ReadOffset: minOffset, WriteHead: minOffset},
+		{Journal: sourceB},
+	})
 
 	// Case: Errors on request validation error.
 	_, err = tf.service.List(tf.ctx, &ListRequest{
@@ -186,6 +212,13 @@ func (s *APISuite) TestApplyCases(c *gc.C) {
 		Changes: []ApplyRequest_Change{{Delete: "invalid shard id"}},
 	})
 	c.Check(err, gc.ErrorMatches, `Changes\[0\].Delete: not a valid token \(invalid shard id\)`)
+
+	// Case: Upsert having a Placement matching no consumer fails with an error.
+	specA.Placement = pb.LabelSelector{Include: pb.MustLabelSet("pool", "gpu")}
+	_, err = tf.service.Apply(tf.ctx, &ApplyRequest{
+		Changes: []ApplyRequest_Change{{Upsert: specA}},
+	})
+	c.Check(err, gc.ErrorMatches, `Changes\[0\].Upsert.Placement: no consumers match selector \(pool=gpu,\)`)
 }
 
 func (s *APISuite) TestApplyShardsInBatches(c *gc.C) {
@@ -0,0 +1,172 @@
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// ShardProvisioner maintains ShardSpecs of a consumer such that each journal
+// matched by a JournalSelector (eg, "topic=events") is consumed by exactly one
+// provisioned Shard. As partition journals are added or removed, Shards are
+// created or deleted to match, eliminating manual ShardSpec churn.
+//
+// Provisioned ShardSpecs are built from the Template. The Id of each is the
+// Template Id (if any) joined with the journal name, and its Sources are those
+// of the Template (eg, broadcast sources) plus the partition journal itself.
+// The Template LabelSet identifies provisioned Shards, so it must be non-empty
+// and distinct from the labels of any other Shards. Shards matching it which
+// don't correspond to a current journal are deleted only if AllowDeletes is
+// set, and are otherwise retained and logged. As a safeguard against a
+// misconfigured JournalSelector or a transient listing failure, a listing
+// having no journals is refused, and no changes are applied. The Disable and Pause
+// fields of an existing ShardSpec are preserved, allowing operators to disable
+// or pause provisioned Shards.
+type ShardProvisioner struct {
+	// Journals is a client of the brokers serving partition journals.
+	Journals pb.JournalClient
+	// Shards is a client of the consumer.
+	Shards ShardClient
+	// JournalSelector of partition journals.
+	JournalSelector pb.LabelSelector
+	// Template of provisioned ShardSpecs.
+	Template ShardSpec
+	// Interval between reconciliations of ShardSpecs with journals.
+	Interval time.Duration
+	// AllowDeletes opts in to the deletion of provisioned Shards which no
+	// longer have a corresponding journal.
+	AllowDeletes bool
+}
+
+// Serve reconciles ShardSpecs with partition journals immediately, and then
+// every Interval until |ctx| is cancelled. Errors are logged, and are retried
+// with the next reconciliation.
+func (p *ShardProvisioner) Serve(ctx context.Context) error {
+	var ticker = time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Reconcile(ctx); err != nil && ctx.Err() == nil {
+			log.WithField("err", err).Warn("failed to reconcile provisioned shards (will retry)")
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Reconcile lists partition journals and provisioned Shards, and applies
+// ShardSpec changes required to bring them into correspondence.
+func (p *ShardProvisioner) Reconcile(ctx context.Context) error {
+	if len(p.Template.LabelSet.Labels) == 0 {
+		return errors.New("ShardProvisioner Template has no labels")
+	}
+	var journals, err = client.ListAllJournals(ctx, p.Journals, pb.ListRequest{Selector: p.JournalSelector})
+	if err != nil {
+		return extendErr(err, "listing journals")
+	} else if len(journals.Journals) == 0 {
+		return errors.Errorf("no journals match JournalSelector %s (refusing to reconcile)", p.JournalSelector.String())
+	}
+	shards, err := ListShards(ctx, p.Shards, &ListRequest{
+		Selector: pb.LabelSelector{Include: p.Template.LabelSet},
+	})
+	if err != nil {
+		return extendErr(err, "listing shards")
+	}
+	changes, err := p.changes(journals.Journals, shards.Shards)
+	if err != nil {
+		return err
+	} else if len(changes) == 0 {
+		return nil
+	}
+	if _, err = ApplyShards(ctx, p.Shards, &ApplyRequest{Changes: changes}); err != nil {
+		return extendErr(err, "applying shards")
+	}
+	log.WithField("changes", len(changes)).Info("applied provisioned shard changes")
+	return nil
+}
+
+// ShardID returns the ID of the Shard provisioned for |journal|.
+func (p *ShardProvisioner) ShardID(journal pb.Journal) ShardID {
+	if p.Template.Id == "" {
+		return ShardID(journal)
+	}
+	return ShardID(p.Template.Id.String() + "/" + journal.String())
+}
+
+// spec returns the ShardSpec provisioned for |journal|.
+func (p *ShardProvisioner) spec(journal pb.Journal) *ShardSpec {
+	var spec = p.Template
+	spec.Id = p.ShardID(journal)
+	spec.Sources = append([]ShardSpec_Source{{Journal: journal}}, p.Template.Sources...)
+
+	sort.Slice(spec.Sources, func(i, j int) bool {
+		return spec.Sources[i].Journal < spec.Sources[j].Journal
+	})
+	return &spec
+}
+
+// changes returns the ApplyRequest_Changes which reconcile current |shards|
+// with partition |journals|.
+func (p *ShardProvisioner) changes(journals []pb.ListResponse_Journal, shards []ListResponse_Shard) ([]ApplyRequest_Change, error) {
+	var current = make(map[ShardID]ListResponse_Shard, len(shards))
+	for _, shard := range shards {
+		current[shard.Spec.Id] = shard
+	}
+	var out []ApplyRequest_Change
+
+	for _, journal := range journals {
+		var spec = p.spec(journal.Spec.Name)
+
+		if err := spec.Validate(); err != nil {
+			return nil, extendErr(err, "provisioned ShardSpec of %s", journal.Spec.Name)
+		}
+		var shard, ok = current[spec.Id]
+		delete(current, spec.Id)
+
+		if !ok {
+			out = append(out, ApplyRequest_Change{Upsert: spec})
+			continue
+		}
+		spec.Disable, spec.Pause = shard.Spec.Disable, shard.Spec.Pause
+
+		if eq, err := specsEqual(spec, &shard.Spec); err != nil {
+			return nil, err
+		} else if !eq {
+			out = append(out, ApplyRequest_Change{Upsert: spec, ExpectModRevision: shard.ModRevision})
+		}
+	}
+	// Remaining |current| Shards no longer have a corresponding journal.
+	for _, shard := range shards {
+		if _, ok := current[shard.Spec.Id]; !ok {
+			continue
+		} else if !p.AllowDeletes {
+			log.WithField("shard", shard.Spec.Id).
+				Warn("provisioned shard has no corresponding journal (retaining, as AllowDeletes is not set)")
+			continue
+		}
+		out = append(out, ApplyRequest_Change{Delete: shard.Spec.Id, ExpectModRevision: shard.ModRevision})
+	}
+	return out, nil
+}
+
+// specsEqual returns whether ShardSpecs |a| and |b| have equal encodings.
+func specsEqual(a, b *ShardSpec) (bool, error) {
+	var ab, err = a.Marshal()
+	if err != nil {
+		return false, err
+	}
+	bb, err := b.Marshal()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(ab, bb), nil
+}
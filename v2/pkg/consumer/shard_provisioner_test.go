@@ -0,0 +1,107 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type ShardProvisionerSuite struct{}
+
+func (s *ShardProvisionerSuite) TestChanges(c *gc.C) {
+	var p = &ShardProvisioner{
+		Template: ShardSpec{
+			Id:                "events",
+			Sources:           []ShardSpec_Source{{Journal: "dimensions", Broadcast: true}},
+			RecoveryLogPrefix: "recovery/logs",
+			HintPrefix:        "/hints",
+			MaxTxnDuration:    time.Second,
+			LabelSet:          pb.MustLabelSet("provisioned-by", "events"),
+		},
+	}
+	var journal = func(name pb.Journal) pb.ListResponse_Journal {
+		return pb.ListResponse_Journal{Spec: pb.JournalSpec{Name: name}}
+	}
+	var shard = func(spec *ShardSpec, rev int64) ListResponse_Shard {
+		return ListResponse_Shard{Spec: *spec, ModRevision: rev}
+	}
+
+	// Expect the ShardSpec of a journal is built from the Template.
+	var specA = p.spec("part/a")
+	c.Check(specA.Id, gc.Equals, ShardID("events/part/a"))
+	c.Check(specA.Sources, gc.DeepEquals, []ShardSpec_Source{
+		{Journal: "dimensions", Broadcast: true},
+		{Journal: "part/a"},
+	})
+	c.Check(specA.LabelSet, gc.DeepEquals, p.Template.LabelSet)
+
+	// Case: journals without shards are created.
+	var out, err = p.changes([]pb.ListResponse_Journal{journal("part/a"), journal("part/b")}, nil)
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.DeepEquals, []ApplyRequest_Change{
+		{Upsert: p.spec("part/a")},
+		{Upsert: p.spec("part/b")},
+	})
+
+	// Case: shards which match the Template are unchanged, and a
+	// disabled & paused shard remains disabled & paused.
+	var specB = p.spec("part/b")
+	specB.Disable, specB.Pause = true, true
+
+	out, err = p.changes([]pb.ListResponse_Journal{journal("part/a"), journal("part/b")},
+		[]ListResponse_Shard{shard(specA, 10), shard(specB, 11)})
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.HasLen, 0)
+
+	// Case: shards which differ from the Template are updated, and shards
+	// without a current journal are retained, as deletes are not allowed.
+	p.Template.MaxTxnDuration = time.Minute
+
+	out, err = p.changes([]pb.ListResponse_Journal{journal("part/a"), journal("part/c")},
+		[]ListResponse_Shard{shard(specA, 10), shard(specB, 11)})
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.DeepEquals, []ApplyRequest_Change{
+		{Upsert: p.spec("part/a"), ExpectModRevision: 10},
+		{Upsert: p.spec("part/c")},
+	})
+
+	// Case: with AllowDeletes, shards without a current journal are deleted.
+	p.AllowDeletes = true
+
+	out, err = p.changes([]pb.ListResponse_Journal{journal("part/a"), journal("part/c")},
+		[]ListResponse_Shard{shard(specA, 10), shard(specB, 11)})
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.DeepEquals, []ApplyRequest_Change{
+		{Upsert: p.spec("part/a"), ExpectModRevision: 10},
+		{Upsert: p.spec("part/c")},
+		{Delete: "events/part/b", ExpectModRevision: 11},
+	})
+
+	// Case: an invalid provisioned ShardSpec is an error.
+	p.Template.HintPrefix = "invalid"
+	_, err = p.changes([]pb.ListResponse_Journal{journal("part/a")}, nil)
+	c.Check(err, gc.ErrorMatches, `provisioned ShardSpec of part/a: HintPrefix is not .*`)
+}
+
+func (s *ShardProvisionerSuite) TestReconcileRefusesEmptyListing(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var p = &ShardProvisioner{
+		Journals:        tf.broker.Client(),
+		Template:        ShardSpec{LabelSet: pb.MustLabelSet("provisioned-by", "events")},
+		JournalSelector: pb.LabelSelector{Include: pb.MustLabelSet("no-journal-has", "this-label")},
+		AllowDeletes:    true,
+	}
+	c.Check(p.Reconcile(context.Background()), gc.ErrorMatches,
+		`no journals match JournalSelector .* \(refusing to reconcile\)`)
+}
+
+func (s *ShardProvisionerSuite) TestShardIDWithoutTemplateID(c *gc.C) {
+	var p = &ShardProvisioner{}
+	c.Check(p.ShardID("part/a"), gc.Equals, ShardID("part/a"))
+}
+
+var _ = gc.Suite(&ShardProvisionerSuite{})
@@ -0,0 +1,112 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pkg/errors"
+)
+
+// ExportSnapshot exports the current state of Shard |id| into the recovery
+// log |snapshot|, and returns FSMHints of the exported state. The snapshot
+// log must already exist, and is typically bound to a fragment store from
+// which it may be retained or shared (eg, a bucket of backups). The Shard is
+// not disturbed: its recovery log is played back through its current write
+// head, and the recovered files are recorded into the snapshot log.
+//
+// The returned FSMHints may be passed to ImportSnapshot to seed a Shard of
+// this, or of another consumer deployment, having a broker cluster which
+// serves the snapshot log (eg, from the same fragment store).
+func ExportSnapshot(ctx context.Context, etcd *clientv3.Client, ks *keyspace.KeySpace,
+	ajc client.AsyncJournalClient, id ShardID, snapshot pb.Journal) (recoverylog.FSMHints, error) {
+
+	var spec, _, err = fetchShardSpec(ctx, etcd, ks, id)
+	if err != nil {
+		return recoverylog.FSMHints{}, err
+	}
+	h, err := fetchHints(ctx, spec, etcd)
+	if err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "fetching hints of shard %s", id)
+	}
+
+	playDir, err := ioutil.TempDir("", id.String()+"-export-")
+	if err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "creating playback directory")
+	}
+	defer os.RemoveAll(playDir)
+
+	var pl = recoverylog.NewPlayer()
+	pl.FinishAtWriteHead()
+
+	if err = pl.Play(ctx, pickFirstHints(h), playDir, ajc); err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "playing log %s", spec.RecoveryLog())
+	}
+
+	// Record the recovered files into |snapshot|, through a Recorder of an
+	// empty FSM and directory.
+	recDir, err := ioutil.TempDir("", id.String()+"-snapshot-")
+	if err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "creating snapshot directory")
+	}
+	defer os.RemoveAll(recDir)
+
+	fsm, err := recoverylog.NewFSM(recoverylog.FSMHints{Log: snapshot})
+	if err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "building snapshot FSM")
+	}
+	author, err := recoverylog.NewRandomAuthorID()
+	if err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "generating Author")
+	}
+	var rec = recoverylog.NewRecorder(fsm, author, recDir, ajc)
+
+	if err = recoverylog.CopyDirectory(rec, playDir, recDir); err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "recording snapshot")
+	}
+	hints, err := rec.BuildHints()
+	if err != nil {
+		return recoverylog.FSMHints{}, extendErr(err, "building snapshot FSMHints")
+	}
+	return hints, nil
+}
+
+// ImportSnapshot seeds Shard |id| with snapshot |hints| previously returned
+// by ExportSnapshot. The Shard must exist and be disabled. Once enabled, the
+// Shard plays back the snapshot log and records its files into the Shard's
+// own recovery log, replacing the Shard's current state, and thereafter
+// resumes its journals from the offsets of the snapshot.
+func ImportSnapshot(ctx context.Context, etcd *clientv3.Client, ks *keyspace.KeySpace,
+	id ShardID, hints recoverylog.FSMHints) error {
+
+	var spec, rev, err = fetchShardSpec(ctx, etcd, ks, id)
+	if err != nil {
+		return err
+	} else if !spec.Disable {
+		return errors.Errorf("shard %s must be disabled to import a snapshot", id)
+	} else if _, err = recoverylog.NewFSM(hints); err != nil {
+		return extendErr(err, "validating snapshot FSMHints")
+	}
+	seed, err := json.Marshal(shardSeed{Hints: hints})
+	if err != nil {
+		return extendErr(err, "marshal shardSeed")
+	}
+	var key = allocator.ItemKey(ks, id.String())
+
+	if err = commitTxn(ctx, etcd, []clientv3.Cmp{
+		clientv3.Compare(clientv3.ModRevision(key), "=", rev),
+		clientv3.Compare(clientv3.ModRevision(spec.HintSeedKey()), "=", 0),
+	}, []clientv3.Op{
+		clientv3.OpPut(spec.HintSeedKey(), string(seed)),
+	}); err != nil {
+		return extendErr(err, "importing snapshot into shard %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package consumer
+
+import (
+	gc "github.com/go-check/check"
+)
+
+type ShardSnapshotSuite struct{}
+
+func (s *ShardSnapshotSuite) TestExportAndImport(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	// Build up state of |shardA|.
+	tf.allocateShard(c, makeShard(shardA), localID)
+	expectStatusCode(c, tf.state, ReplicaStatus_PRIMARY)
+
+	var res, err = tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardA})
+	c.Assert(err, gc.IsNil)
+	runSomeTransactions(c, res.Shard)
+	var ajc = res.Shard.JournalClient()
+	res.Done()
+
+	// Export |shardA| into the recovery log of |shardC|, which serves as the snapshot log.
+	var snapshot = makeShard(shardC).RecoveryLog()
+	hints, err := ExportSnapshot(tf.ctx, tf.etcd, tf.ks, ajc, shardA, snapshot)
+	c.Assert(err, gc.IsNil)
+	c.Check(hints.Log, gc.Equals, snapshot)
+	c.Check(hints.LiveNodes, gc.Not(gc.HasLen), 0)
+
+	tf.allocateShard(c, makeShard(shardA)) // Remove assignment.
+
+	// A snapshot cannot be imported into a Shard which is enabled.
+	var spec = makeShard(shardB)
+	tf.allocateShard(c, spec)
+
+	c.Check(ImportSnapshot(tf.ctx, tf.etcd, tf.ks, shardB, hints), gc.ErrorMatches,
+		`shard shard-B must be disabled to import a snapshot`)
+
+	spec.Disable = true
+	tf.allocateShard(c, spec)
+	c.Check(ImportSnapshot(tf.ctx, tf.etcd, tf.ks, shardB, hints), gc.IsNil)
+
+	// A seed is written, and only one may be imported at a time.
+	seed, err := fetchSeed(tf.ctx, spec, tf.etcd)
+	c.Assert(err, gc.IsNil)
+	c.Check(seed.Hints, gc.DeepEquals, hints)
+	c.Check(seed.Merge, gc.Equals, false)
+
+	c.Check(ImportSnapshot(tf.ctx, tf.etcd, tf.ks, shardB, hints), gc.ErrorMatches,
+		`importing snapshot into shard shard-B: etcd transaction checks failed .*`)
+
+	// Enable and assign |shardB|. Expect it recovers the state of |shardA|.
+	tf.allocateShard(c, makeShard(shardB), localID)
+	expectStatusCode(c, tf.state, ReplicaStatus_PRIMARY)
+
+	res, err = tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardB})
+	c.Assert(err, gc.IsNil)
+	c.Check(res.Store.(*JSONFileStore).State, gc.DeepEquals,
+		&map[string]string{"foo": "fin", "baz": "bing", "ring": "ting"})
+	res.Done()
+
+	seed, err = fetchSeed(tf.ctx, spec, tf.etcd)
+	c.Check(err, gc.IsNil)
+	c.Check(seed, gc.IsNil) // Seed was cleared upon its recovery.
+
+	tf.allocateShard(c, makeShard(shardB)) // Cleanup.
+}
+
+var _ = gc.Suite(&ShardSnapshotSuite{})
@@ -3,6 +3,7 @@ package consumer
 import (
 	"fmt"
 	"path"
+	"sync"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
@@ -55,12 +56,26 @@ func (m *ShardSpec) Validate() error {
 		return pb.NewValidationError("invalid MinTxnDuration (%d; expected >= 0)", m.MinTxnDuration)
 	} else if m.MaxTxnDuration <= 0 {
 		return pb.NewValidationError("invalid MaxTxnDuration (%d; expected > 0)", m.MaxTxnDuration)
+	} else if m.MaxTxnBytes < 0 {
+		return pb.NewValidationError("invalid MaxTxnBytes (%d; expected >= 0)", m.MaxTxnBytes)
+	} else if m.ReadAheadBytes < 0 {
+		return pb.NewValidationError("invalid ReadAheadBytes (%d; expected >= 0)", m.ReadAheadBytes)
+	} else if m.KeyEnd != 0 && m.KeyEnd <= m.KeyBegin {
+		return pb.NewValidationError("invalid key range ([%d, %d); expected KeyBegin < KeyEnd)",
+			m.KeyBegin, m.KeyEnd)
 	} else if err = m.LabelSet.Validate(); err != nil {
 		return pb.ExtendContext(err, "LabelSet")
 	} else if err = pb.ValidateSingleValueLabels(m.LabelSet); err != nil {
 		return pb.ExtendContext(err, "LabelSet")
 	} else if len(m.LabelSet.ValuesOf("id")) != 0 {
 		return pb.NewValidationError(`Labels cannot include label "id"`)
+	} else if _, err = RocksDBOptionsFromLabels(m.LabelSet); err != nil {
+		return pb.ExtendContext(err, "LabelSet")
+	} else if m.MinZones > 1+m.HotStandbys {
+		return pb.NewValidationError("invalid MinZones (%d; expected MinZones <= 1 + HotStandbys %d)",
+			m.MinZones, m.HotStandbys)
+	} else if err = m.Placement.Validate(); err != nil {
+		return pb.ExtendContext(err, "Placement")
 	}
 
 	for i := range m.Sources {
@@ -72,17 +87,41 @@ func (m *ShardSpec) Validate() error {
 		}
 	}
 
-	// Disable and HotStandbys require no extra validation.
+	// Disable, HotStandbys, and MaxReplicasPerZone require no extra validation.
+
+	shardSpecValidatorsMu.Lock()
+	var validators = shardSpecValidators
+	shardSpecValidatorsMu.Unlock()
 
+	for _, fn := range validators {
+		if err := fn(m); err != nil {
+			return pb.AsValidationError(err)
+		}
+	}
 	return nil
 }
 
+// RegisterShardSpecValidator registers |fn| as an additional validation of
+// ShardSpecs, which is run by ShardSpec.Validate after all built-in checks
+// have passed. It parallels protocol.RegisterJournalSpecValidator: consumers
+// Validate ShardSpecs decoded from Etcd and ignore specs which fail, so a
+// validator should be registered by every member of a consumer application,
+// or by none of them. It should be called as part of application initialization.
+func RegisterShardSpecValidator(fn func(*ShardSpec) error) {
+	shardSpecValidatorsMu.Lock()
+	// Copy-on-write, so that in-flight Validate calls retain their snapshot.
+	shardSpecValidators = append(shardSpecValidators[:len(shardSpecValidators):len(shardSpecValidators)], fn)
+	shardSpecValidatorsMu.Unlock()
+}
+
 // Validate returns an error if the ShardSpec_Source is not well-formed.
 func (m *ShardSpec_Source) Validate() error {
 	if err := m.Journal.Validate(); err != nil {
 		return pb.ExtendContext(err, "Journal")
 	} else if m.MinOffset < 0 {
 		return pb.NewValidationError("invalid MinOffset (%d; expected > 0)", m.MinOffset)
+	} else if err = m.Filter.Validate(); err != nil {
+		return pb.ExtendContext(err, "Filter")
 	}
 	return nil
 }
@@ -104,6 +143,22 @@ func (m *ShardSpec) DesiredReplication() int {
 	return 1 + int(m.HotStandbys)
 }
 
+// ZoneConstraints returns the configured MinZones and MaxReplicasPerZone of
+// the shard. allocator.ZoneConstrainedItemValue implementation.
+func (m *ShardSpec) ZoneConstraints() (minZones, maxPerZone int) {
+	return int(m.MinZones), int(m.MaxReplicasPerZone)
+}
+
+// IsPlaceable is whether the shard Placement matches the Labels of the |member|
+// ConsumerSpec. A shard having an empty Placement may be placed on any
+// consumer. allocator.PlacedItemValue implementation.
+func (m *ShardSpec) IsPlaceable(member allocator.MemberValue) bool {
+	if m.Placement.IsEmpty() {
+		return true
+	}
+	return m.Placement.Matches(member.(*ConsumerSpec).Labels)
+}
+
 // IsConsistent is whether the shard assignment is consistent. allocator.ItemValue implementation.
 func (m *ShardSpec) IsConsistent(assignment keyspace.KeyValue, _ keyspace.KeyValues) bool {
 	switch assignment.Decoded.(allocator.Assignment).AssignmentValue.(*ReplicaStatus).Code {
@@ -122,6 +177,11 @@ func (m *ShardSpec) RecoveryLog() pb.Journal {
 // HintPrimaryKey returns the Etcd key to which recorded, primary hints are written.
 func (m *ShardSpec) HintPrimaryKey() string { return m.HintPrefix + "/" + m.Id.String() + ".primary" }
 
+// HintSeedKey returns the Etcd key to which hints of a recovery log which
+// seeds the Shard are written, when the Shard is split from or merged with
+// another Shard.
+func (m *ShardSpec) HintSeedKey() string { return m.HintPrefix + "/" + m.Id.String() + ".seed" }
+
 // HintBackupKeys returns Etcd keys to which verified, disaster-recovery hints are written.
 func (m *ShardSpec) HintBackupKeys() []string {
 	var keys = make([]string, m.HintBackups)
@@ -153,12 +213,42 @@ func UnionShardSpecs(a, b ShardSpec) ShardSpec {
 	if a.MinTxnDuration == 0 {
 		a.MinTxnDuration = b.MinTxnDuration
 	}
+	if a.MaxTxnMessages == 0 {
+		a.MaxTxnMessages = b.MaxTxnMessages
+	}
+	if a.MaxTxnBytes == 0 {
+		a.MaxTxnBytes = b.MaxTxnBytes
+	}
+	if a.ReadAheadMessages == 0 {
+		a.ReadAheadMessages = b.ReadAheadMessages
+	}
+	if a.ReadAheadBytes == 0 {
+		a.ReadAheadBytes = b.ReadAheadBytes
+	}
+	if a.KeyBegin == 0 {
+		a.KeyBegin = b.KeyBegin
+	}
+	if a.KeyEnd == 0 {
+		a.KeyEnd = b.KeyEnd
+	}
 	if a.Disable == false {
 		a.Disable = b.Disable
 	}
+	if a.Pause == false {
+		a.Pause = b.Pause
+	}
 	if a.HotStandbys == 0 {
 		a.HotStandbys = b.HotStandbys
 	}
+	if a.MinZones == 0 {
+		a.MinZones = b.MinZones
+	}
+	if a.MaxReplicasPerZone == 0 {
+		a.MaxReplicasPerZone = b.MaxReplicasPerZone
+	}
+	if a.Placement.IsEmpty() {
+		a.Placement = b.Placement
+	}
 	a.LabelSet = pb.UnionLabelSets(a.LabelSet, b.LabelSet, pb.LabelSet{})
 
 	return a
@@ -185,12 +275,42 @@ func IntersectShardSpecs(a, b ShardSpec) ShardSpec {
 	if a.MinTxnDuration != b.MinTxnDuration {
 		a.MinTxnDuration = 0
 	}
+	if a.MaxTxnMessages != b.MaxTxnMessages {
+		a.MaxTxnMessages = 0
+	}
+	if a.MaxTxnBytes != b.MaxTxnBytes {
+		a.MaxTxnBytes = 0
+	}
+	if a.ReadAheadMessages != b.ReadAheadMessages {
+		a.ReadAheadMessages = 0
+	}
+	if a.ReadAheadBytes != b.ReadAheadBytes {
+		a.ReadAheadBytes = 0
+	}
+	if a.KeyBegin != b.KeyBegin {
+		a.KeyBegin = 0
+	}
+	if a.KeyEnd != b.KeyEnd {
+		a.KeyEnd = 0
+	}
 	if a.Disable != b.Disable {
 		a.Disable = false
 	}
+	if a.Pause != b.Pause {
+		a.Pause = false
+	}
 	if a.HotStandbys != b.HotStandbys {
 		a.HotStandbys = 0
 	}
+	if a.MinZones != b.MinZones {
+		a.MinZones = 0
+	}
+	if a.MaxReplicasPerZone != b.MaxReplicasPerZone {
+		a.MaxReplicasPerZone = 0
+	}
+	if a.Placement.String() != b.Placement.String() {
+		a.Placement = pb.LabelSelector{}
+	}
 	a.LabelSet = pb.IntersectLabelSets(a.LabelSet, b.LabelSet, pb.LabelSet{})
 
 	return a
@@ -217,12 +337,42 @@ func SubtractShardSpecs(a, b ShardSpec) ShardSpec {
 	if a.MinTxnDuration == b.MinTxnDuration {
 		a.MinTxnDuration = 0
 	}
+	if a.MaxTxnMessages == b.MaxTxnMessages {
+		a.MaxTxnMessages = 0
+	}
+	if a.MaxTxnBytes == b.MaxTxnBytes {
+		a.MaxTxnBytes = 0
+	}
+	if a.ReadAheadMessages == b.ReadAheadMessages {
+		a.ReadAheadMessages = 0
+	}
+	if a.ReadAheadBytes == b.ReadAheadBytes {
+		a.ReadAheadBytes = 0
+	}
+	if a.KeyBegin == b.KeyBegin {
+		a.KeyBegin = 0
+	}
+	if a.KeyEnd == b.KeyEnd {
+		a.KeyEnd = 0
+	}
 	if a.Disable == b.Disable {
 		a.Disable = false
 	}
+	if a.Pause == b.Pause {
+		a.Pause = false
+	}
 	if a.HotStandbys == b.HotStandbys {
 		a.HotStandbys = 0
 	}
+	if a.MinZones == b.MinZones {
+		a.MinZones = 0
+	}
+	if a.MaxReplicasPerZone == b.MaxReplicasPerZone {
+		a.MaxReplicasPerZone = 0
+	}
+	if a.Placement.String() == b.Placement.String() {
+		a.Placement = pb.LabelSelector{}
+	}
 	a.LabelSet = pb.SubtractLabelSet(a.LabelSet, b.LabelSet, pb.LabelSet{})
 
 	return a
@@ -238,6 +388,9 @@ func ExtractShardSpecMetaLabels(spec *ShardSpec, out pb.LabelSet) pb.LabelSet {
 func (m *ConsumerSpec) Validate() error {
 	if err := m.ProcessSpec.Validate(); err != nil {
 		return err
+	} else if m.CapacityWeight > maxConsumerCapacityWeight {
+		return pb.NewValidationError("invalid CapacityWeight (%d; expected 0 <= CapacityWeight <= %d)",
+			m.CapacityWeight, maxConsumerCapacityWeight)
 	}
 	// ShardLimit requires no extra validation.
 	return nil
@@ -258,6 +411,15 @@ func (m *ConsumerSpec) ZeroLimit() { m.ShardLimit = 0 }
 // ItemLimit is the maximum number of shards this consumer may process. allocator.MemberValue implementation.
 func (m *ConsumerSpec) ItemLimit() int { return int(m.ShardLimit) }
 
+// ItemWeight is the relative capacity weight of this consumer. allocator.WeightedMemberValue implementation.
+func (m *ConsumerSpec) ItemWeight() int { return int(m.CapacityWeight) }
+
+// MarkDraining marks the ConsumerSpec as draining. allocator.DrainableSpec implementation.
+func (m *ConsumerSpec) MarkDraining() { m.Draining = true }
+
+// IsDraining returns whether this consumer is draining. allocator.DrainingMemberValue implementation.
+func (m *ConsumerSpec) IsDraining() bool { return m.Draining }
+
 // Reduce folds another ReplicaStatus into this one.
 func (m *ReplicaStatus) Reduce(other *ReplicaStatus) {
 	if other.Code > m.Code {
@@ -339,6 +501,25 @@ func (m *StatResponse) Validate() error {
 			return pb.ExtendContext(err, "Offsets[%s]", journal)
 		}
 	}
+	for i, lag := range m.SourceLags {
+		if err := lag.Validate(); err != nil {
+			return pb.ExtendContext(err, "SourceLags[%d]", i)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the SourceLag is not well-formed.
+func (m *SourceLag) Validate() error {
+	if err := m.Journal.Validate(); err != nil {
+		return pb.ExtendContext(err, "Journal")
+	} else if m.ReadOffset < 0 {
+		return pb.NewValidationError("invalid ReadOffset (%d; expected >= 0)", m.ReadOffset)
+	} else if m.WriteHead < 0 {
+		return pb.NewValidationError("invalid WriteHead (%d; expected >= 0)", m.WriteHead)
+	} else if m.Lag < 0 {
+		return pb.NewValidationError("invalid Lag (%d; expected >= 0)", m.Lag)
+	}
 	return nil
 }
 
@@ -381,6 +562,11 @@ func (m *ListResponse_Shard) Validate() error {
 			return pb.ExtendContext(err, "Status[%d]", i)
 		}
 	}
+	for i, lag := range m.SourceLags {
+		if err := lag.Validate(); err != nil {
+			return pb.ExtendContext(err, "SourceLags[%d]", i)
+		}
+	}
 	return nil
 }
 
@@ -465,7 +651,9 @@ func sourcesEq(a, b []ShardSpec_Source) bool {
 	}
 
 	for i := range a {
-		if a[i] != b[i] {
+		if a[i].Journal != b[i].Journal ||
+			a[i].MinOffset != b[i].MinOffset ||
+			a[i].Filter.String() != b[i].Filter.String() {
 			return false
 		}
 	}
@@ -474,4 +662,11 @@ func sourcesEq(a, b []ShardSpec_Source) bool {
 
 const (
 	minShardNameLen, maxShardNameLen = 4, 512
+	// Bound weights such that weighted ShardLimits cannot overflow.
+	maxConsumerCapacityWeight = 1 << 10
+)
+
+var (
+	shardSpecValidators   []func(*ShardSpec) error
+	shardSpecValidatorsMu sync.Mutex
 )
@@ -1,6 +1,7 @@
 package consumer
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -69,6 +70,15 @@ func (s *SpecSuite) TestShardSpecValidationCases(c *gc.C) {
 	spec.MinTxnDuration = 0
 	c.Check(spec.Validate(), gc.ErrorMatches, `invalid MaxTxnDuration \(0; expected > 0\)`)
 	spec.MaxTxnDuration = 1
+	spec.MaxTxnBytes = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid MaxTxnBytes \(-1; expected >= 0\)`)
+	spec.MaxTxnBytes = 0
+	spec.ReadAheadBytes = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid ReadAheadBytes \(-1; expected >= 0\)`)
+	spec.ReadAheadBytes = 0
+	spec.KeyBegin, spec.KeyEnd = 10, 10
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid key range \(\[10, 10\); expected KeyBegin < KeyEnd\)`)
+	spec.KeyBegin, spec.KeyEnd = 10, 0 // A zero KeyEnd denotes the end of the key space.
 	c.Check(spec.Validate(), gc.ErrorMatches, `LabelSet.Labels\[0\].Name: not a valid token \(bad label\)`)
 	spec.LabelSet = pb.MustLabelSet(labels.Instance, "a", labels.Instance, "b")
 	c.Check(spec.Validate(), gc.ErrorMatches, `LabelSet: expected single-value Label has multiple values \(index 1; label `+labels.Instance+` value b\)`)
@@ -76,18 +86,53 @@ func (s *SpecSuite) TestShardSpecValidationCases(c *gc.C) {
 	c.Check(spec.Validate(), gc.ErrorMatches, `Labels cannot include label "id"`)
 	spec.LabelSet = pb.MustLabelSet("id", "") // Label is rejected even if empty.
 	c.Check(spec.Validate(), gc.ErrorMatches, `Labels cannot include label "id"`)
+	spec.LabelSet = pb.MustLabelSet(labels.RocksDBCompression, "gzip")
+	c.Check(spec.Validate(), gc.ErrorMatches, `LabelSet: invalid `+labels.RocksDBCompression+` \(gzip\)`)
 	spec.LabelSet = pb.MustLabelSet(labels.Instance, "an-instance", labels.ManagedBy, "a-tool")
+	spec.MinZones = 2
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid MinZones \(2; expected MinZones <= 1 \+ HotStandbys 0\)`)
+	spec.HotStandbys = 1
+	spec.Placement = pb.LabelSelector{Exclude: pb.LabelSet{Labels: []pb.Label{{Name: "bad label"}}}}
+	c.Check(spec.Validate(), gc.ErrorMatches, `Placement.Exclude.Labels\[0\].Name: not a valid token \(bad label\)`)
+	spec.Placement = pb.LabelSelector{}
 
 	c.Check(spec.Validate(), gc.ErrorMatches, `Sources\[0\].Journal: not a valid token \(journal 2\)`)
 	spec.Sources[0].Journal = "journal/2"
 	c.Check(spec.Validate(), gc.ErrorMatches, `Sources\[1\]: invalid MinOffset \(-1; expected > 0\)`)
 	spec.Sources[1].MinOffset = 1024
+	spec.Sources[1].Filter.Include.Labels = []pb.Label{{Name: "bad field", Value: "value"}}
+	c.Check(spec.Validate(), gc.ErrorMatches, `Sources\[1\].Filter.Include.Labels\[0\].Name: not a valid token \(bad field\)`)
+	spec.Sources[1].Filter = pb.LabelSelector{}
 	c.Check(spec.Validate(), gc.ErrorMatches, `Sources.Journal not in unique, sorted order \(index 1; journal/1 <= journal/2\)`)
 	spec.Sources[0], spec.Sources[1] = spec.Sources[1], spec.Sources[0]
 
 	c.Check(spec.Validate(), gc.IsNil)
 }
 
+func (s *SpecSuite) TestRegisteredShardSpecValidators(c *gc.C) {
+	defer func() { shardSpecValidators = nil }()
+
+	var spec = ShardSpec{
+		Id:                "a-shard-id",
+		Sources:           []ShardSpec_Source{{Journal: "a/journal"}},
+		RecoveryLogPrefix: "recovery/logs",
+		HintPrefix:        "/hints",
+		MaxTxnDuration:    time.Second,
+	}
+	RegisterShardSpecValidator(func(spec *ShardSpec) error {
+		if spec.LabelSet.ValueOf(labels.ManagedBy) == "" {
+			return fmt.Errorf("expected label %s", labels.ManagedBy)
+		}
+		return nil
+	})
+	var err = spec.Validate()
+	c.Check(err, gc.ErrorMatches, `expected label `+labels.ManagedBy)
+	c.Check(err, gc.FitsTypeOf, &pb.ValidationError{})
+
+	spec.LabelSet = pb.MustLabelSet(labels.ManagedBy, "a-tool")
+	c.Check(spec.Validate(), gc.IsNil)
+}
+
 func (s *SpecSuite) TestShardSpecRoutines(c *gc.C) {
 	var spec = ShardSpec{
 		Id:          "shard-id",
@@ -102,6 +147,18 @@ func (s *SpecSuite) TestShardSpecRoutines(c *gc.C) {
 	spec.Disable, spec.HotStandbys = false, 0
 	c.Check(spec.DesiredReplication(), gc.Equals, 1)
 
+	spec.MinZones, spec.MaxReplicasPerZone = 2, 1
+	var minZones, maxPerZone = spec.ZoneConstraints()
+	c.Check(minZones, gc.Equals, 2)
+	c.Check(maxPerZone, gc.Equals, 1)
+
+	var gpu = &ConsumerSpec{ProcessSpec: pb.ProcessSpec{Labels: pb.MustLabelSet("pool", "gpu")}}
+	c.Check(spec.IsPlaceable(gpu), gc.Equals, true)
+	c.Check(spec.IsPlaceable(&ConsumerSpec{}), gc.Equals, true)
+	spec.Placement = pb.LabelSelector{Include: pb.MustLabelSet("pool", "gpu")}
+	c.Check(spec.IsPlaceable(gpu), gc.Equals, true)
+	c.Check(spec.IsPlaceable(&ConsumerSpec{}), gc.Equals, false)
+
 	var status = new(ReplicaStatus)
 	var asn = keyspace.KeyValue{Decoded: allocator.Assignment{AssignmentValue: status}}
 
@@ -117,6 +174,7 @@ func (s *SpecSuite) TestShardSpecRoutines(c *gc.C) {
 		"/a/path/shard-id.backup.0",
 		"/a/path/shard-id.backup.1",
 	})
+	c.Check(spec.HintSeedKey(), gc.Equals, "/a/path/shard-id.seed")
 }
 
 func (s *SpecSuite) TestSetOperations(c *gc.C) {
@@ -124,13 +182,23 @@ func (s *SpecSuite) TestSetOperations(c *gc.C) {
 		Sources: []ShardSpec_Source{
 			{Journal: "a/source", MinOffset: 1234},
 		},
-		RecoveryLogPrefix: "log/prefix",
-		HintPrefix:        "/hints/prefix",
-		HintBackups:       3,
-		MaxTxnDuration:    5 * time.Second,
-		MinTxnDuration:    1 * time.Second,
-		Disable:           true,
-		HotStandbys:       2,
+		RecoveryLogPrefix:  "log/prefix",
+		HintPrefix:         "/hints/prefix",
+		HintBackups:        3,
+		MaxTxnDuration:     5 * time.Second,
+		MinTxnDuration:     1 * time.Second,
+		MaxTxnMessages:     100,
+		MaxTxnBytes:        1 << 20,
+		ReadAheadMessages:  500,
+		ReadAheadBytes:     1 << 24,
+		KeyBegin:           0x10,
+		KeyEnd:             0x20,
+		Disable:            true,
+		Pause:              true,
+		HotStandbys:        2,
+		MinZones:           2,
+		MaxReplicasPerZone: 2,
+		Placement:          pb.LabelSelector{Include: pb.MustLabelSet("pool", "gpu")},
 		LabelSet: pb.LabelSet{
 			Labels: []pb.Label{
 				{Name: "aaa", Value: "val"},
@@ -143,13 +211,22 @@ func (s *SpecSuite) TestSetOperations(c *gc.C) {
 		Sources: []ShardSpec_Source{
 			{Journal: "other/source", MinOffset: 5678},
 		},
-		RecoveryLogPrefix: "other/log/prefix",
-		HintPrefix:        "/hints/other/prefix",
-		HintBackups:       2,
-		MaxTxnDuration:    time.Hour,
-		MinTxnDuration:    time.Minute,
-		Disable:           false,
-		HotStandbys:       1,
+		RecoveryLogPrefix:  "other/log/prefix",
+		HintPrefix:         "/hints/other/prefix",
+		HintBackups:        2,
+		MaxTxnDuration:     time.Hour,
+		MinTxnDuration:     time.Minute,
+		MaxTxnMessages:     1000,
+		MaxTxnBytes:        1 << 30,
+		ReadAheadMessages:  5000,
+		ReadAheadBytes:     1 << 32,
+		KeyBegin:           0x20,
+		KeyEnd:             0x30,
+		Disable:            false,
+		HotStandbys:        1,
+		MinZones:           1,
+		MaxReplicasPerZone: 1,
+		Placement:          pb.LabelSelector{Exclude: pb.MustLabelSet("pool", "gpu")},
 		LabelSet: pb.LabelSet{
 			Labels: []pb.Label{
 				{Name: "aaa", Value: "other"},
@@ -162,9 +239,9 @@ func (s *SpecSuite) TestSetOperations(c *gc.C) {
 	c.Check(UnionShardSpecs(ShardSpec{}, model), gc.DeepEquals, model)
 	c.Check(UnionShardSpecs(model, ShardSpec{}), gc.DeepEquals, model)
 
-	other.Disable = true // Disable == true dominates in union operation.
+	other.Disable, other.Pause = true, true // Disable & Pause == true dominate in union operation.
 	c.Check(UnionShardSpecs(other, model), gc.DeepEquals, other)
-	other.Disable = false
+	other.Disable, other.Pause = false, false
 	c.Check(UnionShardSpecs(model, other), gc.DeepEquals, model)
 
 	c.Check(IntersectShardSpecs(model, model), gc.DeepEquals, model)
@@ -188,13 +265,24 @@ func (s *SpecSuite) TestConsumerSpecValidationCases(c *gc.C) {
 			Id:       pb.ProcessSpec_ID{Zone: "not valid", Suffix: "name"},
 			Endpoint: "http://foo",
 		},
-		ShardLimit: 5,
+		ShardLimit:     5,
+		CapacityWeight: 2,
 	}
 	c.Check(spec.Validate(), gc.ErrorMatches, `Id.Zone: not a valid token \(not valid\)`)
 	spec.Id.Zone = "zone"
 
 	c.Check(spec.Validate(), gc.IsNil)
 	c.Check(spec.ItemLimit(), gc.Equals, 5)
+	c.Check(spec.ItemWeight(), gc.Equals, 2)
+	c.Check(spec.IsDraining(), gc.Equals, false)
+
+	spec.CapacityWeight = 1 << 11
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid CapacityWeight \(2048; expected 0 <= CapacityWeight <= 1024\)`)
+	spec.CapacityWeight = 1 << 10
+	c.Check(spec.Validate(), gc.IsNil)
+
+	spec.MarkDraining()
+	c.Check(spec.IsDraining(), gc.Equals, true)
 }
 
 func (s *SpecSuite) TestReplicaStatusValidationCases(c *gc.C) {
@@ -259,6 +347,19 @@ func (s *SpecSuite) TestStatResponseValidationCases(c *gc.C) {
 	c.Check(resp.Validate(), gc.ErrorMatches, `Offsets\[a/journal\]: invalid offset \(-456; expected >= 0\)`)
 	resp.Offsets["a/journal"] = 789
 
+	resp.SourceLags = []SourceLag{{Journal: "invalid journal"}}
+	c.Check(resp.Validate(), gc.ErrorMatches, `SourceLags\[0\].Journal: not a valid token \(invalid journal\)`)
+	resp.SourceLags[0].Journal = "a/journal"
+	resp.SourceLags[0].ReadOffset = -1
+	c.Check(resp.Validate(), gc.ErrorMatches, `SourceLags\[0\]: invalid ReadOffset \(-1; expected >= 0\)`)
+	resp.SourceLags[0].ReadOffset = 789
+	resp.SourceLags[0].WriteHead = -1
+	c.Check(resp.Validate(), gc.ErrorMatches, `SourceLags\[0\]: invalid WriteHead \(-1; expected >= 0\)`)
+	resp.SourceLags[0].WriteHead = 1000
+	resp.SourceLags[0].Lag = -1
+	c.Check(resp.Validate(), gc.ErrorMatches, `SourceLags\[0\]: invalid Lag \(-1; expected >= 0\)`)
+	resp.SourceLags[0].Lag = 211
+
 	c.Check(resp.Validate(), gc.IsNil)
 }
 
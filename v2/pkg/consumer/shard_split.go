@@ -0,0 +1,591 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/allocator"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pkg/errors"
+)
+
+// KeyedApplication is an optional interface of an Application which maps
+// messages to keys. Only a KeyedApplication may consume Shards having a key
+// range, and its Shards may be split and merged.
+type KeyedApplication interface {
+	// MessageKey appends the key of |msg| to |b|, and returns the result.
+	MessageKey(msg message.Message, b []byte) []byte
+}
+
+// ShardMerger is an optional interface of an Application which merges the
+// state of a Shard with that of a sibling Shard. It's required of an
+// Application whose Shards are merged by MergeShards.
+type ShardMerger interface {
+	// MergeStore merges the state of a sibling Shard, recovered into directory
+	// |dir| from the sibling's recovery log, into the Shard |store|. It returns
+	// the Journal offsets of the sibling's state. The merged Shard resumes each
+	// Journal from the lesser of its offset and that of the sibling, and skips
+	// messages of the key range of the Shard which read further that it has
+	// already consumed. Broadcast Sources are consumed by both Shards and
+	// can't be distinguished by key: their messages may be consumed again.
+	MergeStore(shard Shard, store Store, dir string) (map[pb.Journal]int64, error)
+}
+
+// SplitShard splits the key range of Shard |parent| at its midpoint. The
+// parent retains the lower half of its range, and a new Shard |child| having
+// the upper half is created, which is otherwise a copy of the parent's
+// ShardSpec. The child is seeded with the parent's recovery log, and recovers
+// the parent's state and Journal offsets into its own recovery log, which
+// must already exist.
+//
+// A disabled parent cannot be split, as the child would be disabled as well
+// and would never recover its seed. SplitShard first disables the parent and
+// waits for its assignments to be removed. Within a single Etcd transaction, it then narrows the parent's
+// range, creates the child, and writes the child's seed. Once the child has
+// recovered its seed, the parent is re-enabled. Parent and child thereby
+// each resume from the parent's final state, and each message is consumed
+// by just one of them. If SplitShard fails after the parent is disabled,
+// the parent may be re-enabled by updating its ShardSpec.
+func SplitShard(ctx context.Context, etcd *clientv3.Client, ks *keyspace.KeySpace, parent, child ShardID) error {
+	var spec, rev, err = fetchShardSpec(ctx, etcd, ks, parent)
+	if err != nil {
+		return err
+	}
+	var begin, end = uint64(spec.KeyBegin), uint64(spec.KeyEnd)
+	if end == 0 {
+		end = 1 << 32
+	}
+	if spec.Disable {
+		return errors.Errorf("shard %s is disabled, and cannot be split", parent)
+	} else if end-begin < 2 {
+		return errors.Errorf("key range of shard %s cannot be split", parent)
+	}
+	var mid = begin + (end-begin)/2
+
+	var childSpec = *spec
+	childSpec.Id = child
+	childSpec.KeyBegin = uint32(mid)
+
+	if err = childSpec.Validate(); err != nil {
+		return extendErr(err, "validating child ShardSpec")
+	}
+
+	if rev, err = disableAndDrain(ctx, etcd, ks, spec, rev); err != nil {
+		return err
+	}
+	seed, err := buildSeed(ctx, etcd, spec, false)
+	if err != nil {
+		return err
+	}
+	spec.KeyEnd = uint32(mid)
+
+	var parentKey, childKey = allocator.ItemKey(ks, parent.String()), allocator.ItemKey(ks, child.String())
+
+	if err = commitTxn(ctx, etcd, []clientv3.Cmp{
+		clientv3.Compare(clientv3.ModRevision(parentKey), "=", rev),
+		clientv3.Compare(clientv3.ModRevision(spec.HintSeedKey()), "=", 0),
+		clientv3.Compare(clientv3.ModRevision(childKey), "=", 0),
+		clientv3.Compare(clientv3.ModRevision(childSpec.HintSeedKey()), "=", 0),
+	}, []clientv3.Op{
+		clientv3.OpPut(parentKey, spec.MarshalString()),
+		clientv3.OpPut(childKey, childSpec.MarshalString()),
+		clientv3.OpPut(childSpec.HintSeedKey(), seed),
+	}); err != nil {
+		return extendErr(err, "splitting shard %s", parent)
+	}
+
+	// Await the child's removal of its recovered seed, and then re-enable the parent.
+	for {
+		if resp, err := etcd.Get(ctx, childSpec.HintSeedKey(), clientv3.WithCountOnly()); err != nil {
+			return err
+		} else if resp.Count == 0 {
+			break
+		}
+		select {
+		case <-time.After(splitPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if spec, rev, err = fetchShardSpec(ctx, etcd, ks, parent); err != nil {
+		return err
+	}
+	spec.Disable = false
+
+	if err = commitTxn(ctx, etcd, []clientv3.Cmp{
+		clientv3.Compare(clientv3.ModRevision(parentKey), "=", rev),
+	}, []clientv3.Op{
+		clientv3.OpPut(parentKey, spec.MarshalString()),
+	}); err != nil {
+		return extendErr(err, "re-enabling shard %s", parent)
+	}
+	return nil
+}
+
+// MergeShards merges Shard |rhs| into Shard |lhs|, which must have adjacent
+// key ranges and the same Sources. |lhs| is extended to cover the key range
+// of |rhs|, and |rhs| is deleted. |lhs| is seeded with the recovery log of
+// |rhs|, and upon its next recovery, merges the state of |rhs| into its own
+// via its ShardMerger Application. The Store of |lhs| must be a
+// CheckpointStore, which records the merge.
+//
+// MergeShards first disables both Shards and waits for their assignments
+// to be removed. Within a single Etcd transaction, it then extends and
+// re-enables |lhs|, deletes |rhs|, and writes the seed of |lhs|.
+func MergeShards(ctx context.Context, etcd *clientv3.Client, ks *keyspace.KeySpace, lhs, rhs ShardID) error {
+	var lSpec, lRev, err = fetchShardSpec(ctx, etcd, ks, lhs)
+	if err != nil {
+		return err
+	}
+	rSpec, rRev, err := fetchShardSpec(ctx, etcd, ks, rhs)
+	if err != nil {
+		return err
+	}
+	if lSpec.KeyEnd == 0 || lSpec.KeyEnd != rSpec.KeyBegin {
+		return errors.Errorf("key ranges of shards %s and %s are not adjacent", lhs, rhs)
+	} else if !sourcesEq(lSpec.Sources, rSpec.Sources) {
+		return errors.Errorf("sources of shards %s and %s differ", lhs, rhs)
+	}
+	var disabled = lSpec.Disable
+
+	if lRev, err = disableAndDrain(ctx, etcd, ks, lSpec, lRev); err != nil {
+		return err
+	} else if rRev, err = disableAndDrain(ctx, etcd, ks, rSpec, rRev); err != nil {
+		return err
+	}
+	seed, err := buildSeed(ctx, etcd, rSpec, true)
+	if err != nil {
+		return err
+	}
+	lSpec.KeyEnd, lSpec.Disable = rSpec.KeyEnd, disabled
+
+	var lKey, rKey = allocator.ItemKey(ks, lhs.String()), allocator.ItemKey(ks, rhs.String())
+	var ops = []clientv3.Op{
+		clientv3.OpPut(lKey, lSpec.MarshalString()),
+		clientv3.OpDelete(rKey),
+		clientv3.OpPut(lSpec.HintSeedKey(), seed),
+		clientv3.OpDelete(rSpec.HintPrimaryKey()),
+	}
+	for _, key := range rSpec.HintBackupKeys() {
+		ops = append(ops, clientv3.OpDelete(key))
+	}
+
+	if err = commitTxn(ctx, etcd, []clientv3.Cmp{
+		clientv3.Compare(clientv3.ModRevision(lKey), "=", lRev),
+		clientv3.Compare(clientv3.ModRevision(rKey), "=", rRev),
+		clientv3.Compare(clientv3.ModRevision(lSpec.HintSeedKey()), "=", 0),
+		clientv3.Compare(clientv3.ModRevision(rSpec.HintSeedKey()), "=", 0),
+	}, ops); err != nil {
+		return extendErr(err, "merging shards %s and %s", lhs, rhs)
+	}
+	return nil
+}
+
+// shardSeed is a recovery log which seeds the state of a Shard. It's written
+// to the Shard's HintSeedKey by SplitShard, MergeShards or ImportSnapshot,
+// and is removed by the Shard upon recovering the seed.
+type shardSeed struct {
+	// Hints of the seeding recovery log.
+	Hints recoverylog.FSMHints `json:"hints"`
+	// If Merge, the seeded state is merged with that of the Shard by its
+	// ShardMerger Application. Otherwise it replaces the Shard's state.
+	Merge bool `json:"merge"`
+	// Key range of the seeding Shard, if Merge. The remainder of the merged
+	// Shard's key range is that of the Shard itself, prior to the merge.
+	KeyBegin uint32 `json:"key_begin,omitempty"`
+	KeyEnd   uint32 `json:"key_end,omitempty"`
+	// Etcd ModRevision of the seed.
+	revision int64
+}
+
+// recoverSeed recovers a seed of the Shard, if one exists, into the
+// Shard's Recorder and local directory |dir|. A replacing seed must be
+// recovered before the Store of the Shard is initialized (|store| is nil),
+// and a merged seed after. recoverSeed returns whether a seed was recovered.
+func recoverSeed(shard Shard, app Application, store Store, rec *recoverylog.Recorder,
+	dir string, etcd *clientv3.Client) (bool, error) {
+
+	var seed, err = fetchSeed(shard.Context(), shard.Spec(), etcd)
+	if err != nil {
+		return false, err
+	} else if seed == nil || seed.Merge != (store != nil) {
+		return false, nil
+	}
+
+	// Play back the seeding log into a temporary directory. The log is no
+	// longer written to, so we simply read through its current write head.
+	seedDir, err := ioutil.TempDir("", shard.Spec().Id.String()+"-seed-")
+	if err != nil {
+		return false, extendErr(err, "creating seed directory")
+	}
+	defer os.RemoveAll(seedDir)
+
+	var pl = recoverylog.NewPlayer()
+	pl.FinishAtWriteHead()
+
+	if err = pl.Play(shard.Context(), seed.Hints, seedDir, shard.JournalClient()); err != nil {
+		return false, extendErr(err, "playing seed log %s", seed.Hints.Log)
+	}
+
+	if !seed.Merge {
+		if err = recoverylog.CopyDirectory(rec, seedDir, dir); err != nil {
+			return false, extendErr(err, "copying seed")
+		}
+	} else if err = mergeSeed(shard, app, store, seed, seedDir); err != nil {
+		return false, err
+	}
+	return true, clearSeed(shard, rec, etcd)
+}
+
+// mergeSeed merges the seed recovered into |seedDir| into the Shard |store|,
+// if it's not already merged. The merged seed is recorded as a Checkpoint of
+// the |store| within the same Flush as the merge itself, such that it's not
+// merged again should the Shard fail before the seed is cleared.
+func mergeSeed(shard Shard, app Application, store Store, seed *shardSeed, seedDir string) error {
+	var cs, ok = store.(CheckpointStore)
+	if !ok {
+		return errors.New("Store is not a CheckpointStore, and cannot merge a seed")
+	}
+	var prior mergedSeed
+
+	if b, ok := cs.Checkpoints().Get(mergedSeedCheckpoint); ok {
+		if err := json.Unmarshal(b, &prior); err != nil {
+			return extendErr(err, "decoding merged seed Checkpoint")
+		} else if prior.Revision == seed.revision {
+			return nil // Already merged.
+		}
+	}
+	merger, ok := app.(ShardMerger)
+	if !ok {
+		return errors.New("Application is not a ShardMerger, and cannot merge a seed")
+	}
+	seedOffsets, err := merger.MergeStore(shard, store, seedDir)
+	if err != nil {
+		return extendErr(err, "app.MergeStore")
+	}
+	offsets, err := store.FetchJournalOffsets()
+	if err != nil {
+		return extendErr(err, "fetching journal offsets")
+	}
+	var merged = mergedSeed{
+		Revision:  seed.revision,
+		Frontiers: mergeOffsets(shard.Spec(), seed, offsets, seedOffsets),
+	}
+	// Frontiers of a prior merge remain in effect until they're read through.
+	// Those of the seeding Shard itself are not known, and are not carried.
+	for _, f := range prior.Frontiers {
+		if f.Offset > offsets[f.Journal] {
+			merged.Frontiers = append(merged.Frontiers, f)
+		}
+	}
+
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return extendErr(err, "encoding merged seed Checkpoint")
+	}
+	cs.Checkpoints().Set(mergedSeedCheckpoint, b)
+
+	if err = store.Flush(offsets); err != nil {
+		return extendErr(err, "store.Flush")
+	}
+	var txn = store.Recorder().StrongBarrier()
+	<-txn.Done()
+	return txn.Err()
+}
+
+// mergedSeed is recorded as Checkpoint mergedSeedCheckpoint of a Shard upon
+// merging a seed.
+type mergedSeed struct {
+	// Etcd ModRevision of the merged seed.
+	Revision int64 `json:"revision"`
+	// Frontiers of Journals which one of the merged Shards read further than
+	// the other.
+	Frontiers []mergeFrontier `json:"frontiers,omitempty"`
+}
+
+// mergeFrontier is an offset of a Journal, below which messages within a
+// key range were consumed prior to a merge.
+type mergeFrontier struct {
+	Journal  pb.Journal `json:"journal"`
+	KeyBegin uint32     `json:"key_begin"`
+	KeyEnd   uint32     `json:"key_end"` // Zero if unbounded.
+	Offset   int64      `json:"offset"`
+}
+
+// mergeOffsets updates |offsets| of a Shard |spec| to the lesser of each
+// Journal offset of the Shard and of its merged |seed| (an absent offset is
+// zero). It returns frontiers of the key ranges having read further.
+func mergeOffsets(spec *ShardSpec, seed *shardSeed, offsets, seedOffsets map[pb.Journal]int64) []mergeFrontier {
+	for journal := range seedOffsets {
+		if _, ok := offsets[journal]; !ok {
+			offsets[journal] = 0
+		}
+	}
+	var out []mergeFrontier
+
+	for journal, offset := range offsets {
+		var f = mergeFrontier{Journal: journal}
+
+		if o := seedOffsets[journal]; o < offset {
+			f.KeyBegin, f.KeyEnd, f.Offset = spec.KeyBegin, seed.KeyBegin, offset
+			offsets[journal] = o
+		} else if o > offset {
+			f.KeyBegin, f.KeyEnd, f.Offset = seed.KeyBegin, seed.KeyEnd, o
+		} else {
+			continue
+		}
+		if !isBroadcastSource(spec, journal) {
+			out = append(out, f)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Journal < out[j].Journal })
+	return out
+}
+
+// fetchMergeFrontiers returns the frontiers of seeds merged into |store|.
+func fetchMergeFrontiers(store Store) ([]mergeFrontier, error) {
+	var cs, ok = store.(CheckpointStore)
+	if !ok {
+		return nil, nil
+	}
+	var merged mergedSeed
+
+	if b, ok := cs.Checkpoints().Get(mergedSeedCheckpoint); !ok {
+		return nil, nil
+	} else if err := json.Unmarshal(b, &merged); err != nil {
+		return nil, extendErr(err, "decoding merged seed Checkpoint")
+	}
+	return merged.Frontiers, nil
+}
+
+// fetchSeed retrieves the seed of the ShardSpec, or returns nil if there is none.
+func fetchSeed(ctx context.Context, spec *ShardSpec, etcd *clientv3.Client) (*shardSeed, error) {
+	var resp, err = etcd.Get(ctx, spec.HintSeedKey())
+	if err != nil {
+		return nil, extendErr(err, "fetching ShardSpec.HintSeedKey")
+	} else if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	var seed = &shardSeed{revision: resp.Kvs[0].ModRevision}
+
+	if err = json.Unmarshal(resp.Kvs[0].Value, seed); err != nil {
+		return nil, extendErr(err, "unmarshal shardSeed")
+	} else if _, err = recoverylog.NewFSM(seed.Hints); err != nil {
+		return nil, extendErr(err, "validating seed FSMHints")
+	}
+	return seed, nil
+}
+
+// clearSeed writes hints of the Recorder, which reflect the recovered seed,
+// into the primary hint key of the Shard and removes its seed. It's
+// conditioned on the Shard's Assignment still being in effect.
+func clearSeed(shard Shard, rec *recoverylog.Recorder, etcd *clientv3.Client) error {
+	var hints, err = rec.BuildHints()
+	if err != nil {
+		return extendErr(err, "building FSMHints")
+	}
+	val, err := json.Marshal(hints)
+	if err != nil {
+		return extendErr(err, "marshal FSMHints")
+	}
+	var asn = shard.Assignment()
+
+	if _, err = etcd.Txn(shard.Context()).
+		If(clientv3.Compare(clientv3.CreateRevision(string(asn.Raw.Key)), "=", asn.Raw.CreateRevision)).
+		Then(
+			clientv3.OpPut(shard.Spec().HintPrimaryKey(), string(val)),
+			clientv3.OpDelete(shard.Spec().HintSeedKey()),
+		).
+		Commit(); err != nil {
+		return extendErr(err, "clearing seed")
+	}
+	return nil
+}
+
+// shardKeyFilter returns a function which matches messages of |journal|
+// within |spec|'s key range, or nil if |spec| has no key range or |journal|
+// is a broadcast Source of |spec|.
+func shardKeyFilter(spec *ShardSpec, journal pb.Journal, app Application) (func(message.Message) bool, error) {
+	if spec.KeyBegin == 0 && spec.KeyEnd == 0 {
+		return nil, nil
+	} else if isBroadcastSource(spec, journal) {
+		return nil, nil
+	}
+	var keyed, ok = asKeyedApplication(app)
+	if !ok {
+		return nil, errors.Errorf("shard %s has a key range, but Application is not a KeyedApplication", spec.Id)
+	}
+	var hash = messageKeyHasher(keyed)
+
+	return func(msg message.Message) bool {
+		return inKeyRange(hash(msg), spec.KeyBegin, spec.KeyEnd)
+	}, nil
+}
+
+// mergeFilter returns a function which matches messages of |journal| at
+// offsets which were consumed prior to the merge of |frontiers|, or nil if
+// |journal| has no frontiers.
+func mergeFilter(spec *ShardSpec, journal pb.Journal, app Application,
+	frontiers []mergeFrontier) (func(offset int64, msg message.Message) bool, error) {
+
+	var fs []mergeFrontier
+	for _, f := range frontiers {
+		if f.Journal == journal {
+			fs = append(fs, f)
+		}
+	}
+	if len(fs) == 0 {
+		return nil, nil
+	}
+	var keyed, ok = asKeyedApplication(app)
+	if !ok {
+		return nil, errors.Errorf("shard %s was merged, but Application is not a KeyedApplication", spec.Id)
+	}
+	var hash = messageKeyHasher(keyed)
+
+	return func(offset int64, msg message.Message) bool {
+		var sum *uint32
+
+		for _, f := range fs {
+			if offset >= f.Offset {
+				continue
+			} else if sum == nil {
+				var s = hash(msg)
+				sum = &s
+			}
+			if inKeyRange(*sum, f.KeyBegin, f.KeyEnd) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// messageKeyHasher returns a function which hashes the key of a message.
+func messageKeyHasher(keyed KeyedApplication) func(message.Message) uint32 {
+	var buf []byte
+
+	return func(msg message.Message) uint32 {
+		buf = keyed.MessageKey(msg, buf[:0])
+
+		var h = fnv.New32a()
+		_, _ = h.Write(buf)
+		return h.Sum32()
+	}
+}
+
+// inKeyRange returns whether |sum| is within the range [|begin|, |end|),
+// where an |end| of zero is unbounded.
+func inKeyRange(sum, begin, end uint32) bool {
+	return sum >= begin && (end == 0 || sum < end)
+}
+
+// isBroadcastSource returns whether |journal| is a broadcast Source of |spec|.
+func isBroadcastSource(spec *ShardSpec, journal pb.Journal) bool {
+	for _, src := range spec.Sources {
+		if src.Journal == journal && src.Broadcast {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchShardSpec retrieves the ShardSpec |id| and its Etcd ModRevision.
+func fetchShardSpec(ctx context.Context, etcd *clientv3.Client, ks *keyspace.KeySpace,
+	id ShardID) (*ShardSpec, int64, error) {
+
+	var resp, err = etcd.Get(ctx, allocator.ItemKey(ks, id.String()))
+	if err != nil {
+		return nil, 0, extendErr(err, "fetching ShardSpec %s", id)
+	} else if len(resp.Kvs) == 0 {
+		return nil, 0, errors.Errorf("shard %s does not exist", id)
+	}
+	var spec = new(ShardSpec)
+
+	if err = spec.Unmarshal(resp.Kvs[0].Value); err != nil {
+		return nil, 0, extendErr(err, "decoding ShardSpec %s", id)
+	}
+	return spec, resp.Kvs[0].ModRevision, nil
+}
+
+// disableAndDrain disables ShardSpec |spec| having ModRevision |rev|, if it's
+// not already disabled, and then blocks until the Shard has no Assignments.
+// It returns the ModRevision of the disabled ShardSpec.
+func disableAndDrain(ctx context.Context, etcd *clientv3.Client, ks *keyspace.KeySpace,
+	spec *ShardSpec, rev int64) (int64, error) {
+
+	if !spec.Disable {
+		var key = allocator.ItemKey(ks, spec.Id.String())
+		var disabled = *spec
+		disabled.Disable = true
+
+		var resp, err = etcd.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", rev)).
+			Then(clientv3.OpPut(key, disabled.MarshalString())).
+			Commit()
+
+		if err != nil {
+			return 0, extendErr(err, "disabling shard %s", spec.Id)
+		} else if !resp.Succeeded {
+			return 0, errors.Errorf("shard %s was modified concurrently", spec.Id)
+		}
+		rev = resp.Header.Revision
+	}
+
+	var prefix = allocator.ItemAssignmentsPrefix(ks, spec.Id.String())
+	for {
+		if resp, err := etcd.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithCountOnly()); err != nil {
+			return 0, extendErr(err, "fetching assignments of shard %s", spec.Id)
+		} else if resp.Count == 0 {
+			return rev, nil
+		}
+		select {
+		case <-time.After(splitPollInterval):
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// buildSeed returns an encoded shardSeed of the recovery log of |spec|.
+func buildSeed(ctx context.Context, etcd *clientv3.Client, spec *ShardSpec, merge bool) (string, error) {
+	var h, err = fetchHints(ctx, spec, etcd)
+	if err != nil {
+		return "", extendErr(err, "fetching hints of shard %s", spec.Id)
+	}
+	var seed = shardSeed{Hints: pickFirstHints(h), Merge: merge}
+	if merge {
+		seed.KeyBegin, seed.KeyEnd = spec.KeyBegin, spec.KeyEnd
+	}
+	b, err := json.Marshal(seed)
+	if err != nil {
+		return "", extendErr(err, "marshal shardSeed")
+	}
+	return string(b), nil
+}
+
+// commitTxn commits an Etcd transaction of |ops| conditioned on |cmps|, and
+// returns an error if it fails or its conditions aren't met.
+func commitTxn(ctx context.Context, etcd *clientv3.Client, cmps []clientv3.Cmp, ops []clientv3.Op) error {
+	var resp, err = etcd.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	if err != nil {
+		return err
+	} else if !resp.Succeeded {
+		return errors.New("etcd transaction checks failed (shards were modified concurrently)")
+	}
+	return nil
+}
+
+// mergedSeedCheckpoint is the Checkpoint name of a Shard's mergedSeed.
+const mergedSeedCheckpoint = ".merged-seed"
+
+var splitPollInterval = time.Second
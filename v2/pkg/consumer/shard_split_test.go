@@ -0,0 +1,266 @@
+package consumer
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type ShardSplitSuite struct{}
+
+func (s *ShardSplitSuite) TestKeyFilter(c *gc.C) {
+	var spec = makeShard(shardA)
+
+	// A Shard without a key range has no filter.
+	var filter, err = shardKeyFilter(spec, sourceA, newTestApplication())
+	c.Check(filter, gc.IsNil)
+	c.Check(err, gc.IsNil)
+
+	// A Shard with a key range requires a KeyedApplication.
+	spec.KeyEnd = 1 << 31
+	_, err = shardKeyFilter(spec, sourceA, newTestApplication())
+	c.Check(err, gc.ErrorMatches, `shard shard-A has a key range, but Application is not a KeyedApplication`)
+
+	var h = fnv.New32a()
+	_, _ = h.Write([]byte("a-key"))
+	var sum = h.Sum32()
+
+	for _, tc := range []struct {
+		begin, end uint32
+		expect     bool
+	}{
+		{sum, sum + 1, true},
+		{sum, 0, true},
+		{sum + 1, 0, false},
+		{0, sum, false},
+	} {
+		spec.KeyBegin, spec.KeyEnd = tc.begin, tc.end
+
+		filter, err = shardKeyFilter(spec, sourceA, keyedTestApplication{newTestApplication()})
+		c.Check(err, gc.IsNil)
+		c.Check(filter(&testMessage{Key: "a-key"}), gc.Equals, tc.expect)
+	}
+
+	// Broadcast Sources are not filtered on key.
+	spec.Sources[1].Broadcast = true // sourceB.
+	filter, err = shardKeyFilter(spec, sourceB, keyedTestApplication{newTestApplication()})
+	c.Check(filter, gc.IsNil)
+	c.Check(err, gc.IsNil)
+}
+
+func (s *ShardSplitSuite) TestSplitAndMerge(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	defer func(d time.Duration) { splitPollInterval = d }(splitPollInterval)
+	splitPollInterval = time.Millisecond
+
+	// A disabled Shard cannot be split.
+	var disabled = makeShard(shardA)
+	disabled.Disable = true
+	tf.allocateShard(c, disabled)
+
+	c.Check(SplitShard(tf.ctx, tf.etcd, tf.ks, shardA, shardB), gc.ErrorMatches,
+		`shard shard-A is disabled, and cannot be split`)
+
+	tf.allocateShard(c, makeShard(shardA)) // No assignments.
+
+	var doneCh = make(chan error)
+	go func() { doneCh <- SplitShard(tf.ctx, tf.etcd, tf.ks, shardA, shardB) }()
+
+	// Expect the child seed is written. Play the part of the child by
+	// removing it, as though it were recovered.
+	var child = makeShard(shardB)
+	for {
+		var resp, err = tf.etcd.Get(tf.ctx, child.HintSeedKey())
+		c.Assert(err, gc.IsNil)
+
+		if len(resp.Kvs) == 0 {
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		var seed shardSeed
+		c.Check(json.Unmarshal(resp.Kvs[0].Value, &seed), gc.IsNil)
+		c.Check(seed.Merge, gc.Equals, false)
+		c.Check(seed.Hints.Log, gc.Equals, makeShard(shardA).RecoveryLog())
+
+		_, err = tf.etcd.Delete(tf.ctx, child.HintSeedKey())
+		c.Assert(err, gc.IsNil)
+		break
+	}
+	c.Check(<-doneCh, gc.IsNil)
+
+	var parent, _, err = fetchShardSpec(tf.ctx, tf.etcd, tf.ks, shardA)
+	c.Assert(err, gc.IsNil)
+	c.Check(parent.Disable, gc.Equals, false)
+	c.Check([]uint32{parent.KeyBegin, parent.KeyEnd}, gc.DeepEquals, []uint32{0, 1 << 31})
+
+	child, _, err = fetchShardSpec(tf.ctx, tf.etcd, tf.ks, shardB)
+	c.Assert(err, gc.IsNil)
+	c.Check(child.Disable, gc.Equals, false)
+	c.Check([]uint32{child.KeyBegin, child.KeyEnd}, gc.DeepEquals, []uint32{1 << 31, 0})
+	c.Check(child.Sources, gc.DeepEquals, parent.Sources)
+
+	// Shards which are not adjacent cannot be merged.
+	c.Check(MergeShards(tf.ctx, tf.etcd, tf.ks, shardB, shardA), gc.ErrorMatches,
+		`key ranges of shards shard-B and shard-A are not adjacent`)
+
+	// Merge the child back into the parent.
+	c.Check(MergeShards(tf.ctx, tf.etcd, tf.ks, shardA, shardB), gc.IsNil)
+
+	parent, _, err = fetchShardSpec(tf.ctx, tf.etcd, tf.ks, shardA)
+	c.Assert(err, gc.IsNil)
+	c.Check(parent.Disable, gc.Equals, false)
+	c.Check([]uint32{parent.KeyBegin, parent.KeyEnd}, gc.DeepEquals, []uint32{0, 0})
+
+	_, _, err = fetchShardSpec(tf.ctx, tf.etcd, tf.ks, shardB)
+	c.Check(err, gc.ErrorMatches, `shard shard-B does not exist`)
+
+	seed, err := fetchSeed(tf.ctx, parent, tf.etcd)
+	c.Assert(err, gc.IsNil)
+	c.Check(seed.Merge, gc.Equals, true)
+	c.Check(seed.Hints.Log, gc.Equals, child.RecoveryLog())
+	c.Check([]uint32{seed.KeyBegin, seed.KeyEnd}, gc.DeepEquals, []uint32{1 << 31, 0})
+}
+
+func (s *ShardSplitSuite) TestMergeSeed(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	playAndComplete(c, r)
+	c.Check(r.store.Flush(map[pb.Journal]int64{sourceA: 100, sourceB: 200}), gc.IsNil)
+
+	const mid = 1 << 31
+	var app = mergingTestApplication{
+		testApplication: newTestApplication(),
+		offsets:         map[pb.Journal]int64{sourceA: 150, sourceB: 50, "source/C": 10},
+		mergedDir:       new(string),
+	}
+	var seed = &shardSeed{Merge: true, KeyBegin: mid, revision: 42}
+
+	// Expect each Journal resumes from the lesser offset, and the key range
+	// which read further is recorded with its offset.
+	c.Check(mergeSeed(r, app, r.store, seed, "seed/dir"), gc.IsNil)
+	c.Check(*app.mergedDir, gc.Equals, "seed/dir")
+
+	var offsets, err = r.store.FetchJournalOffsets()
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{sourceA: 100, sourceB: 50, "source/C": 0})
+
+	var expect = []mergeFrontier{
+		{Journal: sourceA, KeyBegin: mid, KeyEnd: 0, Offset: 150},
+		{Journal: sourceB, KeyBegin: 0, KeyEnd: mid, Offset: 200},
+		{Journal: "source/C", KeyBegin: mid, KeyEnd: 0, Offset: 10},
+	}
+	frontiers, err := fetchMergeFrontiers(r.store)
+	c.Check(err, gc.IsNil)
+	c.Check(frontiers, gc.DeepEquals, expect)
+
+	// The seed is not merged again.
+	*app.mergedDir = ""
+	c.Check(mergeSeed(r, app, r.store, seed, "seed/dir"), gc.IsNil)
+	c.Check(*app.mergedDir, gc.Equals, "")
+
+	// A further merge retains frontiers which are not yet read through.
+	app.offsets = nil
+	seed.revision = 43
+
+	c.Check(mergeSeed(r, app, r.store, seed, "seed/dir"), gc.IsNil)
+	frontiers, err = fetchMergeFrontiers(r.store)
+	c.Check(err, gc.IsNil)
+	c.Check(frontiers, gc.DeepEquals, append([]mergeFrontier{
+		{Journal: sourceA, KeyBegin: 0, KeyEnd: mid, Offset: 100},
+		{Journal: sourceB, KeyBegin: 0, KeyEnd: mid, Offset: 50},
+	}, expect...))
+}
+
+func (s *ShardSplitSuite) TestMergeFilter(c *gc.C) {
+	var spec = makeShard(shardA)
+	var app = keyedTestApplication{newTestApplication()}
+
+	var h = fnv.New32a()
+	_, _ = h.Write([]byte("a-key"))
+	var sum = h.Sum32()
+
+	var frontiers = []mergeFrontier{
+		{Journal: sourceA, KeyBegin: sum, KeyEnd: sum + 1, Offset: 100},
+		{Journal: sourceA, KeyBegin: sum + 1, KeyEnd: 0, Offset: 200},
+	}
+	var msg = &testMessage{Key: "a-key"}
+
+	var consumed, err = mergeFilter(spec, sourceA, app, frontiers)
+	c.Check(err, gc.IsNil)
+	c.Check(consumed(99, msg), gc.Equals, true)
+	c.Check(consumed(100, msg), gc.Equals, false)
+	c.Check(consumed(150, msg), gc.Equals, false) // Outside of the second frontier's range.
+
+	// A Journal without frontiers has no filter.
+	consumed, err = mergeFilter(spec, sourceB, app, frontiers)
+	c.Check(consumed, gc.IsNil)
+	c.Check(err, gc.IsNil)
+
+	// Frontiers are filtered on key, requiring a KeyedApplication.
+	_, err = mergeFilter(spec, sourceA, newTestApplication(), frontiers)
+	c.Check(err, gc.ErrorMatches, `shard shard-A was merged, but Application is not a KeyedApplication`)
+}
+
+func (s *ShardSplitSuite) TestWrappedApplications(c *gc.C) {
+	var spec = makeShard(shardA)
+	spec.KeyEnd = 1 << 31
+
+	var keyed = keyedTestApplication{newTestApplication()}
+	var policy = RetryPolicy{MaxAttempts: 2}
+
+	// Key ranges of Shards are filtered through wrapping Applications.
+	for _, app := range []Application{
+		WithRetries(keyed, policy),
+		WithDeadLetters(keyed, DeadLetterConfig{Journal: sourceB}),
+		WithRetries(WithDeadLetters(keyed, DeadLetterConfig{Journal: sourceB}), policy),
+	} {
+		var filter, err = shardKeyFilter(spec, sourceA, app)
+		c.Check(err, gc.IsNil)
+		c.Check(filter(&testMessage{Key: "a-key"}), gc.Equals, true)
+
+		c.Check(string(app.(KeyedApplication).MessageKey(&testMessage{Key: "a-key"}, nil)), gc.Equals, "a-key")
+	}
+	// A wrapped Application which isn't keyed still cannot consume a key range.
+	var _, err = shardKeyFilter(spec, sourceA, WithRetries(newTestApplication(), policy))
+	c.Check(err, gc.ErrorMatches, `shard shard-A has a key range, but Application is not a KeyedApplication`)
+
+	// Merges are delegated to a wrapped ShardMerger.
+	var merger = mergingTestApplication{
+		testApplication: newTestApplication(),
+		offsets:         map[pb.Journal]int64{sourceA: 123},
+		mergedDir:       new(string),
+	}
+	offsets, err := WithRetries(merger, policy).(ShardMerger).MergeStore(nil, nil, "a/dir")
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, merger.offsets)
+	c.Check(*merger.mergedDir, gc.Equals, "a/dir")
+
+	_, err = WithRetries(newTestApplication(), policy).(ShardMerger).MergeStore(nil, nil, "a/dir")
+	c.Check(err, gc.ErrorMatches, `Application is not a ShardMerger, and cannot merge a seed`)
+}
+
+type keyedTestApplication struct{ *testApplication }
+
+func (a keyedTestApplication) MessageKey(msg message.Message, b []byte) []byte {
+	return append(b, msg.(*testMessage).Key...)
+}
+
+type mergingTestApplication struct {
+	*testApplication
+	offsets   map[pb.Journal]int64
+	mergedDir *string
+}
+
+func (a mergingTestApplication) MergeStore(_ Shard, _ Store, dir string) (map[pb.Journal]int64, error) {
+	*a.mergedDir = dir
+	return a.offsets, nil
+}
+
+var _ = gc.Suite(&ShardSplitSuite{})
@@ -0,0 +1,106 @@
+package consumer
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// sourceFilter matches decoded messages against the Filter of a ShardSpec_Source.
+// Messages are mapped into a LabelSet having a label for each field path
+// referenced by the Filter, with values of the field's string representation.
+// Where a field is an array, a label value is added for each element.
+type sourceFilter struct {
+	selector pb.LabelSelector
+	fields   []string
+}
+
+// FieldAccessor is an optional interface of a Message, which provides values of
+// fields referenced by a ShardSpec_Source Filter. Messages which implement
+// FieldAccessor are filtered without their JSON encoding, which is otherwise
+// used to walk each field path.
+type FieldAccessor interface {
+	// FieldValues returns string values of the dot-separated field |path|.
+	// An array field returns a value for each element, and a field which
+	// isn't present returns no values.
+	FieldValues(path string) []string
+}
+
+// newSourceFilter returns a sourceFilter of the |journal| Source of
+// ShardSpec |spec|, or nil if the Source has no Filter.
+func newSourceFilter(spec *ShardSpec, journal pb.Journal) *sourceFilter {
+	for _, src := range spec.Sources {
+		if src.Journal != journal || src.Filter.IsEmpty() {
+			continue
+		}
+		var f = &sourceFilter{selector: src.Filter}
+
+		for _, set := range []pb.LabelSet{src.Filter.Include, src.Filter.Exclude} {
+			for _, l := range set.Labels {
+				if len(f.fields) == 0 || f.fields[len(f.fields)-1] != l.Name {
+					f.fields = append(f.fields, l.Name)
+				}
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// matches returns whether |msg| is matched by the sourceFilter.
+func (f *sourceFilter) matches(msg message.Message) (bool, error) {
+	var set pb.LabelSet
+
+	if fa, ok := msg.(FieldAccessor); ok {
+		for _, field := range f.fields {
+			for _, v := range fa.FieldValues(field) {
+				set.AddValue(field, v)
+			}
+		}
+		return f.selector.Matches(set), nil
+	}
+
+	var doc interface{}
+
+	if b, err := json.Marshal(msg); err != nil {
+		return false, err
+	} else if err = json.Unmarshal(b, &doc); err != nil {
+		return false, err
+	}
+
+	for _, field := range f.fields {
+		var v = doc
+		for _, key := range strings.Split(field, ".") {
+			if m, ok := v.(map[string]interface{}); ok {
+				v = m[key]
+			} else {
+				v = nil
+				break
+			}
+		}
+		if arr, ok := v.([]interface{}); ok {
+			for _, elem := range arr {
+				addFieldValue(&set, field, elem)
+			}
+		} else {
+			addFieldValue(&set, field, v)
+		}
+	}
+	return f.selector.Matches(set), nil
+}
+
+// addFieldValue adds a label |name| of the scalar field value |v| to |set|.
+// Values which aren't scalars are ignored.
+func addFieldValue(set *pb.LabelSet, name string, v interface{}) {
+	switch vv := v.(type) {
+	case string:
+		set.AddValue(name, vv)
+	case float64:
+		set.AddValue(name, strconv.FormatFloat(vv, 'f', -1, 64))
+	case bool:
+		set.AddValue(name, strconv.FormatBool(vv))
+	}
+}
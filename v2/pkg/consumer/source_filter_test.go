@@ -0,0 +1,98 @@
+package consumer
+
+import (
+	"errors"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type SourceFilterSuite struct{}
+
+func (s *SourceFilterSuite) TestFilterMatching(c *gc.C) {
+	type user struct {
+		Region string `json:"region"`
+	}
+	type event struct {
+		Type  string   `json:"type"`
+		Count int      `json:"count"`
+		Admin bool     `json:"admin"`
+		Tags  []string `json:"tags"`
+		User  user     `json:"user"`
+	}
+	var spec = &ShardSpec{Sources: []ShardSpec_Source{
+		{Journal: "a/journal"},
+		{Journal: "b/journal", Filter: mustSelector(c, "type in (click, view), user.region=us, admin!=true, tags!=spam")},
+	}}
+
+	// Expect no sourceFilter is returned for an unfiltered Source.
+	c.Check(newSourceFilter(spec, "a/journal"), gc.IsNil)
+	c.Check(newSourceFilter(spec, "c/journal"), gc.IsNil)
+
+	var f = newSourceFilter(spec, "b/journal")
+	c.Assert(f, gc.NotNil)
+
+	for _, tc := range []struct {
+		msg    event
+		expect bool
+	}{
+		{event{Type: "click", User: user{Region: "us"}}, true},
+		{event{Type: "view", User: user{Region: "us"}, Tags: []string{"a", "b"}}, true},
+		{event{Type: "purchase", User: user{Region: "us"}}, false}, // Type not included.
+		{event{Type: "click", User: user{Region: "eu"}}, false},    // Region not included.
+		{event{Type: "click", User: user{Region: "us"}, Tags: []string{"a", "spam"}}, false},
+		{event{Type: "click", User: user{Region: "us"}, Admin: true}, false},
+	} {
+		var ok, err = f.matches(&tc.msg)
+		c.Check(err, gc.IsNil)
+		c.Check(ok, gc.Equals, tc.expect, gc.Commentf("msg %#v", tc.msg))
+	}
+
+	// Numeric fields are matched by their string representation.
+	spec.Sources[1].Filter = mustSelector(c, "count=42")
+	f = newSourceFilter(spec, "b/journal")
+
+	var ok, _ = f.matches(&event{Count: 42})
+	c.Check(ok, gc.Equals, true)
+	ok, _ = f.matches(&event{Count: 7})
+	c.Check(ok, gc.Equals, false)
+}
+
+func (s *SourceFilterSuite) TestFieldAccessorMatching(c *gc.C) {
+	var spec = &ShardSpec{Sources: []ShardSpec_Source{
+		{Journal: "a/journal", Filter: mustSelector(c, "type=click, tags!=spam")},
+	}}
+	var f = newSourceFilter(spec, "a/journal")
+
+	// Expect FieldValues is used in place of the message's JSON encoding.
+	for _, tc := range []struct {
+		msg    accessorFixture
+		expect bool
+	}{
+		{accessorFixture{"type": {"click"}}, true},
+		{accessorFixture{"type": {"click"}, "tags": {"a", "b"}}, true},
+		{accessorFixture{"type": {"click"}, "tags": {"a", "spam"}}, false},
+		{accessorFixture{"type": {"view"}}, false},
+		{accessorFixture{}, false},
+	} {
+		var ok, err = f.matches(tc.msg)
+		c.Check(err, gc.IsNil)
+		c.Check(ok, gc.Equals, tc.expect, gc.Commentf("msg %#v", tc.msg))
+	}
+}
+
+// accessorFixture is a FieldAccessor which can't be encoded as JSON.
+type accessorFixture map[string][]string
+
+func (a accessorFixture) FieldValues(path string) []string { return a[path] }
+func (a accessorFixture) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("unexpected MarshalJSON")
+}
+
+func mustSelector(c *gc.C, s string) pb.LabelSelector {
+	var sel, err = pb.ParseLabelSelector(s)
+	c.Assert(err, gc.IsNil)
+	return sel
+}
+
+var _ = gc.Suite(&SourceFilterSuite{})
@@ -0,0 +1,108 @@
+package consumer
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// TimerStore is an optional interface of a Store which persists Timers
+// together with its other state, as part of each Store Flush.
+type TimerStore interface {
+	Store
+	// Timers of the Store.
+	Timers() *Timers
+}
+
+// SequencerStore is an optional interface of a Store which persists its
+// Sequencer together with its other state, as part of each Store Flush.
+type SequencerStore interface {
+	Store
+	// Sequencer of the Store.
+	Sequencer() *Sequencer
+}
+
+// CheckpointStore is an optional interface of a Store which persists its
+// Checkpoints together with its other state, as part of each Store Flush.
+type CheckpointStore interface {
+	Store
+	// Checkpoints of the Store.
+	Checkpoints() *Checkpoints
+}
+
+// storeExtensions are the Timers, Sequencer, and Checkpoints of a Store. A
+// Store which embeds storeExtensions, and persists them with each Flush, is
+// a TimerStore, a SequencerStore, and a CheckpointStore.
+type storeExtensions struct {
+	timers      *Timers
+	sequencer   *Sequencer
+	checkpoints *Checkpoints
+}
+
+func newStoreExtensions() storeExtensions {
+	return storeExtensions{
+		timers:      NewTimers(),
+		sequencer:   NewSequencer(),
+		checkpoints: NewCheckpoints(),
+	}
+}
+
+// Timers of the Store, which are persisted with each Flush.
+func (s *storeExtensions) Timers() *Timers { return s.timers }
+
+// Sequencer of the Store, which is persisted with each Flush.
+func (s *storeExtensions) Sequencer() *Sequencer { return s.sequencer }
+
+// Checkpoints of the Store, which are persisted with each Flush.
+func (s *storeExtensions) Checkpoints() *Checkpoints { return s.checkpoints }
+
+// ordered returns the storeExtensions in their order of encoding, by Stores
+// which sequentially encode them.
+func (s *storeExtensions) ordered() []interface{} {
+	return []interface{}{s.timers, s.sequencer, s.checkpoints}
+}
+
+// loadKeyed loads storeExtensions persisted under database keys by a previous
+// storeKeyed. |get| decodes the JSON value of a key into its argument, and
+// leaves it unchanged if the key doesn't exist.
+func (s *storeExtensions) loadKeyed(get func(key []byte, v interface{}) error) error {
+	if err := get(appendTimersKeyEncoding(nil), s.timers); err != nil {
+		return extendErr(err, "loading timers")
+	} else if err = get(appendSequencerKeyEncoding(nil), s.sequencer); err != nil {
+		return extendErr(err, "loading sequencer")
+	} else if err = get(appendCheckpointsKeyEncoding(nil), s.checkpoints); err != nil {
+		return extendErr(err, "loading checkpoints")
+	}
+	return nil
+}
+
+// storeKeyed encodes storeExtensions as JSON values of database keys, which
+// are passed to |set|.
+func (s *storeExtensions) storeKeyed(set func(key, value []byte) error) error {
+	for _, kv := range []struct {
+		key []byte
+		v   interface{}
+	}{
+		{appendTimersKeyEncoding(nil), s.timers},
+		{appendSequencerKeyEncoding(nil), s.sequencer},
+		{appendCheckpointsKeyEncoding(nil), s.checkpoints},
+	} {
+		if b, err := json.Marshal(kv.v); err != nil {
+			return err
+		} else if err = set(kv.key, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStoreExtensions returns an error if the Application requires an
+// optional capability which the Store doesn't implement.
+func checkStoreExtensions(app Application, store Store) error {
+	if _, ok := app.(TimerApplication); !ok {
+		// Pass.
+	} else if _, ok = store.(TimerStore); !ok {
+		return errors.Errorf("Application is a TimerApplication, but Store %T is not a TimerStore", store)
+	}
+	return nil
+}
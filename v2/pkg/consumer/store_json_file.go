@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -15,11 +16,14 @@ import (
 // JSONFileStore is a simple Store which materializes itself as a JSON-encoded
 // file. The store is careful to flush to a new temporary file which is then
 // moved to the well-known location: eg, a process failure cannot result in a
-// recovery of a partially written JSON file.
+// recovery of a partially written JSON file. JSONFileStore is a TimerStore,
+// a SequencerStore, and a CheckpointStore.
 type JSONFileStore struct {
 	// State is a user-provided instance which is un/marshal-able to JSON.
 	State interface{}
 
+	storeExtensions
+
 	dir       string
 	fs        afero.Fs
 	offsets   map[pb.Journal]int64
@@ -32,11 +36,12 @@ type JSONFileStore struct {
 // as JSONFileState.State.
 func NewJSONFileStore(rec *recoverylog.Recorder, dir string, state interface{}) (*JSONFileStore, error) {
 	var store = &JSONFileStore{
-		State:    state,
-		dir:      dir,
-		fs:       recoverylog.RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()},
-		offsets:  make(map[pb.Journal]int64),
-		recorder: rec,
+		State:           state,
+		dir:             dir,
+		fs:              recoverylog.RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()},
+		offsets:         make(map[pb.Journal]int64),
+		recorder:        rec,
+		storeExtensions: newStoreExtensions(),
 	}
 
 	var f, err = store.fs.Open(store.currentPath())
@@ -53,7 +58,14 @@ func NewJSONFileStore(rec *recoverylog.Recorder, dir string, state interface{})
 		return nil, extendErr(err, "decoding offsets")
 	} else if err = dec.Decode(state); err != nil {
 		return nil, extendErr(err, "decoding state")
-	} else if err = f.Close(); err != nil {
+	}
+	// Extensions are absent from state files written prior to their addition.
+	for _, ext := range store.ordered() {
+		if err = dec.Decode(ext); err != nil && err != io.EOF {
+			return nil, extendErr(err, "decoding %T", ext)
+		}
+	}
+	if err = f.Close(); err != nil {
 		return nil, extendErr(err, "closing state file")
 	} else if err = store.Flush(nil); err != nil {
 		return nil, extendErr(err, "flushing state")
@@ -99,7 +111,13 @@ func (s *JSONFileStore) Flush(offsets map[pb.Journal]int64) error {
 		return extendErr(err, "encoding offsets")
 	} else if err = enc.Encode(s.State); err != nil {
 		return extendErr(err, "encoding state")
-	} else if err = f.Close(); err != nil {
+	}
+	for _, ext := range s.ordered() {
+		if err = enc.Encode(ext); err != nil {
+			return extendErr(err, "encoding %T", ext)
+		}
+	}
+	if err = f.Close(); err != nil {
 		return extendErr(err, "closing state file")
 	} else if err = s.fs.Rename(s.nextPath(), s.currentPath()); err != nil {
 		return extendErr(err, "renaming next => current")
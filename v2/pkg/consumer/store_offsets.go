@@ -0,0 +1,45 @@
+package consumer
+
+import (
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/cockroachdb/cockroach/util/encoding"
+)
+
+// appendOffsetKeyEncoding encodes |name| into a database key representing
+// a consumer journal offset checkpoint. A |name| of "" will generate a
+// key which prefixes all other offset key encodings.
+func appendOffsetKeyEncoding(b []byte, journal pb.Journal) []byte {
+	b = encoding.EncodeNullAscending(b)
+	b = encoding.EncodeStringAscending(b, "mark")
+	if journal != "" {
+		b = encoding.EncodeStringAscending(b, journal.String())
+	}
+	return b
+}
+
+// appendOffsetValueEncoding encodes |offset| into a database value representing
+// a consumer journal offset checkpoint.
+func appendOffsetValueEncoding(b []byte, offset int64) []byte {
+	return encoding.EncodeVarintAscending(b, offset)
+}
+
+// appendTimersKeyEncoding encodes a database key representing the
+// persisted Timers of a consumer Store.
+func appendTimersKeyEncoding(b []byte) []byte {
+	b = encoding.EncodeNullAscending(b)
+	return encoding.EncodeStringAscending(b, "timers")
+}
+
+// appendSequencerKeyEncoding encodes a database key representing the
+// persisted Sequencer of a consumer Store.
+func appendSequencerKeyEncoding(b []byte) []byte {
+	b = encoding.EncodeNullAscending(b)
+	return encoding.EncodeStringAscending(b, "sequencer")
+}
+
+// appendCheckpointsKeyEncoding encodes a database key representing the
+// persisted Checkpoints of a consumer Store.
+func appendCheckpointsKeyEncoding(b []byte) []byte {
+	b = encoding.EncodeNullAscending(b)
+	return encoding.EncodeStringAscending(b, "checkpoints")
+}
@@ -0,0 +1,154 @@
+package consumer
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	"github.com/cockroachdb/cockroach/util/encoding"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	log "github.com/sirupsen/logrus"
+)
+
+// PebbleStore implements the Store interface using Pebble, a pure-Go
+// key/value store which is largely compatible with RocksDB. Unlike
+// RocksDBStore, PebbleStore requires no cgo and may be readily
+// cross-compiled. PebbleStore is a TimerStore, a SequencerStore, and a
+// CheckpointStore.
+type PebbleStore struct {
+	DB           *pebble.DB
+	Options      *pebble.Options
+	WriteBatch   *pebble.Batch
+	WriteOptions *pebble.WriteOptions
+
+	// Cache is a convenient mechanism for consumers to associate shard-specific,
+	// in-memory state with a PebbleStore, typically for performance reasons
+	// (eg, records to be reduced over multiple times in a consumer transaction,
+	// and written to the DB only once upon Flush). The representation of Cache
+	// is up to the consumer; it is not directly used by PebbleStore.
+	Cache interface{}
+
+	storeExtensions
+
+	rec *recoverylog.Recorder
+	dir string
+}
+
+// NewPebbleStore builds a PebbleStore which is prepared to open its database,
+// but has not yet done so. The caller may wish to further tweak Options,
+// and should then call Open to open the database.
+func NewPebbleStore(rec *recoverylog.Recorder, dir string) *PebbleStore {
+	return &PebbleStore{
+		Options: &pebble.Options{
+			FS: recoverylog.RecordedPebbleFS{Recorder: rec, FS: vfs.Default},
+		},
+		WriteOptions:    pebble.Sync,
+		storeExtensions: newStoreExtensions(),
+		rec:             rec,
+		dir:             dir,
+	}
+}
+
+// Open the Pebble DB. After Open, further updates to Options are ignored.
+func (s *PebbleStore) Open() (err error) {
+	// As with RocksDBStore, use a small MANIFEST limit to encourage more
+	// frequent compactions into new files, which would otherwise artificially
+	// inflate the recovery log horizon.
+	s.Options.MaxManifestFileSize = 1 << 17 // 131072 bytes.
+
+	if s.DB, err = pebble.Open(s.dir, s.Options); err != nil {
+		return
+	}
+	s.WriteBatch = s.DB.NewBatch()
+
+	// Load Timers, the Sequencer, and Checkpoints persisted by a previous
+	// Flush, if any.
+	err = s.loadKeyed(s.getJSON)
+	return
+}
+
+// getJSON decodes the JSON value of |key| into |v|. If |key| doesn't exist,
+// |v| is left unchanged.
+func (s *PebbleStore) getJSON(key []byte, v interface{}) error {
+	var b, closer, err = s.DB.Get(key)
+	if err == pebble.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	return json.Unmarshal(b, v)
+}
+
+// Recorder of the Pebble DB.
+func (s *PebbleStore) Recorder() *recoverylog.Recorder { return s.rec }
+
+// FetchJournalOffsets returns a map of Journals and offsets captured by the DB.
+func (s *PebbleStore) FetchJournalOffsets() (offsets map[pb.Journal]int64, err error) {
+	var prefix = appendOffsetKeyEncoding(nil, "")
+	offsets = make(map[pb.Journal]int64)
+
+	it, err := s.DB.NewIter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	for it.SeekGE(prefix); it.Valid() && bytes.HasPrefix(it.Key(), prefix); it.Next() {
+		var key, val = it.Key(), it.Value()
+
+		var name string
+		var offset int64
+
+		if _, name, err = encoding.DecodeStringAscending(key[len(prefix):], nil); err != nil {
+			return nil, extendErr(err, "decoding offset key %v", string(key))
+		} else if _, offset, err = encoding.DecodeVarintAscending(val); err != nil {
+			return nil, extendErr(err, "decoding offset %s value %x", name, val)
+		}
+		offsets[pb.Journal(name)] = offset
+	}
+	return
+}
+
+// Flush merges |offsets| into the WriteBatch, and atomically writes it to the DB.
+func (s *PebbleStore) Flush(offsets map[pb.Journal]int64) error {
+	// Persist updated journal offsets alongside other WriteBatch content.
+	for journal, offset := range offsets {
+		if err := s.WriteBatch.Set(
+			appendOffsetKeyEncoding(nil, journal),
+			appendOffsetValueEncoding(nil, offset), nil); err != nil {
+			return err
+		}
+	}
+	if err := s.storeKeyed(func(key, value []byte) error {
+		return s.WriteBatch.Set(key, value, nil)
+	}); err != nil {
+		return err
+	}
+	if err := s.DB.Apply(s.WriteBatch, s.WriteOptions); err != nil {
+		return err
+	}
+	s.WriteBatch.Reset()
+
+	return nil
+}
+
+// Destroy the PebbleStore.
+func (s *PebbleStore) Destroy() {
+	if s.DB != nil {
+		if err := s.DB.Close(); err != nil {
+			log.WithField("err", err).Error("failed to close Pebble DB")
+		}
+		s.DB = nil
+	}
+	if err := os.RemoveAll(s.dir); err != nil {
+		log.WithFields(log.Fields{
+			"dir": s.dir,
+			"err": err,
+		}).Error("failed to remove Pebble directory")
+	}
+}
@@ -0,0 +1,76 @@
+package consumer
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/cockroachdb/pebble/vfs"
+	gc "github.com/go-check/check"
+)
+
+type PebbleSuite struct{}
+
+func (s *PebbleSuite) TestWriteAndReadKeysAndOffsets(c *gc.C) {
+	var dir, err = ioutil.TempDir("", "pebble")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(dir)
+
+	// Replace recorded FS with regular one.
+	var store = NewPebbleStore(nil, dir)
+	store.Options.FS = vfs.Default
+
+	c.Assert(store.Open(), gc.IsNil)
+
+	c.Check(store.WriteBatch.Set([]byte("foo"), []byte("bar"), nil), gc.IsNil)
+	c.Check(store.WriteBatch.Set([]byte("baz"), []byte("bing"), nil), gc.IsNil)
+
+	c.Check(store.Flush(map[protocol.Journal]int64{
+		"journal/A": 1234,
+	}), gc.IsNil)
+
+	r, closer, err := store.DB.Get([]byte("foo"))
+	c.Assert(err, gc.IsNil)
+	c.Check(r, gc.DeepEquals, []byte("bar"))
+	c.Check(closer.Close(), gc.IsNil)
+
+	c.Check(store.Flush(map[protocol.Journal]int64{
+		"journal/B": 5678,
+	}), gc.IsNil)
+
+	offsets, err := store.FetchJournalOffsets()
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[protocol.Journal]int64{
+		"journal/A": 1234,
+		"journal/B": 5678,
+	})
+
+	store.Destroy()
+}
+
+func (s *PebbleSuite) TestCheckpointsAreWrittenWithOffsets(c *gc.C) {
+	var dir, err = ioutil.TempDir("", "pebble")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(dir)
+
+	var store = NewPebbleStore(nil, dir)
+	store.Options.FS = vfs.Default
+	c.Assert(store.Open(), gc.IsNil)
+
+	store.Checkpoints().Set("cursor", []byte("page-3"))
+	c.Check(store.Flush(map[protocol.Journal]int64{"journal/A": 1234}), gc.IsNil)
+	c.Check(store.DB.Close(), gc.IsNil)
+
+	// Re-open the DB. Expect the Checkpoints are recovered.
+	store = NewPebbleStore(nil, dir)
+	store.Options.FS = vfs.Default
+	c.Assert(store.Open(), gc.IsNil)
+
+	var v, ok = store.Checkpoints().Get("cursor")
+	c.Check(ok, gc.Equals, true)
+	c.Check(v, gc.DeepEquals, []byte("page-3"))
+
+	store.Destroy()
+}
+
+var _ = gc.Suite(&PebbleSuite{})
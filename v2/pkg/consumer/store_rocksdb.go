@@ -3,6 +3,7 @@
 package consumer
 
 import (
+	"encoding/json"
 	"os"
 
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
@@ -12,7 +13,8 @@ import (
 	rocks "github.com/tecbot/gorocksdb"
 )
 
-// RocksDBStore implements the Store interface.
+// RocksDBStore implements the Store interface. It's also a TimerStore, a
+// SequencerStore, and a CheckpointStore.
 type RocksDBStore struct {
 	DB           *rocks.DB
 	Env          *rocks.Env
@@ -34,8 +36,12 @@ type RocksDBStore struct {
 	// by RocksDBStore.
 	Cache interface{}
 
-	rec *recoverylog.Recorder
-	dir string
+	storeExtensions
+
+	rec        *recoverylog.Recorder
+	dir        string
+	blockCache *rocks.Cache
+	tableOpts  *rocks.BlockBasedTableOptions
 }
 
 // NewRocksDBStore builds a RocksDBStore which is prepared to open its database,
@@ -43,16 +49,55 @@ type RocksDBStore struct {
 // settings, and should then call Open to open the database.
 func NewRocksDBStore(rec *recoverylog.Recorder, dir string) *RocksDBStore {
 	return &RocksDBStore{
-		Env:          rocks.NewObservedEnv(recoverylog.RecordedRocksDB{Recorder: rec}),
-		Options:      rocks.NewDefaultOptions(),
-		ReadOptions:  rocks.NewDefaultReadOptions(),
-		WriteBatch:   rocks.NewWriteBatch(),
-		WriteOptions: rocks.NewDefaultWriteOptions(),
-		rec:          rec,
-		dir:          dir,
+		Env:             rocks.NewObservedEnv(recoverylog.RecordedRocksDB{Recorder: rec}),
+		Options:         rocks.NewDefaultOptions(),
+		ReadOptions:     rocks.NewDefaultReadOptions(),
+		WriteBatch:      rocks.NewWriteBatch(),
+		WriteOptions:    rocks.NewDefaultWriteOptions(),
+		storeExtensions: newStoreExtensions(),
+		rec:             rec,
+		dir:             dir,
 	}
 }
 
+// Configure Options of the RocksDBStore from RocksDBOptions of the |spec|
+// labels. Applications which wish to allow tuning of their RocksDBStores via
+// ShardSpec labels should call Configure before Open.
+func (s *RocksDBStore) Configure(spec *ShardSpec) error {
+	var o, err = RocksDBOptionsFromLabels(spec.LabelSet)
+	if err != nil {
+		return err
+	}
+	if o.BlockCacheSize != 0 {
+		s.blockCache = rocks.NewLRUCache(int(o.BlockCacheSize))
+		s.tableOpts = rocks.NewDefaultBlockBasedTableOptions()
+		s.tableOpts.SetBlockCache(s.blockCache)
+		s.Options.SetBlockBasedTableFactory(s.tableOpts)
+	}
+	if o.WriteBufferSize != 0 {
+		s.Options.SetWriteBufferSize(int(o.WriteBufferSize))
+	}
+	if o.Compression != "" {
+		s.Options.SetCompression(map[string]rocks.CompressionType{
+			"none":   rocks.NoCompression,
+			"snappy": rocks.SnappyCompression,
+			"zlib":   rocks.ZLibCompression,
+			"bz2":    rocks.Bz2Compression,
+			"lz4":    rocks.LZ4Compression,
+			"lz4hc":  rocks.LZ4HCCompression,
+			"zstd":   rocks.ZSTDCompression,
+		}[o.Compression])
+	}
+	if o.CompactionStyle != "" {
+		s.Options.SetCompactionStyle(map[string]rocks.CompactionStyle{
+			"level":     rocks.LevelCompactionStyle,
+			"universal": rocks.UniversalCompactionStyle,
+			"fifo":      rocks.FIFOCompactionStyle,
+		}[o.CompactionStyle])
+	}
+	return nil
+}
+
 // Open the RocksDB. After Open, further updates to Env or Options are ignored.
 func (s *RocksDBStore) Open() (err error) {
 	// The DB must use our recorded environment for file IO, and should initialize
@@ -69,10 +114,30 @@ func (s *RocksDBStore) Open() (err error) {
 	// to encourage more frequent compactions into new files.
 	s.Options.SetMaxManifestFileSize(1 << 17) // 131072 bytes.
 
-	s.DB, err = rocks.OpenDb(s.Options, s.dir)
+	if s.DB, err = rocks.OpenDb(s.Options, s.dir); err != nil {
+		return
+	}
+	// Load Timers, the Sequencer, and Checkpoints persisted by a previous
+	// Flush, if any.
+	err = s.loadKeyed(s.getJSON)
 	return
 }
 
+// getJSON decodes the JSON value of |key| into |v|. If |key| doesn't exist,
+// |v| is left unchanged.
+func (s *RocksDBStore) getJSON(key []byte, v interface{}) error {
+	var b, err = s.DB.Get(s.ReadOptions, key)
+	if err != nil {
+		return err
+	}
+	defer b.Free()
+
+	if !b.Exists() {
+		return nil
+	}
+	return json.Unmarshal(b.Data(), v)
+}
+
 // Recorder of the RocksDB.
 func (s *RocksDBStore) Recorder() *recoverylog.Recorder { return s.rec }
 
@@ -116,6 +181,12 @@ func (s *RocksDBStore) Flush(offsets map[pb.Journal]int64) error {
 			appendOffsetKeyEncoding(nil, journal),
 			appendOffsetValueEncoding(nil, offset))
 	}
+	if err := s.storeKeyed(func(key, value []byte) error {
+		s.WriteBatch.Put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
 	if err := s.DB.Write(s.WriteOptions, s.WriteBatch); err != nil {
 		return err
 	}
@@ -136,6 +207,12 @@ func (s *RocksDBStore) Destroy() {
 	s.WriteBatch.Destroy()
 	s.WriteOptions.Destroy()
 
+	if s.tableOpts != nil {
+		s.tableOpts.Destroy()
+	}
+	if s.blockCache != nil {
+		s.blockCache.Destroy()
+	}
 	if err := os.RemoveAll(s.dir); err != nil {
 		log.WithFields(log.Fields{
 			"dir": s.dir,
@@ -143,21 +220,3 @@ func (s *RocksDBStore) Destroy() {
 		}).Error("failed to remove RocksDB directory")
 	}
 }
-
-// appendOffsetKeyEncoding encodes |name| into a database key representing
-// a consumer journal offset checkpoint. A |name| of "" will generate a
-// key which prefixes all other offset key encodings.
-func appendOffsetKeyEncoding(b []byte, journal pb.Journal) []byte {
-	b = encoding.EncodeNullAscending(b)
-	b = encoding.EncodeStringAscending(b, "mark")
-	if journal != "" {
-		b = encoding.EncodeStringAscending(b, journal.String())
-	}
-	return b
-}
-
-// appendOffsetValueEncoding encodes |offset| into a database value representing
-// a consumer journal offset checkpoint.
-func appendOffsetValueEncoding(b []byte, offset int64) []byte {
-	return encoding.EncodeVarintAscending(b, offset)
-}
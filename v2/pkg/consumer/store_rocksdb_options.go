@@ -0,0 +1,69 @@
+package consumer
+
+import (
+	"strconv"
+
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// RocksDBOptions are tuning options of a RocksDBStore database. They're
+// typically drawn from the labels of a ShardSpec, allowing heavy Shards to
+// be tuned without changes to Application code. Zero-valued options are
+// left at RocksDB defaults.
+type RocksDBOptions struct {
+	// BlockCacheSize in bytes. See labels.RocksDBBlockCacheSize.
+	BlockCacheSize int64
+	// WriteBufferSize in bytes. See labels.RocksDBWriteBufferSize.
+	WriteBufferSize int64
+	// Compression of SST file blocks. See labels.RocksDBCompression.
+	Compression string
+	// CompactionStyle of SST files. See labels.RocksDBCompactionStyle.
+	CompactionStyle string
+}
+
+// RocksDBOptionsFromLabels returns RocksDBOptions of the LabelSet, or an
+// error if a label has an invalid value.
+func RocksDBOptionsFromLabels(set pb.LabelSet) (RocksDBOptions, error) {
+	var out RocksDBOptions
+	var err error
+
+	if out.BlockCacheSize, err = parseByteSize(set, labels.RocksDBBlockCacheSize); err != nil {
+		return out, err
+	} else if out.WriteBufferSize, err = parseByteSize(set, labels.RocksDBWriteBufferSize); err != nil {
+		return out, err
+	}
+
+	out.Compression = set.ValueOf(labels.RocksDBCompression)
+	out.CompactionStyle = set.ValueOf(labels.RocksDBCompactionStyle)
+
+	if _, ok := rocksDBCompressions[out.Compression]; !ok && out.Compression != "" {
+		return out, pb.NewValidationError("invalid %s (%s)", labels.RocksDBCompression, out.Compression)
+	} else if _, ok := rocksDBCompactionStyles[out.CompactionStyle]; !ok && out.CompactionStyle != "" {
+		return out, pb.NewValidationError("invalid %s (%s)", labels.RocksDBCompactionStyle, out.CompactionStyle)
+	}
+	return out, nil
+}
+
+// parseByteSize parses the value of label |name|, if present, as a positive
+// number of bytes.
+func parseByteSize(set pb.LabelSet, name string) (int64, error) {
+	var v = set.ValueOf(name)
+	if v == "" {
+		return 0, nil
+	}
+	var n, err = strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, pb.NewValidationError("invalid %s (%s; expected a positive number of bytes)", name, v)
+	}
+	return n, nil
+}
+
+var (
+	rocksDBCompressions = map[string]struct{}{
+		"none": {}, "snappy": {}, "zlib": {}, "bz2": {}, "lz4": {}, "lz4hc": {}, "zstd": {},
+	}
+	rocksDBCompactionStyles = map[string]struct{}{
+		"level": {}, "universal": {}, "fifo": {},
+	}
+)
@@ -0,0 +1,43 @@
+package consumer
+
+import (
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type RocksDBOptionsSuite struct{}
+
+func (s *RocksDBOptionsSuite) TestFromLabels(c *gc.C) {
+	var o, err = RocksDBOptionsFromLabels(pb.MustLabelSet("foo", "bar"))
+	c.Check(err, gc.IsNil)
+	c.Check(o, gc.Equals, RocksDBOptions{})
+
+	o, err = RocksDBOptionsFromLabels(pb.MustLabelSet(
+		labels.RocksDBBlockCacheSize, "268435456",
+		labels.RocksDBWriteBufferSize, "67108864",
+		labels.RocksDBCompression, "zstd",
+		labels.RocksDBCompactionStyle, "universal",
+	))
+	c.Check(err, gc.IsNil)
+	c.Check(o, gc.Equals, RocksDBOptions{
+		BlockCacheSize:  1 << 28,
+		WriteBufferSize: 1 << 26,
+		Compression:     "zstd",
+		CompactionStyle: "universal",
+	})
+
+	for _, tc := range []struct {
+		name, value, expect string
+	}{
+		{labels.RocksDBBlockCacheSize, "lots", `invalid app.gazette.dev/rocksdb-block-cache-size \(lots; expected a positive number of bytes\)`},
+		{labels.RocksDBWriteBufferSize, "-1", `invalid app.gazette.dev/rocksdb-write-buffer-size \(-1; expected a positive number of bytes\)`},
+		{labels.RocksDBCompression, "gzip", `invalid app.gazette.dev/rocksdb-compression \(gzip\)`},
+		{labels.RocksDBCompactionStyle, "tiered", `invalid app.gazette.dev/rocksdb-compaction-style \(tiered\)`},
+	} {
+		_, err = RocksDBOptionsFromLabels(pb.MustLabelSet(tc.name, tc.value))
+		c.Check(err, gc.ErrorMatches, tc.expect)
+	}
+}
+
+var _ = gc.Suite(&RocksDBOptionsSuite{})
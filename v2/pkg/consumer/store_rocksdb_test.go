@@ -5,6 +5,7 @@ package consumer
 import (
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/protocol"
 	gc "github.com/go-check/check"
@@ -50,4 +51,35 @@ func (s *RocksDBSuite) TestWriteAndReadKeysAndOffsets(c *gc.C) {
 	store.Destroy()
 }
 
+func (s *RocksDBSuite) TestExtensionsAreWrittenWithOffsets(c *gc.C) {
+	var dir, err = ioutil.TempDir("", "rocksdb")
+	c.Assert(err, gc.IsNil)
+	defer os.RemoveAll(dir)
+
+	var store = NewRocksDBStore(nil, dir)
+	store.Env = gorocksdb.NewDefaultEnv()
+	c.Assert(store.Open(), gc.IsNil)
+
+	var at = time.Unix(1500000000, 0)
+	store.Timers().Set(EventTime, "window", at)
+	store.Checkpoints().Set("cursor", []byte("page-3"))
+	c.Check(store.Flush(map[protocol.Journal]int64{"journal/A": 1234}), gc.IsNil)
+	store.DB.Close()
+
+	// Re-open the DB. Expect Timers and Checkpoints are recovered.
+	store = NewRocksDBStore(nil, dir)
+	store.Env = gorocksdb.NewDefaultEnv()
+	c.Assert(store.Open(), gc.IsNil)
+
+	var v, ok = store.Checkpoints().Get("cursor")
+	c.Check(ok, gc.Equals, true)
+	c.Check(v, gc.DeepEquals, []byte("page-3"))
+
+	recovered, ok := store.Timers().Get(EventTime, "window")
+	c.Check(ok, gc.Equals, true)
+	c.Check(recovered.Equal(at), gc.Equals, true)
+
+	store.Destroy()
+}
+
 var _ = gc.Suite(&RocksDBSuite{})
@@ -0,0 +1,204 @@
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/LiveRamp/gazette/v2/pkg/recoverylog"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// SQLStore is a Store of state which is held in a remote SQL database (eg,
+// PostgreSQL), rather than in local files recorded to the Shard recovery log.
+// Application writes are made through the current Transaction of the SQLStore,
+// and Journal offsets are committed within that same transaction to a
+// checkpoints table, such that application state and offsets always agree.
+//
+// The checkpoints table must already exist, and must have a schema
+// compatible with:
+//
+//	CREATE TABLE gazette_checkpoints (
+//	   shard_fqn  TEXT    PRIMARY KEY NOT NULL,
+//	   fence      INTEGER NOT NULL,
+//	   checkpoint TEXT    NOT NULL
+//	);
+//
+// Resumption after Shard re-assignment is two-phase. First, NewSQLStore
+// "fences" the Shard checkpoint by incrementing its fence. Any in-flight
+// transaction of a previous primary (which may not yet know it's been replaced)
+// will then fail to commit, as its fence is no longer current. Second,
+// FetchJournalOffsets returns offsets of the last committed checkpoint, from
+// which the new primary resumes.
+//
+// Flush commits only after all appends of the Shard which are pending at its
+// invocation (eg, of messages published by the Application within the consumer
+// transaction) have been acknowledged. A committed checkpoint thus never steps
+// past messages whose side-effects may yet be lost.
+//
+// Statements use numbered ($1, $2) placeholders. The Shard must still
+// have a recovery log, which is used only to sequence write barriers.
+type SQLStore struct {
+	// DB is the remote database. It's not closed by Destroy.
+	DB *sql.DB
+	// Table is the name of the checkpoints table.
+	Table string
+
+	ctx      context.Context
+	fqn      string
+	fence    int64
+	offsets  map[pb.Journal]int64
+	recorder *recoverylog.Recorder
+	txn      *sql.Tx
+}
+
+// NewSQLStore returns a SQLStore of |shard| using |db| and checkpoints |table|.
+// It fences the Shard's checkpoint, inserting it if it doesn't yet exist,
+// and loads its last committed offsets.
+func NewSQLStore(shard Shard, rec *recoverylog.Recorder, db *sql.DB, table string) (*SQLStore, error) {
+	var store = &SQLStore{
+		DB:       db,
+		Table:    table,
+		ctx:      shard.Context(),
+		fqn:      shard.Spec().Id.String(),
+		offsets:  make(map[pb.Journal]int64),
+		recorder: rec,
+	}
+	if err := store.restoreCheckpoint(); err != nil {
+		return nil, extendErr(err, "restoring checkpoint")
+	}
+	return store, nil
+}
+
+// Recorder of the SQLStore.
+func (s *SQLStore) Recorder() *recoverylog.Recorder { return s.recorder }
+
+// Transaction returns the current SQL transaction of the SQLStore, beginning
+// one if required. Application writes of the current consumer transaction
+// should be made through the returned Tx, which is committed by Flush.
+func (s *SQLStore) Transaction() (*sql.Tx, error) {
+	if s.txn != nil {
+		return s.txn, nil
+	}
+	var err error
+	if s.txn, err = s.DB.BeginTx(s.ctx, nil); err != nil {
+		return nil, extendErr(err, "beginning transaction")
+	}
+	return s.txn, nil
+}
+
+// FetchJournalOffsets returns offsets of the last committed checkpoint.
+func (s *SQLStore) FetchJournalOffsets() (map[pb.Journal]int64, error) {
+	var offsets = make(map[pb.Journal]int64)
+	for k, o := range s.offsets {
+		offsets[k] = o
+	}
+	return offsets, nil
+}
+
+// Flush merges |offsets| into the checkpoint, and commits it together with
+// application writes of the current Transaction once pending appends of the
+// Shard have been acknowledged. Flush fails if the Shard checkpoint has been
+// fenced by another SQLStore.
+func (s *SQLStore) Flush(offsets map[pb.Journal]int64) error {
+	var txn, err = s.Transaction()
+	if err != nil {
+		return err
+	}
+	s.txn = nil // |txn| is committed or rolled back by this call.
+
+	// A StrongBarrier of the recovery log resolves only after all appends
+	// pending at its issuance have committed.
+	var barrier = s.recorder.StrongBarrier()
+	<-barrier.Done()
+
+	if err = barrier.Err(); err != nil {
+		_ = txn.Rollback()
+		return extendErr(err, "awaiting pending appends")
+	}
+
+	var merged = make(map[pb.Journal]int64, len(s.offsets)+len(offsets))
+	for k, o := range s.offsets {
+		merged[k] = o
+	}
+	for k, o := range offsets {
+		merged[k] = o
+	}
+	checkpoint, err := json.Marshal(merged)
+	if err != nil {
+		_ = txn.Rollback()
+		return extendErr(err, "encoding checkpoint")
+	}
+
+	if result, err := txn.ExecContext(s.ctx,
+		"UPDATE "+s.Table+" SET checkpoint = $1 WHERE shard_fqn = $2 AND fence = $3;",
+		string(checkpoint), s.fqn, s.fence); err != nil {
+		_ = txn.Rollback()
+		return extendErr(err, "updating checkpoint")
+	} else if n, err := result.RowsAffected(); err != nil {
+		_ = txn.Rollback()
+		return extendErr(err, "updating checkpoint")
+	} else if n != 1 {
+		_ = txn.Rollback()
+		return errors.Errorf("checkpoint fence of shard %s was updated by another process (expected fence %d)",
+			s.fqn, s.fence)
+	} else if err = txn.Commit(); err != nil {
+		return extendErr(err, "committing checkpoint")
+	}
+
+	s.offsets = merged
+	return nil
+}
+
+// Destroy rolls back a current uncommitted Transaction. The remote DB is
+// left unchanged, and is not closed.
+func (s *SQLStore) Destroy() {
+	if s.txn == nil {
+		return
+	} else if err := s.txn.Rollback(); err != nil {
+		log.WithFields(log.Fields{
+			"shard": s.fqn,
+			"err":   err,
+		}).Warn("failed to roll back SQLStore transaction")
+	}
+	s.txn = nil
+}
+
+// restoreCheckpoint inserts the Shard checkpoint if it doesn't exist, and
+// otherwise increments its fence. It then loads the fence and checkpoint.
+func (s *SQLStore) restoreCheckpoint() error {
+	var txn, err = s.DB.BeginTx(s.ctx, nil)
+	if err != nil {
+		return extendErr(err, "beginning transaction")
+	}
+	defer func() {
+		if txn != nil {
+			_ = txn.Rollback()
+		}
+	}()
+
+	if _, err = txn.ExecContext(s.ctx,
+		"INSERT INTO "+s.Table+" (shard_fqn, fence, checkpoint) "+
+			"SELECT $1, 0, '{}' WHERE NOT EXISTS (SELECT 1 FROM "+s.Table+" WHERE shard_fqn = $1);",
+		s.fqn); err != nil {
+		return extendErr(err, "inserting checkpoint")
+	} else if _, err = txn.ExecContext(s.ctx,
+		"UPDATE "+s.Table+" SET fence = fence + 1 WHERE shard_fqn = $1;", s.fqn); err != nil {
+		return extendErr(err, "incrementing fence")
+	}
+
+	var checkpoint string
+	if err = txn.QueryRowContext(s.ctx,
+		"SELECT fence, checkpoint FROM "+s.Table+" WHERE shard_fqn = $1;", s.fqn,
+	).Scan(&s.fence, &checkpoint); err != nil {
+		return extendErr(err, "querying checkpoint")
+	} else if err = json.Unmarshal([]byte(checkpoint), &s.offsets); err != nil {
+		return extendErr(err, "decoding checkpoint")
+	} else if err = txn.Commit(); err != nil {
+		return extendErr(err, "committing fence")
+	}
+	txn = nil
+	return nil
+}
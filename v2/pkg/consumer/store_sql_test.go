@@ -0,0 +1,317 @@
+package consumer
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type SQLStoreSuite struct{}
+
+func (s *SQLStoreSuite) TestRestoreCheckpointFences(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+	playAndComplete(c, r)
+
+	var db, fake = newFakeSQLDB(c)
+
+	// Expect the checkpoint is inserted, and fenced.
+	var store, err = NewSQLStore(r, r.store.Recorder(), db, "checkpoints")
+	c.Assert(err, gc.IsNil)
+	c.Check(store.fence, gc.Equals, int64(1))
+	c.Check(fake.committed[store.fqn], gc.DeepEquals, fakeCheckpointRow{fence: 1, checkpoint: "{}"})
+
+	offsets, err := store.FetchJournalOffsets()
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{})
+
+	c.Check(store.Flush(map[pb.Journal]int64{sourceA: 123}), gc.IsNil)
+
+	// A restored store increments the fence, and loads committed offsets.
+	store, err = NewSQLStore(r, r.store.Recorder(), db, "checkpoints")
+	c.Assert(err, gc.IsNil)
+	c.Check(store.fence, gc.Equals, int64(2))
+
+	offsets, err = store.FetchJournalOffsets()
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{sourceA: 123})
+
+	// A malformed checkpoint fails the restore.
+	fake.committed[store.fqn] = fakeCheckpointRow{fence: 2, checkpoint: "{bad"}
+	_, err = NewSQLStore(r, r.store.Recorder(), db, "checkpoints")
+	c.Check(err, gc.ErrorMatches, `restoring checkpoint: decoding checkpoint: .*`)
+}
+
+func (s *SQLStoreSuite) TestFlushCommitsWritesAndOffsets(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+	playAndComplete(c, r)
+
+	var db, fake = newFakeSQLDB(c)
+	var store, err = NewSQLStore(r, r.store.Recorder(), db, "checkpoints")
+	c.Assert(err, gc.IsNil)
+
+	txn, err := store.Transaction()
+	c.Assert(err, gc.IsNil)
+	_, err = txn.Exec("INSERT INTO app (value) VALUES ($1);", "one")
+	c.Check(err, gc.IsNil)
+
+	// Offsets of successive Flushes are merged.
+	c.Check(store.Flush(map[pb.Journal]int64{sourceA: 123}), gc.IsNil)
+	c.Check(store.Flush(map[pb.Journal]int64{sourceB: 456}), gc.IsNil)
+
+	c.Check(fake.writes, gc.DeepEquals, []string{"one"})
+	c.Check(fake.committed[store.fqn].checkpoint, gc.Equals, `{"source/A":123,"source/B":456}`)
+
+	// Destroy rolls back an uncommitted Transaction.
+	txn, err = store.Transaction()
+	c.Assert(err, gc.IsNil)
+	_, err = txn.Exec("INSERT INTO app (value) VALUES ($1);", "two")
+	c.Check(err, gc.IsNil)
+	store.Destroy()
+
+	c.Check(fake.writes, gc.DeepEquals, []string{"one"})
+}
+
+func (s *SQLStoreSuite) TestFlushAwaitsPendingAppends(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+	playAndComplete(c, r)
+
+	var db, fake = newFakeSQLDB(c)
+	var store, err = NewSQLStore(r, r.store.Recorder(), db, "checkpoints")
+	c.Assert(err, gc.IsNil)
+
+	txn, err := store.Transaction()
+	c.Assert(err, gc.IsNil)
+	_, err = txn.Exec("INSERT INTO app (value) VALUES ($1);", "one")
+	c.Check(err, gc.IsNil)
+
+	// Begin an append which is still pending as Flush is invoked.
+	var aa = r.JournalClient().StartAppend(sourceB)
+	_, _ = aa.Writer().WriteString(`{"key":"foo"}` + "\n")
+
+	var flushCh = make(chan error)
+	go func() { flushCh <- store.Flush(map[pb.Journal]int64{sourceA: 123}) }()
+
+	// Expect Flush doesn't commit until the append is acknowledged.
+	select {
+	case <-flushCh:
+		c.Fatal("Flush returned before pending append was acknowledged")
+	case <-time.After(50 * time.Millisecond):
+	}
+	c.Check(fake.writesLocked(), gc.HasLen, 0)
+
+	c.Check(aa.Release(), gc.IsNil)
+	c.Check(<-flushCh, gc.IsNil)
+	c.Check(fake.writesLocked(), gc.DeepEquals, []string{"one"})
+}
+
+func (s *SQLStoreSuite) TestFencedFlushIsRolledBack(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+	playAndComplete(c, r)
+
+	var db, fake = newFakeSQLDB(c)
+	var prior, err = NewSQLStore(r, r.store.Recorder(), db, "checkpoints")
+	c.Assert(err, gc.IsNil)
+
+	txn, err := prior.Transaction()
+	c.Assert(err, gc.IsNil)
+	_, err = txn.Exec("INSERT INTO app (value) VALUES ($1);", "stale")
+	c.Check(err, gc.IsNil)
+
+	// A new SQLStore of the Shard fences the checkpoint, while the prior
+	// store has an in-flight transaction.
+	next, err := NewSQLStore(r, r.store.Recorder(), db, "checkpoints")
+	c.Assert(err, gc.IsNil)
+	c.Check(next.fence, gc.Equals, prior.fence+1)
+
+	// Expect the prior store fails to commit, and its writes are rolled back.
+	c.Check(prior.Flush(map[pb.Journal]int64{sourceA: 123}), gc.ErrorMatches,
+		`checkpoint fence of shard .* was updated by another process \(expected fence 1\)`)
+	c.Check(fake.writes, gc.HasLen, 0)
+	c.Check(fake.committed[next.fqn], gc.DeepEquals, fakeCheckpointRow{fence: 2, checkpoint: "{}"})
+
+	// The next store commits as usual.
+	c.Check(next.Flush(map[pb.Journal]int64{sourceA: 456}), gc.IsNil)
+	c.Check(fake.committed[next.fqn].checkpoint, gc.Equals, `{"source/A":456}`)
+}
+
+// fakeSQL is a minimal database/sql driver, which understands only the
+// statements issued by SQLStore and test Application writes. Statements read
+// committed state, and their effects are applied only on commit.
+type fakeSQL struct {
+	mu        sync.Mutex
+	committed map[string]fakeCheckpointRow
+	writes    []string
+}
+
+type fakeCheckpointRow struct {
+	fence      int64
+	checkpoint string
+}
+
+func (f *fakeSQL) writesLocked() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.writes...)
+}
+
+func newFakeSQLDB(c *gc.C) (*sql.DB, *fakeSQL) {
+	var f = &fakeSQL{committed: make(map[string]fakeCheckpointRow)}
+
+	fakeSQLDriver.mu.Lock()
+	var name = fmt.Sprintf("db-%d", len(fakeSQLDriver.dbs))
+	fakeSQLDriver.dbs[name] = f
+	fakeSQLDriver.mu.Unlock()
+
+	var db, err = sql.Open("consumer-fake-sql", name)
+	c.Assert(err, gc.IsNil)
+	return db, f
+}
+
+type fakeSQLDriverT struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeSQL
+}
+
+func (d *fakeSQLDriverT) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return &fakeSQLConn{db: d.dbs[name]}, nil
+}
+
+type fakeSQLConn struct {
+	db  *fakeSQL
+	txn *fakeSQLTx
+}
+
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeSQLStmt{conn: c, query: query}, nil
+}
+func (c *fakeSQLConn) Close() error { return nil }
+func (c *fakeSQLConn) Begin() (driver.Tx, error) {
+	c.txn = &fakeSQLTx{conn: c, rows: make(map[string]fakeCheckpointRow)}
+	return c.txn, nil
+}
+
+type fakeSQLTx struct {
+	conn   *fakeSQLConn
+	rows   map[string]fakeCheckpointRow
+	writes []string
+}
+
+func (t *fakeSQLTx) Commit() error {
+	var db = t.conn.db
+	db.mu.Lock()
+	for k, r := range t.rows {
+		db.committed[k] = r
+	}
+	db.writes = append(db.writes, t.writes...)
+	db.mu.Unlock()
+
+	t.conn.txn = nil
+	return nil
+}
+
+func (t *fakeSQLTx) Rollback() error {
+	t.conn.txn = nil
+	return nil
+}
+
+// row returns the row of |fqn| as seen by the transaction.
+func (t *fakeSQLTx) row(fqn string) (fakeCheckpointRow, bool) {
+	if r, ok := t.rows[fqn]; ok {
+		return r, true
+	}
+	t.conn.db.mu.Lock()
+	defer t.conn.db.mu.Unlock()
+
+	var r, ok = t.conn.db.committed[fqn]
+	return r, ok
+}
+
+type fakeSQLStmt struct {
+	conn  *fakeSQLConn
+	query string
+}
+
+func (s *fakeSQLStmt) Close() error  { return nil }
+func (s *fakeSQLStmt) NumInput() int { return -1 }
+
+func (s *fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	var txn = s.conn.txn
+	if txn == nil {
+		return nil, fmt.Errorf("no transaction")
+	}
+
+	switch q := s.query; {
+	case strings.HasPrefix(q, "INSERT INTO app"):
+		txn.writes = append(txn.writes, args[0].(string))
+		return driver.RowsAffected(1), nil
+
+	case strings.HasPrefix(q, "INSERT INTO checkpoints"):
+		var fqn = args[0].(string)
+		if _, ok := txn.row(fqn); ok {
+			return driver.RowsAffected(0), nil
+		}
+		txn.rows[fqn] = fakeCheckpointRow{fence: 0, checkpoint: "{}"}
+		return driver.RowsAffected(1), nil
+
+	case strings.HasPrefix(q, "UPDATE checkpoints SET fence = fence + 1"):
+		var fqn = args[0].(string)
+		var r, _ = txn.row(fqn)
+		r.fence++
+		txn.rows[fqn] = r
+		return driver.RowsAffected(1), nil
+
+	case strings.HasPrefix(q, "UPDATE checkpoints SET checkpoint"):
+		var fqn, fence = args[1].(string), args[2].(int64)
+		if r, ok := txn.row(fqn); !ok || r.fence != fence {
+			return driver.RowsAffected(0), nil
+		} else {
+			r.checkpoint = args[0].(string)
+			txn.rows[fqn] = r
+		}
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected statement: %s", s.query)
+}
+
+func (s *fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	var txn = s.conn.txn
+	if txn == nil || !strings.HasPrefix(s.query, "SELECT fence, checkpoint FROM checkpoints") {
+		return nil, fmt.Errorf("unexpected query: %s", s.query)
+	}
+	var r, ok = txn.row(args[0].(string))
+	return &fakeSQLRows{row: r, more: ok}, nil
+}
+
+type fakeSQLRows struct {
+	row  fakeCheckpointRow
+	more bool
+}
+
+func (r *fakeSQLRows) Columns() []string { return []string{"fence", "checkpoint"} }
+func (r *fakeSQLRows) Close() error      { return nil }
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if !r.more {
+		return io.EOF
+	}
+	dest[0], dest[1], r.more = r.row.fence, r.row.checkpoint, false
+	return nil
+}
+
+var fakeSQLDriver = &fakeSQLDriverT{dbs: make(map[string]*fakeSQL)}
+
+func init() { sql.Register("consumer-fake-sql", fakeSQLDriver) }
+
+var _ = gc.Suite(&SQLStoreSuite{})
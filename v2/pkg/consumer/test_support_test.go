@@ -2,6 +2,8 @@ package consumer
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
@@ -46,8 +48,13 @@ type testApplication struct {
 	consumeErr  error
 	finalizeErr error
 	finishErr   error
+	// Fixture error returned by BecomePrimary.
+	becomePrimaryErr error
 	// Signals when FinishTxn is called.
 	finishCh chan struct{}
+	// Records ShardTransitioner calls.
+	transitionsMu sync.Mutex
+	transitions   []string
 }
 
 func newTestApplication() *testApplication {
@@ -77,6 +84,27 @@ func (a *testApplication) ConsumeMessage(shard Shard, store Store, env message.E
 
 func (a *testApplication) FinalizeTxn(shard Shard, store Store) error { return a.finalizeErr }
 
+func (a *testApplication) BecomeStandby(shard Shard) error {
+	a.recordTransition("standby")
+	return nil
+}
+
+func (a *testApplication) BecomePrimary(shard Shard, store Store) error {
+	a.recordTransition("primary")
+	return a.becomePrimaryErr
+}
+
+func (a *testApplication) Shutdown(shard Shard, store Store) error {
+	a.recordTransition(fmt.Sprintf("shutdown (store: %t)", store != nil))
+	return nil
+}
+
+func (a *testApplication) recordTransition(t string) {
+	a.transitionsMu.Lock()
+	a.transitions = append(a.transitions, t)
+	a.transitionsMu.Unlock()
+}
+
 func (a *testApplication) FinishTxn(shard Shard, store Store, _ error) error {
 	var ch = a.finishCh
 	a.finishCh = make(chan struct{})
@@ -257,17 +285,16 @@ func expectStatusCode(c *gc.C, state *allocator.State, code ReplicaStatus_Code)
 	defer state.KS.Mu.RUnlock()
 	state.KS.Mu.RLock()
 
-	for {
+	var status *ReplicaStatus
+	c.Check(state.KS.WaitForCondition(context.Background(), func() bool {
 		var _, kv = pluckTheAssignment(c, state)
-		var status = kv.Decoded.(allocator.Assignment).AssignmentValue.(*ReplicaStatus)
+		status = kv.Decoded.(allocator.Assignment).AssignmentValue.(*ReplicaStatus)
 
 		c.Check(status.Code <= code, gc.Equals, true)
+		return status.Code == code
+	}), gc.IsNil)
 
-		if status.Code == code {
-			return status
-		}
-		state.KS.WaitForRevision(context.Background(), state.KS.Header.Revision+1)
-	}
+	return status
 }
 
 func runSomeTransactions(c *gc.C, shard Shard) {
@@ -0,0 +1,247 @@
+package consumer
+
+import (
+	"container/heap"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+)
+
+// TimeDomain is the time domain of a Timer.
+type TimeDomain int
+
+const (
+	// EventTime Timers fire as the event-time watermark of the Shard passes
+	// the Timer's Time. The watermark is the maximum event time of
+	// consumed EventTimeMessages.
+	EventTime TimeDomain = iota
+	// ProcessingTime Timers fire as the wall-clock time of the consumer
+	// process passes the Timer's Time.
+	ProcessingTime
+)
+
+// Timer is a per-key callback of an Application, scheduled for a Time of a
+// TimeDomain. Timers are persisted by a TimerStore, and are delivered to a
+// TimerApplication within a consumer transaction.
+type Timer struct {
+	// Key of the Timer, as defined by the Application. At most one Timer may
+	// be set for a given Key and TimeDomain.
+	Key string `json:"key"`
+	// Domain of the Timer.
+	Domain TimeDomain `json:"domain"`
+	// Time at which the Timer fires.
+	Time time.Time `json:"time"`
+}
+
+// TimerApplication is an optional interface of an Application which is
+// notified of fired Timers. Timers fire only if the Shard Store is a TimerStore.
+type TimerApplication interface {
+	// OnTimer is called with each Timer which has fired, within the scope of
+	// a consumer transaction and prior to its FinalizeTxn. Timers fire in
+	// order of their Time, and a fired Timer is removed from the Store's
+	// Timers before OnTimer is called (the Application may re-set it).
+	// Timers are evaluated only as transactions are finalized: a
+	// ProcessingTime Timer of an idle Shard fires with its next transaction.
+	OnTimer(Shard, Store, Timer) error
+}
+
+// EventTimeMessage is an optional interface of a Message which has an event
+// time. As an EventTimeMessage is consumed, the event-time watermark of a
+// TimerStore's Timers is advanced to its EventTime.
+type EventTimeMessage interface {
+	message.Message
+	// EventTime of the message.
+	EventTime() time.Time
+}
+
+// Timers is a set of pending Timers, and an event-time watermark.
+// It is not safe for concurrent use. It's intended to be held by a
+// TimerStore, and to be used by Applications only from within
+// ConsumeMessage, OnTimer, and FinalizeTxn.
+type Timers struct {
+	watermark time.Time
+	pending   map[timerID]timerEntry
+	queues    [2]timerQueue // Indexed on TimeDomain.
+	seq       int64
+}
+
+// NewTimers returns an empty Timers.
+func NewTimers() *Timers {
+	return &Timers{pending: make(map[timerID]timerEntry)}
+}
+
+// Set a Timer of |key| in |domain| to fire at |at|. An existing Timer of the
+// key and domain is replaced.
+func (t *Timers) Set(domain TimeDomain, key string, at time.Time) {
+	t.seq++
+
+	var entry = timerEntry{Timer: Timer{Key: key, Domain: domain, Time: at}, seq: t.seq}
+	t.pending[timerID{key: key, domain: domain}] = entry
+	heap.Push(&t.queues[domain], entry)
+}
+
+// Cancel a Timer of |key| in |domain|. Cancel is a no-op if the Timer isn't set.
+func (t *Timers) Cancel(domain TimeDomain, key string) {
+	delete(t.pending, timerID{key: key, domain: domain})
+}
+
+// Get returns the Time of the Timer of |key| in |domain|, and whether it's set.
+func (t *Timers) Get(domain TimeDomain, key string) (time.Time, bool) {
+	var entry, ok = t.pending[timerID{key: key, domain: domain}]
+	return entry.Time, ok
+}
+
+// Len returns the number of pending Timers.
+func (t *Timers) Len() int { return len(t.pending) }
+
+// Watermark returns the current event-time watermark.
+func (t *Timers) Watermark() time.Time { return t.watermark }
+
+// AdvanceWatermark advances the event-time watermark to |et|. The watermark
+// never regresses: an |et| prior to the current watermark is ignored.
+func (t *Timers) AdvanceWatermark(et time.Time) {
+	if et.After(t.watermark) {
+		t.watermark = et
+	}
+}
+
+// popDue removes and returns the earliest Timer which is due, given the
+// event-time watermark and processing time |now|. Only Timers set at or before
+// sequence number |maxSeq| are returned. It returns false if no Timer is due.
+func (t *Timers) popDue(now time.Time, maxSeq int64) (Timer, bool) {
+	var next *timerQueue
+
+	for domain, bound := range [2]time.Time{t.watermark, now} {
+		var q = &t.queues[domain]
+
+		// Discard stale entries which were since cancelled or replaced.
+		for q.Len() != 0 && t.pending[timerID{key: (*q)[0].Key, domain: (*q)[0].Domain}] != (*q)[0] {
+			heap.Pop(q)
+		}
+		if q.Len() == 0 || (*q)[0].Time.After(bound) || (*q)[0].seq > maxSeq {
+			continue
+		} else if next == nil || timerLess((*q)[0].Timer, (*next)[0].Timer) {
+			next = q
+		}
+	}
+	if next == nil {
+		return Timer{}, false
+	}
+	var entry = heap.Pop(next).(timerEntry)
+	delete(t.pending, timerID{key: entry.Key, domain: entry.Domain})
+
+	return entry.Timer, true
+}
+
+// MarshalJSON encodes the Timers, in a form suited for persistence by a TimerStore.
+func (t *Timers) MarshalJSON() ([]byte, error) {
+	var doc = timersJSON{Watermark: t.watermark, Timers: make([]Timer, 0, len(t.pending))}
+	for _, entry := range t.pending {
+		doc.Timers = append(doc.Timers, entry.Timer)
+	}
+	sort.Slice(doc.Timers, func(i, j int) bool { return timerLess(doc.Timers[i], doc.Timers[j]) })
+	return json.Marshal(doc)
+}
+
+// UnmarshalJSON decodes Timers previously encoded by MarshalJSON.
+func (t *Timers) UnmarshalJSON(b []byte) error {
+	var doc timersJSON
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	*t = Timers{watermark: doc.Watermark, pending: make(map[timerID]timerEntry, len(doc.Timers))}
+
+	for _, timer := range doc.Timers {
+		t.Set(timer.Domain, timer.Key, timer.Time)
+	}
+	return nil
+}
+
+// TumblingWindow returns the [begin, end) bounds of the tumbling window of
+// duration |size| which contains |t|. Windows are aligned to the Unix epoch.
+// A typical windowed aggregation keys state on the window |begin|, and sets an
+// EventTime Timer at |end| to emit and expire the window's aggregate.
+func TumblingWindow(t time.Time, size time.Duration) (begin, end time.Time) {
+	var ns = t.UnixNano() % int64(size)
+	if ns < 0 {
+		ns += int64(size)
+	}
+	begin = t.Add(-time.Duration(ns))
+	return begin, begin.Add(size)
+}
+
+// fireTimers delivers Timers of the |store| which are due to |app|,
+// if |app| is a TimerApplication and |store| is a TimerStore. Timers set by
+// OnTimer calls fire no earlier than the next transaction.
+func fireTimers(shard Shard, store Store, app Application) error {
+	var ta, ok = app.(TimerApplication)
+	if !ok {
+		return nil
+	}
+	ts, ok := store.(TimerStore)
+	if !ok {
+		return nil
+	}
+	var timers, now = ts.Timers(), timeNow()
+	var maxSeq = timers.seq
+
+	for {
+		var timer, ok = timers.popDue(now, maxSeq)
+		if !ok {
+			return nil
+		} else if err := ta.OnTimer(shard, store, timer); err != nil {
+			return err
+		}
+	}
+}
+
+// advanceWatermark advances the Timers watermark of |store| to the event time
+// of |msg|, if |store| is a TimerStore and |msg| is an EventTimeMessage.
+func advanceWatermark(store Store, msg message.Message) {
+	if ts, ok := store.(TimerStore); !ok {
+		return
+	} else if etm, ok := msg.(EventTimeMessage); ok {
+		ts.Timers().AdvanceWatermark(etm.EventTime())
+	}
+}
+
+type timerID struct {
+	key    string
+	domain TimeDomain
+}
+
+type timerEntry struct {
+	Timer
+	seq int64
+}
+
+type timersJSON struct {
+	Watermark time.Time `json:"watermark"`
+	Timers    []Timer   `json:"timers"`
+}
+
+func timerLess(a, b Timer) bool {
+	if !a.Time.Equal(b.Time) {
+		return a.Time.Before(b.Time)
+	} else if a.Domain != b.Domain {
+		return a.Domain < b.Domain
+	}
+	return a.Key < b.Key
+}
+
+// timerQueue is a min-heap of timerEntries ordered on Time. Entries are
+// removed lazily, and may be stale with respect to Timers.pending.
+type timerQueue []timerEntry
+
+func (q timerQueue) Len() int            { return len(q) }
+func (q timerQueue) Less(i, j int) bool  { return timerLess(q[i].Timer, q[j].Timer) }
+func (q timerQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *timerQueue) Push(x interface{}) { *q = append(*q, x.(timerEntry)) }
+func (q *timerQueue) Pop() interface{} {
+	var old = *q
+	var x = old[len(old)-1]
+	*q = old[:len(old)-1]
+	return x
+}
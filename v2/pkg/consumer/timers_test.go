@@ -0,0 +1,152 @@
+package consumer
+
+import (
+	"encoding/json"
+	"time"
+
+	gc "github.com/go-check/check"
+)
+
+type TimersSuite struct{}
+
+func (s *TimersSuite) TestSetCancelAndPopDue(c *gc.C) {
+	var t0 = time.Unix(1500000000, 0)
+	var timers = NewTimers()
+
+	timers.Set(EventTime, "a", t0.Add(3*time.Second))
+	timers.Set(EventTime, "b", t0.Add(1*time.Second))
+	timers.Set(EventTime, "c", t0.Add(2*time.Second))
+	timers.Set(ProcessingTime, "a", t0.Add(2*time.Second))
+	timers.Set(EventTime, "b", t0.Add(5*time.Second)) // Replaces prior "b".
+	timers.Cancel(EventTime, "c")
+	c.Check(timers.Len(), gc.Equals, 3)
+
+	var at, ok = timers.Get(EventTime, "b")
+	c.Check(ok, gc.Equals, true)
+	c.Check(at.Equal(t0.Add(5*time.Second)), gc.Equals, true)
+	_, ok = timers.Get(EventTime, "c")
+	c.Check(ok, gc.Equals, false)
+
+	// Nothing is due before the watermark or processing time advance.
+	_, ok = timers.popDue(t0, timers.seq)
+	c.Check(ok, gc.Equals, false)
+
+	// The watermark never regresses.
+	timers.AdvanceWatermark(t0.Add(4 * time.Second))
+	timers.AdvanceWatermark(t0)
+	c.Check(timers.Watermark().Equal(t0.Add(4*time.Second)), gc.Equals, true)
+
+	// Expect due Timers of both domains are returned in Time order.
+	var fired []Timer
+	for {
+		var timer, ok = timers.popDue(t0.Add(10*time.Second), timers.seq)
+		if !ok {
+			break
+		}
+		fired = append(fired, timer)
+	}
+	c.Check(fired, gc.DeepEquals, []Timer{
+		{Key: "a", Domain: ProcessingTime, Time: t0.Add(2 * time.Second)},
+		{Key: "a", Domain: EventTime, Time: t0.Add(3 * time.Second)},
+	})
+	c.Check(timers.Len(), gc.Equals, 1) // EventTime "b" remains.
+
+	// Timers set after |maxSeq| are not returned, even if due.
+	var maxSeq = timers.seq
+	timers.Set(EventTime, "d", t0)
+	_, ok = timers.popDue(t0, maxSeq)
+	c.Check(ok, gc.Equals, false)
+}
+
+func (s *TimersSuite) TestJSONRoundTrip(c *gc.C) {
+	var t0 = time.Unix(1500000000, 0).UTC()
+	var timers = NewTimers()
+
+	timers.AdvanceWatermark(t0)
+	timers.Set(EventTime, "a", t0.Add(time.Second))
+	timers.Set(ProcessingTime, "b", t0.Add(time.Minute))
+	timers.Set(EventTime, "a", t0.Add(time.Hour)) // Stale queue entry is not encoded.
+
+	var b, err = json.Marshal(timers)
+	c.Assert(err, gc.IsNil)
+
+	var out = NewTimers()
+	c.Assert(json.Unmarshal(b, out), gc.IsNil)
+
+	c.Check(out.Len(), gc.Equals, 2)
+	c.Check(out.Watermark().Equal(t0), gc.Equals, true)
+
+	var at, _ = out.Get(EventTime, "a")
+	c.Check(at.Equal(t0.Add(time.Hour)), gc.Equals, true)
+	at, _ = out.Get(ProcessingTime, "b")
+	c.Check(at.Equal(t0.Add(time.Minute)), gc.Equals, true)
+}
+
+func (s *TimersSuite) TestFiringWithinTransaction(c *gc.C) {
+	var t0 = time.Unix(1500000000, 0)
+	var store = &timerTestStore{timers: NewTimers()}
+	var app = &timerTestApp{}
+
+	defer func(fn func() time.Time) { timeNow = fn }(timeNow)
+	timeNow = func() time.Time { return t0.Add(time.Minute) }
+
+	store.timers.Set(ProcessingTime, "ttl", t0)
+	store.timers.Set(EventTime, "window", t0.Add(time.Second))
+
+	// Consumed EventTimeMessages advance the watermark.
+	advanceWatermark(store, timerTestMessage{at: t0.Add(time.Second)})
+	advanceWatermark(store, &testMessage{}) // Not an EventTimeMessage.
+
+	c.Check(fireTimers(nil, store, app), gc.IsNil)
+	c.Check(app.fired, gc.DeepEquals, []Timer{
+		{Key: "ttl", Domain: ProcessingTime, Time: t0},
+		{Key: "window", Domain: EventTime, Time: t0.Add(time.Second)},
+	})
+	// Expect the Timer re-set by OnTimer remains pending for a later transaction.
+	var _, ok = store.timers.Get(ProcessingTime, "ttl")
+	c.Check(ok, gc.Equals, true)
+	c.Check(store.timers.Len(), gc.Equals, 1)
+}
+
+func (s *TimersSuite) TestStoreMustBeTimerStore(c *gc.C) {
+	c.Check(checkStoreExtensions(&timerTestApp{}, &timerTestStore{timers: NewTimers()}), gc.IsNil)
+	c.Check(checkStoreExtensions(&testApplication{}, &JSONFileStore{}), gc.IsNil)
+	c.Check(checkStoreExtensions(&timerTestApp{}, &nonTimerTestStore{}), gc.ErrorMatches,
+		`Application is a TimerApplication, but Store \*consumer.nonTimerTestStore is not a TimerStore`)
+}
+
+func (s *TimersSuite) TestTumblingWindow(c *gc.C) {
+	var begin, end = TumblingWindow(time.Unix(1500000042, 0), time.Minute)
+	c.Check(begin.Unix(), gc.Equals, int64(1500000000))
+	c.Check(end.Unix(), gc.Equals, int64(1500000060))
+}
+
+type timerTestStore struct {
+	Store
+	timers *Timers
+}
+
+func (s *timerTestStore) Timers() *Timers { return s.timers }
+
+type nonTimerTestStore struct{ Store }
+
+type timerTestApp struct {
+	Application
+	fired []Timer
+}
+
+func (a *timerTestApp) OnTimer(_ Shard, store Store, timer Timer) error {
+	a.fired = append(a.fired, timer)
+
+	if timer.Domain == ProcessingTime {
+		// Re-set the Timer at a Time which is already due.
+		store.(TimerStore).Timers().Set(ProcessingTime, timer.Key, timer.Time)
+	}
+	return nil
+}
+
+type timerTestMessage struct{ at time.Time }
+
+func (m timerTestMessage) EventTime() time.Time { return m.at }
+
+var _ = gc.Suite(&TimersSuite{})
@@ -201,6 +201,25 @@ func (s *ConsumerSuite) TestConsumeWithHotStandby(c *gc.C) {
 	c.Check(broker.Tasks.Wait(), gc.IsNil)
 }
 
+func (s *ConsumerSuite) TestHarness(c *gc.C) {
+	var h = NewHarness(c, testApp{})
+	defer h.Stop()
+
+	h.CreateJournals(pb.JournalSpec{Name: "a/journal"})
+	h.CreateShards(Shard(consumer.ShardSpec{
+		Id:      "a-shard",
+		Sources: []consumer.ShardSpec_Source{{Journal: "a/journal"}},
+	}))
+
+	h.PublishJSON("a/journal", testMsg{Key: "the", Value: "quick"}, testMsg{Key: "brown", Value: "fox"})
+	h.WaitForShards()
+
+	h.ReadStore("a-shard", func(store consumer.Store) {
+		c.Check(store.(*consumer.JSONFileStore).State, gc.DeepEquals,
+			&map[string]string{"the": "quick", "brown": "fox"})
+	})
+}
+
 type testApp struct{}
 
 type testMsg struct{ Key, Value string }
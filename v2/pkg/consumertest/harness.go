@@ -0,0 +1,139 @@
+package consumertest
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/brokertest"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/coreos/etcd/clientv3"
+	gc "github.com/go-check/check"
+)
+
+// Harness composes an embedded Etcd, an in-process Broker, and a Consumer of
+// an Application under test. It allows the ConsumeMessage and FinalizeTxn
+// logic of an Application to be exercised end-to-end from an ordinary
+// `go test`, without requiring any external services.
+type Harness struct {
+	C        *gc.C
+	Ctx      context.Context
+	Etcd     *clientv3.Client       // Client of the embedded Etcd.
+	Broker   *brokertest.Broker     // In-process Broker.
+	Journals pb.RoutedJournalClient // Client of the Broker.
+	Consumer *Consumer              // Consumer of the Application under test.
+
+	cancel context.CancelFunc
+}
+
+// NewHarness starts and returns a Harness of Application |app|. The caller
+// must Stop the Harness upon test completion.
+func NewHarness(c *gc.C, app consumer.Application) *Harness {
+	var etcd = etcdtest.TestClient()
+	var broker = brokertest.NewBroker(c, etcd, "local", "broker")
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var ctx, cancel = context.WithCancel(context.Background())
+
+	var cmr = NewConsumer(Args{
+		C:        c,
+		Etcd:     etcd,
+		Journals: rjc,
+		App:      app,
+	})
+	cmr.Tasks.GoRun()
+
+	return &Harness{
+		C:        c,
+		Ctx:      ctx,
+		Etcd:     etcd,
+		Broker:   broker,
+		Journals: rjc,
+		Consumer: cmr,
+		cancel:   cancel,
+	}
+}
+
+// CreateJournals creates |specs|, after applying brokertest.Journal defaults.
+// Journals not having a content type label are created as JSON lines.
+func (h *Harness) CreateJournals(specs ...pb.JournalSpec) {
+	var out []*pb.JournalSpec
+	for _, spec := range specs {
+		if spec.LabelSet.ValueOf(labels.ContentType) == "" {
+			spec.LabelSet = pb.UnionLabelSets(spec.LabelSet,
+				pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines), pb.LabelSet{})
+		}
+		out = append(out, brokertest.Journal(spec))
+	}
+	brokertest.CreateJournals(h.C, h.Broker, out...)
+}
+
+// CreateShards creates the recovery log journal of each of |specs|, and then
+// the shards themselves. Source journals of |specs| must already exist.
+func (h *Harness) CreateShards(specs ...*consumer.ShardSpec) {
+	var logs []*pb.JournalSpec
+	for _, spec := range specs {
+		logs = append(logs, brokertest.Journal(pb.JournalSpec{
+			Name:     spec.RecoveryLog(),
+			LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_RecoveryLog),
+		}))
+	}
+	brokertest.CreateJournals(h.C, h.Broker, logs...)
+	CreateShards(h.C, h.Consumer, specs...)
+}
+
+// PublishJSON appends each of |msgs| to |journal| as a JSON line.
+func (h *Harness) PublishJSON(journal pb.Journal, msgs ...interface{}) {
+	var wc = client.NewAppender(h.Ctx, h.Journals, pb.AppendRequest{Journal: journal})
+	var enc = json.NewEncoder(wc)
+
+	for _, msg := range msgs {
+		h.C.Assert(enc.Encode(msg), gc.IsNil)
+	}
+	h.C.Assert(wc.Close(), gc.IsNil)
+}
+
+// WaitForShards blocks until all shards have consumed through the current
+// write-heads of their source journals.
+func (h *Harness) WaitForShards() {
+	h.C.Assert(WaitForShards(h.Ctx, h.Journals, h.Consumer.Service.Loopback, pb.LabelSelector{}), gc.IsNil)
+}
+
+// ReadStore resolves shard |id| and invokes |fn| with its Store. The
+// resolution is held (and the Store isn't otherwise used) for the duration
+// of the |fn| call.
+func (h *Harness) ReadStore(id consumer.ShardID, fn func(consumer.Store)) {
+	var res, err = h.Consumer.Service.Resolver.Resolve(consumer.ResolveArgs{Context: h.Ctx, ShardID: id})
+	h.C.Assert(err, gc.IsNil)
+	defer res.Done()
+
+	fn(res.Store)
+}
+
+// Stop the Consumer and Broker of the Harness, and clean up Etcd.
+func (h *Harness) Stop() {
+	h.cancel()
+
+	h.Consumer.Tasks.Cancel()
+	h.C.Check(h.Consumer.Tasks.Wait(), gc.IsNil)
+
+	h.Broker.Tasks.Cancel()
+	h.C.Check(h.Broker.Tasks.Wait(), gc.IsNil)
+
+	etcdtest.Cleanup()
+}
+
+// Shard returns |spec| after applying reasonable test defaults for fields
+// which are not already set.
+func Shard(spec consumer.ShardSpec) *consumer.ShardSpec {
+	spec = consumer.UnionShardSpecs(spec, consumer.ShardSpec{
+		RecoveryLogPrefix: "recovery/logs",
+		HintPrefix:        "/hints",
+		HintBackups:       1,
+		MaxTxnDuration:    time.Second,
+	})
+	return &spec
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
-	"golang.org/x/net/trace"
 )
 
 const (
@@ -224,7 +223,5 @@ func WalkAllStores(ctx context.Context, name pb.Journal, stores []pb.FragmentSto
 var timeNow = time.Now
 
 func addTrace(ctx context.Context, format string, args ...interface{}) {
-	if tr, ok := trace.FromContext(ctx); ok {
-		tr.LazyPrintf(format, args...)
-	}
+	pb.AddTrace(ctx, format, args...)
 }
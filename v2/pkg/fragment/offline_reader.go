@@ -0,0 +1,102 @@
+package fragment
+
+import (
+	"context"
+	"io"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+)
+
+// OfflineReader reads journal content directly from the fragment stores of a
+// JournalSpec, without any broker involvement. It's intended for batch jobs
+// which process archived journal content, and which must operate even when
+// brokers are unavailable. As OfflineReader reads only persisted Fragments,
+// it doesn't reflect content which is not yet persisted to a store.
+//
+// Like client.Reader, OfflineReader returns client.ErrOffsetJump if the next
+// available offset is greater than the requested one (eg, because covering
+// Fragments were removed). Offset is updated to reflect the jumped-to offset,
+// and further Reads will continue from it. Read returns io.EOF upon reading
+// through the end of listed Fragments.
+type OfflineReader struct {
+	// Offset is the next journal offset to be read.
+	Offset int64
+
+	ctx context.Context
+	set CoverSet
+	fr  *client.FragmentReader // Currently open Fragment.
+}
+
+// NewOfflineReader lists Fragments of the JournalSpec from each of its
+// configured stores, and returns an OfflineReader of the journal beginning
+// at |offset|. An |offset| of -1 begins reading from the first available
+// journal offset.
+func NewOfflineReader(ctx context.Context, spec *pb.JournalSpec, offset int64) (*OfflineReader, error) {
+	var set, err = WalkAllStores(ctx, spec.Name, spec.Fragment.Stores)
+	if err != nil {
+		return nil, err
+	}
+	if offset == -1 {
+		offset = set.BeginOffset()
+	}
+	return &OfflineReader{Offset: offset, ctx: ctx, set: set}, nil
+}
+
+// Fragments returns the CoverSet of persisted Fragments being read.
+func (r *OfflineReader) Fragments() CoverSet { return r.set }
+
+func (r *OfflineReader) Read(p []byte) (n int, err error) {
+	if r.fr == nil {
+		var ind, found = r.set.LongestOverlappingFragment(r.Offset)
+
+		if ind == len(r.set) {
+			return 0, io.EOF
+		} else if !found {
+			r.Offset = r.set[ind].Begin
+			return 0, client.ErrOffsetJump
+		} else if r.fr, err = openFragment(r.ctx, r.set[ind].Fragment, r.Offset); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = r.fr.Read(p)
+	r.Offset = r.fr.Offset
+
+	if err != nil {
+		_ = r.fr.Close()
+		r.fr = nil
+	}
+	if err == io.EOF {
+		// We read through the Fragment. Continue with the next one.
+		if err = nil; n == 0 {
+			return r.Read(p)
+		}
+	}
+	return
+}
+
+// Close the OfflineReader, releasing any open Fragment.
+func (r *OfflineReader) Close() error {
+	if r.fr != nil {
+		var err = r.fr.Close()
+		r.fr = nil
+		return err
+	}
+	return nil
+}
+
+// openFragment opens |fragment| from its store, and returns a
+// *client.FragmentReader which has been pre-seeked to |offset|.
+func openFragment(ctx context.Context, fragment pb.Fragment, offset int64) (*client.FragmentReader, error) {
+	var rc, err = Open(ctx, fragment)
+	if err != nil {
+		return nil, err
+	}
+	if fragment.CompressionCodec == pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION &&
+		fragment.BackingStore.URL().Scheme == "file" {
+		// Local file stores cannot offload decompression.
+		fragment.CompressionCodec = pb.CompressionCodec_GZIP
+	}
+	return client.NewFragmentReader(rc, fragment, offset)
+}
@@ -0,0 +1,90 @@
+package fragment
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/codecs"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type OfflineReaderSuite struct{}
+
+func (s *OfflineReaderSuite) TestReadAcrossFragmentsAndGaps(c *gc.C) {
+	var tmpdir, err = ioutil.TempDir("", "OfflineReaderSuite")
+	c.Assert(err, gc.IsNil)
+
+	defer func() { os.RemoveAll(tmpdir) }()
+	defer func(s string) { FileSystemStoreRoot = s }(FileSystemStoreRoot)
+	FileSystemStoreRoot = tmpdir
+
+	var writeFixture = func(begin int64, content string, codec pb.CompressionCodec) {
+		var frag = pb.Fragment{
+			Journal:          "a/journal",
+			Begin:            begin,
+			End:              begin + int64(len(content)),
+			Sum:              pb.SHA1SumOf(content),
+			CompressionCodec: codec,
+		}
+		var path = filepath.Join(tmpdir, "root", filepath.FromSlash(frag.ContentPath()))
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0700), gc.IsNil)
+
+		var file, err = os.Create(path)
+		c.Assert(err, gc.IsNil)
+		comp, err := codecs.NewCodecWriter(file, codec)
+		c.Assert(err, gc.IsNil)
+		_, err = comp.Write([]byte(content))
+		c.Assert(err, gc.IsNil)
+		c.Assert(comp.Close(), gc.IsNil)
+		c.Assert(file.Close(), gc.IsNil)
+	}
+	writeFixture(0, "hello, ", pb.CompressionCodec_NONE)
+	writeFixture(7, "world!", pb.CompressionCodec_GZIP)
+	writeFixture(20, "after a gap", pb.CompressionCodec_SNAPPY)
+
+	var spec = &pb.JournalSpec{
+		Name: "a/journal",
+		Fragment: pb.JournalSpec_Fragment{
+			Stores: []pb.FragmentStore{"file:///root/"},
+		},
+	}
+	var ctx = context.Background()
+
+	// Case: read from the first available offset.
+	r, err := NewOfflineReader(ctx, spec, -1)
+	c.Assert(err, gc.IsNil)
+	c.Check(r.Fragments(), gc.HasLen, 3)
+
+	b, err := ioutil.ReadAll(r)
+	c.Check(err, gc.Equals, client.ErrOffsetJump)
+	c.Check(string(b), gc.Equals, "hello, world!")
+	c.Check(r.Offset, gc.Equals, int64(20))
+
+	b, err = ioutil.ReadAll(r) // Continue reading after the jump.
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "after a gap")
+	c.Check(r.Offset, gc.Equals, int64(31))
+	c.Check(r.Close(), gc.IsNil)
+
+	// Case: read from an offset within a Fragment.
+	r, err = NewOfflineReader(ctx, spec, 9)
+	c.Assert(err, gc.IsNil)
+
+	var buf = make([]byte, 4)
+	n, err := io.ReadFull(r, buf)
+	c.Check(err, gc.IsNil)
+	c.Check(string(buf[:n]), gc.Equals, "rld!")
+	c.Check(r.Close(), gc.IsNil)
+
+	// Case: the store cannot be listed.
+	spec.Fragment.Stores = []pb.FragmentStore{"file:///does/not/exist/"}
+	_, err = NewOfflineReader(ctx, spec, 0)
+	c.Check(err, gc.NotNil)
+}
+
+var _ = gc.Suite(&OfflineReaderSuite{})
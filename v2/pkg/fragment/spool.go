@@ -2,6 +2,7 @@ package fragment
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding"
 	"fmt"
 	"hash"
@@ -32,8 +33,8 @@ type Spool struct {
 	compressor codecs.Compressor
 
 	delta    int64     // Delta offset of next byte to write, relative to Fragment.End.
-	summer   hash.Hash // Running SHA1 of the Fragment.File, through |Fragment.End + delta|.
-	sumState []byte    // SHA1 |summer| internal state at the last Fragment commit.
+	summer   hash.Hash // Running SHA1 (or SHA256) of the Fragment.File, through |Fragment.End + delta|.
+	sumState []byte    // |summer| internal state at the last Fragment commit.
 
 	observer SpoolObserver
 }
@@ -48,17 +49,26 @@ type SpoolObserver interface {
 
 // NewSpool returns an empty Spool of |journal|.
 func NewSpool(journal pb.Journal, observer SpoolObserver) Spool {
+	var summer, sumState = newSummer(journal)
+
 	return Spool{
 		Fragment: Fragment{Fragment: pb.Fragment{
 			Journal:          journal,
 			CompressionCodec: pb.CompressionCodec_NONE,
 		}},
-		summer:   sha1.New(),
-		sumState: zeroedSHA1State,
+		summer:   summer,
+		sumState: sumState,
 		observer: observer,
 	}
 }
 
+// SetSHA256Journals sets a predicate of journals whose new Fragments are
+// summed, and named, using SHA256 rather than SHA1. All brokers must agree on
+// the predicate, as replicas of a journal must agree on the sums of proposed
+// Fragments. Readers verify Fragments of either sum, so a journal may be
+// migrated while it has existing SHA1 Fragments.
+func SetSHA256Journals(fn func(pb.Journal) bool) { sumSHA256 = fn }
+
 // Apply the ReplicateRequest to the Spool, returning any encountered error.
 func (s *Spool) Apply(r *pb.ReplicateRequest, primary bool) (pb.ReplicateResponse, error) {
 	if r.Proposal != nil {
@@ -86,7 +96,9 @@ func (s *Spool) Next() pb.Fragment {
 
 	// Empty fragments are special-cased to have Sum of zero (as technically, SHA1('') != <zero>).
 	if f.Begin == f.End {
-		f.Sum = pb.SHA1Sum{}
+		f.Sum, f.Sum256 = pb.SHA1Sum{}, pb.SHA256Sum{}
+	} else if s.summer.Size() == sha256.Size {
+		f.Sum256 = pb.SHA256SumFromDigest(s.summer.Sum(nil))
 	} else {
 		f.Sum = pb.SHA1SumFromDigest(s.summer.Sum(nil))
 	}
@@ -122,6 +134,8 @@ func (s *Spool) applyCommit(r *pb.ReplicateRequest, primary bool) pb.ReplicateRe
 		if s.ContentLength() != 0 {
 			s.observer.SpoolComplete(*s, primary)
 		}
+		var summer, sumState = newSummer(s.Fragment.Journal)
+
 		*s = Spool{
 			Fragment: Fragment{
 				Fragment: pb.Fragment{
@@ -131,8 +145,8 @@ func (s *Spool) applyCommit(r *pb.ReplicateRequest, primary bool) pb.ReplicateRe
 					CompressionCodec: r.Proposal.CompressionCodec,
 				},
 			},
-			summer:   sha1.New(),
-			sumState: zeroedSHA1State,
+			summer:   summer,
+			sumState: sumState,
 			observer: s.observer,
 		}
 	}
@@ -319,7 +333,21 @@ func (s *Spool) restoreSumState() {
 	}
 }
 
+// newSummer returns a hash.Hash of new Fragments of |journal|, and its
+// initial internal state.
+func newSummer(journal pb.Journal) (hash.Hash, []byte) {
+	var summer = sha1.New()
+	if sumSHA256(journal) {
+		summer = sha256.New()
+	}
+	if state, err := summer.(encoding.BinaryMarshaler).MarshalBinary(); err != nil {
+		panic(err.Error()) // Cannot fail.
+	} else {
+		return summer, state
+	}
+}
+
 var (
-	zeroedSHA1State, _ = sha1.New().(encoding.BinaryMarshaler).MarshalBinary()
+	sumSHA256          = func(pb.Journal) bool { return false }
 	spoolRetryInterval = time.Second * 5
 )
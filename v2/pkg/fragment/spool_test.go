@@ -69,6 +69,57 @@ func (s *SpoolSuite) TestNextCases(c *gc.C) {
 	c.Check(spool.FirstAppendTime, gc.Equals, fixedTime)
 }
 
+func (s *SpoolSuite) TestSHA256Journals(c *gc.C) {
+	defer SetSHA256Journals(func(pb.Journal) bool { return false })
+	SetSHA256Journals(func(journal pb.Journal) bool { return journal == "a/sha256/journal" })
+
+	var obv testSpoolObserver
+	var spool = NewSpool("a/sha256/journal", &obv)
+
+	var _, err = spool.Apply(&pb.ReplicateRequest{
+		Content:      []byte("some content"),
+		ContentDelta: 0,
+	}, false)
+	c.Check(err, gc.IsNil)
+
+	// Expect the next Fragment is summed using SHA256.
+	var next = spool.Next()
+	c.Check(next.Sum, gc.Equals, pb.SHA1Sum{})
+	c.Check(next.Sum256, gc.Equals, pb.SHA256SumOf("some content"))
+
+	// A proposal having a SHA1 sum of the content doesn't match.
+	var proposal = next
+	proposal.Sum, proposal.Sum256 = pb.SHA1SumOf("some content"), pb.SHA256Sum{}
+
+	resp, _ := spool.Apply(&pb.ReplicateRequest{Proposal: &proposal}, false)
+	c.Check(resp.Status, gc.Equals, pb.Status_FRAGMENT_MISMATCH)
+
+	resp, _ = spool.Apply(&pb.ReplicateRequest{Proposal: &next}, false)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+	c.Check(spool.Fragment.Sum256, gc.Equals, pb.SHA256SumOf("some content"))
+
+	// Rolled Spools of the journal continue to use SHA256.
+	resp, _ = spool.Apply(&pb.ReplicateRequest{
+		Proposal: &pb.Fragment{
+			Journal:          "a/sha256/journal",
+			Begin:            12,
+			End:              12,
+			CompressionCodec: pb.CompressionCodec_NONE,
+		}}, false)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+
+	_, err = spool.Apply(&pb.ReplicateRequest{Content: []byte("more"), ContentDelta: 0}, false)
+	c.Check(err, gc.IsNil)
+	c.Check(spool.Next().Sum256, gc.Equals, pb.SHA256SumOf("more"))
+
+	// Other journals continue to use SHA1.
+	spool = NewSpool("a/journal", &obv)
+	_, err = spool.Apply(&pb.ReplicateRequest{Content: []byte("more"), ContentDelta: 0}, false)
+	c.Check(err, gc.IsNil)
+	c.Check(spool.Next().Sum, gc.Equals, pb.SHA1SumOf("more"))
+	c.Check(spool.Next().Sum256, gc.Equals, pb.SHA256Sum{})
+}
+
 func (s *SpoolSuite) TestNoCompression(c *gc.C) {
 	var obv testSpoolObserver
 	var spool = NewSpool("a/journal", &obv)
@@ -11,6 +11,8 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/gorilla/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type backend interface {
@@ -72,11 +74,17 @@ func Open(ctx context.Context, fragment pb.Fragment) (io.ReadCloser, error) {
 // Persist a Spool to its store. If the Spool Fragment is already present,
 // this is a no-op. If the Spool has not been compressed incrementally,
 // it will be compressed before being persisted.
-func Persist(ctx context.Context, spool Spool) error {
+func Persist(ctx context.Context, spool Spool) (err error) {
+	var span trace.Span
+	ctx, span = pb.StartSpan(ctx, "fragment.Persist",
+		trace.WithAttributes(attribute.String("fragment", spool.ContentName())))
+	defer func() { pb.EndSpan(span, err) }()
+
 	var ep = spool.Fragment.BackingStore.URL()
 	var b = getBackend(ep.Scheme)
 
-	var exists, err = b.Exists(ctx, ep, spool.Fragment.Fragment)
+	var exists bool
+	exists, err = b.Exists(ctx, ep, spool.Fragment.Fragment)
 	instrumentStoreOp(b.Provider(), "exist", err)
 	if err != nil {
 		return err
@@ -0,0 +1,244 @@
+package http_gateway
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// Grants are permissions of authenticated Gateway requests, as LabelSelectors
+// of journals which may be read or appended to. Selectors match over journal
+// labels as well as the "name" and "prefix" meta-labels of the journal
+// (eg, "prefix=examples/"). A nil selector permits no journals.
+type Grants struct {
+	Read   *pb.LabelSelector
+	Append *pb.LabelSelector
+}
+
+// Verifier authenticates requests of the Gateway.
+type Verifier interface {
+	// Verify the credentials of the request, returning their Grants, or an
+	// error if credentials are missing or invalid.
+	Verify(*http.Request) (Grants, error)
+}
+
+// StaticVerifier is a Verifier of a static set of API keys and basic-auth
+// users. An API key is presented as an "Authorization: Bearer <key>" header,
+// or as an APIKeyHeader header.
+type StaticVerifier struct {
+	// APIKeys maps each API key to its Grants.
+	APIKeys map[string]Grants
+	// Users maps each basic-auth user to its password and Grants.
+	Users map[string]StaticUser
+}
+
+// StaticUser is a basic-auth user of a StaticVerifier.
+type StaticUser struct {
+	Password string
+	Grants
+}
+
+// Verify implements Verifier.
+func (v *StaticVerifier) Verify(r *http.Request) (Grants, error) {
+	if key := requestAPIKey(r); key != "" {
+		for k, grants := range v.APIKeys {
+			if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+				return grants, nil
+			}
+		}
+		return Grants{}, errInvalidCredentials
+	} else if name, password, ok := r.BasicAuth(); ok {
+		if user, ok := v.Users[name]; ok &&
+			subtle.ConstantTimeCompare([]byte(user.Password), []byte(password)) == 1 {
+			return user.Grants, nil
+		}
+		return Grants{}, errInvalidCredentials
+	}
+	return Grants{}, errMissingCredentials
+}
+
+// ParseStaticVerifier parses a StaticVerifier from its YAML configuration, eg:
+//
+//	apiKeys:
+//	  - key: a-secret-key
+//	    read: "prefix=examples/"
+//	    append: "prefix=examples/, app=producer"
+//	users:
+//	  - user: a-user
+//	    password: a-password
+//	    read: ""
+//
+// Selectors are as parsed by ParseLabelSelector, with an empty selector
+// permitting all journals. Omitted selectors permit no journals.
+func ParseStaticVerifier(b []byte) (*StaticVerifier, error) {
+	type grants struct {
+		Read   *string `yaml:"read"`
+		Append *string `yaml:"append"`
+	}
+	var config struct {
+		APIKeys []struct {
+			Key    string `yaml:"key"`
+			grants `yaml:",inline"`
+		} `yaml:"apiKeys"`
+		Users []struct {
+			User     string `yaml:"user"`
+			Password string `yaml:"password"`
+			grants   `yaml:",inline"`
+		} `yaml:"users"`
+	}
+	if err := yaml.UnmarshalStrict(b, &config); err != nil {
+		return nil, err
+	}
+
+	var parse = func(g grants) (out Grants, err error) {
+		if out.Read, err = parseGrantSelector(g.Read); err != nil {
+			err = fmt.Errorf("read: %s", err)
+		} else if out.Append, err = parseGrantSelector(g.Append); err != nil {
+			err = fmt.Errorf("append: %s", err)
+		}
+		return
+	}
+	var v = &StaticVerifier{
+		APIKeys: make(map[string]Grants),
+		Users:   make(map[string]StaticUser),
+	}
+
+	for i, k := range config.APIKeys {
+		if k.Key == "" {
+			return nil, fmt.Errorf("apiKeys[%d]: expected key", i)
+		} else if _, ok := v.APIKeys[k.Key]; ok {
+			return nil, fmt.Errorf("apiKeys[%d]: duplicated key", i)
+		} else if grants, err := parse(k.grants); err != nil {
+			return nil, fmt.Errorf("apiKeys[%d].%s", i, err)
+		} else {
+			v.APIKeys[k.Key] = grants
+		}
+	}
+	for i, u := range config.Users {
+		if u.User == "" || u.Password == "" {
+			return nil, fmt.Errorf("users[%d]: expected user and password", i)
+		} else if _, ok := v.Users[u.User]; ok {
+			return nil, fmt.Errorf("users[%d]: duplicated user (%s)", i, u.User)
+		} else if grants, err := parse(u.grants); err != nil {
+			return nil, fmt.Errorf("users[%d].%s", i, err)
+		} else {
+			v.Users[u.User] = StaticUser{Password: u.Password, Grants: grants}
+		}
+	}
+	return v, nil
+}
+
+// SetVerifier sets the Verifier of the Gateway. If set, requests of the
+// Gateway must present credentials, and may read or append only to journals
+// matched by their Grants. Journals of "/v1/journals" REST API listings are
+// filtered to those which may be read.
+func (h *Gateway) SetVerifier(v Verifier) { h.verifier = v }
+
+// authenticate verifies the credentials of the request with the Gateway
+// Verifier, if any. If credentials are missing or invalid, an error response
+// is written and false is returned. Otherwise, the request having its
+// Grants attached to its Context is returned.
+func (h *Gateway) authenticate(w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	if h.verifier == nil {
+		return r, true
+	}
+	var grants, err = h.verifier.Verify(r)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="gazette"`)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return r, false
+	}
+	return r.WithContext(context.WithValue(r.Context(), grantsKey{}, grants)), true
+}
+
+// authorize returns true if the request may read (or if |forAppend|, append to)
+// the journal. Otherwise, it writes an error response and returns false.
+func (h *Gateway) authorize(w http.ResponseWriter, r *http.Request, journal pb.Journal, forAppend bool) bool {
+	var grants, ok = r.Context().Value(grantsKey{}).(Grants)
+	if !ok {
+		return true // Authentication is not enabled.
+	}
+	var sel = grants.Read
+	if forAppend {
+		sel = grants.Append
+	}
+	if sel == nil {
+		http.Error(w, errNotPermitted.Error(), http.StatusForbidden)
+		return false
+	}
+
+	var spec, err = fetchJournalSpec(r.Context(), h.client, journal)
+	if err == errJournalNotFound {
+		// Don't disclose whether the journal exists.
+		http.Error(w, errNotPermitted.Error(), http.StatusForbidden)
+		return false
+	} else if err != nil {
+		log.WithField("err", err).Warn("http_gateway: failed to fetch JournalSpec")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	} else if !selectorMatchesJournal(*sel, spec) {
+		http.Error(w, errNotPermitted.Error(), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// filterReadableJournals removes journals of the ListResponse which may not be
+// read, as per Grants of the request (if any).
+func filterReadableJournals(r *http.Request, resp *pb.ListResponse) {
+	var grants, ok = r.Context().Value(grantsKey{}).(Grants)
+	if !ok {
+		return // Authentication is not enabled.
+	}
+	var out = resp.Journals[:0]
+	for _, j := range resp.Journals {
+		if grants.Read != nil && selectorMatchesJournal(*grants.Read, &j.Spec) {
+			out = append(out, j)
+		}
+	}
+	resp.Journals = out
+}
+
+// selectorMatchesJournal returns true if the |sel| matches the labels
+// and meta-labels of the JournalSpec.
+func selectorMatchesJournal(sel pb.LabelSelector, spec *pb.JournalSpec) bool {
+	var meta = pb.ExtractJournalSpecMetaLabels(spec, pb.LabelSet{})
+	return sel.Matches(pb.UnionLabelSets(meta, spec.LabelSet, pb.LabelSet{}))
+}
+
+// requestAPIKey returns the API key presented by the request, if any.
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key
+	} else if auth := r.Header.Get("Authorization"); len(auth) > 7 && strings.EqualFold(auth[:7], "Bearer ") {
+		return auth[7:]
+	}
+	return ""
+}
+
+// parseGrantSelector parses a LabelSelector, or returns nil if |s| is nil.
+func parseGrantSelector(s *string) (*pb.LabelSelector, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var sel, err = pb.ParseLabelSelector(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &sel, nil
+}
+
+type grantsKey struct{}
+
+var (
+	errMissingCredentials = errors.New("missing credentials")
+	errInvalidCredentials = errors.New("invalid credentials")
+	errNotPermitted       = errors.New("not permitted")
+)
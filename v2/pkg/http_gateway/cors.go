@@ -0,0 +1,110 @@
+package http_gateway
+
+import (
+	"net/http"
+	"strings"
+)
+
+// CORSPolicy is a Cross-Origin Resource Sharing policy of the Gateway, which
+// permits browser applications served from other origins to read and append
+// to journals. A zero-valued CORSPolicy allows no cross-origin requests.
+type CORSPolicy struct {
+	// AllowedOrigins are origins (eg, "https://app.example.com") from which
+	// cross-origin requests are allowed. "*" allows any origin.
+	AllowedOrigins []string
+	// AllowedMethods are methods of allowed cross-origin requests. If empty,
+	// all methods served by the Gateway (GET, HEAD, PUT, and POST) are allowed.
+	AllowedMethods []string
+	// AllowedHeaders are request headers which may be sent with cross-origin
+	// requests. "*" allows any header.
+	AllowedHeaders []string
+}
+
+// SetCORSPolicy sets the CORSPolicy of the Gateway.
+func (h *Gateway) SetCORSPolicy(policy CORSPolicy) { h.cors = policy }
+
+// allowsOrigin returns true if the |origin| is allowed by the policy.
+func (p *CORSPolicy) allowsOrigin(origin string) bool {
+	return origin != "" && containsFold(p.AllowedOrigins, origin)
+}
+
+// allowsMethod returns true if the |method| is allowed by the policy.
+func (p *CORSPolicy) allowsMethod(method string) bool {
+	if len(p.AllowedMethods) == 0 {
+		return containsFold(gatewayMethods, method)
+	}
+	return containsFold(p.AllowedMethods, method)
+}
+
+// allowsHeaders returns true if all of the comma-separated |headers| are
+// allowed by the policy.
+func (p *CORSPolicy) allowsHeaders(headers string) bool {
+	for _, hdr := range strings.Split(headers, ",") {
+		if hdr = strings.TrimSpace(hdr); hdr != "" && !containsFold(p.AllowedHeaders, hdr) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeCORSHeaders writes headers of the response to an allowed cross-origin
+// request. Headers of the Gateway are exposed to the requesting application.
+func (p *CORSPolicy) writeCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	w.Header().Add("Vary", "Origin")
+	w.Header().Set("Access-Control-Expose-Headers", strings.Join(exposedHeaders, ", "))
+}
+
+// servePreflight answers a preflight OPTIONS request of the |r| cross-origin
+// request, allowing it only if its method and headers are allowed by the policy.
+func (p *CORSPolicy) servePreflight(w http.ResponseWriter, r *http.Request) {
+	var method = r.Header.Get("Access-Control-Request-Method")
+	var headers = r.Header.Get("Access-Control-Request-Headers")
+
+	if !p.allowsOrigin(r.Header.Get("Origin")) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	} else if !p.allowsMethod(method) {
+		http.Error(w, "method not allowed", http.StatusForbidden)
+		return
+	} else if !p.allowsHeaders(headers) {
+		http.Error(w, "headers not allowed", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	w.Header().Add("Vary", "Origin")
+	w.Header().Set("Access-Control-Allow-Methods", method)
+	if headers != "" {
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+	}
+	w.WriteHeader(http.StatusNoContent) // 204.
+}
+
+// containsFold returns true if |values| includes "*" or,
+// ignoring case, the value |v|.
+func containsFold(values []string, v string) bool {
+	for _, vv := range values {
+		if vv == "*" || strings.EqualFold(vv, v) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	gatewayMethods = []string{"GET", "HEAD", "PUT", "POST"}
+	exposedHeaders = []string{
+		FragmentLastModifiedHeader,
+		FragmentLocationHeader,
+		FragmentNameHeader,
+		RouteTokenHeader,
+		CloseErrorHeader,
+		WriteHeadHeader,
+		CommitBeginHeader,
+		CommitEndHeader,
+		CommitSumHeader,
+		"Content-Range",
+		"Location",
+	}
+)
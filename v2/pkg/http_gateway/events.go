@@ -0,0 +1,152 @@
+package http_gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	log "github.com/sirupsen/logrus"
+)
+
+// serveEvents serves a GET request which accepts "text/event-stream" as a
+// stream of Server-Sent Events, tailing the journal. Each message of the
+// journal (as framed per its content-type label) is sent as an event having
+// the journal offset which follows the message as its ID. A request having a
+// Last-Event-ID header, as sent by a reconnecting EventSource, resumes the
+// read from that offset.
+//
+// Messages of text Framings (JSON lines, CSV, and TSV) are sent as event
+// data verbatim. Messages of other Framings are base64 encoded.
+func (h *Gateway) serveEvents(w http.ResponseWriter, r *http.Request) {
+	var req, err = h.parseReadRequest(r)
+	if err == nil {
+		req.Offset, err = parseLastEventID(r, req.Offset)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if !h.authorize(w, r, req.Journal, false) {
+		return
+	}
+	req.Block = true // Events tail the journal.
+
+	framing, err := journalFraming(r.Context(), h.client, req.Journal)
+	if err == errJournalNotFound {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var encode = isBinaryFraming(framing)
+
+	w.Header().Set("Content-Type", EventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	var rr = client.NewRetryReader(r.Context(), h.client, req)
+	var br = bufio.NewReader(rr)
+	var event bytes.Buffer
+
+	for {
+		var frame []byte
+		if frame, err = framing.Unpack(br); err == client.ErrOffsetJump {
+			log.WithFields(log.Fields{"journal": req.Journal, "offset": rr.Offset()}).
+				Warn("http_gateway: events offset jump")
+			continue
+		} else if err != nil {
+			break
+		}
+
+		event.Reset()
+		writeEvent(&event, rr.AdjustedOffset(br), frame, encode)
+
+		if _, err = (flushWriter{w}).Write(event.Bytes()); err != nil {
+			break
+		}
+	}
+
+	if r.Context().Err() != nil || err == context.Canceled {
+		// Request was closed by the client. Don't log.
+	} else {
+		log.WithField("err", err).Warn("http_gateway: failed to serve events")
+	}
+}
+
+// parseLastEventID returns the offset of the request's Last-Event-ID header,
+// or |offset| if the request has no such header.
+func parseLastEventID(r *http.Request, offset int64) (int64, error) {
+	var id = r.Header.Get(LastEventIDHeader)
+	if id == "" {
+		return offset, nil
+	}
+	var o, err = strconv.ParseInt(id, 10, 64)
+	if err != nil || o < 0 {
+		return 0, fmt.Errorf("invalid %s (%s)", LastEventIDHeader, id)
+	}
+	return o, nil
+}
+
+// journalFraming returns the message.Framing of the named journal.
+func journalFraming(ctx context.Context, jc pb.JournalClient, name pb.Journal) (message.Framing, error) {
+	var spec, err = fetchJournalSpec(ctx, jc, name)
+	if err != nil {
+		return nil, err
+	}
+	return message.FramingByContentType(spec.LabelSet.ValueOf(labels.ContentType))
+}
+
+// isBinaryFraming returns true if frames of the |framing| may not be text,
+// and must be base64 encoded as event data.
+func isBinaryFraming(framing message.Framing) bool {
+	switch framing.ContentType() {
+	case labels.ContentType_JSONLines, labels.ContentType_CSV, labels.ContentType_TSV:
+		return false
+	default:
+		return true
+	}
+}
+
+// writeEvent writes a Server-Sent Event of |frame| having ID |offset| to |buf|.
+func writeEvent(buf *bytes.Buffer, offset int64, frame []byte, encode bool) {
+	fmt.Fprintf(buf, "id: %d\n", offset)
+
+	if encode {
+		buf.WriteString("data: ")
+		buf.WriteString(base64.StdEncoding.EncodeToString(frame))
+		buf.WriteByte('\n')
+	} else {
+		// Each line of the frame is a "data" field, which
+		// the event consumer re-joins with newlines.
+		frame = bytes.TrimSuffix(frame, []byte{'\n'})
+
+		for _, line := range bytes.Split(frame, []byte{'\n'}) {
+			buf.WriteString("data: ")
+			buf.Write(bytes.TrimSuffix(line, []byte{'\r'}))
+			buf.WriteByte('\n')
+		}
+	}
+	buf.WriteByte('\n') // Terminates the event.
+}
+
+// acceptsEventStream returns true if the request Accepts "text/event-stream".
+func acceptsEventStream(r *http.Request) bool {
+	for _, accept := range r.Header["Accept"] {
+		for _, part := range strings.Split(accept, ",") {
+			if mt, _, err := mime.ParseMediaType(part); err == nil && mt == EventStreamContentType {
+				return true
+			}
+		}
+	}
+	return false
+}
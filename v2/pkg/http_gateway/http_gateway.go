@@ -7,24 +7,34 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/client"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/gogo/protobuf/proto"
 	"github.com/gorilla/schema"
+	"github.com/klauspost/compress/gzip"
 	log "github.com/sirupsen/logrus"
 )
 
 // Gateway presents an HTTP gateway to Gazette brokers, by mapping GET, HEAD,
-// and PUT requests into equivalent Read RPCs and Append RPCs.
+// and PUT (or POST) requests into equivalent Read RPCs and Append RPCs. GETs of the
+// "/v1/journals" JSON REST API (see serveREST) are instead mapped into List
+// and Fragments RPCs, and shadow reads of journals having such names.
+// Cross-origin requests are served as permitted by the Gateway's CORSPolicy.
 type Gateway struct {
-	decoder *schema.Decoder
-	client  pb.RoutedJournalClient
+	decoder  *schema.Decoder
+	client   pb.RoutedJournalClient
+	cors     CORSPolicy
+	verifier Verifier
+	limiter  *rateLimiter
 }
 
 // NewGateway returns a Gateway using the BrokerClient.
@@ -39,10 +49,48 @@ func NewGateway(client pb.RoutedJournalClient) *Gateway {
 }
 
 func (h *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var endpoint, journal = requestEndpoint(r)
+	var mw = &metricsResponseWriter{ResponseWriter: w}
+	var mr *metricsReader
+
+	if r.Body != nil {
+		mr = &metricsReader{ReadCloser: r.Body}
+		var rr = *r
+		rr.Body = mr
+		r = &rr
+	}
+	defer observeRequest(endpoint, journal, mw, mr, time.Now())
+
+	h.serveHTTP(mw, r)
+}
+
+func (h *Gateway) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
+		h.cors.servePreflight(w, r)
+		return
+	} else if h.cors.allowsOrigin(r.Header.Get("Origin")) && h.cors.allowsMethod(r.Method) {
+		h.cors.writeCORSHeaders(w, r)
+	}
+
+	var cl, ok = h.rateLimitIP(w, r)
+	if !ok {
+		return
+	} else if r, ok = h.authenticate(w, r); !ok {
+		return
+	} else if w, r, ok = h.rateLimit(w, r, cl); !ok {
+		return
+	} else if h.serveREST(w, r) {
+		return
+	}
+
 	switch r.Method {
 	case "GET", "HEAD":
-		h.serveRead(w, r)
-	case "PUT":
+		if r.Method == "GET" && acceptsEventStream(r) {
+			h.serveEvents(w, r)
+		} else {
+			h.serveRead(w, r)
+		}
+	case "PUT", "POST":
 		h.serveWrite(w, r)
 	default:
 		http.Error(w, fmt.Sprintf("unknown method: %s", r.Method), http.StatusBadRequest)
@@ -54,6 +102,15 @@ func (h *Gateway) serveRead(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
+	} else if !h.authorize(w, r, req.Journal, false) {
+		return
+	}
+
+	// A Range request reads from its first byte offset, overriding the
+	// "offset" parameter, and is bounded to its last byte offset (if any).
+	var first, last, ranged = parseRange(r)
+	if ranged {
+		req.Offset = first
 	}
 
 	var reader = client.NewReader(r.Context(), h.client, req)
@@ -72,12 +129,55 @@ func (h *Gateway) serveRead(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+
+	// Determine the exclusive end offset of a Range request. Non-blocking reads
+	// are additionally bounded by the current write head.
+	var limit int64 = math.MaxInt64
+	if ranged && reader.Response.Status == pb.Status_OK {
+		if last != -1 {
+			limit = last + 1
+		}
+		if !req.Block && reader.Response.WriteHead < limit {
+			limit = reader.Response.WriteHead
+		}
+		if limit <= reader.Response.Offset {
+			http.Error(w, "range not satisfiable", http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		if limit != math.MaxInt64 {
+			w.Header().Set("Content-Range", formatContentRange(reader.Response, limit))
+		}
+	}
+	if reader.Response.Status == pb.Status_OK {
+		w.Header().Set("Accept-Ranges", "bytes")
+	}
+
+	// Content proxied by the Gateway has been decompressed by the Reader,
+	// regardless of the CompressionCodec of its Fragment. If the client
+	// accepts it, re-compress content on the fly for transfer. Range requests
+	// are not compressed, as their Content-Range is of the identity content.
+	var body io.Writer = flushWriter{w}
+	if reader.Response.Status == pb.Status_OK && !req.MetadataOnly && !ranged && acceptsGzip(r) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		var gzw = &gzipFlushWriter{gz: gzip.NewWriter(w), w: w}
+		defer gzw.Close()
+		body = gzw
+	}
 	writeReadResponse(w, r, reader.Response)
 
 	if reader.Response.Status != pb.Status_OK {
 		return
 	}
-	if _, err = io.Copy(flushWriter{w}, reader); err == nil {
+	var content, remaining = io.Reader(reader), limit - reader.Response.Offset
+	if limit != math.MaxInt64 {
+		content = io.LimitReader(reader, remaining)
+	}
+	var n int64
+	if n, err = io.Copy(body, content); err == nil && n == remaining {
+		return // Range request completed.
+	} else if err == nil {
 		err = errBrokerTerminated
 	}
 	w.Header().Set(CloseErrorHeader, err.Error())
@@ -98,10 +198,12 @@ func (h *Gateway) serveWrite(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
+	} else if !h.authorize(w, r, req.Journal, true) {
+		return
 	}
 
 	var appender = client.NewAppender(r.Context(), h.client, req)
-	if _, err = io.Copy(appender, r.Body); err == nil {
+	if err = copyAppendBody(appender, r); err == nil {
 		err = appender.Close()
 	}
 
@@ -113,6 +215,29 @@ func (h *Gateway) serveWrite(w http.ResponseWriter, r *http.Request) {
 	writeAppendResponse(w, r, appender.Response)
 }
 
+// copyAppendBody copies the body of the request to the Appender. The body of a
+// multipart request (eg, as sent by `curl -F "file=@my-file"`) has the content
+// of each of its parts appended in turn. Otherwise the body is appended as-is.
+// Note that chunked transfer encoding is decoded by the http.Server.
+func copyAppendBody(appender *client.Appender, r *http.Request) error {
+	var mediaType, params, err = mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		_, err = io.Copy(appender, r.Body)
+		return err
+	}
+
+	var mr = multipart.NewReader(r.Body, params["boundary"])
+	for {
+		if part, err := mr.NextPart(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		} else if _, err = io.Copy(appender, part); err != nil {
+			return err
+		}
+	}
+}
+
 func (h *Gateway) parseReadRequest(r *http.Request) (pb.ReadRequest, error) {
 	var schema struct {
 		Offset int64
@@ -166,8 +291,10 @@ func writeReadResponse(w http.ResponseWriter, r *http.Request, resp pb.ReadRespo
 		if resp.FragmentUrl != "" {
 			w.Header().Add(FragmentLocationHeader, resp.FragmentUrl)
 		}
-		w.Header().Add("Content-Range", fmt.Sprintf("bytes %v-%v/%v", resp.Offset,
-			math.MaxInt64, math.MaxInt64))
+		if w.Header().Get("Content-Range") == "" { // Not already set for a Range request.
+			w.Header().Add("Content-Range", fmt.Sprintf("bytes %v-%v/%v", resp.Offset,
+				math.MaxInt64, math.MaxInt64))
+		}
 	}
 	if resp.WriteHead != 0 {
 		w.Header().Add(WriteHeadHeader, strconv.FormatInt(resp.WriteHead, 10))
@@ -191,6 +318,42 @@ func writeReadResponse(w http.ResponseWriter, r *http.Request, resp pb.ReadRespo
 	}
 }
 
+// parseRange parses the first and last byte offsets of a "Range: bytes=first-last"
+// request header, where |last| may be omitted and is then returned as -1. It
+// returns false if the request has no Range, or if the Range is malformed or
+// has a form (eg, multiple ranges or a suffix range) which isn't supported.
+// As per RFC 7233, such Ranges are ignored and the journal read as usual.
+func parseRange(r *http.Request) (first, last int64, ok bool) {
+	var spec = r.Header.Get("Range")
+	if !strings.HasPrefix(spec, "bytes=") || strings.ContainsRune(spec, ',') {
+		return 0, 0, false
+	}
+	var parts = strings.SplitN(strings.TrimSpace(spec[len("bytes="):]), "-", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return 0, 0, false
+	}
+
+	var err error
+	if first, err = strconv.ParseInt(parts[0], 10, 64); err != nil || first < 0 {
+		return 0, 0, false
+	} else if parts[1] == "" {
+		return first, -1, true
+	} else if last, err = strconv.ParseInt(parts[1], 10, 64); err != nil || last < first {
+		return 0, 0, false
+	}
+	return first, last, true
+}
+
+// formatContentRange returns the Content-Range of a ranged read beginning at
+// the ReadResponse Offset, and ending at exclusive offset |limit|. The complete
+// length is the journal write head, or unknown if |limit| extends beyond it.
+func formatContentRange(resp pb.ReadResponse, limit int64) string {
+	if limit <= resp.WriteHead {
+		return fmt.Sprintf("bytes %d-%d/%d", resp.Offset, limit-1, resp.WriteHead)
+	}
+	return fmt.Sprintf("bytes %d-%d/*", resp.Offset, limit-1)
+}
+
 func writeAppendResponse(w http.ResponseWriter, r *http.Request, resp pb.AppendResponse) {
 	writeHeader(w, r, &resp.Header)
 
@@ -238,6 +401,64 @@ func (fw flushWriter) Write(p []byte) (n int, err error) {
 	return
 }
 
+// fetchJournalSpec returns the JournalSpec of the named journal.
+func fetchJournalSpec(ctx context.Context, jc pb.JournalClient, name pb.Journal) (*pb.JournalSpec, error) {
+	var resp, err = client.ListAllJournals(ctx, jc, pb.ListRequest{
+		Selector: pb.LabelSelector{
+			Include: pb.MustLabelSet("name", name.String()),
+		},
+	})
+	if err != nil {
+		return nil, err
+	} else if len(resp.Journals) == 0 {
+		return nil, errJournalNotFound
+	}
+	return &resp.Journals[0].Spec, nil
+}
+
+// gzipFlushWriter compresses written content, and flushes
+// it through to the http.ResponseWriter with each Write.
+type gzipFlushWriter struct {
+	gz *gzip.Writer
+	w  http.ResponseWriter
+}
+
+func (gw *gzipFlushWriter) Write(p []byte) (n int, err error) {
+	if n, err = gw.gz.Write(p); err == nil {
+		err = gw.gz.Flush()
+	}
+	if flusher, ok := gw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return
+}
+
+// Close writes the gzip footer of the compressed content.
+func (gw *gzipFlushWriter) Close() error { return gw.gz.Close() }
+
+// acceptsGzip returns true if the request's Accept-Encoding allows "gzip".
+func acceptsGzip(r *http.Request) bool {
+	for _, accept := range r.Header["Accept-Encoding"] {
+		for _, part := range strings.Split(accept, ",") {
+			var fields = strings.Split(part, ";")
+			if strings.TrimSpace(fields[0]) != "gzip" {
+				continue
+			}
+			// A coding having a zero quality value is not acceptable.
+			for _, param := range fields[1:] {
+				var kv = strings.SplitN(strings.TrimSpace(param), "=", 2)
+				if len(kv) != 2 || kv[0] != "q" {
+					continue
+				} else if q, err := strconv.ParseFloat(kv[1], 64); err == nil && q == 0 {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
 const (
 	FragmentLastModifiedHeader = "X-Fragment-Last-Modified"
 	FragmentLocationHeader     = "X-Fragment-Location"
@@ -249,6 +470,14 @@ const (
 	CommitBeginHeader = "X-Commit-Begin"
 	CommitEndHeader   = "X-Commit-End"
 	CommitSumHeader   = "X-Commit-SHA1-Sum"
+
+	APIKeyHeader = "X-API-Key"
+
+	LastEventIDHeader      = "Last-Event-ID"
+	EventStreamContentType = "text/event-stream"
 )
 
-var errBrokerTerminated = errors.New("broker terminated RPC")
+var (
+	errBrokerTerminated = errors.New("broker terminated RPC")
+	errJournalNotFound  = errors.New("journal not found")
+)
@@ -1,7 +1,12 @@
 package http_gateway
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,8 +14,14 @@ import (
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/broker/teststub"
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	gc "github.com/go-check/check"
+	"github.com/klauspost/compress/gzip"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"golang.org/x/time/rate"
 )
 
 type HTTPSuite struct{}
@@ -149,6 +160,142 @@ func (s *HTTPSuite) TestServingRead(c *gc.C) {
 	c.Check(w.Flushed, gc.Equals, true)
 }
 
+func (s *HTTPSuite) TestServingRangeRead(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var g = NewGateway(rjc)
+
+	var done = make(chan struct{})
+	go func() {
+		defer close(done)
+
+		// Expect the Range takes precedence over the "offset" parameter.
+		c.Check(<-broker.ReadReqCh, gc.DeepEquals, &pb.ReadRequest{Journal: "a/journal", Offset: 1026})
+
+		var fixture = readResponseFixture
+		fixture.Offset = 1026
+
+		broker.ReadRespCh <- &fixture
+		broker.ReadRespCh <- &pb.ReadResponse{Content: []byte("hello, "), Offset: 1026}
+		broker.ReadRespCh <- &pb.ReadResponse{Content: []byte("world!"), Offset: 1033}
+		broker.ErrCh <- nil
+	}()
+
+	// Expect the response isn't compressed, though the client accepts gzip.
+	var req, _ = http.NewRequest("GET", "/a/journal?offset=123", nil)
+	req.Header.Set("Range", "bytes=1026-1030")
+	req.Header.Set("Accept-Encoding", "gzip")
+	var w = httptest.NewRecorder()
+
+	g.ServeHTTP(w, req)
+
+	c.Check(w.Code, gc.Equals, http.StatusPartialContent)
+	c.Check(w.Header()["Content-Range"], gc.DeepEquals, []string{"bytes 1026-1030/2048"})
+	c.Check(w.Header()["Accept-Ranges"], gc.DeepEquals, []string{"bytes"})
+	c.Check(w.Header()["Content-Encoding"], gc.IsNil)
+	c.Check(w.Header()["X-Close-Error"], gc.IsNil)
+	c.Check(w.Body.String(), gc.Equals, "hello")
+	<-done // Await the first RPC's close.
+
+	// Case: Range is skipped over by an offset jump.
+	go func() {
+		c.Check(<-broker.ReadReqCh, gc.DeepEquals, &pb.ReadRequest{Journal: "a/journal", Offset: 0})
+		broker.ReadRespCh <- &readResponseFixture // At offset 1024.
+		broker.ErrCh <- nil
+	}()
+
+	req.Header.Set("Range", "bytes=0-99")
+	w = httptest.NewRecorder()
+
+	g.ServeHTTP(w, req)
+	c.Check(w.Code, gc.Equals, http.StatusRequestedRangeNotSatisfiable)
+}
+
+func (s *HTTPSuite) TestRangeParsing(c *gc.C) {
+	for _, tc := range []struct {
+		spec        string
+		first, last int64
+		ok          bool
+	}{
+		{"bytes=0-99", 0, 99, true},
+		{"bytes=100-", 100, -1, true},
+		{"bytes=100-100", 100, 100, true},
+		{"", 0, 0, false},
+		{"bytes=-500", 0, 0, false},        // Suffix ranges are not supported.
+		{"bytes=0-10, 20-30", 0, 0, false}, // Nor are multiple ranges.
+		{"bytes=100-99", 0, 0, false},
+		{"bytes=foo-", 0, 0, false},
+		{"items=0-10", 0, 0, false},
+	} {
+		var req, _ = http.NewRequest("GET", "/a/journal", nil)
+		req.Header.Set("Range", tc.spec)
+
+		var first, last, ok = parseRange(req)
+		c.Check([]interface{}{first, last, ok}, gc.DeepEquals,
+			[]interface{}{tc.first, tc.last, tc.ok}, gc.Commentf("%s", tc.spec))
+	}
+}
+
+func (s *HTTPSuite) TestServingCompressedRead(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var g = NewGateway(rjc)
+
+	go func() {
+		c.Check(<-broker.ReadReqCh, gc.DeepEquals, &pb.ReadRequest{Journal: "a/journal", Offset: 123})
+
+		broker.ReadRespCh <- &readResponseFixture
+		broker.ReadRespCh <- &pb.ReadResponse{Content: []byte("hello, "), Offset: 1024}
+		broker.ReadRespCh <- &pb.ReadResponse{Content: []byte("world!"), Offset: 1031}
+		broker.ErrCh <- nil
+	}()
+
+	var req, _ = http.NewRequest("GET", "/a/journal?offset=123", nil)
+	req.Header.Set("Accept-Encoding", "deflate, gzip;q=0.8")
+	var w = httptest.NewRecorder()
+
+	g.ServeHTTP(w, req)
+
+	c.Check(w.Code, gc.Equals, http.StatusPartialContent)
+	c.Check(w.Header()["Content-Encoding"], gc.DeepEquals, []string{"gzip"})
+	c.Check(w.Header()["Vary"], gc.DeepEquals, []string{"Accept-Encoding"})
+	c.Check(w.Header()["X-Write-Head"], gc.DeepEquals, []string{"2048"})
+
+	var gzr, err = gzip.NewReader(w.Body)
+	c.Assert(err, gc.IsNil)
+	content, err := ioutil.ReadAll(gzr)
+	c.Check(err, gc.IsNil)
+	c.Check(string(content), gc.Equals, "hello, world!")
+}
+
+func (s *HTTPSuite) TestAcceptsGzip(c *gc.C) {
+	for _, tc := range []struct {
+		accept string
+		expect bool
+	}{
+		{"", false},
+		{"identity", false},
+		{"gzip", true},
+		{"deflate, gzip", true},
+		{"deflate, gzip; q=0.5", true},
+		{"gzip;q=0", false},
+		{"gzip; q=0.000, identity", false},
+		{"x-gzip", false},
+	} {
+		var req, _ = http.NewRequest("GET", "/a/journal", nil)
+		if tc.accept != "" {
+			req.Header.Set("Accept-Encoding", tc.accept)
+		}
+		c.Check(acceptsGzip(req), gc.Equals, tc.expect, gc.Commentf("%s", tc.accept))
+	}
+}
+
 func (s *HTTPSuite) TestServingWrite(c *gc.C) {
 	var ctx, cancel = context.WithCancel(context.Background())
 	defer cancel()
@@ -175,6 +322,630 @@ func (s *HTTPSuite) TestServingWrite(c *gc.C) {
 	c.Check(w.Header()["X-Write-Head"], gc.DeepEquals, []string{"200"})
 }
 
+func (s *HTTPSuite) TestServingEvents(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var g = NewGateway(rjc)
+
+	broker.ListFunc = func(_ context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+		c.Check(req.Selector, gc.DeepEquals, pb.LabelSelector{Include: pb.MustLabelSet("name", "a/journal")})
+
+		return &pb.ListResponse{
+			Header: *readResponseFixture.Header,
+			Journals: []pb.ListResponse_Journal{{
+				Spec: pb.JournalSpec{
+					Name:        "a/journal",
+					Replication: 1,
+					LabelSet:    pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+					Fragment: pb.JournalSpec_Fragment{
+						Length:           1024,
+						CompressionCodec: pb.CompressionCodec_NONE,
+						RefreshInterval:  time.Minute,
+					},
+				},
+				ModRevision: 1,
+				Route:       readResponseFixture.Header.Route,
+			}},
+		}, nil
+	}
+
+	var reqCtx, reqCancel = context.WithCancel(ctx)
+
+	go func() {
+		// Expect Last-Event-ID takes precedence over the "offset" parameter.
+		c.Check(<-broker.ReadReqCh, gc.DeepEquals, &pb.ReadRequest{Journal: "a/journal", Offset: 1024, Block: true})
+
+		broker.ReadRespCh <- &readResponseFixture
+		broker.ReadRespCh <- &pb.ReadResponse{Content: []byte("{\"a\": 1}\n{\"b\":"), Offset: 1024}
+		broker.ReadRespCh <- &pb.ReadResponse{Content: []byte(" 2}\n"), Offset: 1038}
+		broker.ErrCh <- nil
+
+		// The read is retried from the last offset. Close the client request.
+		c.Check(<-broker.ReadReqCh, gc.DeepEquals, &pb.ReadRequest{Journal: "a/journal", Offset: 1042, Block: true})
+		reqCancel()
+	}()
+
+	var req, _ = http.NewRequest("GET", "/a/journal?offset=5", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Last-Event-ID", "1024")
+	var w = httptest.NewRecorder()
+
+	g.ServeHTTP(w, req.WithContext(reqCtx))
+
+	c.Check(w.Code, gc.Equals, http.StatusOK)
+	c.Check(w.Header().Get("Content-Type"), gc.Equals, "text/event-stream")
+	c.Check(w.Body.String(), gc.Equals, "id: 1033\ndata: {\"a\": 1}\n\nid: 1042\ndata: {\"b\": 2}\n\n")
+	c.Check(w.Flushed, gc.Equals, true)
+
+	// Case: Last-Event-ID is malformed.
+	req.Header.Set("Last-Event-ID", "foobar")
+	w = httptest.NewRecorder()
+
+	g.ServeHTTP(w, req)
+	c.Check(w.Code, gc.Equals, http.StatusBadRequest)
+	c.Check(w.Body.String(), gc.Equals, "invalid Last-Event-ID (foobar)\n")
+}
+
+func (s *HTTPSuite) TestServingREST(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var g = NewGateway(rjc)
+
+	var get = func(url string) *httptest.ResponseRecorder {
+		var req, _ = http.NewRequest("GET", url, nil)
+		var w = httptest.NewRecorder()
+		g.ServeHTTP(w, req)
+		return w
+	}
+
+	broker.ListFunc = func(_ context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+		c.Check(req, gc.DeepEquals, &pb.ListRequest{
+			Selector: pb.LabelSelector{Include: pb.MustLabelSet("topic", "foo")},
+		})
+		return &pb.ListResponse{
+			Header: *readResponseFixture.Header,
+			Journals: []pb.ListResponse_Journal{{
+				Spec: pb.JournalSpec{
+					Name:        "a/journal",
+					Replication: 1,
+					Fragment: pb.JournalSpec_Fragment{
+						Length:           1024,
+						CompressionCodec: pb.CompressionCodec_NONE,
+						RefreshInterval:  time.Minute,
+					},
+				},
+				ModRevision: 1,
+				Route:       readResponseFixture.Header.Route,
+			}},
+		}, nil
+	}
+
+	// Case: List journals matching a selector.
+	var w = get("/v1/journals?selector=topic%3Dfoo")
+	c.Check(w.Code, gc.Equals, http.StatusOK)
+	c.Check(w.Header().Get("Content-Type"), gc.Equals, "application/json")
+
+	var listResp pb.ListResponse
+	c.Check(json.NewDecoder(w.Body).Decode(&listResp), gc.IsNil)
+	c.Check(listResp.Journals, gc.HasLen, 1)
+	c.Check(listResp.Journals[0].Spec.Name, gc.Equals, pb.Journal("a/journal"))
+
+	// Case: Selector is malformed.
+	w = get("/v1/journals?selector=topic%3D%3D%3D")
+	c.Check(w.Code, gc.Equals, http.StatusBadRequest)
+
+	var ttl = time.Hour
+	broker.ListFragmentsFunc = func(_ context.Context, req *pb.FragmentsRequest) (*pb.FragmentsResponse, error) {
+		c.Check(req, gc.DeepEquals, &pb.FragmentsRequest{
+			Journal:      "a/journal",
+			BeginModTime: 10,
+			EndModTime:   20,
+			SignatureTTL: &ttl,
+		})
+		return &pb.FragmentsResponse{
+			Header: *readResponseFixture.Header,
+			Fragments: []pb.FragmentsResponse__Fragment{{
+				Spec: pb.Fragment{
+					Journal:          "a/journal",
+					Begin:            0,
+					End:              1024,
+					CompressionCodec: pb.CompressionCodec_NONE,
+				},
+				SignedUrl: "http://host/path/to/fragment",
+			}},
+		}, nil
+	}
+
+	// Case: List fragments of a journal.
+	w = get("/v1/journals/a/journal/fragments?begin=10&end=20&signatureTTL=1h")
+	c.Check(w.Code, gc.Equals, http.StatusOK)
+
+	var fragResp pb.FragmentsResponse
+	c.Check(json.NewDecoder(w.Body).Decode(&fragResp), gc.IsNil)
+	c.Check(fragResp.Fragments, gc.HasLen, 1)
+	c.Check(fragResp.Fragments[0].SignedUrl, gc.Equals, "http://host/path/to/fragment")
+
+	// Case: Journal is not found.
+	broker.ListFragmentsFunc = func(_ context.Context, req *pb.FragmentsRequest) (*pb.FragmentsResponse, error) {
+		return &pb.FragmentsResponse{
+			Header: *readResponseFixture.Header,
+			Status: pb.Status_JOURNAL_NOT_FOUND,
+		}, nil
+	}
+	w = get("/v1/journals/a/journal/fragments")
+	c.Check(w.Code, gc.Equals, http.StatusNotFound)
+
+	// Case: Parameters are malformed.
+	w = get("/v1/journals/a/journal/fragments?signatureTTL=foo")
+	c.Check(w.Code, gc.Equals, http.StatusBadRequest)
+	w = get("/v1/journals/a/journal/fragments?begin=20&end=10")
+	c.Check(w.Code, gc.Equals, http.StatusBadRequest)
+	w = get("/v1/journals/a/journal/fragments?extra=1")
+	c.Check(w.Code, gc.Equals, http.StatusBadRequest)
+}
+
+func (s *HTTPSuite) TestCORSPolicy(c *gc.C) {
+	var g = NewGateway(nil)
+
+	var preflight = func(origin, method, headers string) *httptest.ResponseRecorder {
+		var req, _ = http.NewRequest("OPTIONS", "/a/journal", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", method)
+		if headers != "" {
+			req.Header.Set("Access-Control-Request-Headers", headers)
+		}
+		var w = httptest.NewRecorder()
+		g.ServeHTTP(w, req)
+		return w
+	}
+
+	// Case: The zero-valued policy allows no cross-origin requests.
+	var w = preflight("https://app.example", "GET", "")
+	c.Check(w.Code, gc.Equals, http.StatusForbidden)
+	c.Check(w.Header().Get("Access-Control-Allow-Origin"), gc.Equals, "")
+
+	g.SetCORSPolicy(CORSPolicy{
+		AllowedOrigins: []string{"https://app.example"},
+		AllowedHeaders: []string{"Content-Type", "last-event-id"},
+	})
+
+	// Case: An allowed origin, method, and headers.
+	w = preflight("https://app.example", "PUT", "content-type, Last-Event-ID")
+	c.Check(w.Code, gc.Equals, http.StatusNoContent)
+	c.Check(w.Header(), gc.DeepEquals, http.Header{
+		"Access-Control-Allow-Origin":  []string{"https://app.example"},
+		"Access-Control-Allow-Methods": []string{"PUT"},
+		"Access-Control-Allow-Headers": []string{"content-type, Last-Event-ID"},
+		"Vary":                         []string{"Origin"},
+	})
+
+	// Case: Origin, method, or headers are not allowed.
+	c.Check(preflight("https://other.example", "GET", "").Code, gc.Equals, http.StatusForbidden)
+	c.Check(preflight("https://app.example", "DELETE", "").Code, gc.Equals, http.StatusForbidden)
+	c.Check(preflight("https://app.example", "GET", "X-Other").Code, gc.Equals, http.StatusForbidden)
+
+	// Case: Policy with wildcards, and restricted methods.
+	g.SetCORSPolicy(CORSPolicy{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET"},
+		AllowedHeaders: []string{"*"},
+	})
+	c.Check(preflight("https://other.example", "GET", "X-Other").Code, gc.Equals, http.StatusNoContent)
+	c.Check(preflight("https://other.example", "PUT", "").Code, gc.Equals, http.StatusForbidden)
+
+	// Case: Headers of an allowed cross-origin request are exposed.
+	var req, _ = http.NewRequest("PUT", "/a/journal?extra=1", nil)
+	req.Header.Set("Origin", "https://other.example")
+	w = httptest.NewRecorder()
+	g.ServeHTTP(w, req) // Method not allowed.
+
+	c.Check(w.Header().Get("Access-Control-Allow-Origin"), gc.Equals, "")
+
+	req.Method = "GET"
+	w = httptest.NewRecorder()
+	g.ServeHTTP(w, req) // Fails to parse, but exposes headers.
+
+	c.Check(w.Code, gc.Equals, http.StatusBadRequest)
+	c.Check(w.Header().Get("Access-Control-Allow-Origin"), gc.Equals, "https://other.example")
+	c.Check(w.Header().Get("Access-Control-Expose-Headers"), gc.Matches, ".*X-Write-Head.*")
+}
+
+func (s *HTTPSuite) TestStaticVerifierParsingAndVerification(c *gc.C) {
+	var v, err = ParseStaticVerifier([]byte(`
+apiKeys:
+  - key: a-key
+    read: "prefix=examples/"
+    append: "name=examples/foo"
+users:
+  - user: a-user
+    password: a-password
+    read: ""
+`))
+	c.Assert(err, gc.IsNil)
+
+	var all, examples, foo = pb.LabelSelector{},
+		pb.LabelSelector{Include: pb.MustLabelSet("prefix", "examples/")},
+		pb.LabelSelector{Include: pb.MustLabelSet("name", "examples/foo")}
+
+	c.Check(v, gc.DeepEquals, &StaticVerifier{
+		APIKeys: map[string]Grants{"a-key": {Read: &examples, Append: &foo}},
+		Users: map[string]StaticUser{
+			"a-user": {Password: "a-password", Grants: Grants{Read: &all}},
+		},
+	})
+
+	var verify = func(mutate func(*http.Request)) (Grants, error) {
+		var req, _ = http.NewRequest("GET", "/a/journal", nil)
+		mutate(req)
+		return v.Verify(req)
+	}
+
+	// Case: API key, as a header or a bearer token.
+	grants, err := verify(func(r *http.Request) { r.Header.Set("X-API-Key", "a-key") })
+	c.Check(err, gc.IsNil)
+	c.Check(grants, gc.DeepEquals, Grants{Read: &examples, Append: &foo})
+	grants, err = verify(func(r *http.Request) { r.Header.Set("Authorization", "Bearer a-key") })
+	c.Check(err, gc.IsNil)
+	c.Check(grants, gc.DeepEquals, Grants{Read: &examples, Append: &foo})
+
+	// Case: Basic-auth user.
+	grants, err = verify(func(r *http.Request) { r.SetBasicAuth("a-user", "a-password") })
+	c.Check(err, gc.IsNil)
+	c.Check(grants, gc.DeepEquals, Grants{Read: &all})
+
+	// Case: Invalid and missing credentials.
+	_, err = verify(func(r *http.Request) { r.Header.Set("X-API-Key", "other-key") })
+	c.Check(err, gc.ErrorMatches, "invalid credentials")
+	_, err = verify(func(r *http.Request) { r.SetBasicAuth("a-user", "other-password") })
+	c.Check(err, gc.ErrorMatches, "invalid credentials")
+	_, err = verify(func(r *http.Request) { r.SetBasicAuth("other-user", "a-password") })
+	c.Check(err, gc.ErrorMatches, "invalid credentials")
+	_, err = verify(func(r *http.Request) {})
+	c.Check(err, gc.ErrorMatches, "missing credentials")
+
+	// Case: Configuration errors.
+	for _, tc := range []struct{ yaml, err string }{
+		{"apiKeys: [{read: ''}]", `apiKeys\[0\]: expected key`},
+		{"apiKeys: [{key: a}, {key: a}]", `apiKeys\[1\]: duplicated key`},
+		{"apiKeys: [{key: a, append: 'foo=='}]", `apiKeys\[0\].append: .*`},
+		{"users: [{user: a}]", `users\[0\]: expected user and password`},
+		{"users: [{user: a, password: b}, {user: a, password: c}]", `users\[1\]: duplicated user \(a\)`},
+		{"users: [{user: a, password: b, extra: c}]", `(?s).*field extra not found.*`},
+	} {
+		_, err = ParseStaticVerifier([]byte(tc.yaml))
+		c.Check(err, gc.ErrorMatches, tc.err)
+	}
+}
+
+func (s *HTTPSuite) TestServingAuthenticatedRequests(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var g = NewGateway(rjc)
+
+	var examples = pb.LabelSelector{Include: pb.MustLabelSet("prefix", "examples/")}
+	g.SetVerifier(&StaticVerifier{
+		APIKeys: map[string]Grants{
+			"reader": {Read: &examples},
+			"writer": {Append: &examples},
+		},
+	})
+
+	var specs = []pb.ListResponse_Journal{
+		{Spec: pb.JournalSpec{Name: "examples/foo"}},
+		{Spec: pb.JournalSpec{Name: "other/bar"}},
+	}
+	for i := range specs {
+		specs[i].Spec.Replication = 1
+		specs[i].Spec.Fragment = pb.JournalSpec_Fragment{
+			Length:           1024,
+			CompressionCodec: pb.CompressionCodec_NONE,
+			RefreshInterval:  time.Minute,
+		}
+		specs[i].ModRevision = 1
+		specs[i].Route = readResponseFixture.Header.Route
+	}
+
+	broker.ListFunc = func(_ context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+		var resp = &pb.ListResponse{Header: *readResponseFixture.Header}
+		for _, j := range specs {
+			if req.Selector.Include.ValueOf("name") == "" ||
+				req.Selector.Include.ValueOf("name") == j.Spec.Name.String() {
+				resp.Journals = append(resp.Journals, j)
+			}
+		}
+		return resp, nil
+	}
+
+	var serve = func(method, url, key string) *httptest.ResponseRecorder {
+		var req, _ = http.NewRequest(method, url, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		var w = httptest.NewRecorder()
+		g.ServeHTTP(w, req)
+		return w
+	}
+
+	// Case: Requests without valid credentials are rejected.
+	var w = serve("GET", "/examples/foo", "")
+	c.Check(w.Code, gc.Equals, http.StatusUnauthorized)
+	c.Check(w.Header().Get("WWW-Authenticate"), gc.Equals, `Basic realm="gazette"`)
+	c.Check(serve("GET", "/examples/foo", "other").Code, gc.Equals, http.StatusUnauthorized)
+
+	// Case: Reads and appends of journals which aren't permitted.
+	c.Check(serve("GET", "/other/bar", "reader").Code, gc.Equals, http.StatusForbidden)
+	c.Check(serve("GET", "/examples/missing", "reader").Code, gc.Equals, http.StatusForbidden)
+	c.Check(serve("PUT", "/examples/foo", "reader").Code, gc.Equals, http.StatusForbidden)
+	c.Check(serve("GET", "/examples/foo", "writer").Code, gc.Equals, http.StatusForbidden)
+	c.Check(serve("GET", "/v1/journals/other/bar/fragments", "reader").Code, gc.Equals, http.StatusForbidden)
+
+	// Case: A permitted read.
+	go func() {
+		c.Check(<-broker.ReadReqCh, gc.DeepEquals, &pb.ReadRequest{Journal: "examples/foo"})
+		broker.ReadRespCh <- &pb.ReadResponse{
+			Status: pb.Status_JOURNAL_NOT_FOUND,
+			Header: readResponseFixture.Header,
+		}
+		broker.ErrCh <- nil
+	}()
+	c.Check(serve("GET", "/examples/foo", "reader").Code, gc.Equals, http.StatusNotFound)
+
+	// Case: Listed journals are filtered to those which may be read.
+	w = serve("GET", "/v1/journals", "reader")
+	c.Check(w.Code, gc.Equals, http.StatusOK)
+
+	var listResp pb.ListResponse
+	c.Check(json.NewDecoder(w.Body).Decode(&listResp), gc.IsNil)
+	c.Check(listResp.Journals, gc.HasLen, 1)
+	c.Check(listResp.Journals[0].Spec.Name, gc.Equals, pb.Journal("examples/foo"))
+
+	w = serve("GET", "/v1/journals", "writer")
+	c.Check(w.Code, gc.Equals, http.StatusOK)
+	listResp = pb.ListResponse{}
+	c.Check(json.NewDecoder(w.Body).Decode(&listResp), gc.IsNil)
+	c.Check(listResp.Journals, gc.HasLen, 0)
+}
+
+func (s *HTTPSuite) TestRequestRateLimits(c *gc.C) {
+	var g = NewGateway(nil)
+	g.SetRateLimits(RateLimits{RequestsPerSecond: 2})
+
+	var serve = func(remoteAddr string) *httptest.ResponseRecorder {
+		var req, _ = http.NewRequest("DELETE", "/a/journal", nil)
+		req.RemoteAddr = remoteAddr
+		var w = httptest.NewRecorder()
+		g.ServeHTTP(w, req)
+		return w
+	}
+
+	// Requests are admitted (and fail as DELETE is not a known method)
+	// until the client's budget is exhausted.
+	c.Check(serve("1.2.3.4:5678").Code, gc.Equals, http.StatusBadRequest)
+	c.Check(serve("1.2.3.4:5679").Code, gc.Equals, http.StatusBadRequest)
+
+	var w = serve("1.2.3.4:5680")
+	c.Check(w.Code, gc.Equals, http.StatusTooManyRequests)
+	c.Check(w.Header().Get("Retry-After"), gc.Equals, "1")
+
+	// Other clients have independent budgets.
+	c.Check(serve("5.6.7.8:5678").Code, gc.Equals, http.StatusBadRequest)
+}
+
+func (s *HTTPSuite) TestAuthenticatedRequestRateLimits(c *gc.C) {
+	var g = NewGateway(nil)
+	g.SetVerifier(&StaticVerifier{APIKeys: map[string]Grants{"a-key": {}}})
+	g.SetRateLimits(RateLimits{RequestsPerSecond: 2})
+
+	var serve = func(remoteAddr, key string) int {
+		var req, _ = http.NewRequest("DELETE", "/a/journal", nil)
+		req.RemoteAddr = remoteAddr
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		var w = httptest.NewRecorder()
+		g.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Unauthenticated requests are limited by remote IP, before they're
+	// authenticated.
+	c.Check(serve("1.2.3.4:5678", "bad-key"), gc.Equals, http.StatusUnauthorized)
+	c.Check(serve("1.2.3.4:5678", "bad-key"), gc.Equals, http.StatusUnauthorized)
+	c.Check(serve("1.2.3.4:5678", "a-key"), gc.Equals, http.StatusTooManyRequests)
+
+	// Authenticated requests are admitted (and fail as DELETE is not a known
+	// method) until the budget of their API key is exhausted.
+	c.Check(serve("5.6.7.8:5678", "a-key"), gc.Equals, http.StatusBadRequest)
+	c.Check(serve("9.10.11.12:5678", "a-key"), gc.Equals, http.StatusBadRequest)
+	c.Check(serve("13.14.15.16:5678", "a-key"), gc.Equals, http.StatusTooManyRequests)
+}
+
+func (s *HTTPSuite) TestRateLimiterBudgets(c *gc.C) {
+	var rl = &rateLimiter{
+		limits:  RateLimits{BytesPerSecond: 10},
+		clients: make(map[string]*clientLimiter),
+	}
+	var now = time.Now()
+
+	var cl, delay = rl.admit("a", now)
+	c.Check(delay, gc.Equals, time.Duration(0))
+	c.Check(cl.requests, gc.IsNil)
+
+	// Transfer a full second of budget. The client isn't admitted until
+	// its budget refills.
+	c.Check(cl.bytes.ReserveN(now, 10).OK(), gc.Equals, true)
+
+	_, delay = rl.admit("a", now)
+	c.Check(delay, gc.Equals, 100*time.Millisecond)
+	_, delay = rl.admit("a", now.Add(100*time.Millisecond))
+	c.Check(delay, gc.Equals, time.Duration(0))
+
+	// Idle clients are dropped.
+	_, _ = rl.admit("b", now.Add(2*rateLimitIdleTimeout))
+	c.Check(rl.clients, gc.HasLen, 1)
+	c.Check(rl.clients["b"], gc.NotNil)
+
+	// Transfers are throttled to the client's budget.
+	var w = &throttledResponseWriter{
+		ResponseWriter: httptest.NewRecorder(),
+		lim:            rate.NewLimiter(rate.Inf, 4),
+		ctx:            context.Background(),
+	}
+	n, err := w.Write([]byte("hello, world"))
+	c.Check(n, gc.Equals, 12)
+	c.Check(err, gc.IsNil)
+
+	var r = &throttledReader{
+		ReadCloser: ioutil.NopCloser(strings.NewReader("hello, world")),
+		lim:        rate.NewLimiter(rate.Inf, 4),
+		ctx:        context.Background(),
+	}
+	var buf [32]byte
+	n, err = r.Read(buf[:])
+	c.Check(string(buf[:n]), gc.Equals, "hell") // Bounded by the Limiter burst.
+	c.Check(err, gc.IsNil)
+}
+
+func (s *HTTPSuite) TestRequestEndpoints(c *gc.C) {
+	for _, tc := range []struct {
+		method, url, accept string
+		endpoint, journal   string
+	}{
+		{"GET", "/a/journal", "", endpointRead, "a/journal"},
+		{"HEAD", "/a/journal", "", endpointRead, "a/journal"},
+		{"GET", "/a/journal", "text/event-stream", endpointEvents, "a/journal"},
+		{"PUT", "/a/journal", "", endpointAppend, "a/journal"},
+		{"POST", "/a/journal", "", endpointAppend, "a/journal"},
+		{"GET", "/v1/journals?selector=foo%3Dbar", "", endpointListJournals, ""},
+		{"GET", "/v1/journals/a/journal/fragments", "", endpointListFragments, "a/journal"},
+		{"DELETE", "/a/journal", "", endpointUnknown, ""},
+	} {
+		var req, _ = http.NewRequest(tc.method, tc.url, nil)
+		if tc.accept != "" {
+			req.Header.Set("Accept", tc.accept)
+		}
+		var endpoint, journal = requestEndpoint(req)
+		c.Check(endpoint, gc.Equals, tc.endpoint)
+		c.Check(journal, gc.Equals, tc.journal)
+	}
+
+	var req, _ = http.NewRequest("OPTIONS", "/a/journal", nil)
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	var endpoint, journal = requestEndpoint(req)
+	c.Check(endpoint, gc.Equals, endpointPreflight)
+	c.Check(journal, gc.Equals, "")
+}
+
+func (s *HTTPSuite) TestRequestMetrics(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var g = NewGateway(rjc)
+
+	var readCounter = func(m prometheus.Metric) float64 {
+		var out dto.Metric
+		c.Assert(m.Write(&out), gc.IsNil)
+		return out.Counter.GetValue()
+	}
+	var requests = func(endpoint, journal, code string) float64 {
+		return readCounter(metrics.HTTPGatewayRequestsTotal.WithLabelValues(endpoint, journal, code))
+	}
+	var received = readCounter(metrics.HTTPGatewayReceivedBytesTotal.WithLabelValues(endpointAppend, "metrics/journal"))
+	var appends, unknowns = requests(endpointAppend, "metrics/journal", "204"), requests(endpointUnknown, "", "400")
+
+	go func() {
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "metrics/journal"})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("some content")})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+
+		var resp = appendResponseFixture
+		resp.Commit = &pb.Fragment{Journal: "metrics/journal", Begin: 100, End: 112,
+			CompressionCodec: pb.CompressionCodec_NONE}
+		broker.AppendRespCh <- &resp
+	}()
+
+	var req, _ = http.NewRequest("PUT", "/metrics/journal", strings.NewReader("some content"))
+	var w = httptest.NewRecorder()
+	g.ServeHTTP(w, req)
+	c.Check(w.Code, gc.Equals, http.StatusNoContent)
+
+	req, _ = http.NewRequest("DELETE", "/metrics/journal", nil)
+	g.ServeHTTP(httptest.NewRecorder(), req)
+
+	c.Check(requests(endpointAppend, "metrics/journal", "204"), gc.Equals, appends+1)
+	c.Check(requests(endpointUnknown, "", "400"), gc.Equals, unknowns+1)
+	c.Check(readCounter(metrics.HTTPGatewayReceivedBytesTotal.WithLabelValues(endpointAppend, "metrics/journal")),
+		gc.Equals, received+12)
+}
+
+func (s *HTTPSuite) TestEventEncoding(c *gc.C) {
+	var buf bytes.Buffer
+
+	writeEvent(&buf, 123, []byte("multi\r\nline\n"), false)
+	c.Check(buf.String(), gc.Equals, "id: 123\ndata: multi\ndata: line\n\n")
+
+	buf.Reset()
+	writeEvent(&buf, 456, []byte{0x00, 0x01, 0x02}, true)
+	c.Check(buf.String(), gc.Equals, "id: 456\ndata: AAEC\n\n")
+}
+
+func (s *HTTPSuite) TestServingChunkedMultipartWrite(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var broker = teststub.NewBroker(c, ctx)
+	var rjc = pb.NewRoutedJournalClient(broker.MustClient(), pb.NoopDispatchRouter{})
+	var srv = httptest.NewServer(NewGateway(rjc))
+	defer srv.Close()
+
+	go func() {
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+
+		// Content of parts may be read in arbitrary chunks. Gather until commit.
+		var content []byte
+		for req := <-broker.AppendReqCh; len(req.Content) != 0; req = <-broker.AppendReqCh {
+			content = append(content, req.Content...)
+		}
+		c.Check(string(content), gc.Equals, "part one, part two")
+
+		broker.AppendRespCh <- &appendResponseFixture
+	}()
+
+	// Write a multipart body through a pipe, which
+	// is sent using chunked transfer encoding.
+	var pr, pw = io.Pipe()
+	var mw = multipart.NewWriter(pw)
+
+	go func() {
+		var part, _ = mw.CreateFormFile("file", "one.txt")
+		_, _ = part.Write([]byte("part one, "))
+		part, _ = mw.CreateFormFile("file", "two.txt")
+		_, _ = part.Write([]byte("part two"))
+		c.Check(mw.Close(), gc.IsNil)
+		c.Check(pw.Close(), gc.IsNil)
+	}()
+
+	var resp, err = http.Post(srv.URL+"/a/journal", mw.FormDataContentType(), pr)
+	c.Assert(err, gc.IsNil)
+	c.Check(resp.Body.Close(), gc.IsNil)
+
+	c.Check(resp.StatusCode, gc.Equals, http.StatusNoContent)
+	c.Check(resp.Header.Get("X-Commit-Begin"), gc.Equals, "100")
+	c.Check(resp.Header.Get("X-Commit-End"), gc.Equals, "200")
+	c.Check(resp.Header.Get("X-Write-Head"), gc.Equals, "200")
+}
+
 var (
 	_ = gc.Suite(&HTTPSuite{})
 
@@ -0,0 +1,105 @@
+package http_gateway
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
+)
+
+// Endpoints of Gateway requests, by which metrics are labeled.
+const (
+	endpointRead          = "read"
+	endpointEvents        = "events"
+	endpointAppend        = "append"
+	endpointListJournals  = "list_journals"
+	endpointListFragments = "list_fragments"
+	endpointPreflight     = "preflight"
+	endpointUnknown       = "unknown"
+)
+
+// requestEndpoint returns the endpoint of the request, and its journal (if any).
+func requestEndpoint(r *http.Request) (endpoint, journal string) {
+	if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
+		return endpointPreflight, ""
+	}
+	switch ep := restEndpoint(r); ep {
+	case endpointListJournals:
+		return ep, ""
+	case endpointListFragments:
+		return ep, restFragmentsJournal(r).String()
+	}
+
+	switch r.Method {
+	case "GET", "HEAD":
+		if r.Method == "GET" && acceptsEventStream(r) {
+			return endpointEvents, r.URL.Path[1:]
+		}
+		return endpointRead, r.URL.Path[1:]
+	case "PUT", "POST":
+		return endpointAppend, r.URL.Path[1:]
+	default:
+		return endpointUnknown, ""
+	}
+}
+
+// observeRequest records metrics of a completed request. So that arbitrary
+// request paths don't produce unbounded label values, the journal label is
+// applied only to requests which didn't fail with a client error (4xx status).
+func observeRequest(endpoint, journal string, mw *metricsResponseWriter, mr *metricsReader, started time.Time) {
+	var code = mw.code
+	if code == 0 {
+		code = http.StatusOK // Implied by a Write without a WriteHeader.
+	}
+	if code >= 400 && code < 500 {
+		journal = ""
+	}
+	metrics.HTTPGatewayRequestsTotal.WithLabelValues(endpoint, journal, strconv.Itoa(code)).Inc()
+	metrics.HTTPGatewayRequestSeconds.WithLabelValues(endpoint, journal).Observe(time.Since(started).Seconds())
+	metrics.HTTPGatewaySentBytesTotal.WithLabelValues(endpoint, journal).Add(float64(mw.bytes))
+
+	if mr != nil {
+		metrics.HTTPGatewayReceivedBytesTotal.WithLabelValues(endpoint, journal).Add(float64(mr.bytes))
+	}
+}
+
+// metricsResponseWriter tracks the status code and written bytes of a response.
+type metricsResponseWriter struct {
+	http.ResponseWriter
+	code  int
+	bytes int64
+}
+
+func (w *metricsResponseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *metricsResponseWriter) Write(p []byte) (int, error) {
+	var n, err = w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher, if the wrapped ResponseWriter does.
+func (w *metricsResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// metricsReader tracks bytes read of a request body.
+type metricsReader struct {
+	io.ReadCloser
+	bytes int64
+}
+
+func (r *metricsReader) Read(p []byte) (int, error) {
+	var n, err = r.ReadCloser.Read(p)
+	r.bytes += int64(n)
+	return n, err
+}
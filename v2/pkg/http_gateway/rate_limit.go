@@ -0,0 +1,245 @@
+package http_gateway
+
+import (
+	"context"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimits are token-bucket budgets of requests and transferred bytes (of
+// both reads and appends), applied to each client of the Gateway. A budget is
+// permitted to burst up to one second of its rate.
+//
+// Every request is first admitted against the budget of its remote IP, before
+// it's authenticated, so that unauthenticated clients (eg, those guessing
+// credentials) are also limited. A request which then authenticates using an
+// API key is additionally admitted against the budget of that key, which also
+// throttles its transfers. Transfers of other requests are throttled to the
+// budget of their remote IP.
+//
+// A request of a client having no remaining budget is rejected with status
+// 429 (Too Many Requests) and a Retry-After header. Transfers of admitted
+// requests are throttled to the client's budget of bytes.
+type RateLimits struct {
+	// RequestsPerSecond of each client. Zero is unlimited.
+	RequestsPerSecond int
+	// BytesPerSecond of each client. Zero is unlimited.
+	BytesPerSecond int
+}
+
+// SetRateLimits sets per-client RateLimits of the Gateway.
+func (h *Gateway) SetRateLimits(limits RateLimits) {
+	if limits.RequestsPerSecond == 0 && limits.BytesPerSecond == 0 {
+		h.limiter = nil
+	} else {
+		h.limiter = &rateLimiter{
+			limits:  limits,
+			clients: make(map[string]*clientLimiter),
+		}
+	}
+}
+
+// rateLimitIP admits the unauthenticated request against the RateLimits of
+// its remote IP, if the Gateway has RateLimits. If the request is rejected, an
+// error response is written and false is returned. Otherwise, the admitting
+// clientLimiter (or nil, if the Gateway has no RateLimits) is returned.
+func (h *Gateway) rateLimitIP(w http.ResponseWriter, r *http.Request) (*clientLimiter, bool) {
+	if h.limiter == nil {
+		return nil, true
+	}
+	return h.admit(w, remoteIPKey(r))
+}
+
+// rateLimit applies RateLimits of the Gateway, if any, to the authenticated
+// request admitted by clientLimiter |cl| of its remote IP. If the request
+// authenticated using an API key, it's re-keyed and admitted against the
+// budget of that key. If the request is rejected, an error response is
+// written and false is returned. Otherwise, the ResponseWriter and request
+// having throttled transfers are returned.
+func (h *Gateway) rateLimit(w http.ResponseWriter, r *http.Request, cl *clientLimiter) (http.ResponseWriter, *http.Request, bool) {
+	if h.limiter == nil {
+		return w, r, true
+	}
+	if key := authenticatedAPIKey(r); key != "" {
+		var ok bool
+		if cl, ok = h.admit(w, "key:"+key); !ok {
+			return w, r, false
+		}
+	}
+	if cl.bytes == nil {
+		return w, r, true
+	}
+
+	if r.Body != nil {
+		var rr = *r
+		rr.Body = &throttledReader{ReadCloser: r.Body, lim: cl.bytes, ctx: r.Context()}
+		r = &rr
+	}
+	return &throttledResponseWriter{ResponseWriter: w, lim: cl.bytes, ctx: r.Context()}, r, true
+}
+
+// admit a request of the client |key|. If the client has no remaining budget,
+// an error response is written and false is returned.
+func (h *Gateway) admit(w http.ResponseWriter, key string) (*clientLimiter, bool) {
+	var cl, delay = h.limiter.admit(key, time.Now())
+	if delay != 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return nil, false
+	}
+	return cl, true
+}
+
+// authenticatedAPIKey returns the API key of the request, if it was
+// authenticated using one, or empty otherwise.
+func authenticatedAPIKey(r *http.Request) string {
+	if _, ok := r.Context().Value(grantsKey{}).(Grants); ok {
+		return requestAPIKey(r)
+	}
+	return ""
+}
+
+// remoteIPKey returns the rate limit key of the request's remote IP.
+func remoteIPKey(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return "ip:" + host
+	}
+	return "ip:" + r.RemoteAddr
+}
+
+// rateLimiter tracks the budgets of Gateway clients.
+type rateLimiter struct {
+	limits    RateLimits
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+// clientLimiter is the budget of a Gateway client.
+type clientLimiter struct {
+	requests *rate.Limiter // Budget of requests, or nil if unlimited.
+	bytes    *rate.Limiter // Budget of bytes, or nil if unlimited.
+	lastSeen time.Time
+}
+
+// admit a request of the client |key| at |now|, returning its clientLimiter.
+// If the client has no remaining budget, the request isn't admitted and the
+// delay after which budget will be available is returned.
+func (rl *rateLimiter) admit(key string, now time.Time) (*clientLimiter, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	// Clients idle for longer than |rateLimitIdleTimeout| have fully refilled
+	// budgets, and are indistinguishable from new clients. Drop them.
+	if now.Sub(rl.lastSweep) > rateLimitIdleTimeout {
+		for k, cl := range rl.clients {
+			if now.Sub(cl.lastSeen) > rateLimitIdleTimeout {
+				delete(rl.clients, k)
+			}
+		}
+		rl.lastSweep = now
+	}
+
+	var cl, ok = rl.clients[key]
+	if !ok {
+		cl = new(clientLimiter)
+		if n := rl.limits.RequestsPerSecond; n > 0 {
+			cl.requests = rate.NewLimiter(rate.Limit(n), n)
+		}
+		if n := rl.limits.BytesPerSecond; n > 0 {
+			cl.bytes = rate.NewLimiter(rate.Limit(n), n)
+		}
+		rl.clients[key] = cl
+	}
+	cl.lastSeen = now
+
+	var rReq, rBytes *rate.Reservation
+	if cl.requests != nil {
+		rReq = cl.requests.ReserveN(now, 1)
+	}
+	if cl.bytes != nil {
+		// Bytes are taken only as they're transferred, but a client which
+		// has exhausted its budget of bytes is not admitted.
+		rBytes = cl.bytes.ReserveN(now, 1)
+		defer rBytes.CancelAt(now)
+	}
+
+	var delay time.Duration
+	for _, r := range []*rate.Reservation{rReq, rBytes} {
+		if r != nil && r.DelayFrom(now) > delay {
+			delay = r.DelayFrom(now)
+		}
+	}
+	if delay != 0 && rReq != nil {
+		rReq.CancelAt(now)
+	}
+	return cl, delay
+}
+
+// throttledResponseWriter throttles written response content to a budget.
+type throttledResponseWriter struct {
+	http.ResponseWriter
+	lim *rate.Limiter
+	ctx context.Context
+}
+
+func (w *throttledResponseWriter) Write(p []byte) (int, error) {
+	if err := waitN(w.ctx, w.lim, len(p)); err != nil {
+		return 0, err
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher, if the wrapped ResponseWriter does.
+func (w *throttledResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// throttledReader throttles read request content to a budget.
+type throttledReader struct {
+	io.ReadCloser
+	lim *rate.Limiter
+	ctx context.Context
+}
+
+func (r *throttledReader) Read(p []byte) (n int, err error) {
+	// Bound the read to the Limiter's burst, so that
+	// content isn't read beyond the current budget.
+	if len(p) > r.lim.Burst() {
+		p = p[:r.lim.Burst()]
+	}
+	if n, err = r.ReadCloser.Read(p); n != 0 {
+		if waitErr := waitN(r.ctx, r.lim, n); waitErr != nil {
+			return 0, waitErr
+		}
+	}
+	return
+}
+
+// waitN waits for |n| tokens from the Limiter, in increments
+// of at most the Limiter's burst.
+func waitN(ctx context.Context, lim *rate.Limiter, n int) error {
+	for n != 0 {
+		var m = n
+		if b := lim.Burst(); m > b {
+			m = b
+		}
+		if err := lim.WaitN(ctx, m); err != nil {
+			return err
+		}
+		n -= m
+	}
+	return nil
+}
+
+// rateLimitIdleTimeout is the duration after which an idle client is dropped.
+var rateLimitIdleTimeout = time.Minute
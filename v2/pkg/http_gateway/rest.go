@@ -0,0 +1,164 @@
+package http_gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	log "github.com/sirupsen/logrus"
+)
+
+// serveREST serves GET requests of the JSON REST API of the Gateway:
+//
+//   - "/v1/journals" lists journals, as does the List RPC. An optional
+//     "selector" parameter is a LabelSelector (eg, "topic=foo, app!=bar").
+//   - "/v1/journals/{name}/fragments" lists the Fragments of the journal,
+//     as does the Fragments RPC. Optional "begin" and "end" parameters bound
+//     Fragment modification times (as seconds since the epoch), and an optional
+//     "signatureTTL" (eg, "1h") requests signed Fragment URLs.
+//
+// Responses are JSON encodings of the ListResponse or FragmentsResponse, joined
+// across all response pages. It returns false if the request isn't a REST API
+// request, and should be served as a journal Read instead.
+func (h *Gateway) serveREST(w http.ResponseWriter, r *http.Request) bool {
+	switch restEndpoint(r) {
+	case endpointListJournals:
+		h.serveListJournals(w, r)
+	case endpointListFragments:
+		h.serveListFragments(w, r)
+	default:
+		return false
+	}
+	return true
+}
+
+// restEndpoint returns the REST API endpoint of the request,
+// or the empty string if it's not a REST API request.
+func restEndpoint(r *http.Request) string {
+	if r.Method != "GET" {
+		return ""
+	} else if r.URL.Path == restJournalsPath {
+		return endpointListJournals
+	} else if strings.HasPrefix(r.URL.Path, restJournalsPath+"/") &&
+		strings.HasSuffix(r.URL.Path, restFragmentsSuffix) &&
+		len(r.URL.Path) > len(restJournalsPath)+len(restFragmentsSuffix)+1 {
+		return endpointListFragments
+	}
+	return ""
+}
+
+func (h *Gateway) serveListJournals(w http.ResponseWriter, r *http.Request) {
+	var req, err = h.parseListRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp, err := client.ListAllJournals(r.Context(), h.client, req)
+	if err != nil {
+		writeRESTError(w, r, err)
+		return
+	}
+	filterReadableJournals(r, resp)
+	writeRESTResponse(w, resp)
+}
+
+func (h *Gateway) serveListFragments(w http.ResponseWriter, r *http.Request) {
+	var req, err = h.parseFragmentsRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if !h.authorize(w, r, req.Journal, false) {
+		return
+	}
+	resp, err := client.ListAllFragments(r.Context(), h.client, req)
+	if err != nil {
+		writeRESTError(w, r, err)
+		return
+	}
+	writeRESTResponse(w, resp)
+}
+
+func (h *Gateway) parseListRequest(r *http.Request) (pb.ListRequest, error) {
+	var schema struct {
+		Selector string
+	}
+	var q url.Values
+	var err error
+	var req pb.ListRequest
+
+	if q, err = url.ParseQuery(r.URL.RawQuery); err == nil {
+		err = h.decoder.Decode(&schema, q)
+	}
+	if err == nil {
+		req.Selector, err = pb.ParseLabelSelector(schema.Selector)
+	}
+	if err == nil {
+		err = req.Validate()
+	}
+	return req, err
+}
+
+func (h *Gateway) parseFragmentsRequest(r *http.Request) (pb.FragmentsRequest, error) {
+	var schema struct {
+		Begin        int64
+		End          int64
+		SignatureTTL string
+	}
+	var q url.Values
+	var err error
+
+	if q, err = url.ParseQuery(r.URL.RawQuery); err == nil {
+		err = h.decoder.Decode(&schema, q)
+	}
+	var req = pb.FragmentsRequest{
+		Journal:      restFragmentsJournal(r),
+		BeginModTime: schema.Begin,
+		EndModTime:   schema.End,
+	}
+	if err == nil && schema.SignatureTTL != "" {
+		var ttl time.Duration
+		if ttl, err = time.ParseDuration(schema.SignatureTTL); err == nil {
+			req.SignatureTTL = &ttl
+		}
+	}
+	if err == nil {
+		err = req.Validate()
+	}
+	return req, err
+}
+
+// restFragmentsJournal returns the journal of a "/v1/journals/{name}/fragments" request.
+func restFragmentsJournal(r *http.Request) pb.Journal {
+	return pb.Journal(r.URL.Path[len(restJournalsPath)+1 : len(r.URL.Path)-len(restFragmentsSuffix)])
+}
+
+// writeRESTError maps an error of a REST API request into an HTTP status code.
+func writeRESTError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == client.StatusError(pb.Status_JOURNAL_NOT_FOUND) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if r.Context().Err() != nil {
+		// Request was aborted by client.
+		http.Error(w, err.Error(), http.StatusRequestTimeout)
+	} else {
+		log.WithField("err", err).Warn("http_gateway: failed to serve REST request")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeRESTResponse(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.WithField("err", err).Warn("http_gateway: failed to write REST response")
+	}
+}
+
+const (
+	restJournalsPath    = "/v1/journals"
+	restFragmentsSuffix = "/fragments"
+)
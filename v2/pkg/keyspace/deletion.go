@@ -0,0 +1,56 @@
+package keyspace
+
+// Deletion describes a KeyValue which was removed from the KeySpace.
+type Deletion struct {
+	// KeyValue is the last value of the deleted key. Its Raw.Lease is the ID
+	// of the Lease to which the key was attached, or zero if none was.
+	KeyValue
+	// Revision at which the key was deleted.
+	Revision int64
+	// LeaseRevoked is true if the deletion is attributed to revocation of the
+	// key's Lease, which is typically due to its expiry (eg, the loss of the
+	// session of a member process) rather than a graceful delete. Etcd doesn't
+	// attribute deletions to a cause, and it's instead inferred: the key was
+	// attached to a Lease, and the deletion removed every key of the KeySpace
+	// attached to that Lease at a single revision. Keys of a Lease which are
+	// deleted at separate revisions, or while other keys of the Lease remain,
+	// are graceful deletes. So too are deletions of un-leased keys.
+	LeaseRevoked bool
+}
+
+// buildDeletions infers LeaseRevoked of |deleted| keys, returning those which
+// are not present in |next|. |deleted| must be ordered on key.
+func buildDeletions(next KeyValues, deleted []Deletion) []Deletion {
+	var out []Deletion
+	var revisions = make(map[int64]int64) // Lease ID => deletion revision, or -1.
+
+	for _, d := range deleted {
+		if _, found := next.Search(string(d.Raw.Key)); found {
+			continue // Re-created after its deletion.
+		}
+		if lease := d.Raw.Lease; lease != 0 {
+			if rev, ok := revisions[lease]; !ok {
+				revisions[lease] = d.Revision
+			} else if rev != d.Revision {
+				revisions[lease] = -1 // Keys of the Lease were deleted separately.
+			}
+		}
+		out = append(out, d)
+	}
+	if len(revisions) == 0 {
+		return out
+	}
+
+	// Leases which continue to have attached keys weren't revoked.
+	for _, kv := range next {
+		if _, ok := revisions[kv.Raw.Lease]; ok {
+			revisions[kv.Raw.Lease] = -1
+		}
+	}
+	for i := range out {
+		if lease := out[i].Raw.Lease; lease != 0 && revisions[lease] != -1 {
+			out[i].LeaseRevoked = true
+		}
+	}
+	return out
+}
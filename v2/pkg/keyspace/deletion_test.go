@@ -0,0 +1,102 @@
+package keyspace
+
+import (
+	"github.com/coreos/etcd/clientv3"
+	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	gc "github.com/go-check/check"
+)
+
+type DeletionSuite struct{}
+
+func (s *DeletionSuite) TestLeaseRevocationInference(c *gc.C) {
+	var ks = NewKeySpace("/", testDecoder)
+
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 10},
+		Events: []*clientv3.Event{
+			leasedPutEvent("/member/a", "1", 10, 7),
+			leasedPutEvent("/member/b", "2", 10, 7),
+			leasedPutEvent("/member/c", "3", 10, 8),
+			leasedPutEvent("/member/d", "4", 10, 9),
+			putEvent("/item", "5", 10, 10, 1),
+		},
+	}), gc.IsNil)
+	c.Check(ks.Deletions, gc.HasLen, 0)
+
+	// Lease 7 is revoked, deleting all of its keys. /member/c is deleted, but
+	// lease 8 remains attached to /member/e. /item is deleted, and had no lease.
+	var observed []Deletion
+	ks.Observers = append(ks.Observers, func() { observed = ks.Deletions })
+
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 11},
+		Events: []*clientv3.Event{
+			delEvent("/member/a", 11),
+			delEvent("/member/b", 11),
+			delEvent("/member/c", 11),
+			leasedPutEvent("/member/e", "6", 11, 8),
+			delEvent("/item", 11),
+			delEvent("/not/found", 11),
+		},
+	}), gc.IsNil)
+
+	c.Check(summarizeDeletions(observed), gc.DeepEquals, map[string]bool{
+		"/item":     false,
+		"/member/a": true,
+		"/member/b": true,
+		"/member/c": false,
+	})
+	c.Check(observed[1].Raw.Lease, gc.Equals, int64(7))
+	c.Check(observed[1].Revision, gc.Equals, int64(11))
+	c.Check(observed[1].Decoded, gc.Equals, 1)
+
+	// Add a second key of lease 9, and delete each key of lease 9 at separate
+	// revisions. Also delete /member/e.
+	c.Check(ks.Apply(
+		clientv3.WatchResponse{
+			Header: epb.ResponseHeader{ClusterId: 9999, Revision: 12},
+			Events: []*clientv3.Event{
+				leasedPutEvent("/member/f", "7", 12, 9),
+			},
+		},
+		clientv3.WatchResponse{
+			Header: epb.ResponseHeader{ClusterId: 9999, Revision: 14},
+			Events: []*clientv3.Event{
+				delEvent("/member/d", 13),
+				delEvent("/member/e", 14),
+				delEvent("/member/f", 14),
+			},
+		},
+	), gc.IsNil)
+
+	// /member/e was the last key of lease 8. The keys of lease 9 were deleted
+	// at different revisions, and aren't attributed to its revocation.
+	c.Check(summarizeDeletions(observed), gc.DeepEquals, map[string]bool{
+		"/member/d": false,
+		"/member/e": true,
+		"/member/f": false,
+	})
+
+	// An update without deletions clears Deletions.
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 15},
+		Events: []*clientv3.Event{putEvent("/item", "8", 15, 15, 1)},
+	}), gc.IsNil)
+	c.Check(observed, gc.HasLen, 0)
+}
+
+func leasedPutEvent(key, value string, rev, lease int64) *clientv3.Event {
+	var ev = putEvent(key, value, rev, rev, 1)
+	ev.Kv.Lease = lease
+	return ev
+}
+
+func summarizeDeletions(deletions []Deletion) map[string]bool {
+	var out = make(map[string]bool)
+	for _, d := range deletions {
+		out[string(d.Raw.Key)] = d.LeaseRevoked
+	}
+	return out
+}
+
+var _ = gc.Suite(&DeletionSuite{})
@@ -0,0 +1,104 @@
+package keyspace
+
+import "strings"
+
+// Delta describes the changes of KeyValues having a Prefix, which were made
+// by a single update of the KeySpace. Each of Added, Updated, and Deleted is
+// ordered on key.
+type Delta struct {
+	// Prefix of the observed KeyValues.
+	Prefix string
+	// Added KeyValues, which were not present prior to the update.
+	Added KeyValues
+	// Updated KeyValues, at their values following the update.
+	Updated KeyValues
+	// Deleted KeyValues, at their last values prior to the update.
+	Deleted KeyValues
+}
+
+// Empty returns true if the Delta has no changes.
+func (d Delta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Updated) == 0 && len(d.Deleted) == 0
+}
+
+// ObservePrefix registers |fn| to be called with the Delta of KeyValues having
+// |prefix|, upon each update of the KeySpace which adds, updates, or deletes
+// at least one of them. Unlike Observers, |fn| needn't diff the KeySpace to
+// determine what changed. Calls are made after those of Observers, and under
+// the same guarantees: the KeySpace has been updated, and its write-lock is
+// held. Loads of the KeySpace are also observed, as the differences between
+// the prior and loaded KeyValues. ObservePrefix must be called before |ks| is
+// Loaded or Watched.
+func (ks *KeySpace) ObservePrefix(prefix string, fn func(Delta)) {
+	ks.prefixObservers = append(ks.prefixObservers, prefixObserver{prefix: prefix, fn: fn})
+}
+
+// prefixObserver is an observer registered with ObservePrefix.
+type prefixObserver struct {
+	prefix string
+	fn     func(Delta)
+}
+
+// buildDeltas returns a Delta of each of |observers| for the changes of
+// |keys| from KeyValues |prev| to |next|. |keys| must be ordered, and may
+// include keys which weren't actually changed. Returned Deltas reference
+// copies of KeyValues, and remain valid after |prev| is re-used.
+func buildDeltas(observers []prefixObserver, prev, next KeyValues, keys []string) []Delta {
+	if len(observers) == 0 {
+		return nil
+	}
+	var out = make([]Delta, len(observers))
+	for i := range observers {
+		out[i].Prefix = observers[i].prefix
+	}
+
+	for _, key := range keys {
+		var p, n *KeyValue
+
+		if ind, found := prev.Search(key); found {
+			p = &prev[ind]
+		}
+		if ind, found := next.Search(key); found {
+			n = &next[ind]
+		}
+		for i := range observers {
+			if !strings.HasPrefix(key, observers[i].prefix) {
+				continue
+			}
+			switch {
+			case p == nil && n != nil:
+				out[i].Added = append(out[i].Added, *n)
+			case p != nil && n == nil:
+				out[i].Deleted = append(out[i].Deleted, *p)
+			case p != nil && n != nil && p.Raw.ModRevision != n.Raw.ModRevision:
+				out[i].Updated = append(out[i].Updated, *n)
+			}
+		}
+	}
+	return out
+}
+
+// unionKeys returns the ordered union of keys of KeyValues |a| and |b|.
+func unionKeys(a, b KeyValues) []string {
+	var out = make([]string, 0, len(a))
+
+	for len(a) != 0 || len(b) != 0 {
+		var ka, kb string
+		if len(a) != 0 {
+			ka = string(a[0].Raw.Key)
+		}
+		if len(b) != 0 {
+			kb = string(b[0].Raw.Key)
+		}
+
+		switch {
+		case len(b) == 0 || (len(a) != 0 && ka < kb):
+			out, a = append(out, ka), a[1:]
+		case len(a) == 0 || kb < ka:
+			out, b = append(out, kb), b[1:]
+		default:
+			out, a, b = append(out, ka), a[1:], b[1:]
+		}
+	}
+	return out
+}
@@ -0,0 +1,168 @@
+package keyspace
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/clientv3"
+	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	gc "github.com/go-check/check"
+)
+
+type DeltaSuite struct{}
+
+func (s *DeltaSuite) TestApplyDeltas(c *gc.C) {
+	var ks = NewKeySpace("/", testDecoder)
+
+	var fooDeltas, barDeltas []Delta
+	ks.ObservePrefix("/foo/", func(d Delta) { fooDeltas = append(fooDeltas, d) })
+	ks.ObservePrefix("/bar/", func(d Delta) { barDeltas = append(barDeltas, d) })
+
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 10},
+		Events: []*clientv3.Event{
+			putEvent("/foo/a", "1", 10, 10, 1),
+			putEvent("/foo/b", "2", 10, 10, 1),
+			putEvent("/foo/c", "3", 10, 10, 1),
+			putEvent("/other", "4", 10, 10, 1),
+		},
+	}), gc.IsNil)
+
+	c.Assert(fooDeltas, gc.HasLen, 1)
+	c.Check(summarizeDelta(fooDeltas[0]), gc.DeepEquals, map[string][]string{
+		"added": {"/foo/a", "/foo/b", "/foo/c"},
+	})
+	c.Check(barDeltas, gc.HasLen, 0) // Not called, as nothing changed.
+
+	// Update, delete, and add keys across multiple responses. A key updated
+	// with an invalid value isn't changed. A key deleted and re-created is
+	// updated. A key created and deleted in the same Apply isn't observed.
+	c.Check(ks.Apply(
+		clientv3.WatchResponse{
+			Header: epb.ResponseHeader{ClusterId: 9999, Revision: 11},
+			Events: []*clientv3.Event{
+				putEvent("/foo/a", "11", 10, 11, 2),
+				delEvent("/foo/b", 11),
+				putEvent("/foo/c", "invalid", 10, 11, 2),
+				putEvent("/bar/a", "5", 11, 11, 1),
+				putEvent("/foo/d", "6", 11, 11, 1),
+				putEvent("/other", "44", 10, 11, 2),
+			},
+		},
+		clientv3.WatchResponse{
+			Header: epb.ResponseHeader{ClusterId: 9999, Revision: 12},
+			Events: []*clientv3.Event{
+				delEvent("/foo/a", 12),
+				delEvent("/foo/d", 12),
+				putEvent("/foo/b", "22", 12, 12, 1),
+				putEvent("/foo/a", "111", 12, 12, 1),
+			},
+		},
+	), gc.IsNil)
+
+	c.Assert(fooDeltas, gc.HasLen, 2)
+	c.Check(summarizeDelta(fooDeltas[1]), gc.DeepEquals, map[string][]string{
+		"updated": {"/foo/a", "/foo/b"},
+	})
+	verifyDecodedKeyValues(c, fooDeltas[1].Updated, map[string]int{"/foo/a": 111, "/foo/b": 22})
+
+	c.Assert(barDeltas, gc.HasLen, 1)
+	c.Check(barDeltas[0].Prefix, gc.Equals, "/bar/")
+	c.Check(summarizeDelta(barDeltas[0]), gc.DeepEquals, map[string][]string{
+		"added": {"/bar/a"},
+	})
+
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 13},
+		Events: []*clientv3.Event{delEvent("/foo/c", 13), delEvent("/bar/a", 13)},
+	}), gc.IsNil)
+
+	c.Check(summarizeDelta(fooDeltas[2]), gc.DeepEquals, map[string][]string{
+		"deleted": {"/foo/c"},
+	})
+	// Deleted KeyValues are at their last value.
+	verifyDecodedKeyValues(c, fooDeltas[2].Deleted, map[string]int{"/foo/c": 3})
+	c.Check(summarizeDelta(barDeltas[1]), gc.DeepEquals, map[string][]string{
+		"deleted": {"/bar/a"},
+	})
+}
+
+func (s *DeltaSuite) TestLoadDeltas(c *gc.C) {
+	var ks = NewKeySpace("/", testDecoder)
+
+	var deltas []Delta
+	ks.ObservePrefix("/foo/", func(d Delta) { deltas = append(deltas, d) })
+
+	var backend = &fixedBackend{
+		rev: 10,
+		kvs: []*mvccpb.KeyValue{
+			putEvent("/foo/a", "1", 10, 10, 1).Kv,
+			putEvent("/foo/b", "2", 10, 10, 1).Kv,
+			putEvent("/other", "3", 10, 10, 1).Kv,
+		},
+	}
+	c.Check(ks.LoadFrom(context.Background(), backend, 0), gc.IsNil)
+
+	c.Assert(deltas, gc.HasLen, 1)
+	c.Check(summarizeDelta(deltas[0]), gc.DeepEquals, map[string][]string{
+		"added": {"/foo/a", "/foo/b"},
+	})
+
+	// A re-load is observed as the difference from the prior KeyValues.
+	backend.rev, backend.kvs = 20, []*mvccpb.KeyValue{
+		putEvent("/foo/b", "22", 10, 15, 2).Kv,
+		putEvent("/foo/c", "3", 15, 15, 1).Kv,
+		putEvent("/other", "33", 10, 20, 2).Kv,
+	}
+	c.Check(ks.LoadFrom(context.Background(), backend, 0), gc.IsNil)
+
+	c.Assert(deltas, gc.HasLen, 2)
+	c.Check(summarizeDelta(deltas[1]), gc.DeepEquals, map[string][]string{
+		"added":   {"/foo/c"},
+		"updated": {"/foo/b"},
+		"deleted": {"/foo/a"},
+	})
+	verifyDecodedKeyValues(c, deltas[1].Deleted, map[string]int{"/foo/a": 1})
+}
+
+func (s *DeltaSuite) TestUnionKeys(c *gc.C) {
+	var a, b = buildKeyValuesFixture(c), buildKeyValuesFixture(c)
+
+	c.Check(unionKeys(a, nil), gc.DeepEquals, keysOf(a))
+	c.Check(unionKeys(nil, b), gc.DeepEquals, keysOf(b))
+	c.Check(unionKeys(a, b), gc.DeepEquals, keysOf(a))
+	c.Check(unionKeys(a[:2], b[3:]), gc.DeepEquals, append(keysOf(a[:2]), keysOf(b[3:])...))
+	c.Check(unionKeys(a[1:], b[:2]), gc.DeepEquals, keysOf(a))
+}
+
+// fixedBackend is a Backend which Loads fixed KeyValues.
+type fixedBackend struct {
+	rev int64
+	kvs []*mvccpb.KeyValue
+}
+
+func (b *fixedBackend) Load(_ context.Context, _ []string, _ int64) (epb.ResponseHeader, []*mvccpb.KeyValue, error) {
+	return epb.ResponseHeader{ClusterId: 9999, Revision: b.rev}, b.kvs, nil
+}
+
+func (b *fixedBackend) Watch(ctx context.Context, _ []string, _ int64) clientv3.WatchChan {
+	var ch = make(chan clientv3.WatchResponse)
+	go func() { <-ctx.Done(); close(ch) }()
+	return ch
+}
+
+func summarizeDelta(d Delta) map[string][]string {
+	var out = make(map[string][]string)
+	if len(d.Added) != 0 {
+		out["added"] = keysOf(d.Added)
+	}
+	if len(d.Updated) != 0 {
+		out["updated"] = keysOf(d.Updated)
+	}
+	if len(d.Deleted) != 0 {
+		out["deleted"] = keysOf(d.Deleted)
+	}
+	return out
+}
+
+var _ = gc.Suite(&DeltaSuite{})
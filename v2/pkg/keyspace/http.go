@@ -0,0 +1,137 @@
+package keyspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// HTTPHandler serves a read-only view of a KeySpace, rendering its decoded
+// keys and values and its current Header revision. It's intended for
+// debugging the state of a KeySpace (eg, of an allocator or consumer) without
+// requiring direct access to Etcd, and is typically registered under
+// "/debug/keyspace".
+//
+// The view is rendered as HTML, or as JSON if the request has a "format=json"
+// query parameter or Accepts "application/json". A "prefix" query parameter
+// restricts the view to keys having the prefix.
+type HTTPHandler struct {
+	// KeySpace to serve.
+	KeySpace *KeySpace
+	// Stringer renders the decoded value of a KeyValue. If nil, decoded
+	// values are rendered with the "%+v" verb of package fmt (and therefore
+	// with their String method, if they implement fmt.Stringer).
+	Stringer func(KeyValue) string
+}
+
+// NewHTTPHandler returns an HTTPHandler of the KeySpace, using |stringer| to
+// render decoded values. |stringer| may be nil.
+func NewHTTPHandler(ks *KeySpace, stringer func(KeyValue) string) *HTTPHandler {
+	return &HTTPHandler{KeySpace: ks, Stringer: stringer}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	var view = h.buildView(r.URL.Query().Get("prefix"))
+
+	var err error
+	if r.URL.Query().Get("format") == "json" ||
+		strings.Contains(r.Header.Get("Accept"), "application/json") {
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		var enc = json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(view)
+	} else {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err = httpViewTemplate.Execute(w, view)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// buildView captures a httpView of the KeySpace under its read-lock.
+func (h *HTTPHandler) buildView(prefix string) httpView {
+	var ks = h.KeySpace
+	var stringer = h.Stringer
+	if stringer == nil {
+		stringer = func(kv KeyValue) string { return fmt.Sprintf("%+v", kv.Decoded) }
+	}
+
+	ks.Mu.RLock()
+	defer ks.Mu.RUnlock()
+
+	var view = httpView{
+		Prefixes:  ks.Prefixes,
+		ClusterID: ks.Header.ClusterId,
+		MemberID:  ks.Header.MemberId,
+		Revision:  ks.Header.Revision,
+		KeyValues: []httpKeyValue{},
+	}
+	var kvs = ks.KeyValues
+	if prefix != "" {
+		kvs = kvs.Prefixed(prefix)
+	}
+	for _, kv := range kvs {
+		view.KeyValues = append(view.KeyValues, httpKeyValue{
+			Key:            string(kv.Raw.Key),
+			Value:          stringer(kv),
+			CreateRevision: kv.Raw.CreateRevision,
+			ModRevision:    kv.Raw.ModRevision,
+			Version:        kv.Raw.Version,
+			Lease:          kv.Raw.Lease,
+		})
+	}
+	return view
+}
+
+// httpView is the rendered representation of a KeySpace.
+type httpView struct {
+	Prefixes  []string       `json:"prefixes"`
+	ClusterID uint64         `json:"clusterId"`
+	MemberID  uint64         `json:"memberId"`
+	Revision  int64          `json:"revision"`
+	KeyValues []httpKeyValue `json:"keyValues"`
+}
+
+// httpKeyValue is the rendered representation of a KeyValue.
+type httpKeyValue struct {
+	Key            string `json:"key"`
+	Value          string `json:"value"`
+	CreateRevision int64  `json:"createRevision"`
+	ModRevision    int64  `json:"modRevision"`
+	Version        int64  `json:"version"`
+	Lease          int64  `json:"lease,omitempty"`
+}
+
+var httpViewTemplate = template.Must(template.New("keyspace").Parse(`<!DOCTYPE html>
+<html>
+<head><title>KeySpace {{range .Prefixes}}{{.}} {{end}}</title></head>
+<body>
+<h1>KeySpace</h1>
+<p>
+Prefixes: {{range .Prefixes}}<code>{{.}}</code> {{end}}<br>
+Revision: {{.Revision}} (cluster {{.ClusterID}}, member {{.MemberID}})<br>
+Keys: {{len .KeyValues}}
+</p>
+<table border="1" cellpadding="4">
+<tr><th>Key</th><th>Value</th><th>Create Rev</th><th>Mod Rev</th><th>Version</th><th>Lease</th></tr>
+{{range .KeyValues}}<tr>
+<td><code>{{.Key}}</code></td>
+<td><pre>{{.Value}}</pre></td>
+<td>{{.CreateRevision}}</td>
+<td>{{.ModRevision}}</td>
+<td>{{.Version}}</td>
+<td>{{if .Lease}}{{printf "%x" .Lease}}{{end}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,81 @@
+package keyspace
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/etcd/clientv3"
+	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	gc "github.com/go-check/check"
+)
+
+type HTTPSuite struct{}
+
+func (s *HTTPSuite) TestRendering(c *gc.C) {
+	var ks = NewKeySpace("/", testDecoder)
+
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 10},
+		Events: []*clientv3.Event{
+			putEvent("/foo/a", "1", 10, 10, 1),
+			leasedPutEvent("/foo/b", "2", 10, 255),
+			putEvent("/bar/<c>", "3", 10, 10, 1),
+		},
+	}), gc.IsNil)
+
+	var h = NewHTTPHandler(ks, func(kv KeyValue) string {
+		return "decoded-" + strconv.Itoa(kv.Decoded.(int))
+	})
+
+	// Request a JSON view of prefix "/foo/".
+	var w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/debug/keyspace?format=json&prefix=/foo/", nil))
+
+	c.Check(w.Code, gc.Equals, http.StatusOK)
+	c.Check(w.Header().Get("Content-Type"), gc.Equals, "application/json; charset=utf-8")
+
+	var view httpView
+	c.Check(json.Unmarshal(w.Body.Bytes(), &view), gc.IsNil)
+	c.Check(view, gc.DeepEquals, httpView{
+		Prefixes:  []string{"/"},
+		ClusterID: 9999,
+		Revision:  10,
+		KeyValues: []httpKeyValue{
+			{Key: "/foo/a", Value: "decoded-1", CreateRevision: 10, ModRevision: 10, Version: 1},
+			{Key: "/foo/b", Value: "decoded-2", CreateRevision: 10, ModRevision: 10, Version: 1, Lease: 255},
+		},
+	})
+
+	// JSON is also selected by the Accept header. Without a Stringer, values
+	// are rendered with fmt.
+	h.Stringer = nil
+	w = httptest.NewRecorder()
+	var req = httptest.NewRequest("GET", "/debug/keyspace?prefix=/bar/", nil)
+	req.Header.Set("Accept", "application/json")
+	h.ServeHTTP(w, req)
+
+	c.Check(json.Unmarshal(w.Body.Bytes(), &view), gc.IsNil)
+	c.Check(view.KeyValues, gc.DeepEquals, []httpKeyValue{
+		{Key: "/bar/<c>", Value: "3", CreateRevision: 10, ModRevision: 10, Version: 1},
+	})
+
+	// Request an HTML view. Expect keys are escaped.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/debug/keyspace", nil))
+
+	c.Check(w.Code, gc.Equals, http.StatusOK)
+	c.Check(w.Header().Get("Content-Type"), gc.Equals, "text/html; charset=utf-8")
+	c.Check(strings.Contains(w.Body.String(), "Revision: 10 (cluster 9999, member 0)"), gc.Equals, true)
+	c.Check(strings.Contains(w.Body.String(), "<code>/bar/&lt;c&gt;</code>"), gc.Equals, true)
+	c.Check(strings.Contains(w.Body.String(), "<td>ff</td>"), gc.Equals, true)
+
+	// Mutations are not permitted.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/debug/keyspace", nil))
+	c.Check(w.Code, gc.Equals, http.StatusMethodNotAllowed)
+}
+
+var _ = gc.Suite(&HTTPSuite{})
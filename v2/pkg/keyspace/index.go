@@ -0,0 +1,114 @@
+package keyspace
+
+import "sort"
+
+// IndexFunc returns the index keys of a KeyValue. A KeyValue may have zero,
+// one, or multiple index keys.
+type IndexFunc func(KeyValue) []string
+
+// Index is a secondary index of the KeyValues of a KeySpace, which maps index
+// keys to the KeyValues having those keys. Rather than being rebuilt by a scan
+// of the KeySpace on every update, an Index is updated incrementally from only
+// the KeyValues which each update adds, modifies, or removes. Updates are
+// applied while the KeySpace write-lock is held, and before KeySpace Observers
+// are called, so an Index is consistent with the KeySpace to any reader
+// holding its read-lock (and Observers may use Indexes).
+//
+// Decoded values are interface{}. Clients will typically wrap an Index with
+// accessors which return their own types:
+//
+//	func (s *State) AssignmentsOf(item string) []Assignment {
+//	    var out []Assignment
+//	    for _, kv := range s.byItem.Get(item) {
+//	        out = append(out, kv.Decoded.(Assignment))
+//	    }
+//	    return out
+//	}
+type Index struct {
+	fn IndexFunc
+	m  map[string]KeyValues
+}
+
+// NewIndex returns an Index over KeySpace |ks| which is keyed by |fn|. The
+// Index is maintained by |ks| from this point forward. NewIndex must be called
+// before |ks| is Loaded.
+func NewIndex(ks *KeySpace, fn IndexFunc) *Index {
+	var idx = &Index{fn: fn, m: make(map[string]KeyValues)}
+	ks.indices = append(ks.indices, idx)
+	return idx
+}
+
+// Get returns the KeyValues having index |key|, ordered on their KeySpace
+// key. The KeySpace read-lock must be held. The returned KeyValues are
+// modified by later updates, and must be copied if retained beyond the lock.
+func (idx *Index) Get(key string) KeyValues { return idx.m[key] }
+
+// Keys returns all index keys which currently have KeyValues, in sorted order.
+// The KeySpace read-lock must be held.
+func (idx *Index) Keys() []string {
+	var out = make([]string, 0, len(idx.m))
+	for key := range idx.m {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// reset rebuilds the Index from the complete KeyValues |kvs|.
+func (idx *Index) reset(kvs KeyValues) {
+	idx.m = make(map[string]KeyValues)
+	for _, kv := range kvs {
+		idx.update(nil, &kv)
+	}
+}
+
+// update the Index with the replacement of KeyValue |prev| by |next|.
+// Either may be nil, if a KeyValue was added or removed.
+func (idx *Index) update(prev, next *KeyValue) {
+	if prev != nil {
+		for _, key := range idx.fn(*prev) {
+			var kvs = idx.m[key]
+			if ind, found := kvs.Search(string(prev.Raw.Key)); found {
+				kvs = append(kvs[:ind], kvs[ind+1:]...)
+			}
+			if len(kvs) == 0 {
+				delete(idx.m, key)
+			} else {
+				idx.m[key] = kvs
+			}
+		}
+	}
+	if next != nil {
+		for _, key := range idx.fn(*next) {
+			var kvs = idx.m[key]
+			var ind, found = kvs.Search(string(next.Raw.Key))
+
+			if found {
+				kvs[ind] = *next
+			} else {
+				kvs = append(kvs, KeyValue{})
+				copy(kvs[ind+1:], kvs[ind:])
+				kvs[ind] = *next
+			}
+			idx.m[key] = kvs
+		}
+	}
+}
+
+// updateIndices applies the changes of |keys| from KeyValues |prev| to |next|
+// to each of |indices|.
+func updateIndices(indices []*Index, prev, next KeyValues, keys []string) {
+	for _, key := range keys {
+		var p, n *KeyValue
+
+		if ind, found := prev.Search(key); found {
+			p = &prev[ind]
+		}
+		if ind, found := next.Search(key); found {
+			n = &next[ind]
+		}
+		for _, idx := range indices {
+			idx.update(p, n)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package keyspace
+
+import (
+	"github.com/coreos/etcd/clientv3"
+	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	gc "github.com/go-check/check"
+)
+
+type IndexSuite struct{}
+
+func (s *IndexSuite) TestIncrementalUpdates(c *gc.C) {
+	var ks = NewKeySpace("/", testDecoder)
+
+	// Index KeyValues on whether their decoded value is odd or even. Values
+	// which are a multiple of ten are additionally indexed as "tens".
+	var idx = NewIndex(ks, func(kv KeyValue) []string {
+		var keys []string
+		if kv.Decoded.(int)%2 == 0 {
+			keys = append(keys, "even")
+		} else {
+			keys = append(keys, "odd")
+		}
+		if kv.Decoded.(int)%10 == 0 {
+			keys = append(keys, "tens")
+		}
+		return keys
+	})
+
+	// Expect the Index is updated before Observers are called.
+	var observed []string
+	ks.Observers = append(ks.Observers, func() { observed = idx.Keys() })
+
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 10},
+		Events: []*clientv3.Event{
+			putEvent("/c", "3", 10, 10, 1),
+			putEvent("/a", "1", 10, 10, 1),
+			putEvent("/b", "2", 10, 10, 1),
+			putEvent("/d", "20", 10, 10, 1),
+		},
+	}), gc.IsNil)
+
+	c.Check(observed, gc.DeepEquals, []string{"even", "odd", "tens"})
+	verifyDecodedKeyValues(c, idx.Get("odd"), map[string]int{"/a": 1, "/c": 3})
+	verifyDecodedKeyValues(c, idx.Get("even"), map[string]int{"/b": 2, "/d": 20})
+	verifyDecodedKeyValues(c, idx.Get("tens"), map[string]int{"/d": 20})
+	c.Check(keysOf(idx.Get("odd")), gc.DeepEquals, []string{"/a", "/c"})
+
+	// Update keys such that they move between index keys, and delete a key.
+	// A key updated multiple times in one Apply is indexed on its final value.
+	// A value which fails to decode leaves the prior value indexed.
+	c.Check(ks.Apply(
+		clientv3.WatchResponse{
+			Header: epb.ResponseHeader{ClusterId: 9999, Revision: 11},
+			Events: []*clientv3.Event{
+				putEvent("/a", "4", 10, 11, 2),
+				delEvent("/d", 11),
+				putEvent("/c", "invalid", 10, 11, 2),
+			},
+		},
+		clientv3.WatchResponse{
+			Header: epb.ResponseHeader{ClusterId: 9999, Revision: 12},
+			Events: []*clientv3.Event{
+				putEvent("/a", "30", 10, 12, 3),
+				putEvent("/e", "5", 12, 12, 1),
+			},
+		},
+	), gc.IsNil)
+
+	verifyDecodedKeyValues(c, idx.Get("odd"), map[string]int{"/c": 3, "/e": 5})
+	verifyDecodedKeyValues(c, idx.Get("even"), map[string]int{"/a": 30, "/b": 2})
+	verifyDecodedKeyValues(c, idx.Get("tens"), map[string]int{"/a": 30})
+	c.Check(keysOf(idx.Get("even")), gc.DeepEquals, []string{"/a", "/b"})
+
+	// Remove all "tens". Expect the index key is removed.
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 13},
+		Events: []*clientv3.Event{delEvent("/a", 13)},
+	}), gc.IsNil)
+
+	c.Check(idx.Get("tens"), gc.IsNil)
+	c.Check(observed, gc.DeepEquals, []string{"even", "odd"})
+
+	// Expect a reset rebuilds an equivalent Index.
+	var rebuilt = &Index{fn: idx.fn}
+	rebuilt.reset(ks.KeyValues)
+	c.Check(rebuilt.m, gc.DeepEquals, idx.m)
+}
+
+func keysOf(kvs KeyValues) []string {
+	var out []string
+	for _, kv := range kvs {
+		out = append(out, string(kv.Raw.Key))
+	}
+	return out
+}
+
+var _ = gc.Suite(&IndexSuite{})
@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"path"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	"github.com/coreos/etcd/clientv3"
-	"github.com/coreos/etcd/clientv3/mirror"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/coreos/etcd/etcdserver/etcdserverpb"
 	log "github.com/sirupsen/logrus"
 )
@@ -20,8 +22,12 @@ import (
 // which is kept in sync with Etcd via long-lived Watch operations. KeySpace
 // must be read-locked before access, to guard against concurrent updates.
 type KeySpace struct {
-	// Key prefix which roots this KeySpace.
+	// Key prefix which roots this KeySpace. If the KeySpace has multiple
+	// Prefixes, Root is the first of them.
 	Root string
+	// Prefixes mirrored by the KeySpace, in sorted order. A KeySpace built by
+	// NewKeySpace has the single prefix Root.
+	Prefixes []string
 	// Header is the last Etcd operation header which updated this KeySpace.
 	Header etcdserverpb.ResponseHeader
 	// KeyValues is a complete and decoded mirror of the (prefixed) Etcd key/value space.
@@ -39,12 +45,29 @@ type KeySpace struct {
 	// This Nagle-like mechanism amortizes the cost of applying many
 	// WatchResponses arriving in close succession. Default is 30ms.
 	WatchApplyDelay time.Duration
-	// Mu guards Header, KeyValues, and Observers. It must be locked before any are accessed.
+	// MaxApplyEvents bounds the number of watched events applied to the
+	// KeySpace by a single update. Larger bursts of events (eg, from a bulk
+	// import of keys) are split at revision boundaries into multiple updates,
+	// between which the KeySpace write-lock is released so that readers may
+	// proceed. Events of a single revision are never split. If zero, bursts
+	// are applied as a single update. Default is 10,000.
+	MaxApplyEvents int
+	// Deletions of KeyValues by the most recent update of the KeySpace, ordered
+	// on key. Observers may inspect Deletions to distinguish graceful deletes
+	// from those caused by expiry of an Etcd Lease. Deletions is empty after
+	// a Load.
+	Deletions []Deletion
+	// Mu guards Header, KeyValues, Observers, and Indexes of the KeySpace.
+	// It must be locked before any are accessed.
 	Mu sync.RWMutex
 
 	decode   KeyValueDecoder // Client-provided KeySpace decoder.
 	next     KeyValues       // Reusable buffer for next, amortized KeyValues update.
 	updateCh chan struct{}   // Signals waiting goroutines of an update.
+	watches  int             // Number of Watch invocations. Guarded by Mu.
+	indices  []*Index        // Indices maintained by the KeySpace.
+
+	prefixObservers []prefixObserver // Observers registered with ObservePrefix.
 }
 
 // NewKeySpace returns a KeySpace with the configured key |prefix| and |decoder|.
@@ -52,12 +75,38 @@ type KeySpace struct {
 // This check limits the space of possible prefixes somewhat, but guards against
 // many common and unintentional errors (eg, mixed use of trailing slashes).
 func NewKeySpace(prefix string, decoder KeyValueDecoder) *KeySpace {
-	if c := path.Clean(prefix); c != prefix {
-		panic(fmt.Sprintf("expected prefix to be a cleaned path (%s != %s)", c, prefix))
+	return NewMultiPrefixKeySpace([]string{prefix}, decoder)
+}
+
+// NewMultiPrefixKeySpace returns a KeySpace which mirrors each of the disjoint
+// key |prefixes| with |decoder|. The prefixes share a single Header and Watch,
+// and are updated atomically with respect to one another. |prefixes| must be
+// non-empty, must each be a "Clean" path (see NewKeySpace), and must be
+// disjoint: no prefix may itself be prefixed by another. Otherwise,
+// NewMultiPrefixKeySpace panics.
+//
+// KeySpace Watches the range spanning the first through last prefix, and
+// discards events of keys which fall between prefixes. Prefixes are therefore
+// best chosen such that the keys between them are few, or rarely updated.
+func NewMultiPrefixKeySpace(prefixes []string, decoder KeyValueDecoder) *KeySpace {
+	if len(prefixes) == 0 {
+		panic("expected at least one prefix")
+	}
+	prefixes = append([]string(nil), prefixes...)
+	sort.Strings(prefixes)
+
+	for i, prefix := range prefixes {
+		if c := path.Clean(prefix); c != prefix {
+			panic(fmt.Sprintf("expected prefix to be a cleaned path (%s != %s)", c, prefix))
+		} else if i != 0 && strings.HasPrefix(prefix, prefixes[i-1]) {
+			panic(fmt.Sprintf("expected disjoint prefixes (%s is prefixed by %s)", prefix, prefixes[i-1]))
+		}
 	}
 	var ks = &KeySpace{
-		Root:            prefix,
+		Root:            prefixes[0],
+		Prefixes:        prefixes,
 		WatchApplyDelay: 30 * time.Millisecond,
+		MaxApplyEvents:  10000,
 		decode:          decoder,
 		updateCh:        make(chan struct{}),
 	}
@@ -67,74 +116,97 @@ func NewKeySpace(prefix string, decoder KeyValueDecoder) *KeySpace {
 // Load loads a snapshot of the prefixed KeySpace at revision |rev|,
 // or if |rev| is zero, at the current revision.
 func (ks *KeySpace) Load(ctx context.Context, client *clientv3.Client, rev int64) error {
-	if rev == 0 {
-		// Resolve a current Revision. Note |rev| of zero is also interpreted by
-		// SyncBase as "use a recent revision", which we would use instead except
-		// there's no way to extract what that Revision actually was...
-		if resp, err := client.Get(ctx, "a-key-we-don't-expect-to-exist"); err != nil {
-			return err
-		} else {
-			rev = resp.Header.Revision
-		}
+	return ks.LoadFrom(ctx, &EtcdSource{Client: client}, rev)
+}
+
+// LoadFrom loads a snapshot of the prefixed KeySpace from |src| at
+// revision |rev|, or if |rev| is zero, at the current revision.
+func (ks *KeySpace) LoadFrom(ctx context.Context, src Source, rev int64) error {
+	var hdr, kvs, err = src.Load(ctx, ks.Prefixes, rev)
+	if err != nil {
+		return err
 	}
 	defer ks.Mu.Unlock()
 	ks.Mu.Lock()
 
-	ks.Header, ks.KeyValues = etcdserverpb.ResponseHeader{}, ks.KeyValues[:0]
-	var respCh, errCh = mirror.NewSyncer(client, ks.Root, rev).SyncBase(ctx)
+	// KeyValues are re-built in place. Retain a copy for prefix observers.
+	var prev KeyValues
+	if len(ks.prefixObservers) != 0 {
+		prev = ks.KeyValues.Copy()
+	}
+	ks.Header, ks.KeyValues, ks.Deletions = hdr, ks.KeyValues[:0], nil
 
-	// Read messages across |respCh| and |errCh| until both are closed.
-	for respCh != nil || errCh != nil {
-		select {
-		case resp, ok := <-respCh:
-			if !ok {
-				respCh = nil // Finished draining |respCh|.
-			} else if err := patchHeader(&ks.Header, *resp.Header, true); err != nil {
-				return err
-			} else {
-				for _, kv := range resp.Kvs {
-					if ks.KeyValues, err = appendKeyValue(ks.KeyValues, ks.decode, kv); err != nil {
-						log.WithFields(log.Fields{"key": string(kv.Key), "err": err}).
-							Error("key/value decode failed while loading")
-					}
-				}
-			}
-		case err, ok := <-errCh:
-			if !ok {
-				errCh = nil // Finished draining |errCh|.
-			} else {
-				return err
-			}
+	for _, kv := range kvs {
+		if ks.KeyValues, err = appendKeyValue(ks.KeyValues, ks.decode, kv); err != nil {
+			metrics.KeySpaceDecodeFailuresTotal.WithLabelValues(ks.Root).Inc()
+			log.WithFields(log.Fields{"key": string(kv.Key), "err": err}).
+				Error("key/value decode failed while loading")
 		}
 	}
-	// Etcd defines `ResponseHeader.Revision` to be the store revision when the
-	// request was applied (and importantly, not of the revision of the request).
-	// We deviate from this and record the requested revision. In other words, we
-	// maintain our Header as the effective Revision of the KeySpace.
-	ks.Header.Revision = rev
-
-	ks.onUpdate()
+	for _, idx := range ks.indices {
+		idx.reset(ks.KeyValues)
+	}
+	var deltas []Delta
+	if len(ks.prefixObservers) != 0 {
+		deltas = buildDeltas(ks.prefixObservers, prev, ks.KeyValues, unionKeys(prev, ks.KeyValues))
+	}
+	ks.onUpdate(deltas)
 	return nil
 }
 
-// Watch a loaded KeySpace and apply updates as they are received.
+// Watch a loaded KeySpace and apply updates as they are received. If the
+// watched revision has been compacted by Etcd and |client| is a
+// *clientv3.Client, Watch re-Loads the KeySpace at the current revision and
+// resumes watching from there. Otherwise, the Watch fails with ErrCompacted.
 func (ks *KeySpace) Watch(ctx context.Context, client clientv3.Watcher) error {
+	var src = &EtcdSource{Watcher: client}
+	if etcd, ok := client.(*clientv3.Client); ok {
+		src.Client = etcd
+	}
+	return ks.WatchFrom(ctx, src)
+}
+
+// WatchFrom watches |src| for updates of a loaded KeySpace, and applies
+// them as they are received. If the watched revision is no longer available
+// from |src|, WatchFrom re-Loads the KeySpace at the current revision and
+// resumes watching from there.
+func (ks *KeySpace) WatchFrom(ctx context.Context, src Source) error {
+	for {
+		var err = ks.watch(ctx, src)
+		if err != rpctypes.ErrCompacted {
+			return err
+		} else if es, ok := src.(*EtcdSource); ok && es.Client == nil {
+			return err // Cannot re-Load without a Client.
+		}
+
+		ks.Mu.RLock()
+		log.WithFields(log.Fields{"prefix": ks.Root, "revision": ks.Header.Revision}).
+			Warn("watched revision was compacted; re-loading KeySpace")
+		ks.Mu.RUnlock()
+
+		if err = ks.LoadFrom(ctx, src, 0); err != nil {
+			return err
+		}
+	}
+}
+
+// watch the KeySpace from its current revision, until an error occurs.
+func (ks *KeySpace) watch(ctx context.Context, src Source) error {
 	var watchCh clientv3.WatchChan
 
-	ks.Mu.RLock()
-	// Begin a new long-lived, auto-retried Watch. Note this is very similar to
-	// mirror.Syncer: A key difference (and the reason that API is not used) is
-	// the additional WithProgressNotify option - without this option, in the
-	// event of a long-lived watch over a prefix which has no key changes, Etcd
-	// may compact away the watch revision and a retried Watch will later fail.
-	// WithProgressNotify ensures the watched revision is kept reasonably recent
-	// even if no WatchResponses otherwise arrive.
-	watchCh = client.Watch(ctx, ks.Root,
-		clientv3.WithPrefix(),
-		clientv3.WithProgressNotify(),
-		clientv3.WithRev(ks.Header.Revision+1),
-	)
-	ks.Mu.RUnlock()
+	// Cancel the Source Watch upon return, as WatchFrom may restart it.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var revisionLag = metrics.KeySpaceRevisionLag.WithLabelValues(ks.Root)
+	var watchedRevision int64
+
+	ks.Mu.Lock()
+	if ks.watches++; ks.watches > 1 {
+		metrics.KeySpaceWatchRestartsTotal.WithLabelValues(ks.Root).Inc()
+	}
+	watchCh = src.Watch(ctx, ks.Prefixes, ks.Header.Revision+1)
+	ks.Mu.Unlock()
 
 	// WatchResponses can often arrive in quick succession and contain many key
 	// events. We amortize the cost of updating the KeySpace by briefly delaying
@@ -157,11 +229,19 @@ func (ks *KeySpace) Watch(ctx context.Context, client clientv3.Watcher) error {
 				applyTimer.Reset(ks.WatchApplyDelay)
 			}
 			responses = append(responses, resp)
+
+			// Header is updated only by Apply, from this goroutine.
+			watchedRevision = resp.Header.Revision
+			revisionLag.Set(float64(watchedRevision - ks.Header.Revision))
+
 		case <-applyTimer.C:
-			if err := ks.Apply(responses...); err != nil {
-				return err
+			for _, chunk := range chunkResponses(responses, ks.MaxApplyEvents) {
+				if err := ks.Apply(chunk...); err != nil {
+					return err
+				}
 			}
 			responses = responses[:0]
+			revisionLag.Set(float64(watchedRevision - ks.Header.Revision))
 		}
 	}
 }
@@ -170,8 +250,17 @@ func (ks *KeySpace) Watch(ctx context.Context, client clientv3.Watcher) error {
 // or until the context is done. A read lock of the KeySpace Mutex must be
 // held at invocation, and will be re-acquired before WaitForRevision returns.
 func (ks *KeySpace) WaitForRevision(ctx context.Context, revision int64) error {
+	return ks.WaitForCondition(ctx, func() bool { return ks.Header.Revision >= revision })
+}
+
+// WaitForCondition blocks until |cond| returns true, or until the context is
+// done. |cond| is evaluated at invocation, and again after each update of the
+// KeySpace, while the KeySpace read lock is held. A read lock of the KeySpace
+// Mutex must be held at invocation, and will be re-acquired before
+// WaitForCondition returns.
+func (ks *KeySpace) WaitForCondition(ctx context.Context, cond func() bool) error {
 	for {
-		if ks.Header.Revision >= revision {
+		if cond() {
 			return nil
 		} else if err := ctx.Err(); err != nil {
 			return err
@@ -196,8 +285,20 @@ func (ks *KeySpace) WaitForRevision(ctx context.Context, revision int64) error {
 func (ks *KeySpace) Apply(responses ...clientv3.WatchResponse) error {
 	var hdr etcdserverpb.ResponseHeader
 	var wr clientv3.WatchResponse
+	var started, events = time.Now(), 0
+
+	defer func() {
+		metrics.KeySpaceApplySeconds.WithLabelValues(ks.Root).Observe(time.Since(started).Seconds())
+		metrics.KeySpaceApplyEvents.WithLabelValues(ks.Root).Observe(float64(events))
+	}()
+
+	for i := range responses {
+		if len(ks.Prefixes) > 1 {
+			responses[i].Events = ks.filterEvents(responses[i].Events)
+		}
+		wr = responses[i]
+		events += len(wr.Events)
 
-	for _, wr = range responses {
 		if err := patchHeader(&hdr, wr.Header, false); err != nil {
 			return err
 		}
@@ -216,6 +317,9 @@ func (ks *KeySpace) Apply(responses ...clientv3.WatchResponse) error {
 	// key space. Unmodified runs of keys are copied from |current|, with
 	// Watch Events applied as they are encountered and in (Key, ModRevision) order.
 	var current, next = ks.KeyValues, ks.next
+	var updatedKeys []string // Maintained only if there are |indices| or |prefixObservers|.
+	var trackKeys = len(ks.indices) != 0 || len(ks.prefixObservers) != 0
+	var deleted []Deletion
 
 	for responseHeap.Len() != 0 {
 		if wr = heap.Pop(&responseHeap).(clientv3.WatchResponse); len(wr.Events) == 0 {
@@ -232,9 +336,29 @@ func (ks *KeySpace) Apply(responses ...clientv3.WatchResponse) error {
 		}
 		next, current = append(next, current[:ind]...), current[ind:]
 
+		if key := string(wr.Events[0].Kv.Key); trackKeys &&
+			(len(updatedKeys) == 0 || updatedKeys[len(updatedKeys)-1] != key) {
+			updatedKeys = append(updatedKeys, key)
+		}
+
+		// Capture the last value of a deleted key. It may have been applied from
+		// |current|, or from a preceding Event of these |responses|.
+		if ev := *wr.Events[0]; ev.Type == clientv3.EventTypeDelete {
+			if l := len(next); l != 0 && bytes.Equal(ev.Kv.Key, next[l-1].Raw.Key) {
+				var d = Deletion{KeyValue: next[l-1], Revision: ev.Kv.ModRevision}
+
+				if l = len(deleted); l != 0 && bytes.Equal(deleted[l-1].Raw.Key, ev.Kv.Key) {
+					deleted[l-1] = d // Repeated deletion of a re-created key.
+				} else {
+					deleted = append(deleted, d)
+				}
+			}
+		}
+
 		// Patch the tail of |next|, inserting, modifying, or deleting at the last element.
 		var err error
 		if next, err = updateKeyValuesTail(next, ks.decode, *wr.Events[0]); err != nil {
+			metrics.KeySpaceDecodeFailuresTotal.WithLabelValues(ks.Root).Inc()
 			log.WithFields(log.Fields{"err": err, "event": wr.Events[0].Kv.String()}).
 				Error("inconsistent watched key/value event")
 		}
@@ -257,18 +381,96 @@ func (ks *KeySpace) Apply(responses ...clientv3.WatchResponse) error {
 
 	var err = patchHeader(&ks.Header, hdr, expectSameRevision)
 	if err == nil {
+		updateIndices(ks.indices, ks.KeyValues, next, updatedKeys)
+		var deltas = buildDeltas(ks.prefixObservers, ks.KeyValues, next, updatedKeys)
+		ks.Deletions = buildDeletions(next, deleted)
 		ks.KeyValues, ks.next = next, ks.KeyValues[:0]
-		ks.onUpdate()
+		ks.onUpdate(deltas)
 	}
 	ks.Mu.Unlock()
 
 	return err
 }
 
-func (ks *KeySpace) onUpdate() {
+// chunkResponses splits |responses| into chunks having at most |max| Events.
+// WatchResponses are split only at revision boundaries, and a split
+// WatchResponse is divided into parts each having a Header Revision of its
+// last Event. A revision having more than |max| Events forms its own chunk.
+// If |max| is zero, |responses| are returned as a single chunk.
+func chunkResponses(responses []clientv3.WatchResponse, max int) [][]clientv3.WatchResponse {
+	if max <= 0 {
+		return [][]clientv3.WatchResponse{responses}
+	}
+	var out [][]clientv3.WatchResponse
+	var chunk []clientv3.WatchResponse
+	var size int
+
+	for _, wr := range responses {
+		for {
+			// Take runs of same-revision Events from |wr| while they fit.
+			// At least one run is taken into an empty chunk.
+			var n int
+			for n != len(wr.Events) {
+				var end = n + 1
+				for end != len(wr.Events) && wr.Events[end].Kv.ModRevision == wr.Events[n].Kv.ModRevision {
+					end++
+				}
+				if size+end > max && (size != 0 || n != 0) {
+					break
+				}
+				n = end
+			}
+
+			if n == len(wr.Events) {
+				chunk, size = append(chunk, wr), size+n
+				break
+			} else if n != 0 {
+				var part = wr
+				part.Events = wr.Events[:n]
+				part.Header.Revision = wr.Events[n-1].Kv.ModRevision
+				chunk = append(chunk, part)
+			}
+			out, chunk, size = append(out, chunk), nil, 0
+			wr.Events = wr.Events[n:]
+		}
+	}
+	if len(chunk) != 0 {
+		out = append(out, chunk)
+	}
+	return out
+}
+
+// filterEvents returns the |events| having keys within the KeySpace Prefixes.
+func (ks *KeySpace) filterEvents(events []*clientv3.Event) []*clientv3.Event {
+	var out = events[:0:0]
+
+	for _, ev := range events {
+		var key = string(ev.Kv.Key)
+		// Find the last prefix which is less than or equal to |key|.
+		var ind = sort.SearchStrings(ks.Prefixes, key)
+		if ind == len(ks.Prefixes) || ks.Prefixes[ind] != key {
+			ind--
+		}
+		if ind >= 0 && strings.HasPrefix(key, ks.Prefixes[ind]) {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// onUpdate notifies Observers, and prefix observers of their non-empty
+// |deltas|, of an update of the KeySpace.
+func (ks *KeySpace) onUpdate(deltas []Delta) {
+	metrics.KeySpaceKeys.WithLabelValues(ks.Root).Set(float64(len(ks.KeyValues)))
+
 	for _, obv := range ks.Observers {
 		obv()
 	}
+	for i, d := range deltas {
+		if !d.Empty() {
+			ks.prefixObservers[i].fn(d)
+		}
+	}
 	close(ks.updateCh)
 	ks.updateCh = make(chan struct{})
 }
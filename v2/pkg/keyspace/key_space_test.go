@@ -6,9 +6,12 @@ import (
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	"github.com/coreos/etcd/clientv3"
 	epb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 	gc "github.com/go-check/check"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 type KeySpaceSuite struct{}
@@ -62,6 +65,117 @@ func (s *KeySpaceSuite) TestLoadAndWatch(c *gc.C) {
 		map[string]int{"/two": 2, "/three": 4, "/foo": 5, "/raced": 999})
 }
 
+func (s *KeySpaceSuite) TestWatchRecoversFromCompaction(c *gc.C) {
+	var client = etcdtest.TestClient()
+	var ctx, cancel = context.WithCancel(context.Background())
+
+	defer etcdtest.Cleanup()
+
+	resp, err := client.Put(ctx, "/one", "1")
+	c.Assert(err, gc.IsNil)
+
+	var ks = NewKeySpace("/", testDecoder)
+	c.Check(ks.Load(ctx, client, resp.Header.Revision), gc.IsNil)
+
+	// Mutate keys, and compact away the revisions following the Load.
+	_, err = client.Put(ctx, "/two", "2")
+	c.Assert(err, gc.IsNil)
+	resp, err = client.Put(ctx, "/three", "3")
+	c.Assert(err, gc.IsNil)
+	_, err = client.Compact(ctx, resp.Header.Revision)
+	c.Assert(err, gc.IsNil)
+
+	// Expect the Watch fails with ErrCompacted, and the KeySpace is re-loaded.
+	var expectObserverCallCh = make(chan struct{}, 1)
+	ks.Observers = append(ks.Observers, func() { expectObserverCallCh <- struct{}{} })
+
+	go func() {
+		<-expectObserverCallCh // Re-load.
+
+		// Expect the Watch resumes from the re-loaded revision.
+		var _, err = client.Put(ctx, "/four", "4")
+		c.Check(err, gc.IsNil)
+
+		<-expectObserverCallCh
+		cancel()
+	}()
+
+	c.Check(ks.Watch(ctx, client), gc.Equals, context.Canceled)
+
+	verifyDecodedKeyValues(c, ks.KeyValues,
+		map[string]int{"/one": 1, "/two": 2, "/three": 3, "/four": 4})
+}
+
+func (s *KeySpaceSuite) TestMultiplePrefixes(c *gc.C) {
+	var client = etcdtest.TestClient()
+	var ctx, cancel = context.WithCancel(context.Background())
+
+	defer etcdtest.Cleanup()
+
+	for _, kv := range [][2]string{
+		{"/aaa/one", "1"},
+		{"/bbb/ignored", "2"},
+		{"/ccc/three", "3"},
+		{"/ddd/ignored", "4"},
+	} {
+		var _, err = client.Put(ctx, kv[0], kv[1])
+		c.Assert(err, gc.IsNil)
+	}
+
+	var ks = NewMultiPrefixKeySpace([]string{"/ccc", "/aaa"}, testDecoder)
+	c.Check(ks.Root, gc.Equals, "/aaa")
+	c.Check(ks.Prefixes, gc.DeepEquals, []string{"/aaa", "/ccc"})
+
+	c.Check(ks.Load(ctx, client, 0), gc.IsNil)
+	verifyDecodedKeyValues(c, ks.KeyValues, map[string]int{"/aaa/one": 1, "/ccc/three": 3})
+
+	var expectObserverCallCh = make(chan struct{}, 1)
+	ks.Observers = append(ks.Observers, func() { expectObserverCallCh <- struct{}{} })
+
+	go func() {
+		// Updates of keys between or beyond prefixes are not applied.
+		for _, op := range []clientv3.Op{
+			clientv3.OpPut("/bbb/ignored", "5"),
+			clientv3.OpPut("/ccc/three", "6"),
+			clientv3.OpPut("/ddd/ignored", "7"),
+			clientv3.OpPut("/aaa/two", "8"),
+		} {
+			var _, err = client.Do(ctx, op)
+			c.Check(err, gc.IsNil)
+		}
+		// Expect a Txn touching both prefixes is applied atomically.
+		var _, err = client.Txn(ctx).Then(
+			clientv3.OpDelete("/aaa/one"),
+			clientv3.OpPut("/ccc/four", "9"),
+		).Commit()
+		c.Check(err, gc.IsNil)
+
+		for {
+			<-expectObserverCallCh
+
+			ks.Mu.RLock()
+			var done = len(ks.KeyValues.Prefixed("/ccc/four")) != 0
+			ks.Mu.RUnlock()
+
+			if done {
+				break
+			}
+		}
+		cancel()
+	}()
+
+	c.Check(ks.Watch(ctx, client), gc.Equals, context.Canceled)
+
+	verifyDecodedKeyValues(c, ks.KeyValues,
+		map[string]int{"/aaa/two": 8, "/ccc/three": 6, "/ccc/four": 9})
+
+	// Prefixes must be disjoint.
+	c.Check(func() { NewMultiPrefixKeySpace([]string{"/aaa", "/aaa/bbb"}, testDecoder) },
+		gc.PanicMatches, `expected disjoint prefixes .*`)
+	c.Check(func() { NewMultiPrefixKeySpace(nil, testDecoder) },
+		gc.PanicMatches, `expected at least one prefix`)
+}
+
 func (s *KeySpaceSuite) TestHeaderPatching(c *gc.C) {
 	var h epb.ResponseHeader
 
@@ -213,6 +327,108 @@ func (s *KeySpaceSuite) TestWatchResponseApply(c *gc.C) {
 		})
 }
 
+func (s *KeySpaceSuite) TestChunkedApply(c *gc.C) {
+	var fixture = func() []clientv3.WatchResponse {
+		return []clientv3.WatchResponse{
+			{
+				Header: epb.ResponseHeader{ClusterId: 9999, Revision: 13},
+				Events: []*clientv3.Event{
+					putEvent("/aaaa", "1", 11, 11, 1),
+					putEvent("/bbbb", "2", 12, 12, 1),
+					putEvent("/cccc", "3", 12, 12, 1),
+					putEvent("/dddd", "4", 12, 12, 1),
+					putEvent("/aaaa", "5", 11, 13, 2),
+				},
+			},
+			{
+				Header: epb.ResponseHeader{ClusterId: 9999, Revision: 14},
+				Events: []*clientv3.Event{},
+			},
+			{
+				Header: epb.ResponseHeader{ClusterId: 9999, Revision: 16},
+				Events: []*clientv3.Event{
+					delEvent("/bbbb", 15),
+					putEvent("/eeee", "6", 16, 16, 1),
+				},
+			},
+		}
+	}
+	var summarize = func(chunks [][]clientv3.WatchResponse) (out [][]int64) {
+		for _, chunk := range chunks {
+			var s []int64 // Pairs of (Revision, len(Events)).
+			for _, wr := range chunk {
+				s = append(s, wr.Header.Revision, int64(len(wr.Events)))
+			}
+			out = append(out, s)
+		}
+		return
+	}
+
+	// Zero |max| applies all responses as a single chunk.
+	c.Check(summarize(chunkResponses(fixture(), 0)), gc.DeepEquals,
+		[][]int64{{13, 5, 14, 0, 16, 2}})
+	// The revision 12 run of three events isn't split.
+	c.Check(summarize(chunkResponses(fixture(), 2)), gc.DeepEquals,
+		[][]int64{{11, 1}, {12, 3}, {13, 1, 14, 0, 15, 1}, {16, 1}})
+	c.Check(summarize(chunkResponses(fixture(), 4)), gc.DeepEquals,
+		[][]int64{{12, 4}, {13, 1, 14, 0, 16, 2}})
+	c.Check(summarize(chunkResponses(fixture(), 10)), gc.DeepEquals,
+		[][]int64{{13, 5, 14, 0, 16, 2}})
+
+	// Applying chunks in sequence produces the same KeySpace as a single Apply.
+	var ks1, ks2 = NewKeySpace("/", testDecoder), NewKeySpace("/", testDecoder)
+	c.Check(ks1.Apply(fixture()...), gc.IsNil)
+
+	for _, chunk := range chunkResponses(fixture(), 2) {
+		c.Check(ks2.Apply(chunk...), gc.IsNil)
+	}
+	c.Check(ks2.Header, gc.DeepEquals, ks1.Header)
+	c.Check(ks2.KeyValues, gc.DeepEquals, ks1.KeyValues)
+	verifyDecodedKeyValues(c, ks2.KeyValues,
+		map[string]int{"/aaaa": 5, "/cccc": 3, "/dddd": 4, "/eeee": 6})
+}
+
+func (s *KeySpaceSuite) TestApplyMetrics(c *gc.C) {
+	var ks = NewKeySpace("/metrics", testDecoder)
+	var failures = metrics.KeySpaceDecodeFailuresTotal.WithLabelValues("/metrics")
+
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 10},
+		Events: []*clientv3.Event{
+			putEvent("/metrics/one", "1", 10, 10, 1),
+			putEvent("/metrics/two", "2", 10, 10, 1),
+			putEvent("/metrics/bad", "invalid", 10, 10, 1),
+		},
+	}), gc.IsNil)
+
+	c.Check(readMetric(c, metrics.KeySpaceKeys.WithLabelValues("/metrics")).Gauge.GetValue(),
+		gc.Equals, 2.0)
+	c.Check(readMetric(c, failures).Counter.GetValue(), gc.Equals, 1.0)
+
+	var events = readMetric(c, metrics.KeySpaceApplyEvents.WithLabelValues("/metrics").(prometheus.Metric))
+	c.Check(events.Histogram.GetSampleCount(), gc.Equals, uint64(1))
+	c.Check(events.Histogram.GetSampleSum(), gc.Equals, 3.0)
+
+	// Deletion of an unknown key is an inconsistency, and also counted.
+	c.Check(ks.Apply(clientv3.WatchResponse{
+		Header: epb.ResponseHeader{ClusterId: 9999, Revision: 11},
+		Events: []*clientv3.Event{
+			delEvent("/metrics/one", 11),
+			delEvent("/metrics/not-here", 11),
+		},
+	}), gc.IsNil)
+
+	c.Check(readMetric(c, metrics.KeySpaceKeys.WithLabelValues("/metrics")).Gauge.GetValue(),
+		gc.Equals, 1.0)
+	c.Check(readMetric(c, failures).Counter.GetValue(), gc.Equals, 2.0)
+}
+
+func readMetric(c *gc.C, m prometheus.Metric) *dto.Metric {
+	var out = new(dto.Metric)
+	c.Assert(m.Write(out), gc.IsNil)
+	return out
+}
+
 func (s *KeySpaceSuite) TestWaitForRevision(c *gc.C) {
 	var ks = NewKeySpace("/", testDecoder)
 
@@ -258,6 +474,47 @@ func (s *KeySpaceSuite) TestWaitForRevision(c *gc.C) {
 	c.Check(ks.WaitForRevision(ctx, 101), gc.Equals, context.Canceled)
 }
 
+func (s *KeySpaceSuite) TestWaitForCondition(c *gc.C) {
+	var ks = NewKeySpace("/", testDecoder)
+	var ctx, cancel = context.WithCancel(context.Background())
+	var blockedCh = make(chan struct{})
+
+	go func() {
+		// Apply updates only after the condition is first evaluated. As Apply
+		// requires the write lock, they're applied after the waiter is blocked.
+		<-blockedCh
+
+		for i, key := range []string{"/aaa", "/bbb", "/ccc"} {
+			var rev = int64(10 + i)
+			c.Check(ks.Apply(clientv3.WatchResponse{
+				Header: epb.ResponseHeader{ClusterId: 123, Revision: rev},
+				Events: []*clientv3.Event{putEvent(key, "1", rev, rev, 1)},
+			}), gc.IsNil)
+		}
+	}()
+
+	ks.Mu.RLock()
+	defer ks.Mu.RUnlock()
+
+	// Wait for a condition which doesn't depend on the revision.
+	var calls int
+	c.Check(ks.WaitForCondition(ctx, func() bool {
+		if calls++; calls == 1 {
+			close(blockedCh)
+		}
+		return len(ks.KeyValues) == 3
+	}), gc.IsNil)
+	c.Check(ks.Header.Revision, gc.Equals, int64(12))
+	c.Check(calls >= 2, gc.Equals, true)
+
+	cancel()
+
+	// Condition already met: succeeds immediately.
+	c.Check(ks.WaitForCondition(ctx, func() bool { return true }), gc.IsNil)
+	// Unmet condition: doesn't block as context is cancelled.
+	c.Check(ks.WaitForCondition(ctx, func() bool { return false }), gc.Equals, context.Canceled)
+}
+
 var _ = gc.Suite(&KeySpaceSuite{})
 
 func Test(t *testing.T) { gc.TestingT(t) }
@@ -0,0 +1,121 @@
+package keyspace
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/mirror"
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+// Source is a read-only source of keys and values which is mirrored by a
+// KeySpace. Source is expressed in terms of the Etcd key/value model, which is
+// native to KeySpace: implementations over other stores map their keys,
+// values, and modification indices onto mvccpb.KeyValues, and their notion of
+// a store revision onto the Revision of an etcdserverpb.ResponseHeader.
+//
+// A Source provides only for loading and watching a KeySpace. Writes,
+// transactions, and leases (as are required by participants of an allocator,
+// such as brokers and consumers) remain specific to Etcd.
+type Source interface {
+	// Load returns the KeyValues having any of |prefixes|, which are sorted
+	// and disjoint. KeyValues must be ordered on key. If |rev| is non-zero,
+	// KeyValues are loaded at that revision, and otherwise at a current one.
+	// The Revision of the returned ResponseHeader is the effective revision of
+	// the loaded KeyValues.
+	Load(ctx context.Context, prefixes []string, rev int64) (etcdserverpb.ResponseHeader, []*mvccpb.KeyValue, error)
+	// Watch returns a channel of WatchResponses which describe changes of keys
+	// having |prefixes|, beginning at revision |rev|. WatchResponses may also
+	// describe changes of other keys, which are ignored. Revisions of
+	// successive WatchResponses must strictly increase, with the exception of
+	// progress notifications (WatchResponses having no Events) which may repeat
+	// the last revision. If |rev| is no longer available, a WatchResponse
+	// having a CompactRevision is sent, upon which the KeySpace is re-loaded.
+	// The channel is closed when |ctx| is done.
+	Watch(ctx context.Context, prefixes []string, rev int64) clientv3.WatchChan
+}
+
+// EtcdSource is a Source of an Etcd cluster.
+type EtcdSource struct {
+	// Client of the Etcd cluster. Client is required to Load.
+	Client *clientv3.Client
+	// Watcher of the Etcd cluster. If nil, Client is used.
+	Watcher clientv3.Watcher
+}
+
+// Load implements Source.
+func (es *EtcdSource) Load(ctx context.Context, prefixes []string, rev int64) (etcdserverpb.ResponseHeader, []*mvccpb.KeyValue, error) {
+	var hdr etcdserverpb.ResponseHeader
+	var out []*mvccpb.KeyValue
+
+	if rev == 0 {
+		// Resolve a current Revision. Note |rev| of zero is also interpreted by
+		// SyncBase as "use a recent revision", which we would use instead except
+		// there's no way to extract what that Revision actually was...
+		if resp, err := es.Client.Get(ctx, "a-key-we-don't-expect-to-exist"); err != nil {
+			return hdr, nil, err
+		} else {
+			rev = resp.Header.Revision
+		}
+	}
+
+	// Prefixes are sorted and disjoint, so loading each in turn produces
+	// KeyValues which are also ordered.
+	for _, prefix := range prefixes {
+		var respCh, errCh = mirror.NewSyncer(es.Client, prefix, rev).SyncBase(ctx)
+
+		// Read messages across |respCh| and |errCh| until both are closed.
+		for respCh != nil || errCh != nil {
+			select {
+			case resp, ok := <-respCh:
+				if !ok {
+					respCh = nil // Finished draining |respCh|.
+				} else if err := patchHeader(&hdr, *resp.Header, true); err != nil {
+					return hdr, nil, err
+				} else {
+					out = append(out, resp.Kvs...)
+				}
+			case err, ok := <-errCh:
+				if !ok {
+					errCh = nil // Finished draining |errCh|.
+				} else {
+					return hdr, nil, err
+				}
+			}
+		}
+	}
+	// Etcd defines `ResponseHeader.Revision` to be the store revision when the
+	// request was applied (and importantly, not of the revision of the request).
+	// We deviate from this and record the requested revision. In other words, we
+	// maintain our Header as the effective Revision of the KeySpace.
+	hdr.Revision = rev
+
+	return hdr, out, nil
+}
+
+// Watch implements Source.
+func (es *EtcdSource) Watch(ctx context.Context, prefixes []string, rev int64) clientv3.WatchChan {
+	var watcher = es.Watcher
+	if watcher == nil {
+		watcher = es.Client
+	}
+	// Begin a new long-lived, auto-retried Watch. Note this is very similar to
+	// mirror.Syncer: A key difference (and the reason that API is not used) is
+	// the additional WithProgressNotify option - without this option, in the
+	// event of a long-lived watch over a prefix which has no key changes, Etcd
+	// may compact away the watch revision and a retried Watch will later fail.
+	// WithProgressNotify ensures the watched revision is kept reasonably recent
+	// even if no WatchResponses otherwise arrive.
+	//
+	// Multiple prefixes are watched as the single range spanning all of them,
+	// which preserves a total order of WatchResponse revisions. Events of keys
+	// between prefixes are discarded by KeySpace.Apply.
+	var last = prefixes[len(prefixes)-1]
+
+	return watcher.Watch(ctx, prefixes[0],
+		clientv3.WithRange(clientv3.GetPrefixRangeEnd(last)),
+		clientv3.WithProgressNotify(),
+		clientv3.WithRev(rev),
+	)
+}
@@ -0,0 +1,120 @@
+package keyspace
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	log "github.com/sirupsen/logrus"
+)
+
+// Verify checks the consistency of the KeySpace with Etcd. It performs a
+// paginated range read of each KeySpace prefix at the current Revision of the
+// KeySpace, and diffs the result against the mirrored KeyValues. Each
+// divergence is logged and counted, and the total number of divergences is
+// returned. Verify is intended to catch bugs in the application of
+// WatchResponses, which would otherwise go unnoticed in production.
+//
+// KeyValues which fail to decode are not mirrored by the KeySpace, which
+// retains the prior value of the key (if any). Such keys are not counted
+// as divergences.
+func (ks *KeySpace) Verify(ctx context.Context, kv clientv3.KV, pageSize int64) (int, error) {
+	ks.Mu.RLock()
+	var rev, all = ks.Header.Revision, ks.KeyValues.Copy()
+	ks.Mu.RUnlock()
+
+	if rev == 0 {
+		return 0, errors.New("KeySpace has not been loaded")
+	}
+
+	var divergences int
+	var onDivergence = func(reason string, key []byte, localRev, etcdRev int64) {
+		divergences++
+		log.WithFields(log.Fields{
+			"prefix":   ks.Root,
+			"revision": rev,
+			"key":      string(key),
+			"local":    localRev,
+			"etcd":     etcdRev,
+		}).Error("KeySpace diverges from Etcd: " + reason)
+	}
+
+	for _, prefix := range ks.Prefixes {
+		var local = all.Prefixed(prefix)
+		var from, to = prefix, clientv3.GetPrefixRangeEnd(prefix)
+
+		for more := true; more; {
+			var resp, err = kv.Get(ctx, from,
+				clientv3.WithRange(to),
+				clientv3.WithRev(rev),
+				clientv3.WithLimit(pageSize),
+				clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
+			)
+			if err != nil {
+				return divergences, err
+			}
+			more = resp.More && len(resp.Kvs) != 0
+
+			for _, raw := range resp.Kvs {
+				// Step |local| through keys which precede |raw|.
+				var ind, found = local.Search(string(raw.Key))
+				for _, l := range local[:ind] {
+					onDivergence("key not in Etcd", l.Raw.Key, l.Raw.ModRevision, 0)
+				}
+				local = local[ind:]
+
+				if found {
+					if l := local[0].Raw; l.ModRevision != raw.ModRevision && ks.decodes(raw) {
+						onDivergence("key revision differs", l.Key, l.ModRevision, raw.ModRevision)
+					}
+					local = local[1:]
+				} else if ks.decodes(raw) {
+					onDivergence("key not in KeySpace", raw.Key, 0, raw.ModRevision)
+				}
+			}
+			if more {
+				from = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+			}
+		}
+		for _, l := range local {
+			onDivergence("key not in Etcd", l.Raw.Key, l.Raw.ModRevision, 0)
+		}
+	}
+
+	metrics.KeySpaceVerificationsTotal.WithLabelValues(ks.Root).Inc()
+	metrics.KeySpaceDivergencesTotal.WithLabelValues(ks.Root).Add(float64(divergences))
+	return divergences, nil
+}
+
+// VerifyPeriodically invokes Verify on each |interval| until |ctx| is done.
+// Errors of Verify (eg, because the KeySpace Revision was compacted) are
+// logged, and the KeySpace is verified again at the next interval.
+func (ks *KeySpace) VerifyPeriodically(ctx context.Context, kv clientv3.KV, interval time.Duration) {
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if _, err := ks.Verify(ctx, kv, verifyPageSize); err != nil && ctx.Err() == nil {
+			log.WithFields(log.Fields{"prefix": ks.Root, "err": err}).
+				Warn("failed to verify KeySpace (will retry)")
+		}
+	}
+}
+
+// decodes returns whether |raw| is successfully decoded by the KeySpace.
+func (ks *KeySpace) decodes(raw *mvccpb.KeyValue) bool {
+	var _, err = ks.decode(raw)
+	return err == nil
+}
+
+// verifyPageSize is the number of keys read by each range request of Verify.
+const verifyPageSize = 1000
@@ -0,0 +1,78 @@
+package keyspace
+
+import (
+	"context"
+
+	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
+	"github.com/LiveRamp/gazette/v2/pkg/metrics"
+	gc "github.com/go-check/check"
+)
+
+type VerifySuite struct{}
+
+func (s *VerifySuite) TestVerifyDetectsDivergence(c *gc.C) {
+	var client = etcdtest.TestClient()
+	var ctx = context.Background()
+
+	defer etcdtest.Cleanup()
+
+	for _, kv := range [][2]string{
+		{"/aaa/1", "1"},
+		{"/aaa/2", "2"},
+		{"/aaa/3", "invalid value is not a divergence"},
+		{"/aaa/4", "4"},
+		{"/aaa/5", "5"},
+		{"/bbb/ignored", "6"},
+		{"/ccc/7", "7"},
+		{"/ccc/8", "8"},
+	} {
+		var _, err = client.Put(ctx, kv[0], kv[1])
+		c.Assert(err, gc.IsNil)
+	}
+
+	var ks = NewMultiPrefixKeySpace([]string{"/aaa", "/ccc"}, testDecoder)
+
+	// Verify fails if the KeySpace isn't loaded.
+	var n, err = ks.Verify(ctx, client, 2)
+	c.Check(err, gc.ErrorMatches, "KeySpace has not been loaded")
+
+	c.Check(ks.Load(ctx, client, 0), gc.IsNil)
+
+	// Use a small page size, to exercise pagination.
+	n, err = ks.Verify(ctx, client, 2)
+	c.Check(err, gc.IsNil)
+	c.Check(n, gc.Equals, 0)
+
+	// Mutations following the KeySpace Revision are not divergences.
+	_, err = client.Put(ctx, "/aaa/9", "9")
+	c.Assert(err, gc.IsNil)
+
+	n, err = ks.Verify(ctx, client, 2)
+	c.Check(err, gc.IsNil)
+	c.Check(n, gc.Equals, 0)
+
+	// Corrupt the mirrored KeyValues: remove "/aaa/2", alter the revision of
+	// "/aaa/4", and add "/aaa/6" and "/ccc/9".
+	ks.Mu.Lock()
+	var kvs = ks.KeyValues.Copy()
+	c.Check(keysOf(kvs), gc.DeepEquals, []string{
+		"/aaa/1", "/aaa/2", "/aaa/4", "/aaa/5", "/ccc/7", "/ccc/8"})
+
+	var extra1, extra2 = kvs[3], kvs[5]
+	extra1.Raw.Key, extra2.Raw.Key = []byte("/aaa/6"), []byte("/ccc/9")
+	kvs[2].Raw.ModRevision += 100
+
+	ks.KeyValues = KeyValues{kvs[0], kvs[2], kvs[3], extra1, kvs[4], kvs[5], extra2}
+	ks.Mu.Unlock()
+
+	var divergences = metrics.KeySpaceDivergencesTotal.WithLabelValues("/aaa")
+	var before = readMetric(c, divergences).Counter.GetValue()
+
+	n, err = ks.Verify(ctx, client, 2)
+	c.Check(err, gc.IsNil)
+	c.Check(n, gc.Equals, 4)
+
+	c.Check(readMetric(c, divergences).Counter.GetValue()-before, gc.Equals, 4.0)
+}
+
+var _ = gc.Suite(&VerifySuite{})
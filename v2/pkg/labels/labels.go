@@ -16,9 +16,34 @@ const (
 	// integrity) followed by a 4-byte little-endian message length, followed by
 	// the packed Protobuf message. ProtoFixed is implemented by message.FixedFraming.
 	ContentType_ProtoFixed = "application/x-protobuf-fixed"
+	// ContentType_ProtoDelimited is a ContentType for Protobuf messages delimited
+	// by a varint-encoded message length, followed by the packed Protobuf message.
+	// It's the conventional framing of streamed Protobuf messages, and may be
+	// parsed by stock Protobuf libraries of other languages (eg, Java's
+	// parseDelimitedFrom). ProtoDelimited is implemented by message.DelimitedFraming.
+	ContentType_ProtoDelimited = "application/x-protobuf-delimited"
 	// ContentType_JSONLines is a ContentType for newline-delimited, JSON-encoded
 	// messages. JSONLines is implemented by message.JSONFraming.
 	ContentType_JSONLines = "application/x-ndjson"
+	// ContentType_SchemaEnvelope is a ContentType for Protobuf messages which
+	// are framed as per ContentType_ProtoFixed, and additionally carry the ID
+	// of their schema within a schema registry. SchemaEnvelope is implemented
+	// by message.SchemaFraming.
+	ContentType_SchemaEnvelope = "application/x-gazette-schema-envelope"
+	// ContentType_AvroSingleObject is a ContentType for Avro messages in the
+	// single-object encoding, which carries the fingerprint of each message's
+	// writer schema, and which are further delimited by a varint length as per
+	// ContentType_ProtoDelimited. AvroSingleObject is implemented by
+	// message.AvroFraming.
+	ContentType_AvroSingleObject = "application/x-gazette-avro-single-object"
+	// ContentType_CSV is a ContentType for rows of comma-separated text, as per
+	// RFC 4180. Each row is a message, and the optional CSVHeader label names
+	// the columns of rows. CSV is implemented by message.CSVFraming.
+	ContentType_CSV = "text/csv"
+	// ContentType_TSV is a ContentType for rows of tab-separated text. It's
+	// otherwise identical to ContentType_CSV. TSV is implemented by
+	// message.TSVFraming.
+	ContentType_TSV = "text/tab-separated-values"
 	// ContentType_RecoveryLog is a ContentType for Gazette's recovery log encoding.
 	// RecoveryLog is implemented by package `recoverylog`. To serve as a shard
 	// recovery log, a JournalSpec must be labeled with ContentType_RecoveryLog.
@@ -51,23 +76,58 @@ const (
 	// AWS, Azure, or GCP regions like "us-central1", "us-east-1", etc. Only one
 	// Region label is allowed. Compare to failure-domain.beta.kubernetes.io/region.
 	Region = "app.gazette.dev/region"
+	// CSVHeader names the columns of rows of a journal having ContentType_CSV
+	// or ContentType_TSV. Column names are separated by CSVHeaderSeparator,
+	// eg "id+name+created_at". Only one CSVHeader label is allowed.
+	CSVHeader = "app.gazette.dev/csv-header"
+	// CSVHeaderSeparator separates column names of a CSVHeader label value.
+	CSVHeaderSeparator = "+"
+)
+
+// Labels of a ShardSpec which tune the RocksDB database of a consumer.RocksDBStore.
+// Each is optional, and only one of each label is allowed.
+const (
+	// RocksDBBlockCacheSize is the size, in bytes, of the LRU cache of
+	// uncompressed blocks read from SST files. If not set, RocksDB's default
+	// 8MB cache is used.
+	RocksDBBlockCacheSize = "app.gazette.dev/rocksdb-block-cache-size"
+	// RocksDBWriteBufferSize is the size, in bytes, to which a memtable may
+	// grow before it's flushed to an SST file.
+	RocksDBWriteBufferSize = "app.gazette.dev/rocksdb-write-buffer-size"
+	// RocksDBCompression is the compression applied to SST file blocks. It's
+	// one of "none", "snappy", "zlib", "bz2", "lz4", "lz4hc", or "zstd".
+	RocksDBCompression = "app.gazette.dev/rocksdb-compression"
+	// RocksDBCompactionStyle is the style of compaction of SST files. It's one
+	// of "level", "universal", or "fifo".
+	RocksDBCompactionStyle = "app.gazette.dev/rocksdb-compaction-style"
 )
 
 // SingleValueLabels identifies label names which must only have one label value
 // within a specification.
 var SingleValueLabels = map[string]struct{}{
 	ContentType:    {},
+	CSVHeader:      {},
 	Instance:       {},
 	ManagedBy:      {},
 	MessageSubType: {},
 	MessageType:    {},
 	Region:         {},
+
+	RocksDBBlockCacheSize:  {},
+	RocksDBWriteBufferSize: {},
+	RocksDBCompression:     {},
+	RocksDBCompactionStyle: {},
 }
 
 // FramedContentTypes is the set of ContentType values which are understood by
 // a message.Framing. To serve as a ShardSpec.Source, a JournalSpec must be
 // labeled from among these ContentTypes.
 var FramedContentTypes = map[string]struct{}{
-	ContentType_JSONLines:  {},
-	ContentType_ProtoFixed: {},
+	ContentType_JSONLines:        {},
+	ContentType_ProtoFixed:       {},
+	ContentType_ProtoDelimited:   {},
+	ContentType_SchemaEnvelope:   {},
+	ContentType_AvroSingleObject: {},
+	ContentType_CSV:              {},
+	ContentType_TSV:              {},
 }
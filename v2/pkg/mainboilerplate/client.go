@@ -2,6 +2,7 @@ package mainboilerplate
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/client"
@@ -9,35 +10,162 @@ import (
 	"github.com/LiveRamp/gazette/v2/pkg/keepalive"
 	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"google.golang.org/grpc"
+	grpcKeepalive "google.golang.org/grpc/keepalive"
 )
 
 // AddressConfig of a remote service.
 type AddressConfig struct {
-	Address pb.Endpoint `long:"address" env:"ADDRESS" default:"http://localhost:8080" description:"Service address endpoint"`
+	Address     pb.Endpoint   `long:"address" env:"ADDRESS" default:"http://localhost:8080" description:"Service address endpoint"`
+	Connections int           `long:"connections" env:"CONNECTIONS" default:"1" description:"Number of gRPC connections to maintain with each remote process. RPCs are distributed across connections"`
+	RPCTimeout  time.Duration `long:"rpc-timeout" env:"RPC_TIMEOUT" default:"0s" description:"Timeout of unary (non-streaming) RPCs. If zero, no timeout is applied"`
+
+	Keepalive struct {
+		Time                time.Duration `long:"keepalive.time" env:"KEEPALIVE_TIME" default:"0s" description:"Interval of gRPC keepalive pings sent over idle connections. If zero, pings are not sent"`
+		Timeout             time.Duration `long:"keepalive.timeout" env:"KEEPALIVE_TIMEOUT" default:"20s" description:"Time to wait for a keepalive ping acknowledgement before closing the connection"`
+		PermitWithoutStream bool          `long:"keepalive.permit-without-stream" env:"KEEPALIVE_PERMIT_WITHOUT_STREAM" description:"Send keepalive pings even if there are no active RPCs"`
+	}
 }
 
 // Dial the server address using a protocol.Dispatcher balancer.
 // TODO(johnny): Rename => MustDial.
 func (c *AddressConfig) Dial(ctx context.Context) *grpc.ClientConn {
-	var cc, err = grpc.DialContext(ctx, c.Address.URL().Host,
+	var opts = []grpc.DialOption{
 		grpc.WithInsecure(),
 		grpc.WithDialer(keepalive.DialerFunc),
-		grpc.WithBalancerName(pb.DispatcherGRPCBalancerName))
+		grpc.WithBalancerName(pb.DispatcherGRPCBalancerName),
+		grpc.WithUnaryInterceptor(c.unaryInterceptor()),
+		grpc.WithStreamInterceptor(pb.TracingStreamClientInterceptor),
+	}
+	if c.Keepalive.Time != 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(grpcKeepalive.ClientParameters{
+			Time:                c.Keepalive.Time,
+			Timeout:             c.Keepalive.Timeout,
+			PermitWithoutStream: c.Keepalive.PermitWithoutStream,
+		}))
+	}
+
+	var cc, err = grpc.DialContext(ctx, c.Address.URL().Host, opts...)
 	Must(err, "failed to dial remote service", "endpoint", c.Address)
 
 	return cc
 }
 
-// JournalClient dials and returns a new JournalClient.
+// JournalClient dials and returns a new JournalClient. If Connections is
+// greater than one, the returned JournalClient distributes RPCs across
+// multiple dialed connections.
 // TODO(johnny): Rename => MustJournalClient.
 func (c *AddressConfig) JournalClient(ctx context.Context) pb.JournalClient {
-	return pb.NewJournalClient(c.Dial(ctx))
+	if c.Connections <= 1 {
+		return pb.NewJournalClient(c.Dial(ctx))
+	}
+	var pool = &journalClientPool{clients: make([]pb.JournalClient, c.Connections)}
+	for i := range pool.clients {
+		pool.clients[i] = pb.NewJournalClient(c.Dial(ctx))
+	}
+	return pool
 }
 
-// ShardClient dials and returns a new ShardClient.
+// ShardClient dials and returns a new ShardClient. If Connections is greater
+// than one, the returned ShardClient distributes RPCs across multiple dialed
+// connections.
 // TODO(johnny): Rename => MustShardClient.
 func (c *AddressConfig) ShardClient(ctx context.Context) consumer.ShardClient {
-	return consumer.NewShardClient(c.Dial(ctx))
+	if c.Connections <= 1 {
+		return consumer.NewShardClient(c.Dial(ctx))
+	}
+	var pool = &shardClientPool{clients: make([]consumer.ShardClient, c.Connections)}
+	for i := range pool.clients {
+		pool.clients[i] = consumer.NewShardClient(c.Dial(ctx))
+	}
+	return pool
+}
+
+// unaryInterceptor returns a grpc.UnaryClientInterceptor which traces RPCs
+// and, if RPCTimeout is set, applies it to each RPC. Streaming RPCs (eg, Read
+// & Append) may be long-lived, and are not subject to RPCTimeout.
+func (c *AddressConfig) unaryInterceptor() grpc.UnaryClientInterceptor {
+	if c.RPCTimeout == 0 {
+		return pb.TracingUnaryClientInterceptor
+	}
+	var timeout = c.RPCTimeout
+
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return pb.TracingUnaryClientInterceptor(ctx, method, req, reply, cc, invoker, opts...)
+	}
+}
+
+// journalClientPool round-robins RPCs across multiple JournalClients.
+type journalClientPool struct {
+	clients []pb.JournalClient
+	next    uint32
+}
+
+func (p *journalClientPool) pick() pb.JournalClient {
+	return p.clients[atomic.AddUint32(&p.next, 1)%uint32(len(p.clients))]
+}
+
+func (p *journalClientPool) List(ctx context.Context, in *pb.ListRequest, opts ...grpc.CallOption) (*pb.ListResponse, error) {
+	return p.pick().List(ctx, in, opts...)
+}
+
+func (p *journalClientPool) WatchList(ctx context.Context, in *pb.ListRequest, opts ...grpc.CallOption) (pb.Journal_WatchListClient, error) {
+	return p.pick().WatchList(ctx, in, opts...)
+}
+
+func (p *journalClientPool) Apply(ctx context.Context, in *pb.ApplyRequest, opts ...grpc.CallOption) (*pb.ApplyResponse, error) {
+	return p.pick().Apply(ctx, in, opts...)
+}
+
+func (p *journalClientPool) Read(ctx context.Context, in *pb.ReadRequest, opts ...grpc.CallOption) (pb.Journal_ReadClient, error) {
+	return p.pick().Read(ctx, in, opts...)
+}
+
+func (p *journalClientPool) Append(ctx context.Context, opts ...grpc.CallOption) (pb.Journal_AppendClient, error) {
+	return p.pick().Append(ctx, opts...)
+}
+
+func (p *journalClientPool) Replicate(ctx context.Context, opts ...grpc.CallOption) (pb.Journal_ReplicateClient, error) {
+	return p.pick().Replicate(ctx, opts...)
+}
+
+func (p *journalClientPool) ListFragments(ctx context.Context, in *pb.FragmentsRequest, opts ...grpc.CallOption) (*pb.FragmentsResponse, error) {
+	return p.pick().ListFragments(ctx, in, opts...)
+}
+
+func (p *journalClientPool) StreamFragments(ctx context.Context, in *pb.FragmentsRequest, opts ...grpc.CallOption) (pb.Journal_StreamFragmentsClient, error) {
+	return p.pick().StreamFragments(ctx, in, opts...)
+}
+
+// shardClientPool round-robins RPCs across multiple ShardClients.
+type shardClientPool struct {
+	clients []consumer.ShardClient
+	next    uint32
+}
+
+func (p *shardClientPool) pick() consumer.ShardClient {
+	return p.clients[atomic.AddUint32(&p.next, 1)%uint32(len(p.clients))]
+}
+
+func (p *shardClientPool) Stat(ctx context.Context, in *consumer.StatRequest, opts ...grpc.CallOption) (*consumer.StatResponse, error) {
+	return p.pick().Stat(ctx, in, opts...)
+}
+
+func (p *shardClientPool) List(ctx context.Context, in *consumer.ListRequest, opts ...grpc.CallOption) (*consumer.ListResponse, error) {
+	return p.pick().List(ctx, in, opts...)
+}
+
+func (p *shardClientPool) Apply(ctx context.Context, in *consumer.ApplyRequest, opts ...grpc.CallOption) (*consumer.ApplyResponse, error) {
+	return p.pick().Apply(ctx, in, opts...)
+}
+
+func (p *shardClientPool) GetHints(ctx context.Context, in *consumer.GetHintsRequest, opts ...grpc.CallOption) (*consumer.GetHintsResponse, error) {
+	return p.pick().GetHints(ctx, in, opts...)
 }
 
 // ClientConfig configures the client of a remote Gazette service.
@@ -0,0 +1,128 @@
+package mainboilerplate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+	"google.golang.org/grpc"
+)
+
+type ClientSuite struct{}
+
+func (s *ClientSuite) TestUnaryInterceptorTimeout(c *gc.C) {
+	var deadline time.Time
+	var hasDeadline bool
+
+	var invoker = func(ctx context.Context, _ string, _, _ interface{},
+		_ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		deadline, hasDeadline = ctx.Deadline()
+		return ctx.Err()
+	}
+
+	// Without an RPCTimeout, unary RPCs have no deadline.
+	var cfg AddressConfig
+	c.Check(cfg.unaryInterceptor()(context.Background(), "/Method", nil, nil, nil, invoker), gc.IsNil)
+	c.Check(hasDeadline, gc.Equals, false)
+
+	// With an RPCTimeout, it's applied to each RPC.
+	cfg.RPCTimeout = time.Minute
+	var now = time.Now()
+
+	c.Check(cfg.unaryInterceptor()(context.Background(), "/Method", nil, nil, nil, invoker), gc.IsNil)
+	c.Check(hasDeadline, gc.Equals, true)
+	c.Check(deadline.After(now.Add(time.Minute-time.Second)), gc.Equals, true)
+	c.Check(deadline.After(now.Add(time.Minute+time.Second)), gc.Equals, false)
+
+	// An earlier deadline of the caller's Context is retained.
+	var ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	c.Check(cfg.unaryInterceptor()(ctx, "/Method", nil, nil, nil, invoker), gc.IsNil)
+	c.Check(deadline.After(now.Add(time.Minute-time.Second)), gc.Equals, false)
+}
+
+func (s *ClientSuite) TestJournalClientPoolRoundRobin(c *gc.C) {
+	var called []int
+	var pool = &journalClientPool{}
+
+	for i := 0; i != 3; i++ {
+		pool.clients = append(pool.clients, journalClientFixture{id: i, called: &called})
+	}
+	for i := 0; i != 6; i++ {
+		var _, err = pool.List(context.Background(), &pb.ListRequest{})
+		c.Check(err, gc.IsNil)
+	}
+	c.Check(called, gc.DeepEquals, []int{1, 2, 0, 1, 2, 0})
+}
+
+func (s *ClientSuite) TestShardClientPoolRoundRobin(c *gc.C) {
+	var called []int
+	var pool = &shardClientPool{}
+
+	for i := 0; i != 2; i++ {
+		pool.clients = append(pool.clients, shardClientFixture{id: i, called: &called})
+	}
+	for i := 0; i != 4; i++ {
+		var _, err = pool.Stat(context.Background(), &consumer.StatRequest{})
+		c.Check(err, gc.IsNil)
+	}
+	c.Check(called, gc.DeepEquals, []int{1, 0, 1, 0})
+}
+
+func (s *ClientSuite) TestClientConnections(c *gc.C) {
+	pb.RegisterGRPCDispatcher("local")
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var cfg ClientConfig
+	cfg.Address = "http://localhost:8080"
+
+	// A single connection is dialed by default.
+	c.Check(cfg.JournalClient(ctx), gc.Not(gc.FitsTypeOf), &journalClientPool{})
+	c.Check(cfg.ShardClient(ctx), gc.Not(gc.FitsTypeOf), &shardClientPool{})
+
+	// Multiple Connections are pooled.
+	cfg.Connections = 3
+	c.Check(cfg.JournalClient(ctx).(*journalClientPool).clients, gc.HasLen, 3)
+	c.Check(cfg.ShardClient(ctx).(*shardClientPool).clients, gc.HasLen, 3)
+}
+
+func (s *ClientSuite) TestBuildRouter(c *gc.C) {
+	var cfg ClientConfig
+	c.Check(cfg.BuildRouter(), gc.Equals, pb.NoopDispatchRouter{})
+
+	cfg.Cache.Size, cfg.Cache.TTL = 10, time.Minute
+	c.Check(cfg.BuildRouter(), gc.FitsTypeOf, &client.RouteCache{})
+}
+
+type journalClientFixture struct {
+	pb.JournalClient
+	id     int
+	called *[]int
+}
+
+func (f journalClientFixture) List(context.Context, *pb.ListRequest, ...grpc.CallOption) (*pb.ListResponse, error) {
+	*f.called = append(*f.called, f.id)
+	return new(pb.ListResponse), nil
+}
+
+type shardClientFixture struct {
+	consumer.ShardClient
+	id     int
+	called *[]int
+}
+
+func (f shardClientFixture) Stat(context.Context, *consumer.StatRequest, ...grpc.CallOption) (*consumer.StatResponse, error) {
+	*f.called = append(*f.called, f.id)
+	return new(consumer.StatResponse), nil
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func Test(t *testing.T) { gc.TestingT(t) }
@@ -1,12 +1,17 @@
 package mainboilerplate
 
 import (
+	"context"
 	_ "expvar" // Import for /debug/vars
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" // Import for /debug/pprof
 	"os"
+	"time"
 
+	"github.com/LiveRamp/gazette/pkg/cloudstore"
+	"github.com/LiveRamp/gazette/pkg/pprof"
+	"github.com/LiveRamp/gazette/v2/pkg/protocol"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -14,15 +19,50 @@ import (
 
 // DiagnosticsConfig configures pull-based application metrics, debugging and diagnostics.
 type DiagnosticsConfig struct {
-	// Nothing to see here (yet).
+	KeySpaceVerifyInterval time.Duration `long:"keyspace-verify-interval" env:"KEYSPACE_VERIFY_INTERVAL" default:"0s" description:"Interval of background verification of the KeySpace against Etcd. Zero disables verification"`
+	KeySpaceHandler        bool          `long:"keyspace-handler" env:"KEYSPACE_HANDLER" description:"Serve the KeySpace (including all specs and member keys) at /debug/keyspace. Disabled unless set"`
+
+	Port                 string        `long:"port" env:"PORT" description:"Address (eg, :6060) on which profiling endpoints, including /profile/cpu, are additionally served. Disabled if empty"`
+	ProfileDir           string        `long:"profile-dir" env:"PROFILE_DIR" default:"/var/tmp" description:"Directory to which signal-triggered profiles and execution traces are written"`
+	ProfileRetention     int           `long:"profile-retention" env:"PROFILE_RETENTION" default:"0" description:"Number of profiles of each type retained in the profile directory. Zero retains all"`
+	BlockProfileRate     int           `long:"block-profile-rate" env:"BLOCK_PROFILE_RATE" default:"10000" description:"Rate of block profiling, while contention profiling is toggled on (by SIGTTIN)"`
+	MutexProfileFraction int           `long:"mutex-profile-fraction" env:"MUTEX_PROFILE_FRACTION" default:"10" description:"Fraction of mutex contention events profiled, while contention profiling is toggled on (by SIGTTIN)"`
+	MaxTraceDuration     time.Duration `long:"max-trace-duration" env:"MAX_TRACE_DURATION" default:"1m" description:"Maximum duration of an execution trace toggled on (by SIGTTOU), after which it's stopped"`
+	ProfileURL           string        `long:"profile-url" env:"PROFILE_URL" description:"Cloud filesystem URL (eg, gs://bucket/profiles/) to which continuous profiles are uploaded. Disabled if empty"`
+	ProfileInterval      time.Duration `long:"profile-interval" env:"PROFILE_INTERVAL" default:"5m" description:"Interval between continuous profile captures"`
 }
 
 // InitDiagnosticsAndRecover enables serving of metrics and debugging services
-// registered on the default HTTPMux. It also returns a closure which should be
+// registered on the default HTTPMux, as well as signal-triggered and (if
+// configured) continuous profiling. It also returns a closure which should be
 // deferred, which recover a panic and attempt to log a K8s termination message.
 func InitDiagnosticsAndRecover(cfg DiagnosticsConfig) func() {
 	grpc.EnableTracing = true
 
+	// Configure profiling triggered by signals (see pprof.RegisterSignalHandlers).
+	pprof.Configure(
+		pprof.WithDirectory(cfg.ProfileDir),
+		pprof.WithRetention(cfg.ProfileRetention),
+		pprof.WithBlockProfileRate(cfg.BlockProfileRate),
+		pprof.WithMutexProfileFraction(cfg.MutexProfileFraction),
+		pprof.WithMaxTraceDuration(cfg.MaxTraceDuration),
+	)
+	pprof.RegisterSignalHandlers()
+
+	if cfg.Port != "" {
+		go func() {
+			if err := pprof.ListenAndServe(cfg.Port); err != nil {
+				log.WithField("err", err).Error("failed to serve profiling endpoints")
+			}
+		}()
+	}
+	if cfg.ProfileURL != "" {
+		var cfs, err = cloudstore.NewFileSystem(nil, cfg.ProfileURL)
+		Must(err, "failed to initialize profile cloudstore", "url", cfg.ProfileURL)
+
+		go pprof.NewContinuousProfiler(cfs, cfg.ProfileInterval, protocol.BuildVersion).Run(context.Background())
+	}
+
 	// Package "net/http/pprof" serves /debug/pprof/.
 	// Package "expvar" serves /debug/vars
 
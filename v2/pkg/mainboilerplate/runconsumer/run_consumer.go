@@ -12,6 +12,7 @@ import (
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
 	"github.com/LiveRamp/gazette/v2/pkg/consumer"
+	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
 	mbp "github.com/LiveRamp/gazette/v2/pkg/mainboilerplate"
 	"github.com/LiveRamp/gazette/v2/pkg/metrics"
 	"github.com/LiveRamp/gazette/v2/pkg/protocol"
@@ -63,12 +64,19 @@ type Config interface {
 type BaseConfig struct {
 	Consumer struct {
 		mbp.ServiceConfig
+		mbp.AllocatorConfig
 
-		Limit uint32 `long:"limit" env:"LIMIT" default:"32" description:"Maximum number of Shards this consumer process will allocate"`
+		Limit  uint32 `long:"limit" env:"LIMIT" default:"32" description:"Maximum number of Shards this consumer process will allocate"`
+		Weight uint32 `long:"weight" env:"WEIGHT" default:"1" description:"Relative capacity weight of this consumer process, by which Shards are balanced across consumers"`
 	} `group:"Consumer" namespace:"consumer" env-namespace:"CONSUMER"`
 
 	Broker mbp.ClientConfig `group:"Broker" namespace:"broker" env-namespace:"BROKER"`
 
+	DeadLetter struct {
+		Journal     string `long:"journal" env:"JOURNAL" description:"Journal to which messages which repeatedly fail with a retryable error are appended, rather than failing the Shard. If empty, dead-letter routing is disabled"`
+		MaxAttempts int    `long:"max-attempts" env:"MAX_ATTEMPTS" default:"3" description:"Attempts to consume a failing message before it's routed to the dead-letter journal"`
+	} `group:"Dead Letter" namespace:"dead-letter" env-namespace:"DEAD_LETTER"`
+
 	Etcd struct {
 		mbp.EtcdConfig
 
@@ -82,7 +90,11 @@ type BaseConfig struct {
 // GetBaseConfig returns itself, and trivially implements the Config interface.
 func (c BaseConfig) GetBaseConfig() BaseConfig { return c }
 
-const iniFilename = "gazette.ini"
+const (
+	iniFilename = "gazette.ini"
+	// Number of allocator Explanations retained for "/debug/allocator/explain".
+	explainTraceSize = 1024
+)
 
 type serveConsumer struct {
 	cfg Config
@@ -98,6 +110,7 @@ func (sc serveConsumer) Execute(args []string) error {
 	log.WithField("config", sc.cfg).Info("starting consumer")
 	prometheus.MustRegister(metrics.GazetteClientCollectors()...)
 	prometheus.MustRegister(metrics.GazetteConsumerCollectors()...)
+	prometheus.MustRegister(metrics.KeySpaceCollectors()...)
 
 	var ks = consumer.NewKeySpace(bc.Etcd.Prefix)
 	var allocState = allocator.NewObservedState(ks, bc.Consumer.MemberKey(ks))
@@ -111,12 +124,27 @@ func (sc serveConsumer) Execute(args []string) error {
 		log.Warn("--broker.cache.size is disabled; consider setting > 0")
 	}
 	var rjc = bc.Broker.RoutedJournalClient(context.Background())
-	var service = consumer.NewService(sc.app, allocState, rjc, srv.MustGRPCLoopback(), etcd)
+	var app consumer.Application = sc.app
+	if bc.DeadLetter.Journal != "" {
+		var journal = protocol.Journal(bc.DeadLetter.Journal)
+		mbp.Must(journal.Validate(), "invalid dead-letter journal")
+
+		app = consumer.WithDeadLetters(app, consumer.DeadLetterConfig{
+			Journal:     journal,
+			MaxAttempts: bc.DeadLetter.MaxAttempts,
+		})
+	}
+	var service = consumer.NewService(app, allocState, rjc, srv.MustGRPCLoopback(), etcd)
 
 	var tasks = task.NewGroup(context.Background())
 	srv.QueueTasks(tasks)
 
 	consumer.RegisterShardServer(srv.GRPCServer, service)
+	if bc.Diagnostics.KeySpaceHandler {
+		srv.HTTPMux.Handle("/debug/keyspace", keyspace.NewHTTPHandler(ks, nil))
+	}
+	var trace = allocator.NewExplainTrace(explainTraceSize)
+	srv.HTTPMux.Handle("/debug/allocator/explain", trace)
 	mbp.Must(sc.app.InitApplication(InitArgs{
 		Context: context.Background(),
 		Config:  sc.cfg,
@@ -132,13 +160,22 @@ func (sc serveConsumer) Execute(args []string) error {
 		LeaseTTL: bc.Etcd.LeaseTTL,
 		SignalCh: signalCh,
 		Spec: &consumer.ConsumerSpec{
-			ProcessSpec: bc.Consumer.ProcessSpec(),
-			ShardLimit:  bc.Consumer.Limit,
+			ProcessSpec:    bc.Consumer.ProcessSpec(),
+			ShardLimit:     bc.Consumer.Limit,
+			CapacityWeight: bc.Consumer.Weight,
 		},
-		State: allocState,
-		Tasks: tasks,
+		State:       allocState,
+		Tasks:       tasks,
+		ChurnLimits: bc.Consumer.ChurnLimits(),
+		Trace:       trace,
 	}), "starting allocator session")
 
+	if interval := bc.Diagnostics.KeySpaceVerifyInterval; interval > 0 {
+		tasks.Queue("ks.VerifyPeriodically", func() error {
+			ks.VerifyPeriodically(tasks.Context(), etcd, interval)
+			return nil
+		})
+	}
 	tasks.Queue("service.Watch", func() error { return service.Watch(tasks.Context()) })
 
 	// Install signal handler, and launch consumer tasks.
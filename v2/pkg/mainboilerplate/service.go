@@ -2,6 +2,7 @@ package mainboilerplate
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
 	"github.com/LiveRamp/gazette/v2/pkg/keyspace"
@@ -16,17 +17,41 @@ type ZoneConfig struct {
 // ServiceConfig represents identification and addressing configuration of the process.
 type ServiceConfig struct {
 	ZoneConfig
-	ID   string `long:"id" env:"ID" default:"localhost" description:"Unique ID of the process"`
-	Host string `long:"host" env:"HOST" default:"localhost" description:"Addressable, advertised hostname of this process"`
-	Port uint16 `long:"port" env:"PORT" default:"8080" description:"Service port for HTTP and gRPC requests"`
+	ID     string            `long:"id" env:"ID" default:"localhost" description:"Unique ID of the process"`
+	Host   string            `long:"host" env:"HOST" default:"localhost" description:"Addressable, advertised hostname of this process"`
+	Port   uint16            `long:"port" env:"PORT" default:"8080" description:"Service port for HTTP and gRPC requests"`
+	Labels map[string]string `long:"label" env:"LABELS" env-delim:"," description:"Labels of the process, as name:value, which are matched by Placement selectors. May be repeated"`
 }
 
 // ProcessSpec of the ServiceConfig.
 func (cfg ServiceConfig) ProcessSpec() protocol.ProcessSpec {
-	return protocol.ProcessSpec{
+	var spec = protocol.ProcessSpec{
 		Id:       protocol.ProcessSpec_ID{Zone: cfg.Zone, Suffix: cfg.ID},
 		Endpoint: protocol.Endpoint(fmt.Sprintf("http://%s:%d", cfg.Host, cfg.Port)),
 	}
+	for name, value := range cfg.Labels {
+		spec.Labels.AddValue(name, value)
+	}
+	return spec
+}
+
+// AllocatorConfig configures churn limits of the allocator. Limits are applied
+// by whichever allocator member is currently leader.
+type AllocatorConfig struct {
+	MaxChanges        int           `long:"max-changes" env:"MAX_CHANGES" default:"0" description:"Maximum number of assignment changes applied by the allocator within each change interval. Zero is unlimited"`
+	ChangeInterval    time.Duration `long:"change-interval" env:"CHANGE_INTERVAL" default:"1m" description:"Interval over which the allocator applies --max-changes"`
+	DepartureWindow   time.Duration `long:"departure-window" env:"DEPARTURE_WINDOW" default:"0s" description:"Duration for which all allocation is paused after a member departs, awaiting its return. Assignments are not changed while paused. Zero reacts to departures immediately"`
+	PrimaryStickiness float64       `long:"primary-stickiness" env:"PRIMARY_STICKINESS" default:"0" description:"Fraction by which a member may exceed its balanced share of items, in order to retain its current primary assignments. Zero disables"`
+}
+
+// ChurnLimits of the AllocatorConfig.
+func (cfg AllocatorConfig) ChurnLimits() allocator.ChurnLimits {
+	return allocator.ChurnLimits{
+		MaxChanges:        cfg.MaxChanges,
+		Interval:          cfg.ChangeInterval,
+		DepartureWindow:   cfg.DepartureWindow,
+		PrimaryStickiness: cfg.PrimaryStickiness,
+	}
 }
 
 // MemberKey of an allocator implied by the ServiceConfig.
@@ -0,0 +1,199 @@
+package message
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	"github.com/pkg/errors"
+)
+
+// AvroMessage is a Message which is encoded with Avro. It's typically
+// implemented by wrapping a code-generated Avro type, or a generic Avro codec.
+type AvroMessage interface {
+	// AvroSchema returns the Parsing Canonical Form of the Message schema.
+	AvroSchema() string
+	// MarshalAvro appends the Avro binary encoding of the Message to |b|.
+	MarshalAvro(b []byte) ([]byte, error)
+	// UnmarshalAvro decodes the Avro binary encoding |b| into the Message.
+	// |writerSchema| is the Parsing Canonical Form of the schema with which
+	// |b| was written. It may differ from AvroSchema, in which case the
+	// Message is responsible for resolving the writer and reader schemas
+	// as per the Avro specification.
+	UnmarshalAvro(writerSchema string, b []byte) error
+}
+
+// AvroSchemaResolver resolves the fingerprints of Avro schemas.
+type AvroSchemaResolver interface {
+	// AvroSchema returns the Parsing Canonical Form of the schema having
+	// CRC-64-AVRO |fingerprint|. It returns an error having cause
+	// ErrUnknownSchema if the fingerprint isn't known. Any other returned
+	// error indicates a failure to consult the resolver.
+	AvroSchema(fingerprint uint64) (string, error)
+}
+
+// AvroSchemas is an AvroSchemaResolver of a static set of schemas, keyed on
+// their fingerprints.
+type AvroSchemas map[uint64]string
+
+// Add |schema|, which must be in Parsing Canonical Form, to the AvroSchemas.
+func (s AvroSchemas) Add(schema string) { s[AvroFingerprint(schema)] = schema }
+
+// AvroSchema implements AvroSchemaResolver.
+func (s AvroSchemas) AvroSchema(fingerprint uint64) (string, error) {
+	if schema, ok := s[fingerprint]; ok {
+		return schema, nil
+	}
+	return "", errors.WithMessage(ErrUnknownSchema, fmt.Sprintf("fingerprint %#x", fingerprint))
+}
+
+// AvroFraming is a Framing of AvroMessages in the Avro single-object encoding:
+// a two-byte marker, followed by the little-endian CRC-64-AVRO fingerprint of
+// the writer schema, followed by the Avro binary encoding of the Message.
+// As the single-object encoding isn't self-delimiting, each object is further
+// prefixed with its varint length, as per DelimitedFraming. Avro-aware tools
+// can thus read journal fragments as a stream of length-delimited single
+// objects, and resolve the writer schema of each from its fingerprint.
+//
+// Unmarshal resolves the fingerprint of each frame to its writer schema using
+// the AvroSchemaResolver, and refuses a Message having an unknown schema.
+type AvroFraming struct {
+	Resolver AvroSchemaResolver
+
+	fingerprints sync.Map // Cache of schema => fingerprint.
+}
+
+// NewAvroFraming returns an AvroFraming of the AvroSchemaResolver.
+func NewAvroFraming(resolver AvroSchemaResolver) *AvroFraming {
+	return &AvroFraming{Resolver: resolver}
+}
+
+// AvroSingleObjectHeaderLength is the number of leading bytes of an Avro
+// single object: a two-byte marker followed by a schema fingerprint.
+const AvroSingleObjectHeaderLength = 10
+
+// ContentType returns labels.ContentType_AvroSingleObject.
+func (f *AvroFraming) ContentType() string { return labels.ContentType_AvroSingleObject }
+
+// Marshal implements Framing. It returns an error only if the Message
+// cannot be encoded.
+func (f *AvroFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var b, err = f.Encode(msg, bufferPool.Get().([]byte))
+	if err == nil {
+		_, _ = bw.Write(b)
+	}
+	bufferPool.Put(b[:0])
+	return err
+}
+
+// Encode a Message by appending into buffer |b|, which will be grown if needed and returned.
+func (f *AvroFraming) Encode(msg Message, b []byte) ([]byte, error) {
+	var am, ok = msg.(AvroMessage)
+	if !ok {
+		return b, fmt.Errorf("%+v is not avro-frameable (must implement AvroMessage)", msg)
+	}
+	var fingerprint = f.fingerprint(am.AvroSchema())
+
+	// Reserve space for the largest varint length prefix, and write
+	// the single object which follows it.
+	var offset = len(b)
+	var begin = offset + binary.MaxVarintLen64
+
+	b = append(b, make([]byte, binary.MaxVarintLen64)...)
+	b = append(b, avroSingleObjectMarker[0], avroSingleObjectMarker[1])
+	b = append(b, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(b[begin+2:], fingerprint)
+
+	var err error
+	if b, err = am.MarshalAvro(b); err != nil {
+		return b, err
+	}
+
+	// Encode the actual varint length prefix, and shift the object to follow it.
+	var n = binary.PutUvarint(b[offset:], uint64(len(b)-begin))
+	var size = n + copy(b[offset+n:], b[begin:])
+
+	return b[:offset+size], nil
+}
+
+// Unpack returns the next frame of content from the Reader, as per
+// DelimitedFraming. It implements Framing.
+func (f *AvroFraming) Unpack(r *bufio.Reader) ([]byte, error) { return DelimitedFraming.Unpack(r) }
+
+// Unmarshal verifies the frame length and single-object header, resolves the
+// writer schema of the frame fingerprint, and unpacks Message content. If the
+// AvroSchemaResolver could not be consulted, a *SchemaRegistryError is returned.
+//
+// It implements Framing.
+func (f *AvroFraming) Unmarshal(b []byte, msg Message) error {
+	var am, ok = msg.(AvroMessage)
+	if !ok {
+		return fmt.Errorf("%+v is not avro-frameable (must implement AvroMessage)", msg)
+	}
+
+	var length, n = binary.Uvarint(b)
+	if n <= 0 || uint64(len(b)-n) != length {
+		return ErrDelimitedLengthMismatch
+	} else if b = b[n:]; len(b) < AvroSingleObjectHeaderLength ||
+		b[0] != avroSingleObjectMarker[0] || b[1] != avroSingleObjectMarker[1] {
+		return ErrInvalidAvroSingleObject
+	}
+	var fingerprint = binary.LittleEndian.Uint64(b[2:])
+
+	var schema, err = f.Resolver.AvroSchema(fingerprint)
+	if err != nil {
+		if errors.Cause(err) == ErrUnknownSchema {
+			return err
+		}
+		return &SchemaRegistryError{Err: err}
+	} else if err = am.UnmarshalAvro(schema, b[AvroSingleObjectHeaderLength:]); err != nil {
+		return err
+	} else if fx, ok := msg.(Fixupable); ok {
+		return fx.Fixup()
+	}
+	return nil
+}
+
+// fingerprint returns the AvroFingerprint of |schema|, from cache if possible.
+func (f *AvroFraming) fingerprint(schema string) uint64 {
+	if fp, ok := f.fingerprints.Load(schema); ok {
+		return fp.(uint64)
+	}
+	var fp = AvroFingerprint(schema)
+	f.fingerprints.Store(schema, fp)
+	return fp
+}
+
+// AvroFingerprint returns the CRC-64-AVRO fingerprint of |schema|, which
+// should be in Parsing Canonical Form.
+func AvroFingerprint(schema string) uint64 {
+	var fp = avroFingerprintEmpty
+	for i := 0; i != len(schema); i++ {
+		fp = (fp >> 8) ^ avroFingerprintTable[byte(fp)^schema[i]]
+	}
+	return fp
+}
+
+var (
+	// ErrInvalidAvroSingleObject is returned by AvroFraming.Unmarshal if the
+	// frame doesn't begin with a valid Avro single-object header.
+	ErrInvalidAvroSingleObject = errors.New("invalid avro single object")
+	// avroSingleObjectMarker begins each Avro single object.
+	avroSingleObjectMarker = [2]byte{0xc3, 0x01}
+	// avroFingerprintTable is the lookup table of the CRC-64-AVRO fingerprint.
+	avroFingerprintTable = func() (t [256]uint64) {
+		for i := range t {
+			var fp = uint64(i)
+			for j := 0; j != 8; j++ {
+				fp = (fp >> 1) ^ (avroFingerprintEmpty & -(fp & 1))
+			}
+			t[i] = fp
+		}
+		return
+	}()
+)
+
+// avroFingerprintEmpty is the CRC-64-AVRO fingerprint of empty input.
+const avroFingerprintEmpty uint64 = 0xc15d213aa4d7a795
@@ -0,0 +1,143 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	gc "github.com/go-check/check"
+	"github.com/pkg/errors"
+)
+
+type AvroFramingSuite struct{}
+
+func (s *AvroFramingSuite) TestFingerprintFixtures(c *gc.C) {
+	// Fixtures are drawn from the Avro specification & reference implementations.
+	c.Check(AvroFingerprint(`"int"`), gc.Equals, uint64(0x7275d51a3f395c8f))
+	c.Check(AvroFingerprint(`"string"`), gc.Equals, uint64(0x8f014872634503c7))
+}
+
+func (s *AvroFramingSuite) TestMarshalAndUnmarshalWithFixtures(c *gc.C) {
+	var schemas = make(AvroSchemas)
+	schemas.Add(`"string"`)
+	var f = NewAvroFraming(schemas)
+
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	var hello, world = avroString("hello"), avroString("world!")
+	c.Check(f.Marshal(&hello, bw), gc.IsNil)
+	c.Check(f.Marshal(&world, bw), gc.IsNil)
+	_ = bw.Flush()
+
+	c.Check(buf.Bytes(), gc.DeepEquals, []byte{
+		0x10, 0xc3, 0x01, 0xc7, 0x03, 0x45, 0x63, 0x72, 0x48, 0x01, 0x8f, 0x0a, 'h', 'e', 'l', 'l', 'o',
+		0x11, 0xc3, 0x01, 0xc7, 0x03, 0x45, 0x63, 0x72, 0x48, 0x01, 0x8f, 0x0c, 'w', 'o', 'r', 'l', 'd', '!',
+	})
+
+	var r = testReader(buf.Bytes())
+	for _, expect := range []avroString{"hello", "world!"} {
+		var frame, err = f.Unpack(r)
+		c.Check(err, gc.IsNil)
+
+		var msg avroString
+		c.Check(f.Unmarshal(frame, &msg), gc.IsNil)
+		c.Check(msg, gc.Equals, expect)
+	}
+	var _, err = f.Unpack(r)
+	c.Check(err, gc.Equals, io.EOF)
+}
+
+func (s *AvroFramingSuite) TestMarshalErrors(c *gc.C) {
+	var f = NewAvroFraming(make(AvroSchemas))
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	c.Check(f.Marshal(struct{}{}, bw), gc.ErrorMatches,
+		`.* is not avro-frameable \(must implement AvroMessage\)`)
+	var fail = avroString("fail")
+	c.Check(f.Marshal(&fail, bw), gc.ErrorMatches, "marshal failed")
+
+	_ = bw.Flush()
+	c.Check(buf.Len(), gc.Equals, 0) // Nothing written.
+}
+
+func (s *AvroFramingSuite) TestUnmarshalErrors(c *gc.C) {
+	var schemas = make(AvroSchemas)
+	var f = NewAvroFraming(schemas)
+
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+	var hello = avroString("hello")
+	c.Assert(f.Marshal(&hello, bw), gc.IsNil)
+	_ = bw.Flush()
+
+	var frame = buf.Bytes()
+	var msg avroString
+
+	// Case: schema isn't known to the resolver.
+	var err = f.Unmarshal(frame, &msg)
+	c.Check(err, gc.ErrorMatches, `fingerprint 0x8f014872634503c7: unknown schema`)
+	c.Check(errors.Cause(err), gc.Equals, ErrUnknownSchema)
+
+	// Case: resolver cannot be consulted.
+	f.Resolver = failingAvroResolver{}
+	err = f.Unmarshal(frame, &msg)
+	c.Check(err, gc.FitsTypeOf, &SchemaRegistryError{})
+	c.Check(err, gc.ErrorMatches, `schema registry: unavailable`)
+
+	// Case: schema is known, but the message fails to decode.
+	schemas.Add(`"string"`)
+	f.Resolver = schemas
+	c.Check(f.Unmarshal(frame[:len(frame)-1], &msg), gc.Equals, ErrDelimitedLengthMismatch)
+
+	var truncated = append([]byte{0x0f}, frame[1:len(frame)-1]...)
+	c.Check(f.Unmarshal(truncated, &msg), gc.ErrorMatches, "unexpected end of avro string")
+
+	// Case: invalid single-object marker.
+	var corrupt = append([]byte(nil), frame...)
+	corrupt[1] = 0xff
+	c.Check(f.Unmarshal(corrupt, &msg), gc.Equals, ErrInvalidAvroSingleObject)
+
+	// Case: message isn't an AvroMessage.
+	c.Check(f.Unmarshal(frame, struct{}{}), gc.ErrorMatches,
+		`.* is not avro-frameable \(must implement AvroMessage\)`)
+}
+
+// avroString is an AvroMessage of schema "string".
+type avroString string
+
+func (avroString) AvroSchema() string { return `"string"` }
+
+func (m avroString) MarshalAvro(b []byte) ([]byte, error) {
+	if m == "fail" {
+		return b, errors.New("marshal failed")
+	}
+	var tmp [binary.MaxVarintLen64]byte
+	b = append(b, tmp[:binary.PutVarint(tmp[:], int64(len(m)))]...)
+	return append(b, m...), nil
+}
+
+func (m *avroString) UnmarshalAvro(writerSchema string, b []byte) error {
+	if writerSchema != `"string"` {
+		return fmt.Errorf("cannot resolve writer schema %s", writerSchema)
+	}
+	var length, n = binary.Varint(b)
+	if n <= 0 || int64(len(b)-n) != length {
+		return errors.New("unexpected end of avro string")
+	}
+	*m = avroString(b[n:])
+	return nil
+}
+
+type failingAvroResolver struct{}
+
+func (failingAvroResolver) AvroSchema(uint64) (string, error) { return "", errors.New("unavailable") }
+
+var (
+	_ Framing            = new(AvroFraming)
+	_ AvroSchemaResolver = AvroSchemas{}
+	_                    = gc.Suite(&AvroFramingSuite{})
+)
@@ -0,0 +1,183 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+)
+
+// CSVMarshaler is a Message which may be framed as a row of delimited text.
+type CSVMarshaler interface {
+	// MarshalCSV returns the fields of the Message row.
+	MarshalCSV() ([]string, error)
+}
+
+// CSVUnmarshaler is a Message which may be decoded from a row of delimited text.
+type CSVUnmarshaler interface {
+	// UnmarshalCSV decodes the Message from the fields of its row.
+	UnmarshalCSV(fields []string) error
+}
+
+// CSVFraming is a Framing implementation which encodes each message as a row
+// of comma-separated text, as per RFC 4180. Rows are terminated by a newline,
+// and fields may be quoted (and must be, if they contain a delimiter, quote,
+// or newline). Messages must implement CSVMarshaler for marshal support, and
+// CSVUnmarshaler for unmarshal support. CSVRecord is a generic implementation.
+//
+// CSVFraming allows bulk file backfills and SQL-engine exports to be appended
+// to journals directly, without re-encoding each row. Any header row of the
+// file should be removed, and may instead be expressed by the labels.CSVHeader
+// label of the journal.
+var CSVFraming Framing = &csvFraming{comma: ',', contentType: labels.ContentType_CSV}
+
+// TSVFraming is a Framing implementation which encodes each message as a row
+// of tab-separated text. It's otherwise identical to CSVFraming.
+var TSVFraming Framing = &csvFraming{comma: '\t', contentType: labels.ContentType_TSV}
+
+type csvFraming struct {
+	comma       rune
+	contentType string
+}
+
+// ContentType returns labels.ContentType_CSV or labels.ContentType_TSV.
+func (f *csvFraming) ContentType() string { return f.contentType }
+
+// Marshal implements Framing.
+func (f *csvFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var m, ok = msg.(CSVMarshaler)
+	if !ok {
+		return fmt.Errorf("%+v is not csv-frameable (must implement CSVMarshaler)", msg)
+	}
+	var fields, err = m.MarshalCSV()
+	if err != nil {
+		return err
+	}
+
+	// Encode into an intermediate buffer, as csv.Writer would otherwise
+	// flush |bw| (which it re-uses, rather than wrapping).
+	var buf bytes.Buffer
+	var w = csv.NewWriter(&buf)
+	w.Comma = f.comma
+
+	if err = w.Write(fields); err == nil {
+		w.Flush()
+		err = w.Error()
+	}
+	if err == nil {
+		_, _ = bw.Write(buf.Bytes())
+	}
+	return err
+}
+
+// Unpack returns the next row of the Reader, which may span multiple lines
+// if it has a quoted field containing newlines.
+//
+// It implements Framing.
+func (f *csvFraming) Unpack(r *bufio.Reader) ([]byte, error) {
+	var line, err = UnpackLine(r)
+	var quotes = bytes.Count(line, csvQuote)
+
+	if err != nil || quotes%2 == 0 {
+		return line, err
+	}
+	// |line| ends within a quoted field. Copy it (as it may reference an
+	// internal buffer), and read further lines until quotes are balanced.
+	var row = append([]byte(nil), line...)
+
+	for quotes%2 != 0 {
+		if line, err = UnpackLine(r); err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, line...)
+		quotes += bytes.Count(line, csvQuote)
+	}
+	return row, nil
+}
+
+// Unmarshal implements Framing.
+func (f *csvFraming) Unmarshal(row []byte, msg Message) error {
+	var m, ok = msg.(CSVUnmarshaler)
+	if !ok {
+		return fmt.Errorf("%+v is not csv-frameable (must implement CSVUnmarshaler)", msg)
+	}
+
+	var r = csv.NewReader(bytes.NewReader(row))
+	r.Comma = f.comma
+	r.FieldsPerRecord = -1 // Leave validation of field counts to the Message.
+
+	var fields, err = r.Read()
+	if err == io.EOF {
+		return ErrEmptyCSVRow
+	} else if err != nil {
+		return err
+	} else if err = m.UnmarshalCSV(fields); err != nil {
+		return err
+	} else if fx, ok := msg.(Fixupable); ok {
+		return fx.Fixup()
+	}
+	return nil
+}
+
+// CSVRecord is a generic Message of a CSVFraming or TSVFraming journal.
+type CSVRecord struct {
+	// Header names the columns of Fields. It's empty if the journal has no
+	// labels.CSVHeader label.
+	Header []string
+	// Fields of the row.
+	Fields []string
+}
+
+// NewCSVRecord returns a CSVRecord having the Header of the JournalSpec.
+// It's suited for use within consumer.Application's NewMessage.
+func NewCSVRecord(spec *pb.JournalSpec) *CSVRecord {
+	return &CSVRecord{Header: ParseCSVHeader(spec.LabelSet.ValueOf(labels.CSVHeader))}
+}
+
+// ParseCSVHeader parses a labels.CSVHeader label value into column names.
+func ParseCSVHeader(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, labels.CSVHeaderSeparator)
+}
+
+// Get returns the field of |column| within the Header, and whether it's present.
+func (r *CSVRecord) Get(column string) (string, bool) {
+	for i, c := range r.Header {
+		if c == column && i < len(r.Fields) {
+			return r.Fields[i], true
+		}
+	}
+	return "", false
+}
+
+// MarshalCSV returns Fields. It implements CSVMarshaler.
+func (r *CSVRecord) MarshalCSV() ([]string, error) { return r.Fields, nil }
+
+// UnmarshalCSV sets Fields. If the CSVRecord has a Header, the number of
+// |fields| must match. It implements CSVUnmarshaler.
+func (r *CSVRecord) UnmarshalCSV(fields []string) error {
+	if len(r.Header) != 0 && len(fields) != len(r.Header) {
+		return fmt.Errorf("row has %d fields, but header has %d columns", len(fields), len(r.Header))
+	}
+	r.Fields = fields
+	return nil
+}
+
+var (
+	// ErrEmptyCSVRow is returned by Unmarshal of a CSVFraming or TSVFraming
+	// row which is empty.
+	ErrEmptyCSVRow = errors.New("empty csv row")
+
+	csvQuote = []byte{'"'}
+)
@@ -0,0 +1,116 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type CSVFramingSuite struct{}
+
+func (s *CSVFramingSuite) TestMarshalWithFixtures(c *gc.C) {
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	c.Check(CSVFraming.Marshal(&CSVRecord{Fields: []string{"1", "plain", "text"}}, bw), gc.IsNil)
+	c.Check(CSVFraming.Marshal(&CSVRecord{Fields: []string{"2", "with, comma", "multi\nline \"quoted\""}}, bw), gc.IsNil)
+	c.Check(TSVFraming.Marshal(&CSVRecord{Fields: []string{"3", "tab\tseparated", ""}}, bw), gc.IsNil)
+	_ = bw.Flush()
+
+	c.Check(buf.String(), gc.Equals, "1,plain,text\n"+
+		"2,\"with, comma\",\"multi\nline \"\"quoted\"\"\"\n"+
+		"3\t\"tab\tseparated\"\t\n")
+
+	c.Check(CSVFraming.Marshal(struct{}{}, bw), gc.ErrorMatches,
+		`.* is not csv-frameable \(must implement CSVMarshaler\)`)
+}
+
+func (s *CSVFramingSuite) TestUnpackAndUnmarshalWithFixtures(c *gc.C) {
+	var fixture = []byte("1,plain,text\n" +
+		"2,\"with, comma\",\"multi\nline \"\"quoted\"\"\"\n" +
+		"3,\"spans\n\nthree lines\",x\n")
+	var r = testReader(fixture)
+
+	for _, expect := range [][]string{
+		{"1", "plain", "text"},
+		{"2", "with, comma", "multi\nline \"quoted\""},
+		{"3", "spans\n\nthree lines", "x"},
+	} {
+		var row, err = CSVFraming.Unpack(r)
+		c.Check(err, gc.IsNil)
+
+		var rec CSVRecord
+		c.Check(CSVFraming.Unmarshal(row, &rec), gc.IsNil)
+		c.Check(rec.Fields, gc.DeepEquals, expect)
+	}
+	var _, err = CSVFraming.Unpack(r)
+	c.Check(err, gc.Equals, io.EOF)
+
+	// A row which ends within a quoted field is unexpected.
+	_, err = CSVFraming.Unpack(testReader([]byte("1,\"unterminated\n")))
+	c.Check(err, gc.Equals, io.ErrUnexpectedEOF)
+}
+
+func (s *CSVFramingSuite) TestTSVRoundTrip(c *gc.C) {
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	var expect = []string{"a b", "c,d", "e\tf"}
+	c.Check(TSVFraming.Marshal(&CSVRecord{Fields: expect}, bw), gc.IsNil)
+	_ = bw.Flush()
+
+	var row, err = TSVFraming.Unpack(testReader(buf.Bytes()))
+	c.Check(err, gc.IsNil)
+
+	var rec CSVRecord
+	c.Check(TSVFraming.Unmarshal(row, &rec), gc.IsNil)
+	c.Check(rec.Fields, gc.DeepEquals, expect)
+}
+
+func (s *CSVFramingSuite) TestRecordWithHeader(c *gc.C) {
+	var spec = &pb.JournalSpec{
+		LabelSet: pb.MustLabelSet(
+			labels.ContentType, labels.ContentType_CSV,
+			labels.CSVHeader, "id+name+created_at",
+		),
+	}
+	var rec = NewCSVRecord(spec)
+	c.Check(rec.Header, gc.DeepEquals, []string{"id", "name", "created_at"})
+
+	c.Check(CSVFraming.Unmarshal([]byte("42,alice,2019-01-01\n"), rec), gc.IsNil)
+
+	var v, ok = rec.Get("name")
+	c.Check(v, gc.Equals, "alice")
+	c.Check(ok, gc.Equals, true)
+
+	_, ok = rec.Get("missing")
+	c.Check(ok, gc.Equals, false)
+
+	// Rows must match the header.
+	c.Check(CSVFraming.Unmarshal([]byte("42,alice\n"), rec), gc.ErrorMatches,
+		`row has 2 fields, but header has 3 columns`)
+
+	// Without a header, any number of fields are allowed.
+	rec = NewCSVRecord(&pb.JournalSpec{})
+	c.Check(rec.Header, gc.IsNil)
+	c.Check(CSVFraming.Unmarshal([]byte("42,alice\n"), rec), gc.IsNil)
+}
+
+func (s *CSVFramingSuite) TestUnmarshalErrors(c *gc.C) {
+	var rec CSVRecord
+
+	c.Check(CSVFraming.Unmarshal([]byte("\n"), &rec), gc.Equals, ErrEmptyCSVRow)
+	c.Check(CSVFraming.Unmarshal([]byte("1,bad\"quote\n"), &rec), gc.ErrorMatches, `.*bare " in non-quoted-field`)
+	c.Check(CSVFraming.Unmarshal([]byte("1,2\n"), struct{}{}), gc.ErrorMatches,
+		`.* is not csv-frameable \(must implement CSVUnmarshaler\)`)
+}
+
+var (
+	_ CSVMarshaler   = new(CSVRecord)
+	_ CSVUnmarshaler = new(CSVRecord)
+	_                = gc.Suite(&CSVFramingSuite{})
+)
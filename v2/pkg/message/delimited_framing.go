@@ -0,0 +1,140 @@
+package message
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	"github.com/pkg/errors"
+)
+
+// DelimitedFraming is a Framing implementation which encodes messages as a
+// varint length prefix, followed by payload bytes. It's the conventional
+// encoding of streamed Protobuf messages (eg, as written by Java's
+// writeDelimitedTo, or C++'s SerializeDelimitedToOstream), and journals of
+// DelimitedFraming may be parsed by non-Go consumers using stock Protobuf
+// libraries. As with FixedFraming, messages must support ProtoSize and
+// MarshalTo functions for marshal support, and Unmarshal for unmarshal support.
+//
+// Unlike FixedFraming, DelimitedFraming has no magic word and is unable to
+// detect or recover from a de-synchronization of the journal content.
+var DelimitedFraming = new(delimitedFraming)
+
+type delimitedFraming struct{}
+
+// ContentType returns labels.ContentType_ProtoDelimited.
+func (*delimitedFraming) ContentType() string { return labels.ContentType_ProtoDelimited }
+
+// Marshal implements Framing. It returns an error only if Message.Encode fails.
+func (f *delimitedFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var b, err = f.Encode(msg, bufferPool.Get().([]byte))
+	if err == nil {
+		_, _ = bw.Write(b)
+	}
+	bufferPool.Put(b[:0])
+	return err
+}
+
+// Encode a Message by appending into buffer |b|, which will be grown if needed and returned.
+func (*delimitedFraming) Encode(msg Message, b []byte) ([]byte, error) {
+	var p, ok = msg.(interface {
+		ProtoSize() int
+		MarshalTo([]byte) (int, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("%+v is not delimited-frameable (must implement ProtoSize and MarshalTo)", msg)
+	}
+
+	var length = p.ProtoSize()
+	var prefix [binary.MaxVarintLen64]byte
+	var n = binary.PutUvarint(prefix[:], uint64(length))
+
+	var size = n + length
+	var offset = len(b)
+
+	if size > (cap(b) - offset) {
+		b = append(b, make([]byte, size)...)
+	} else {
+		b = b[:offset+size]
+	}
+	copy(b[offset:offset+n], prefix[:n])
+
+	if _, err := p.MarshalTo(b[offset+n:]); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Unpack returns the next delimited frame of content from the Reader,
+// including its varint length prefix.
+//
+// It implements Framing.
+func (*delimitedFraming) Unpack(r *bufio.Reader) ([]byte, error) {
+	var b, err = r.Peek(binary.MaxVarintLen64)
+
+	if err == io.EOF && len(b) == 0 {
+		return nil, io.EOF
+	} else if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "Peek(MaxVarintLen64)")
+	}
+
+	var length, n = binary.Uvarint(b)
+	if n == 0 && len(b) < binary.MaxVarintLen64 {
+		// |b| is a partial varint, and we read at least one byte. An EOF
+		// should occur only on whole-message boundaries.
+		return nil, io.ErrUnexpectedEOF
+	} else if n <= 0 || length > maxDelimitedFrameLength {
+		return nil, ErrDelimitedLengthOverflow
+	}
+	var size = n + int(length)
+
+	// Fast path: check if the full frame is available in buffer. Return the
+	// buffer internal slice without copying. It is invalidated by the next
+	// Unpack (or other Reader operation).
+	if b, err = r.Peek(size); err == nil {
+		_, _ = r.Discard(size)
+		return b, nil
+	}
+
+	// Slow path. Allocate and attempt to Read the full frame.
+	b = make([]byte, size)
+	_, err = io.ReadFull(r, b)
+	return b, errors.Wrap(err, "io.ReadFull")
+}
+
+// Unmarshal unpacks Message content of the frame, following its length prefix.
+//
+// It implements Framing.
+func (*delimitedFraming) Unmarshal(b []byte, msg Message) error {
+	var p, ok = msg.(interface {
+		Unmarshal([]byte) error
+	})
+	if !ok {
+		return fmt.Errorf("%+v is not delimited-frameable (must implement Unmarshal)", msg)
+	}
+
+	var length, n = binary.Uvarint(b)
+	if n <= 0 || uint64(len(b)-n) != length {
+		return ErrDelimitedLengthMismatch
+	} else if err := p.Unmarshal(b[n:]); err != nil {
+		return err
+	} else if f, ok := msg.(Fixupable); ok {
+		return f.Fixup()
+	}
+	return nil
+}
+
+var (
+	// ErrDelimitedLengthOverflow is returned by DelimitedFraming Unpack upon
+	// reading a length prefix which is invalid or too large.
+	ErrDelimitedLengthOverflow = errors.New("delimited frame length overflow")
+	// ErrDelimitedLengthMismatch is returned by DelimitedFraming Unmarshal if
+	// the frame length doesn't match its length prefix.
+	ErrDelimitedLengthMismatch = errors.New("delimited frame length mismatch")
+)
+
+// maxDelimitedFrameLength bounds the length of a delimited frame. It matches
+// the 2GB limit of a serialized Protobuf message.
+const maxDelimitedFrameLength = 1<<31 - 1
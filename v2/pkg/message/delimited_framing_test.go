@@ -0,0 +1,107 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	gc "github.com/go-check/check"
+	"github.com/pkg/errors"
+)
+
+type DelimitedFramingSuite struct{}
+
+func (s *DelimitedFramingSuite) TestMarshalWithFixtures(c *gc.C) {
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	c.Check(DelimitedFraming.Marshal(frameablestring("test message content"), bw), gc.IsNil)
+	_ = bw.Flush()
+	c.Check(buf.Bytes(), gc.DeepEquals, []byte{
+		0x14, 't', 'e', 's', 't', ' ', 'm', 'e', 's', 's', 'a', 'g', 'e',
+		' ', 'c', 'o', 'n', 't', 'e', 'n', 't'})
+
+	// Append another message, having a two-byte varint length.
+	var long = strings.Repeat("x", 300)
+	c.Check(DelimitedFraming.Marshal(frameablestring(long), bw), gc.IsNil)
+	_ = bw.Flush()
+	c.Check(buf.Bytes()[21:23], gc.DeepEquals, []byte{0xac, 0x02})
+	c.Check(buf.Len(), gc.Equals, 21+2+300)
+
+	// Expect both messages may be unpacked and unmarshaled.
+	var r = testReader(buf.Bytes())
+	var msg frameablestring
+
+	var b, err = DelimitedFraming.Unpack(r)
+	c.Check(err, gc.IsNil)
+	c.Check(DelimitedFraming.Unmarshal(b, &msg), gc.IsNil)
+	c.Check(msg, gc.Equals, frameablestring("test message content"))
+
+	b, err = DelimitedFraming.Unpack(r)
+	c.Check(err, gc.IsNil)
+	c.Check(DelimitedFraming.Unmarshal(b, &msg), gc.IsNil)
+	c.Check(msg, gc.Equals, frameablestring(long))
+
+	_, err = DelimitedFraming.Unpack(r)
+	c.Check(err, gc.Equals, io.EOF)
+}
+
+func (s *DelimitedFramingSuite) TestMarshalError(c *gc.C) {
+	var err = DelimitedFraming.Marshal(struct{ Unframeable int }{}, nil)
+	c.Check(err, gc.ErrorMatches, `{Unframeable:0} is not delimited-frameable \(must implement ProtoSize and MarshalTo\)`)
+
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	c.Check(DelimitedFraming.Marshal(frameableerror("test message"), bw), gc.ErrorMatches, "error!")
+	_ = bw.Flush()
+	c.Check(buf.Len(), gc.Equals, 0) // Nothing written.
+}
+
+func (s *DelimitedFramingSuite) TestUnpackErrors(c *gc.C) {
+	var fixture = []byte{0xac, 0x02}
+	fixture = append(fixture, bytes.Repeat([]byte{'x'}, 300)...)
+
+	// EOF at message boundary.
+	var _, err = DelimitedFraming.Unpack(testReader(nil))
+	c.Check(err, gc.Equals, io.EOF)
+
+	// EOF partway through the varint length.
+	_, err = DelimitedFraming.Unpack(testReader(fixture[:1]))
+	c.Check(err, gc.Equals, io.ErrUnexpectedEOF)
+
+	// EOF partway through the message.
+	_, err = DelimitedFraming.Unpack(testReader(fixture[:200]))
+	c.Check(errors.Cause(err), gc.Equals, io.ErrUnexpectedEOF)
+
+	// Full message. Success.
+	b, err := DelimitedFraming.Unpack(testReader(fixture))
+	c.Check(err, gc.IsNil)
+	c.Check(b, gc.DeepEquals, fixture)
+
+	// Length prefix which overflows.
+	_, err = DelimitedFraming.Unpack(testReader(bytes.Repeat([]byte{0xff}, 12)))
+	c.Check(err, gc.Equals, ErrDelimitedLengthOverflow)
+}
+
+func (s *DelimitedFramingSuite) TestUnmarshalErrors(c *gc.C) {
+	var msg frameablestring
+
+	// Frame length doesn't match its length prefix.
+	c.Check(DelimitedFraming.Unmarshal([]byte{0x03, 'x', 'x'}, &msg), gc.Equals, ErrDelimitedLengthMismatch)
+	c.Check(DelimitedFraming.Unmarshal(nil, &msg), gc.Equals, ErrDelimitedLengthMismatch)
+
+	// Message-level decoding errors are passed through.
+	var msgErr frameableerror
+	c.Check(DelimitedFraming.Unmarshal([]byte{0x02, 'x', 'x'}, &msgErr), gc.ErrorMatches, "error!")
+
+	// Messages must implement Unmarshal.
+	c.Check(DelimitedFraming.Unmarshal([]byte{0x00}, struct{}{}), gc.ErrorMatches,
+		`{} is not delimited-frameable \(must implement Unmarshal\)`)
+}
+
+var (
+	expectDelimitedIsFraming Framing = new(delimitedFraming)
+	_                                = gc.Suite(&DelimitedFramingSuite{})
+)
@@ -9,6 +9,10 @@ import (
 
 // JSONFraming is a Framing implementation which encodes messages as line-
 // delimited JSON. Messages must be encode-able by the encoding/json package.
+// It's selected for journals labeled with ContentType_JSONLines, and any
+// producer (in any language) may append plain JSON to such journals, so long
+// as each message is written as a single line terminated by a newline.
+// Messages which implement Fixupable are fixed up after each Unmarshal.
 var JSONFraming = new(jsonFraming)
 
 type jsonFraming struct{}
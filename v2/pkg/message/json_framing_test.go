@@ -6,6 +6,7 @@ import (
 	"io"
 
 	gc "github.com/go-check/check"
+	"github.com/pkg/errors"
 )
 
 type JsonFramingSuite struct{}
@@ -75,6 +76,36 @@ func (s *JsonFramingSuite) TestMessageDecodeError(c *gc.C) {
 	c.Check(JSONFraming.Unmarshal(frame, &msg), gc.ErrorMatches, "invalid character .*")
 }
 
+func (s *JsonFramingSuite) TestUnmarshalWithFixup(c *gc.C) {
+	// Fixture is as might be written by a non-Go producer, with a CRLF line ending.
+	var fixture = []byte(`{"B": "fix me"}` + "\r\n" + `{"B": "fail"}` + "\n")
+	var br = testReader(fixture)
+
+	var frame, err = JSONFraming.Unpack(br)
+	c.Check(err, gc.IsNil)
+
+	var msg fixupMessage
+	c.Check(JSONFraming.Unmarshal(frame, &msg), gc.IsNil)
+	c.Check(msg, gc.DeepEquals, fixupMessage{B: "fix me", Fixed: true})
+
+	frame, err = JSONFraming.Unpack(br)
+	c.Check(err, gc.IsNil)
+	c.Check(JSONFraming.Unmarshal(frame, &msg), gc.ErrorMatches, "fixup failed")
+}
+
+type fixupMessage struct {
+	B     string
+	Fixed bool
+}
+
+func (m *fixupMessage) Fixup() error {
+	if m.B == "fail" {
+		return errors.New("fixup failed")
+	}
+	m.Fixed = true
+	return nil
+}
+
 var (
 	expectJsonIsFraming Framing = new(jsonFraming)
 	_                           = gc.Suite(&JsonFramingSuite{})
@@ -0,0 +1,253 @@
+package message
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// PublishTimeFunc returns the publish time embedded within a Message.
+type PublishTimeFunc func(Message) time.Time
+
+// NewMessageFunc returns a new, zero-valued Message to be decoded from the
+// journal of the JournalSpec.
+type NewMessageFunc func(*pb.JournalSpec) (Message, error)
+
+// MergedReader reads Messages from multiple journals (eg, partitions of a
+// topic), and merges them into a single sequence ordered on embedded publish
+// times. Merging requires a message from each journal to decide which is
+// next, but a tailed journal may have no ready message. MergedReader bounds
+// the reordering delay this implies: a message is held for at most |window|
+// awaiting messages of idle journals, after which it's returned regardless.
+// Messages of an idle journal which arrive later than |window| may thus be
+// returned out of publish-time order, relative to messages of other journals.
+// Messages of a single journal are always returned in journal order.
+//
+// Journals are read with the provided ReadRequests. If a ReadRequest doesn't
+// Block, its journal is finished upon reading through its write head, and
+// Next returns io.EOF once all journals have finished. MergedReader is not
+// thread-safe.
+type MergedReader struct {
+	ctx     context.Context
+	pubTime PublishTimeFunc
+	window  time.Duration
+
+	sources []mergedSource
+	itemCh  chan mergedItem // Messages sent by source pumps.
+	pending int             // Number of sources which are not finished.
+}
+
+// mergedSource is a journal being read by a MergedReader.
+type mergedSource struct {
+	head   *mergedItem   // Next Message of the source, if ready.
+	nextCh chan struct{} // Signals that |head| was consumed.
+}
+
+// mergedItem is a read Message (or error) of a mergedSource.
+type mergedItem struct {
+	source   int
+	env      Envelope
+	err      error
+	received time.Time
+}
+
+// NewMergedReader returns a MergedReader which reads messages of each of
+// |reqs|, decoding them with Messages returned by |newMsg|, and merging
+// them on publish times returned by |pubTime|.
+func NewMergedReader(ctx context.Context, rjc pb.RoutedJournalClient, reqs []pb.ReadRequest,
+	newMsg NewMessageFunc, pubTime PublishTimeFunc, window time.Duration) *MergedReader {
+
+	var mr = &MergedReader{
+		ctx:     ctx,
+		pubTime: pubTime,
+		window:  window,
+		sources: make([]mergedSource, len(reqs)),
+		itemCh:  make(chan mergedItem, len(reqs)),
+		pending: len(reqs),
+	}
+	for i, req := range reqs {
+		mr.sources[i].nextCh = make(chan struct{}, 1)
+		go mr.pump(i, rjc, req, newMsg)
+	}
+	return mr
+}
+
+// Next returns the next merged Message. It returns io.EOF if all journals
+// have finished, or a non-nil error encountered while reading a journal.
+func (mr *MergedReader) Next() (Envelope, error) {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		// Find the source having the minimum publish-time head, and the
+		// receipt time of the oldest head.
+		var min, ready = -1, 0
+		var oldest time.Time
+
+		for i, src := range mr.sources {
+			if src.head == nil {
+				continue
+			}
+			ready++
+
+			if min == -1 || mr.pubTime(src.head.env.Message).Before(mr.pubTime(mr.sources[min].head.env.Message)) {
+				min = i
+			}
+			if oldest.IsZero() || src.head.received.Before(oldest) {
+				oldest = src.head.received
+			}
+		}
+
+		if ready != 0 && ready == mr.pending {
+			return mr.pop(min), nil // All unfinished sources have a ready head.
+		} else if mr.pending == 0 {
+			return Envelope{}, io.EOF
+		}
+
+		// Wait for another source head, or for the reordering |window|
+		// of the oldest head to elapse.
+		var timeoutCh <-chan time.Time
+		if ready != 0 {
+			if timer == nil {
+				timer = time.NewTimer(time.Until(oldest.Add(mr.window)))
+			} else {
+				timer.Reset(time.Until(oldest.Add(mr.window)))
+			}
+			timeoutCh = timer.C
+		}
+
+		select {
+		case item := <-mr.itemCh:
+			if item.err == client.ErrOffsetNotYetAvailable {
+				mr.pending-- // Source has finished.
+			} else if item.err != nil {
+				return Envelope{}, item.err
+			} else {
+				mr.sources[item.source].head = &item
+			}
+		case <-timeoutCh:
+			return mr.pop(min), nil
+		case <-mr.ctx.Done():
+			return Envelope{}, mr.ctx.Err()
+		}
+
+		if timer != nil && !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+	}
+}
+
+// pop returns the head Envelope of the |ind| source, and signals its pump.
+func (mr *MergedReader) pop(ind int) Envelope {
+	var env = mr.sources[ind].head.env
+	mr.sources[ind].head = nil
+	mr.sources[ind].nextCh <- struct{}{}
+	return env
+}
+
+// pump reads and decodes messages of the |ind| source, sending each to
+// |mr.itemCh| and then awaiting its consumption before sending another.
+func (mr *MergedReader) pump(ind int, rjc pb.RoutedJournalClient, req pb.ReadRequest, newMsg NewMessageFunc) {
+	var send = func(item mergedItem) bool {
+		item.source, item.received = ind, time.Now()
+
+		select {
+		case mr.itemCh <- item:
+		case <-mr.ctx.Done():
+			return false
+		}
+		if item.err != nil {
+			return false
+		}
+		select {
+		case <-mr.sources[ind].nextCh:
+			return true
+		case <-mr.ctx.Done():
+			return false
+		}
+	}
+
+	var spec, framing, err = fetchSpecAndFraming(mr.ctx, rjc, req.Journal)
+	if err != nil {
+		send(mergedItem{err: err})
+		return
+	}
+	var rr = client.NewRetryReader(mr.ctx, rjc, req)
+	var br = bufio.NewReader(rr)
+
+	for offset, next := req.Offset, req.Offset; ; offset = next {
+		var frame []byte
+		var msg Message
+
+		if frame, err = framing.Unpack(br); errors.Cause(err) == client.ErrOffsetJump {
+			log.WithFields(log.Fields{"journal": req.Journal, "from": offset, "to": rr.Offset()}).
+				Warn("merged journal offset jump")
+
+			next = rr.Offset()
+			continue
+		} else if err != nil {
+			if errors.Cause(err) == client.ErrOffsetNotYetAvailable {
+				err = client.ErrOffsetNotYetAvailable // Source is finished.
+			} else {
+				err = errors.WithMessage(err, fmt.Sprintf("unpacking frame (%s:%d)", req.Journal, offset))
+			}
+			send(mergedItem{err: err})
+			return
+		}
+		next = rr.AdjustedOffset(br)
+
+		if msg, err = newMsg(spec); err != nil {
+			send(mergedItem{err: errors.WithMessage(err, fmt.Sprintf("NewMessage (%s)", req.Journal))})
+			return
+		} else if err = framing.Unmarshal(frame, msg); err != nil {
+			log.WithFields(log.Fields{"journal": req.Journal, "offset": offset, "err": err}).
+				Error("failed to unmarshal message")
+			continue
+		}
+
+		if !send(mergedItem{env: Envelope{
+			Message:     msg,
+			Fragment:    rr.Reader.Response.Fragment,
+			JournalSpec: spec,
+			NextOffset:  next,
+		}}) {
+			return
+		}
+	}
+}
+
+// fetchSpecAndFraming fetches the JournalSpec of |name|, and its Framing.
+func fetchSpecAndFraming(ctx context.Context, jc pb.JournalClient, name pb.Journal) (*pb.JournalSpec, Framing, error) {
+	var lr, err = client.ListAllJournals(ctx, jc, pb.ListRequest{
+		Selector: pb.LabelSelector{
+			Include: pb.LabelSet{Labels: []pb.Label{{Name: "name", Value: name.String()}}},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	} else if len(lr.Journals) == 0 {
+		return nil, nil, errors.Errorf("named journal does not exist (%s)", name)
+	}
+	var spec = &lr.Journals[0].Spec
+
+	framing, err := FramingByContentType(spec.LabelSet.ValueOf(labels.ContentType))
+	if err != nil {
+		return nil, nil, err
+	}
+	return spec, framing, nil
+}
@@ -0,0 +1,118 @@
+package message
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/brokertest"
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/LiveRamp/gazette/v2/pkg/etcdtest"
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type MergedReaderSuite struct{}
+
+func (s *MergedReaderSuite) TestMergeOnPublishTime(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var jsonLabels = pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines)
+
+	brokertest.CreateJournals(c, bk,
+		brokertest.Journal(pb.JournalSpec{Name: "part/one", LabelSet: jsonLabels}),
+		brokertest.Journal(pb.JournalSpec{Name: "part/two", LabelSet: jsonLabels}),
+		brokertest.Journal(pb.JournalSpec{Name: "part/three", LabelSet: jsonLabels}),
+	)
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+
+	for journal, content := range map[pb.Journal]string{
+		"part/one":   `{"T":1,"ID":"a"}` + "\n" + `{"T":4,"ID":"d"}` + "\n" + `{"T":5,"ID":"e"}` + "\n",
+		"part/two":   `{"T":2,"ID":"b"}` + "\n" + `{"T":7,"ID":"g"}` + "\n",
+		"part/three": `{"T":3,"ID":"c"}` + "\n" + `{"T":6,"ID":"f"}` + "\n",
+	} {
+		var _, err = client.Append(ctx, rjc, pb.AppendRequest{Journal: journal}, strings.NewReader(content))
+		c.Assert(err, gc.IsNil)
+	}
+
+	type testMsg struct {
+		T  int64
+		ID string
+	}
+	var mr = NewMergedReader(ctx, rjc,
+		[]pb.ReadRequest{{Journal: "part/one"}, {Journal: "part/two"}, {Journal: "part/three"}},
+		func(*pb.JournalSpec) (Message, error) { return new(testMsg), nil },
+		func(msg Message) time.Time { return time.Unix(msg.(*testMsg).T, 0) },
+		time.Minute)
+
+	// Expect messages are merged in publish-time order. As each journal is read
+	// without blocking, all journals eventually finish and Next returns EOF.
+	var ids []string
+	for {
+		var env, err = mr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, gc.IsNil)
+		ids = append(ids, env.Message.(*testMsg).ID)
+	}
+	c.Check(ids, gc.DeepEquals, []string{"a", "b", "c", "d", "e", "f", "g"})
+
+	cancel()
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+func (s *MergedReaderSuite) TestIdleSourceIsBoundedByWindow(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var jsonLabels = pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines)
+
+	brokertest.CreateJournals(c, bk,
+		brokertest.Journal(pb.JournalSpec{Name: "part/one", LabelSet: jsonLabels}),
+		brokertest.Journal(pb.JournalSpec{Name: "part/idle", LabelSet: jsonLabels}),
+	)
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+
+	var _, err = client.Append(ctx, rjc, pb.AppendRequest{Journal: "part/one"},
+		strings.NewReader(`{"T":1,"ID":"a"}`+"\n"))
+	c.Assert(err, gc.IsNil)
+
+	type testMsg struct {
+		T  int64
+		ID string
+	}
+	// "part/idle" is tailed with a blocking read, and has no messages.
+	var mr = NewMergedReader(ctx, rjc,
+		[]pb.ReadRequest{{Journal: "part/one", Block: true}, {Journal: "part/idle", Block: true}},
+		func(*pb.JournalSpec) (Message, error) { return new(testMsg), nil },
+		func(msg Message) time.Time { return time.Unix(msg.(*testMsg).T, 0) },
+		10*time.Millisecond)
+
+	// Expect the message is returned after the reordering window elapses.
+	env, err := mr.Next()
+	c.Check(err, gc.IsNil)
+	c.Check(env.Message.(*testMsg).ID, gc.Equals, "a")
+	c.Check(env.JournalSpec.Name, gc.Equals, pb.Journal("part/one"))
+
+	cancel()
+	_, err = mr.Next()
+	c.Check(err, gc.ErrorMatches, `.*context canceled`)
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+var _ = gc.Suite(&MergedReaderSuite{})
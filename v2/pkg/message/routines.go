@@ -16,12 +16,30 @@ import (
 // Publish maps the Message to its target journal and begins an Append of the
 // Message's marshaled content under the mapped journal framing. If Message
 // implements Validate, the message is first validated and any error returned.
+// If Message is a UUIDMessage without a UUID, it's stamped with a generated UUID.
 func Publish(broker client.AsyncJournalClient, mapping MappingFunc, msg Message) (*client.AsyncAppend, error) {
+	return publish(broker, mapping, msg, nil)
+}
+
+// PublishOnCommit is like Publish, but additionally registers |cb| to be
+// invoked with the journal offsets [begin, end) of the Message's framed content
+// once its Append commits. Brokers are agnostic to message framing, and an
+// AppendResponse reports only the offset range of an entire Append (which
+// may batch many Messages). PublishOnCommit instead allows producers to build
+// offset-addressed indices of the Messages they publish, without re-reading
+// them. See client.CommitCallback.
+func PublishOnCommit(broker client.AsyncJournalClient, mapping MappingFunc, msg Message, cb client.CommitCallback) (*client.AsyncAppend, error) {
+	return publish(broker, mapping, msg, cb)
+}
+
+func publish(broker client.AsyncJournalClient, mapping MappingFunc, msg Message, cb client.CommitCallback) (*client.AsyncAppend, error) {
 	if v, ok := msg.(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
 			return nil, err
 		}
 	}
+	StampUUID(msg)
+
 	var journal, framing, err = mapping(msg)
 	if err != nil {
 		return nil, err
@@ -29,6 +47,9 @@ func Publish(broker client.AsyncJournalClient, mapping MappingFunc, msg Message)
 	var aa = broker.StartAppend(journal)
 	aa.Require(framing.Marshal(msg, aa.Writer()))
 
+	if cb != nil {
+		aa.OnCommit(cb)
+	}
 	if err = aa.Release(); err != nil {
 		return nil, err
 	}
@@ -43,9 +64,32 @@ func FramingByContentType(contentType string) (Framing, error) {
 		return FixedFraming, nil
 	case labels.ContentType_JSONLines:
 		return JSONFraming, nil
-	default:
+	case labels.ContentType_ProtoDelimited:
+		return DelimitedFraming, nil
+	case labels.ContentType_CSV:
+		return CSVFraming, nil
+	case labels.ContentType_TSV:
+		return TSVFraming, nil
+	}
+
+	registeredFramingsMu.Lock()
+	var framing, ok = registeredFramings[contentType]
+	registeredFramingsMu.Unlock()
+
+	if !ok {
 		return nil, fmt.Errorf(`unrecognized %s (%s)`, labels.ContentType, contentType)
 	}
+	return framing, nil
+}
+
+// RegisterFraming registers |framing| to be returned by FramingByContentType
+// for its ContentType. It's intended for Framings which are built with
+// run-time configuration (eg, a SchemaFraming of a SchemaRegistry), and
+// should be called as part of application initialization.
+func RegisterFraming(framing Framing) {
+	registeredFramingsMu.Lock()
+	registeredFramings[framing.ContentType()] = framing
+	registeredFramingsMu.Unlock()
 }
 
 // UnpackLine returns bytes through to the first encountered newline "\n". If
@@ -175,3 +219,8 @@ func RendezvousMapping(key MappingKeyFunc, partitions PartitionsFunc) MappingFun
 		return
 	}
 }
+
+var (
+	registeredFramingsMu sync.Mutex
+	registeredFramings   = make(map[string]Framing)
+)
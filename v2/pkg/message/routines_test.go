@@ -47,6 +47,44 @@ func (s *RoutinesSuite) TestPublishSuccess(c *gc.C) {
 	c.Check(bk.Tasks.Wait(), gc.IsNil)
 }
 
+func (s *RoutinesSuite) TestPublishOnCommitOffsets(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	brokertest.CreateJournals(c, bk, brokertest.Journal(pb.JournalSpec{Name: "a/journal"}))
+
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(context.Background(), rjc)
+
+	var mapping = func(msg Message) (pb.Journal, Framing, error) {
+		return "a/journal", JSONFraming, nil
+	}
+	type offsets struct{ begin, end int64 }
+	var commitCh = make(chan offsets, 3)
+
+	var aa *client.AsyncAppend
+	for _, data := range []string{"one", "two", "three"} {
+		var err error
+		aa, err = PublishOnCommit(as, mapping, struct{ Data string }{Data: data},
+			func(begin, end int64, err error) {
+				c.Check(err, gc.IsNil)
+				commitCh <- offsets{begin, end}
+			})
+		c.Check(err, gc.IsNil)
+	}
+	<-aa.Done()
+
+	// Expect each message reports its own offset range, which together span
+	// the committed content.
+	c.Check(<-commitCh, gc.Equals, offsets{0, 15})
+	c.Check(<-commitCh, gc.Equals, offsets{15, 30})
+	c.Check(<-commitCh, gc.Equals, offsets{30, 47})
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
 func (s *RoutinesSuite) TestFramingDetermination(c *gc.C) {
 	var f, err = FramingByContentType(labels.ContentType_JSONLines)
 	c.Check(err, gc.IsNil)
@@ -56,6 +94,14 @@ func (s *RoutinesSuite) TestFramingDetermination(c *gc.C) {
 	c.Check(err, gc.IsNil)
 	c.Check(f, gc.Equals, FixedFraming)
 
+	f, err = FramingByContentType(labels.ContentType_ProtoDelimited)
+	c.Check(err, gc.IsNil)
+	c.Check(f, gc.Equals, DelimitedFraming)
+
+	f, err = FramingByContentType(labels.ContentType_TSV)
+	c.Check(err, gc.IsNil)
+	c.Check(f, gc.Equals, TSVFraming)
+
 	_, err = FramingByContentType(labels.ContentType_RecoveryLog) // Not a valid message framing.
 	c.Check(err, gc.ErrorMatches, `unrecognized `+labels.ContentType+` \(`+labels.ContentType_RecoveryLog+`\)`)
 }
@@ -0,0 +1,150 @@
+package message
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	"github.com/pkg/errors"
+)
+
+// SchemaRegistry resolves the schemas of Messages framed by a SchemaFraming.
+type SchemaRegistry interface {
+	// SchemaID returns the registered ID of the schema of Message |msg|.
+	SchemaID(msg Message) (uint32, error)
+	// CheckSchema returns nil if a Message written with schema |id| may be
+	// decoded into |msg|. It returns an error having cause ErrUnknownSchema
+	// if |id| isn't registered, or ErrIncompatibleSchema if a Message of
+	// schema |id| cannot be decoded into |msg|. Any other returned error
+	// indicates a failure to consult the registry.
+	CheckSchema(id uint32, msg Message) error
+}
+
+// SchemaFraming is a Framing of Protobuf Messages which additionally carries
+// the schema ID of each Message, as resolved by a SchemaRegistry. Frames are
+// encoded as per FixedFraming, with a payload consisting of a zero byte and
+// big-endian uint32 schema ID (the "wire format" prefix of the Confluent
+// Schema Registry), followed by the packed Protobuf message.
+//
+// Unmarshal refuses a Message having a schema which is unknown to, or deemed
+// incompatible by, the SchemaRegistry.
+type SchemaFraming struct {
+	Registry SchemaRegistry
+}
+
+// NewSchemaFraming returns a SchemaFraming of the SchemaRegistry.
+func NewSchemaFraming(registry SchemaRegistry) *SchemaFraming {
+	return &SchemaFraming{Registry: registry}
+}
+
+// SchemaEnvelopeHeaderLength is the number of leading bytes of a SchemaFraming
+// frame payload: a zero byte followed by a big-endian schema ID.
+const SchemaEnvelopeHeaderLength = 5
+
+// ContentType returns labels.ContentType_SchemaEnvelope.
+func (f *SchemaFraming) ContentType() string { return labels.ContentType_SchemaEnvelope }
+
+// Marshal implements Framing. It returns an error if the schema ID of the
+// Message cannot be resolved, or if the Message cannot be encoded.
+func (f *SchemaFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var b, err = f.Encode(msg, bufferPool.Get().([]byte))
+	if err == nil {
+		_, _ = bw.Write(b)
+	}
+	bufferPool.Put(b[:0])
+	return err
+}
+
+// Encode a Message by appending into buffer |b|, which will be grown if needed and returned.
+func (f *SchemaFraming) Encode(msg Message, b []byte) ([]byte, error) {
+	var p, ok = msg.(interface {
+		ProtoSize() int
+		MarshalTo([]byte) (int, error)
+	})
+	if !ok {
+		return b, fmt.Errorf("%+v is not fixed-frameable (must implement ProtoSize and MarshalTo)", msg)
+	}
+	var id, err = f.Registry.SchemaID(msg)
+	if err != nil {
+		return b, errors.WithMessage(err, "resolving schema ID")
+	}
+
+	var size = FixedFrameHeaderLength + SchemaEnvelopeHeaderLength + p.ProtoSize()
+	var offset = len(b)
+
+	if size > (cap(b) - offset) {
+		b = append(b, make([]byte, size)...)
+	} else {
+		b = b[:offset+size]
+	}
+
+	copy(b[offset:offset+4], magicWord[:])
+	binary.LittleEndian.PutUint32(b[offset+4:offset+8], uint32(size-FixedFrameHeaderLength))
+	b[offset+FixedFrameHeaderLength] = 0
+	binary.BigEndian.PutUint32(b[offset+FixedFrameHeaderLength+1:], id)
+
+	if _, err = p.MarshalTo(b[offset+FixedFrameHeaderLength+SchemaEnvelopeHeaderLength:]); err != nil {
+		return b, err
+	}
+	return b, nil
+}
+
+// Unpack returns the next frame of content from the Reader, as per
+// FixedFraming. It implements Framing.
+func (f *SchemaFraming) Unpack(r *bufio.Reader) ([]byte, error) { return FixedFraming.Unpack(r) }
+
+// Unmarshal verifies the frame header, checks the schema of the frame with
+// the SchemaRegistry, and unpacks Message content. If the frame header
+// indicates a desync occurred, ErrDesyncDetected is returned. If the
+// SchemaRegistry could not be consulted, a *SchemaRegistryError is returned.
+//
+// It implements Framing.
+func (f *SchemaFraming) Unmarshal(b []byte, msg Message) error {
+	var p, ok = msg.(interface {
+		Unmarshal([]byte) error
+	})
+
+	if !ok {
+		return fmt.Errorf("%+v is not fixed-frameable (must implement Unmarshal)", msg)
+	} else if !matchesMagicWord(b) {
+		return ErrDesyncDetected
+	} else if len(b) < FixedFrameHeaderLength+SchemaEnvelopeHeaderLength || b[FixedFrameHeaderLength] != 0 {
+		return ErrInvalidSchemaEnvelope
+	}
+	var id = binary.BigEndian.Uint32(b[FixedFrameHeaderLength+1:])
+
+	if err := f.Registry.CheckSchema(id, msg); err != nil {
+		if c := errors.Cause(err); c == ErrUnknownSchema || c == ErrIncompatibleSchema {
+			return err
+		}
+		return &SchemaRegistryError{Err: err}
+	} else if err = p.Unmarshal(b[FixedFrameHeaderLength+SchemaEnvelopeHeaderLength:]); err != nil {
+		return err
+	} else if fx, ok := msg.(Fixupable); ok {
+		return fx.Fixup()
+	}
+	return nil
+}
+
+// SchemaRegistryError is returned by SchemaFraming.Unmarshal upon a failure
+// to consult its SchemaRegistry. Unlike other Unmarshal errors, it doesn't
+// indicate a problem with the frame itself, which may decode successfully
+// upon a later attempt.
+type SchemaRegistryError struct {
+	Err error
+}
+
+func (e *SchemaRegistryError) Error() string { return "schema registry: " + e.Err.Error() }
+
+var (
+	// ErrUnknownSchema is the cause of an error returned by a SchemaRegistry
+	// for a schema ID which isn't registered.
+	ErrUnknownSchema = errors.New("unknown schema")
+	// ErrIncompatibleSchema is the cause of an error returned by a
+	// SchemaRegistry for a schema ID which cannot be decoded into a Message.
+	ErrIncompatibleSchema = errors.New("incompatible schema")
+	// ErrInvalidSchemaEnvelope is returned by SchemaFraming.Unmarshal if the
+	// frame doesn't begin with a valid schema envelope header.
+	ErrInvalidSchemaEnvelope = errors.New("invalid schema envelope")
+)
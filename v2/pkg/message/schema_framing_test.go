@@ -0,0 +1,99 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+
+	"github.com/LiveRamp/gazette/v2/pkg/labels"
+	gc "github.com/go-check/check"
+	"github.com/pkg/errors"
+)
+
+type SchemaFramingSuite struct{}
+
+func (s *SchemaFramingSuite) TestMarshalAndUnmarshal(c *gc.C) {
+	var reg = &stubRegistry{id: 0x01020304}
+	var f = NewSchemaFraming(reg)
+
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	c.Check(f.Marshal(frameablestring("content"), bw), gc.IsNil)
+	_ = bw.Flush()
+	c.Check(buf.Bytes(), gc.DeepEquals, []byte{
+		0x66, 0x33, 0x93, 0x36, 0xc, 0x0, 0x0, 0x0, // Fixed frame header.
+		0x0, 0x1, 0x2, 0x3, 0x4, // Schema envelope header.
+		'c', 'o', 'n', 't', 'e', 'n', 't'})
+
+	var frame, err = f.Unpack(bufio.NewReader(&buf))
+	c.Check(err, gc.IsNil)
+
+	var msg frameablestring
+	c.Check(f.Unmarshal(frame, &msg), gc.IsNil)
+	c.Check(msg, gc.Equals, frameablestring("content"))
+	c.Check(reg.checked, gc.DeepEquals, []uint32{0x01020304})
+
+	// Case: schemas which are refused by the registry are returned as-is.
+	reg.checkErr = errors.WithMessage(ErrIncompatibleSchema, "id 1234")
+	c.Check(f.Unmarshal(frame, &msg), gc.ErrorMatches, "id 1234: incompatible schema")
+
+	// Case: other registry errors are wrapped as a SchemaRegistryError.
+	reg.checkErr = errors.New("connection refused")
+	err = f.Unmarshal(frame, &msg)
+	c.Check(err, gc.FitsTypeOf, &SchemaRegistryError{})
+	c.Check(err, gc.ErrorMatches, "schema registry: connection refused")
+
+	// Case: a frame without a valid envelope header.
+	frame[FixedFrameHeaderLength] = 0xff
+	c.Check(f.Unmarshal(frame, &msg), gc.Equals, ErrInvalidSchemaEnvelope)
+
+	// Case: a desync'd frame.
+	frame[0] = 0xff
+	c.Check(f.Unmarshal(frame, &msg), gc.Equals, ErrDesyncDetected)
+}
+
+func (s *SchemaFramingSuite) TestMarshalErrors(c *gc.C) {
+	var reg = &stubRegistry{idErr: errors.New("no such subject")}
+	var bw = bufio.NewWriter(new(bytes.Buffer))
+
+	c.Check(NewSchemaFraming(reg).Marshal(frameablestring("content"), bw),
+		gc.ErrorMatches, "resolving schema ID: no such subject")
+	c.Check(NewSchemaFraming(reg).Marshal(struct{}{}, bw),
+		gc.ErrorMatches, `.* is not fixed-frameable \(must implement ProtoSize and MarshalTo\)`)
+}
+
+func (s *SchemaFramingSuite) TestRegistration(c *gc.C) {
+	var _, err = FramingByContentType(labels.ContentType_SchemaEnvelope)
+	c.Check(err, gc.ErrorMatches, `unrecognized content-type \(application/x-gazette-schema-envelope\)`)
+
+	var f = NewSchemaFraming(new(stubRegistry))
+	RegisterFraming(f)
+	defer func() {
+		registeredFramingsMu.Lock()
+		delete(registeredFramings, labels.ContentType_SchemaEnvelope)
+		registeredFramingsMu.Unlock()
+	}()
+
+	out, err := FramingByContentType(labels.ContentType_SchemaEnvelope)
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.Equals, f)
+}
+
+type stubRegistry struct {
+	id       uint32
+	idErr    error
+	checkErr error
+	checked  []uint32
+}
+
+func (r *stubRegistry) SchemaID(Message) (uint32, error) { return r.id, r.idErr }
+
+func (r *stubRegistry) CheckSchema(id uint32, _ Message) error {
+	r.checked = append(r.checked, id)
+	return r.checkErr
+}
+
+var (
+	_ Framing = new(SchemaFraming)
+	_         = gc.Suite(&SchemaFramingSuite{})
+)
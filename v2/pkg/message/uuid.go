@@ -0,0 +1,88 @@
+package message
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// UUIDMessage is an optional Message interface of Messages which carry a
+// UUID within their encoding. Publish stamps a UUIDMessage having a zero-
+// valued UUID with a generated UUID, prior to its marshal. Producers may
+// instead supply a UUID (eg, one derived from an upstream record), such that
+// re-publishing the record yields a Message having the same UUID.
+type UUIDMessage interface {
+	// GetUUID returns the UUID of the Message, or uuid.Nil if it has none.
+	GetUUID() uuid.UUID
+	// SetUUID sets the UUID of the Message.
+	SetUUID(uuid.UUID)
+}
+
+// StampUUID sets a generated (V4) UUID of |msg|, if it's a UUIDMessage having
+// a zero-valued UUID. It returns the UUID of the Message, and whether the
+// Message is a UUIDMessage.
+func StampUUID(msg Message) (uuid.UUID, bool) {
+	var um, ok = msg.(UUIDMessage)
+	if !ok {
+		return uuid.Nil, false
+	}
+	var id = um.GetUUID()
+	if uuid.Equal(id, uuid.Nil) {
+		id = uuid.NewV4()
+		um.SetUUID(id)
+	}
+	return id, true
+}
+
+// Deduplicator drops Messages having UUIDs which were seen within a window of
+// the most recent distinct UUIDs. At-least-once pipelines may re-publish
+// messages upon a failure (eg, a consumer transaction which is retried after
+// a shard fault), and a Deduplicator allows a reader to drop such replayed
+// duplicates, so long as they occur within the window.
+//
+// Deduplicator is not safe for concurrent use. Its window is held only in
+// memory: a consumer which must drop duplicates across Shard recoveries should
+// additionally persist recent UUIDs within its Store.
+type Deduplicator struct {
+	seen map[uuid.UUID]struct{}
+	ring []uuid.UUID // Window of seen UUIDs, in order of observation.
+	next int         // Index of |ring| to be evicted & replaced by the next UUID.
+}
+
+// NewDeduplicator returns a Deduplicator having a window of |size| UUIDs.
+func NewDeduplicator(size int) *Deduplicator {
+	if size <= 0 {
+		panic("Deduplicator size must be positive")
+	}
+	return &Deduplicator{
+		seen: make(map[uuid.UUID]struct{}, size),
+		ring: make([]uuid.UUID, 0, size),
+	}
+}
+
+// IsDuplicate returns true if |msg| is a UUIDMessage having a UUID which was
+// seen within the window. Otherwise, the UUID of |msg| (if any) is added to
+// the window, evicting its oldest UUID if the window is full, and false is
+// returned. Messages which aren't UUIDMessages, or which have no UUID, are
+// never duplicates.
+func (d *Deduplicator) IsDuplicate(msg Message) bool {
+	var um, ok = msg.(UUIDMessage)
+	if !ok {
+		return false
+	}
+	var id = um.GetUUID()
+
+	if uuid.Equal(id, uuid.Nil) {
+		return false
+	} else if _, ok = d.seen[id]; ok {
+		return true
+	}
+
+	if len(d.ring) != cap(d.ring) {
+		d.ring = append(d.ring, id)
+	} else {
+		delete(d.seen, d.ring[d.next])
+		d.ring[d.next] = id
+		d.next = (d.next + 1) % len(d.ring)
+	}
+	d.seen[id] = struct{}{}
+	return false
+}
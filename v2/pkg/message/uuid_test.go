@@ -0,0 +1,67 @@
+package message
+
+import (
+	gc "github.com/go-check/check"
+	uuid "github.com/satori/go.uuid"
+)
+
+type UUIDSuite struct{}
+
+func (s *UUIDSuite) TestStamping(c *gc.C) {
+	// Messages which aren't UUIDMessages are not stamped.
+	var id, ok = StampUUID(struct{}{})
+	c.Check(ok, gc.Equals, false)
+	c.Check(id, gc.Equals, uuid.Nil)
+
+	// A UUIDMessage without a UUID is stamped with a generated UUID.
+	var msg uuidMessage
+	id, ok = StampUUID(&msg)
+	c.Check(ok, gc.Equals, true)
+	c.Check(id, gc.Not(gc.Equals), uuid.Nil)
+	c.Check(msg.ID, gc.Equals, id)
+
+	// A producer-supplied UUID is retained.
+	var supplied = uuid.NewV4()
+	msg.ID = supplied
+	id, ok = StampUUID(&msg)
+	c.Check(ok, gc.Equals, true)
+	c.Check(id, gc.Equals, supplied)
+	c.Check(msg.ID, gc.Equals, supplied)
+}
+
+func (s *UUIDSuite) TestDeduplicationWindow(c *gc.C) {
+	var d = NewDeduplicator(2)
+	var a, b, cc = uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+
+	c.Check(d.IsDuplicate(&uuidMessage{ID: a}), gc.Equals, false)
+	c.Check(d.IsDuplicate(&uuidMessage{ID: b}), gc.Equals, false)
+	c.Check(d.IsDuplicate(&uuidMessage{ID: a}), gc.Equals, true)
+	c.Check(d.IsDuplicate(&uuidMessage{ID: b}), gc.Equals, true)
+
+	// Observing |cc| evicts |a|, which is no longer detected as a duplicate.
+	c.Check(d.IsDuplicate(&uuidMessage{ID: cc}), gc.Equals, false)
+	c.Check(d.IsDuplicate(&uuidMessage{ID: cc}), gc.Equals, true)
+	c.Check(d.IsDuplicate(&uuidMessage{ID: b}), gc.Equals, true)
+	c.Check(d.IsDuplicate(&uuidMessage{ID: a}), gc.Equals, false) // Evicts |b|.
+	c.Check(d.IsDuplicate(&uuidMessage{ID: b}), gc.Equals, false) // Evicts |cc|.
+	c.Check(d.IsDuplicate(&uuidMessage{ID: a}), gc.Equals, true)
+
+	// Messages without UUIDs are never duplicates.
+	c.Check(d.IsDuplicate(&uuidMessage{}), gc.Equals, false)
+	c.Check(d.IsDuplicate(&uuidMessage{}), gc.Equals, false)
+	c.Check(d.IsDuplicate(struct{}{}), gc.Equals, false)
+
+	c.Check(func() { NewDeduplicator(0) }, gc.PanicMatches, "Deduplicator size must be positive")
+}
+
+type uuidMessage struct {
+	ID uuid.UUID
+}
+
+func (m *uuidMessage) GetUUID() uuid.UUID   { return m.ID }
+func (m *uuidMessage) SetUUID(id uuid.UUID) { m.ID = id }
+
+var (
+	_ UUIDMessage = new(uuidMessage)
+	_             = gc.Suite(&UUIDSuite{})
+)
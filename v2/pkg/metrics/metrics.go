@@ -16,6 +16,10 @@ const (
 	AllocatorMembersKey                 = "gazette_allocator_members"
 	AllocatorItemsKey                   = "gazette_allocator_items"
 	AllocatorDesiredReplicationSlotsKey = "gazette_allocator_desired_replication_slots"
+	AllocatorChurnLimitedTotalKey       = "gazette_allocator_churn_limited_total"
+	AllocatorItemsOutOfReplicationKey   = "gazette_allocator_items_out_of_replication"
+	AllocatorPendingChangesKey          = "gazette_allocator_pending_changes"
+	AllocatorSolveSecondsKey            = "gazette_allocator_solve_seconds"
 	JournalServerResponseTimeSecondsKey = "gazette_journal_server_response_time_seconds"
 
 	Fail = "fail"
@@ -60,6 +64,22 @@ var (
 		Name: AllocatorDesiredReplicationSlotsKey,
 		Help: "Number of desired replicaiton slots summed across all items.",
 	})
+	AllocatorChurnLimitedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: AllocatorChurnLimitedTotalKey,
+		Help: "Cumulative number of allocator iterations deferred by churn limits.",
+	}, []string{"reason"})
+	AllocatorItemsOutOfReplication = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: AllocatorItemsOutOfReplicationKey,
+		Help: "Number of items having more or fewer assignments than their desired replication.",
+	})
+	AllocatorPendingChanges = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: AllocatorPendingChangesKey,
+		Help: "Number of assignment additions and removals required to reach the desired allocation.",
+	})
+	AllocatorSolveSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: AllocatorSolveSecondsKey,
+		Help: "Duration of solving for a maximum assignment of items to members.",
+	})
 	JournalServerResponseTimeSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Name: JournalServerResponseTimeSecondsKey,
 		Help: "Response time of JournalServer.Append.",
@@ -78,10 +98,117 @@ func GazetteBrokerCollectors() []prometheus.Collector {
 		AllocatorMembers,
 		AllocatorItems,
 		AllocatorDesiredReplicationSlots,
+		AllocatorChurnLimitedTotal,
+		AllocatorItemsOutOfReplication,
+		AllocatorPendingChanges,
+		AllocatorSolveSeconds,
 		JournalServerResponseTimeSeconds,
 	}
 }
 
+// Keys for http_gateway.Gateway metrics.
+const (
+	HTTPGatewayRequestsTotalKey      = "gazette_http_gateway_requests_total"
+	HTTPGatewayRequestSecondsKey     = "gazette_http_gateway_request_seconds"
+	HTTPGatewayReceivedBytesTotalKey = "gazette_http_gateway_received_bytes_total"
+	HTTPGatewaySentBytesTotalKey     = "gazette_http_gateway_sent_bytes_total"
+)
+
+// Collectors for http_gateway.Gateway metrics. Each is labeled with the
+// endpoint of the request (eg, "read" or "append") and its journal, if any.
+var (
+	HTTPGatewayRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: HTTPGatewayRequestsTotalKey,
+		Help: "Cumulative number of completed HTTP gateway requests.",
+	}, []string{"endpoint", "journal", "code"})
+	HTTPGatewayRequestSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: HTTPGatewayRequestSecondsKey,
+		Help: "Duration of HTTP gateway requests.",
+	}, []string{"endpoint", "journal"})
+	HTTPGatewayReceivedBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: HTTPGatewayReceivedBytesTotalKey,
+		Help: "Cumulative number of request body bytes received by the HTTP gateway.",
+	}, []string{"endpoint", "journal"})
+	HTTPGatewaySentBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: HTTPGatewaySentBytesTotalKey,
+		Help: "Cumulative number of response body bytes sent by the HTTP gateway.",
+	}, []string{"endpoint", "journal"})
+)
+
+// HTTPGatewayCollectors returns the metrics used by the http_gateway package.
+func HTTPGatewayCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		HTTPGatewayRequestsTotal,
+		HTTPGatewayRequestSeconds,
+		HTTPGatewayReceivedBytesTotal,
+		HTTPGatewaySentBytesTotal,
+	}
+}
+
+// Keys for keyspace.KeySpace metrics.
+const (
+	KeySpaceApplySecondsKey        = "gazette_keyspace_apply_seconds"
+	KeySpaceApplyEventsKey         = "gazette_keyspace_apply_events"
+	KeySpaceWatchRestartsTotalKey  = "gazette_keyspace_watch_restarts_total"
+	KeySpaceDecodeFailuresTotalKey = "gazette_keyspace_decode_failures_total"
+	KeySpaceKeysKey                = "gazette_keyspace_keys"
+	KeySpaceRevisionLagKey         = "gazette_keyspace_revision_lag"
+	KeySpaceVerificationsTotalKey  = "gazette_keyspace_verifications_total"
+	KeySpaceDivergencesTotalKey    = "gazette_keyspace_divergences_total"
+)
+
+// Collectors for keyspace.KeySpace metrics. Each is labeled with the
+// KeySpace prefix.
+var (
+	KeySpaceApplySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: KeySpaceApplySecondsKey,
+		Help: "Duration of applying Etcd WatchResponses to a KeySpace.",
+	}, []string{"prefix"})
+	KeySpaceApplyEvents = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    KeySpaceApplyEventsKey,
+		Help:    "Number of Etcd events applied to a KeySpace by each apply.",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{"prefix"})
+	KeySpaceWatchRestartsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: KeySpaceWatchRestartsTotalKey,
+		Help: "Cumulative number of KeySpace Watches restarted after a prior Watch.",
+	}, []string{"prefix"})
+	KeySpaceDecodeFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: KeySpaceDecodeFailuresTotalKey,
+		Help: "Cumulative number of key/values which failed to decode, or were inconsistent.",
+	}, []string{"prefix"})
+	KeySpaceKeys = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: KeySpaceKeysKey,
+		Help: "Number of decoded keys of a KeySpace.",
+	}, []string{"prefix"})
+	KeySpaceRevisionLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: KeySpaceRevisionLagKey,
+		Help: "Etcd revision most recently watched, less the revision applied to a KeySpace.",
+	}, []string{"prefix"})
+	KeySpaceVerificationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: KeySpaceVerificationsTotalKey,
+		Help: "Cumulative number of completed verifications of a KeySpace against Etcd.",
+	}, []string{"prefix"})
+	KeySpaceDivergencesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: KeySpaceDivergencesTotalKey,
+		Help: "Cumulative number of keys found by verification to diverge from Etcd.",
+	}, []string{"prefix"})
+)
+
+// KeySpaceCollectors returns the metrics used by the keyspace package.
+func KeySpaceCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		KeySpaceApplySeconds,
+		KeySpaceApplyEvents,
+		KeySpaceWatchRestartsTotal,
+		KeySpaceDecodeFailuresTotal,
+		KeySpaceKeys,
+		KeySpaceRevisionLag,
+		KeySpaceVerificationsTotal,
+		KeySpaceDivergencesTotal,
+	}
+}
+
 // Keys for gazconsumer metrics.
 const (
 	GazconsumerLagBytesKey = "gazconsumer_lag_bytes"
@@ -198,6 +325,16 @@ const (
 	GazetteConsumerTxFlushSecondsTotalKey   = "gazette_consumer_tx_flush_seconds_total"
 	GazetteConsumerTxSyncSecondsTotalKey    = "gazette_consumer_tx_sync_seconds_total"
 	GazetteConsumerConsumedBytesTotalKey    = "gazette_consumer_consumed_bytes_total"
+	GazetteConsumerDeadLettersTotalKey      = "gazette_consumer_dead_letters_total"
+)
+
+// Keys for per-shard consumer.Runner metrics.
+const (
+	GazetteConsumerMessageConsumeSecondsKey = "gazette_consumer_message_consume_seconds"
+	GazetteConsumerTxDurationSecondsKey     = "gazette_consumer_tx_duration_seconds"
+	GazetteConsumerTxMessagesKey            = "gazette_consumer_tx_messages"
+	GazetteConsumerTxBytesKey               = "gazette_consumer_tx_bytes"
+	GazetteConsumerRecoverySecondsKey       = "gazette_consumer_recovery_seconds"
 )
 
 // Collectors for consumer.Runner metrics.
@@ -234,6 +371,40 @@ var (
 		Name: GazetteConsumerConsumedBytesTotalKey,
 		Help: "Cumulative number of bytes consumed.",
 	})
+	GazetteConsumerDeadLettersTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: GazetteConsumerDeadLettersTotalKey,
+		Help: "Cumulative number of messages appended to a dead-letter journal.",
+	})
+)
+
+// Collectors for per-shard consumer.Runner metrics. Each is labeled with the
+// shard ID.
+var (
+	GazetteConsumerMessageConsumeSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    GazetteConsumerMessageConsumeSecondsKey,
+		Help:    "Duration of consuming a message by the Application.",
+		Buckets: prometheus.ExponentialBuckets(0.00001, 4, 10),
+	}, []string{"shard"})
+	GazetteConsumerTxDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    GazetteConsumerTxDurationSecondsKey,
+		Help:    "Duration of transactions, from their first message through their commit sync.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"shard"})
+	GazetteConsumerTxMessages = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    GazetteConsumerTxMessagesKey,
+		Help:    "Number of messages of transactions.",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"shard"})
+	GazetteConsumerTxBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    GazetteConsumerTxBytesKey,
+		Help:    "Approximate number of source journal bytes of transactions.",
+		Buckets: prometheus.ExponentialBuckets(256, 4, 10),
+	}, []string{"shard"})
+	GazetteConsumerRecoverySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    GazetteConsumerRecoverySecondsKey,
+		Help:    "Duration of recovery log playback, from assignment as primary until the store is ready.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 4, 8),
+	}, []string{"shard"})
 )
 
 // GazetteConsumerCollectors returns the metrics used by the consumer package.
@@ -246,5 +417,11 @@ func GazetteConsumerCollectors() []prometheus.Collector {
 		GazetteConsumerTxStalledSecondsTotal,
 		GazetteConsumerTxFlushSecondsTotal,
 		GazetteConsumerBytesConsumedTotal,
+		GazetteConsumerDeadLettersTotal,
+		GazetteConsumerMessageConsumeSeconds,
+		GazetteConsumerTxDurationSeconds,
+		GazetteConsumerTxMessages,
+		GazetteConsumerTxBytes,
+		GazetteConsumerRecoverySeconds,
 	}
 }
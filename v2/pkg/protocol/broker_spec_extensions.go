@@ -25,6 +25,8 @@ func (m *ProcessSpec) Validate() error {
 		return ExtendContext(err, "Id")
 	} else if err = m.Endpoint.Validate(); err != nil {
 		return ExtendContext(err, "Endpoint")
+	} else if err = m.Labels.Validate(); err != nil {
+		return ExtendContext(err, "Labels")
 	}
 	return nil
 }
@@ -36,6 +38,9 @@ func (m *BrokerSpec) Validate() error {
 	} else if m.JournalLimit > maxBrokerJournalLimit {
 		return NewValidationError("invalid JournalLimit (%d; expected 0 <= JournalLimit <= %d)",
 			m.JournalLimit, maxBrokerJournalLimit)
+	} else if m.CapacityWeight > maxBrokerCapacityWeight {
+		return NewValidationError("invalid CapacityWeight (%d; expected 0 <= CapacityWeight <= %d)",
+			m.CapacityWeight, maxBrokerCapacityWeight)
 	}
 	return nil
 }
@@ -55,10 +60,21 @@ func (m *BrokerSpec) ZeroLimit() { m.JournalLimit = 0 }
 // v3_allocator.MemberValue implementation.
 func (m *BrokerSpec) ItemLimit() int { return int(m.JournalLimit) }
 
+// v3_allocator.WeightedMemberValue implementation.
+func (m *BrokerSpec) ItemWeight() int { return int(m.CapacityWeight) }
+
+// MarkDraining marks the BrokerSpec as draining. v3_allocator.DrainableSpec implementation.
+func (m *BrokerSpec) MarkDraining() { m.Draining = true }
+
+// v3_allocator.DrainingMemberValue implementation.
+func (m *BrokerSpec) IsDraining() bool { return m.Draining }
+
 const (
 	minZoneLen            = 1
-	maxZoneLen            = 16
+	maxZoneLen            = 64
 	minBrokerSuffixLen    = 4
 	maxBrokerSuffixLen    = 128
 	maxBrokerJournalLimit = 1 << 17
+	// Bound weights such that weighted JournalLimits cannot overflow.
+	maxBrokerCapacityWeight = 1 << 10
 )
@@ -11,10 +11,11 @@ func (s *BrokerSpecSuite) TestIDValidationCases(c *gc.C) {
 		id     ProcessSpec_ID
 		expect string
 	}{
-		{ProcessSpec_ID{Zone: "a-zone", Suffix: "a-name"}, ""}, // Success.
+		{ProcessSpec_ID{Zone: "a-zone", Suffix: "a-name"}, ""},              // Success.
+		{ProcessSpec_ID{Zone: "us-east/az-1/rack-7", Suffix: "a-name"}, ""}, // Hierarchical zone.
 		{ProcessSpec_ID{Zone: "", Suffix: "a-name"}, "Zone: invalid length .*"},
 		{ProcessSpec_ID{Zone: "&*", Suffix: "a-name"}, `Zone: not a valid token .*`},
-		{ProcessSpec_ID{Zone: "a-very-very-very-very-looong-zone", Suffix: "a-name"}, "Zone: invalid length .*"},
+		{ProcessSpec_ID{Zone: "a-very-very-very-very-very-very-very-very-very-very-very-looong-zone", Suffix: "a-name"}, "Zone: invalid length .*"},
 		{ProcessSpec_ID{Zone: "a-zone", Suffix: "ae"}, "Suffix: invalid length .*"},
 		{ProcessSpec_ID{Zone: "a-zone", Suffix: "&*($"}, "Suffix: not a valid token .*"},
 	}
@@ -33,10 +34,23 @@ func (s *BrokerSpecSuite) TestSpecValidationCases(c *gc.C) {
 			Id:       ProcessSpec_ID{Zone: "a-zone", Suffix: "a-name"},
 			Endpoint: "http://foo",
 		},
-		JournalLimit: 5,
+		JournalLimit:   5,
+		CapacityWeight: 4,
 	}
 	c.Check(model.Validate(), gc.Equals, nil)
 	c.Check(model.ItemLimit(), gc.Equals, 5)
+	c.Check(model.ItemWeight(), gc.Equals, 4)
+	c.Check(model.IsDraining(), gc.Equals, false)
+
+	model.MarkDraining()
+	c.Check(model.IsDraining(), gc.Equals, true)
+	model.Draining = false
+
+	model.Labels = MustLabelSet("tier", "ssd")
+	c.Check(model.Validate(), gc.Equals, nil)
+	model.Labels.Labels[0].Value = "a|value"
+	c.Check(model.Validate(), gc.ErrorMatches, `Labels.Labels\[0\].Value: not a valid token \(a|value\)`)
+	model.Labels = LabelSet{}
 
 	model.Id.Zone = ""
 	c.Check(model.Validate(), gc.ErrorMatches, "Id.Zone: invalid length .*")
@@ -48,6 +62,10 @@ func (s *BrokerSpecSuite) TestSpecValidationCases(c *gc.C) {
 	model.Endpoint = "http://foo"
 	model.JournalLimit = maxBrokerJournalLimit + 1
 	c.Check(model.Validate(), gc.ErrorMatches, `invalid JournalLimit \(\d+; expected 0 <= JournalLimit <= \d+\)`)
+
+	model.JournalLimit = 5
+	model.CapacityWeight = maxBrokerCapacityWeight + 1
+	c.Check(model.Validate(), gc.ErrorMatches, `invalid CapacityWeight \(\d+; expected 0 <= CapacityWeight <= \d+\)`)
 }
 
 var _ = gc.Suite(&BrokerSpecSuite{})
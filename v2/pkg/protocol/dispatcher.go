@@ -5,7 +5,6 @@ import (
 	"sync"
 	"time"
 
-	"golang.org/x/net/trace"
 	"google.golang.org/grpc/balancer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
@@ -151,10 +150,9 @@ func (d *dispatcher) Pick(ctx context.Context, opts balancer.PickOptions) (balan
 
 	var state = d.connState[msc.subConn]
 
-	if tr, ok := trace.FromContext(ctx); ok {
-		tr.LazyPrintf("Pick(Route: %s, ID: %s) => %s (%s)",
-			&dr.route, &dr.id, &dispatchID, state)
-	}
+	AddTrace(ctx, "Pick(Route: %s, ID: %s) => %s (%s)",
+		&dr.route, &dr.id, &dispatchID, state)
+
 	switch state {
 	case connectivity.Idle, connectivity.Connecting:
 		// gRPC will block until connection becomes ready.
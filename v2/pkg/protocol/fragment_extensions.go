@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -11,9 +12,19 @@ import (
 )
 
 // ContentName returns the content-addressed base file name of this Fragment.
+// The Fragment is named by its SHA256 sum if it has one, and by its SHA1
+// sum otherwise.
 func (m *Fragment) ContentName() string {
+	var digest []byte
+	if !m.Sum256.IsZero() {
+		var d = m.Sum256.ToDigest()
+		digest = d[:]
+	} else {
+		var d = m.Sum.ToDigest()
+		digest = d[:]
+	}
 	return fmt.Sprintf("%016x-%016x-%x%s", m.Begin, m.End,
-		m.Sum.ToDigest(), m.CompressionCodec.ToExtension())
+		digest, m.CompressionCodec.ToExtension())
 }
 
 // ContentPath returns the content-addressed path of this Fragment.
@@ -31,6 +42,8 @@ func (m *Fragment) Validate() error {
 		return NewValidationError("expected Begin <= End (have %d, %d)", m.Begin, m.End)
 	} else if err = m.CompressionCodec.Validate(); err != nil {
 		return ExtendContext(err, "CompressionCodec")
+	} else if !m.Sum.IsZero() && !m.Sum256.IsZero() {
+		return NewValidationError("expected only one of Sum or Sum256 to be set")
 	}
 	return nil
 }
@@ -55,8 +68,8 @@ func ParseContentName(journal Journal, name string) (Fragment, error) {
 		return Fragment{}, ExtendContext(&ValidationError{Err: err}, "End")
 	} else if sum, err := hex.DecodeString(fields[2]); err != nil {
 		return Fragment{}, ExtendContext(&ValidationError{Err: err}, "Sum")
-	} else if len(sum) != sha1.Size {
-		return Fragment{}, NewValidationError("invalid SHA1Sum length: %x", sum)
+	} else if len(sum) != sha1.Size && len(sum) != sha256.Size {
+		return Fragment{}, NewValidationError("invalid SHA1Sum or SHA256Sum length: %x", sum)
 	} else if cc, err := CompressionCodecFromExtension(ext); err != nil {
 		return Fragment{}, err
 	} else {
@@ -64,9 +77,14 @@ func ParseContentName(journal Journal, name string) (Fragment, error) {
 			Journal:          journal,
 			Begin:            begin,
 			End:              end,
-			Sum:              SHA1SumFromDigest(sum),
 			CompressionCodec: cc,
 		}
+		// The sum algorithm is implied by the digest length.
+		if len(sum) == sha256.Size {
+			f.Sum256 = SHA256SumFromDigest(sum)
+		} else {
+			f.Sum = SHA1SumFromDigest(sum)
+		}
 	}
 	return f, f.Validate()
 }
@@ -103,6 +121,39 @@ func (m SHA1Sum) ToDigest() (r [20]byte) {
 // (rather than SHA1 of "", which is da39a3ee5e6b4b0d3255bfef95601890afd80709).
 func (m SHA1Sum) IsZero() bool { return m == (SHA1Sum{}) }
 
+// SHA256SumFromDigest converts SHA256 sum in digest form into a SHA256Sum.
+// |r| must have the length of a SHA256 digest (32 bytes), or it panics.
+func SHA256SumFromDigest(r []byte) SHA256Sum {
+	if len(r) != 32 {
+		panic("invalid slice length")
+	}
+	var m SHA256Sum
+	m.Part1 = binary.BigEndian.Uint64(r[0:8])
+	m.Part2 = binary.BigEndian.Uint64(r[8:16])
+	m.Part3 = binary.BigEndian.Uint64(r[16:24])
+	m.Part4 = binary.BigEndian.Uint64(r[24:32])
+	return m
+}
+
+// SHA256SumOf SHA256 sums |str| and returns a SHA256Sum.
+func SHA256SumOf(str string) SHA256Sum {
+	var r = sha256.Sum256([]byte(str))
+	return SHA256SumFromDigest(r[:])
+}
+
+// ToDigest converts the SHA256Sum to a flat, fixed-size array.
+func (m SHA256Sum) ToDigest() (r [32]byte) {
+	binary.BigEndian.PutUint64(r[0:8], m.Part1)
+	binary.BigEndian.PutUint64(r[8:16], m.Part2)
+	binary.BigEndian.PutUint64(r[16:24], m.Part3)
+	binary.BigEndian.PutUint64(r[24:32], m.Part4)
+	return
+}
+
+// IsZero returns whether this SHA256Sum is zero-valued. As with SHA1Sum,
+// Fragments having no content are mapped to the zero-valued SHA256Sum.
+func (m SHA256Sum) IsZero() bool { return m == (SHA256Sum{}) }
+
 // CompressionCodecFromExtension matches a file extension to its corresponding CompressionCodec.
 func CompressionCodecFromExtension(ext string) (CompressionCodec, error) {
 	switch strings.ToLower(ext) {
@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"crypto/sha256"
 	"math"
 	"testing"
 
@@ -27,6 +28,12 @@ func (s *FragmentSuite) TestContentName(c *gc.C) {
 	f.CompressionCodec = CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION
 	c.Check(f.ContentName(), gc.Equals,
 		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314")
+
+	// A Fragment having a SHA256 sum is named by it.
+	f.Sum, f.Sum256 = SHA1Sum{}, sha256Fixture
+	f.CompressionCodec = CompressionCodec_GZIP
+	c.Check(f.ContentName(), gc.Equals,
+		"00000000499602d2-7fffffffffffffff-"+sha256FixtureHex+".gz")
 }
 
 func (s *FragmentSuite) TestContentPath(c *gc.C) {
@@ -61,6 +68,12 @@ func (s *FragmentSuite) TestValidationCases(c *gc.C) {
 	f.Journal = "foo/bar/baz"
 	f.CompressionCodec = 1 << 20
 	c.Check(f.Validate(), gc.ErrorMatches, "CompressionCodec: invalid value .*")
+	f.CompressionCodec = CompressionCodec_GZIP
+
+	f.Sum256 = sha256Fixture
+	c.Check(f.Validate(), gc.ErrorMatches, "expected only one of Sum or Sum256 to be set")
+	f.Sum = SHA1Sum{}
+	c.Check(f.Validate(), gc.IsNil)
 }
 
 func (s *FragmentSuite) TestParsingSuccessCases(c *gc.C) {
@@ -100,6 +113,21 @@ func (s *FragmentSuite) TestParsingSuccessCases(c *gc.C) {
 		Sum:              SHA1Sum{},
 		CompressionCodec: CompressionCodec_NONE,
 	})
+
+	// A SHA256 sum is inferred from the digest length.
+	f, err = ParseContentPath("a/journal/" +
+		"00000000499602d2-7fffffffffffffff-" + sha256FixtureHex + ".gz")
+
+	c.Check(err, gc.IsNil)
+	c.Check(f, gc.DeepEquals, Fragment{
+		Journal:          "a/journal",
+		Begin:            1234567890,
+		End:              math.MaxInt64,
+		Sum256:           sha256Fixture,
+		CompressionCodec: CompressionCodec_GZIP,
+	})
+	c.Check(f.ContentName(), gc.Equals,
+		"00000000499602d2-7fffffffffffffff-"+sha256FixtureHex+".gz")
 }
 
 func (s *FragmentSuite) TestParsingErrorCases(c *gc.C) {
@@ -121,7 +149,7 @@ func (s *FragmentSuite) TestParsingErrorCases(c *gc.C) {
 
 	_, err = ParseContentPath("a/journal/" +
 		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f10111213.gz")
-	c.Check(err, gc.ErrorMatches, "invalid SHA1Sum length: .*")
+	c.Check(err, gc.ErrorMatches, "invalid SHA1Sum or SHA256Sum length: .*")
 
 	_, err = ParseContentPath("a/journal/" +
 		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314.XXX")
@@ -133,6 +161,35 @@ func (s *FragmentSuite) TestParsingErrorCases(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "expected Begin <= End .*")
 }
 
+func (s *FragmentSuite) TestSHA256SumRoundTrip(c *gc.C) {
+	var sum = SHA256SumOf("content")
+	var digest = sha256.Sum256([]byte("content"))
+
+	c.Check(sum.ToDigest(), gc.Equals, digest)
+	c.Check(SHA256SumFromDigest(digest[:]), gc.Equals, sum)
+	c.Check(sum.IsZero(), gc.Equals, false)
+	c.Check(SHA256Sum{}.IsZero(), gc.Equals, true)
+
+	// Expect Sum256 round-trips through the Fragment's wire encoding.
+	var f = Fragment{Journal: "a/journal", Begin: 12, End: 34, Sum256: sum, CompressionCodec: CompressionCodec_NONE}
+	var b, err = f.Marshal()
+	c.Assert(err, gc.IsNil)
+
+	var out Fragment
+	c.Check(out.Unmarshal(b), gc.IsNil)
+	c.Check(out, gc.DeepEquals, f)
+}
+
+var (
+	sha256Fixture = SHA256Sum{
+		Part1: 0x0102030405060708,
+		Part2: 0x090a0b0c0d0e0f10,
+		Part3: 0x1112131415161718,
+		Part4: 0x191a1b1c1d1e1f20,
+	}
+	sha256FixtureHex = "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+)
+
 var _ = gc.Suite(&FragmentSuite{})
 
 func Test(t *testing.T) { gc.TestingT(t) }
@@ -58,5 +58,44 @@ func NewUnroutedHeader(s *allocator.State) (hdr Header) {
 	}
 	hdr.Route = Route{Primary: -1}
 	hdr.Etcd = FromEtcdResponseHeader(s.KS.Header)
+	hdr.BuildVersion, hdr.Capabilities = BuildVersion, LocalCapabilities
 	return
 }
+
+// Supports returns true if the Header was authored by a process supporting all
+// Capabilities of |c|. Clients may use Supports to negotiate optional features
+// with brokers (or consumers) of differing versions.
+func (m Header) Supports(c Capability) bool { return m.Capabilities&c == c }
+
+// Capability is a bit-flag of an optional protocol feature supported by a
+// process. Capabilities are or'd into the Header of each response the
+// process authors.
+type Capability uint64
+
+const (
+	// Capability_APPEND_CONTENT_TYPE indicates that Append RPCs verify the
+	// AppendRequest ContentType against the journal's content-type label.
+	Capability_APPEND_CONTENT_TYPE Capability = 1 << iota
+	// Capability_SELECTOR_REGEXP indicates that LabelSelectors support
+	// IncludeRegexp and ExcludeRegexp.
+	Capability_SELECTOR_REGEXP
+	// Capability_JOURNAL_ANNOTATIONS indicates that JournalSpec Annotations
+	// are persisted and returned by List.
+	Capability_JOURNAL_ANNOTATIONS
+	// Capability_APPEND_CONTINUATION indicates that Append RPCs may be
+	// suspended, and resumed by a following Append RPC.
+	Capability_APPEND_CONTINUATION
+)
+
+var (
+	// BuildVersion is the semantic version of this build, which is attached to
+	// Headers authored by this process. It's intended to be set at link time:
+	//   go build -ldflags "-X github.com/LiveRamp/gazette/v2/pkg/protocol.BuildVersion=v2.1.0"
+	BuildVersion = "development"
+	// LocalCapabilities are the Capabilities of this build, which are
+	// attached to Headers authored by this process.
+	LocalCapabilities = Capability_APPEND_CONTENT_TYPE |
+		Capability_SELECTOR_REGEXP |
+		Capability_JOURNAL_ANNOTATIONS |
+		Capability_APPEND_CONTINUATION
+)
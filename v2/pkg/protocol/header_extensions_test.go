@@ -86,10 +86,33 @@ func (s *HeaderSuite) TestUnroutedHeader(c *gc.C) {
 		},
 	}
 	c.Check(NewUnroutedHeader(fixture), gc.DeepEquals, Header{
-		ProcessId: ProcessSpec_ID{Zone: "zone", Suffix: "suffix"},
-		Route:     Route{Primary: -1},
-		Etcd:      FromEtcdResponseHeader(etcd),
+		ProcessId:    ProcessSpec_ID{Zone: "zone", Suffix: "suffix"},
+		Route:        Route{Primary: -1},
+		Etcd:         FromEtcdResponseHeader(etcd),
+		BuildVersion: BuildVersion,
+		Capabilities: LocalCapabilities,
 	})
 }
 
+func (s *HeaderSuite) TestCapabilities(c *gc.C) {
+	var hdr = Header{Capabilities: Capability_APPEND_CONTENT_TYPE | Capability_JOURNAL_ANNOTATIONS}
+
+	c.Check(hdr.Supports(Capability_APPEND_CONTENT_TYPE), gc.Equals, true)
+	c.Check(hdr.Supports(Capability_SELECTOR_REGEXP), gc.Equals, false)
+	c.Check(hdr.Supports(Capability_APPEND_CONTENT_TYPE|Capability_JOURNAL_ANNOTATIONS), gc.Equals, true)
+	c.Check(hdr.Supports(Capability_APPEND_CONTENT_TYPE|Capability_SELECTOR_REGEXP), gc.Equals, false)
+
+	// Headers of processes which pre-date Capabilities support none of them.
+	c.Check(Header{}.Supports(Capability_APPEND_CONTENT_TYPE), gc.Equals, false)
+
+	// Round-trip through the wire encoding.
+	hdr.BuildVersion = "v1.2.3"
+	var b, err = hdr.Marshal()
+	c.Assert(err, gc.IsNil)
+
+	var out Header
+	c.Check(out.Unmarshal(b), gc.IsNil)
+	c.Check(out, gc.DeepEquals, hdr)
+}
+
 var _ = gc.Suite(&HeaderSuite{})
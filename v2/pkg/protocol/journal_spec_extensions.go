@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"mime"
 	"path"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
@@ -55,10 +57,46 @@ func (m *JournalSpec) Validate() error {
 		return ExtendContext(err, "Fragment")
 	} else if err = m.Flags.Validate(); err != nil {
 		return ExtendContext(err, "Flags")
+	} else if int(m.MinZones) > int(m.Replication) {
+		return NewValidationError("invalid MinZones (%d; expected MinZones <= Replication %d)",
+			m.MinZones, m.Replication)
+	} else if err = m.Placement.Validate(); err != nil {
+		return ExtendContext(err, "Placement")
+	} else if err = validateAnnotations(m.Annotations); err != nil {
+		return err
+	}
+	// MaxReplicasPerZone requires no extra validation.
+
+	journalSpecValidatorsMu.Lock()
+	var validators = journalSpecValidators
+	journalSpecValidatorsMu.Unlock()
+
+	for _, fn := range validators {
+		if err := fn(m); err != nil {
+			return AsValidationError(err)
+		}
 	}
 	return nil
 }
 
+// RegisterJournalSpecValidator registers |fn| as an additional validation of
+// JournalSpecs, which is run by JournalSpec.Validate after all built-in checks
+// have passed. It allows an organization to enforce policies such as journal
+// naming conventions, mandatory labels, or whitelisted fragment stores within
+// any binary (brokers, gazctl, or applications) which registers it.
+//
+// Note that brokers also Validate JournalSpecs decoded from Etcd, and ignore
+// specs which fail. A validator should be registered by every broker of the
+// cluster, or by none of them, and care must be taken when registering one
+// which existing JournalSpecs may not satisfy. RegisterJournalSpecValidator
+// should be called as part of application initialization.
+func RegisterJournalSpecValidator(fn func(*JournalSpec) error) {
+	journalSpecValidatorsMu.Lock()
+	// Copy-on-write, so that in-flight Validate calls retain their snapshot.
+	journalSpecValidators = append(journalSpecValidators[:len(journalSpecValidators):len(journalSpecValidators)], fn)
+	journalSpecValidatorsMu.Unlock()
+}
+
 // Validate returns an error if the JournalSpec_Fragment is not well-formed.
 func (m *JournalSpec_Fragment) Validate() error {
 	if m.Length < minFragmentLen || m.Length > maxFragmentLen {
@@ -165,6 +203,22 @@ func (m *JournalSpec) MarshalString() string {
 // implements allocator.ItemValue.
 func (m *JournalSpec) DesiredReplication() int { return int(m.Replication) }
 
+// ZoneConstraints returns the configured MinZones and MaxReplicasPerZone of
+// the spec. It implements allocator.ZoneConstrainedItemValue.
+func (m *JournalSpec) ZoneConstraints() (minZones, maxPerZone int) {
+	return int(m.MinZones), int(m.MaxReplicasPerZone)
+}
+
+// IsPlaceable returns true if the JournalSpec Placement matches the Labels
+// of the |member| BrokerSpec. A JournalSpec having an empty Placement may be
+// placed on any broker. It implements allocator.PlacedItemValue.
+func (m *JournalSpec) IsPlaceable(member allocator.MemberValue) bool {
+	if m.Placement.IsEmpty() {
+		return true
+	}
+	return m.Placement.Matches(member.(*BrokerSpec).Labels)
+}
+
 // IsConsistent returns true if the Route stored under each of |assignments|
 // agrees with the Route implied by the |assignments| keys. It implements
 // allocator.ItemValue.
@@ -180,6 +234,71 @@ func (m *JournalSpec) IsConsistent(_ keyspace.KeyValue, assignments keyspace.Key
 	return true
 }
 
+// ReplicationOverride is a cluster-level policy which requires that each
+// JournalSpec matched by its Selector have a Replication of at least
+// MinReplication. The Selector is matched against JournalSpec Labels, as well
+// as its meta-labels (see ExtractJournalSpecMetaLabels).
+type ReplicationOverride struct {
+	Selector       LabelSelector
+	MinReplication int32
+}
+
+// ParseReplicationOverride parses a ReplicationOverride from its string form
+// "MinReplication:Selector", eg "3:tier=critical,prefix=orders/".
+func ParseReplicationOverride(s string) (ReplicationOverride, error) {
+	var out ReplicationOverride
+	var ind = strings.IndexByte(s, ':')
+
+	if ind == -1 {
+		return out, NewValidationError("expected MinReplication:Selector (%s)", s)
+	} else if n, err := strconv.ParseInt(s[:ind], 10, 32); err != nil {
+		return out, NewValidationError("parsing MinReplication (%s): %s", s[:ind], err)
+	} else {
+		out.MinReplication = int32(n)
+	}
+
+	var err error
+	if out.Selector, err = ParseLabelSelector(s[ind+1:]); err != nil {
+		return out, NewValidationError("parsing Selector (%s): %s", s[ind+1:], err)
+	}
+	return out, out.Validate()
+}
+
+// Validate returns an error if the ReplicationOverride is not well-formed.
+func (m ReplicationOverride) Validate() error {
+	if err := m.Selector.Validate(); err != nil {
+		return ExtendContext(err, "Selector")
+	} else if m.MinReplication < 1 || m.MinReplication > maxJournalReplication {
+		return NewValidationError("invalid MinReplication (%d; expected 1 <= MinReplication <= %d)",
+			m.MinReplication, maxJournalReplication)
+	}
+	return nil
+}
+
+// String returns the ReplicationOverride in its parse-able string form.
+func (m ReplicationOverride) String() string {
+	return fmt.Sprintf("%d:%s", m.MinReplication, m.Selector.String())
+}
+
+// OverriddenReplication returns the larger of the JournalSpec Replication and
+// the largest MinReplication of |overrides| having a Selector which matches
+// the JournalSpec. The JournalSpec itself is not modified.
+func OverriddenReplication(spec *JournalSpec, overrides []ReplicationOverride) int32 {
+	var out = spec.Replication
+	if len(overrides) == 0 {
+		return out
+	}
+	var labels = ExtractJournalSpecMetaLabels(spec, LabelSet{})
+	labels = UnionLabelSets(labels, spec.LabelSet, LabelSet{})
+
+	for _, o := range overrides {
+		if o.MinReplication > out && o.Selector.Matches(labels) {
+			out = o.MinReplication
+		}
+	}
+	return out
+}
+
 // UnionJournalSpecs returns a JournalSpec combining all non-zero-valued fields
 // across |a| and |b|. Where both |a| and |b| provide a non-zero value for
 // a field, the value of |a| is retained.
@@ -210,6 +329,16 @@ func UnionJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Flags == JournalSpec_NOT_SPECIFIED {
 		a.Flags = b.Flags
 	}
+	if a.MinZones == 0 {
+		a.MinZones = b.MinZones
+	}
+	if a.MaxReplicasPerZone == 0 {
+		a.MaxReplicasPerZone = b.MaxReplicasPerZone
+	}
+	if a.Placement.IsEmpty() {
+		a.Placement = b.Placement
+	}
+	a.Annotations = unionAnnotations(a.Annotations, b.Annotations)
 	return a
 }
 
@@ -242,6 +371,16 @@ func IntersectJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Flags != b.Flags {
 		a.Flags = JournalSpec_NOT_SPECIFIED
 	}
+	if a.MinZones != b.MinZones {
+		a.MinZones = 0
+	}
+	if a.MaxReplicasPerZone != b.MaxReplicasPerZone {
+		a.MaxReplicasPerZone = 0
+	}
+	if a.Placement.String() != b.Placement.String() {
+		a.Placement = LabelSelector{}
+	}
+	a.Annotations = intersectAnnotations(a.Annotations, b.Annotations)
 	return a
 }
 
@@ -274,9 +413,81 @@ func SubtractJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Flags == b.Flags {
 		a.Flags = JournalSpec_NOT_SPECIFIED
 	}
+	if a.MinZones == b.MinZones {
+		a.MinZones = 0
+	}
+	if a.MaxReplicasPerZone == b.MaxReplicasPerZone {
+		a.MaxReplicasPerZone = 0
+	}
+	if a.Placement.String() == b.Placement.String() {
+		a.Placement = LabelSelector{}
+	}
+	a.Annotations = subtractAnnotations(a.Annotations, b.Annotations)
 	return a
 }
 
+// validateAnnotations asserts that annotation keys are valid label names,
+// and that values are of bounded length.
+func validateAnnotations(m map[string]string) error {
+	for k, v := range m {
+		if err := ValidateToken(k, minLabelLen, maxLabelLen); err != nil {
+			return ExtendContext(err, "Annotations[%s]", k)
+		} else if len(v) > maxAnnotationValueLen {
+			return ExtendContext(NewValidationError("invalid value length (%d; expected <= %d)",
+				len(v), maxAnnotationValueLen), "Annotations[%s]", k)
+		}
+	}
+	return nil
+}
+
+// unionAnnotations returns annotations of |a| and |b|, where the value of |a|
+// is retained if both have an annotation key. It doesn't modify |a| or |b|.
+func unionAnnotations(a, b map[string]string) map[string]string {
+	if len(b) == 0 {
+		return a
+	} else if len(a) == 0 {
+		return b
+	}
+	var out = make(map[string]string, len(a)+len(b))
+	for k, v := range b {
+		out[k] = v
+	}
+	for k, v := range a {
+		out[k] = v
+	}
+	return out
+}
+
+// intersectAnnotations returns annotations of |a| which are also in |b| with
+// the same value. It doesn't modify |a| or |b|.
+func intersectAnnotations(a, b map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range a {
+		if bv, ok := b[k]; ok && bv == v {
+			if out == nil {
+				out = make(map[string]string)
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// subtractAnnotations returns annotations of |a| which are not in |b| with
+// the same value. It doesn't modify |a| or |b|.
+func subtractAnnotations(a, b map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			if out == nil {
+				out = make(map[string]string)
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
 // ExtractJournalSpecMetaLabels adds to the LabelSet a singular label "name",
 // with value of the JournalSpec Name, and multi-label "prefix", having a value
 // for each path component prefix of Name.
@@ -325,4 +536,10 @@ const (
 	minRefreshInterval, maxRefreshInterval = time.Second, time.Hour * 24
 	minFlushInterval                       = time.Minute * 10
 	minFragmentLen, maxFragmentLen         = 1 << 10, 1 << 34 // 1024 => 17,179,869,184
+	maxAnnotationValueLen                  = 1 << 12
+)
+
+var (
+	journalSpecValidators   []func(*JournalSpec) error
+	journalSpecValidatorsMu sync.Mutex
 )
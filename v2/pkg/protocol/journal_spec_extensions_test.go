@@ -1,6 +1,8 @@
 package protocol
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/LiveRamp/gazette/v2/pkg/allocator"
@@ -61,6 +63,31 @@ func (s *JournalSuite) TestSpecValidationCases(c *gc.C) {
 	c.Check(spec.Validate(), gc.ErrorMatches, `invalid Replication \(1024; .*`)
 	spec.Replication = 3
 
+	spec.MinZones, spec.MaxReplicasPerZone = 4, 1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid MinZones \(4; expected MinZones <= Replication 3\)`)
+	spec.MinZones = 3
+	c.Check(spec.Validate(), gc.IsNil)
+
+	var minZones, maxPerZone = spec.ZoneConstraints()
+	c.Check(minZones, gc.Equals, 3)
+	c.Check(maxPerZone, gc.Equals, 1)
+	spec.MinZones, spec.MaxReplicasPerZone = 0, 0
+
+	spec.Placement = LabelSelector{Include: MustLabelSet("tier", "ssd")}
+	c.Check(spec.Validate(), gc.IsNil)
+	spec.Placement.Include.Labels[0].Name = "xxx xxx"
+	c.Check(spec.Validate(), gc.ErrorMatches, `Placement.Include.Labels\[0\].Name: not a valid token \(xxx xxx\)`)
+	spec.Placement = LabelSelector{}
+
+	spec.Annotations = map[string]string{"owner": "Data Team <data@example.com>"}
+	c.Check(spec.Validate(), gc.IsNil)
+	spec.Annotations["xxx xxx"] = "value"
+	c.Check(spec.Validate(), gc.ErrorMatches, `Annotations\[xxx xxx\]: not a valid token \(xxx xxx\)`)
+	delete(spec.Annotations, "xxx xxx")
+	spec.Annotations["owner"] = strings.Repeat("x", maxAnnotationValueLen+1)
+	c.Check(spec.Validate(), gc.ErrorMatches, `Annotations\[owner\]: invalid value length \(4097; expected <= 4096\)`)
+	spec.Annotations = nil
+
 	spec.Labels[0].Name = "xxx xxx"
 	c.Check(spec.Validate(), gc.ErrorMatches, `Labels.Labels\[0\].Name: not a valid token \(xxx xxx\)`)
 
@@ -144,6 +171,73 @@ func (s *JournalSuite) TestSpecValidationCases(c *gc.C) {
 	c.Check(f.Validate(), gc.ErrorMatches, `Stores\[2\]: not absolute \(invalid\)`)
 }
 
+func (s *JournalSuite) TestRegisteredSpecValidators(c *gc.C) {
+	defer func() { journalSpecValidators = nil }()
+
+	var spec = JournalSpec{
+		Name:        "a/journal",
+		Replication: 1,
+		LabelSet:    MustLabelSet("team", "data"),
+		Fragment: JournalSpec_Fragment{
+			Length:           1 << 18,
+			CompressionCodec: CompressionCodec_NONE,
+			Stores:           []FragmentStore{"s3://bucket/path/"},
+			RefreshInterval:  time.Minute,
+		},
+	}
+	RegisterJournalSpecValidator(func(spec *JournalSpec) error {
+		if len(spec.LabelSet.ValuesOf("team")) == 0 {
+			return NewValidationError(`expected label "team"`)
+		}
+		return nil
+	})
+	RegisterJournalSpecValidator(func(spec *JournalSpec) error {
+		for _, fs := range spec.Fragment.Stores {
+			if fs.URL().Scheme != "s3" {
+				return fmt.Errorf("store not permitted (%s)", fs)
+			}
+		}
+		return nil
+	})
+	c.Check(spec.Validate(), gc.IsNil)
+
+	spec.LabelSet = LabelSet{}
+	c.Check(spec.Validate(), gc.ErrorMatches, `expected label "team"`)
+	spec.LabelSet = MustLabelSet("team", "data")
+
+	// Errors of registered validators are always ValidationErrors.
+	spec.Fragment.Stores = append(spec.Fragment.Stores, "gs://other-bucket/path/")
+	var err = spec.Validate()
+	c.Check(err, gc.ErrorMatches, `store not permitted \(gs://other-bucket/path/\)`)
+	c.Check(err, gc.FitsTypeOf, &ValidationError{})
+
+	// Built-in checks run first, and are reported with their context.
+	spec.Name = "/bad/name"
+	c.Check(spec.Validate(), gc.ErrorMatches, `Name: cannot begin with '/' \(/bad/name\)`)
+}
+
+func (s *JournalSuite) TestAnnotationsRoundTrip(c *gc.C) {
+	var spec = JournalSpec{
+		Name:        "a/journal",
+		Annotations: map[string]string{"owner": "team-a", "lineage": "upstream/source", "empty": ""},
+	}
+	var b, err = spec.Marshal()
+	c.Assert(err, gc.IsNil)
+
+	// Marshalled annotations are ordered on key, and are deterministic.
+	b2, _ := spec.Marshal()
+	c.Check(b, gc.DeepEquals, b2)
+
+	var out JournalSpec
+	c.Check(out.Unmarshal(b), gc.IsNil)
+	c.Check(out.Annotations, gc.DeepEquals, spec.Annotations)
+
+	// Annotations are represented in YAML.
+	y, err := yaml.Marshal(spec.Annotations)
+	c.Check(err, gc.IsNil)
+	c.Check(string(y), gc.Equals, "empty: \"\"\nlineage: upstream/source\nowner: team-a\n")
+}
+
 func (s *JournalSuite) TestMetaLabelExtraction(c *gc.C) {
 	c.Check(ExtractJournalSpecMetaLabels(&JournalSpec{Name: "path/to/my/journal"}, MustLabelSet("label", "buffer")),
 		gc.DeepEquals, MustLabelSet(
@@ -216,6 +310,66 @@ func (s *JournalSuite) TestConsistencyCases(c *gc.C) {
 	c.Check(spec.IsConsistent(keyspace.KeyValue{}, assignments), gc.Equals, false)
 }
 
+func (s *JournalSuite) TestPlacement(c *gc.C) {
+	var ssd = &BrokerSpec{ProcessSpec: ProcessSpec{Labels: MustLabelSet("tier", "ssd")}}
+	var hdd = &BrokerSpec{ProcessSpec: ProcessSpec{Labels: MustLabelSet("tier", "hdd")}}
+	var none = &BrokerSpec{}
+
+	// An empty Placement is placeable on any broker.
+	var spec JournalSpec
+	c.Check(spec.IsPlaceable(ssd), gc.Equals, true)
+	c.Check(spec.IsPlaceable(none), gc.Equals, true)
+
+	spec.Placement = LabelSelector{Include: MustLabelSet("tier", "ssd")}
+	c.Check(spec.IsPlaceable(ssd), gc.Equals, true)
+	c.Check(spec.IsPlaceable(hdd), gc.Equals, false)
+	c.Check(spec.IsPlaceable(none), gc.Equals, false)
+
+	spec.Placement = LabelSelector{Exclude: MustLabelSet("tier", "hdd")}
+	c.Check(spec.IsPlaceable(ssd), gc.Equals, true)
+	c.Check(spec.IsPlaceable(hdd), gc.Equals, false)
+	c.Check(spec.IsPlaceable(none), gc.Equals, true)
+}
+
+func (s *JournalSuite) TestReplicationOverrides(c *gc.C) {
+	var overrides []ReplicationOverride
+	for _, o := range []string{"3:tier=critical", "2:prefix=orders/", "4:region=us,tier=critical"} {
+		var override, err = ParseReplicationOverride(o)
+		c.Assert(err, gc.IsNil)
+		// Expect the override round-trips through its string form.
+		rt, err := ParseReplicationOverride(override.String())
+		c.Check(err, gc.IsNil)
+		c.Check(rt, gc.DeepEquals, override)
+		overrides = append(overrides, override)
+	}
+
+	var spec = JournalSpec{Name: "orders/part-000", Replication: 1, LabelSet: MustLabelSet("tier", "critical")}
+	c.Check(OverriddenReplication(&spec, overrides), gc.Equals, int32(3)) // Largest matched MinReplication.
+	c.Check(spec.Replication, gc.Equals, int32(1))                        // Not modified.
+
+	spec = JournalSpec{Name: "orders/part-000", Replication: 5}
+	c.Check(OverriddenReplication(&spec, overrides), gc.Equals, int32(5)) // Never lowered.
+
+	spec = JournalSpec{Name: "orders/part-000", Replication: 1}
+	c.Check(OverriddenReplication(&spec, overrides), gc.Equals, int32(2)) // Matched on meta-label "prefix".
+
+	spec = JournalSpec{Name: "other/journal", Replication: 1, LabelSet: MustLabelSet("tier", "critical", "region", "us")}
+	c.Check(OverriddenReplication(&spec, overrides), gc.Equals, int32(4))
+
+	spec = JournalSpec{Name: "other/journal", Replication: 1}
+	c.Check(OverriddenReplication(&spec, overrides), gc.Equals, int32(1))
+
+	// Parse and validation errors.
+	var _, err = ParseReplicationOverride("tier=critical")
+	c.Check(err, gc.ErrorMatches, `expected MinReplication:Selector \(tier=critical\)`)
+	_, err = ParseReplicationOverride("three:tier=critical")
+	c.Check(err, gc.ErrorMatches, `parsing MinReplication \(three\): .*`)
+	_, err = ParseReplicationOverride("3:tier err in (bar)")
+	c.Check(err, gc.ErrorMatches, `parsing Selector \(tier err in \(bar\)\): could not match .*`)
+	_, err = ParseReplicationOverride("9:tier=critical")
+	c.Check(err, gc.ErrorMatches, `invalid MinReplication \(9; expected 1 <= MinReplication <= 5\)`)
+}
+
 func (s *JournalSuite) TestSetOperations(c *gc.C) {
 	var model = JournalSpec{
 		Replication: 3,
@@ -234,7 +388,11 @@ func (s *JournalSuite) TestSetOperations(c *gc.C) {
 			Retention:        time.Hour,
 			FlushInterval:    time.Hour,
 		},
-		Flags: JournalSpec_O_RDWR,
+		Flags:              JournalSpec_O_RDWR,
+		MinZones:           2,
+		MaxReplicasPerZone: 2,
+		Placement:          LabelSelector{Include: MustLabelSet("tier", "ssd")},
+		Annotations:        map[string]string{"owner": "team-a", "lineage": "upstream"},
 	}
 	var other = JournalSpec{
 		Replication: 1,
@@ -253,7 +411,11 @@ func (s *JournalSuite) TestSetOperations(c *gc.C) {
 			Retention:        10 * time.Hour,
 			FlushInterval:    10 * time.Hour,
 		},
-		Flags: JournalSpec_O_RDONLY,
+		Flags:              JournalSpec_O_RDONLY,
+		MinZones:           1,
+		MaxReplicasPerZone: 1,
+		Placement:          LabelSelector{Exclude: MustLabelSet("tier", "hdd")},
+		Annotations:        map[string]string{"owner": "team-b", "lineage": "other"},
 	}
 
 	c.Check(UnionJournalSpecs(JournalSpec{}, model), gc.DeepEquals, model)
@@ -275,6 +437,15 @@ func (s *JournalSuite) TestSetOperations(c *gc.C) {
 
 	c.Check(SubtractJournalSpecs(other, model), gc.DeepEquals, other)
 	c.Check(SubtractJournalSpecs(model, other), gc.DeepEquals, model)
+
+	// Annotations are combined per-key.
+	c.Check(UnionJournalSpecs(JournalSpec{Annotations: map[string]string{"owner": "team-c"}}, model).Annotations,
+		gc.DeepEquals, map[string]string{"owner": "team-c", "lineage": "upstream"})
+	c.Check(IntersectJournalSpecs(JournalSpec{Annotations: map[string]string{"owner": "team-a", "other": "x"}}, model).Annotations,
+		gc.DeepEquals, map[string]string{"owner": "team-a"})
+	c.Check(SubtractJournalSpecs(JournalSpec{Annotations: map[string]string{"owner": "team-a", "other": "x"}}, model).Annotations,
+		gc.DeepEquals, map[string]string{"other": "x"})
+	c.Check(model.Annotations, gc.DeepEquals, map[string]string{"owner": "team-a", "lineage": "upstream"}) // Not modified.
 }
 
 var _ = gc.Suite(&JournalSuite{})
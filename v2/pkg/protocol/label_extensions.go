@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"regexp"
 	"sort"
+	"sync"
+	"sync/atomic"
 
 	"github.com/LiveRamp/gazette/v2/pkg/labels"
 )
@@ -211,6 +213,10 @@ func (m LabelSelector) Validate() error {
 		return ExtendContext(err, "Include")
 	} else if err := m.Exclude.Validate(); err != nil {
 		return ExtendContext(err, "Exclude")
+	} else if err := validateRegexpSet(m.IncludeRegexp); err != nil {
+		return ExtendContext(err, "IncludeRegexp")
+	} else if err := validateRegexpSet(m.ExcludeRegexp); err != nil {
+		return ExtendContext(err, "ExcludeRegexp")
 	}
 	return nil
 }
@@ -221,10 +227,21 @@ func (m LabelSelector) Matches(s LabelSet) bool {
 		return false // At least one excluded label is matched.
 	} else if !matchSelector(m.Include.Labels, s.Labels, true) {
 		return false // Not every included label is matched.
+	} else if matchRegexpSelector(m.ExcludeRegexp.Labels, s, false) {
+		return false // At least one excluded expression is matched.
+	} else if !matchRegexpSelector(m.IncludeRegexp.Labels, s, true) {
+		return false // Not every included expression is matched.
 	}
 	return true
 }
 
+// IsEmpty returns true if the LabelSelector has no Include or Exclude labels
+// or expressions.
+func (m LabelSelector) IsEmpty() bool {
+	return len(m.Include.Labels) == 0 && len(m.Exclude.Labels) == 0 &&
+		len(m.IncludeRegexp.Labels) == 0 && len(m.ExcludeRegexp.Labels) == 0
+}
+
 // String returns a canonical string representation of the LabelSelector.
 func (s LabelSelector) String() string {
 	var w = bytes.NewBuffer(nil)
@@ -266,11 +283,34 @@ func (s LabelSelector) String() string {
 			}
 		}
 	}
-	f(s.Include.Labels, false)
-	if w.Len() != 0 {
-		w.WriteByte(',')
+	var fr = func(l []Label, exc bool) {
+		for i := range l {
+			w.WriteString(l[i].Name)
+
+			if exc {
+				w.WriteString("!~")
+			} else {
+				w.WriteString("=~")
+			}
+			w.WriteString(l[i].Value)
+
+			if i+1 != len(l) {
+				w.WriteByte(',')
+			}
+		}
 	}
+	var sep = func() {
+		if w.Len() != 0 && w.Bytes()[w.Len()-1] != ',' {
+			w.WriteByte(',')
+		}
+	}
+	f(s.Include.Labels, false)
+	sep()
+	fr(s.IncludeRegexp.Labels, false)
+	sep()
 	f(s.Exclude.Labels, true)
+	sep()
+	fr(s.ExcludeRegexp.Labels, true)
 
 	return w.String()
 }
@@ -308,6 +348,87 @@ func matchSelector(sel, set []Label, reqAll bool) bool {
 	return reqAll
 }
 
+// matchRegexpSelector returns whether any (or, if |reqAll|, every) label
+// expression of |sel| is matched by a value of the same label in |set|.
+func matchRegexpSelector(sel []Label, set LabelSet, reqAll bool) bool {
+	for _, l := range sel {
+		var matched bool
+
+		if re, err := compileLabelRegexp(l.Value); err == nil {
+			for _, v := range set.ValuesOf(l.Name) {
+				if matched = re.MatchString(v); matched {
+					break
+				}
+			}
+		}
+
+		if !reqAll && matched {
+			return true
+		} else if reqAll && !matched {
+			return false
+		}
+	}
+	return reqAll
+}
+
+// validateRegexpSet returns an error if the LabelSet of label expressions
+// is not well-formed.
+func validateRegexpSet(set LabelSet) error {
+	for i, l := range set.Labels {
+		if err := ValidateToken(l.Name, minLabelLen, maxLabelLen); err != nil {
+			return ExtendContext(err, "Labels[%d].Name", i)
+		} else if l.Value == "" || len(l.Value) > maxLabelValueLen {
+			return ExtendContext(NewValidationError("invalid length (%d; expected 1 <= length <= %d)",
+				len(l.Value), maxLabelValueLen), "Labels[%d].Value", i)
+		} else if _, err := compileLabelRegexp(l.Value); err != nil {
+			return ExtendContext(NewValidationError("%s", err), "Labels[%d].Value", i)
+		} else if i == 0 {
+			continue
+		}
+
+		var prev = set.Labels[i-1]
+		if prev.Name > l.Name || (prev.Name == l.Name && prev.Value >= l.Value) {
+			return NewValidationError("Labels not in unique, sorted order (index %d; label %s=~%s)",
+				i, l.Name, l.Value)
+		}
+	}
+	return nil
+}
+
+// compileLabelRegexp compiles the label expression |pattern|, which is
+// implicitly anchored to match entire label values. Expressions are compiled
+// once, as their selector is parsed or validated, and cached thereafter:
+// selectors are typically matched many times, and a cached expression is
+// returned without locking.
+func compileLabelRegexp(pattern string) (*regexp.Regexp, error) {
+	var cache, _ = labelRegexps.Load().(map[string]*regexp.Regexp)
+
+	if re, ok := cache[pattern]; ok {
+		return re, nil
+	}
+	var re, err = regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy-on-write a new cache, which is reset if it's grown too large.
+	labelRegexpsMu.Lock()
+	defer labelRegexpsMu.Unlock()
+
+	var prev, _ = labelRegexps.Load().(map[string]*regexp.Regexp)
+	var next = make(map[string]*regexp.Regexp, len(prev)+1)
+
+	if len(prev) < maxCachedLabelRegexps {
+		for p, r := range prev {
+			next[p] = r
+		}
+	}
+	next[pattern] = re
+	labelRegexps.Store(next)
+
+	return re, nil
+}
+
 // labelJoin performs a full outer join of two sorted []Label sets.
 type labelJoin struct {
 	setL, setR []Label
@@ -368,7 +489,7 @@ func labelValuesEqual(it labelJoin, cur labelJoinCursor) bool {
 // ParseLabelSelector parses a LabelSelector string. Selector strings are
 // composed of a comma-separate list of selector expressions. Allowed
 // expression types are equality, in-equality, set membership, set exclusion,
-// existence, and non-existence. Eg:
+// existence, non-existence, and regular expression matches and mis-matches. Eg:
 //
 //   * "foo = bar" requires that label "foo" be present with value "bar"
 //   * "foo != bar" requires that label "foo" not be present with value "bar"
@@ -376,6 +497,11 @@ func labelValuesEqual(it labelJoin, cur labelJoinCursor) bool {
 //   * "!foo" requires that label "foo" not be present.
 //   * "foo in (bar,baz)" requires that "foo" be present with either "bar" or "baz".
 //   * "foo notin (bar,baz)" requires that "foo", if present, not have value "bar" or "baz".
+//   * "foo =~ ba[rz]" requires that "foo" be present with a value fully matching "ba[rz]".
+//   * "foo !~ ba[rz]" requires that "foo", if present, not have a value fully matching "ba[rz]".
+//
+// Regular expressions use RE2 syntax, and are implicitly anchored to match the
+// entire label value. They may not contain commas or spaces.
 //
 // Additional examples of composite expressions:
 //   * "topic in (topic/one, topic/two), prefix=/my/journal/prefix"
@@ -389,7 +515,11 @@ func ParseLabelSelector(s string) (LabelSelector, error) {
 
 	for len(s) != 0 {
 		var m []string
-		if m = reSelectorEqual.FindStringSubmatch(s); m != nil {
+		if m = reSelectorRegexp.FindStringSubmatch(s); m != nil {
+			out.IncludeRegexp.Labels = append(out.IncludeRegexp.Labels, Label{Name: m[1], Value: m[2]})
+		} else if m = reSelectorNotRegexp.FindStringSubmatch(s); m != nil {
+			out.ExcludeRegexp.Labels = append(out.ExcludeRegexp.Labels, Label{Name: m[1], Value: m[2]})
+		} else if m = reSelectorEqual.FindStringSubmatch(s); m != nil {
 			out.Include.Labels = append(out.Include.Labels, Label{Name: m[1], Value: m[2]})
 		} else if m = reSelectorNotEqual.FindStringSubmatch(s); m != nil {
 			out.Exclude.Labels = append(out.Exclude.Labels, Label{Name: m[1], Value: m[2]})
@@ -415,7 +545,8 @@ func ParseLabelSelector(s string) (LabelSelector, error) {
 		s = s[len(m[0]):]
 	}
 
-	for _, l := range [][]Label{out.Include.Labels, out.Exclude.Labels} {
+	for _, l := range [][]Label{out.Include.Labels, out.Exclude.Labels,
+		out.IncludeRegexp.Labels, out.ExcludeRegexp.Labels} {
 		sort.Slice(l, func(i, j int) bool {
 			if l[i].Name != l[j].Name {
 				return l[i].Name < l[j].Name
@@ -446,17 +577,27 @@ var (
 	reToken         = ` ?([\` + regexp.QuoteMeta(tokenAlphabet) + `]{2,})`
 	reCommaOrEnd    = ` ?(?:,|$)`
 	reParenthetical = ` ?\(([^)]+)\)`
+	reRegexp        = ` ?([^, ]+)`
 
 	reSelectorEqual    = regexp.MustCompile(`^` + reToken + ` ?=?=` + reToken + reCommaOrEnd)
 	reSelectorNotEqual = regexp.MustCompile(`^` + reToken + ` ?!=` + reToken + reCommaOrEnd)
 
+	reSelectorRegexp    = regexp.MustCompile(`^` + reToken + ` ?=~` + reRegexp + reCommaOrEnd)
+	reSelectorNotRegexp = regexp.MustCompile(`^` + reToken + ` ?!~` + reRegexp + reCommaOrEnd)
+
 	reSelectorSetIn        = regexp.MustCompile(`^` + reToken + ` in` + reParenthetical + reCommaOrEnd)
 	reSelectorSetNotIn     = regexp.MustCompile(`^` + reToken + ` not ?in` + reParenthetical + reCommaOrEnd)
 	reSelectorSetExists    = regexp.MustCompile(`^` + reToken + reCommaOrEnd)
 	reSelectorSetNotExists = regexp.MustCompile(`^ ?!` + reToken + reCommaOrEnd)
 )
 
+var (
+	labelRegexps   atomic.Value // map[string]*regexp.Regexp
+	labelRegexpsMu sync.Mutex   // Serializes updates of |labelRegexps|.
+)
+
 const (
 	minLabelLen, maxLabelLen = 2, 64
 	maxLabelValueLen         = 1024
+	maxCachedLabelRegexps    = 1024
 )
@@ -239,6 +239,27 @@ func (s *LabelSuite) TestSelectorValidationCases(c *gc.C) {
 
 	sel.Exclude.Labels[0].Name = "bad label"
 	c.Check(sel.Validate(), gc.ErrorMatches, `Exclude.Labels\[0\].Name: not a valid token \(bad label\)`)
+	sel.Exclude.Labels[0].Name = "exclude"
+
+	sel.IncludeRegexp = LabelSet{Labels: []Label{{Name: "include", Value: "a-(va|lue)+"}}}
+	sel.ExcludeRegexp = LabelSet{Labels: []Label{{Name: "exclude", Value: ".*"}}}
+	c.Check(sel.Validate(), gc.IsNil)
+
+	sel.IncludeRegexp.Labels[0].Value = "a-(va"
+	c.Check(sel.Validate(), gc.ErrorMatches, `IncludeRegexp.Labels\[0\].Value: error parsing regexp: .*`)
+	sel.IncludeRegexp.Labels[0].Value = ""
+	c.Check(sel.Validate(), gc.ErrorMatches, `IncludeRegexp.Labels\[0\].Value: invalid length \(0; expected 1 <= length <= 1024\)`)
+	sel.IncludeRegexp.Labels[0].Value = "a-value"
+
+	sel.ExcludeRegexp.Labels = append(sel.ExcludeRegexp.Labels, Label{Name: "bad label", Value: "foo"})
+	c.Check(sel.Validate(), gc.ErrorMatches, `ExcludeRegexp.Labels\[1\].Name: not a valid token \(bad label\)`)
+	sel.ExcludeRegexp.Labels[1].Name = "exclude"
+	c.Check(sel.Validate(), gc.IsNil) // ".*" sorts before "foo".
+
+	sel.ExcludeRegexp.Labels[1].Value = "(foo)"
+	c.Check(sel.Validate(), gc.ErrorMatches, `ExcludeRegexp: Labels not in unique, sorted order \(index 1; label exclude=~\(foo\)\)`)
+	sel.ExcludeRegexp.Labels[1].Value = ".*"
+	c.Check(sel.Validate(), gc.ErrorMatches, `ExcludeRegexp: Labels not in unique, sorted order \(index 1; label exclude=~\.\*\)`)
 }
 
 func (s *LabelSuite) TestSelectorMatchingCases(c *gc.C) {
@@ -285,6 +306,39 @@ func (s *LabelSuite) TestSelectorMatchingCases(c *gc.C) {
 	c.Check(sel.Matches(MustLabelSet("exc-1", "any", "foo", "bar")), gc.Equals, false)
 }
 
+func (s *LabelSuite) TestSelectorRegexpMatchingCases(c *gc.C) {
+	var sel = LabelSelector{
+		Include: MustLabelSet("inc", ""),
+		IncludeRegexp: LabelSet{Labels: []Label{
+			{Name: "inc-re-1", Value: "val-[0-9]+"},
+			{Name: "inc-re-2", Value: "a|b"},
+		}},
+		ExcludeRegexp: LabelSet{Labels: []Label{
+			{Name: "exc-re", Value: "bad.*"},
+		}},
+	}
+	var cases = []struct {
+		set    LabelSet
+		expect bool
+	}{
+		{set: MustLabelSet(), expect: false},                                                                       // Not matched.
+		{set: MustLabelSet("inc", "x", "inc-re-1", "val-12", "inc-re-2", "a"), expect: true},                       // Matched.
+		{set: MustLabelSet("inc", "x", "inc-re-1", "val-12", "inc-re-1", "zzz", "inc-re-2", "b"), expect: true},    // Matched (any value of inc-re-1).
+		{set: MustLabelSet("inc-re-1", "val-12", "inc-re-2", "a"), expect: false},                                  // Not matched (inc missing).
+		{set: MustLabelSet("inc", "x", "inc-re-1", "val-x", "inc-re-2", "a"), expect: false},                       // Not matched (inc-re-1 not matched).
+		{set: MustLabelSet("inc", "x", "inc-re-1", "a-val-12", "inc-re-2", "a"), expect: false},                    // Not matched (expression is anchored).
+		{set: MustLabelSet("inc", "x", "inc-re-1", "val-12", "inc-re-2", "ab"), expect: false},                     // Not matched (alternation is anchored).
+		{set: MustLabelSet("inc", "x", "inc-re-1", "val-12"), expect: false},                                       // Not matched (inc-re-2 missing).
+		{set: MustLabelSet("exc-re", "bad-val", "inc", "x", "inc-re-1", "val-12", "inc-re-2", "a"), expect: false}, // Not matched (exc-re matched).
+		{set: MustLabelSet("exc-re", "not-bad", "inc", "x", "inc-re-1", "val-12", "inc-re-2", "a"), expect: true},  // Matched (exc-re is anchored).
+	}
+	for _, tc := range cases {
+		c.Check(sel.Matches(tc.set), gc.Equals, tc.expect)
+	}
+	c.Check(sel.IsEmpty(), gc.Equals, false)
+	c.Check(LabelSelector{ExcludeRegexp: sel.ExcludeRegexp}.IsEmpty(), gc.Equals, false)
+}
+
 func (s *LabelSuite) TestOuterJoin(c *gc.C) {
 	var lhs, rhs = MustLabelSet(
 		"aaa", "l0",
@@ -361,6 +415,18 @@ func (s *LabelSuite) TestSelectorParsingCases(c *gc.C) {
 				Exclude: MustLabelSet("baz", "bing"),
 			},
 		},
+		{
+			s: "foo =~ ba[rz]+, bar!~(one|two)-.*, foo=~ab?,baz",
+			expect: LabelSelector{
+				Include:       MustLabelSet("baz", ""),
+				IncludeRegexp: LabelSet{Labels: []Label{{Name: "foo", Value: "ab?"}, {Name: "foo", Value: "ba[rz]+"}}},
+				ExcludeRegexp: LabelSet{Labels: []Label{{Name: "bar", Value: "(one|two)-.*"}}},
+			},
+		},
+		{
+			s:      "foo !~ bar",
+			expect: LabelSelector{ExcludeRegexp: LabelSet{Labels: []Label{{Name: "foo", Value: "bar"}}}},
+		},
 		{
 			s: "!foo,baz,bing not in (thing-one, thing-2),!bar,",
 			expect: LabelSelector{
@@ -394,6 +460,10 @@ func (s *LabelSuite) TestSelectorParsingCases(c *gc.C) {
 	_, err = ParseLabelSelector("foo, foo in (bar)")
 	c.Check(err, gc.ErrorMatches,
 		`Include: Label has empty & non-empty values \(index 1; label foo value bar\)`)
+
+	// Case: Expect regular expressions are validated.
+	_, err = ParseLabelSelector("foo =~ ba(r")
+	c.Check(err, gc.ErrorMatches, `IncludeRegexp.Labels\[0\].Value: error parsing regexp: .*`)
 }
 
 var _ = gc.Suite(&LabelSuite{})
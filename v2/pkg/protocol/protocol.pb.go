@@ -18,6 +18,7 @@ import (
 
 import encoding_binary "encoding/binary"
 import github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+import github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
 
 import io "io"
 
@@ -73,6 +74,12 @@ const (
 	// that journal replication consistency has been lost in the past, due to
 	// too many broker or Etcd failures.
 	Status_INDEX_HAS_GREATER_OFFSET Status = 12
+	// The Append is refused because its declared content type doesn't match
+	// the content-type label of the journal.
+	Status_WRONG_CONTENT_TYPE Status = 13
+	// The Append continuation is not known to the broker, or has expired and
+	// its suspended content was rolled back.
+	Status_APPEND_CONTINUATION_EXPIRED Status = 14
 )
 
 var Status_name = map[int32]string{
@@ -89,6 +96,8 @@ var Status_name = map[int32]string{
 	10: "NOT_ALLOWED",
 	11: "WRONG_APPEND_OFFSET",
 	12: "INDEX_HAS_GREATER_OFFSET",
+	13: "WRONG_CONTENT_TYPE",
+	14: "APPEND_CONTINUATION_EXPIRED",
 }
 var Status_value = map[string]int32{
 	"OK":                           0,
@@ -104,6 +113,8 @@ var Status_value = map[string]int32{
 	"NOT_ALLOWED":                  10,
 	"WRONG_APPEND_OFFSET":          11,
 	"INDEX_HAS_GREATER_OFFSET":     12,
+	"WRONG_CONTENT_TYPE":           13,
+	"APPEND_CONTINUATION_EXPIRED":  14,
 }
 
 func (x Status) String() string {
@@ -293,6 +304,14 @@ type LabelSelector struct {
 	// empty, no Labels are excluded. An exclude Label with empty ("") value
 	// excludes a Label of the same name having any value.
 	Exclude LabelSet `protobuf:"bytes,2,opt,name=exclude" json:"exclude"`
+	// IncludeRegexp is Labels having values which are regular expressions. Each
+	// must be matched by a Label of the same name, having a value which fully
+	// matches the (implicitly anchored) expression, for a LabelSet to be selected.
+	IncludeRegexp LabelSet `protobuf:"bytes,3,opt,name=include_regexp,json=includeRegexp" json:"include_regexp"`
+	// ExcludeRegexp is Labels having values which are regular expressions. If
+	// any is matched by a Label of the same name, having a value which fully
+	// matches the (implicitly anchored) expression, the LabelSet is not selected.
+	ExcludeRegexp LabelSet `protobuf:"bytes,4,opt,name=exclude_regexp,json=excludeRegexp" json:"exclude_regexp"`
 }
 
 func (m *LabelSelector) Reset()      { *m = LabelSelector{} }
@@ -342,6 +361,26 @@ type JournalSpec struct {
 	// Flags of the Journal, as a combination of Flag enum values. The Flag enum
 	// not used directly, as protobuf enums do not allow for or'ed bitfields.
 	Flags JournalSpec_Flag `protobuf:"varint,6,opt,name=flags,proto3,casttype=JournalSpec_Flag" json:"flags,omitempty" yaml:",omitempty"`
+	// Minimum number of distinct zones across which replicas of the Journal must
+	// be placed. If zero, replicas are placed across at least two zones where
+	// the brokers of multiple zones have sufficient capacity. Unlike that
+	// default, an explicit minimum is enforced: the Journal is left with fewer
+	// replicas if it cannot otherwise be satisfied.
+	MinZones uint32 `protobuf:"varint,7,opt,name=min_zones,json=minZones,proto3" json:"min_zones,omitempty" yaml:"min_zones,omitempty"`
+	// Maximum number of replicas of the Journal which may be placed within a
+	// single zone. If zero, the replicas of a zone are not explicitly bounded. A
+	// value of one ensures no two replicas of the Journal share a zone.
+	MaxReplicasPerZone uint32 `protobuf:"varint,8,opt,name=max_replicas_per_zone,json=maxReplicasPerZone,proto3" json:"max_replicas_per_zone,omitempty" yaml:"max_replicas_per_zone,omitempty"`
+	// Placement selects the brokers to which the Journal may be assigned, by
+	// matching against broker labels. Eg, a placement of "tier=ssd" restricts the
+	// Journal to brokers having the label "tier" with value "ssd". If empty,
+	// the Journal may be assigned to any broker.
+	Placement LabelSelector `protobuf:"bytes,9,opt,name=placement,proto3" json:"placement" yaml:",omitempty"`
+	// Annotations are arbitrary, user-defined metadata of the Journal, such as
+	// its owning team, data classification, or pipeline lineage. Unlike Labels,
+	// Annotations are not used to select or place Journals, and have no meaning
+	// to Gazette. Annotation keys must be valid label names.
+	Annotations map[string]string `protobuf:"bytes,10,rep,name=annotations,proto3" json:"annotations,omitempty" yaml:",omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *JournalSpec) Reset()         { *m = JournalSpec{} }
@@ -458,6 +497,9 @@ type ProcessSpec struct {
 	Id ProcessSpec_ID `protobuf:"bytes,1,opt,name=id" json:"id"`
 	// Advertised URL of the process.
 	Endpoint Endpoint `protobuf:"bytes,2,opt,name=endpoint,proto3,casttype=Endpoint" json:"endpoint,omitempty"`
+	// Labels of the process, against which the placement selectors of
+	// JournalSpecs and ShardSpecs are matched.
+	Labels LabelSet `protobuf:"bytes,3,opt,name=labels,proto3" json:"labels" yaml:",omitempty"`
 }
 
 func (m *ProcessSpec) Reset()         { *m = ProcessSpec{} }
@@ -507,6 +549,13 @@ func (m *ProcessSpec) GetEndpoint() Endpoint {
 	return ""
 }
 
+func (m *ProcessSpec) GetLabels() LabelSet {
+	if m != nil {
+		return m.Labels
+	}
+	return LabelSet{}
+}
+
 // ID composes a zone and a suffix to uniquely identify a ProcessSpec.
 type ProcessSpec_ID struct {
 	// "Zone" in which the process is running. Zones may be AWS, Azure, or Google
@@ -514,6 +563,10 @@ type ProcessSpec_ID struct {
 	// given some other custom meaning. Gazette will replicate across multiple
 	// zones, and seeks to minimize traffic which must cross zones (for example,
 	// by proxying reads to a broker in the current zone).
+	//
+	// Zones may also be hierarchical, as "/"-separated paths of failure domains
+	// ordered from highest-level to lowest (eg, "us-east/az-1/rack-7"), in which
+	// case replicas are spread across the highest-level domains first.
 	Zone string `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
 	// Unique suffix of the process within |zone|. It is permissible for a
 	// suffix value to repeat across zones, but never within zones. In practice,
@@ -561,6 +614,15 @@ type BrokerSpec struct {
 	ProcessSpec `protobuf:"bytes,1,opt,name=process_spec,json=processSpec,embedded=process_spec" json:"process_spec" yaml:",inline"`
 	// Maximum number of assigned Journal replicas.
 	JournalLimit uint32 `protobuf:"varint,2,opt,name=journal_limit,json=journalLimit,proto3" json:"journal_limit,omitempty"`
+	// Relative capacity weight of the broker. Journal replicas are balanced
+	// across brokers in proportion to the products of their limits and
+	// weights. A broker exceeds its weighted share only if other brokers
+	// lack capacity. If zero, a weight of one is used.
+	CapacityWeight uint32 `protobuf:"varint,3,opt,name=capacity_weight,json=capacityWeight,proto3" json:"capacity_weight,omitempty"`
+	// Draining brokers are assigned no new Journal replicas, and hand off
+	// current primary assignments before their replicas are removed. Brokers
+	// mark themselves as draining upon a graceful shutdown.
+	Draining bool `protobuf:"varint,4,opt,name=draining,proto3" json:"draining,omitempty"`
 }
 
 func (m *BrokerSpec) Reset()         { *m = BrokerSpec{} }
@@ -598,7 +660,7 @@ var xxx_messageInfo_BrokerSpec proto.InternalMessageInfo
 
 // Fragment is a content-addressed description of a contiguous Journal span,
 // defined by the [begin, end) offset range covered by the Fragment and the
-// SHA1 sum of the corresponding Journal content.
+// SHA1 (or SHA256) sum of the corresponding Journal content.
 type Fragment struct {
 	// Journal of the Fragment.
 	Journal Journal `protobuf:"bytes,1,opt,name=journal,proto3,casttype=Journal" json:"journal,omitempty"`
@@ -615,6 +677,10 @@ type Fragment struct {
 	// Modification timestamp of the Fragment within the backing store, represented as seconds
 	// since the epoch.
 	ModTime int64 `protobuf:"varint,7,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"`
+	// SHA256 sum of the Fragment's content. A Fragment is summed using SHA1 or
+	// SHA256, but not both: if sum256 is set, the Fragment's content is named
+	// by its SHA256 sum, and sum must be zero-valued.
+	Sum256 SHA256Sum `protobuf:"bytes,8,opt,name=sum256" json:"sum256"`
 }
 
 func (m *Fragment) Reset()         { *m = Fragment{} }
@@ -690,6 +756,47 @@ func (m *SHA1Sum) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SHA1Sum proto.InternalMessageInfo
 
+// SHA256Sum is a 256-bit SHA256 digest.
+type SHA256Sum struct {
+	Part1 uint64 `protobuf:"fixed64,1,opt,name=part1,proto3" json:"part1,omitempty"`
+	Part2 uint64 `protobuf:"fixed64,2,opt,name=part2,proto3" json:"part2,omitempty"`
+	Part3 uint64 `protobuf:"fixed64,3,opt,name=part3,proto3" json:"part3,omitempty"`
+	Part4 uint64 `protobuf:"fixed64,4,opt,name=part4,proto3" json:"part4,omitempty"`
+}
+
+func (m *SHA256Sum) Reset()         { *m = SHA256Sum{} }
+func (m *SHA256Sum) String() string { return proto.CompactTextString(m) }
+func (*SHA256Sum) ProtoMessage()    {}
+func (*SHA256Sum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{8}
+}
+func (m *SHA256Sum) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SHA256Sum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SHA256Sum.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SHA256Sum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SHA256Sum.Merge(dst, src)
+}
+func (m *SHA256Sum) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *SHA256Sum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SHA256Sum.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SHA256Sum proto.InternalMessageInfo
+
 type ReadRequest struct {
 	// Header is attached by a proxying broker peer.
 	Header *Header `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -711,13 +818,18 @@ type ReadRequest struct {
 	// If metadata_only is true, the broker will respond with Journal and
 	// Fragment metadata but not content.
 	MetadataOnly bool `protobuf:"varint,6,opt,name=metadata_only,json=metadataOnly,proto3" json:"metadata_only,omitempty"`
+	// Optional CompressionCodec with which the broker should compress response
+	// Content, reducing the bytes transferred to remote readers of compressible
+	// journals. Each Content chunk is compressed independently. If zero-valued,
+	// Content is not compressed.
+	ContentCodec CompressionCodec `protobuf:"varint,7,opt,name=content_codec,json=contentCodec,proto3,enum=protocol.CompressionCodec" json:"content_codec,omitempty"`
 }
 
 func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{8}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{9}
 }
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -765,13 +877,17 @@ type ReadResponse struct {
 	FragmentUrl string `protobuf:"bytes,6,opt,name=fragment_url,json=fragmentUrl,proto3" json:"fragment_url,omitempty"`
 	// Content chunks of the read.
 	Content []byte `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+	// CompressionCodec of the Content chunk, if it's compressed. Brokers may
+	// send uncompressed chunks despite a requested ReadRequest ContentCodec
+	// (eg, because the chunk is not compressible).
+	ContentCodec CompressionCodec `protobuf:"varint,8,opt,name=content_codec,json=contentCodec,proto3,enum=protocol.CompressionCodec" json:"content_codec,omitempty"`
 }
 
 func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{9}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{10}
 }
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -818,13 +934,26 @@ type AppendRequest struct {
 	// indicate the Append should be committed. Absence of this empty chunk
 	// prior to EOF is interpreted by the broker as a rollback of the Append.
 	Content []byte `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// Content type of the appended content, as optionally declared by the
+	// client. If set, it must match the content-type label of the journal,
+	// or WRONG_CONTENT_TYPE is returned. Media type parameters are ignored.
+	ContentType string `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Continuation of a suspended Append to resume, as returned by a prior
+	// AppendResponse. Content chunks of this RPC extend the suspended Append,
+	// and the Append may be committed, rolled back, or suspended again.
+	Continuation string `protobuf:"bytes,7,opt,name=continuation,proto3" json:"continuation,omitempty"`
+	// Suspend may be set on an empty chunk sent in place of the empty commit
+	// chunk, to suspend rather than commit the Append. The broker retains
+	// appended content (but does not commit it), and responds with a
+	// continuation by which a following Append RPC may resume the Append.
+	Suspend bool `protobuf:"varint,8,opt,name=suspend,proto3" json:"suspend,omitempty"`
 }
 
 func (m *AppendRequest) Reset()         { *m = AppendRequest{} }
 func (m *AppendRequest) String() string { return proto.CompactTextString(m) }
 func (*AppendRequest) ProtoMessage()    {}
 func (*AppendRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{10}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{11}
 }
 func (m *AppendRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -861,13 +990,17 @@ type AppendResponse struct {
 	// If status is OK, then |commit| is the Fragment which places the
 	// committed Append content within the Journal.
 	Commit *Fragment `protobuf:"bytes,3,opt,name=commit" json:"commit,omitempty"`
+	// If status is OK and the Append was suspended, then |continuation| is
+	// the token by which a following Append RPC may resume it, and |commit|
+	// is unset.
+	Continuation string `protobuf:"bytes,4,opt,name=continuation,proto3" json:"continuation,omitempty"`
 }
 
 func (m *AppendResponse) Reset()         { *m = AppendResponse{} }
 func (m *AppendResponse) String() string { return proto.CompactTextString(m) }
 func (*AppendResponse) ProtoMessage()    {}
 func (*AppendResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{11}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{12}
 }
 func (m *AppendResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -918,7 +1051,7 @@ func (m *ReplicateRequest) Reset()         { *m = ReplicateRequest{} }
 func (m *ReplicateRequest) String() string { return proto.CompactTextString(m) }
 func (*ReplicateRequest) ProtoMessage()    {}
 func (*ReplicateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{12}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{13}
 }
 func (m *ReplicateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -962,7 +1095,7 @@ func (m *ReplicateResponse) Reset()         { *m = ReplicateResponse{} }
 func (m *ReplicateResponse) String() string { return proto.CompactTextString(m) }
 func (*ReplicateResponse) ProtoMessage()    {}
 func (*ReplicateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{13}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{14}
 }
 func (m *ReplicateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1012,7 +1145,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{14}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{15}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1050,13 +1183,16 @@ type ListResponse struct {
 	// A pagination token which indicates where the next request should continue
 	// from. Empty if and only if this ListResponse completes the listing.
 	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Journals which have been removed since the preceding ListResponse of a
+	// WatchList RPC. Always empty for responses of the List RPC.
+	RemovedJournals []Journal `protobuf:"bytes,5,rep,name=removed_journals,json=removedJournals,proto3,casttype=Journal" json:"removed_journals,omitempty"`
 }
 
 func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{15}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{16}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1098,7 +1234,7 @@ func (m *ListResponse_Journal) Reset()         { *m = ListResponse_Journal{} }
 func (m *ListResponse_Journal) String() string { return proto.CompactTextString(m) }
 func (*ListResponse_Journal) ProtoMessage()    {}
 func (*ListResponse_Journal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{15, 0}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{16, 0}
 }
 func (m *ListResponse_Journal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1135,7 +1271,7 @@ func (m *ApplyRequest) Reset()         { *m = ApplyRequest{} }
 func (m *ApplyRequest) String() string { return proto.CompactTextString(m) }
 func (*ApplyRequest) ProtoMessage()    {}
 func (*ApplyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{16}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{17}
 }
 func (m *ApplyRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1181,7 +1317,7 @@ func (m *ApplyRequest_Change) Reset()         { *m = ApplyRequest_Change{} }
 func (m *ApplyRequest_Change) String() string { return proto.CompactTextString(m) }
 func (*ApplyRequest_Change) ProtoMessage()    {}
 func (*ApplyRequest_Change) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{16, 0}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{17, 0}
 }
 func (m *ApplyRequest_Change) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1221,7 +1357,7 @@ func (m *ApplyResponse) Reset()         { *m = ApplyResponse{} }
 func (m *ApplyResponse) String() string { return proto.CompactTextString(m) }
 func (*ApplyResponse) ProtoMessage()    {}
 func (*ApplyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{17}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{18}
 }
 func (m *ApplyResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1279,7 +1415,7 @@ func (m *FragmentsRequest) Reset()         { *m = FragmentsRequest{} }
 func (m *FragmentsRequest) String() string { return proto.CompactTextString(m) }
 func (*FragmentsRequest) ProtoMessage()    {}
 func (*FragmentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{18}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{19}
 }
 func (m *FragmentsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1323,7 +1459,7 @@ func (m *FragmentsResponse) Reset()         { *m = FragmentsResponse{} }
 func (m *FragmentsResponse) String() string { return proto.CompactTextString(m) }
 func (*FragmentsResponse) ProtoMessage()    {}
 func (*FragmentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{19}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{20}
 }
 func (m *FragmentsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1365,7 +1501,7 @@ func (m *FragmentsResponse__Fragment) Reset()         { *m = FragmentsResponse__
 func (m *FragmentsResponse__Fragment) String() string { return proto.CompactTextString(m) }
 func (*FragmentsResponse__Fragment) ProtoMessage()    {}
 func (*FragmentsResponse__Fragment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{19, 0}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{20, 0}
 }
 func (m *FragmentsResponse__Fragment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1410,7 +1546,7 @@ func (m *Route) Reset()         { *m = Route{} }
 func (m *Route) String() string { return proto.CompactTextString(m) }
 func (*Route) ProtoMessage()    {}
 func (*Route) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{20}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{21}
 }
 func (m *Route) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1450,13 +1586,23 @@ type Header struct {
 	// if any process is capable of serving the RPC.
 	Route Route       `protobuf:"bytes,2,opt,name=route" json:"route"`
 	Etcd  Header_Etcd `protobuf:"bytes,3,opt,name=etcd" json:"etcd"`
+	// BuildVersion is the semantic version of the process which authored the
+	// Header, or empty if the process pre-dates its inclusion.
+	BuildVersion string `protobuf:"bytes,4,opt,name=build_version,json=buildVersion,proto3" json:"build_version,omitempty"`
+	// Capabilities is a bitmask of optional protocol features which are
+	// supported by the process which authored the Header.
+	Capabilities Capability `protobuf:"varint,5,opt,name=capabilities,proto3,casttype=Capability" json:"capabilities,omitempty"`
+	// AppendSuspendTimeout is the duration after which an Append suspended by
+	// its client is rolled back, if it's not yet been resumed. It's set by
+	// brokers supporting Capability_APPEND_CONTINUATION.
+	AppendSuspendTimeout time.Duration `protobuf:"varint,6,opt,name=append_suspend_timeout,json=appendSuspendTimeout,proto3,casttype=time.Duration" json:"append_suspend_timeout,omitempty"`
 }
 
 func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{21}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{22}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1507,7 +1653,7 @@ func (m *Header_Etcd) Reset()         { *m = Header_Etcd{} }
 func (m *Header_Etcd) String() string { return proto.CompactTextString(m) }
 func (*Header_Etcd) ProtoMessage()    {}
 func (*Header_Etcd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{21, 0}
+	return fileDescriptor_protocol_ffc263d8ecf7e451, []int{22, 0}
 }
 func (m *Header_Etcd) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1547,6 +1693,7 @@ func init() {
 	proto.RegisterType((*BrokerSpec)(nil), "protocol.BrokerSpec")
 	proto.RegisterType((*Fragment)(nil), "protocol.Fragment")
 	proto.RegisterType((*SHA1Sum)(nil), "protocol.SHA1Sum")
+	proto.RegisterType((*SHA256Sum)(nil), "protocol.SHA256Sum")
 	proto.RegisterType((*ReadRequest)(nil), "protocol.ReadRequest")
 	proto.RegisterType((*ReadResponse)(nil), "protocol.ReadResponse")
 	proto.RegisterType((*AppendRequest)(nil), "protocol.AppendRequest")
@@ -1584,6 +1731,11 @@ const _ = grpc.SupportPackageIsVersion4
 type JournalClient interface {
 	// List Journals, their JournalSpecs and current Routes.
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// WatchList watches the Journals of a ListRequest. The first ListResponse
+	// of the stream holds the complete listing. Each subsequent ListResponse is
+	// a delta, holding Journals which were added or updated, and the names of
+	// Journals which were removed, since the preceding ListResponse.
+	WatchList(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (Journal_WatchListClient, error)
 	// Apply changes to the collection of Journals managed by the brokers.
 	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResponse, error)
 	// Read from a specific Journal.
@@ -1596,6 +1748,11 @@ type JournalClient interface {
 	Replicate(ctx context.Context, opts ...grpc.CallOption) (Journal_ReplicateClient, error)
 	// List Fragments of a Journal.
 	ListFragments(ctx context.Context, in *FragmentsRequest, opts ...grpc.CallOption) (*FragmentsResponse, error)
+	// StreamFragments lists Fragments of a Journal as a stream of
+	// FragmentsResponse pages, each having at most PageLimit Fragments. The
+	// NextPageToken of each page may be used to resume the listing, and the
+	// stream closes after the final page (having a zero NextPageToken).
+	StreamFragments(ctx context.Context, in *FragmentsRequest, opts ...grpc.CallOption) (Journal_StreamFragmentsClient, error)
 }
 
 type journalClient struct {
@@ -1615,6 +1772,38 @@ func (c *journalClient) List(ctx context.Context, in *ListRequest, opts ...grpc.
 	return out, nil
 }
 
+func (c *journalClient) WatchList(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (Journal_WatchListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Journal_serviceDesc.Streams[0], "/protocol.Journal/WatchList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &journalWatchListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Journal_WatchListClient interface {
+	Recv() (*ListResponse, error)
+	grpc.ClientStream
+}
+
+type journalWatchListClient struct {
+	grpc.ClientStream
+}
+
+func (x *journalWatchListClient) Recv() (*ListResponse, error) {
+	m := new(ListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *journalClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResponse, error) {
 	out := new(ApplyResponse)
 	err := c.cc.Invoke(ctx, "/protocol.Journal/Apply", in, out, opts...)
@@ -1625,7 +1814,7 @@ func (c *journalClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grp
 }
 
 func (c *journalClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (Journal_ReadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Journal_serviceDesc.Streams[0], "/protocol.Journal/Read", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Journal_serviceDesc.Streams[1], "/protocol.Journal/Read", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1657,7 +1846,7 @@ func (x *journalReadClient) Recv() (*ReadResponse, error) {
 }
 
 func (c *journalClient) Append(ctx context.Context, opts ...grpc.CallOption) (Journal_AppendClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Journal_serviceDesc.Streams[1], "/protocol.Journal/Append", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Journal_serviceDesc.Streams[2], "/protocol.Journal/Append", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1691,7 +1880,7 @@ func (x *journalAppendClient) CloseAndRecv() (*AppendResponse, error) {
 }
 
 func (c *journalClient) Replicate(ctx context.Context, opts ...grpc.CallOption) (Journal_ReplicateClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Journal_serviceDesc.Streams[2], "/protocol.Journal/Replicate", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Journal_serviceDesc.Streams[3], "/protocol.Journal/Replicate", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1730,10 +1919,47 @@ func (c *journalClient) ListFragments(ctx context.Context, in *FragmentsRequest,
 	return out, nil
 }
 
+func (c *journalClient) StreamFragments(ctx context.Context, in *FragmentsRequest, opts ...grpc.CallOption) (Journal_StreamFragmentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Journal_serviceDesc.Streams[4], "/protocol.Journal/StreamFragments", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &journalStreamFragmentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Journal_StreamFragmentsClient interface {
+	Recv() (*FragmentsResponse, error)
+	grpc.ClientStream
+}
+
+type journalStreamFragmentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *journalStreamFragmentsClient) Recv() (*FragmentsResponse, error) {
+	m := new(FragmentsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // JournalServer is the server API for Journal service.
 type JournalServer interface {
 	// List Journals, their JournalSpecs and current Routes.
 	List(context.Context, *ListRequest) (*ListResponse, error)
+	// WatchList watches the Journals of a ListRequest. The first ListResponse
+	// of the stream holds the complete listing. Each subsequent ListResponse is
+	// a delta, holding Journals which were added or updated, and the names of
+	// Journals which were removed, since the preceding ListResponse.
+	WatchList(*ListRequest, Journal_WatchListServer) error
 	// Apply changes to the collection of Journals managed by the brokers.
 	Apply(context.Context, *ApplyRequest) (*ApplyResponse, error)
 	// Read from a specific Journal.
@@ -1746,6 +1972,11 @@ type JournalServer interface {
 	Replicate(Journal_ReplicateServer) error
 	// List Fragments of a Journal.
 	ListFragments(context.Context, *FragmentsRequest) (*FragmentsResponse, error)
+	// StreamFragments lists Fragments of a Journal as a stream of
+	// FragmentsResponse pages, each having at most PageLimit Fragments. The
+	// NextPageToken of each page may be used to resume the listing, and the
+	// stream closes after the final page (having a zero NextPageToken).
+	StreamFragments(*FragmentsRequest, Journal_StreamFragmentsServer) error
 }
 
 func RegisterJournalServer(s *grpc.Server, srv JournalServer) {
@@ -1770,6 +2001,27 @@ func _Journal_List_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Journal_WatchList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JournalServer).WatchList(m, &journalWatchListServer{stream})
+}
+
+type Journal_WatchListServer interface {
+	Send(*ListResponse) error
+	grpc.ServerStream
+}
+
+type journalWatchListServer struct {
+	grpc.ServerStream
+}
+
+func (x *journalWatchListServer) Send(m *ListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Journal_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ApplyRequest)
 	if err := dec(in); err != nil {
@@ -1879,6 +2131,27 @@ func _Journal_ListFragments_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Journal_StreamFragments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FragmentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JournalServer).StreamFragments(m, &journalStreamFragmentsServer{stream})
+}
+
+type Journal_StreamFragmentsServer interface {
+	Send(*FragmentsResponse) error
+	grpc.ServerStream
+}
+
+type journalStreamFragmentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *journalStreamFragmentsServer) Send(m *FragmentsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Journal_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protocol.Journal",
 	HandlerType: (*JournalServer)(nil),
@@ -1897,6 +2170,11 @@ var _Journal_serviceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchList",
+			Handler:       _Journal_WatchList_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Read",
 			Handler:       _Journal_Read_Handler,
@@ -1913,6 +2191,11 @@ var _Journal_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamFragments",
+			Handler:       _Journal_StreamFragments_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "protocol.proto",
 }
@@ -2008,6 +2291,26 @@ func (m *LabelSelector) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n2
+	if len(m.IncludeRegexp.Labels) != 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.IncludeRegexp.ProtoSize()))
+		n3, err := m.IncludeRegexp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if len(m.ExcludeRegexp.Labels) != 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.ExcludeRegexp.ProtoSize()))
+		n4, err := m.ExcludeRegexp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
 	return i, nil
 }
 
@@ -2058,6 +2361,46 @@ func (m *JournalSpec) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Flags))
 	}
+	if m.MinZones != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MinZones))
+	}
+	if m.MaxReplicasPerZone != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MaxReplicasPerZone))
+	}
+	dAtA[i] = 0x4a
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Placement.ProtoSize()))
+	n34, err := m.Placement.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n34
+	if len(m.Annotations) > 0 {
+		keysForAnnotations := make([]string, 0, len(m.Annotations))
+		for k, _ := range m.Annotations {
+			keysForAnnotations = append(keysForAnnotations, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForAnnotations)
+		for _, k := range keysForAnnotations {
+			dAtA[i] = 0x52
+			i++
+			v := m.Annotations[string(k)]
+			mapSize := 1 + len(k) + sovProtocol(uint64(len(k))) + 1 + len(v) + sovProtocol(uint64(len(v)))
+			i = encodeVarintProtocol(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintProtocol(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintProtocol(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
 	return i, nil
 }
 
@@ -2157,6 +2500,14 @@ func (m *ProcessSpec) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Endpoint)))
 		i += copy(dAtA[i:], m.Endpoint)
 	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Labels.ProtoSize()))
+	n35, err := m.Labels.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n35
 	return i, nil
 }
 
@@ -2218,6 +2569,21 @@ func (m *BrokerSpec) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.JournalLimit))
 	}
+	if m.CapacityWeight != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.CapacityWeight))
+	}
+	if m.Draining {
+		dAtA[i] = 0x20
+		i++
+		if m.Draining {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -2276,6 +2642,14 @@ func (m *Fragment) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.ModTime))
 	}
+	dAtA[i] = 0x42
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Sum256.ProtoSize()))
+	n256, err := m.Sum256.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n256
 	return i, nil
 }
 
@@ -2315,6 +2689,48 @@ func (m *SHA1Sum) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *SHA256Sum) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SHA256Sum) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Part1 != 0 {
+		dAtA[i] = 0x9
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Part1))
+		i += 8
+	}
+	if m.Part2 != 0 {
+		dAtA[i] = 0x11
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Part2))
+		i += 8
+	}
+	if m.Part3 != 0 {
+		dAtA[i] = 0x19
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Part3))
+		i += 8
+	}
+	if m.Part4 != 0 {
+		dAtA[i] = 0x21
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Part4))
+		i += 8
+	}
+	return i, nil
+}
+
 func (m *ReadRequest) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
@@ -2381,6 +2797,11 @@ func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.ContentCodec != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.ContentCodec))
+	}
 	return i, nil
 }
 
@@ -2446,6 +2867,11 @@ func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Content)))
 		i += copy(dAtA[i:], m.Content)
 	}
+	if m.ContentCodec != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.ContentCodec))
+	}
 	return i, nil
 }
 
@@ -2501,6 +2927,28 @@ func (m *AppendRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Offset))
 	}
+	if len(m.ContentType) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.ContentType)))
+		i += copy(dAtA[i:], m.ContentType)
+	}
+	if len(m.Continuation) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Continuation)))
+		i += copy(dAtA[i:], m.Continuation)
+	}
+	if m.Suspend {
+		dAtA[i] = 0x40
+		i++
+		if m.Suspend {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -2542,6 +2990,12 @@ func (m *AppendResponse) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n16
 	}
+	if len(m.Continuation) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Continuation)))
+		i += copy(dAtA[i:], m.Continuation)
+	}
 	return i, nil
 }
 
@@ -2736,15 +3190,30 @@ func (m *ListResponse) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintProtocol(dAtA, i, uint64(len(m.NextPageToken)))
 		i += copy(dAtA[i:], m.NextPageToken)
 	}
-	return i, nil
-}
-
-func (m *ListResponse_Journal) Marshal() (dAtA []byte, err error) {
-	size := m.ProtoSize()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if len(m.RemovedJournals) > 0 {
+		for _, s := range m.RemovedJournals {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *ListResponse_Journal) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
 	return dAtA[:n], nil
 }
@@ -3121,6 +3590,22 @@ func (m *Header) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n33
+	if len(m.BuildVersion) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.BuildVersion)))
+		i += copy(dAtA[i:], m.BuildVersion)
+	}
+	if m.Capabilities != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Capabilities))
+	}
+	if m.AppendSuspendTimeout != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.AppendSuspendTimeout))
+	}
 	return i, nil
 }
 
@@ -3213,6 +3698,14 @@ func (m *LabelSelector) ProtoSize() (n int) {
 	n += 1 + l + sovProtocol(uint64(l))
 	l = m.Exclude.ProtoSize()
 	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.IncludeRegexp.Labels) != 0 {
+		l = m.IncludeRegexp.ProtoSize()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if len(m.ExcludeRegexp.Labels) != 0 {
+		l = m.ExcludeRegexp.ProtoSize()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
 	return n
 }
 
@@ -3236,6 +3729,22 @@ func (m *JournalSpec) ProtoSize() (n int) {
 	if m.Flags != 0 {
 		n += 1 + sovProtocol(uint64(m.Flags))
 	}
+	if m.MinZones != 0 {
+		n += 1 + sovProtocol(uint64(m.MinZones))
+	}
+	if m.MaxReplicasPerZone != 0 {
+		n += 1 + sovProtocol(uint64(m.MaxReplicasPerZone))
+	}
+	l = m.Placement.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Annotations) > 0 {
+		for k, v := range m.Annotations {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovProtocol(uint64(len(k))) + 1 + len(v) + sovProtocol(uint64(len(v)))
+			n += mapEntrySize + 1 + sovProtocol(uint64(mapEntrySize))
+		}
+	}
 	return n
 }
 
@@ -3278,6 +3787,8 @@ func (m *ProcessSpec) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProtocol(uint64(l))
 	}
+	l = m.Labels.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
 	return n
 }
 
@@ -3309,6 +3820,12 @@ func (m *BrokerSpec) ProtoSize() (n int) {
 	if m.JournalLimit != 0 {
 		n += 1 + sovProtocol(uint64(m.JournalLimit))
 	}
+	if m.CapacityWeight != 0 {
+		n += 1 + sovProtocol(uint64(m.CapacityWeight))
+	}
+	if m.Draining {
+		n += 2
+	}
 	return n
 }
 
@@ -3340,6 +3857,8 @@ func (m *Fragment) ProtoSize() (n int) {
 	if m.ModTime != 0 {
 		n += 1 + sovProtocol(uint64(m.ModTime))
 	}
+	l = m.Sum256.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
 	return n
 }
 
@@ -3361,6 +3880,27 @@ func (m *SHA1Sum) ProtoSize() (n int) {
 	return n
 }
 
+func (m *SHA256Sum) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Part1 != 0 {
+		n += 9
+	}
+	if m.Part2 != 0 {
+		n += 9
+	}
+	if m.Part3 != 0 {
+		n += 9
+	}
+	if m.Part4 != 0 {
+		n += 9
+	}
+	return n
+}
+
 func (m *ReadRequest) ProtoSize() (n int) {
 	if m == nil {
 		return 0
@@ -3387,6 +3927,9 @@ func (m *ReadRequest) ProtoSize() (n int) {
 	if m.MetadataOnly {
 		n += 2
 	}
+	if m.ContentCodec != 0 {
+		n += 1 + sovProtocol(uint64(m.ContentCodec))
+	}
 	return n
 }
 
@@ -3421,6 +3964,9 @@ func (m *ReadResponse) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProtocol(uint64(l))
 	}
+	if m.ContentCodec != 0 {
+		n += 1 + sovProtocol(uint64(m.ContentCodec))
+	}
 	return n
 }
 
@@ -3448,6 +3994,17 @@ func (m *AppendRequest) ProtoSize() (n int) {
 	if m.Offset != 0 {
 		n += 1 + sovProtocol(uint64(m.Offset))
 	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = len(m.Continuation)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Suspend {
+		n += 2
+	}
 	return n
 }
 
@@ -3466,6 +4023,10 @@ func (m *AppendResponse) ProtoSize() (n int) {
 		l = m.Commit.ProtoSize()
 		n += 1 + l + sovProtocol(uint64(l))
 	}
+	l = len(m.Continuation)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
 	return n
 }
 
@@ -3559,6 +4120,12 @@ func (m *ListResponse) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProtocol(uint64(l))
 	}
+	if len(m.RemovedJournals) > 0 {
+		for _, s := range m.RemovedJournals {
+			l = len(s)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -3737,6 +4304,16 @@ func (m *Header) ProtoSize() (n int) {
 	n += 1 + l + sovProtocol(uint64(l))
 	l = m.Etcd.ProtoSize()
 	n += 1 + l + sovProtocol(uint64(l))
+	l = len(m.BuildVersion)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Capabilities != 0 {
+		n += 1 + sovProtocol(uint64(m.Capabilities))
+	}
+	if m.AppendSuspendTimeout != 0 {
+		n += 1 + sovProtocol(uint64(m.AppendSuspendTimeout))
+	}
 	return n
 }
 
@@ -4052,6 +4629,66 @@ func (m *LabelSelector) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeRegexp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.IncludeRegexp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeRegexp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ExcludeRegexp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4229,6 +4866,192 @@ func (m *JournalSpec) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinZones", wireType)
+			}
+			m.MinZones = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinZones |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxReplicasPerZone", wireType)
+			}
+			m.MaxReplicasPerZone = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxReplicasPerZone |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Annotations == nil {
+				m.Annotations = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProtocol
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProtocol
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProtocol
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipProtocol(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Annotations[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4545,6 +5368,36 @@ func (m *ProcessSpec) Unmarshal(dAtA []byte) error {
 			}
 			m.Endpoint = Endpoint(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4752,6 +5605,45 @@ func (m *BrokerSpec) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CapacityWeight", wireType)
+			}
+			m.CapacityWeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CapacityWeight |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Draining", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Draining = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4951,21 +5843,131 @@ func (m *Fragment) Unmarshal(dAtA []byte) error {
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ModTime", wireType)
 			}
-			m.ModTime = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ModTime |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.ModTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ModTime |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum256", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Sum256.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SHA1Sum) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SHA1Sum: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SHA1Sum: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Part1", wireType)
+			}
+			m.Part1 = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Part1 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Part2", wireType)
+			}
+			m.Part2 = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Part2 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Part3", wireType)
+			}
+			m.Part3 = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Part3 = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4987,7 +5989,7 @@ func (m *Fragment) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SHA1Sum) Unmarshal(dAtA []byte) error {
+func (m *SHA256Sum) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5010,10 +6012,10 @@ func (m *SHA1Sum) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SHA1Sum: wiretype end group for non-group")
+			return fmt.Errorf("proto: SHA256Sum: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SHA1Sum: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SHA256Sum: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5037,15 +6039,25 @@ func (m *SHA1Sum) Unmarshal(dAtA []byte) error {
 			m.Part2 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
 		case 3:
-			if wireType != 5 {
+			if wireType != 1 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Part3", wireType)
 			}
 			m.Part3 = 0
-			if (iNdEx + 4) > l {
+			if (iNdEx + 8) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Part3 = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
-			iNdEx += 4
+			m.Part3 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Part4", wireType)
+			}
+			m.Part4 = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Part4 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5237,6 +6249,25 @@ func (m *ReadRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.MetadataOnly = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentCodec", wireType)
+			}
+			m.ContentCodec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContentCodec |= (CompressionCodec(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5470,6 +6501,25 @@ func (m *ReadResponse) Unmarshal(dAtA []byte) error {
 				m.Content = []byte{}
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentCodec", wireType)
+			}
+			m.ContentCodec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContentCodec |= (CompressionCodec(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5652,6 +6702,84 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Continuation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Continuation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suspend", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Suspend = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5784,6 +6912,35 @@ func (m *AppendResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Continuation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Continuation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -6421,6 +7578,35 @@ func (m *ListResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.NextPageToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedJournals", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemovedJournals = append(m.RemovedJournals, Journal(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7629,6 +8815,73 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BuildVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BuildVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			m.Capabilities = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Capabilities |= (Capability(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppendSuspendTimeout", wireType)
+			}
+			m.AppendSuspendTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppendSuspendTimeout |= (time.Duration(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7884,146 +9137,176 @@ var (
 func init() { proto.RegisterFile("protocol.proto", fileDescriptor_protocol_ffc263d8ecf7e451) }
 
 var fileDescriptor_protocol_ffc263d8ecf7e451 = []byte{
-	// 2248 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x18, 0x49, 0x73, 0x1b, 0x59,
-	0xd9, 0xad, 0x5d, 0x9f, 0x24, 0xa7, 0xfd, 0x86, 0x24, 0x8e, 0x66, 0x62, 0x79, 0x7a, 0x96, 0xf2,
-	0x64, 0x12, 0x25, 0x71, 0x80, 0x19, 0x52, 0x15, 0xa0, 0x65, 0xc9, 0x4e, 0x4f, 0x64, 0x49, 0xf5,
-	0x24, 0x27, 0x93, 0xb9, 0x74, 0xb5, 0xbb, 0x9f, 0x95, 0x26, 0xbd, 0x88, 0xee, 0x56, 0x26, 0x86,
-	0xe2, 0x1a, 0x28, 0x8a, 0x03, 0x27, 0x98, 0x1b, 0x29, 0x0e, 0xfc, 0x02, 0x4e, 0xfc, 0x82, 0x54,
-	0x71, 0x49, 0xc1, 0x85, 0x03, 0x78, 0x8a, 0xc9, 0x3f, 0x48, 0x71, 0x9a, 0x13, 0xf5, 0x96, 0x96,
-	0x5a, 0xb2, 0x1c, 0xc3, 0xc1, 0xb7, 0x7e, 0xdf, 0xf6, 0xbe, 0xe5, 0x7d, 0x5b, 0xc3, 0xf2, 0x28,
-	0xf0, 0x23, 0xdf, 0xf4, 0x9d, 0x3a, 0xfb, 0x40, 0x85, 0xf8, 0x5c, 0xbd, 0x36, 0xb4, 0xa3, 0x47,
-	0xe3, 0xfd, 0xba, 0xe9, 0xbb, 0xd7, 0x87, 0xfe, 0xd0, 0xbf, 0xce, 0x30, 0xfb, 0xe3, 0x03, 0x76,
-	0x62, 0x07, 0xf6, 0xc5, 0x19, 0xab, 0x6b, 0x43, 0xdf, 0x1f, 0x3a, 0x64, 0x4a, 0x65, 0x8d, 0x03,
-	0x23, 0xb2, 0x7d, 0x8f, 0xe3, 0x95, 0x9b, 0x90, 0x6d, 0x1b, 0xfb, 0xc4, 0x41, 0x08, 0x32, 0x9e,
-	0xe1, 0x92, 0x55, 0x69, 0x5d, 0xda, 0x28, 0x62, 0xf6, 0x8d, 0xbe, 0x03, 0xd9, 0x27, 0x86, 0x33,
-	0x26, 0xab, 0x29, 0x06, 0xe4, 0x07, 0xa5, 0x03, 0x05, 0xc6, 0xd2, 0x27, 0x11, 0x6a, 0x40, 0xce,
-	0xa1, 0xdf, 0xe1, 0xaa, 0xb4, 0x9e, 0xde, 0x28, 0x6d, 0x9e, 0xab, 0x4f, 0x14, 0x67, 0x34, 0x8d,
-	0x4b, 0x2f, 0x8e, 0x6a, 0x4b, 0xaf, 0x8f, 0x6a, 0x2b, 0x87, 0x86, 0xeb, 0xdc, 0x56, 0xae, 0xfa,
-	0xae, 0x1d, 0x11, 0x77, 0x14, 0x1d, 0x2a, 0x58, 0x70, 0x2a, 0xbf, 0x80, 0x8a, 0x90, 0xe7, 0x10,
-	0x33, 0xf2, 0x03, 0xb4, 0x09, 0x79, 0xdb, 0x33, 0x9d, 0xb1, 0xc5, 0xb5, 0x29, 0x6d, 0xa2, 0x39,
-	0xa9, 0x7d, 0x12, 0x35, 0x32, 0x54, 0x30, 0x8e, 0x09, 0x29, 0x0f, 0x79, 0xca, 0x79, 0x52, 0xa7,
-	0xf1, 0x08, 0xc2, 0xdb, 0x99, 0xaf, 0x9e, 0xd7, 0x96, 0x94, 0xbf, 0xe5, 0xa1, 0xf4, 0x99, 0x3f,
-	0x0e, 0x3c, 0xc3, 0xe9, 0x8f, 0x88, 0x89, 0xbe, 0x9b, 0x74, 0x44, 0x63, 0x7d, 0xa1, 0xee, 0xdf,
-	0x1e, 0xd5, 0xf2, 0x82, 0x47, 0xb8, 0xea, 0x13, 0x28, 0x05, 0x64, 0xe4, 0xd8, 0x26, 0x73, 0x2e,
-	0xd3, 0x21, 0xdb, 0x38, 0xbf, 0xd8, 0xf0, 0x24, 0x25, 0xea, 0x4d, 0x3c, 0x98, 0x3e, 0x51, 0xef,
-	0xf7, 0xa9, 0xde, 0x2f, 0x8f, 0x6a, 0xd2, 0xeb, 0xa3, 0xda, 0xea, 0xbc, 0xbc, 0xab, 0xb6, 0xe7,
-	0xd8, 0x1e, 0x99, 0xf8, 0x13, 0xed, 0x41, 0xe1, 0x20, 0x30, 0x86, 0x2e, 0xf1, 0xa2, 0xd5, 0x0c,
-	0x93, 0xb9, 0x36, 0x95, 0x99, 0xb0, 0xb4, 0xbe, 0x2d, 0xa8, 0xde, 0x14, 0xa4, 0x89, 0x28, 0xf4,
-	0x23, 0xc8, 0x1e, 0x38, 0xc6, 0x30, 0x5c, 0xcd, 0xad, 0x4b, 0x1b, 0x95, 0xc6, 0x47, 0x27, 0x39,
-	0x46, 0x4e, 0x5c, 0xa1, 0x6f, 0x3b, 0xc6, 0x10, 0x73, 0xbe, 0xea, 0x9f, 0x32, 0x50, 0x88, 0xaf,
-	0x44, 0xd7, 0x20, 0xe7, 0x10, 0x6f, 0x18, 0x3d, 0x62, 0x7e, 0x4e, 0x9f, 0xe4, 0x2a, 0x41, 0x84,
-	0x7c, 0x58, 0x31, 0x7d, 0x77, 0x14, 0x90, 0x30, 0xb4, 0x7d, 0x4f, 0x37, 0x7d, 0x8b, 0x98, 0xcc,
-	0xc9, 0xcb, 0x9b, 0xd5, 0xa9, 0x71, 0x5b, 0x53, 0x92, 0x2d, 0x4a, 0xd1, 0xf8, 0xf0, 0xf5, 0x51,
-	0x4d, 0xe1, 0x52, 0x8f, 0xb1, 0x27, 0xaf, 0x91, 0xcd, 0x39, 0x4e, 0xf4, 0x43, 0xc8, 0x85, 0x91,
-	0x1f, 0x10, 0x1a, 0x96, 0xf4, 0x46, 0x91, 0x49, 0x5a, 0x68, 0x6e, 0x25, 0x36, 0xa9, 0x4f, 0xc9,
-	0xb1, 0xe0, 0x42, 0x21, 0xc8, 0x01, 0x39, 0x08, 0x48, 0xf8, 0x48, 0xb7, 0xbd, 0x88, 0x04, 0x4f,
-	0x0c, 0x47, 0x04, 0xe3, 0x52, 0x9d, 0xa7, 0x64, 0x3d, 0x4e, 0xc9, 0x7a, 0x53, 0xa4, 0x64, 0xe3,
-	0x9a, 0x88, 0xc3, 0xbb, 0xfc, 0xa2, 0x79, 0x01, 0x89, 0x8b, 0xbf, 0xfa, 0xba, 0x26, 0xe1, 0x73,
-	0x82, 0x40, 0x13, 0x78, 0x74, 0x1f, 0x8a, 0x01, 0x89, 0x88, 0xc7, 0x9e, 0x60, 0xf6, 0xb4, 0xdb,
-	0x2e, 0x9f, 0x18, 0x75, 0x26, 0x7d, 0x2a, 0x0a, 0xb9, 0xb0, 0x7c, 0xe0, 0x8c, 0x93, 0xa6, 0xe4,
-	0x4e, 0x13, 0xfe, 0xb1, 0x10, 0x5e, 0xe3, 0xc2, 0x67, 0xd9, 0xe7, 0xaf, 0xaa, 0x30, 0x74, 0x6c,
-	0x86, 0xa2, 0x42, 0x86, 0xbe, 0x1b, 0xb4, 0x02, 0x95, 0x4e, 0x77, 0xa0, 0xf7, 0x7b, 0xad, 0x2d,
-	0x6d, 0x5b, 0x6b, 0x35, 0xe5, 0x25, 0x54, 0x86, 0x42, 0x57, 0xc7, 0xcd, 0x6e, 0xa7, 0xfd, 0x50,
-	0x96, 0xf8, 0xe9, 0x01, 0x66, 0xa7, 0x14, 0x02, 0xc8, 0x51, 0xdc, 0x03, 0x2c, 0x67, 0x94, 0x3f,
-	0x48, 0x50, 0xea, 0x05, 0xbe, 0x49, 0xc2, 0x90, 0x25, 0x75, 0x1d, 0x52, 0xb6, 0x25, 0xaa, 0xc9,
-	0xea, 0xf4, 0xc1, 0x24, 0x48, 0xea, 0x5a, 0x53, 0xd4, 0x87, 0x94, 0x6d, 0xa1, 0x0d, 0x28, 0x10,
-	0xcf, 0x1a, 0xf9, 0xb6, 0x17, 0xf1, 0xe2, 0xd7, 0x28, 0x7f, 0x7b, 0x54, 0x2b, 0xb4, 0x04, 0x0c,
-	0x4f, 0xb0, 0xd5, 0x1b, 0x90, 0xd2, 0x9a, 0xb4, 0x7a, 0xfe, 0xcc, 0xf7, 0x26, 0xd5, 0x93, 0x7e,
-	0xa3, 0x0b, 0x90, 0x0b, 0xc7, 0x07, 0x07, 0xf6, 0x53, 0x51, 0x3e, 0xc5, 0xe9, 0x76, 0xe6, 0x57,
-	0xcf, 0x6b, 0x92, 0xf2, 0x4b, 0x09, 0xa0, 0x11, 0xf8, 0x8f, 0x49, 0xc0, 0x14, 0x1c, 0x40, 0x79,
-	0xc4, 0x95, 0xd1, 0xc3, 0x11, 0x31, 0x85, 0xaa, 0xe7, 0x17, 0xaa, 0xda, 0xa8, 0x26, 0xea, 0xc1,
-	0xb2, 0x88, 0x5e, 0x5c, 0x05, 0x4a, 0xa3, 0x84, 0xd9, 0xef, 0x41, 0xe5, 0x27, 0x3c, 0x1b, 0x75,
-	0xc7, 0x76, 0x6d, 0x6e, 0x4b, 0x05, 0x97, 0x05, 0xb0, 0x4d, 0x61, 0xca, 0xf3, 0x54, 0x22, 0x2f,
-	0x3f, 0x80, 0xbc, 0x40, 0x8a, 0x02, 0x58, 0x4a, 0xd6, 0xba, 0x18, 0x47, 0x3b, 0xc3, 0x3e, 0x19,
-	0xda, 0xbc, 0xd0, 0xa5, 0x31, 0x3f, 0x20, 0x19, 0xd2, 0xc4, 0xb3, 0x58, 0x21, 0x4b, 0x63, 0xfa,
-	0x89, 0x3e, 0x82, 0x74, 0x38, 0x76, 0xc5, 0xcb, 0x5f, 0x99, 0x5a, 0xd3, 0xbf, 0xab, 0xde, 0xec,
-	0x8f, 0x5d, 0xe1, 0x71, 0x4a, 0x83, 0x76, 0x16, 0xa5, 0x78, 0xf6, 0xb4, 0x14, 0x5f, 0x90, 0xba,
-	0xdf, 0x87, 0xca, 0xbe, 0x61, 0x3e, 0xb6, 0xbd, 0xa1, 0xce, 0x92, 0x91, 0x3d, 0xd6, 0x62, 0x63,
-	0xe5, 0x78, 0xb2, 0x96, 0x05, 0x1d, 0x3b, 0xa1, 0x4b, 0x50, 0x70, 0x7d, 0x4b, 0x8f, 0x6c, 0x97,
-	0xac, 0xe6, 0x99, 0x09, 0x79, 0xd7, 0xb7, 0x06, 0xb6, 0x4b, 0x94, 0x7b, 0x90, 0x17, 0x1a, 0x53,
-	0xcb, 0x47, 0x46, 0x10, 0xdd, 0x64, 0xee, 0xc9, 0x61, 0x7e, 0x88, 0xa1, 0x9b, 0xcc, 0x1f, 0x02,
-	0xba, 0x19, 0x43, 0x6f, 0x31, 0x8f, 0xe4, 0x39, 0xf4, 0x96, 0xf2, 0x77, 0x09, 0x4a, 0x98, 0x18,
-	0x16, 0x26, 0x3f, 0x1d, 0x93, 0x30, 0x42, 0x1b, 0x90, 0x7b, 0x44, 0x0c, 0x8b, 0x04, 0x22, 0xe8,
-	0xf2, 0xd4, 0xda, 0xbb, 0x0c, 0x8e, 0x05, 0x3e, 0x19, 0x9c, 0xd4, 0x1b, 0x82, 0x73, 0x01, 0x72,
-	0xfe, 0xc1, 0x41, 0x48, 0x22, 0x11, 0x09, 0x71, 0x62, 0x41, 0x73, 0x7c, 0xf3, 0x31, 0x0b, 0x47,
-	0x01, 0xf3, 0x03, 0x5a, 0x87, 0xb2, 0xe5, 0xeb, 0x9e, 0x1f, 0xe9, 0xa3, 0xc0, 0x7f, 0x7a, 0xc8,
-	0x5c, 0x5e, 0xc0, 0x60, 0xf9, 0x1d, 0x3f, 0xea, 0x51, 0x08, 0x7d, 0x45, 0x2e, 0x89, 0x0c, 0xcb,
-	0x88, 0x0c, 0xdd, 0xf7, 0x9c, 0x43, 0xe6, 0xd0, 0x02, 0x2e, 0xc7, 0xc0, 0xae, 0xe7, 0x1c, 0x2a,
-	0xcf, 0x52, 0x50, 0xe6, 0x56, 0x85, 0x23, 0xdf, 0x0b, 0x09, 0x35, 0x2b, 0x8c, 0x8c, 0x68, 0x1c,
-	0x32, 0xb3, 0x96, 0x93, 0x66, 0xf5, 0x19, 0x1c, 0x0b, 0x7c, 0xc2, 0x01, 0xa9, 0x53, 0x1c, 0x70,
-	0x92, 0x65, 0x97, 0x01, 0xbe, 0x0c, 0xec, 0x88, 0xe8, 0x94, 0x8e, 0x99, 0x97, 0xc6, 0x45, 0x06,
-	0xa1, 0x02, 0x50, 0x3d, 0xd1, 0x11, 0xb3, 0xf3, 0x5d, 0x36, 0x7e, 0x12, 0x89, 0x56, 0xf7, 0x2e,
-	0x94, 0xe3, 0x6f, 0x7d, 0x1c, 0xf0, 0x6a, 0x57, 0xc4, 0xa5, 0x18, 0xb6, 0x17, 0x38, 0x68, 0x15,
-	0xf2, 0xa6, 0xef, 0xd1, 0x02, 0xc9, 0xde, 0x4a, 0x19, 0xc7, 0x47, 0xe5, 0xcf, 0x12, 0x54, 0xd4,
-	0xd1, 0x88, 0x78, 0x67, 0x17, 0xe0, 0xf9, 0x90, 0xa5, 0x8f, 0x85, 0x2c, 0xa1, 0x5e, 0x66, 0x46,
-	0xbd, 0x84, 0x0b, 0xb3, 0x49, 0x17, 0x2a, 0xbf, 0x93, 0x60, 0x39, 0x56, 0xfb, 0xff, 0x8e, 0x60,
-	0xfd, 0xb4, 0x08, 0x8a, 0x44, 0x8f, 0xed, 0xbc, 0x02, 0x39, 0xd3, 0x77, 0x69, 0x41, 0x4a, 0x9f,
-	0x18, 0x0e, 0x41, 0xa1, 0xfc, 0x47, 0x02, 0x19, 0x8b, 0x81, 0x89, 0x9c, 0x99, 0x4b, 0xeb, 0x40,
-	0x47, 0xec, 0x91, 0x1f, 0x1a, 0xce, 0x1b, 0x74, 0x9a, 0xd0, 0xbc, 0xc1, 0xc1, 0xef, 0x41, 0x45,
-	0x7c, 0xea, 0x16, 0x71, 0x22, 0x43, 0xf8, 0xb9, 0x2c, 0x80, 0x4d, 0x0a, 0x43, 0xeb, 0x50, 0x32,
-	0xcc, 0xc7, 0x9e, 0xff, 0xa5, 0x43, 0xac, 0x21, 0x11, 0x09, 0x95, 0x04, 0x29, 0xbf, 0x97, 0x60,
-	0x25, 0x61, 0xf6, 0x19, 0x26, 0x55, 0x32, 0x3b, 0xd2, 0xa7, 0x67, 0x87, 0xf2, 0x4c, 0x82, 0x52,
-	0xdb, 0x0e, 0xa3, 0x38, 0x16, 0x3f, 0x80, 0x42, 0x28, 0x46, 0x77, 0x11, 0x8d, 0x8b, 0xc7, 0x66,
-	0x58, 0x8e, 0x16, 0xaf, 0x60, 0x42, 0x4e, 0xf3, 0x76, 0x64, 0x0c, 0xc9, 0x4c, 0x73, 0x2a, 0x52,
-	0x08, 0xeb, 0x4c, 0x13, 0x74, 0xe4, 0x3f, 0x26, 0x1e, 0xd3, 0xad, 0xc8, 0xd1, 0x03, 0x0a, 0x50,
-	0xbe, 0x4e, 0x41, 0x99, 0x2b, 0x72, 0xe6, 0x0f, 0xf6, 0xc7, 0x50, 0x10, 0x2f, 0x85, 0x0f, 0x84,
-	0x33, 0x33, 0x75, 0x52, 0x87, 0x78, 0xc0, 0x8e, 0x4d, 0x8d, 0xb9, 0xd0, 0x87, 0x70, 0xce, 0x23,
-	0x4f, 0x23, 0x3d, 0x61, 0x50, 0x86, 0x19, 0x54, 0xa1, 0xe0, 0x5e, 0x6c, 0x54, 0xf5, 0xd7, 0x12,
-	0xc4, 0xaf, 0x13, 0x5d, 0x87, 0xcc, 0xe2, 0x61, 0x20, 0x31, 0x62, 0x8b, 0x8b, 0x18, 0x21, 0x2d,
-	0x5c, 0xb4, 0x85, 0x05, 0xe4, 0x89, 0x1d, 0xc6, 0x6b, 0x48, 0x1a, 0x97, 0x5c, 0xdf, 0xc2, 0x02,
-	0x84, 0x3e, 0x86, 0x6c, 0xe0, 0x8f, 0x23, 0x22, 0x42, 0x9d, 0x58, 0xd8, 0x30, 0x05, 0x0b, 0x71,
-	0x9c, 0x46, 0xf9, 0xa7, 0x04, 0x65, 0x75, 0x34, 0x72, 0x0e, 0xe3, 0x58, 0xdf, 0x81, 0xbc, 0xf9,
-	0xc8, 0xf0, 0x86, 0x24, 0x5e, 0xf8, 0x2e, 0x4f, 0xf9, 0x93, 0x84, 0xf5, 0x2d, 0x46, 0x15, 0x6f,
-	0x5c, 0x82, 0xa7, 0xfa, 0x1b, 0x09, 0x72, 0x1c, 0x83, 0xea, 0xf0, 0x16, 0x79, 0x3a, 0x22, 0x66,
-	0xa4, 0xcf, 0x68, 0xcc, 0xb6, 0x01, 0xbc, 0xc2, 0x51, 0xbb, 0x09, 0xbd, 0xaf, 0x41, 0x6e, 0x3c,
-	0x0a, 0x49, 0x10, 0x89, 0x88, 0x2d, 0xf6, 0x06, 0x16, 0x44, 0xe8, 0x3d, 0xc8, 0x59, 0xc4, 0x21,
-	0xc2, 0xce, 0xb9, 0xac, 0x17, 0x28, 0xc5, 0x66, 0x95, 0x9a, 0x2a, 0x7d, 0xd6, 0x0f, 0x48, 0xf9,
-	0x57, 0x0a, 0xe4, 0x38, 0x97, 0xc2, 0x33, 0xab, 0x62, 0xef, 0xc3, 0x32, 0x9b, 0xc4, 0xf4, 0xc9,
-	0x20, 0xc3, 0xfb, 0x64, 0x99, 0x41, 0x77, 0xf9, 0x34, 0x43, 0xdb, 0x07, 0xf1, 0xac, 0x29, 0x0d,
-	0xef, 0x97, 0x40, 0x3c, 0x2b, 0xa6, 0x58, 0xf0, 0x58, 0x79, 0x15, 0x9b, 0x7d, 0xac, 0x73, 0xf9,
-	0x4b, 0xab, 0x58, 0x36, 0x99, 0xbf, 0x3b, 0x50, 0x0e, 0xed, 0xa1, 0x67, 0x44, 0xe3, 0x80, 0x0c,
-	0x06, 0x6d, 0xd6, 0x29, 0xdf, 0xb8, 0x35, 0x14, 0x5e, 0x1c, 0xd5, 0x24, 0xb6, 0x12, 0xcc, 0x30,
-	0x1e, 0x6b, 0x78, 0x85, 0xf9, 0x86, 0xa7, 0xfc, 0x25, 0x05, 0x2b, 0x09, 0xff, 0x9e, 0x79, 0x41,
-	0xd0, 0xa0, 0x18, 0x17, 0xc4, 0xb8, 0x22, 0x7c, 0x70, 0xbc, 0x6a, 0x4e, 0x34, 0xa9, 0xeb, 0x93,
-	0x65, 0x9b, 0xcb, 0x99, 0x72, 0x9f, 0x54, 0x19, 0xe6, 0x9d, 0x5d, 0xfd, 0x1c, 0x8a, 0x13, 0x29,
-	0xe8, 0xea, 0x4c, 0x69, 0x58, 0x50, 0xb0, 0x67, 0xea, 0xc2, 0x65, 0x00, 0xea, 0x4f, 0x62, 0xb1,
-	0x71, 0x86, 0xaf, 0x23, 0x45, 0x0e, 0xd9, 0x0b, 0x1c, 0xba, 0x8b, 0x64, 0x59, 0xf6, 0xa3, 0x4f,
-	0x21, 0xef, 0x12, 0x77, 0x9f, 0x04, 0x71, 0x7e, 0x9f, 0xb6, 0x2c, 0xc5, 0xe4, 0xb4, 0x21, 0x8e,
-	0x02, 0xdb, 0x35, 0x82, 0x43, 0xfe, 0xf3, 0x03, 0xc7, 0x47, 0x74, 0x05, 0x8a, 0xf1, 0xb6, 0x14,
-	0x6f, 0xd3, 0xb3, 0xcb, 0xd4, 0x14, 0xad, 0xfc, 0x31, 0x05, 0x39, 0xee, 0x6f, 0x74, 0x07, 0x20,
-	0xde, 0x88, 0xfe, 0xe7, 0xd5, 0xad, 0x28, 0x38, 0x34, 0x6b, 0x5a, 0xe7, 0x52, 0xa7, 0xd7, 0x39,
-	0x5a, 0x68, 0x49, 0x64, 0x5a, 0xa2, 0x26, 0x9e, 0x9f, 0x8f, 0x7d, 0xbd, 0x15, 0x99, 0x56, 0xec,
-	0x50, 0x4a, 0x58, 0xfd, 0x39, 0x64, 0x28, 0x8c, 0x3a, 0xd6, 0x74, 0xc6, 0x61, 0x44, 0x82, 0x58,
-	0xc9, 0x0c, 0x2e, 0x0a, 0x88, 0x66, 0xa1, 0xb7, 0xa1, 0xc8, 0xfd, 0x43, 0xb1, 0x29, 0x86, 0x2d,
-	0x70, 0x80, 0x66, 0xa1, 0x2a, 0x14, 0x26, 0x65, 0x8f, 0xa7, 0xe9, 0xe4, 0x4c, 0x19, 0x03, 0xe3,
-	0x20, 0xd2, 0x23, 0x12, 0xf0, 0xed, 0x29, 0x83, 0x0b, 0x14, 0x30, 0x20, 0x81, 0x7b, 0xe5, 0xaf,
-	0x29, 0xc8, 0xf1, 0xe7, 0x8b, 0x72, 0x90, 0xea, 0xde, 0x93, 0x97, 0xd0, 0x79, 0x58, 0xf9, 0xac,
-	0xbb, 0x87, 0x3b, 0x6a, 0x5b, 0xa7, 0x2b, 0xf3, 0x76, 0x77, 0xaf, 0xd3, 0x94, 0x25, 0x74, 0x19,
-	0x2e, 0x75, 0xba, 0x7a, 0x8c, 0xe9, 0x61, 0x6d, 0x57, 0xc5, 0x0f, 0xf5, 0x06, 0xee, 0xde, 0x6b,
-	0x61, 0x39, 0x85, 0xd6, 0xa0, 0x4a, 0xa9, 0x4f, 0xc0, 0xa7, 0xd1, 0x05, 0x40, 0x49, 0xbc, 0x80,
-	0x67, 0xd1, 0x3a, 0xbc, 0xa3, 0x75, 0xfa, 0x7b, 0xdb, 0xdb, 0xda, 0x96, 0xd6, 0xea, 0xcc, 0x13,
-	0xf4, 0xe5, 0x0c, 0x7a, 0x07, 0x56, 0xbb, 0xdb, 0xdb, 0xfd, 0xd6, 0x80, 0xa9, 0xf3, 0xb0, 0x35,
-	0xd0, 0xd5, 0xfb, 0xaa, 0xd6, 0x56, 0x1b, 0xed, 0x96, 0x9c, 0x43, 0xe7, 0xa0, 0x44, 0xb7, 0xf6,
-	0x1d, 0x1d, 0x77, 0xf7, 0x06, 0x2d, 0x39, 0x4f, 0xd5, 0xdf, 0xc6, 0xea, 0xce, 0x2e, 0x15, 0xb6,
-	0xab, 0xf5, 0x77, 0xd5, 0xc1, 0xd6, 0x5d, 0xb9, 0x80, 0xde, 0x86, 0x8b, 0xad, 0xc1, 0x56, 0x53,
-	0x1f, 0x60, 0xb5, 0xd3, 0x57, 0xb7, 0x06, 0x5a, 0xb7, 0xa3, 0x6f, 0xab, 0x5a, 0xbb, 0xd5, 0x94,
-	0x8b, 0x54, 0x08, 0x95, 0xad, 0xb6, 0xdb, 0xdd, 0x07, 0xad, 0xa6, 0x0c, 0xe8, 0x22, 0xbc, 0xc5,
-	0xa5, 0xaa, 0xbd, 0x5e, 0xab, 0xd3, 0xd4, 0xb9, 0x02, 0x72, 0x89, 0x2a, 0xa3, 0x75, 0x9a, 0xad,
-	0xcf, 0xf5, 0xbb, 0x6a, 0x5f, 0xdf, 0xc1, 0x2d, 0x75, 0xd0, 0xc2, 0x31, 0xb6, 0x7c, 0xc5, 0x03,
-	0x79, 0x7e, 0xa9, 0x44, 0x25, 0xc8, 0x6b, 0x9d, 0xfb, 0x6a, 0x5b, 0x6b, 0xca, 0x4b, 0xa8, 0x00,
-	0x99, 0x4e, 0xb7, 0xd3, 0x92, 0x25, 0xfa, 0xb5, 0xf3, 0x85, 0xd6, 0x93, 0x53, 0xa8, 0x02, 0xc5,
-	0x2f, 0xfa, 0x03, 0xb5, 0xd3, 0x54, 0x71, 0x53, 0x4e, 0x23, 0x80, 0x5c, 0xbf, 0xa3, 0xf6, 0x7a,
-	0x0f, 0xe5, 0x0c, 0x75, 0x2a, 0x25, 0xa2, 0x17, 0xb4, 0xbb, 0x6a, 0x53, 0x6f, 0xb6, 0xb6, 0xba,
-	0xbb, 0x3d, 0xdc, 0xea, 0xf7, 0xb5, 0x6e, 0x47, 0xce, 0x6e, 0x3e, 0x4b, 0x4f, 0x1b, 0xfc, 0xf7,
-	0x20, 0x43, 0x87, 0x07, 0x74, 0x7e, 0x7e, 0x98, 0x60, 0xfd, 0xa1, 0x7a, 0x61, 0xf1, 0x8c, 0x81,
-	0x3e, 0x85, 0x2c, 0xeb, 0x5b, 0xe8, 0xc2, 0xe2, 0xee, 0x5b, 0xbd, 0x78, 0x0c, 0x2e, 0x38, 0x3f,
-	0x81, 0x0c, 0x5d, 0xd2, 0x92, 0x17, 0x26, 0x56, 0xd1, 0xe4, 0x85, 0xc9, 0x5d, 0xee, 0x86, 0x84,
-	0xee, 0x40, 0x8e, 0x6f, 0x07, 0x68, 0x56, 0xf6, 0x74, 0xcd, 0xa9, 0xae, 0x1e, 0x47, 0x70, 0xf6,
-	0x0d, 0x09, 0xdd, 0x85, 0xe2, 0x64, 0x98, 0x45, 0xd5, 0xe4, 0x2d, 0xb3, 0x83, 0x7d, 0xf5, 0xed,
-	0x85, 0xb8, 0x58, 0xce, 0x0d, 0x2a, 0xa9, 0x42, 0x7d, 0x31, 0xa9, 0xb0, 0x49, 0x69, 0xf3, 0x0d,
-	0x36, 0x29, 0xed, 0x58, 0x49, 0x6e, 0xbc, 0xf3, 0xe2, 0xdf, 0x6b, 0x4b, 0x2f, 0xbe, 0x59, 0x93,
-	0x5e, 0x7e, 0xb3, 0x26, 0xfd, 0xf6, 0xd5, 0xda, 0xd2, 0xf3, 0x57, 0x6b, 0xd2, 0xcb, 0x57, 0x6b,
-	0x4b, 0xff, 0x78, 0xb5, 0xb6, 0xb4, 0x9f, 0x63, 0x9c, 0xb7, 0xfe, 0x1b, 0x00, 0x00, 0xff, 0xff,
-	0xa4, 0x57, 0x56, 0x98, 0x8a, 0x17, 0x00, 0x00,
+	// 2733 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x6f, 0x1b, 0xc7,
+	0x15, 0xd7, 0xf2, 0x9b, 0x8f, 0xa4, 0xb4, 0x9a, 0xc4, 0x32, 0x4d, 0xc7, 0xa2, 0xb2, 0x49, 0x5c,
+	0xc5, 0x89, 0x19, 0x5b, 0x4e, 0x9c, 0xd4, 0x6d, 0x92, 0x92, 0x22, 0x25, 0x33, 0xa1, 0x48, 0x62,
+	0x49, 0xd9, 0x71, 0x80, 0x62, 0xb1, 0xda, 0x1d, 0x51, 0x5b, 0xef, 0x57, 0x77, 0x97, 0x8e, 0xd4,
+	0x5e, 0x8b, 0xb6, 0x28, 0x7a, 0x28, 0x8a, 0x16, 0xc9, 0x31, 0xa7, 0xfe, 0x11, 0x39, 0x17, 0xa8,
+	0x0f, 0x3d, 0x04, 0xe8, 0xa5, 0x87, 0x56, 0x45, 0x62, 0xa0, 0x7f, 0x80, 0xd1, 0x93, 0x2f, 0x2d,
+	0xe6, 0x63, 0xc9, 0x25, 0x45, 0x89, 0x49, 0x0a, 0xdd, 0x76, 0xde, 0xd7, 0xbc, 0xf7, 0x9b, 0x37,
+	0x6f, 0xde, 0x5b, 0x58, 0x74, 0x3d, 0x27, 0x70, 0x34, 0xc7, 0xac, 0xd0, 0x0f, 0x94, 0x09, 0xd7,
+	0xa5, 0xeb, 0x03, 0x23, 0x38, 0x18, 0xee, 0x55, 0x34, 0xc7, 0x7a, 0x63, 0xe0, 0x0c, 0x9c, 0x37,
+	0x28, 0x67, 0x6f, 0xb8, 0x4f, 0x57, 0x74, 0x41, 0xbf, 0x98, 0x62, 0x69, 0x75, 0xe0, 0x38, 0x03,
+	0x13, 0x8f, 0xa5, 0xf4, 0xa1, 0xa7, 0x06, 0x86, 0x63, 0x33, 0xbe, 0x74, 0x13, 0x92, 0x2d, 0x75,
+	0x0f, 0x9b, 0x08, 0x41, 0xc2, 0x56, 0x2d, 0x5c, 0x14, 0xd6, 0x84, 0xf5, 0xac, 0x4c, 0xbf, 0xd1,
+	0xf3, 0x90, 0x7c, 0xa4, 0x9a, 0x43, 0x5c, 0x8c, 0x51, 0x22, 0x5b, 0x48, 0x6d, 0xc8, 0x50, 0x95,
+	0x1e, 0x0e, 0x50, 0x0d, 0x52, 0x26, 0xf9, 0xf6, 0x8b, 0xc2, 0x5a, 0x7c, 0x3d, 0xb7, 0xb1, 0x54,
+	0x19, 0x39, 0x4e, 0x65, 0x6a, 0x97, 0x1e, 0x1f, 0x97, 0x17, 0x9e, 0x1e, 0x97, 0x97, 0x8f, 0x54,
+	0xcb, 0xbc, 0x23, 0xbd, 0xee, 0x58, 0x46, 0x80, 0x2d, 0x37, 0x38, 0x92, 0x64, 0xae, 0x29, 0xfd,
+	0x57, 0x80, 0x02, 0x37, 0x68, 0x62, 0x2d, 0x70, 0x3c, 0xb4, 0x01, 0x69, 0xc3, 0xd6, 0xcc, 0xa1,
+	0xce, 0xdc, 0xc9, 0x6d, 0xa0, 0x29, 0xb3, 0x3d, 0x1c, 0xd4, 0x12, 0xc4, 0xb2, 0x1c, 0x0a, 0x12,
+	0x1d, 0x7c, 0xc8, 0x74, 0x62, 0xf3, 0x74, 0xb8, 0x20, 0x7a, 0x1f, 0x16, 0xb9, 0xba, 0xe2, 0xe1,
+	0x01, 0x3e, 0x74, 0x8b, 0xf1, 0x39, 0xaa, 0x05, 0x2e, 0x2f, 0x53, 0x71, 0x62, 0x00, 0x1f, 0x4e,
+	0x18, 0x48, 0xcc, 0x33, 0x80, 0x0f, 0x23, 0x06, 0xee, 0x24, 0x3e, 0xfb, 0xbc, 0xbc, 0x20, 0x7d,
+	0x05, 0x90, 0xfb, 0xc0, 0x19, 0x7a, 0xb6, 0x6a, 0xf6, 0x5c, 0xac, 0xa1, 0x37, 0xa3, 0x67, 0x51,
+	0x5b, 0x9b, 0x09, 0xdf, 0xb3, 0xe3, 0x72, 0x9a, 0xeb, 0xf0, 0xd3, 0x7a, 0x1b, 0x72, 0x1e, 0x76,
+	0x4d, 0x43, 0xa3, 0xe7, 0x4b, 0x51, 0x48, 0xd6, 0x2e, 0xcc, 0xc6, 0x3e, 0x2a, 0x89, 0xba, 0xa3,
+	0x43, 0x3c, 0x3d, 0xfc, 0x97, 0x89, 0xf7, 0x5f, 0x1e, 0x97, 0x85, 0xa7, 0xc7, 0xe5, 0xe2, 0xb4,
+	0xbd, 0xd7, 0x0d, 0xdb, 0x34, 0x6c, 0x3c, 0x3a, 0x52, 0xb4, 0x0b, 0x99, 0x7d, 0x4f, 0x1d, 0x58,
+	0xd8, 0x0e, 0x38, 0x22, 0xab, 0x63, 0x9b, 0x91, 0x48, 0x2b, 0x5b, 0x5c, 0xea, 0xac, 0x3c, 0x19,
+	0x99, 0x42, 0xef, 0x43, 0x72, 0xdf, 0x54, 0x07, 0x7e, 0x31, 0xb5, 0x26, 0xac, 0x17, 0x6a, 0xaf,
+	0x9e, 0x06, 0x8c, 0x18, 0xd9, 0x42, 0xd9, 0x32, 0xd5, 0x81, 0xcc, 0xf4, 0xd0, 0x0f, 0x20, 0x6b,
+	0x19, 0xb6, 0xf2, 0x33, 0xc7, 0xc6, 0x7e, 0x31, 0x4d, 0x8d, 0xac, 0x3e, 0x3d, 0x2e, 0x97, 0x98,
+	0x91, 0x11, 0x6b, 0x62, 0x77, 0xcb, 0xb0, 0x3f, 0x26, 0x44, 0xf4, 0x63, 0xb8, 0x60, 0xa9, 0x87,
+	0x0a, 0x47, 0xce, 0x57, 0x5c, 0xec, 0x51, 0xf1, 0x62, 0x86, 0x1a, 0xba, 0xf6, 0xf4, 0xb8, 0x7c,
+	0x95, 0x1b, 0x9a, 0x25, 0x16, 0x35, 0x8a, 0x2c, 0xf5, 0x50, 0xe6, 0x02, 0x5d, 0xec, 0x11, 0xfb,
+	0xa8, 0x0b, 0x59, 0xd7, 0x54, 0x35, 0x4c, 0x41, 0xcb, 0x52, 0xd0, 0x2e, 0x9e, 0x38, 0x08, 0x76,
+	0x41, 0xce, 0x42, 0x6b, 0x6c, 0x04, 0x29, 0x90, 0x53, 0x6d, 0xdb, 0x09, 0xe8, 0x29, 0xfb, 0x45,
+	0xa0, 0x37, 0xf4, 0xea, 0xec, 0x83, 0xa8, 0x8e, 0x05, 0x1b, 0x76, 0xe0, 0x1d, 0x9d, 0x9a, 0x38,
+	0x11, 0x8b, 0xa5, 0x3f, 0x25, 0x20, 0x13, 0x9e, 0x20, 0xba, 0x0e, 0x29, 0x13, 0xdb, 0x83, 0xe0,
+	0x80, 0xa6, 0x6d, 0xfc, 0x34, 0x03, 0x5c, 0x08, 0x39, 0xb0, 0xac, 0x39, 0x96, 0xeb, 0x61, 0xdf,
+	0x37, 0x1c, 0x5b, 0xd1, 0x1c, 0x1d, 0x6b, 0x34, 0x67, 0x17, 0x37, 0x4a, 0x63, 0x17, 0x37, 0xc7,
+	0x22, 0x9b, 0x44, 0xa2, 0x76, 0xf5, 0xe9, 0x71, 0x59, 0x62, 0x56, 0x4f, 0xa8, 0x47, 0xb7, 0x11,
+	0xb5, 0x29, 0x4d, 0xf4, 0x1e, 0xa4, 0xfc, 0xc0, 0xf1, 0x30, 0xc9, 0xf2, 0xf8, 0x7a, 0xb6, 0x76,
+	0x75, 0xa6, 0x7f, 0xcf, 0x8e, 0xcb, 0x85, 0x30, 0xa4, 0x1e, 0x11, 0x97, 0xb9, 0x16, 0xf2, 0x41,
+	0xf4, 0xf0, 0xbe, 0x87, 0xfd, 0x03, 0xc5, 0xb0, 0x03, 0xec, 0x3d, 0x52, 0x4d, 0x9e, 0xdb, 0x97,
+	0x2a, 0xac, 0xc8, 0x56, 0xc2, 0x22, 0x5b, 0xa9, 0xf3, 0x22, 0x5b, 0xbb, 0xce, 0x0f, 0xea, 0x45,
+	0xb6, 0xd1, 0xb4, 0x81, 0xc8, 0xc6, 0x9f, 0xfd, 0xab, 0x2c, 0xc8, 0x4b, 0x5c, 0xa0, 0xc9, 0xf9,
+	0xe8, 0x1e, 0x64, 0x3d, 0x1c, 0x60, 0x9b, 0xde, 0xe8, 0xe4, 0xbc, 0xdd, 0xae, 0x9c, 0x9a, 0x16,
+	0xd4, 0xfa, 0xd8, 0x14, 0xb2, 0x60, 0x71, 0xdf, 0x1c, 0x46, 0x43, 0x49, 0xcd, 0x33, 0xfe, 0x1a,
+	0x37, 0x5e, 0x66, 0xc6, 0x27, 0xd5, 0xa7, 0xb7, 0x2a, 0x50, 0x76, 0x18, 0x46, 0xe9, 0x3d, 0x10,
+	0xa7, 0x13, 0x0c, 0x89, 0x10, 0x7f, 0x88, 0x8f, 0xf8, 0x7b, 0x43, 0x3e, 0x67, 0x3f, 0x37, 0x77,
+	0x62, 0xef, 0x08, 0x52, 0x15, 0x12, 0xe4, 0x1a, 0xa3, 0x65, 0x28, 0xb4, 0x3b, 0x7d, 0xa5, 0xd7,
+	0x6d, 0x6c, 0x36, 0xb7, 0x9a, 0x8d, 0xba, 0xb8, 0x80, 0xf2, 0x90, 0xe9, 0x28, 0x72, 0xbd, 0xd3,
+	0x6e, 0x3d, 0x10, 0x05, 0xb6, 0xba, 0x2f, 0xd3, 0x55, 0x0c, 0x01, 0xa4, 0x08, 0xef, 0xbe, 0x2c,
+	0x26, 0xa4, 0x7f, 0x0b, 0x90, 0xeb, 0x7a, 0x8e, 0x86, 0x7d, 0x9f, 0xd6, 0xd8, 0x0a, 0xc4, 0x0c,
+	0x9d, 0x3f, 0x2f, 0xc5, 0x71, 0xc2, 0x45, 0x44, 0x2a, 0xcd, 0x3a, 0x2f, 0xda, 0x31, 0x43, 0x47,
+	0xeb, 0x90, 0xc1, 0xb6, 0xee, 0x3a, 0x86, 0x1d, 0x30, 0xff, 0x6a, 0xf9, 0x67, 0xc7, 0xe5, 0x4c,
+	0x83, 0xd3, 0xe4, 0x11, 0x17, 0x35, 0xbe, 0x41, 0x39, 0x9d, 0xff, 0x2c, 0x96, 0x6e, 0x40, 0xac,
+	0x59, 0x27, 0xcf, 0x32, 0xad, 0x31, 0xfc, 0x59, 0x26, 0xdf, 0x68, 0x05, 0x52, 0xfe, 0x70, 0x7f,
+	0xdf, 0x38, 0xe4, 0x40, 0xf1, 0xd5, 0x9d, 0xc4, 0xaf, 0x3f, 0x2f, 0x0b, 0xd2, 0x5f, 0x05, 0x80,
+	0x9a, 0xe7, 0x3c, 0xc4, 0x1e, 0x8d, 0xb3, 0x0f, 0x79, 0x97, 0xc5, 0xa4, 0xf8, 0x2e, 0xd6, 0x78,
+	0xc4, 0x17, 0x66, 0x46, 0x5c, 0x2b, 0x45, 0xaa, 0xfc, 0x22, 0x77, 0x2d, 0xac, 0xed, 0x39, 0x37,
+	0x82, 0xde, 0x4b, 0x50, 0xf8, 0x09, 0xab, 0x1e, 0x8a, 0x69, 0x58, 0x06, 0x83, 0xa4, 0x20, 0xe7,
+	0x39, 0xb1, 0x45, 0x68, 0xe8, 0x7b, 0xb0, 0xa4, 0xa9, 0xae, 0xaa, 0x19, 0xc1, 0x91, 0xf2, 0x09,
+	0x36, 0x06, 0x07, 0x01, 0x45, 0xa4, 0x20, 0x2f, 0x86, 0xe4, 0xfb, 0x94, 0x8a, 0x4a, 0x90, 0xd1,
+	0x3d, 0xd5, 0xb0, 0x0d, 0x7b, 0x40, 0xaf, 0x54, 0x46, 0x1e, 0xad, 0xa5, 0xbf, 0xc5, 0x22, 0x35,
+	0xe6, 0x15, 0x48, 0xf3, 0x1d, 0xf8, 0xdb, 0x98, 0x8b, 0x3e, 0x83, 0x21, 0x8f, 0x24, 0xd2, 0x1e,
+	0x1e, 0x18, 0xec, 0x0d, 0x8c, 0xcb, 0x6c, 0x41, 0x12, 0x0e, 0xdb, 0x3a, 0x75, 0x21, 0x2e, 0x93,
+	0x4f, 0xf4, 0x2a, 0xc4, 0xfd, 0xa1, 0xc5, 0x6f, 0xf1, 0xf2, 0x18, 0x92, 0xde, 0xdd, 0xea, 0xcd,
+	0xde, 0xd0, 0xe2, 0xa7, 0x4f, 0x64, 0xd0, 0xf6, 0xac, 0x72, 0x95, 0x9c, 0x57, 0xae, 0x66, 0x94,
+	0xa1, 0xdb, 0x50, 0xd8, 0x53, 0xb5, 0x87, 0x86, 0x3d, 0x50, 0x68, 0x61, 0xa1, 0x17, 0x2f, 0x5b,
+	0x5b, 0x3e, 0x59, 0x78, 0xf2, 0x5c, 0x8e, 0xae, 0xd0, 0x25, 0xc8, 0x58, 0x8e, 0xae, 0x04, 0x86,
+	0x85, 0xe9, 0xcb, 0x15, 0x97, 0xd3, 0x96, 0xa3, 0xf7, 0x0d, 0x0b, 0xa3, 0x9b, 0x24, 0x1f, 0xac,
+	0x8d, 0xb7, 0x6e, 0xd3, 0x97, 0x28, 0xb7, 0xf1, 0xdc, 0x44, 0x24, 0x1b, 0x6f, 0xdd, 0x1e, 0xc7,
+	0xc2, 0x05, 0xa5, 0x0f, 0x21, 0xcd, 0x83, 0x24, 0x60, 0xb9, 0xaa, 0x17, 0xdc, 0xa4, 0x88, 0xa6,
+	0x64, 0xb6, 0x08, 0xa9, 0x1b, 0xc5, 0xd8, 0x98, 0xba, 0x11, 0x52, 0x6f, 0x51, 0x10, 0xd3, 0x8c,
+	0x7a, 0x4b, 0xd2, 0x20, 0x3b, 0xda, 0xe7, 0xbb, 0x9b, 0xe3, 0xd4, 0x5b, 0x21, 0xf5, 0xcd, 0x62,
+	0x62, 0x4c, 0x7d, 0x53, 0xfa, 0x43, 0x0c, 0x72, 0x32, 0x56, 0x75, 0x19, 0xff, 0x74, 0x88, 0xfd,
+	0x00, 0xad, 0x43, 0xea, 0x00, 0xab, 0x3a, 0xf6, 0x78, 0x46, 0x8b, 0xe3, 0xa0, 0xef, 0x52, 0xba,
+	0xcc, 0xf9, 0xd1, 0xa4, 0x89, 0x9d, 0x91, 0x34, 0x2b, 0x90, 0x72, 0xf6, 0xf7, 0x7d, 0x1c, 0xf0,
+	0x0c, 0xe1, 0x2b, 0x9a, 0x4c, 0xa6, 0xa3, 0x3d, 0xe4, 0x99, 0xc9, 0x16, 0x68, 0x0d, 0xf2, 0xba,
+	0xa3, 0xd8, 0x4e, 0xa0, 0xb8, 0x9e, 0x73, 0x78, 0x44, 0x53, 0x21, 0x23, 0x83, 0xee, 0xb4, 0x9d,
+	0xa0, 0x4b, 0x28, 0xe4, 0x8a, 0x58, 0x38, 0x50, 0x75, 0x35, 0x50, 0x15, 0xc7, 0x36, 0x8f, 0xe8,
+	0x41, 0x67, 0xe4, 0x7c, 0x48, 0xec, 0xd8, 0xe6, 0x11, 0x7a, 0x1f, 0x0a, 0x9a, 0x63, 0x93, 0xaa,
+	0xcc, 0x53, 0x2a, 0x3d, 0x37, 0xa5, 0xf2, 0x5c, 0x81, 0xae, 0xa4, 0xbf, 0xc4, 0x20, 0xcf, 0x60,
+	0xf1, 0x5d, 0xc7, 0xf6, 0x31, 0xc1, 0xc5, 0x0f, 0xd4, 0x60, 0xe8, 0x53, 0x5c, 0x16, 0xa3, 0xb8,
+	0xf4, 0x28, 0x5d, 0xe6, 0xfc, 0x08, 0x82, 0xb1, 0x39, 0x08, 0x9e, 0x06, 0xcd, 0x15, 0x80, 0x4f,
+	0x3c, 0x23, 0xc0, 0x0a, 0x91, 0xa3, 0xf8, 0xc4, 0xe5, 0x2c, 0xa5, 0x10, 0x03, 0xa8, 0x12, 0xe9,
+	0x02, 0x93, 0xd3, 0xa5, 0x30, 0xcc, 0xf5, 0x48, 0x7b, 0xf7, 0x22, 0xe4, 0xc3, 0x6f, 0x65, 0xe8,
+	0xb1, 0x27, 0x29, 0x2b, 0xe7, 0x42, 0xda, 0xae, 0x67, 0xa2, 0x22, 0xa4, 0x79, 0xf8, 0x14, 0xa9,
+	0xbc, 0x1c, 0x2e, 0x4f, 0x22, 0x99, 0xf9, 0x96, 0x48, 0xfe, 0x31, 0x06, 0x85, 0xaa, 0xeb, 0x62,
+	0xfb, 0xfc, 0x52, 0x6c, 0x3a, 0x69, 0xe2, 0x27, 0x92, 0x66, 0x8c, 0x74, 0x72, 0x02, 0xe9, 0x48,
+	0xdc, 0x89, 0xc9, 0xb8, 0x5f, 0x84, 0x30, 0x0c, 0x25, 0x38, 0x72, 0x71, 0x08, 0x1a, 0xa7, 0xf5,
+	0x8f, 0x5c, 0x8c, 0x24, 0x26, 0x62, 0xd8, 0x43, 0x36, 0x19, 0xa4, 0xa9, 0xc8, 0x04, 0x8d, 0x6c,
+	0xe0, 0x0f, 0x7d, 0x12, 0x3d, 0x05, 0x2e, 0x23, 0x87, 0x4b, 0xe9, 0x0b, 0x01, 0x16, 0x43, 0x5c,
+	0xbe, 0x75, 0x8e, 0x55, 0xe6, 0xe5, 0x58, 0x58, 0x97, 0x38, 0x90, 0xd7, 0x20, 0xa5, 0x39, 0x16,
+	0x79, 0x50, 0xe2, 0xa7, 0x26, 0x0c, 0x97, 0x38, 0x11, 0x56, 0xe2, 0x64, 0x58, 0xd2, 0x7f, 0x04,
+	0x10, 0x79, 0xa3, 0x1d, 0xe0, 0x73, 0x3b, 0xd7, 0x0a, 0x90, 0xf9, 0xdc, 0x75, 0x7c, 0xd5, 0x3c,
+	0xc3, 0xef, 0x91, 0xcc, 0x19, 0xa7, 0xf9, 0xd2, 0x38, 0x8b, 0x75, 0x6c, 0x06, 0x2a, 0x4f, 0x83,
+	0xf0, 0x88, 0xeb, 0x84, 0x86, 0xd6, 0x20, 0xa7, 0x6a, 0x0f, 0x6d, 0xe7, 0x13, 0x13, 0xeb, 0x03,
+	0xcc, 0xeb, 0x4a, 0x94, 0x24, 0x7d, 0x2a, 0xc0, 0x72, 0x24, 0xec, 0x73, 0x2c, 0x0d, 0xd1, 0x3b,
+	0x1e, 0x9f, 0x7f, 0xc7, 0xa5, 0x5f, 0x0a, 0x90, 0x6b, 0x19, 0x7e, 0x10, 0x9e, 0xc5, 0xf7, 0x21,
+	0xe3, 0xf3, 0xa9, 0xa6, 0x28, 0x9c, 0x3d, 0xf4, 0xb0, 0x4c, 0x19, 0x89, 0x93, 0xea, 0xe3, 0xaa,
+	0x03, 0x3c, 0xd1, 0x80, 0x64, 0x09, 0x85, 0x75, 0x1f, 0x21, 0x3b, 0x70, 0x1e, 0x62, 0x9b, 0xfa,
+	0x96, 0x65, 0xec, 0x3e, 0x21, 0x48, 0x9f, 0xc6, 0x21, 0xcf, 0x1c, 0x39, 0xf7, 0xa4, 0xfe, 0x11,
+	0x64, 0x78, 0xa6, 0xb0, 0xd9, 0x63, 0x62, 0x1a, 0x8e, 0xfa, 0x10, 0x4e, 0x64, 0x61, 0xa8, 0xa1,
+	0x16, 0xba, 0x0a, 0x4b, 0x36, 0x3e, 0x0c, 0x94, 0x48, 0x40, 0x2c, 0xdb, 0x0b, 0x84, 0xdc, 0x0d,
+	0x83, 0x42, 0xb7, 0xc9, 0x8c, 0x62, 0x39, 0x8f, 0xb0, 0xae, 0x8c, 0x76, 0x4c, 0xae, 0xc5, 0xa7,
+	0x13, 0x77, 0x89, 0x0b, 0xf1, 0xb5, 0x5f, 0xfa, 0x8d, 0x00, 0x21, 0x13, 0xbd, 0x01, 0x89, 0xd9,
+	0x8d, 0x62, 0x64, 0x5c, 0xe4, 0x0e, 0x52, 0x41, 0x52, 0x81, 0x48, 0x67, 0xe2, 0xe1, 0x47, 0x86,
+	0x1f, 0xfe, 0x78, 0x88, 0xcb, 0x39, 0xcb, 0xd1, 0x65, 0x4e, 0x42, 0xaf, 0x41, 0xd2, 0x73, 0x86,
+	0x01, 0xe6, 0x29, 0x12, 0xf9, 0x4b, 0x24, 0x13, 0x32, 0x37, 0xc7, 0x64, 0xa4, 0x7f, 0x08, 0x90,
+	0xaf, 0xba, 0xae, 0x79, 0x14, 0xe6, 0xc8, 0xbb, 0x90, 0xd6, 0x0e, 0x54, 0x7b, 0x80, 0xc3, 0xbf,
+	0x4c, 0x57, 0xc6, 0xfa, 0x51, 0xc1, 0xca, 0x26, 0x95, 0x0a, 0xff, 0xf2, 0x70, 0x9d, 0xd2, 0x6f,
+	0x05, 0x48, 0x31, 0x0e, 0xaa, 0xc0, 0x73, 0xf8, 0xd0, 0xc5, 0x5a, 0xa0, 0x4c, 0x78, 0x4c, 0x07,
+	0x56, 0x79, 0x99, 0xb1, 0x76, 0x22, 0x7e, 0x5f, 0x87, 0xd4, 0xd0, 0xf5, 0xb1, 0x17, 0x14, 0x63,
+	0x67, 0xa0, 0x21, 0x73, 0x21, 0xf4, 0x12, 0xa4, 0x74, 0x6c, 0x62, 0x1e, 0xe7, 0x14, 0xe8, 0x9c,
+	0x25, 0x19, 0x50, 0xe0, 0x4e, 0x9f, 0x77, 0xe2, 0x49, 0xff, 0x8c, 0x81, 0x18, 0xde, 0x41, 0xff,
+	0xdc, 0xaa, 0xdf, 0xcb, 0xb0, 0x48, 0x1b, 0x6c, 0x65, 0xd4, 0x9f, 0xb2, 0x2e, 0x21, 0x4f, 0xa9,
+	0x3b, 0xbc, 0x49, 0x5d, 0x83, 0x3c, 0xb6, 0xf5, 0xb1, 0x0c, 0xeb, 0x16, 0x00, 0xdb, 0x7a, 0x28,
+	0x31, 0x23, 0xc9, 0x59, 0xf5, 0x9b, 0x4a, 0xf2, 0xc9, 0x7b, 0x4f, 0xaa, 0x5f, 0x32, 0x7a, 0xef,
+	0xb7, 0x21, 0xef, 0x1b, 0x03, 0x5b, 0x0d, 0x86, 0x1e, 0xee, 0xf7, 0x5b, 0xc5, 0xf4, 0xbc, 0xc1,
+	0x36, 0xf3, 0xf8, 0xb8, 0x2c, 0xd0, 0xa9, 0x75, 0x42, 0xf1, 0xc4, 0x6b, 0x9d, 0x99, 0x7e, 0xad,
+	0xa5, 0x2f, 0x62, 0xb0, 0x1c, 0xc1, 0xf7, 0xdc, 0x0b, 0x49, 0x13, 0xb2, 0x61, 0x21, 0x0d, 0x2b,
+	0xc9, 0x2b, 0x27, 0xab, 0xed, 0xc8, 0x93, 0x8a, 0x12, 0x92, 0xb8, 0x9d, 0xb1, 0xf6, 0x69, 0x15,
+	0x65, 0x1a, 0xec, 0xd2, 0x47, 0x90, 0x1d, 0x59, 0x41, 0xaf, 0x4f, 0x94, 0x86, 0x19, 0x85, 0x7e,
+	0xa2, 0x2e, 0x5c, 0x01, 0x20, 0x78, 0x62, 0x9d, 0x36, 0x73, 0x6c, 0x54, 0xcd, 0x32, 0xca, 0xae,
+	0x67, 0x4a, 0xbf, 0x12, 0x20, 0x49, 0x6f, 0x3f, 0x7a, 0x07, 0xd2, 0x16, 0xb6, 0xf6, 0xb0, 0x17,
+	0xde, 0xef, 0x79, 0xf3, 0x78, 0x28, 0x4e, 0x1e, 0x52, 0xd7, 0x33, 0x2c, 0xd5, 0x3b, 0x62, 0xbf,
+	0x3b, 0xe5, 0x70, 0x89, 0xae, 0x41, 0x36, 0x1c, 0xc8, 0xc3, 0x1f, 0x3e, 0x93, 0xf3, 0xfa, 0x98,
+	0x2d, 0xfd, 0x39, 0x0e, 0x29, 0x86, 0x37, 0x7a, 0x17, 0x20, 0x9c, 0x96, 0xbf, 0xf1, 0xdf, 0x81,
+	0x2c, 0xd7, 0x68, 0xea, 0xe3, 0x3a, 0x17, 0x9b, 0x5f, 0xe7, 0x48, 0xa1, 0xc5, 0x81, 0xa6, 0x17,
+	0xe3, 0xd3, 0xa5, 0x85, 0xf9, 0x52, 0x69, 0x04, 0x9a, 0x1e, 0x02, 0x4a, 0x04, 0x49, 0x73, 0xb0,
+	0x37, 0x34, 0x4c, 0x5d, 0x79, 0x84, 0x3d, 0x3f, 0xd2, 0xf1, 0x50, 0xe2, 0x3d, 0x46, 0x43, 0x1b,
+	0x90, 0x27, 0xd3, 0xf5, 0x9e, 0x61, 0x1a, 0x81, 0x81, 0x7d, 0x7a, 0x85, 0x12, 0xb5, 0xc5, 0x67,
+	0xc7, 0x65, 0xd8, 0x0c, 0xe9, 0x47, 0xf2, 0x84, 0x0c, 0xda, 0x86, 0x15, 0x95, 0x76, 0x78, 0x0a,
+	0x6f, 0xfa, 0xe8, 0x15, 0x75, 0x86, 0xec, 0x76, 0xc5, 0xd9, 0x70, 0x4a, 0x48, 0xa3, 0x4b, 0x23,
+	0x3f, 0xcf, 0x14, 0x7a, 0x4c, 0xbe, 0xcf, 0xc4, 0x4b, 0x3f, 0x87, 0x04, 0xf1, 0x9a, 0x1c, 0xbd,
+	0x66, 0x0e, 0xfd, 0x00, 0x7b, 0x21, 0x8c, 0x09, 0x39, 0xcb, 0x29, 0x4d, 0x1d, 0x5d, 0x86, 0x2c,
+	0x3b, 0x41, 0xc2, 0x8d, 0x51, 0x6e, 0x86, 0x11, 0x9a, 0x3a, 0xf9, 0x19, 0x30, 0x2a, 0xcc, 0xac,
+	0x90, 0x8c, 0xd6, 0x44, 0xd1, 0x53, 0xf7, 0x03, 0x25, 0xc0, 0x1e, 0x1b, 0xdb, 0x13, 0x72, 0x86,
+	0x10, 0xfa, 0xd8, 0xb3, 0xae, 0xfd, 0x22, 0x0e, 0x29, 0x76, 0xc1, 0x50, 0x0a, 0x62, 0x9d, 0x0f,
+	0xc5, 0x05, 0x74, 0x01, 0x96, 0x3f, 0xe8, 0xec, 0xca, 0xed, 0x6a, 0x4b, 0x21, 0xff, 0x8d, 0xb6,
+	0x3a, 0xbb, 0xed, 0xba, 0x28, 0xa0, 0x2b, 0x70, 0xa9, 0xdd, 0x51, 0x42, 0x4e, 0x57, 0x6e, 0xee,
+	0x54, 0xe5, 0x07, 0x4a, 0x4d, 0xee, 0x7c, 0xd8, 0x90, 0xc5, 0x18, 0x5a, 0x85, 0x12, 0x91, 0x3e,
+	0x85, 0x1f, 0x47, 0x2b, 0x80, 0xa2, 0x7c, 0x4e, 0x4f, 0xa2, 0x35, 0x78, 0xa1, 0xd9, 0xee, 0xed,
+	0x6e, 0x6d, 0x35, 0x37, 0x9b, 0x8d, 0xf6, 0xb4, 0x40, 0x4f, 0x4c, 0xa0, 0x17, 0xa0, 0xd8, 0xd9,
+	0xda, 0xea, 0x35, 0xfa, 0xd4, 0x9d, 0x07, 0x8d, 0xbe, 0x52, 0xbd, 0x57, 0x6d, 0xb6, 0xaa, 0xb5,
+	0x56, 0x43, 0x4c, 0xa1, 0x25, 0xc8, 0x91, 0x5f, 0x57, 0xdb, 0x8a, 0xdc, 0xd9, 0xed, 0x37, 0xc4,
+	0x34, 0x71, 0x7f, 0x4b, 0xae, 0x6e, 0xef, 0x10, 0x63, 0x3b, 0xcd, 0xde, 0x4e, 0xb5, 0xbf, 0x79,
+	0x57, 0xcc, 0xa0, 0xcb, 0x70, 0xb1, 0xd1, 0xdf, 0xac, 0x2b, 0x7d, 0xb9, 0xda, 0xee, 0x55, 0x37,
+	0xfb, 0xcd, 0x4e, 0x5b, 0xd9, 0xaa, 0x36, 0x5b, 0x8d, 0xba, 0x98, 0x25, 0x46, 0x88, 0xed, 0x6a,
+	0xab, 0xd5, 0xb9, 0xdf, 0xa8, 0x8b, 0x80, 0x2e, 0xc2, 0x73, 0xcc, 0x6a, 0xb5, 0xdb, 0x6d, 0xb4,
+	0xeb, 0x0a, 0x73, 0x40, 0xcc, 0x11, 0x67, 0x9a, 0xed, 0x7a, 0xe3, 0x23, 0xe5, 0x6e, 0xb5, 0xa7,
+	0x6c, 0xcb, 0x8d, 0x6a, 0xbf, 0x21, 0x87, 0xdc, 0x3c, 0x09, 0x92, 0xa9, 0x6d, 0x76, 0xda, 0x7d,
+	0xe2, 0x40, 0xff, 0x41, 0xb7, 0x21, 0x16, 0x50, 0x19, 0x2e, 0x73, 0x43, 0x84, 0xd1, 0x6c, 0xef,
+	0x56, 0xe9, 0xfe, 0x8d, 0x8f, 0xba, 0x4d, 0xb9, 0x51, 0x17, 0x17, 0xaf, 0xd9, 0x20, 0x4e, 0x4f,
+	0x5a, 0x28, 0x07, 0xe9, 0x66, 0xfb, 0x5e, 0xb5, 0xd5, 0x24, 0x7f, 0xec, 0x32, 0x90, 0x68, 0x77,
+	0xda, 0x0d, 0x51, 0x20, 0x5f, 0xdb, 0x1f, 0x37, 0xbb, 0x62, 0x0c, 0x15, 0x20, 0xfb, 0x71, 0xaf,
+	0x5f, 0x6d, 0xd7, 0xab, 0x72, 0x5d, 0x8c, 0x93, 0x1f, 0x77, 0xbd, 0x76, 0xb5, 0xdb, 0x7d, 0x20,
+	0x26, 0xc8, 0x69, 0x10, 0x21, 0xe2, 0x59, 0xab, 0x53, 0xad, 0x2b, 0xf5, 0xc6, 0x66, 0x67, 0xa7,
+	0x2b, 0x37, 0x7a, 0xbd, 0x66, 0xa7, 0x2d, 0x26, 0x37, 0x7e, 0x9f, 0x18, 0xf7, 0x2e, 0x6f, 0x41,
+	0x82, 0xf4, 0x53, 0xe8, 0xc2, 0x74, 0x7f, 0x45, 0x9f, 0xbe, 0xd2, 0xca, 0xec, 0xb6, 0x0b, 0xfd,
+	0x10, 0xb2, 0xf7, 0xd5, 0x40, 0x3b, 0xf8, 0x0e, 0xba, 0x37, 0x04, 0xf4, 0x0e, 0x24, 0xe9, 0x83,
+	0x8e, 0x56, 0x66, 0xb7, 0x25, 0xa5, 0x8b, 0x27, 0xe8, 0x7c, 0xdf, 0xb7, 0x21, 0x41, 0x66, 0xf7,
+	0xe8, 0x96, 0x91, 0x5f, 0x1c, 0xa5, 0x95, 0x69, 0xf2, 0x68, 0xcb, 0x77, 0x21, 0xc5, 0x46, 0x32,
+	0x34, 0x69, 0x7b, 0x3c, 0xbc, 0x96, 0x8a, 0x27, 0x19, 0x4c, 0x7d, 0x5d, 0x40, 0x77, 0x21, 0x3b,
+	0x9a, 0x0e, 0x50, 0x29, 0xba, 0xcb, 0xe4, 0xa4, 0x54, 0xba, 0x3c, 0x93, 0x17, 0xda, 0xb9, 0x41,
+	0x2c, 0x15, 0x08, 0x1a, 0xa3, 0xa7, 0x27, 0x6a, 0x6d, 0xba, 0xf3, 0x28, 0x5d, 0x9e, 0xc9, 0xe3,
+	0x58, 0xb4, 0x60, 0xa9, 0x17, 0x78, 0x58, 0xb5, 0xfe, 0x7f, 0x5b, 0x37, 0x84, 0xda, 0x0b, 0x8f,
+	0xbf, 0x5a, 0x5d, 0x78, 0xfc, 0xf5, 0xaa, 0xf0, 0xe5, 0xd7, 0xab, 0xc2, 0xef, 0x9e, 0xac, 0x2e,
+	0x7c, 0xfe, 0x64, 0x55, 0xf8, 0xf2, 0xc9, 0xea, 0xc2, 0xdf, 0x9f, 0xac, 0x2e, 0xec, 0xa5, 0xa8,
+	0xee, 0xad, 0xff, 0x0d, 0x00, 0x7a, 0xe1, 0x58, 0x56, 0x66, 0x1d, 0x00, 0x00,
 }
@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"mime"
 	"net/url"
 	"strings"
 )
@@ -33,6 +34,8 @@ func (m *ReadRequest) Validate() error {
 		return ExtendContext(err, "Journal")
 	} else if m.Offset < -1 {
 		return NewValidationError("invalid Offset (%d; expected -1 <= Offset <= MaxInt64)", m.Offset)
+	} else if err = validateContentCodec(m.ContentCodec); err != nil {
+		return ExtendContext(err, "ContentCodec")
 	}
 
 	// Block, DoNotProxy, and MetadataOnly (each type bool) require no extra validation.
@@ -58,8 +61,12 @@ func (m *ReadResponse) Validate() error {
 			return NewValidationError("unexpected Fragment with Content (%s)", m.Fragment)
 		} else if m.FragmentUrl != "" {
 			return NewValidationError("unexpected FragmentUrl with Content (%s)", m.FragmentUrl)
+		} else if err := validateContentCodec(m.ContentCodec); err != nil {
+			return ExtendContext(err, "ContentCodec")
 		}
 		return nil
+	} else if m.ContentCodec != CompressionCodec_INVALID {
+		return NewValidationError("unexpected ContentCodec without Content (%s)", m.ContentCodec)
 	}
 
 	if m.Header != nil {
@@ -96,6 +103,20 @@ func (m *ReadResponse) Validate() error {
 	return nil
 }
 
+// validateContentCodec returns an error if |codec| is neither zero-valued nor
+// a CompressionCodec which may compress Read Content. GZIP_OFFLOAD_DECOMPRESSION
+// is a codec of stored Fragments only, and isn't a valid ContentCodec.
+func validateContentCodec(codec CompressionCodec) error {
+	if codec == CompressionCodec_INVALID {
+		return nil
+	} else if err := codec.Validate(); err != nil {
+		return err
+	} else if codec == CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION {
+		return NewValidationError("invalid value (%s; GZIP_OFFLOAD_DECOMPRESSION is not a content codec)", codec)
+	}
+	return nil
+}
+
 // Validate returns an error if the AppendRequest is not well-formed.
 func (m *AppendRequest) Validate() error {
 	if m.Journal != "" {
@@ -110,6 +131,15 @@ func (m *AppendRequest) Validate() error {
 			return NewValidationError("invalid Offset (%d; expected >= 0)", m.Offset)
 		} else if len(m.Content) != 0 {
 			return NewValidationError("unexpected Content")
+		} else if m.Continuation != "" && m.Offset != 0 {
+			return NewValidationError("unexpected Offset with Continuation")
+		} else if m.Suspend {
+			return NewValidationError("unexpected Suspend")
+		}
+		if m.ContentType != "" {
+			if _, _, err := mime.ParseMediaType(m.ContentType); err != nil {
+				return NewValidationError("parsing ContentType: %s", err)
+			}
 		}
 	} else if m.Header != nil {
 		return NewValidationError("unexpected Header")
@@ -117,6 +147,12 @@ func (m *AppendRequest) Validate() error {
 		return NewValidationError("unexpected DoNotProxy")
 	} else if m.Offset != 0 {
 		return NewValidationError("unexpected Offset")
+	} else if m.ContentType != "" {
+		return NewValidationError("unexpected ContentType")
+	} else if m.Continuation != "" {
+		return NewValidationError("unexpected Continuation")
+	} else if m.Suspend && len(m.Content) != 0 {
+		return NewValidationError("unexpected Content with Suspend")
 	}
 	return nil
 }
@@ -127,8 +163,10 @@ func (m *AppendResponse) Validate() error {
 		return ExtendContext(err, "Status")
 	} else if err = m.Header.Validate(); err != nil {
 		return ExtendContext(err, "Header")
-	} else if m.Status == Status_OK && m.Commit == nil {
+	} else if m.Status == Status_OK && m.Commit == nil && m.Continuation == "" {
 		return NewValidationError("expected Commit")
+	} else if m.Commit != nil && m.Continuation != "" {
+		return NewValidationError("unexpected Commit with Continuation")
 	}
 	if m.Commit != nil {
 		if err := m.Commit.Validate(); err != nil {
@@ -254,6 +292,11 @@ func (m *ListResponse) Validate() error {
 			return ExtendContext(err, "Journals[%d]", i)
 		}
 	}
+	for i, j := range m.RemovedJournals {
+		if err := j.Validate(); err != nil {
+			return ExtendContext(err, "RemovedJournals[%d]", i)
+		}
+	}
 
 	// NextPageToken requires no extra validation.
 
@@ -13,9 +13,10 @@ type RPCSuite struct{}
 
 func (s *RPCSuite) TestReadRequestValidation(c *gc.C) {
 	var req = ReadRequest{
-		Header:  badHeaderFixture(),
-		Journal: "/bad",
-		Offset:  -2,
+		Header:       badHeaderFixture(),
+		Journal:      "/bad",
+		Offset:       -2,
+		ContentCodec: CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION,
 	}
 	c.Check(req.Validate(), gc.ErrorMatches, `Header.Etcd: invalid ClusterId .*`)
 	req.Header.Etcd.ClusterId = 12
@@ -23,7 +24,13 @@ func (s *RPCSuite) TestReadRequestValidation(c *gc.C) {
 	req.Journal = "good"
 	c.Check(req.Validate(), gc.ErrorMatches, `invalid Offset \(-2; expected -1 <= Offset <= MaxInt64\)`)
 	req.Offset = -1
+	c.Check(req.Validate(), gc.ErrorMatches, `ContentCodec: invalid value \(GZIP_OFFLOAD_DECOMPRESSION; .*`)
+	req.ContentCodec = 9999
+	c.Check(req.Validate(), gc.ErrorMatches, `ContentCodec: invalid value \(9999\)`)
+	req.ContentCodec = CompressionCodec_SNAPPY
 
+	c.Check(req.Validate(), gc.IsNil)
+	req.ContentCodec = CompressionCodec_INVALID // Zero-value is also valid.
 	c.Check(req.Validate(), gc.IsNil)
 
 	// Block, DoNotProxy, and MetadataOnly have no validation.
@@ -74,6 +81,10 @@ func (s *RPCSuite) TestReadResponseValidationCases(c *gc.C) {
 	c.Check(resp.Validate(), gc.ErrorMatches, `unexpected FragmentUrl without Fragment \(http://foo\)`)
 	resp.FragmentUrl = ""
 
+	resp.ContentCodec = CompressionCodec_GZIP
+	c.Check(resp.Validate(), gc.ErrorMatches, `unexpected ContentCodec without Content \(GZIP\)`)
+	resp.ContentCodec = CompressionCodec_INVALID
+
 	c.Check(resp.Validate(), gc.IsNil) // Success.
 
 	// Set Content.
@@ -99,15 +110,22 @@ func (s *RPCSuite) TestReadResponseValidationCases(c *gc.C) {
 	resp.FragmentUrl = ""
 
 	c.Check(resp.Validate(), gc.IsNil)
+
+	resp.ContentCodec = CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION
+	c.Check(resp.Validate(), gc.ErrorMatches, `ContentCodec: invalid value \(GZIP_OFFLOAD_DECOMPRESSION; .*`)
+	resp.ContentCodec = CompressionCodec_ZSTANDARD
+
+	c.Check(resp.Validate(), gc.IsNil)
 }
 
 func (s *RPCSuite) TestAppendRequestValidationCases(c *gc.C) {
 	var req = AppendRequest{
-		Header:     badHeaderFixture(),
-		Journal:    "/bad",
-		DoNotProxy: true,
-		Offset:     -1,
-		Content:    []byte("foo"),
+		Header:      badHeaderFixture(),
+		Journal:     "/bad",
+		DoNotProxy:  true,
+		Offset:      -1,
+		Content:     []byte("foo"),
+		ContentType: "bad type",
 	}
 
 	c.Check(req.Validate(), gc.ErrorMatches, `Header.Etcd: invalid ClusterId .*`)
@@ -118,11 +136,23 @@ func (s *RPCSuite) TestAppendRequestValidationCases(c *gc.C) {
 	req.Offset = 100
 	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Content`)
 	req.Content = nil
+	c.Check(req.Validate(), gc.ErrorMatches, `parsing ContentType: mime: .*`)
+	req.ContentType = "text/csv; charset=utf-8"
+
+	c.Check(req.Validate(), gc.IsNil)
+
+	req.Continuation = "a-token"
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Offset with Continuation`)
+	req.Offset = 0
+	req.Suspend = true
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Suspend`)
+	req.Suspend = false
 
 	c.Check(req.Validate(), gc.IsNil)
 
 	req.Journal = ""
 	req.Content = []byte("foo")
+	req.Offset = 100
 
 	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Header`)
 	req.Header = nil
@@ -130,9 +160,19 @@ func (s *RPCSuite) TestAppendRequestValidationCases(c *gc.C) {
 	req.DoNotProxy = false
 	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Offset`)
 	req.Offset = 0
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected ContentType`)
+	req.ContentType = ""
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Continuation`)
+	req.Continuation = ""
 
 	c.Check(req.Validate(), gc.IsNil)
 
+	req.Suspend = true
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Content with Suspend`)
+
+	req = AppendRequest{Suspend: true} // Indicates Append should suspend.
+	c.Check(req.Validate(), gc.IsNil)
+
 	req = AppendRequest{} // Indicates Append should commit.
 	c.Check(req.Validate(), gc.IsNil)
 }
@@ -153,6 +193,12 @@ func (s *RPCSuite) TestAppendResponseValidationCases(c *gc.C) {
 	resp.Commit.Journal = "good/name"
 
 	c.Check(resp.Validate(), gc.IsNil)
+
+	resp.Continuation = "a-token"
+	c.Check(resp.Validate(), gc.ErrorMatches, `unexpected Commit with Continuation`)
+	resp.Commit = nil // Append was suspended.
+
+	c.Check(resp.Validate(), gc.IsNil)
 }
 
 func (s *RPCSuite) TestReplicateRequestValidationCases(c *gc.C) {
@@ -298,6 +344,7 @@ func (s *RPCSuite) TestListResponseValidationCases(c *gc.C) {
 				Route: Route{Primary: 0},
 			},
 		},
+		RemovedJournals: []Journal{"a/removed journal"},
 	}
 
 	c.Check(resp.Validate(), gc.ErrorMatches, `Status: invalid status \(9101\)`)
@@ -310,6 +357,8 @@ func (s *RPCSuite) TestListResponseValidationCases(c *gc.C) {
 	resp.Journals[0].ModRevision = 1
 	c.Check(resp.Validate(), gc.ErrorMatches, `Journals\[0\].Route: invalid Primary .*`)
 	resp.Journals[0].Route.Primary = -1
+	c.Check(resp.Validate(), gc.ErrorMatches, `RemovedJournals\[0\]: not a valid token \(.*\)`)
+	resp.RemovedJournals[0] = "a/removed"
 
 	c.Check(resp.Validate(), gc.IsNil)
 }
@@ -0,0 +1,165 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TracerName is the instrumentation name under which Gazette spans are created.
+const TracerName = "github.com/LiveRamp/gazette/v2"
+
+// StartSpan begins a new span of |name| as a child of any span of |ctx|,
+// using the globally registered OpenTelemetry TracerProvider. If no
+// TracerProvider has been registered, the returned span is a no-op.
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// AddTrace formats and adds an event to the span of |ctx|, if it's recording.
+func AddTrace(ctx context.Context, format string, args ...interface{}) {
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.AddEvent(fmt.Sprintf(format, args...))
+	}
+}
+
+// EndSpan records a non-nil |err| into |span|, and then ends it. It's
+// typically used with a defer statement.
+//
+// Example Usage:
+//
+//  ctx, span := StartSpan(ctx, "resolve")
+//  defer func() { EndSpan(span, err) }()
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TracingDialOptions returns grpc.DialOptions which start client spans of
+// unary & streaming RPCs, and propagate their trace context to the server.
+func TracingDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(TracingUnaryClientInterceptor),
+		grpc.WithStreamInterceptor(TracingStreamClientInterceptor),
+	}
+}
+
+// TracingServerOptions returns grpc.ServerOptions which extract propagated
+// trace context from RPC metadata, and start server spans of unary & streaming RPCs.
+func TracingServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(tracingUnaryServerInterceptor),
+		grpc.StreamInterceptor(tracingStreamServerInterceptor),
+	}
+}
+
+// TracingUnaryClientInterceptor is a grpc.UnaryClientInterceptor which starts
+// a client span of the RPC. Prefer TracingDialOptions, unless the interceptor
+// must be composed with others.
+func TracingUnaryClientInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) (err error) {
+
+	ctx, span := StartSpan(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { EndSpan(span, err) }()
+
+	return invoker(injectTraceMetadata(ctx), method, req, reply, cc, opts...)
+}
+
+// TracingStreamClientInterceptor is a grpc.StreamClientInterceptor which starts
+// a client span of the RPC. Prefer TracingDialOptions, unless the interceptor
+// must be composed with others.
+func TracingStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+	ctx, span := StartSpan(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+
+	var cs, err = streamer(injectTraceMetadata(ctx), desc, cc, method, opts...)
+	if err != nil {
+		EndSpan(span, err)
+		return nil, err
+	}
+	// Note the span cannot be precisely ended when the stream completes,
+	// as that requires wrapping & tracking each RecvMsg. End it instead
+	// when the stream Context is done.
+	go func() {
+		<-cs.Context().Done()
+		span.End()
+	}()
+	return cs, nil
+}
+
+func tracingUnaryServerInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+
+	ctx, span := StartSpan(extractTraceMetadata(ctx), info.FullMethod,
+		trace.WithSpanKind(trace.SpanKindServer))
+	defer func() { EndSpan(span, err) }()
+
+	return handler(ctx, req)
+}
+
+func tracingStreamServerInterceptor(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+
+	ctx, span := StartSpan(extractTraceMetadata(ss.Context()), info.FullMethod,
+		trace.WithSpanKind(trace.SpanKindServer))
+	defer func() { EndSpan(span, err) }()
+
+	return handler(srv, tracedServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// tracedServerStream overrides the Context of a grpc.ServerStream.
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s tracedServerStream) Context() context.Context { return s.ctx }
+
+// injectTraceMetadata returns a Context having outgoing gRPC metadata
+// into which the trace context of |ctx| has been injected.
+func injectTraceMetadata(ctx context.Context) context.Context {
+	var md, _ = metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// extractTraceMetadata returns a Context having the remote trace context
+// extracted from incoming gRPC metadata of |ctx|.
+func extractTraceMetadata(ctx context.Context) context.Context {
+	var md, _ = metadata.FromIncomingContext(ctx)
+	return otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+}
+
+// metadataCarrier adapts metadata.MD to the propagation.TextMapCarrier interface.
+type metadataCarrier metadata.MD
+
+func (mc metadataCarrier) Get(key string) string {
+	if v := metadata.MD(mc).Get(key); len(v) != 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (mc metadataCarrier) Set(key, value string) { metadata.MD(mc).Set(key, value) }
+
+func (mc metadataCarrier) Keys() []string {
+	var out = make([]string, 0, len(mc))
+	for k := range mc {
+		out = append(out, k)
+	}
+	return out
+}
+
+var _ propagation.TextMapCarrier = metadataCarrier{}
@@ -0,0 +1,53 @@
+package protocol
+
+import (
+	"context"
+
+	gc "github.com/go-check/check"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+type TracingSuite struct{}
+
+func (s *TracingSuite) TestMetadataCarrier(c *gc.C) {
+	var md = metadata.MD{}
+	var mc = metadataCarrier(md)
+
+	c.Check(mc.Get("missing"), gc.Equals, "")
+	mc.Set("Traceparent", "value")
+	c.Check(mc.Get("traceparent"), gc.Equals, "value")
+	c.Check(md["traceparent"], gc.DeepEquals, []string{"value"})
+	c.Check(mc.Keys(), gc.DeepEquals, []string{"traceparent"})
+}
+
+func (s *TracingSuite) TestTraceContextRoundTrip(c *gc.C) {
+	var prev = otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(prev)
+
+	var sc = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03},
+		SpanID:     trace.SpanID{0x04, 0x05, 0x06},
+		TraceFlags: trace.FlagsSampled,
+	})
+	var ctx = trace.ContextWithSpanContext(context.Background(), sc)
+	ctx = metadata.AppendToOutgoingContext(ctx, "other", "value")
+
+	// Inject into outgoing metadata, and then extract as incoming metadata.
+	ctx = injectTraceMetadata(ctx)
+	var md, _ = metadata.FromOutgoingContext(ctx)
+	c.Check(md.Get("other"), gc.DeepEquals, []string{"value"})
+	c.Check(md.Get("traceparent"), gc.HasLen, 1)
+
+	ctx = extractTraceMetadata(metadata.NewIncomingContext(context.Background(), md))
+	var out = trace.SpanContextFromContext(ctx)
+
+	c.Check(out.TraceID(), gc.Equals, sc.TraceID())
+	c.Check(out.SpanID(), gc.Equals, sc.SpanID())
+	c.Check(out.IsRemote(), gc.Equals, true)
+}
+
+var _ = gc.Suite(&TracingSuite{})
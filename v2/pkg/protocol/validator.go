@@ -42,6 +42,16 @@ func NewValidationError(format string, args ...interface{}) error {
 	return &ValidationError{Err: fmt.Errorf(format, args...)}
 }
 
+// AsValidationError returns |err| if it's a *ValidationError, or otherwise
+// wraps |err| as the cause of a new ValidationError. It's useful in adapting
+// errors of external validation functions.
+func AsValidationError(err error) error {
+	if _, ok := err.(*ValidationError); ok {
+		return err
+	}
+	return &ValidationError{Err: err}
+}
+
 // ValidateToken ensures the string consists only of |tokenAlphabet| characters,
 // and is of length |min| <= len(n) <= |max|.
 func ValidateToken(n string, min, max int) error {
@@ -0,0 +1,61 @@
+package recoverylog
+
+import (
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	"github.com/pkg/errors"
+)
+
+// PrunableFragments returns the Fragments of |fragments| which aren't
+// required to play back the log from any of |hints|, and which may therefore
+// be removed. All |hints| must be of the same log, as must |fragments|.
+//
+// Callers should provide every FSMHints from which a playback may currently
+// begin or is underway (eg, both primary and backup hints of a consumer
+// Shard). Hints written after PrunableFragments is called are safe, as a live
+// file of a later FSM was either live under an earlier FSM, or was written at
+// a later log offset (and Fragments after the final hinted Segment of any
+// |hints| are never pruned). If |hints| is empty, or if any FSMHints has no
+// live Segments (and playback reads the log from its beginning), no
+// Fragments are prunable.
+func PrunableFragments(hints []FSMHints, fragments []pb.Fragment) ([]pb.Fragment, error) {
+	var sets []SegmentSet
+
+	for _, h := range hints {
+		if h.Log != hints[0].Log {
+			return nil, errors.Errorf("hints are of different logs (%s vs %s)", h.Log, hints[0].Log)
+		}
+		var _, set, err = h.LiveLogSegments()
+		if err != nil {
+			return nil, err
+		} else if len(set) == 0 {
+			return nil, nil // Playback of |h| reads the entire log.
+		}
+		// Zero the LastOffset of the final hinted Segment. This has the effect of
+		// implicitly intersecting with all fragments having offsets greater than its
+		// FirstOffset. We want this behavior because playback will continue to read
+		// offsets & Fragments after reading past the final hinted Segment.
+		set[len(set)-1].LastOffset = 0
+		sets = append(sets, set)
+	}
+
+	var out []pb.Fragment
+	for _, f := range fragments {
+		if len(sets) == 0 {
+			break
+		} else if f.Journal != hints[0].Log {
+			return nil, errors.Errorf("fragment is not of the hinted log (%s vs %s)", f.Journal, hints[0].Log)
+		}
+
+		var live bool
+		for _, set := range sets {
+			if len(set.Intersect(f.Begin, f.End)) != 0 {
+				live = true
+				break
+			}
+		}
+		if !live {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,74 @@
+package recoverylog
+
+import (
+	pb "github.com/LiveRamp/gazette/v2/pkg/protocol"
+	gc "github.com/go-check/check"
+)
+
+type PruneSuite struct{}
+
+func (s *PruneSuite) TestPrunableFragments(c *gc.C) {
+	var older = FSMHints{
+		Log: aRecoveryLog,
+		LiveNodes: []FnodeSegments{
+			{Fnode: 2, Segments: []Segment{
+				{Author: 0x1, FirstSeqNo: 2, LastSeqNo: 7, FirstOffset: 200, LastOffset: 700},
+			}},
+			{Fnode: 10, Segments: []Segment{
+				{Author: 0x1, FirstSeqNo: 10, LastSeqNo: 10, FirstOffset: 1000, LastOffset: 1001},
+			}},
+		},
+	}
+	var newer = FSMHints{
+		Log: aRecoveryLog,
+		LiveNodes: []FnodeSegments{
+			{Fnode: 10, Segments: []Segment{
+				{Author: 0x1, FirstSeqNo: 10, LastSeqNo: 10, FirstOffset: 1000, LastOffset: 1001},
+			}},
+			{Fnode: 20, Segments: []Segment{
+				{Author: 0x1, FirstSeqNo: 20, LastSeqNo: 25, FirstOffset: 1500, LastOffset: 1600},
+			}},
+		},
+	}
+	var frag = func(begin, end int64) pb.Fragment {
+		return pb.Fragment{Journal: aRecoveryLog, Begin: begin, End: end}
+	}
+	var fragments = []pb.Fragment{
+		frag(0, 100),
+		frag(100, 250),
+		frag(700, 1000),
+		frag(1000, 1200),
+		frag(1200, 1500),
+		frag(1500, 1550),
+		frag(2000, 2100), // Beyond the final hinted Segment.
+	}
+
+	// Case: fragments not needed by either hints are prunable.
+	var out, err = PrunableFragments([]FSMHints{newer, older}, fragments)
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.DeepEquals, []pb.Fragment{frag(0, 100), frag(700, 1000)})
+
+	// Case: more fragments are prunable given only |newer|.
+	out, err = PrunableFragments([]FSMHints{newer}, fragments)
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.DeepEquals, []pb.Fragment{
+		frag(0, 100), frag(100, 250), frag(700, 1000), frag(1200, 1500)})
+
+	// Case: no fragments are prunable without hints, or if hints have no live segments.
+	out, err = PrunableFragments(nil, fragments)
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.HasLen, 0)
+
+	out, err = PrunableFragments([]FSMHints{newer, {Log: aRecoveryLog}}, fragments)
+	c.Check(err, gc.IsNil)
+	c.Check(out, gc.HasLen, 0)
+
+	// Case: hints and fragments must be of the same log.
+	_, err = PrunableFragments([]FSMHints{newer, {Log: "other/log"}}, fragments)
+	c.Check(err, gc.ErrorMatches, `hints are of different logs \(other/log vs .*\)`)
+
+	_, err = PrunableFragments([]FSMHints{newer}, []pb.Fragment{{Journal: "other/log", Begin: 0, End: 1}})
+	c.Check(err, gc.ErrorMatches, `fragment is not of the hinted log \(other/log vs .*\)`)
+}
+
+var _ = gc.Suite(&PruneSuite{})
@@ -0,0 +1,77 @@
+package recoverylog
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+)
+
+// CopyDirectory replaces the files of |dir|, recorded by |rec|, with the files
+// of directory |src|. Each removal, creation and write is recorded to the log
+// of |rec|. CopyDirectory is used to seed a log with files recovered from
+// another log (eg, when splitting a consumer Shard), such that the log may
+// thereafter be played back without reference to the other log. It returns
+// only after the recorded operations have synced to the log.
+func CopyDirectory(rec *Recorder, src, dir string) error {
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+
+	// Remove files currently tracked by the Recorder. Properties cannot be
+	// removed, but will be overwritten by a copied property of the same path.
+	var links []string
+	for link := range rec.fsm.Links {
+		links = append(links, link)
+	}
+	sort.Strings(links)
+
+	for _, link := range links {
+		if err := fs.Remove(filepath.Join(dir, link)); err != nil {
+			return extendErr(err, "removing %s", link)
+		}
+	}
+
+	if err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		var rel, _ = filepath.Rel(src, path)
+		var target = filepath.Join(dir, rel)
+
+		if err = os.MkdirAll(filepath.Dir(target), 0777); err != nil {
+			return err
+		}
+		if _, isProperty := propertyFiles[rec.normalizePath(target)]; !isProperty {
+			return copyRecordedFile(fs, path, target)
+		}
+		// Properties are recorded by renaming a written file to the property path.
+		if err = copyRecordedFile(fs, path, target+".copy"); err != nil {
+			return err
+		}
+		return fs.Rename(target+".copy", target)
+	}); err != nil {
+		return extendErr(err, "copying %s", src)
+	}
+
+	var txn = rec.StrongBarrier()
+	<-txn.Done()
+	return txn.Err()
+}
+
+// copyRecordedFile copies the file |src| to |target| of the RecordedAferoFS.
+func copyRecordedFile(fs RecordedAferoFS, src, target string) error {
+	var fin, err = os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer fin.Close()
+
+	fout, err := fs.Create(target)
+	if err != nil {
+		return err
+	} else if _, err = io.Copy(fout, fin); err != nil {
+		return err
+	}
+	return fout.Close()
+}
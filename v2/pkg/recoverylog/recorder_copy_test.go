@@ -0,0 +1,80 @@
+package recoverylog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	gc "github.com/go-check/check"
+	"github.com/spf13/afero"
+)
+
+type CopyDirectorySuite struct{}
+
+func (s *CopyDirectorySuite) TestCopyIsRecordedAndPlayedBack(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+
+	var dir, src, out = mustTempDir(c), mustTempDir(c), mustTempDir(c)
+	defer func() {
+		for _, d := range []string{dir, src, out} {
+			c.Check(os.RemoveAll(d), gc.IsNil)
+		}
+	}()
+
+	// Record initial files of |dir|, which are to be replaced.
+	var rec = NewRecorder(fsm, 1234, dir, bk)
+	var fs = RecordedAferoFS{Recorder: rec, Fs: afero.NewOsFs()}
+	c.Assert(afero.WriteFile(fs, filepath.Join(dir, "stale"), []byte("stale content"), 0644), gc.IsNil)
+
+	// Build a source directory having nested files and a property.
+	c.Assert(os.MkdirAll(filepath.Join(src, "nested"), 0777), gc.IsNil)
+	for path, content := range map[string]string{
+		"one":         "content of one",
+		"nested/two":  "content of two",
+		"IDENTITY":    "an-identity",
+		"nested/none": "",
+	} {
+		c.Assert(ioutil.WriteFile(filepath.Join(src, path), []byte(content), 0644), gc.IsNil)
+	}
+
+	c.Check(CopyDirectory(rec, src, dir), gc.IsNil)
+
+	// Expect the stale file was removed, and copied files & property are recorded.
+	_, err = os.Stat(filepath.Join(dir, "stale"))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+	c.Check(rec.fsm.Properties, gc.DeepEquals, map[string]string{"/IDENTITY": "an-identity"})
+
+	hints, err := rec.BuildHints()
+	c.Assert(err, gc.IsNil)
+
+	// Play back the log, and expect it reproduces the source directory.
+	var pl = NewPlayer()
+	pl.FinishAtWriteHead()
+	c.Assert(pl.Play(context.Background(), hints, out, bk), gc.IsNil)
+
+	for path, content := range map[string]string{
+		"one":         "content of one",
+		"nested/two":  "content of two",
+		"IDENTITY":    "an-identity",
+		"nested/none": "",
+	} {
+		var b, err = ioutil.ReadFile(filepath.Join(out, path))
+		c.Check(err, gc.IsNil)
+		c.Check(string(b), gc.Equals, content)
+	}
+	_, err = os.Stat(filepath.Join(out, "stale"))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+}
+
+func mustTempDir(c *gc.C) string {
+	var dir, err = ioutil.TempDir("", "recoverylog-copy")
+	c.Assert(err, gc.IsNil)
+	return dir
+}
+
+var _ = gc.Suite(&CopyDirectorySuite{})
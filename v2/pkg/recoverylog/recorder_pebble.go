@@ -0,0 +1,121 @@
+package recoverylog
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+// RecordedPebbleFS adapts a Recorder to wrap a pebble vfs.FS instance.
+type RecordedPebbleFS struct {
+	*Recorder
+	vfs.FS
+}
+
+func (r RecordedPebbleFS) Create(name string) (vfs.File, error) {
+	if file, err := r.FS.Create(name); err != nil {
+		return file, err
+	} else {
+		return recordedPebbleFile{
+			FileRecorder: r.Recorder.RecordCreate(name),
+			File:         file,
+		}, nil
+	}
+}
+
+func (r RecordedPebbleFS) Link(oldname, newname string) error {
+	if err := r.FS.Link(oldname, newname); err != nil {
+		return err
+	}
+	r.Recorder.RecordLink(oldname, newname)
+	return nil
+}
+
+// OpenReadWrite is not supported, as writes of an existing file opened for
+// update cannot be attributed to a recorded Fnode.
+func (r RecordedPebbleFS) OpenReadWrite(name string, opts ...vfs.OpenOption) (vfs.File, error) {
+	return nil, fmt.Errorf("openReadWrite not supported by RecordedPebbleFS")
+}
+
+func (r RecordedPebbleFS) Remove(name string) error {
+	if err := r.FS.Remove(name); err != nil {
+		return err
+	}
+	r.Recorder.RecordRemove(name)
+	return nil
+}
+
+func (r RecordedPebbleFS) RemoveAll(name string) error {
+	return fmt.Errorf("removeAll not supported by RecordedPebbleFS")
+}
+
+func (r RecordedPebbleFS) Rename(oldname, newname string) error {
+	if err := r.FS.Rename(oldname, newname); err != nil {
+		return err
+	}
+	r.Recorder.RecordRename(oldname, newname)
+	return nil
+}
+
+// ReuseForWrite is used by pebble to recycle log files. Recycled files are
+// re-written from their beginning, which cannot be expressed as recorded
+// operations of the existing Fnode. Instead, |oldname| is removed and
+// |newname| is created anew.
+func (r RecordedPebbleFS) ReuseForWrite(oldname, newname string) (vfs.File, error) {
+	if err := r.Remove(oldname); err != nil {
+		return nil, err
+	}
+	return r.Create(newname)
+}
+
+type recordedPebbleFile struct {
+	*FileRecorder
+	vfs.File
+}
+
+func (r recordedPebbleFile) Write(p []byte) (n int, err error) {
+	n, err = r.File.Write(p)
+	r.FileRecorder.RecordWrite(p[:n])
+	return
+}
+
+func (r recordedPebbleFile) WriteAt(p []byte, off int64) (n int, err error) {
+	n, err = r.File.WriteAt(p, off)
+
+	var prev = r.FileRecorder.offset
+	r.FileRecorder.offset = off
+	r.FileRecorder.RecordWrite(p[:n])
+	r.FileRecorder.offset = prev
+	return
+}
+
+func (r recordedPebbleFile) Sync() error {
+	if err := r.File.Sync(); err != nil {
+		return err
+	}
+	return r.barrier()
+}
+
+func (r recordedPebbleFile) SyncData() error {
+	if err := r.File.SyncData(); err != nil {
+		return err
+	}
+	return r.barrier()
+}
+
+// SyncTo syncs the entire file, and always returns |fullSync|: recorded
+// writes can be made durable only by a barrier of the recovery log, which
+// encompasses all prior writes of the file.
+func (r recordedPebbleFile) SyncTo(length int64) (fullSync bool, err error) {
+	if err = r.SyncData(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// barrier blocks until all recorded operations are committed to the log.
+func (r recordedPebbleFile) barrier() error {
+	var txn = r.Recorder.StrongBarrier()
+	<-txn.Done()
+	return txn.Err()
+}
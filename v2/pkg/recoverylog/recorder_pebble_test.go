@@ -0,0 +1,164 @@
+package recoverylog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/LiveRamp/gazette/v2/pkg/client"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	gc "github.com/go-check/check"
+)
+
+type RecordedPebbleSuite struct{}
+
+func (s *RecordedPebbleSuite) TestSimpleStopAndStart(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var replica1 = newPebbleTestReplica(c, bk)
+	defer replica1.teardown(c)
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+	replica1.initDB(c, fsm)
+
+	replica1.put(c, "key3", "value three!")
+	replica1.put(c, "key1", "value one")
+	replica1.put(c, "key2", "value2")
+	c.Assert(replica1.db.Flush(), gc.IsNil)
+
+	var hints, _ = replica1.recorder.BuildHints()
+
+	var replica2 = newPebbleTestReplica(c, bk)
+	defer replica2.teardown(c)
+
+	go func() {
+		c.Assert(replica2.player.Play(context.Background(), hints, replica2.tmpdir, bk), gc.IsNil)
+	}()
+	replica2.player.InjectHandoff(replica2.author)
+	<-replica2.player.Done()
+
+	c.Assert(replica2.player.FSM, gc.NotNil)
+	replica2.initDB(c, replica2.player.FSM)
+
+	replica2.expectValues(c, map[string]string{
+		"key1": "value one",
+		"key2": "value2",
+		"key3": "value three!",
+	})
+}
+
+func (s *RecordedPebbleSuite) TestSyncedWritesAreRecovered(c *gc.C) {
+	var bk, cleanup = newBrokerAndLog(c)
+	defer cleanup()
+
+	var replica1 = newPebbleTestReplica(c, bk)
+	defer replica1.teardown(c)
+
+	var fsm, err = NewFSM(FSMHints{Log: aRecoveryLog})
+	c.Assert(err, gc.IsNil)
+	replica1.initDB(c, fsm)
+
+	// Writes are synced to the WAL, but are not flushed to an SSTable.
+	// They must be recovered by playback of recorded WAL writes alone.
+	// Each synced write blocks until its recorded operations have committed.
+	replica1.put(c, "key1", "value one")
+	c.Check(bk.PendingExcept(""), gc.HasLen, 0)
+	replica1.put(c, "key2", "value2")
+	c.Check(bk.PendingExcept(""), gc.HasLen, 0)
+
+	var hints, _ = replica1.recorder.BuildHints()
+
+	var replica2 = newPebbleTestReplica(c, bk)
+	defer replica2.teardown(c)
+
+	go func() {
+		c.Assert(replica2.player.Play(context.Background(), hints, replica2.tmpdir, bk), gc.IsNil)
+	}()
+	replica2.player.InjectHandoff(replica2.author)
+	<-replica2.player.Done()
+
+	c.Assert(replica2.player.FSM, gc.NotNil)
+	replica2.initDB(c, replica2.player.FSM)
+
+	replica2.expectValues(c, map[string]string{
+		"key1": "value one",
+		"key2": "value2",
+	})
+}
+
+func (s *RecordedPebbleSuite) TestUnsupportedOperations(c *gc.C) {
+	var fs = RecordedPebbleFS{FS: vfs.NewMem()}
+
+	var _, err = fs.OpenReadWrite("foo")
+	c.Check(err, gc.ErrorMatches, "openReadWrite not supported by RecordedPebbleFS")
+	c.Check(fs.RemoveAll("foo"), gc.ErrorMatches, "removeAll not supported by RecordedPebbleFS")
+}
+
+// pebbleTestReplica models the lifetime of an observed pebble database,
+// which is either recorded from empty hints or played back from prior hints.
+type pebbleTestReplica struct {
+	client client.AsyncJournalClient
+
+	tmpdir string
+	db     *pebble.DB
+
+	author   Author
+	recorder *Recorder
+	player   *Player
+}
+
+func newPebbleTestReplica(c *gc.C, client client.AsyncJournalClient) *pebbleTestReplica {
+	var r = &pebbleTestReplica{
+		client: client,
+		player: NewPlayer(),
+	}
+
+	var err error
+	r.tmpdir, err = ioutil.TempDir("", "recoverylog-suite")
+	c.Assert(err, gc.IsNil)
+
+	r.author, err = NewRandomAuthorID()
+	c.Assert(err, gc.IsNil)
+
+	return r
+}
+
+func (r *pebbleTestReplica) initDB(c *gc.C, fsm *FSM) {
+	r.recorder = NewRecorder(fsm, r.author, r.tmpdir, r.client)
+
+	var err error
+	r.db, err = pebble.Open(r.tmpdir, &pebble.Options{
+		FS: RecordedPebbleFS{Recorder: r.recorder, FS: vfs.Default},
+	})
+	c.Assert(err, gc.IsNil)
+}
+
+func (r *pebbleTestReplica) put(c *gc.C, key, value string) {
+	c.Check(r.db.Set([]byte(key), []byte(value), pebble.Sync), gc.IsNil)
+}
+
+func (r *pebbleTestReplica) expectValues(c *gc.C, expect map[string]string) {
+	var it, err = r.db.NewIter(nil)
+	c.Assert(err, gc.IsNil)
+
+	for it.First(); it.Valid(); it.Next() {
+		var key, value = string(it.Key()), string(it.Value())
+
+		c.Check(expect[key], gc.Equals, value)
+		delete(expect, key)
+	}
+	c.Check(it.Close(), gc.IsNil)
+	c.Check(expect, gc.HasLen, 0)
+}
+
+func (r *pebbleTestReplica) teardown(c *gc.C) {
+	if r.db != nil {
+		c.Check(r.db.Close(), gc.IsNil)
+	}
+	c.Assert(os.RemoveAll(r.tmpdir), gc.IsNil)
+}
+
+var _ = gc.Suite(&RecordedPebbleSuite{})
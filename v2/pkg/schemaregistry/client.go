@@ -0,0 +1,250 @@
+// Package schemaregistry provides a message.SchemaRegistry which resolves
+// schemas against a registry implementing the Confluent Schema Registry API.
+package schemaregistry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	"github.com/pkg/errors"
+)
+
+// Message is a message.Message having a schema within the registry.
+type Message interface {
+	message.Message
+	// SchemaSubject is the registry subject under which versions of the
+	// Message's schema are registered.
+	SchemaSubject() string
+	// Schema returns the registry schema type (eg, "PROTOBUF") and schema
+	// definition of the Message.
+	Schema() (schemaType, schema string)
+}
+
+// Client is a message.SchemaRegistry of a Confluent-compatible registry.
+//
+// Schema IDs of published Messages are resolved by looking up the Message
+// schema under its subject, and are optionally registered if not found. A
+// Message read with a schema ID may be decoded into a Message type only if
+// the schema ID is a registered version of the type's subject: the registry
+// enforces compatibility of versions within a subject (as per the subject's
+// compatibility level), and a schema of another subject is incompatible.
+//
+// Resolved schema IDs are cached for the lifetime of the Client, as
+// registered schemas are immutable. The subjects of a schema ID may grow,
+// however, as its schema is registered under further subjects: a schema ID
+// found not to be a version of a Message's subject is re-checked with the
+// registry once its cached subjects are older than RefreshInterval.
+type Client struct {
+	// URL of the registry, eg "http://schema-registry:8081".
+	URL string
+	// HTTPClient used for registry requests. If nil, a client having
+	// DefaultTimeout is used.
+	HTTPClient *http.Client
+	// AutoRegister schemas of published Messages which aren't yet registered.
+	AutoRegister bool
+	// RefreshInterval after which cached subjects of a schema ID are
+	// re-checked, upon the schema ID not being a version of a Message's
+	// subject. If zero, DefaultRefreshInterval is used.
+	RefreshInterval time.Duration
+
+	mu       sync.Mutex
+	ids      map[subjectSchema]uint32
+	subjects map[uint32]cachedSubjects
+}
+
+// NewClient returns a Client of the registry at |url|.
+func NewClient(url string) *Client {
+	return &Client{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// SchemaID returns the registered ID of the schema of |msg|, which must be a
+// Message. It implements message.SchemaRegistry.
+func (c *Client) SchemaID(msg message.Message) (uint32, error) {
+	var m, ok = msg.(Message)
+	if !ok {
+		return 0, errors.Errorf("%T is not a schemaregistry.Message", msg)
+	}
+	var key subjectSchema
+	key.subject = m.SchemaSubject()
+	key.schemaType, key.schema = m.Schema()
+
+	c.mu.Lock()
+	var id, cached = c.ids[key]
+	c.mu.Unlock()
+
+	if cached {
+		return id, nil
+	}
+
+	var req = schemaRequest{Schema: key.schema, SchemaType: key.schemaType}
+	var resp schemaResponse
+	var path = "/subjects/" + url.PathEscape(key.subject)
+
+	if c.AutoRegister {
+		path += "/versions"
+	}
+	if err := c.do("POST", path, req, &resp); err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	if c.ids == nil {
+		c.ids = make(map[subjectSchema]uint32)
+	}
+	c.ids[key] = resp.ID
+	c.mu.Unlock()
+
+	return resp.ID, nil
+}
+
+// CheckSchema returns nil if schema |id| is a registered version of the
+// subject of |msg|, which must be a Message. It implements message.SchemaRegistry.
+func (c *Client) CheckSchema(id uint32, msg message.Message) error {
+	var m, ok = msg.(Message)
+	if !ok {
+		return errors.Errorf("%T is not a schemaregistry.Message", msg)
+	}
+
+	var refresh = c.RefreshInterval
+	if refresh == 0 {
+		refresh = DefaultRefreshInterval
+	}
+
+	c.mu.Lock()
+	var cached, isCached = c.subjects[id]
+	c.mu.Unlock()
+
+	if isCached && cached.has(m.SchemaSubject()) {
+		return nil
+	} else if !isCached || timeNow().Sub(cached.fetchedAt) >= refresh {
+		var resp []subjectVersion
+		if err := c.do("GET", fmt.Sprintf("/schemas/ids/%d/versions", id), nil, &resp); err != nil {
+			return err
+		}
+		cached = cachedSubjects{fetchedAt: timeNow()}
+		for _, sv := range resp {
+			cached.subjects = append(cached.subjects, sv.Subject)
+		}
+
+		c.mu.Lock()
+		if c.subjects == nil {
+			c.subjects = make(map[uint32]cachedSubjects)
+		}
+		c.subjects[id] = cached
+		c.mu.Unlock()
+
+		if cached.has(m.SchemaSubject()) {
+			return nil
+		}
+	}
+	return errors.WithMessage(message.ErrIncompatibleSchema,
+		fmt.Sprintf("schema %d is not a version of subject %s (registered under %v)", id, m.SchemaSubject(), cached.subjects))
+}
+
+// do performs a registry request, decoding a successful response into |out|.
+// A response of a schema or subject which isn't found is mapped to an error
+// having cause message.ErrUnknownSchema.
+func (c *Client) do(method, path string, body, out interface{}) error {
+	var rd = new(bytes.Buffer)
+	if body != nil {
+		if err := json.NewEncoder(rd).Encode(body); err != nil {
+			return err
+		}
+	}
+	var req, err = http.NewRequest(method, strings.TrimSuffix(c.URL, "/")+path, rd)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", contentType)
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	var hc = c.HTTPClient
+	if hc == nil {
+		hc = defaultHTTPClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return errors.Wrapf(json.NewDecoder(resp.Body).Decode(out), "decoding response of %s %s", method, path)
+	}
+
+	var e errorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&e)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.WithMessage(message.ErrUnknownSchema,
+			fmt.Sprintf("%s %s: %s (%d)", method, path, e.Message, e.ErrorCode))
+	}
+	return errors.Errorf("%s %s: unexpected status %s: %s (%d)", method, path, resp.Status, e.Message, e.ErrorCode)
+}
+
+type subjectSchema struct {
+	subject, schemaType, schema string
+}
+
+// cachedSubjects are subjects of a schema ID, as fetched at |fetchedAt|.
+type cachedSubjects struct {
+	subjects  []string
+	fetchedAt time.Time
+}
+
+func (cs cachedSubjects) has(subject string) bool {
+	for _, s := range cs.subjects {
+		if s == subject {
+			return true
+		}
+	}
+	return false
+}
+
+type schemaRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+type schemaResponse struct {
+	ID uint32 `json:"id"`
+}
+
+type subjectVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type errorResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+const (
+	// DefaultTimeout of registry requests.
+	DefaultTimeout = 10 * time.Second
+	// DefaultRefreshInterval of cached subjects of a schema ID.
+	DefaultRefreshInterval = time.Minute
+
+	// contentType of registry requests and responses.
+	contentType = "application/vnd.schemaregistry.v1+json"
+)
+
+var (
+	defaultHTTPClient = &http.Client{Timeout: DefaultTimeout}
+	timeNow           = time.Now
+)
+
+var _ message.SchemaRegistry = new(Client)
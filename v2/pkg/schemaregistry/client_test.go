@@ -0,0 +1,174 @@
+package schemaregistry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LiveRamp/gazette/v2/pkg/message"
+	gc "github.com/go-check/check"
+	"github.com/pkg/errors"
+)
+
+type ClientSuite struct{}
+
+func (s *ClientSuite) TestSchemaID(c *gc.C) {
+	var requests []string
+	var srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		var req schemaRequest
+		c.Check(json.NewDecoder(r.Body).Decode(&req), gc.IsNil)
+		c.Check(req, gc.Equals, schemaRequest{Schema: "message Foo {}", SchemaType: "PROTOBUF"})
+
+		switch r.URL.Path {
+		case "/subjects/a-subject":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":40403,"message":"Schema not found"}`))
+		case "/subjects/a-subject/versions":
+			_, _ = w.Write([]byte(`{"id":42}`))
+		default:
+			c.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	var cl = NewClient(srv.URL)
+
+	// Case: lookup of an unregistered schema fails.
+	var _, err = cl.SchemaID(testMessage{})
+	c.Check(errors.Cause(err), gc.Equals, message.ErrUnknownSchema)
+	c.Check(err, gc.ErrorMatches, `POST /subjects/a-subject: Schema not found \(40403\): unknown schema`)
+
+	// Case: the schema is registered if AutoRegister is set.
+	cl.AutoRegister = true
+	id, err := cl.SchemaID(testMessage{})
+	c.Check(err, gc.IsNil)
+	c.Check(id, gc.Equals, uint32(42))
+
+	// Expect the ID is cached.
+	id, err = cl.SchemaID(testMessage{})
+	c.Check(err, gc.IsNil)
+	c.Check(id, gc.Equals, uint32(42))
+
+	c.Check(requests, gc.DeepEquals, []string{
+		"POST /subjects/a-subject",
+		"POST /subjects/a-subject/versions",
+	})
+
+	// Case: messages which aren't a Message are an error.
+	_, err = cl.SchemaID(struct{}{})
+	c.Check(err, gc.ErrorMatches, `struct {} is not a schemaregistry.Message`)
+}
+
+func (s *ClientSuite) TestCheckSchema(c *gc.C) {
+	var requests []string
+	var srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/schemas/ids/1/versions":
+			_, _ = w.Write([]byte(`[{"subject":"a-subject","version":1}]`))
+		case "/schemas/ids/2/versions":
+			_, _ = w.Write([]byte(`[{"subject":"other-subject","version":3}]`))
+		case "/schemas/ids/3/versions":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":40403,"message":"Schema not found"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error_code":50001,"message":"Error in the backend data store"}`))
+		}
+	}))
+	defer srv.Close()
+
+	var cl = NewClient(srv.URL)
+
+	// Case: a version of the Message's subject.
+	c.Check(cl.CheckSchema(1, testMessage{}), gc.IsNil)
+	c.Check(cl.CheckSchema(1, testMessage{}), gc.IsNil) // Cached.
+
+	// Case: a version of another subject is incompatible.
+	var err = cl.CheckSchema(2, testMessage{})
+	c.Check(errors.Cause(err), gc.Equals, message.ErrIncompatibleSchema)
+	c.Check(err, gc.ErrorMatches, `schema 2 is not a version of subject a-subject \(registered under \[other-subject\]\): incompatible schema`)
+
+	// Case: an unregistered schema.
+	err = cl.CheckSchema(3, testMessage{})
+	c.Check(errors.Cause(err), gc.Equals, message.ErrUnknownSchema)
+
+	// Case: a registry failure.
+	err = cl.CheckSchema(4, testMessage{})
+	c.Check(err, gc.ErrorMatches, `GET /schemas/ids/4/versions: unexpected status 500 Internal Server Error: `+
+		`Error in the backend data store \(50001\)`)
+
+	c.Check(requests, gc.DeepEquals, []string{
+		"GET /schemas/ids/1/versions",
+		"GET /schemas/ids/2/versions",
+		"GET /schemas/ids/3/versions",
+		"GET /schemas/ids/4/versions",
+	})
+}
+
+func (s *ClientSuite) TestCheckSchemaRefreshesSubjects(c *gc.C) {
+	var subjects = `[{"subject":"other-subject","version":3}]`
+	var requests int
+	var srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		c.Check(r.URL.Path, gc.Equals, "/schemas/ids/2/versions")
+		_, _ = w.Write([]byte(subjects))
+	}))
+	defer srv.Close()
+
+	var now = time.Unix(1500000000, 0)
+	defer func(f func() time.Time) { timeNow = f }(timeNow)
+	timeNow = func() time.Time { return now }
+
+	var cl = NewClient(srv.URL)
+	cl.RefreshInterval = time.Minute
+
+	c.Check(errors.Cause(cl.CheckSchema(2, testMessage{})), gc.Equals, message.ErrIncompatibleSchema)
+	c.Check(requests, gc.Equals, 1)
+
+	// The schema is registered under the Message subject. Until the cached
+	// subjects are refreshed, the schema remains incompatible.
+	subjects = `[{"subject":"other-subject","version":3},{"subject":"a-subject","version":1}]`
+
+	now = now.Add(time.Second)
+	c.Check(errors.Cause(cl.CheckSchema(2, testMessage{})), gc.Equals, message.ErrIncompatibleSchema)
+	c.Check(requests, gc.Equals, 1)
+
+	now = now.Add(time.Minute)
+	c.Check(cl.CheckSchema(2, testMessage{}), gc.IsNil)
+	c.Check(requests, gc.Equals, 2)
+
+	// Positive results are not refreshed.
+	now = now.Add(time.Hour)
+	c.Check(cl.CheckSchema(2, testMessage{}), gc.IsNil)
+	c.Check(requests, gc.Equals, 2)
+}
+
+func (s *ClientSuite) TestRequestTimeout(c *gc.C) {
+	var releaseCh = make(chan struct{})
+	var srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-releaseCh
+	}))
+	defer srv.Close()
+	defer close(releaseCh)
+
+	var cl = NewClient(srv.URL)
+	c.Check(cl.HTTPClient.Timeout, gc.Equals, DefaultTimeout)
+
+	cl.HTTPClient.Timeout = time.Millisecond
+	c.Check(cl.CheckSchema(1, testMessage{}), gc.ErrorMatches, `.*Client.Timeout exceeded.*`)
+}
+
+type testMessage struct{}
+
+func (testMessage) SchemaSubject() string    { return "a-subject" }
+func (testMessage) Schema() (string, string) { return "PROTOBUF", "message Foo {}" }
+
+var _ = gc.Suite(&ClientSuite{})
+
+func Test(t *testing.T) { gc.TestingT(t) }
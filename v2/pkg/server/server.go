@@ -54,7 +54,7 @@ func New(iface string, port uint16) (*Server, error) {
 
 	var srv = &Server{
 		HTTPMux:     http.DefaultServeMux,
-		GRPCServer:  grpc.NewServer(),
+		GRPCServer:  grpc.NewServer(protocol.TracingServerOptions()...),
 		RawListener: raw.(*net.TCPListener),
 		Ctx:         ctx,
 		cancel:      cancel,
@@ -126,10 +126,11 @@ func (s *Server) QueueTasks(tg *task.Group) {
 func (s *Server) GRPCLoopback() (*grpc.ClientConn, error) {
 	var addr = s.RawListener.Addr().String()
 
-	var cc, err = grpc.DialContext(s.Ctx, addr,
+	var cc, err = grpc.DialContext(s.Ctx, addr, append([]grpc.DialOption{
 		grpc.WithInsecure(),
 		grpc.WithDialer(keepalive.DialerFunc),
-		grpc.WithBalancerName(protocol.DispatcherGRPCBalancerName))
+		grpc.WithBalancerName(protocol.DispatcherGRPCBalancerName),
+	}, protocol.TracingDialOptions()...)...)
 
 	if err != nil {
 		return nil, err